@@ -18,6 +18,7 @@ import (
 
 	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/spf13/cobra"
 )
 
@@ -35,6 +36,12 @@ var aliasSetCmd = &cobra.Command{
 	Short:   "Set a local alias for a FRED series ID",
 	Example: `  reserve alias set pce-services PB0000031Q225SBEA`,
 	Args:    cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) != 1 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return storedSeriesCompletions(nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		alias, err := validateAliasName(args[0])
 		if err != nil {
@@ -93,7 +100,7 @@ var aliasListCmd = &cobra.Command{
 	Short:   "List local series aliases",
 	Example: `  reserve alias list`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load(globalFlags.APIKey)
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
@@ -108,6 +115,9 @@ var aliasListCmd = &cobra.Command{
 			enc.SetIndent("", "  ")
 			return enc.Encode(aliases)
 		}
+		if format == render.FormatYAML {
+			return render.EncodeYAML(cmd.OutOrStdout(), aliases)
+		}
 		printAliasTable(cmd.OutOrStdout(), aliases)
 		return nil
 	},
@@ -120,7 +130,7 @@ var aliasGetCmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		alias := config.NormalizeAlias(args[0])
-		cfg, err := config.Load(globalFlags.APIKey)
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
@@ -134,6 +144,9 @@ var aliasGetCmd = &cobra.Command{
 			enc.SetIndent("", "  ")
 			return enc.Encode(map[string]config.Alias{alias: entry})
 		}
+		if format == render.FormatYAML {
+			return render.EncodeYAML(cmd.OutOrStdout(), map[string]config.Alias{alias: entry})
+		}
 		if entry.Note == "" {
 			fmt.Fprintf(cmd.OutOrStdout(), "%s -> %s\n", alias, entry.SeriesID)
 			return nil
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/app"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/transform"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alignMethod    string
+	alignFreq      string
+	alignAggMethod string
+)
+
+var alignCmd = &cobra.Command{
+	Use:   "align <SERIES_ID1> <SERIES_ID2>",
+	Short: "Put two series on a shared date grid as a JSONL stream",
+	Long: `Resamples two series to one frequency, cuts them to the date range they
+have in common, joins them, and writes both as a multi-series JSONL stream
+for further pipeline processing. Each series is read from the local cache
+when its full history is stored there, and fetched live otherwise.
+
+--method inner (the default) keeps the dates both series report; left keeps
+every date of the first series; outer keeps every date of either. A date one
+series lacks is written with a null value.
+
+--freq defaults to the coarser of the two series' frequencies (monthly at the
+finest). --agg-method chooses how a finer series is aggregated: mean, last,
+or sum.
+
+Unlike compare, which prints a side-by-side report, align always writes
+JSONL, one block of rows per series.`,
+	Example: `  reserve align GDP CPIAUCSL --method inner --freq quarterly --agg-method mean
+  reserve align UNRATE DGS10 --method left --agg-method last | reserve analyze xcorr --with DGS10
+  reserve align GDP PAYEMS --method outer | reserve chart plot --multi`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("align needs exactly two series IDs, got %d", len(args))
+		}
+		return nil
+	},
+	ValidArgsFunction: completeSeriesIDs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		method := transform.JoinMode(strings.ToLower(alignMethod))
+		switch method {
+		case transform.JoinInner, transform.JoinOuter, transform.JoinLeft:
+		default:
+			return fmt.Errorf("--method must be inner, outer, or left, got %q", alignMethod)
+		}
+		if globalFlags.Format != "" && globalFlags.Format != render.FormatJSONL {
+			return fmt.Errorf("align writes JSONL only; drop --format %s", globalFlags.Format)
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		ids := resolveSeriesIDs(deps, args)
+		if ids[0] == ids[1] {
+			return fmt.Errorf("align needs two different series, got %s twice", ids[0])
+		}
+		metas := make([]model.SeriesMeta, len(ids))
+		for i, id := range ids {
+			if metas[i], err = ensureSeriesCompliance(cmd.Context(), deps, id, "display"); err != nil {
+				return err
+			}
+		}
+
+		aligned, err := app.AlignSeries(cmd.Context(), deps, ids, method,
+			transform.ResampleFreq(strings.ToLower(alignFreq)),
+			transform.ResampleMethod(strings.ToLower(alignAggMethod)))
+		if err != nil {
+			return err
+		}
+		for i := range aligned {
+			aligned[i].Meta = &metas[i]
+		}
+
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		if err := writeAlignedJSONL(w, aligned); err != nil {
+			_ = closeFn()
+			return err
+		}
+		return closeFn()
+	},
+}
+
+// writeAlignedJSONL writes each aligned series as a block of JSONL rows, the
+// shape pipeline readers group by series_id.
+func writeAlignedJSONL(w io.Writer, aligned []*model.SeriesData) error {
+	for _, data := range aligned {
+		result := &model.Result{
+			Kind:        model.KindSeriesData,
+			GeneratedAt: time.Now(),
+			Command:     "align " + data.SeriesID,
+			Data:        data,
+			Stats:       model.ResultStats{Items: len(data.Obs)},
+		}
+		if err := render.Render(w, result, render.FormatJSONL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(alignCmd)
+	alignCmd.Flags().StringVar(&alignMethod, "method", string(transform.JoinInner), "join method: inner, outer, or left")
+	alignCmd.Flags().StringVar(&alignFreq, "freq", "", "target frequency: monthly, quarterly, or annual (default: the coarser series' frequency)")
+	alignCmd.Flags().StringVar(&alignAggMethod, "agg-method", string(transform.ResampleMean), "aggregation when resampling: mean, last, or sum")
+}
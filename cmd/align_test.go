@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+func TestAlignRejectsBadArguments(t *testing.T) {
+	if err := alignCmd.Args(alignCmd, []string{"GDP"}); err == nil {
+		t.Error("expected an error for one series")
+	}
+	orig := alignMethod
+	alignMethod = "right"
+	t.Cleanup(func() { alignMethod = orig })
+	err := alignCmd.RunE(&cobra.Command{}, []string{"GDP", "CPIAUCSL"})
+	if err == nil || !strings.Contains(err.Error(), "--method must be inner, outer, or left") {
+		t.Errorf("expected a --method error, got %v", err)
+	}
+}
+
+func TestWriteAlignedJSONLReadsBackAsGroups(t *testing.T) {
+	dates := monthStarts(2020, 3)
+	gdp := compareSeries("GDP", dates, 100, math.NaN(), 102)
+	cpi := compareSeries("CPIAUCSL", dates, 250, 251, 252)
+	gdp.Meta = &model.SeriesMeta{CitationText: "U.S. Bureau of Economic Analysis"}
+
+	var buf bytes.Buffer
+	if err := writeAlignedJSONL(&buf, []*model.SeriesData{gdp, cpi}); err != nil {
+		t.Fatalf("writeAlignedJSONL: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"value":null`) {
+		t.Errorf("a missing value should be written as null:\n%s", buf.String())
+	}
+	groups, err := pipeline.ReadObservationGroups(&buf)
+	if err != nil {
+		t.Fatalf("ReadObservationGroups: %v", err)
+	}
+	if len(groups) != 2 || groups[0].SeriesID != "GDP" || groups[1].SeriesID != "CPIAUCSL" {
+		t.Fatalf("unexpected groups %+v", groups)
+	}
+	for _, g := range groups {
+		if len(g.Obs) != 3 || !g.Obs[2].Date.Equal(dates[2]) {
+			t.Errorf("%s: expected three rows on the shared grid, got %+v", g.SeriesID, g.Obs)
+		}
+	}
+	if !math.IsNaN(groups[0].Obs[1].Value) || groups[1].Obs[1].Value != 251 {
+		t.Errorf("unexpected February values %v / %v", groups[0].Obs[1].Value, groups[1].Obs[1].Value)
+	}
+}
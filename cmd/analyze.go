@@ -4,16 +4,23 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/derickschaefer/reserve/internal/analyze"
+	"github.com/derickschaefer/reserve/internal/chart"
+	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/pipeline"
+	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/spf13/cobra"
 )
 
@@ -31,14 +38,62 @@ Examples:
 
 var analyzeSummaryBySeries bool
 var analyzeSummaryWindow int
+var analyzeSummarySpark bool
+var analyzeSummaryRobust bool
+var analyzeSummaryPercentiles []float64
+var analyzeSummaryDDOF int
+var analyzeSummaryByPeriod string
+var analyzeSummaryStream bool
 
 var analyzeSummaryCmd = &cobra.Command{
 	Use:   "summary",
 	Short: "Descriptive statistics: count, mean, std, min, max, median, skew",
 	Example: `  reserve obs get GDP --from cache --format jsonl | reserve analyze summary
   reserve obs get UNRATE --from cache --format jsonl | reserve transform pct-change | reserve analyze summary
-  reserve obs get FEDFUNDS T10Y2Y UNRATE --format jsonl | reserve analyze summary --by-series`,
+  reserve obs get FEDFUNDS T10Y2Y UNRATE --format jsonl | reserve analyze summary --by-series
+  reserve obs get FEDFUNDS T10Y2Y UNRATE --format jsonl | reserve analyze summary --by-series --spark
+  reserve obs get UNRATE --from cache --format jsonl | reserve analyze summary --robust
+  reserve obs get UNRATE --from cache --format jsonl | reserve analyze summary --percentiles 5,50,95,99
+  reserve obs get UNRATE --from cache --format jsonl | reserve analyze summary --ddof 0
+  reserve obs get UNRATE --from cache --format jsonl | reserve analyze summary --by-period month
+  reserve obs get DGS10 --from cache --format jsonl | reserve analyze summary --stream`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if analyzeSummarySpark && analyzeSummaryWindow > 0 {
+			return fmt.Errorf("--spark is not supported with --window")
+		}
+		if analyzeSummaryByPeriod != "" {
+			switch {
+			case analyzeSummaryBySeries:
+				return fmt.Errorf("--by-period is not supported with --by-series")
+			case analyzeSummaryWindow > 0:
+				return fmt.Errorf("--by-period is not supported with --window")
+			case analyzeSummarySpark:
+				return fmt.Errorf("--spark is not supported with --by-period")
+			}
+		}
+		if analyzeSummaryStream {
+			switch {
+			case analyzeSummaryBySeries:
+				return fmt.Errorf("--stream is not supported with --by-series")
+			case analyzeSummaryByPeriod != "":
+				return fmt.Errorf("--stream is not supported with --by-period")
+			case analyzeSummaryWindow > 0:
+				return fmt.Errorf("--stream is not supported with --window")
+			case analyzeSummarySpark:
+				return fmt.Errorf("--stream is not supported with --spark")
+			case analyzeSummaryRobust:
+				return fmt.Errorf("--stream is not supported with --robust")
+			}
+		}
+		if analyzeSummaryDDOF != 0 && analyzeSummaryDDOF != 1 {
+			return fmt.Errorf("--ddof must be 0 or 1, got %d", analyzeSummaryDDOF)
+		}
+		cuts := analyzeSummaryPercentiles
+		for _, p := range cuts {
+			if math.IsNaN(p) || p < 0 || p > 100 {
+				return fmt.Errorf("--percentiles: %g is outside [0, 100]", p)
+			}
+		}
 		format := resolveFormat("")
 		w, closeFn, err := outputWriter(cmd.OutOrStdout())
 		if err != nil {
@@ -56,11 +111,22 @@ var analyzeSummaryCmd = &cobra.Command{
 			}
 			summaries := make([]analyze.Summary, 0, len(groups))
 			for _, group := range groups {
-				s := analyze.Summarize(group.SeriesID, group.Obs)
+				s := analyze.SummarizeDDOF(group.SeriesID, group.Obs, analyzeSummaryDDOF, cuts...)
 				applyProvenanceToSummary(&s, group.Provenance)
+				if analyzeSummarySpark {
+					s.Spark = summarySpark(group.Obs)
+				}
 				summaries = append(summaries, s)
 			}
-			return renderSummaryBatch(w, format, summaries)
+			return renderSummaryBatch(w, format, summaries, analyzeSummaryRobust)
+		}
+
+		if analyzeSummaryStream {
+			s, err := analyze.StreamSummarizeDDOF(os.Stdin, analyzeSummaryDDOF, cuts...)
+			if err != nil {
+				return err
+			}
+			return renderSummarySingle(w, format, *s, false)
 		}
 
 		seriesID, obs, prov, err := pipeline.ReadObservationsWithProvenance(os.Stdin)
@@ -68,19 +134,32 @@ var analyzeSummaryCmd = &cobra.Command{
 			return err
 		}
 
-		s := analyze.Summarize(seriesID, obs)
+		s := analyze.SummarizeDDOF(seriesID, obs, analyzeSummaryDDOF, cuts...)
 		applyProvenanceToSummary(&s, prov)
+		if analyzeSummarySpark {
+			s.Spark = summarySpark(obs)
+		}
+		if analyzeSummaryByPeriod != "" {
+			grouped, err := analyze.SummarizeGroupedDDOF(seriesID, obs, analyzeSummaryByPeriod, analyzeSummaryDDOF, cuts...)
+			if err != nil {
+				return err
+			}
+			for i := range grouped {
+				applyProvenanceToSummary(&grouped[i], prov)
+			}
+			return renderSummaryBatch(w, format, grouped, analyzeSummaryRobust)
+		}
 		if analyzeSummaryWindow > 0 {
-			windows := analyze.SummarizeWindows(seriesID, obs, analyzeSummaryWindow)
+			windows := analyze.SummarizeWindowsDDOF(seriesID, obs, analyzeSummaryWindow, analyzeSummaryDDOF, cuts...)
 			if len(windows) == 0 {
 				return fmt.Errorf("window=%d exceeds available observations (%d)", analyzeSummaryWindow, len(obs))
 			}
 			for i := range windows {
 				applyProvenanceToSummary(&windows[i], prov)
 			}
-			return renderSummaryBatch(w, format, windows)
+			return renderSummaryBatch(w, format, windows, analyzeSummaryRobust)
 		}
-		return renderSummarySingle(w, format, s)
+		return renderSummarySingle(w, format, s, analyzeSummaryRobust)
 	},
 }
 
@@ -88,30 +167,72 @@ var analyzeSummaryCmd = &cobra.Command{
 
 var analyzeTrendMethod string
 var analyzeTrendConfidence bool
+var analyzeTrendDegree int
+var analyzeTrendRolling int
 var analyzeCompareAgainst string
 var analyzeCompareSeries string
+var analyzeXCorrWith string
+var analyzeXCorrSeries string
+var analyzeXCorrMaxLag int
+var analyzeRollCorrWith string
+var analyzeRollCorrSeries string
+var analyzeRollCorrWindow int
 var analyzeRegimeMethod string
 var analyzeRegimeThreshold float64
+var analyzeCacheResults bool
 
 var analyzeTrendCmd = &cobra.Command{
 	Use:   "trend",
 	Short: "Fit a linear trend: slope, intercept, R², direction",
 	Example: `  reserve obs get GDP --from cache --format jsonl | reserve analyze trend
-  reserve obs get UNRATE --from cache --format jsonl | reserve analyze trend --method theil-sen`,
+  reserve obs get UNRATE --from cache --format jsonl | reserve analyze trend --method theil-sen
+  reserve obs get CIVPART --from cache --format jsonl | reserve analyze trend --method poly --degree 2
+  reserve obs get CPIAUCSL --from cache --format jsonl | reserve analyze trend --rolling 60 --format jsonl`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		poly := analyze.TrendMethod(analyzeTrendMethod) == analyze.TrendPolynomial
+		if cmd.Flags().Changed("degree") && !poly {
+			return fmt.Errorf("--degree requires --method poly")
+		}
+		if poly && analyzeTrendConfidence {
+			return fmt.Errorf("--confidence is not supported with --method poly")
+		}
+		if analyzeTrendRolling > 0 {
+			switch {
+			case poly:
+				return fmt.Errorf("--rolling is not supported with --method poly")
+			case analyzeTrendConfidence:
+				return fmt.Errorf("--confidence is not supported with --rolling")
+			}
+		}
 		seriesID, obs, prov, err := pipeline.ReadObservationsWithProvenance(os.Stdin)
 		if err != nil {
 			return err
 		}
+		if analyzeTrendRolling > 0 {
+			return runRollingTrend(cmd, seriesID, obs, prov)
+		}
+		if poly {
+			return runPolyTrend(cmd, seriesID, obs, prov)
+		}
 
-		tr, err := analyze.Trend(seriesID, obs, analyze.TrendMethod(analyzeTrendMethod))
+		var tr analyze.TrendResult
+		key := analyzeResultKey("trend", seriesID, obs,
+			"method:"+analyzeTrendMethod, "confidence:"+strconv.FormatBool(analyzeTrendConfidence))
+		err = withCachedResult(cmd, key, "analyze trend", &tr, func() error {
+			res, err := analyze.Trend(seriesID, obs, analyze.TrendMethod(analyzeTrendMethod))
+			if err != nil {
+				return err
+			}
+			applyProvenanceToTrend(&res, prov)
+			if analyzeTrendConfidence {
+				res.Confidence = analyze.AddTrendConfidence(res, obs)
+			}
+			tr = res
+			return nil
+		})
 		if err != nil {
 			return err
 		}
-		applyProvenanceToTrend(&tr, prov)
-		if analyzeTrendConfidence {
-			tr.Confidence = analyze.AddTrendConfidence(tr, obs)
-		}
 
 		format := resolveFormat("")
 		w, closeFn, err := outputWriter(cmd.OutOrStdout())
@@ -124,6 +245,9 @@ var analyzeTrendCmd = &cobra.Command{
 			enc.SetIndent("", "  ")
 			return enc.Encode(tr)
 		}
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, tr)
+		}
 
 		rows := [][]string{
 			{"Context", "-"},
@@ -140,7 +264,9 @@ var analyzeTrendCmd = &cobra.Command{
 		if analyzeTrendConfidence {
 			rows = append(rows,
 				[]string{"Confidence", "-"},
+				[]string{"Test", fmtConfidenceTest(tr.Confidence)},
 				[]string{"Slope StdErr", fmtConfidence(tr.Confidence, func(c *analyze.TrendConfidence) float64 { return c.SlopeStdErr }, 6)},
+				[]string{"Slope T-Stat", fmtConfidence(tr.Confidence, func(c *analyze.TrendConfidence) float64 { return c.SlopeTStat }, 4)},
 				[]string{"Slope P-Value", fmtConfidence(tr.Confidence, func(c *analyze.TrendConfidence) float64 { return c.SlopePValue }, 6)},
 				[]string{"Slope CI95 Low", fmtConfidence(tr.Confidence, func(c *analyze.TrendConfidence) float64 { return c.SlopeCI95Low }, 6)},
 				[]string{"Slope CI95 High", fmtConfidence(tr.Confidence, func(c *analyze.TrendConfidence) float64 { return c.SlopeCI95High }, 6)},
@@ -161,6 +287,140 @@ var analyzeTrendCmd = &cobra.Command{
 	},
 }
 
+// rollingTrendRow is one window of analyze trend --rolling, with NaN as null.
+type rollingTrendRow struct {
+	SeriesID     string   `json:"series_id"`
+	Date         string   `json:"date"`
+	SlopePerYear *float64 `json:"slope_per_year"`
+	R2           *float64 `json:"r2"`
+	Direction    string   `json:"direction"`
+}
+
+// runRollingTrend is analyze trend --rolling N: one trend per trailing window,
+// emitted as JSONL rows or a table.
+func runRollingTrend(cmd *cobra.Command, seriesID string, obs []model.Observation, prov pipeline.Provenance) error {
+	trends, err := analyze.RollingTrend(seriesID, obs, analyzeTrendRolling, analyze.TrendMethod(analyzeTrendMethod))
+	if err != nil {
+		return err
+	}
+	orNil := func(v float64) *float64 {
+		if math.IsNaN(v) {
+			return nil
+		}
+		return &v
+	}
+	rows := make([]rollingTrendRow, len(trends))
+	for i, tr := range trends {
+		rows[i] = rollingTrendRow{
+			SeriesID:     tr.SeriesID,
+			Date:         tr.Date,
+			SlopePerYear: orNil(tr.SlopePerYear),
+			R2:           orNil(tr.R2),
+			Direction:    tr.Direction,
+		}
+	}
+
+	format := resolveFormat("")
+	w, closeFn, err := outputWriter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case render.FormatYAML:
+		return render.EncodeYAML(w, rows)
+	case "jsonl":
+		enc := json.NewEncoder(w)
+		for _, r := range rows {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	printSimpleTable(w, []string{"DATE", "SLOPE / YEAR", "R2", "DIRECTION"}, func(add func(...string)) {
+		for _, tr := range trends {
+			add(tr.Date, fmtFloatTable(tr.SlopePerYear, 4), fmtFloatTable(tr.R2, 4), tr.Direction)
+		}
+	})
+	if citation := strings.TrimSpace(prov.CitationText); citation != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, citation)
+	}
+	return nil
+}
+
+// runPolyTrend is analyze trend --method poly. Its result has coefficients
+// in place of a slope, so it renders separately from the linear methods.
+func runPolyTrend(cmd *cobra.Command, seriesID string, obs []model.Observation, prov pipeline.Provenance) error {
+	var tr analyze.PolyTrendResult
+	key := analyzeResultKey("trend", seriesID, obs,
+		"method:"+analyzeTrendMethod, "degree:"+strconv.Itoa(analyzeTrendDegree))
+	err := withCachedResult(cmd, key, "analyze trend", &tr, func() error {
+		res, err := analyze.TrendPoly(obs, analyzeTrendDegree)
+		if err != nil {
+			return err
+		}
+		res.SeriesID = seriesID
+		applyProvenanceToPolyTrend(&res, prov)
+		tr = res
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	format := resolveFormat("")
+	w, closeFn, err := outputWriter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tr)
+	}
+	if format == render.FormatYAML {
+		return render.EncodeYAML(w, tr)
+	}
+
+	rows := [][]string{
+		{"Context", "-"},
+		{"Series", tr.SeriesID},
+		{"Method", string(tr.Method)},
+		{"Degree", strconv.Itoa(tr.Degree)},
+		{"Trend", "-"},
+		{"Curvature", tr.Curvature},
+		{"Fit", "-"},
+	}
+	for k, c := range tr.Coefficients {
+		label := fmt.Sprintf("Coef x^%d", k)
+		switch k {
+		case 0:
+			label = "Intercept"
+		case 1:
+			label = "Coef x (per day)"
+		}
+		rows = append(rows, []string{label, strconv.FormatFloat(c, 'g', 6, 64)})
+	}
+	rows = append(rows, []string{"R2", fmtFloatTable(tr.R2, 4)})
+	printSimpleTable(w, []string{"METRIC", "VALUE"}, func(add func(...string)) {
+		for _, row := range rows {
+			add(row[0], row[1])
+		}
+	})
+	if citation := strings.TrimSpace(tr.CitationText); citation != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, citation)
+	}
+	return nil
+}
+
 var analyzeCompareCmd = &cobra.Command{
 	Use:   "compare --against <SERIES_ID>",
 	Short: "Compare two aligned series: correlation, beta, delta, tracking error",
@@ -170,35 +430,11 @@ var analyzeCompareCmd = &cobra.Command{
 		if strings.TrimSpace(analyzeCompareAgainst) == "" {
 			return fmt.Errorf("--against is required")
 		}
-		groups, err := pipeline.ReadObservationGroups(os.Stdin)
+		lhs, rhs, err := readSeriesPair(os.Stdin, "compare", analyzeCompareSeries, "against", analyzeCompareAgainst)
 		if err != nil {
 			return err
 		}
-		byID := map[string]pipeline.ObservationGroup{}
-		for _, g := range groups {
-			byID[g.SeriesID] = g
-		}
-		rhsID := strings.ToUpper(strings.TrimSpace(analyzeCompareAgainst))
-		rhs, ok := byID[rhsID]
-		if !ok {
-			return fmt.Errorf("against series %q not found in input stream", rhsID)
-		}
-		lhsID := strings.ToUpper(strings.TrimSpace(analyzeCompareSeries))
-		if lhsID == "" {
-			for _, g := range groups {
-				if g.SeriesID != rhsID {
-					lhsID = g.SeriesID
-					break
-				}
-			}
-		}
-		if lhsID == "" {
-			return fmt.Errorf("compare requires two series in input stream")
-		}
-		lhs, ok := byID[lhsID]
-		if !ok {
-			return fmt.Errorf("series %q not found in input stream", lhsID)
-		}
+		lhsID, rhsID := lhs.SeriesID, rhs.SeriesID
 		res, err := analyze.Compare(lhsID, lhs.Obs, rhsID, rhs.Obs)
 		if err != nil {
 			return err
@@ -210,6 +446,9 @@ var analyzeCompareCmd = &cobra.Command{
 			return err
 		}
 		defer closeFn()
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, res)
+		}
 		if format == "json" || format == "jsonl" {
 			enc := json.NewEncoder(w)
 			if format == "json" {
@@ -235,6 +474,102 @@ var analyzeCompareCmd = &cobra.Command{
 	},
 }
 
+var analyzeXCorrCmd = &cobra.Command{
+	Use:   "xcorr --with <SERIES_ID>",
+	Short: "Cross-correlate two series across lags to find which one leads",
+	Long: `Correlates the primary series with the --with series shifted from
+-max-lag to +max-lag periods. A positive best lag means the --with series
+leads the primary series by that many periods; a negative one that the
+primary series leads. Both series should share a frequency.`,
+	Example: `  reserve obs get INDPRO T10Y3M --freq monthly --format jsonl | reserve analyze xcorr --series INDPRO --with T10Y3M --max-lag 24
+  reserve obs get UNRATE ICSA --freq monthly --format jsonl | reserve analyze xcorr --with ICSA --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(analyzeXCorrWith) == "" {
+			return fmt.Errorf("--with is required")
+		}
+		primary, with, err := readSeriesPair(os.Stdin, "xcorr", analyzeXCorrSeries, "with", analyzeXCorrWith)
+		if err != nil {
+			return err
+		}
+		seriesID, withID := primary.SeriesID, with.SeriesID
+		res, err := analyze.CrossCorrelation(primary.Obs, with.Obs, analyzeXCorrMaxLag)
+		if err != nil {
+			return err
+		}
+		res.SeriesID, res.WithSeriesID = seriesID, withID
+		applyProvenanceToXCorr(&res, primary.Provenance, with.Provenance)
+		format := resolveFormat("")
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, res)
+		}
+		if format == "json" || format == "jsonl" {
+			enc := json.NewEncoder(w)
+			if format == "json" {
+				enc.SetIndent("", "  ")
+			}
+			return enc.Encode(res)
+		}
+		printSimpleTable(w, []string{"METRIC", "VALUE"}, func(add func(...string)) {
+			add("Series", res.SeriesID)
+			add("With", res.WithSeriesID)
+			add("Best Lag", fmt.Sprintf("%+d", res.BestLag))
+			add("Correlation", fmtFloatTable(res.BestCorrelation, 4))
+			add("Leader", xcorrLeaderText(res))
+		})
+		fmt.Fprintln(w)
+		printSimpleTable(w, []string{"LAG", "CORRELATION", "PAIRS"}, func(add func(...string)) {
+			for _, l := range res.Lags {
+				add(fmt.Sprintf("%+d", l.Lag), fmtFloatTable(l.Correlation, 4), fmt.Sprintf("%d", l.Pairs))
+			}
+		})
+		if footer := compareCitationFooter(seriesID, primary.Provenance.CitationText, withID, with.Provenance.CitationText); footer != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, footer)
+		}
+		return nil
+	},
+}
+
+var analyzeRollCorrCmd = &cobra.Command{
+	Use:   "roll-corr --with <SERIES_ID>",
+	Short: "Rolling correlation between two series, as an observation stream",
+	Long: `Inner-joins the primary series and the --with series on date and emits the
+Pearson correlation over each trailing --window of aligned observations. The
+first window-1 dates are null.
+
+Unlike the other analyze verbs, roll-corr is not terminal: it writes JSONL
+observations under the primary series ID when piped, so the correlation can be
+charted, trended, or summarized like any other series.`,
+	Example: `  reserve obs get SP500 DGS10 --freq monthly --format jsonl | reserve analyze roll-corr --series SP500 --with DGS10 --window 36
+  reserve obs get SP500 DGS10 --freq monthly --format jsonl | reserve analyze roll-corr --with DGS10 --window 36 | reserve chart plot`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(analyzeRollCorrWith) == "" {
+			return fmt.Errorf("--with is required")
+		}
+		primary, with, err := readSeriesPair(os.Stdin, "roll-corr", analyzeRollCorrSeries, "with", analyzeRollCorrWith)
+		if err != nil {
+			return err
+		}
+		out, err := analyze.RollingCorrelation(primary.Obs, with.Obs, analyzeRollCorrWindow)
+		if err != nil {
+			return err
+		}
+		// The citation travels on every emitted row, so keep it to one line.
+		var citations []string
+		for _, c := range []string{primary.Provenance.CitationText, with.Provenance.CitationText} {
+			if c = strings.TrimSpace(c); c != "" && (len(citations) == 0 || citations[0] != c) {
+				citations = append(citations, c)
+			}
+		}
+		return writeTransformOutput(cmd, primary.SeriesID, out, strings.Join(citations, "; "))
+	},
+}
+
 var analyzeRegimeCmd = &cobra.Command{
 	Use:   "regime",
 	Short: "Experimental regime detection (change points + labeled segments)",
@@ -252,17 +587,30 @@ var analyzeRegimeCmd = &cobra.Command{
 		if method != "cusum" {
 			return fmt.Errorf("unsupported regime method %q (supported: cusum)", method)
 		}
-		res, err := analyze.RegimeCUSUM(seriesID, obs, analyzeRegimeThreshold)
+		var res analyze.RegimeResult
+		key := analyzeResultKey("regime", seriesID, obs,
+			"method:"+method, "threshold:"+strconv.FormatFloat(analyzeRegimeThreshold, 'g', -1, 64))
+		err = withCachedResult(cmd, key, "analyze regime", &res, func() error {
+			r, err := analyze.RegimeCUSUM(seriesID, obs, analyzeRegimeThreshold)
+			if err != nil {
+				return err
+			}
+			applyProvenanceToRegime(&r, prov)
+			res = r
+			return nil
+		})
 		if err != nil {
 			return err
 		}
-		applyProvenanceToRegime(&res, prov)
 		format := resolveFormat("")
 		w, closeFn, err := outputWriter(cmd.OutOrStdout())
 		if err != nil {
 			return err
 		}
 		defer closeFn()
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, res)
+		}
 		if format == "json" || format == "jsonl" {
 			enc := json.NewEncoder(w)
 			if format == "json" {
@@ -303,6 +651,306 @@ var analyzeRegimeCmd = &cobra.Command{
 	},
 }
 
+var analyzeSubseriesCmd = &cobra.Command{
+	Use:   "subseries",
+	Short: "Seasonal subseries: month × year matrix with per-month averages",
+	Example: `  reserve obs get UNRATE --start 2019-01-01 --format jsonl | reserve analyze subseries
+  reserve obs get RSXFS --from cache --format jsonl | reserve analyze subseries --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seriesID, obs, prov, err := pipeline.ReadObservationsWithProvenance(os.Stdin)
+		if err != nil {
+			return err
+		}
+		res, err := analyze.SeasonalSubseries(seriesID, obs)
+		if err != nil {
+			return err
+		}
+		applyProvenanceToSubseries(&res, prov)
+		format := resolveFormat("")
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, res)
+		}
+		if format == "json" || format == "jsonl" {
+			enc := json.NewEncoder(w)
+			if format == "json" {
+				enc.SetIndent("", "  ")
+			}
+			return enc.Encode(res)
+		}
+		headers := []string{"MONTH"}
+		for _, y := range res.Years {
+			headers = append(headers, fmt.Sprintf("%d", y))
+		}
+		headers = append(headers, "AVG")
+		printSimpleTable(w, headers, func(add func(...string)) {
+			for _, m := range res.Months {
+				row := []string{m.Name}
+				for _, v := range m.Values {
+					row = append(row, fmtFloatTable(v, 4))
+				}
+				add(append(row, fmtFloatTable(m.Mean, 4))...)
+			}
+		})
+		if citation := strings.TrimSpace(res.CitationText); citation != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, citation)
+		}
+		return nil
+	},
+}
+
+var analyzeHalfLifeCmd = &cobra.Command{
+	Use:   "half-life",
+	Short: "Estimate the half-life of mean reversion (AR(1) fit of Δy on lagged y)",
+	Example: `  reserve obs get T10Y2Y --start 2000-01-01 --format jsonl | reserve analyze half-life
+  reserve obs get UNRATE --from cache --format jsonl | reserve analyze half-life --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seriesID, obs, prov, err := pipeline.ReadObservationsWithProvenance(os.Stdin)
+		if err != nil {
+			return err
+		}
+		res, err := analyze.HalfLife(seriesID, obs)
+		if err != nil {
+			return err
+		}
+		applyProvenanceToHalfLife(&res, prov)
+		format := resolveFormat("")
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, res)
+		}
+		if format == "json" || format == "jsonl" {
+			enc := json.NewEncoder(w)
+			if format == "json" {
+				enc.SetIndent("", "  ")
+			}
+			return enc.Encode(res)
+		}
+		printSimpleTable(w, []string{"METRIC", "VALUE"}, func(add func(...string)) {
+			add("Series", res.SeriesID)
+			add("Pairs", fmt.Sprintf("%d", res.Count))
+			add("Beta", fmtFloatTable(res.Beta, 4))
+			add("Mean Reverting", fmt.Sprintf("%t", res.MeanReverting))
+			add("Half-Life (periods)", fmtFloatTable(res.HalfLifePeriods, 2))
+			add("Periods/Year", fmtFloatTable(res.PeriodsPerYear, 2))
+			add("Half-Life (years)", fmtFloatTable(res.HalfLifeYears, 2))
+		})
+		if res.Note != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintf(w, "⚠  %s\n", res.Note)
+		}
+		if citation := strings.TrimSpace(res.CitationText); citation != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, citation)
+		}
+		return nil
+	},
+}
+
+// ─── analyze decompose ───────────────────────────────────────────────────────
+
+var (
+	analyzeDecomposePeriod int
+	analyzeDecomposeModel  string
+	analyzeDecomposeEmit   string
+)
+
+var analyzeDecomposeCmd = &cobra.Command{
+	Use:   "decompose",
+	Short: "Classical seasonal decomposition into trend, seasonal, and residual",
+	Long: `Splits a series into trend, seasonal, and residual components by classical
+decomposition. The trend is a centered moving average over --period
+observations; the seasonal component is the average detrended value at each
+position in the cycle; the residual is what remains. --model multiplicative
+divides instead of subtracting and requires positive values.
+
+Trend and residual are undefined for the first and last half-period. Missing
+values are skipped when averaging the seasonal component.
+
+--emit writes one component as an observation stream, so it can be piped into
+transform, analyze, or chart like any other series.`,
+	Example: `  reserve obs get RSXFSN --start 2015-01-01 --format jsonl | reserve analyze decompose
+  reserve obs get RSXFSN --from cache --format jsonl | reserve analyze decompose --model multiplicative --format json
+  reserve obs get RSXFSN --from cache --format jsonl | reserve analyze decompose --emit seasonal | reserve chart plot`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch analyzeDecomposeEmit {
+		case "", "trend", "seasonal", "residual":
+		default:
+			return fmt.Errorf("--emit must be trend, seasonal, or residual, got %q", analyzeDecomposeEmit)
+		}
+		seriesID, obs, prov, err := pipeline.ReadObservationsWithProvenance(os.Stdin)
+		if err != nil {
+			return err
+		}
+		res, err := analyze.Decompose(obs, analyzeDecomposePeriod, analyzeDecomposeModel)
+		if err != nil {
+			return err
+		}
+		res.SeriesID = seriesID
+		applyProvenanceToDecompose(&res, prov)
+
+		switch analyzeDecomposeEmit {
+		case "trend":
+			return writeTransformOutput(cmd, seriesID, res.Trend, prov.CitationText)
+		case "seasonal":
+			return writeTransformOutput(cmd, seriesID, res.Seasonal, prov.CitationText)
+		case "residual":
+			return writeTransformOutput(cmd, seriesID, res.Residual, prov.CitationText)
+		}
+
+		format := resolveFormat("")
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, res)
+		}
+		if format == "json" || format == "jsonl" {
+			enc := json.NewEncoder(w)
+			if format == "json" {
+				enc.SetIndent("", "  ")
+			}
+			return enc.Encode(res)
+		}
+		printSimpleTable(w, []string{"DATE", "VALUE", "TREND", "SEASONAL", "RESIDUAL"}, func(add func(...string)) {
+			for i, o := range obs {
+				add(o.Date.Format("2006-01-02"),
+					fmtFloatTable(o.Value, 4),
+					fmtFloatTable(res.Trend[i].Value, 4),
+					fmtFloatTable(res.Seasonal[i].Value, 4),
+					fmtFloatTable(res.Residual[i].Value, 4))
+			}
+		})
+		if citation := strings.TrimSpace(res.CitationText); citation != "" {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, citation)
+		}
+		return nil
+	},
+}
+
+// ─── analyze laspeyres ───────────────────────────────────────────────────────
+
+var (
+	analyzeLaspeyresBase       string
+	analyzeLaspeyresComponents string
+	analyzeLaspeyresWeights    string
+)
+
+var analyzeLaspeyresCmd = &cobra.Command{
+	Use:   "laspeyres",
+	Short: "Fixed-weight composite index with per-component contributions",
+	Long: `Builds a Laspeyres composite from cached component series. --base resolves to
+one observation date every component reports (the exact date, else the one in
+the same period or nearest to it); each component is rebased to 100 there,
+weighted by its base-period weight (weights are
+normalized to sum to 1), and summed on the dates every component reports.
+
+Reports the composite's change from base at the latest common date and each
+component's contribution to that change in index points; contributions sum to
+the composite change. Unlike the other analyze commands, components are read
+from the local store rather than stdin.`,
+	Example: `  reserve analyze laspeyres --base 2020-01-01 --components "CPIUFDSL,CPIENGSL,CPILFESL" --weights "13.5,7.4,79.1"
+  reserve analyze laspeyres --base 2019-01-01 --components "A,B" --weights "0.6,0.4" --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, err := time.Parse("2006-01-02", analyzeLaspeyresBase)
+		if err != nil {
+			return fmt.Errorf("--base: invalid date %q, expected YYYY-MM-DD", analyzeLaspeyresBase)
+		}
+		ids := splitCSV(analyzeLaspeyresComponents)
+		weightStrs := splitCSV(analyzeLaspeyresWeights)
+		if len(ids) == 0 {
+			return fmt.Errorf("--components is required")
+		}
+		if len(weightStrs) != len(ids) {
+			return fmt.Errorf("--weights has %d values for %d components", len(weightStrs), len(ids))
+		}
+
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		components := make([]analyze.WeightedSeries, len(ids))
+		var citations []string
+		for i, raw := range ids {
+			id := resolveSeriesID(deps, raw)
+			weight, err := strconv.ParseFloat(weightStrs[i], 64)
+			if err != nil {
+				return fmt.Errorf("--weights: invalid weight %q for %s", weightStrs[i], id)
+			}
+			obs, err := cachedObservations(deps, id)
+			if err != nil {
+				return err
+			}
+			meta, err := ensureSeriesCompliance(cmd.Context(), deps, id, "display")
+			if err != nil {
+				return err
+			}
+			if c := strings.TrimSpace(meta.CitationText); c != "" {
+				citations = append(citations, fmt.Sprintf("%s: %s", id, c))
+			}
+			components[i] = analyze.WeightedSeries{SeriesID: id, Weight: weight, Obs: obs}
+		}
+
+		res, err := analyze.Laspeyres(components, base)
+		if err != nil {
+			return err
+		}
+
+		format := resolveFormat("")
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, res)
+		}
+		if format == "json" || format == "jsonl" {
+			enc := json.NewEncoder(w)
+			if format == "json" {
+				enc.SetIndent("", "  ")
+			}
+			return enc.Encode(res)
+		}
+		printSimpleTable(w, []string{"METRIC", "VALUE"}, func(add func(...string)) {
+			add("Method", res.Method)
+			add("Base Date", res.BaseDate)
+			add("End Date", res.EndDate)
+			add("Composite", fmtFloatTable(res.CompositeEnd, 4))
+			add("Change", fmtFloatTable(res.CompositeChange, 4))
+		})
+		fmt.Fprintln(w)
+		printSimpleTable(w, []string{"SERIES", "WEIGHT", "REBASED", "CONTRIBUTION", "SHARE"}, func(add func(...string)) {
+			for _, c := range res.Components {
+				add(c.SeriesID, fmtFloatTable(c.Weight, 4), fmtFloatTable(c.Rebased, 4),
+					fmtFloatTable(c.Contribution, 4), fmtPctTable(c.ShareOfChange))
+			}
+		})
+		if len(citations) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, strings.Join(citations, "\n"))
+		}
+		return nil
+	},
+}
+
 // ─── Registration ─────────────────────────────────────────────────────────────
 
 func init() {
@@ -310,25 +958,121 @@ func init() {
 	analyzeCmd.AddCommand(analyzeSummaryCmd)
 	analyzeCmd.AddCommand(analyzeTrendCmd)
 	analyzeCmd.AddCommand(analyzeCompareCmd)
+	analyzeCmd.AddCommand(analyzeXCorrCmd)
+	analyzeCmd.AddCommand(analyzeRollCorrCmd)
 	analyzeCmd.AddCommand(analyzeRegimeCmd)
+	analyzeCmd.AddCommand(analyzeSubseriesCmd)
+	analyzeCmd.AddCommand(analyzeHalfLifeCmd)
+	analyzeCmd.AddCommand(analyzeDecomposeCmd)
+	analyzeCmd.AddCommand(analyzeLaspeyresCmd)
 
 	analyzeSummaryCmd.Flags().BoolVar(&analyzeSummaryBySeries, "by-series", false,
 		"group multi-series JSONL input by series_id and emit one summary per series")
 	analyzeSummaryCmd.Flags().IntVar(&analyzeSummaryWindow, "window", 0,
 		"rolling window size (observations) for summary output")
+	analyzeSummaryCmd.Flags().BoolVar(&analyzeSummarySpark, "spark", false,
+		"append a one-line sparkline of each series to the summary")
+	analyzeSummaryCmd.Flags().BoolVar(&analyzeSummaryRobust, "robust", false,
+		"add robust statistics to table output: MAD, IQR, and 10% trimmed mean")
+	analyzeSummaryCmd.Flags().Float64SliceVar(&analyzeSummaryPercentiles, "percentiles", nil,
+		"comma-separated percentiles (0-100) to report in place of P25/median/P75, e.g. 5,50,95,99")
+	analyzeSummaryCmd.Flags().IntVar(&analyzeSummaryDDOF, "ddof", 1,
+		"std degrees-of-freedom correction: 1 for sample std (n-1), 0 for population std (n)")
+	analyzeSummaryCmd.Flags().StringVar(&analyzeSummaryByPeriod, "by-period", "",
+		"emit one summary per calendar period across years: month, quarter, or year")
+	analyzeSummaryCmd.Flags().BoolVar(&analyzeSummaryStream, "stream", false,
+		"summarize row by row without holding the input in memory; quantiles are approximate (within 0.1%)")
 	analyzeTrendCmd.Flags().StringVar(&analyzeTrendMethod, "method", "linear",
-		"regression method: linear|theil-sen")
+		"regression method: linear|theil-sen|poly")
+	analyzeTrendCmd.Flags().IntVar(&analyzeTrendDegree, "degree", 2,
+		"polynomial degree for --method poly: 2 or 3")
+	analyzeTrendCmd.Flags().IntVar(&analyzeTrendRolling, "rolling", 0,
+		"fit a trend over each trailing window of N observations and emit one row per date")
 	analyzeTrendCmd.Flags().BoolVar(&analyzeTrendConfidence, "confidence", false,
-		"include confidence metadata for linear trend (stderr, p-value, 95% CI)")
+		"include slope significance (stderr, t-stat, p-value, 95% CI); Theil-Sen uses Mann-Kendall and Sen's interval")
 	analyzeCompareCmd.Flags().StringVar(&analyzeCompareAgainst, "against", "", "series ID to compare against (must exist in input stream)")
 	analyzeCompareCmd.Flags().StringVar(&analyzeCompareSeries, "series", "", "primary series ID (defaults to first non-against series)")
+	analyzeXCorrCmd.Flags().StringVar(&analyzeXCorrWith, "with", "", "series ID to correlate against at each lag (must exist in input stream)")
+	analyzeXCorrCmd.Flags().StringVar(&analyzeXCorrSeries, "series", "", "primary series ID (defaults to first non-with series)")
+	analyzeXCorrCmd.Flags().IntVar(&analyzeXCorrMaxLag, "max-lag", 12, "largest lead or lag to test, in periods")
+	analyzeRollCorrCmd.Flags().StringVar(&analyzeRollCorrWith, "with", "", "series ID to correlate against (must exist in input stream)")
+	analyzeRollCorrCmd.Flags().StringVar(&analyzeRollCorrSeries, "series", "", "primary series ID (defaults to first non-with series)")
+	analyzeRollCorrCmd.Flags().IntVar(&analyzeRollCorrWindow, "window", 36, "trailing window size in aligned observations")
 	analyzeRegimeCmd.Flags().StringVar(&analyzeRegimeMethod, "method", "cusum", "experimental method: cusum")
 	analyzeRegimeCmd.Flags().Float64Var(&analyzeRegimeThreshold, "threshold", 5.0, "cusum threshold multiplier")
+	analyzeDecomposeCmd.Flags().IntVar(&analyzeDecomposePeriod, "period", 12, "observations per seasonal cycle (12 for monthly, 4 for quarterly)")
+	analyzeDecomposeCmd.Flags().StringVar(&analyzeDecomposeModel, "model", analyze.DecomposeAdditive, "decomposition model: additive|multiplicative")
+	analyzeDecomposeCmd.Flags().StringVar(&analyzeDecomposeEmit, "emit", "", "write one component as JSONL observations: trend|seasonal|residual")
+	analyzeLaspeyresCmd.Flags().StringVar(&analyzeLaspeyresBase, "base", "", "base date YYYY-MM-DD; every component must have a value on it")
+	analyzeLaspeyresCmd.Flags().StringVar(&analyzeLaspeyresComponents, "components", "", "comma-separated cached series IDs")
+	analyzeLaspeyresCmd.Flags().StringVar(&analyzeLaspeyresWeights, "weights", "", "comma-separated base-period weights, one per component")
+	for _, c := range []*cobra.Command{analyzeTrendCmd, analyzeRegimeCmd} {
+		c.Flags().BoolVar(&analyzeCacheResults, "cache-results", false,
+			"reuse a stored result for identical input and parameters, storing new results in the local store")
+	}
 }
 
 // ─── Helpers ──────────────────────────────────────────────────────────────────
 
-func renderSummarySingle(w io.Writer, format string, s analyze.Summary) error {
+// analyzeResultKey builds the results-bucket key for an analysis. The key
+// includes a digest of the input observations, so new or revised data never
+// matches a previously stored result.
+func analyzeResultKey(command, seriesID string, obs []model.Observation, params ...string) string {
+	h := sha256.New()
+	for _, o := range obs {
+		fmt.Fprintf(h, "%s=%s\n", o.Date.Format("2006-01-02"), strconv.FormatFloat(o.Value, 'g', -1, 64))
+	}
+	key := "analyze:" + command + "|series:" + seriesID
+	for _, p := range params {
+		key += "|" + p
+	}
+	return key + "|input:" + hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// withCachedResult fills v from the results bucket when --cache-results is set
+// and key is present; otherwise it runs compute, which must populate v, and
+// stores the outcome. Failing to store a result is reported but not fatal.
+func withCachedResult(cmd *cobra.Command, key, command string, v any, compute func() error) error {
+	if !analyzeCacheResults {
+		return compute()
+	}
+	deps, err := buildDeps()
+	if err != nil {
+		return err
+	}
+	if err := deps.RequireStore(); err != nil {
+		return err
+	}
+	defer deps.Close()
+
+	cached, found, err := deps.Store.GetResult(key)
+	if err != nil {
+		return fmt.Errorf("reading cached result: %w", err)
+	}
+	if raw, ok := cached.Data.(json.RawMessage); found && ok && json.Unmarshal(raw, v) == nil {
+		return nil
+	}
+
+	if err := compute(); err != nil {
+		return err
+	}
+	result := model.Result{
+		Kind:        model.KindReport,
+		GeneratedAt: time.Now(),
+		Command:     command,
+		Data:        v,
+	}
+	if err := deps.Store.PutResult(key, result); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "⚠  result not cached: %v\n", err)
+	}
+	return nil
+}
+
+// renderSummarySingle and renderSummaryBatch always include the robust
+// statistics in JSON; robust adds them to table output. When the summaries
+// carry requested percentiles, tables show those in place of the default
+// quartile columns.
+func renderSummarySingle(w io.Writer, format string, s analyze.Summary, robust bool) error {
 	if format == "json" {
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
@@ -337,6 +1081,9 @@ func renderSummarySingle(w io.Writer, format string, s analyze.Summary) error {
 	if format == "jsonl" {
 		return json.NewEncoder(w).Encode(s)
 	}
+	if format == render.FormatYAML {
+		return render.EncodeYAML(w, s)
+	}
 
 	rows := [][]string{
 		{"Context", "-"},
@@ -344,6 +1091,7 @@ func renderSummarySingle(w io.Writer, format string, s analyze.Summary) error {
 		{"Series", s.SeriesID},
 		{"Start Date", s.StartDate},
 		{"End Date", s.EndDate},
+		{"Frequency", s.Frequency},
 		{"Observations", fmt.Sprintf("%d", s.Count)},
 		{"Data Quality", "-"},
 		{"Missing Count", fmt.Sprintf("%d", s.MissingCount)},
@@ -352,16 +1100,39 @@ func renderSummarySingle(w io.Writer, format string, s analyze.Summary) error {
 		{"Mean", fmtFloatTable(s.Mean, 4)},
 		{"Std Dev", fmtFloatTable(s.Std, 4)},
 		{"Min", fmtFloatTable(s.Min, 4)},
-		{"P25", fmtFloatTable(s.P25, 4)},
-		{"Median", fmtFloatTable(s.Median, 4)},
-		{"P75", fmtFloatTable(s.P75, 4)},
-		{"Max", fmtFloatTable(s.Max, 4)},
-		{"Skew", fmtFloatTable(s.Skew, 4)},
+	}
+	if len(s.Percentiles) > 0 {
+		for _, p := range s.Percentiles {
+			rows = append(rows, []string{p.Label(), fmtFloatTable(p.Value, 4)})
+		}
+	} else {
+		rows = append(rows,
+			[]string{"P25", fmtFloatTable(s.P25, 4)},
+			[]string{"Median", fmtFloatTable(s.Median, 4)},
+			[]string{"P75", fmtFloatTable(s.P75, 4)},
+		)
+	}
+	rows = append(rows,
+		[]string{"Max", fmtFloatTable(s.Max, 4)},
+		[]string{"Skew", fmtFloatTable(s.Skew, 4)},
+	)
+	if robust {
+		rows = append(rows,
+			[]string{"MAD", fmtFloatTable(s.MAD, 4)},
+			[]string{"IQR", fmtFloatTable(s.IQR, 4)},
+			[]string{"Trimmed Mean (10%)", fmtFloatTable(s.TrimmedMean, 4)},
+		)
+	}
+	rows = append(rows, [][]string{
 		{"Movement", "-"},
 		{"First", fmtFloatTable(s.First, 4)},
 		{"Last", fmtFloatTable(s.Last, 4)},
 		{"Change", fmtFloatTable(s.Change, 4)},
-		{"Change %", fmtPctTable(s.ChangePct)},
+		{"Change %", fmtSignedPctTable(s.ChangePct, format == render.FormatTable && useColor(w))},
+		{"CAGR %", fmtSignedPctTable(s.CAGR*100, format == render.FormatTable && useColor(w))},
+	}...)
+	if s.Spark != "" {
+		rows = append(rows, []string{"Spark", s.Spark})
 	}
 	printSimpleTable(w, []string{"METRIC", "VALUE"}, func(add func(...string)) {
 		for _, row := range rows {
@@ -375,12 +1146,14 @@ func renderSummarySingle(w io.Writer, format string, s analyze.Summary) error {
 	return nil
 }
 
-func renderSummaryBatch(w io.Writer, format string, summaries []analyze.Summary) error {
+func renderSummaryBatch(w io.Writer, format string, summaries []analyze.Summary, robust bool) error {
 	switch format {
 	case "json":
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		return enc.Encode(summaries)
+	case render.FormatYAML:
+		return render.EncodeYAML(w, summaries)
 	case "jsonl":
 		enc := json.NewEncoder(w)
 		for _, s := range summaries {
@@ -390,6 +1163,15 @@ func renderSummaryBatch(w io.Writer, format string, summaries []analyze.Summary)
 		}
 		return nil
 	default:
+		color := format == render.FormatTable && useColor(w)
+		if len(summaries) > 0 && summaries[0].Period != "" {
+			renderSummaryPeriods(w, summaries, robust)
+			if footer := summaryCitationFooter(summaries); footer != "" {
+				fmt.Fprintln(w)
+				fmt.Fprintln(w, footer)
+			}
+			return nil
+		}
 		sorted := append([]analyze.Summary(nil), summaries...)
 		sort.Slice(sorted, func(i, j int) bool {
 			if sorted[i].SeriesID != sorted[j].SeriesID {
@@ -405,9 +1187,14 @@ func renderSummaryBatch(w io.Writer, format string, summaries []analyze.Summary)
 			}
 		}
 		if isWindowBatch {
-			printSimpleTable(w, []string{"SERIES", "START_DATE", "END_DATE", "COUNT", "MISS", "MEAN", "STD", "MIN", "MEDIAN", "MAX", "CHANGE_PCT"}, func(add func(...string)) {
+			headers := []string{"SERIES", "START_DATE", "END_DATE", "COUNT", "MISS", "MEAN", "STD", "MIN"}
+			headers = append(append(headers, percentileSummaryHeaders(sorted)...), "MAX")
+			if robust {
+				headers = append(headers, robustSummaryHeaders...)
+			}
+			printSimpleTable(w, append(headers, "CHANGE_PCT"), func(add func(...string)) {
 				for _, s := range sorted {
-					add(
+					row := []string{
 						s.SeriesID,
 						s.StartDate,
 						s.EndDate,
@@ -416,26 +1203,54 @@ func renderSummaryBatch(w io.Writer, format string, summaries []analyze.Summary)
 						fmtFloatTable(s.Mean, 4),
 						fmtFloatTable(s.Std, 4),
 						fmtFloatTable(s.Min, 4),
-						fmtFloatTable(s.Median, 4),
-						fmtFloatTable(s.Max, 4),
-						fmtPctTable(s.ChangePct),
-					)
+					}
+					row = append(append(row, percentileSummaryCells(s)...), fmtFloatTable(s.Max, 4))
+					if robust {
+						row = append(row, robustSummaryCells(s)...)
+					}
+					add(append(row, fmtSignedPctTable(s.ChangePct, color))...)
 				}
 			})
 		} else {
-			printSimpleTable(w, []string{"SERIES", "COUNT", "MISS", "MEAN", "STD", "MIN", "MEDIAN", "MAX", "CHANGE_PCT"}, func(add func(...string)) {
+			headers := []string{"SERIES", "FREQ", "COUNT", "MISS", "MEAN", "STD", "MIN"}
+			headers = append(append(headers, percentileSummaryHeaders(sorted)...), "MAX")
+			if robust {
+				headers = append(headers, robustSummaryHeaders...)
+			}
+			headers = append(headers, "CHANGE_PCT", "CAGR_PCT")
+			withSpark := false
+			for _, s := range sorted {
+				if s.Spark != "" {
+					withSpark = true
+					break
+				}
+			}
+			if withSpark {
+				headers = append(headers, "SPARK")
+			}
+			printSimpleTable(w, headers, func(add func(...string)) {
 				for _, s := range sorted {
-					add(
+					row := []string{
 						s.SeriesID,
+						s.Frequency,
 						fmt.Sprintf("%d", s.Count),
 						fmtMissCompact(s.MissingCount, s.MissingPct),
 						fmtFloatTable(s.Mean, 4),
 						fmtFloatTable(s.Std, 4),
 						fmtFloatTable(s.Min, 4),
-						fmtFloatTable(s.Median, 4),
-						fmtFloatTable(s.Max, 4),
-						fmtPctTable(s.ChangePct),
+					}
+					row = append(append(row, percentileSummaryCells(s)...), fmtFloatTable(s.Max, 4))
+					if robust {
+						row = append(row, robustSummaryCells(s)...)
+					}
+					row = append(row,
+						fmtSignedPctTable(s.ChangePct, color),
+						fmtSignedPctTable(s.CAGR*100, color),
 					)
+					if withSpark {
+						row = append(row, s.Spark)
+					}
+					add(row...)
 				}
 			})
 		}
@@ -447,6 +1262,66 @@ func renderSummaryBatch(w io.Writer, format string, summaries []analyze.Summary)
 	}
 }
 
+// renderSummaryPeriods prints --by-period summaries one row per period, in
+// the calendar order SummarizeGrouped returns them.
+func renderSummaryPeriods(w io.Writer, summaries []analyze.Summary, robust bool) {
+	headers := []string{"SERIES", "PERIOD", "COUNT", "MISS", "MEAN", "STD", "MIN"}
+	headers = append(append(headers, percentileSummaryHeaders(summaries)...), "MAX")
+	if robust {
+		headers = append(headers, robustSummaryHeaders...)
+	}
+	printSimpleTable(w, headers, func(add func(...string)) {
+		for _, s := range summaries {
+			row := []string{
+				s.SeriesID,
+				s.Period,
+				fmt.Sprintf("%d", s.Count),
+				fmtMissCompact(s.MissingCount, s.MissingPct),
+				fmtFloatTable(s.Mean, 4),
+				fmtFloatTable(s.Std, 4),
+				fmtFloatTable(s.Min, 4),
+			}
+			row = append(append(row, percentileSummaryCells(s)...), fmtFloatTable(s.Max, 4))
+			if robust {
+				row = append(row, robustSummaryCells(s)...)
+			}
+			add(row...)
+		}
+	})
+}
+
+// percentileSummaryHeaders label the percentile columns of a batch summary
+// table: MEDIAN by default, or one column per requested percentile. Every
+// summary in a batch shares the same cut points.
+func percentileSummaryHeaders(summaries []analyze.Summary) []string {
+	if len(summaries) == 0 || len(summaries[0].Percentiles) == 0 {
+		return []string{"MEDIAN"}
+	}
+	headers := make([]string, len(summaries[0].Percentiles))
+	for i, p := range summaries[0].Percentiles {
+		headers[i] = p.Label()
+	}
+	return headers
+}
+
+func percentileSummaryCells(s analyze.Summary) []string {
+	if len(s.Percentiles) == 0 {
+		return []string{fmtFloatTable(s.Median, 4)}
+	}
+	cells := make([]string, len(s.Percentiles))
+	for i, p := range s.Percentiles {
+		cells[i] = fmtFloatTable(p.Value, 4)
+	}
+	return cells
+}
+
+// robustSummaryHeaders label the columns --robust adds to batch summary tables.
+var robustSummaryHeaders = []string{"MAD", "IQR", "TRIM_MEAN"}
+
+func robustSummaryCells(s analyze.Summary) []string {
+	return []string{fmtFloatTable(s.MAD, 4), fmtFloatTable(s.IQR, 4), fmtFloatTable(s.TrimmedMean, 4)}
+}
+
 func fmtStat(v float64) string {
 	if math.IsNaN(v) {
 		return "."
@@ -487,6 +1362,15 @@ func fmtPctTable(v float64) string {
 	return fmtFloatTable(v, 2) + "%"
 }
 
+// fmtSignedPctTable is fmtPctTable colored green or red by sign when color
+// is set.
+func fmtSignedPctTable(v float64, color bool) string {
+	if !color {
+		return fmtPctTable(v)
+	}
+	return chart.ColorSigned(fmtPctTable(v), v)
+}
+
 func addThousandsSeparators(s string) string {
 	if len(s) <= 3 {
 		return s
@@ -505,6 +1389,13 @@ func addThousandsSeparators(s string) string {
 	return b.String()
 }
 
+func fmtConfidenceTest(c *analyze.TrendConfidence) string {
+	if c == nil {
+		return "n/a"
+	}
+	return c.Test
+}
+
 func fmtConfidence(c *analyze.TrendConfidence, f func(*analyze.TrendConfidence) float64, decimals int) string {
 	if c == nil {
 		return "n/a"
@@ -512,6 +1403,16 @@ func fmtConfidence(c *analyze.TrendConfidence, f func(*analyze.TrendConfidence)
 	return fmtFloatTable(f(c), decimals)
 }
 
+// summarySpark renders the sparkline for analyze summary --spark. A series
+// with no valid values gets an empty spark rather than failing the summary.
+func summarySpark(obs []model.Observation) string {
+	spark, err := chart.Sparkline(obs, chart.DefaultSparkWidth)
+	if err != nil {
+		return ""
+	}
+	return spark
+}
+
 func fmtMissCompact(count int, pct float64) string {
 	return fmt.Sprintf("%d|%.1f%%", count, pct)
 }
@@ -528,12 +1429,36 @@ func applyProvenanceToTrend(t *analyze.TrendResult, p pipeline.Provenance) {
 	t.SourceNames = append([]string(nil), p.SourceNames...)
 }
 
+func applyProvenanceToPolyTrend(t *analyze.PolyTrendResult, p pipeline.Provenance) {
+	t.CitationText = p.CitationText
+	t.SourceName = p.SourceName
+	t.SourceNames = append([]string(nil), p.SourceNames...)
+}
+
 func applyProvenanceToRegime(r *analyze.RegimeResult, p pipeline.Provenance) {
 	r.CitationText = p.CitationText
 	r.SourceName = p.SourceName
 	r.SourceNames = append([]string(nil), p.SourceNames...)
 }
 
+func applyProvenanceToSubseries(r *analyze.SubseriesResult, p pipeline.Provenance) {
+	r.CitationText = p.CitationText
+	r.SourceName = p.SourceName
+	r.SourceNames = append([]string(nil), p.SourceNames...)
+}
+
+func applyProvenanceToHalfLife(r *analyze.HalfLifeResult, p pipeline.Provenance) {
+	r.CitationText = p.CitationText
+	r.SourceName = p.SourceName
+	r.SourceNames = append([]string(nil), p.SourceNames...)
+}
+
+func applyProvenanceToDecompose(r *analyze.DecomposeResult, p pipeline.Provenance) {
+	r.CitationText = p.CitationText
+	r.SourceName = p.SourceName
+	r.SourceNames = append([]string(nil), p.SourceNames...)
+}
+
 func applyProvenanceToCompare(c *analyze.CompareResult, lhs, rhs pipeline.Provenance) {
 	c.CitationText = lhs.CitationText
 	c.SourceName = lhs.SourceName
@@ -543,6 +1468,69 @@ func applyProvenanceToCompare(c *analyze.CompareResult, lhs, rhs pipeline.Proven
 	c.AgainstSourceNames = append([]string(nil), rhs.SourceNames...)
 }
 
+// readSeriesPair reads a multi-series JSONL stream and picks the two series a
+// pairwise verb works on: the one named by otherID (required, reported as the
+// otherFlag series), and the one named by primaryID, defaulting to the first
+// other series in the stream.
+func readSeriesPair(r io.Reader, verb, primaryID, otherFlag, otherID string) (primary, other pipeline.ObservationGroup, err error) {
+	groups, err := pipeline.ReadObservationGroups(r)
+	if err != nil {
+		return primary, other, err
+	}
+	byID := map[string]pipeline.ObservationGroup{}
+	for _, g := range groups {
+		byID[g.SeriesID] = g
+	}
+	otherID = strings.ToUpper(strings.TrimSpace(otherID))
+	other, ok := byID[otherID]
+	if !ok {
+		return primary, other, fmt.Errorf("%s series %q not found in input stream", otherFlag, otherID)
+	}
+	primaryID = strings.ToUpper(strings.TrimSpace(primaryID))
+	if primaryID == "" {
+		for _, g := range groups {
+			if g.SeriesID != otherID {
+				primaryID = g.SeriesID
+				break
+			}
+		}
+	}
+	if primaryID == "" {
+		return primary, other, fmt.Errorf("%s requires two series in input stream", verb)
+	}
+	primary, ok = byID[primaryID]
+	if !ok {
+		return primary, other, fmt.Errorf("series %q not found in input stream", primaryID)
+	}
+	return primary, other, nil
+}
+
+func applyProvenanceToXCorr(r *analyze.XCorrResult, primary, with pipeline.Provenance) {
+	r.CitationText = primary.CitationText
+	r.SourceName = primary.SourceName
+	r.SourceNames = append([]string(nil), primary.SourceNames...)
+	r.WithCitationText = with.CitationText
+	r.WithSourceName = with.SourceName
+	r.WithSourceNames = append([]string(nil), with.SourceNames...)
+}
+
+// xcorrLeaderText describes the best lag of r in words.
+func xcorrLeaderText(r analyze.XCorrResult) string {
+	leader := r.Leader()
+	if leader == "" {
+		return "coincident"
+	}
+	follower := r.SeriesID
+	if leader == r.SeriesID {
+		follower = r.WithSeriesID
+	}
+	unit := "periods"
+	if r.LeadPeriods() == 1 {
+		unit = "period"
+	}
+	return fmt.Sprintf("%s leads %s by %d %s", leader, follower, r.LeadPeriods(), unit)
+}
+
 func summaryCitationFooter(summaries []analyze.Summary) string {
 	seen := map[string]struct{}{}
 	type row struct {
@@ -6,9 +6,20 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"math"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/analyze"
+	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/pipeline"
+	"github.com/derickschaefer/reserve/internal/store"
 )
 
 func TestAnalyzeSummaryBySeriesJSON(t *testing.T) {
@@ -88,6 +99,81 @@ func TestAnalyzeSummarySingleSeriesJSONL(t *testing.T) {
 	}
 }
 
+func TestAnalyzeSummaryRobustTable(t *testing.T) {
+	input := strings.Join([]string{
+		`{"series_id":"GDP","date":"2020-01-01","value":1,"value_raw":"1"}`,
+		`{"series_id":"GDP","date":"2020-04-01","value":2,"value_raw":"2"}`,
+		`{"series_id":"GDP","date":"2020-07-01","value":9,"value_raw":"9"}`,
+	}, "\n") + "\n"
+
+	out, err := runAnalyzeSummaryForTest(t, input, false, "table")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	if strings.Contains(out, "MAD") {
+		t.Fatalf("default table should stay compact:\n%s", out)
+	}
+
+	orig := analyzeSummaryRobust
+	analyzeSummaryRobust = true
+	t.Cleanup(func() { analyzeSummaryRobust = orig })
+	out, err = runAnalyzeSummaryForTest(t, input, false, "table")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	for _, token := range []string{"MAD", "IQR", "Trimmed Mean (10%)", "4.0000"} {
+		if !strings.Contains(out, token) {
+			t.Fatalf("robust table output missing %q:\n%s", token, out)
+		}
+	}
+}
+
+func TestAnalyzeSummaryPercentiles(t *testing.T) {
+	input := strings.Join([]string{
+		`{"series_id":"GDP","date":"2020-01-01","value":0,"value_raw":"0"}`,
+		`{"series_id":"GDP","date":"2020-04-01","value":10,"value_raw":"10"}`,
+		`{"series_id":"GDP","date":"2020-07-01","value":20,"value_raw":"20"}`,
+	}, "\n") + "\n"
+
+	orig := analyzeSummaryPercentiles
+	t.Cleanup(func() { analyzeSummaryPercentiles = orig })
+
+	analyzeSummaryPercentiles = []float64{5, 95}
+	out, err := runAnalyzeSummaryForTest(t, input, false, "table")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	for _, token := range []string{"P5", "1.0000", "P95", "19.0000"} {
+		if !strings.Contains(out, token) {
+			t.Fatalf("percentile table output missing %q:\n%s", token, out)
+		}
+	}
+	if strings.Contains(out, "P25") || strings.Contains(out, "Median") {
+		t.Fatalf("requested percentiles should replace the quartile rows:\n%s", out)
+	}
+
+	out, err = runAnalyzeSummaryForTest(t, input, true, "table")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	if !strings.Contains(out, "P5") || strings.Contains(out, "MEDIAN") {
+		t.Fatalf("by-series table should have one column per percentile:\n%s", out)
+	}
+
+	out, err = runAnalyzeSummaryForTest(t, input, false, "jsonl")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	if !strings.Contains(out, `"percentiles":[{"p":5,"value":1},{"p":95,"value":19}]`) {
+		t.Fatalf("missing percentiles in JSON output: %s", out)
+	}
+
+	analyzeSummaryPercentiles = []float64{50, 101}
+	if _, err := runAnalyzeSummaryForTest(t, input, false, "table"); err == nil || !strings.Contains(err.Error(), "101") {
+		t.Fatalf("expected out-of-range error, got %v", err)
+	}
+}
+
 func TestAnalyzeSummarySingleSeriesTable(t *testing.T) {
 	input := strings.Join([]string{
 		`{"series_id":"GDP","date":"2020-01-01","value":21751.238,"value_raw":"21751.238"}`,
@@ -157,6 +243,60 @@ func TestAnalyzeTrendSingleSeriesTable(t *testing.T) {
 	}
 }
 
+func TestAnalyzeTrendPolyTable(t *testing.T) {
+	var rows []string
+	for i := 0; i < 8; i++ {
+		v := float64(i * i)
+		rows = append(rows, fmt.Sprintf(`{"series_id":"CIVPART","date":"%d-01-01","value":%g,"value_raw":"%g"}`, 2000+i, v, v))
+	}
+	tmp, err := os.CreateTemp(t.TempDir(), "analyze-trend-poly-stdin-*.jsonl")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	if _, err := tmp.WriteString(strings.Join(rows, "\n") + "\n"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	origStdin := os.Stdin
+	origFormat := globalFlags.Format
+	origMethod, origDegree := analyzeTrendMethod, analyzeTrendDegree
+	os.Stdin = tmp
+	globalFlags.Format = "table"
+	analyzeTrendMethod, analyzeTrendDegree = "poly", 2
+	t.Cleanup(func() {
+		os.Stdin = origStdin
+		globalFlags.Format = origFormat
+		analyzeTrendMethod, analyzeTrendDegree = origMethod, origDegree
+		_ = tmp.Close()
+	})
+
+	var buf bytes.Buffer
+	analyzeTrendCmd.SetOut(&buf)
+	analyzeTrendCmd.SetErr(&buf)
+	if err := analyzeTrendCmd.RunE(analyzeTrendCmd, nil); err != nil {
+		t.Fatalf("RunE: %v", err)
+	}
+	out := buf.String()
+	for _, token := range []string{"poly", "Degree", "Curvature", "convex", "Intercept", "Coef x^2", "R2"} {
+		if !strings.Contains(out, token) {
+			t.Fatalf("poly trend table output missing %q:\n%s", token, out)
+		}
+	}
+}
+
+func TestAnalyzeTrendPolyRejectsConfidence(t *testing.T) {
+	origMethod, origConfidence := analyzeTrendMethod, analyzeTrendConfidence
+	analyzeTrendMethod, analyzeTrendConfidence = "poly", true
+	t.Cleanup(func() { analyzeTrendMethod, analyzeTrendConfidence = origMethod, origConfidence })
+	err := analyzeTrendCmd.RunE(analyzeTrendCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--confidence") {
+		t.Fatalf("expected --confidence error, got %v", err)
+	}
+}
+
 func TestAnalyzeTrendConfidenceJSON(t *testing.T) {
 	input := strings.Join([]string{
 		`{"series_id":"GDP","date":"2020-01-01","value":1.0,"value_raw":"1.0"}`,
@@ -212,6 +352,74 @@ func TestAnalyzeTrendConfidenceJSON(t *testing.T) {
 	}
 }
 
+func TestAnalyzeTrendRollingJSONL(t *testing.T) {
+	input := strings.Join([]string{
+		`{"series_id":"GDP","date":"2020-01-01","value":1.0,"value_raw":"1.0"}`,
+		`{"series_id":"GDP","date":"2020-04-01","value":2.0,"value_raw":"2.0"}`,
+		`{"series_id":"GDP","date":"2020-07-01","value":3.0,"value_raw":"3.0"}`,
+		`{"series_id":"GDP","date":"2020-10-01","value":4.0,"value_raw":"4.0"}`,
+	}, "\n") + "\n"
+
+	tmp, err := os.CreateTemp(t.TempDir(), "analyze-trend-rolling-stdin-*.jsonl")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	if _, err := tmp.WriteString(input); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	origStdin := os.Stdin
+	origFormat := globalFlags.Format
+	origMethod := analyzeTrendMethod
+	origRolling := analyzeTrendRolling
+	os.Stdin = tmp
+	globalFlags.Format = "jsonl"
+	analyzeTrendMethod = "linear"
+	analyzeTrendRolling = 2
+	t.Cleanup(func() {
+		os.Stdin = origStdin
+		globalFlags.Format = origFormat
+		analyzeTrendMethod = origMethod
+		analyzeTrendRolling = origRolling
+		_ = tmp.Close()
+	})
+
+	var buf bytes.Buffer
+	analyzeTrendCmd.SetOut(&buf)
+	analyzeTrendCmd.SetErr(&buf)
+	if err := analyzeTrendCmd.RunE(analyzeTrendCmd, nil); err != nil {
+		t.Fatalf("RunE: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 JSONL rows, got %d:\n%s", len(lines), buf.String())
+	}
+	if want := `{"series_id":"GDP","date":"2020-01-01","slope_per_year":null,"r2":null,"direction":""}`; lines[0] != want {
+		t.Errorf("first row:\n got  %s\n want %s", lines[0], want)
+	}
+	var row map[string]any
+	if err := json.Unmarshal([]byte(lines[3]), &row); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if row["date"] != "2020-10-01" || row["direction"] != "up" {
+		t.Errorf("unexpected last row %v", row)
+	}
+}
+
+func TestAnalyzeTrendRollingRejectsConfidence(t *testing.T) {
+	origRolling, origConfidence := analyzeTrendRolling, analyzeTrendConfidence
+	analyzeTrendRolling, analyzeTrendConfidence = 12, true
+	t.Cleanup(func() { analyzeTrendRolling, analyzeTrendConfidence = origRolling, origConfidence })
+	err := analyzeTrendCmd.RunE(analyzeTrendCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--rolling") {
+		t.Fatalf("expected --rolling error, got %v", err)
+	}
+}
+
 func TestAnalyzeSummaryWindowJSONL(t *testing.T) {
 	input := strings.Join([]string{
 		`{"series_id":"GDP","date":"2020-01-01","value":1.0,"value_raw":"1.0"}`,
@@ -259,6 +467,69 @@ func TestAnalyzeSummaryWindowJSONL(t *testing.T) {
 	}
 }
 
+func TestAnalyzeSummaryStreamJSON(t *testing.T) {
+	input := strings.Join([]string{
+		`{"series_id":"GDP","date":"2020-01-01","value":1.0,"value_raw":"1.0","citation_text":"Source: BEA"}`,
+		`{"series_id":"GDP","date":"2020-04-01","value":2.0,"value_raw":"2.0","citation_text":"Source: BEA"}`,
+		`{"series_id":"GDP","date":"2020-07-01","value":null,"value_raw":"."}`,
+		`{"series_id":"GDP","date":"2020-10-01","value":4.0,"value_raw":"4.0"}`,
+	}, "\n") + "\n"
+
+	tmp, err := os.CreateTemp(t.TempDir(), "analyze-summary-stream-stdin-*.jsonl")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	if _, err := tmp.WriteString(input); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	origStdin := os.Stdin
+	origFormat := globalFlags.Format
+	origStream := analyzeSummaryStream
+	os.Stdin = tmp
+	globalFlags.Format = "json"
+	analyzeSummaryStream = true
+	t.Cleanup(func() {
+		os.Stdin = origStdin
+		globalFlags.Format = origFormat
+		analyzeSummaryStream = origStream
+		_ = tmp.Close()
+	})
+
+	var buf bytes.Buffer
+	analyzeSummaryCmd.SetOut(&buf)
+	analyzeSummaryCmd.SetErr(&buf)
+	if err := analyzeSummaryCmd.RunE(analyzeSummaryCmd, nil); err != nil {
+		t.Fatalf("RunE: %v", err)
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+	}
+	if payload["series_id"] != "GDP" || payload["count"] != 4.0 || payload["missing_count"] != 1.0 {
+		t.Errorf("unexpected counts: %v", payload)
+	}
+	if payload["mean"] != 7.0/3 || payload["citation_text"] != "Source: BEA" {
+		t.Errorf("unexpected mean or citation: %v", payload)
+	}
+	if payload["mad"] != nil {
+		t.Errorf("mad should be null when streaming, got %v", payload["mad"])
+	}
+}
+
+func TestAnalyzeSummaryStreamRejectsBySeries(t *testing.T) {
+	origStream, origBySeries := analyzeSummaryStream, analyzeSummaryBySeries
+	analyzeSummaryStream, analyzeSummaryBySeries = true, true
+	t.Cleanup(func() { analyzeSummaryStream, analyzeSummaryBySeries = origStream, origBySeries })
+	err := analyzeSummaryCmd.RunE(analyzeSummaryCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--stream") {
+		t.Fatalf("expected --stream error, got %v", err)
+	}
+}
+
 func TestAnalyzeSummaryWindowTableUsesCompactMissColumn(t *testing.T) {
 	input := strings.Join([]string{
 		`{"series_id":"GDP","date":"2020-01-01","value":1.0,"value_raw":"1.0"}`,
@@ -367,6 +638,126 @@ func TestAnalyzeCompareJSON(t *testing.T) {
 	}
 }
 
+func TestAnalyzeXCorrTable(t *testing.T) {
+	// B runs two months ahead of A.
+	vals := []float64{1, 4, 2, 8, 5, 7, 3, 9, 6, 2, 8, 4}
+	var lines []string
+	for i := 0; i < 10; i++ {
+		date := time.Date(2020, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		lines = append(lines,
+			fmt.Sprintf(`{"series_id":"A","date":"%s","value":%g,"value_raw":"%g"}`, date, vals[i], vals[i]),
+			fmt.Sprintf(`{"series_id":"B","date":"%s","value":%g,"value_raw":"%g"}`, date, vals[i+2], vals[i+2]))
+	}
+	tmp, err := os.CreateTemp(t.TempDir(), "analyze-xcorr-stdin-*.jsonl")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	if _, err := tmp.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	origStdin := os.Stdin
+	origFormat := globalFlags.Format
+	origWith := analyzeXCorrWith
+	origSeries := analyzeXCorrSeries
+	origMaxLag := analyzeXCorrMaxLag
+	os.Stdin = tmp
+	globalFlags.Format = "table"
+	analyzeXCorrWith = "b"
+	analyzeXCorrSeries = ""
+	analyzeXCorrMaxLag = 3
+	t.Cleanup(func() {
+		os.Stdin = origStdin
+		globalFlags.Format = origFormat
+		analyzeXCorrWith = origWith
+		analyzeXCorrSeries = origSeries
+		analyzeXCorrMaxLag = origMaxLag
+		_ = tmp.Close()
+	})
+
+	var buf bytes.Buffer
+	analyzeXCorrCmd.SetOut(&buf)
+	analyzeXCorrCmd.SetErr(&buf)
+	if err := analyzeXCorrCmd.RunE(analyzeXCorrCmd, nil); err != nil {
+		t.Fatalf("RunE: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"B leads A by 2 periods", "+2", "-3", "1.0000"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in xcorr output:\n%s", want, out)
+		}
+	}
+}
+
+func TestAnalyzeRollCorrEmitsJSONL(t *testing.T) {
+	var lines []string
+	for i := 0; i < 5; i++ {
+		date := time.Date(2020, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		lines = append(lines,
+			fmt.Sprintf(`{"series_id":"A","date":"%s","value":%d,"value_raw":"%d","citation_text":"Source: Alpha Bureau via FRED"}`, date, i, i),
+			fmt.Sprintf(`{"series_id":"B","date":"%s","value":%d,"value_raw":"%d","citation_text":"Source: Beta Board via FRED"}`, date, 10-i, 10-i))
+	}
+	tmp, err := os.CreateTemp(t.TempDir(), "analyze-rollcorr-stdin-*.jsonl")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	if _, err := tmp.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+	outPath := filepath.Join(t.TempDir(), "rollcorr.jsonl")
+
+	origStdin := os.Stdin
+	origFormat, origOut := globalFlags.Format, globalFlags.Out
+	origWith, origSeries, origWindow := analyzeRollCorrWith, analyzeRollCorrSeries, analyzeRollCorrWindow
+	os.Stdin = tmp
+	globalFlags.Format, globalFlags.Out = "jsonl", outPath
+	analyzeRollCorrWith, analyzeRollCorrSeries, analyzeRollCorrWindow = "B", "", 3
+	t.Cleanup(func() {
+		os.Stdin = origStdin
+		globalFlags.Format, globalFlags.Out = origFormat, origOut
+		analyzeRollCorrWith, analyzeRollCorrSeries, analyzeRollCorrWindow = origWith, origSeries, origWindow
+		_ = tmp.Close()
+	})
+
+	if err := analyzeRollCorrCmd.RunE(analyzeRollCorrCmd, nil); err != nil {
+		t.Fatalf("RunE: %v", err)
+	}
+	raw, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	rows := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if len(rows) != 5 {
+		t.Fatalf("expected 5 JSONL rows, got %d:\n%s", len(rows), raw)
+	}
+	var first, last struct {
+		SeriesID     string   `json:"series_id"`
+		Value        *float64 `json:"value"`
+		CitationText string   `json:"citation_text"`
+	}
+	if err := json.Unmarshal([]byte(rows[0]), &first); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if err := json.Unmarshal([]byte(rows[4]), &last); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if first.SeriesID != "A" || first.Value != nil {
+		t.Errorf("first row should be a null A observation, got %+v", first)
+	}
+	if last.Value == nil || math.Abs(*last.Value+1) > 1e-9 {
+		t.Errorf("last row should be -1, got %v", last.Value)
+	}
+	if last.CitationText != "Source: Alpha Bureau via FRED; Source: Beta Board via FRED" {
+		t.Errorf("unexpected citation %q", last.CitationText)
+	}
+}
+
 func TestAnalyzeCompareTableShowsSourcesBySeriesFooter(t *testing.T) {
 	input := strings.Join([]string{
 		`{"series_id":"A","date":"2020-01-01","value":1.0,"value_raw":"1.0","citation_text":"Source: Alpha Bureau via FRED"}`,
@@ -541,6 +932,68 @@ func TestAnalyzeSummaryTablePrintsCitationFooter(t *testing.T) {
 	}
 }
 
+func TestAnalyzeSummaryBySeriesSparkColumn(t *testing.T) {
+	input := strings.Join([]string{
+		`{"series_id":"FEDFUNDS","date":"2025-01-01","value":4.25,"value_raw":"4.25"}`,
+		`{"series_id":"UNRATE","date":"2025-01-01","value":4.0,"value_raw":"4.0"}`,
+		`{"series_id":"FEDFUNDS","date":"2025-02-01","value":4.5,"value_raw":"4.5"}`,
+		`{"series_id":"UNRATE","date":"2025-02-01","value":4.1,"value_raw":"4.1"}`,
+	}, "\n") + "\n"
+
+	origSpark := analyzeSummarySpark
+	analyzeSummarySpark = true
+	t.Cleanup(func() { analyzeSummarySpark = origSpark })
+
+	out, err := runAnalyzeSummaryForTest(t, input, true, "table")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	if !strings.Contains(out, "SPARK") || !strings.Contains(out, "▁█") {
+		t.Fatalf("expected SPARK column with a rising sparkline:\n%s", out)
+	}
+}
+
+func TestAnalyzeSummaryColorsChangePctBySign(t *testing.T) {
+	input := strings.Join([]string{
+		`{"series_id":"FEDFUNDS","date":"2025-01-01","value":4.0,"value_raw":"4.0"}`,
+		`{"series_id":"UNRATE","date":"2025-01-01","value":4.0,"value_raw":"4.0"}`,
+		`{"series_id":"FEDFUNDS","date":"2025-02-01","value":5.0,"value_raw":"5.0"}`,
+		`{"series_id":"UNRATE","date":"2025-02-01","value":3.0,"value_raw":"3.0"}`,
+	}, "\n") + "\n"
+
+	origColor := globalFlags.Color
+	t.Cleanup(func() { globalFlags.Color = origColor })
+
+	globalFlags.Color = "never"
+	plain, err := runAnalyzeSummaryForTest(t, input, true, "table")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	if strings.Contains(plain, "\x1b[") {
+		t.Fatalf("--color never should not emit escapes:\n%q", plain)
+	}
+
+	globalFlags.Color = "always"
+	colored, err := runAnalyzeSummaryForTest(t, input, true, "table")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	if !strings.Contains(colored, "\x1b[32m25.00%") || !strings.Contains(colored, "\x1b[31m-25.00%") {
+		t.Fatalf("expected green rise and red fall:\n%q", colored)
+	}
+	if got := regexp.MustCompile("\x1b\\[[0-9;]*m").ReplaceAllString(colored, ""); got != plain {
+		t.Fatalf("table borders should ignore escape bytes:\n%s\nwant:\n%s", got, plain)
+	}
+
+	colored, err = runAnalyzeSummaryForTest(t, input, true, "csv")
+	if err != nil {
+		t.Fatalf("runAnalyzeSummaryForTest: %v", err)
+	}
+	if strings.Contains(colored, "\x1b[") {
+		t.Fatalf("non-table formats should stay plain:\n%q", colored)
+	}
+}
+
 func runAnalyzeSummaryForTest(t *testing.T, input string, bySeries bool, format string) (string, error) {
 	t.Helper()
 
@@ -574,3 +1027,223 @@ func runAnalyzeSummaryForTest(t *testing.T, input string, bySeries bool, format
 	err = analyzeSummaryCmd.RunE(analyzeSummaryCmd, nil)
 	return buf.String(), err
 }
+
+func TestAnalyzeTrendCacheResultsReusesStoredResult(t *testing.T) {
+	dir := t.TempDir()
+	isolateCacheCommandConfig(t, dir)
+	dbPath := filepath.Join(dir, "reserve.db")
+	if err := config.WriteFile(filepath.Join(dir, "config.json"), config.File{DBPath: dbPath}); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	orig, _ := os.Getwd()
+	_ = os.Chdir(dir)
+
+	input := strings.Join([]string{
+		`{"series_id":"GDP","date":"2020-01-01","value":1.0,"value_raw":"1.0"}`,
+		`{"series_id":"GDP","date":"2020-04-01","value":2.0,"value_raw":"2.0"}`,
+		`{"series_id":"GDP","date":"2020-07-01","value":3.0,"value_raw":"3.0"}`,
+	}, "\n") + "\n"
+
+	origStdin := os.Stdin
+	origFormat := globalFlags.Format
+	origMethod := analyzeTrendMethod
+	globalFlags.Format = "json"
+	analyzeTrendMethod = "linear"
+	analyzeCacheResults = true
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+		os.Stdin = origStdin
+		globalFlags.Format = origFormat
+		analyzeTrendMethod = origMethod
+		analyzeCacheResults = false
+	})
+
+	run := func() map[string]any {
+		t.Helper()
+		tmp, err := os.CreateTemp(t.TempDir(), "analyze-trend-cache-stdin-*.jsonl")
+		if err != nil {
+			t.Fatalf("CreateTemp: %v", err)
+		}
+		defer tmp.Close()
+		_, _ = tmp.WriteString(input)
+		_, _ = tmp.Seek(0, 0)
+		os.Stdin = tmp
+
+		var buf bytes.Buffer
+		analyzeTrendCmd.SetOut(&buf)
+		analyzeTrendCmd.SetErr(&buf)
+		if err := analyzeTrendCmd.RunE(analyzeTrendCmd, nil); err != nil {
+			t.Fatalf("RunE: %v", err)
+		}
+		var payload map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+			t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+		}
+		return payload
+	}
+
+	first := run()
+	if first["series_id"] != "GDP" {
+		t.Fatalf("unexpected first result: %v", first)
+	}
+
+	// Tamper with the stored result so a second run proves it was reused.
+	_, obs, err := pipeline.ReadObservations(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadObservations: %v", err)
+	}
+	key := analyzeResultKey("trend", "GDP", obs, "method:linear", "confidence:false")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	cached, found, err := s.GetResult(key)
+	if err != nil || !found {
+		_ = s.Close()
+		t.Fatalf("expected stored result under %q: found=%v err=%v", key, found, err)
+	}
+	first["direction"] = "cached"
+	cached.Data = first
+	if err := s.PutResult(key, cached); err != nil {
+		t.Fatalf("PutResult: %v", err)
+	}
+	_ = s.Close()
+
+	if second := run(); second["direction"] != "cached" {
+		t.Fatalf("expected stored result to be reused, got %v", second)
+	}
+}
+
+func TestAnalyzeLaspeyresLoadsComponentsFromStore(t *testing.T) {
+	dir := t.TempDir()
+	isolateCacheCommandConfig(t, dir)
+	dbPath := filepath.Join(dir, "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for _, id := range []string{"FOOD", "ENERGY"} {
+		if err := s.PutObs(store.ObsKey(id, "", "", "", "", ""), monthlySeries(id, "2020-01-01", 3)); err != nil {
+			t.Fatalf("PutObs %s: %v", id, err)
+		}
+		if err := s.PutSeriesMeta(model.SeriesMeta{
+			ID:                id,
+			CopyrightStatus:   "public_domain_citation_requested",
+			CitationText:      "Source: U.S. Bureau of Labor Statistics via FRED",
+			LastRightsCheckAt: time.Now().UTC(),
+		}); err != nil {
+			t.Fatalf("PutSeriesMeta %s: %v", id, err)
+		}
+	}
+	_ = s.Close()
+	if err := config.WriteFile(filepath.Join(dir, "config.json"), config.File{DBPath: dbPath}); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	orig, _ := os.Getwd()
+	_ = os.Chdir(dir)
+
+	origFormat := globalFlags.Format
+	globalFlags.Format = "json"
+	analyzeLaspeyresBase = "2020-01-01"
+	analyzeLaspeyresComponents = "FOOD, ENERGY"
+	analyzeLaspeyresWeights = "1,3"
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+		globalFlags.Format = origFormat
+		analyzeLaspeyresBase, analyzeLaspeyresComponents, analyzeLaspeyresWeights = "", "", ""
+	})
+
+	var buf bytes.Buffer
+	analyzeLaspeyresCmd.SetOut(&buf)
+	if err := analyzeLaspeyresCmd.RunE(analyzeLaspeyresCmd, nil); err != nil {
+		t.Fatalf("RunE: %v", err)
+	}
+	var res analyze.IndexResult
+	if err := json.Unmarshal(buf.Bytes(), &res); err != nil {
+		t.Fatalf("Unmarshal: %v\n%s", err, buf.String())
+	}
+	// Both components triple (1 → 3), so the composite ends at 300.
+	if res.EndDate != "2020-03-01" || res.CompositeChange != 200 {
+		t.Fatalf("unexpected composite: end=%s change=%v", res.EndDate, res.CompositeChange)
+	}
+	if len(res.Components) != 2 || res.Components[0].Contribution != 50 || res.Components[1].Contribution != 150 {
+		t.Fatalf("unexpected contributions: %+v", res.Components)
+	}
+
+	analyzeLaspeyresWeights = "1"
+	if err := analyzeLaspeyresCmd.RunE(analyzeLaspeyresCmd, nil); err == nil || !strings.Contains(err.Error(), "--weights") {
+		t.Fatalf("expected weight count error, got %v", err)
+	}
+}
+
+func TestAnalyzeDecomposeJSON(t *testing.T) {
+	var rows []string
+	for i := 0; i < 8; i++ {
+		v := 10 + float64(i)
+		if i%2 == 1 {
+			v += 2
+		}
+		rows = append(rows, fmt.Sprintf(`{"series_id":"TEST","date":"2020-%02d-01","value":%g,"value_raw":"%g"}`, i+1, v, v))
+	}
+	tmp, err := os.CreateTemp(t.TempDir(), "analyze-decompose-stdin-*.jsonl")
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	if _, err := tmp.WriteString(strings.Join(rows, "\n") + "\n"); err != nil {
+		t.Fatalf("WriteString: %v", err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	origStdin := os.Stdin
+	origFormat := globalFlags.Format
+	origPeriod, origModel, origEmit := analyzeDecomposePeriod, analyzeDecomposeModel, analyzeDecomposeEmit
+	os.Stdin = tmp
+	globalFlags.Format = "json"
+	analyzeDecomposePeriod, analyzeDecomposeModel, analyzeDecomposeEmit = 2, "additive", ""
+	t.Cleanup(func() {
+		os.Stdin = origStdin
+		globalFlags.Format = origFormat
+		analyzeDecomposePeriod, analyzeDecomposeModel, analyzeDecomposeEmit = origPeriod, origModel, origEmit
+		_ = tmp.Close()
+	})
+
+	var buf bytes.Buffer
+	analyzeDecomposeCmd.SetOut(&buf)
+	analyzeDecomposeCmd.SetErr(&buf)
+	if err := analyzeDecomposeCmd.RunE(analyzeDecomposeCmd, nil); err != nil {
+		t.Fatalf("RunE: %v", err)
+	}
+	var got struct {
+		SeriesID   string `json:"series_id"`
+		Period     int    `json:"period"`
+		Components []struct {
+			Date     string   `json:"date"`
+			Trend    *float64 `json:"trend"`
+			Seasonal *float64 `json:"seasonal"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, buf.String())
+	}
+	if got.SeriesID != "TEST" || got.Period != 2 || len(got.Components) != 8 {
+		t.Fatalf("unexpected result header: %+v", got)
+	}
+	if got.Components[0].Trend != nil {
+		t.Errorf("first trend value should be null, got %v", *got.Components[0].Trend)
+	}
+	if s := got.Components[1].Seasonal; s == nil || math.Abs(*s-1) > 1e-9 {
+		t.Errorf("expected seasonal +1 for odd months, got %v", s)
+	}
+}
+
+func TestAnalyzeDecomposeRejectsUnknownEmit(t *testing.T) {
+	orig := analyzeDecomposeEmit
+	analyzeDecomposeEmit = "cycle"
+	t.Cleanup(func() { analyzeDecomposeEmit = orig })
+	err := analyzeDecomposeCmd.RunE(analyzeDecomposeCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--emit") {
+		t.Fatalf("expected --emit error, got %v", err)
+	}
+}
@@ -4,15 +4,22 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/compliance"
+	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/store"
 	"github.com/spf13/cobra"
 )
 
@@ -23,7 +30,12 @@ var cacheCmd = &cobra.Command{
 
 The local store accumulates series data fetched with 'reserve fetch --store'.
 It is an intentional data store, not a transparent cache — data persists until
-you explicitly clear it.`,
+you explicitly clear it.
+
+The inspecting commands (stats, path, inventory, export, verify) open the database
+read-only, so several can run at once. No command can open it while another
+process holds it for writing, such as a long 'fetch --store'; they wait up to
+2s for the lock and then report the database as unavailable.`,
 }
 
 // ─── cache stats ──────────────────────────────────────────────────────────────
@@ -33,7 +45,7 @@ var cacheStatsCmd = &cobra.Command{
 	Short:   "Show row counts and sizes for each bucket",
 	Example: `  reserve cache stats`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		deps, err := buildDeps()
+		deps, err := buildReadOnlyDeps()
 		if err != nil {
 			return err
 		}
@@ -71,7 +83,7 @@ var cachePathCmd = &cobra.Command{
 	Short:   "Print the active local database path",
 	Example: `  reserve cache path`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		deps, err := buildDeps()
+		deps, err := buildReadOnlyDeps()
 		if err != nil {
 			return err
 		}
@@ -113,12 +125,15 @@ type cacheInventoryOut struct {
 	Actions []string `json:"actions,omitempty"`
 }
 
+var cacheInventoryStale string
+
 var cacheInventoryCmd = &cobra.Command{
-	Use:     "inventory",
-	Short:   "Show per-series cache coverage and completeness",
-	Example: `  reserve cache inventory`,
+	Use:   "inventory",
+	Short: "Show per-series cache coverage and completeness",
+	Example: `  reserve cache inventory
+  reserve cache inventory --stale 7d`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		deps, err := buildDeps()
+		deps, err := buildReadOnlyDeps()
 		if err != nil {
 			return err
 		}
@@ -131,6 +146,15 @@ var cacheInventoryCmd = &cobra.Command{
 		if err != nil {
 			return fmt.Errorf("building cache inventory: %w", err)
 		}
+		if cacheInventoryStale != "" {
+			threshold, err := parseAge(cacheInventoryStale)
+			if err != nil {
+				return fmt.Errorf("--stale: %w", err)
+			}
+			if rows, err = filterStaleInventory(deps.Store, rows, threshold); err != nil {
+				return fmt.Errorf("checking cache age: %w", err)
+			}
+		}
 
 		sort.Slice(rows, func(i, j int) bool { return rows[i].SeriesID < rows[j].SeriesID })
 
@@ -162,6 +186,8 @@ var cacheInventoryCmd = &cobra.Command{
 			return writeCacheInventoryJSON(cmd.OutOrStdout(), out, false)
 		case "jsonl":
 			return writeCacheInventoryJSON(cmd.OutOrStdout(), out, true)
+		case render.FormatYAML:
+			return render.EncodeYAML(cmd.OutOrStdout(), out)
 		default:
 			return writeCacheInventoryTable(cmd.OutOrStdout(), out)
 		}
@@ -187,6 +213,7 @@ run 'reserve cache compact' after clearing.`,
 	Example: `  reserve cache clear --all
   reserve cache clear --bucket obs
   reserve cache clear --bucket series_meta
+  reserve cache clear --bucket results
   reserve cache clear --series GDP`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		selected := 0
@@ -200,7 +227,7 @@ run 'reserve cache compact' after clearing.`,
 			selected++
 		}
 		if selected == 0 {
-			return fmt.Errorf("specify exactly one of --all, --bucket <n>, or --series <id>\n\nBuckets: obs, series_meta")
+			return fmt.Errorf("specify exactly one of --all, --bucket <n>, or --series <id>\n\nBuckets: obs, series_meta, results")
 		}
 		if selected > 1 {
 			return fmt.Errorf("use only one of --all, --bucket, or --series")
@@ -250,6 +277,43 @@ run 'reserve cache compact' after clearing.`,
 	},
 }
 
+// ─── cache delete ─────────────────────────────────────────────────────────────
+
+var cacheDeleteCmd = &cobra.Command{
+	Use:   "delete <SERIES_ID>",
+	Short: "Evict one series and its metadata from the local store",
+	Long: `Delete every cached observation set for a series together with its stored
+metadata. Use this to drop a stale or discontinued series before re-fetching it.
+
+Unlike 'cache clear --series', metadata is removed as well.`,
+	Example:           `  reserve cache delete GDP`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		seriesID := strings.ToUpper(strings.TrimSpace(args[0]))
+		removed, err := deps.Store.DeleteSeries(seriesID)
+		if err != nil {
+			return fmt.Errorf("deleting %q: %w", seriesID, err)
+		}
+		if removed == 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "Nothing stored for %q.\n", seriesID)
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Deleted %q (%d stored record(s): observation sets and metadata)\n", seriesID, removed)
+		fmt.Fprintln(cmd.OutOrStdout(), "  Run 'reserve cache compact' to reclaim disk space.")
+		return nil
+	},
+}
+
 // ─── cache compact ────────────────────────────────────────────────────────────
 
 var cacheCompactCmd = &cobra.Command{
@@ -299,6 +363,282 @@ compaction completes.`,
 	},
 }
 
+// ─── cache warm ───────────────────────────────────────────────────────────────
+
+var (
+	cacheWarmStart string
+	cacheWarmEnd   string
+)
+
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm <SERIES_ID...>",
+	Short: "Fetch and store observations for a list of series",
+	Long: `Warm downloads observations for each series, all available history unless
+--start or --end narrows it, and stores them with their metadata in the local
+database, ready for offline analysis with --from cache. It is 'fetch series
+--store' with a summary of what was stored.
+
+Series run --concurrency at a time. A series that fails is reported as a
+warning and does not stop the others; the command fails only when no series
+could be stored.`,
+	Example: `  reserve cache warm UNRATE FEDFUNDS CPIAUCSL DGS10
+  reserve cache warm GDP GDPC1 --start 2000-01-01 --concurrency 2`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeSeriesIDs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.Config.Validate(); err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		ids := resolveSeriesIDs(deps, args)
+		return runCacheWarm(cmd.Context(), cmd.OutOrStdout(), deps, ids, fred.ObsOptions{Start: cacheWarmStart, End: cacheWarmEnd})
+	},
+}
+
+// runCacheWarm fetches ids into deps.Store under their canonical obs keys in
+// one write transaction, then prints the summary, one line per stored series,
+// and the warnings for those that failed.
+func runCacheWarm(ctx context.Context, w io.Writer, deps *app.Deps, ids []string, opts fred.ObsOptions) error {
+	var src obsSource = liveObsSource{}
+	if !deps.Config.NoCache {
+		src = revalidatingObsSource{}
+	}
+	datas, warnings, _ := batchGetObs(ctx, deps, ids, opts, src)
+
+	entries := make(map[string]model.SeriesData, len(datas))
+	keys := make([]string, 0, len(datas))
+	var metas []model.SeriesMeta
+	for _, data := range datas {
+		key := store.ObsKey(data.SeriesID, opts.Start, opts.End, "", "", "")
+		entries[key] = *data
+		keys = append(keys, key)
+		if data.Meta != nil {
+			metas = append(metas, *data.Meta)
+		}
+	}
+	multiSetWarnings, err := collectStoreWarnings(deps.Store, entries)
+	if err != nil {
+		return fmt.Errorf("checking existing cache entries: %w", err)
+	}
+	warnings = append(warnings, multiSetWarnings...)
+
+	if err := deps.Store.PutObsBatch(entries); err != nil {
+		return fmt.Errorf("storing observations: %w", err)
+	}
+	if len(metas) > 0 {
+		if err := deps.Store.PutSeriesMetaBatch(metas); err != nil {
+			// Non-fatal: obs are safely stored; warn and continue.
+			warnings = append(warnings, fmt.Sprintf("storing metadata: %v", err))
+		}
+	}
+	size, err := deps.Store.ObsBytes(keys)
+	if err != nil {
+		return fmt.Errorf("measuring stored observations: %w", err)
+	}
+
+	if !deps.Config.Quiet {
+		fmt.Fprintf(w, "✓ Warmed %d/%d series, %s stored\n", len(datas), len(ids), humanBytes(size))
+		for _, data := range datas {
+			fmt.Fprintf(w, "  ✓  %s  %d observations\n", data.SeriesID, len(data.Obs))
+		}
+		for _, warn := range warnings {
+			fmt.Fprintf(w, "  ⚠  %s\n", warn)
+		}
+	}
+	if len(datas) == 0 {
+		return fmt.Errorf("no series warmed")
+	}
+	return nil
+}
+
+// ─── cache verify ─────────────────────────────────────────────────────────────
+
+var cacheVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the database for corrupt or undecodable entries",
+	Long: `Verify runs bbolt's page-level consistency check, then decodes every key in
+every bucket into the type reserve stored there. It reports the schema version,
+the buckets found, how many keys were checked, and any key that failed to
+decode.
+
+A database can be left with damaged entries if the process is killed during a
+write. Verify exits with an error when any problem is found, so it can gate
+scripts.`,
+	Example: `  reserve cache verify
+  reserve cache verify --format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deps, err := buildReadOnlyDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		res, err := deps.Store.Verify()
+		if err != nil {
+			return fmt.Errorf("verifying store: %w", err)
+		}
+
+		out := cmd.OutOrStdout()
+		if format := resolveFormat(""); format == "json" || format == "jsonl" || format == render.FormatYAML {
+			report := struct {
+				store.VerifyResult
+				OK bool `json:"ok"`
+			}{res, res.OK()}
+			if format == render.FormatYAML {
+				if err := render.EncodeYAML(out, report); err != nil {
+					return err
+				}
+			} else {
+				enc := json.NewEncoder(out)
+				if format == "json" {
+					enc.SetIndent("", "  ")
+				}
+				if err := enc.Encode(report); err != nil {
+					return err
+				}
+			}
+		} else {
+			sort.Strings(res.Buckets)
+			fmt.Fprintf(out, "Database: %s\n", deps.Store.Path())
+			fmt.Fprintf(out, "Schema:   v%d\n", res.SchemaVersion)
+			fmt.Fprintf(out, "Buckets:  %s\n", strings.Join(res.Buckets, ", "))
+			fmt.Fprintf(out, "Keys:     %d checked\n", res.KeysChecked)
+			if res.OK() {
+				fmt.Fprintln(out, "✓ Verification passed")
+				return nil
+			}
+			for _, pe := range res.PageErrors {
+				fmt.Fprintf(out, "⚠  page check: %s\n", pe)
+			}
+			if len(res.CorruptKeys) > 0 {
+				fmt.Fprintln(out)
+				printSimpleTable(out, []string{"BUCKET", "KEY", "ERROR"}, func(add func(...string)) {
+					for _, ck := range res.CorruptKeys {
+						add(ck.Bucket, ck.Key, ck.Error)
+					}
+				})
+			}
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "  Delete or re-fetch the affected series ('reserve cache delete <ID>' then")
+			fmt.Fprintln(out, "  'reserve fetch series <ID> --store'), then run 'reserve cache compact'.")
+		}
+		if !res.OK() {
+			return fmt.Errorf("store verification failed: %d corrupt key(s), %d page error(s)",
+				len(res.CorruptKeys), len(res.PageErrors))
+		}
+		return nil
+	},
+}
+
+// ─── cache backup / restore ──────────────────────────────────────────────────
+
+var cacheRestoreFrom string
+
+var cacheBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Compact the database and archive it as a .tar.gz",
+	Long: `Backup compacts the local database, then writes a gzip-compressed tar archive
+containing the database file and a reserve_version manifest recording the
+reserve release and schema version. Share the archive or keep it as a
+snapshot, and bring it back with 'reserve cache restore'.
+
+Unlike 'cache export', the archive is the bbolt file itself, so it restores
+quickly but should be restored by a reserve build of the same or a newer
+schema version.`,
+	Example: `  reserve cache backup --out ~/reserve-backup-2026.tar.gz`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if globalFlags.Out == "" {
+			return fmt.Errorf("--out <FILE> is required")
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		if _, _, err := deps.Store.Compact(); err != nil {
+			return fmt.Errorf("compaction failed: %w", err)
+		}
+		f, err := os.Create(globalFlags.Out)
+		if err != nil {
+			return fmt.Errorf("creating backup file: %w", err)
+		}
+		manifest, err := deps.Store.Backup(f, Version)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = os.Remove(globalFlags.Out)
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Backed up %s\n", deps.Store.Path())
+		fmt.Fprintf(cmd.OutOrStdout(), "  Archive: %s\n", globalFlags.Out)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Schema:  v%d (reserve %s)\n", manifest.SchemaVersion, manifest.ReserveVersion)
+		if fi, err := os.Stat(globalFlags.Out); err == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "  Size:    %s\n", humanBytes(fi.Size()))
+		}
+		return nil
+	},
+}
+
+var cacheRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Replace the local database with a 'cache backup' archive",
+	Long: `Restore extracts a 'reserve cache backup' archive into the configured database
+path. Archives from an older schema are migrated during the restore; archives
+from a newer schema are rejected, since this build cannot read them.
+
+The current database, if any, is kept alongside as <db_path>.bak. Do not run
+restore while another reserve process has the database open.`,
+	Example: `  reserve cache restore --from ~/reserve-backup-2026.tar.gz`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cacheRestoreFrom == "" {
+			return fmt.Errorf("--from <FILE> is required")
+		}
+		cfg, err := resolveRuntimeConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.DBPath == "" {
+			return fmt.Errorf("db_path is not configured; set it in config.json or RESERVE_DB_PATH")
+		}
+		f, err := os.Open(cacheRestoreFrom)
+		if err != nil {
+			return fmt.Errorf("opening backup: %w", err)
+		}
+		defer f.Close()
+
+		_, statErr := os.Stat(cfg.DBPath)
+		manifest, err := store.RestoreBackup(f, cfg.DBPath)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Restored %s\n", cfg.DBPath)
+		fmt.Fprintf(cmd.OutOrStdout(), "  From:    %s (reserve %s, schema v%d, created %s)\n",
+			cacheRestoreFrom, manifest.ReserveVersion, manifest.SchemaVersion, manifest.CreatedAt.Format(time.RFC3339))
+		if statErr == nil {
+			fmt.Fprintf(cmd.OutOrStdout(), "  Previous database kept at %s.bak\n", cfg.DBPath)
+		}
+		return nil
+	},
+}
+
 // ─── cache reset-backfill ────────────────────────────────────────────────────
 
 var cacheResetBackfillCmd = &cobra.Command{
@@ -325,6 +665,176 @@ var cacheResetBackfillCmd = &cobra.Command{
 	},
 }
 
+// ─── cache export / import ───────────────────────────────────────────────────
+
+var (
+	cacheExportAll         bool
+	cacheExportNaNSentinel string
+)
+
+var cacheExportCmd = &cobra.Command{
+	Use:   "export [SERIES_ID...]",
+	Short: "Dump the local store as portable JSONL, or cached series as CSV",
+	Long: `Write every obs, series_meta, results, and schedules entry as newline-delimited
+JSON, one record per line tagged with its bucket and key. The dump is architecture-independent,
+so it can be moved between machines and restored with 'reserve cache import'.
+
+With --format csv, write cached observations for the given series as CSV
+(date,value,value_raw) for use in R, Python, or a spreadsheet. When more than
+one series is given a leading series_id column is added. With --all, every
+cached series is written to its own <SERIES_ID>.csv inside the --out directory.
+Missing values are written as an empty field, or "." with --nan-sentinel dot.
+
+Writes to stdout unless --out is given.`,
+	Example: `  reserve cache export --out backup.jsonl
+  reserve cache export | gzip > backup.jsonl.gz
+  reserve cache export CPIAUCSL --format csv --out cpi.csv
+  reserve cache export GDP UNRATE --format csv --nan-sentinel dot
+  reserve cache export --all --format csv --out ./csv`,
+	ValidArgsFunction: completeSeriesIDs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		csvMode := globalFlags.Format == render.FormatCSV
+		if !csvMode && (len(args) > 0 || cacheExportAll) {
+			return fmt.Errorf("exporting individual series requires --format csv")
+		}
+		nanSentinel, err := resolveNaNSentinel(cacheExportNaNSentinel)
+		if err != nil {
+			return err
+		}
+		if csvMode {
+			if cacheExportAll && len(args) > 0 {
+				return fmt.Errorf("--all cannot be combined with series IDs")
+			}
+			if !cacheExportAll && len(args) == 0 {
+				return fmt.Errorf("specify one or more series IDs, or --all")
+			}
+			if cacheExportAll && globalFlags.Out == "" {
+				return fmt.Errorf("--all requires --out <DIR>")
+			}
+		}
+
+		deps, err := buildReadOnlyDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		if csvMode && cacheExportAll {
+			return exportAllSeriesCSV(cmd.OutOrStdout(), deps.Store, globalFlags.Out, nanSentinel)
+		}
+
+		ids := resolveSeriesIDs(deps, args)
+		w, closeOut, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		switch {
+		case !csvMode:
+			err = deps.Store.ExportAll(w)
+		case len(ids) == 1:
+			err = deps.Store.ExportCSV(ids[0], w, nanSentinel)
+		default:
+			err = deps.Store.ExportCSVSeries(ids, w, nanSentinel)
+		}
+		if err != nil {
+			_ = closeOut()
+			return fmt.Errorf("exporting store: %w", err)
+		}
+		if err := closeOut(); err != nil {
+			return err
+		}
+		if globalFlags.Out != "" {
+			what := "local store"
+			if csvMode {
+				what = strings.Join(ids, ", ")
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Exported %s to %s\n", what, globalFlags.Out)
+		}
+		return nil
+	},
+}
+
+var (
+	cacheImportSeriesID    string
+	cacheImportDateCol     string
+	cacheImportValueCol    string
+	cacheImportKey         string
+	cacheImportNaNSentinel string
+)
+
+var cacheImportCmd = &cobra.Command{
+	Use:   "import <FILE>",
+	Short: "Restore a 'cache export' dump, or ingest a CSV of observations",
+	Long: `Read a JSONL dump produced by 'reserve cache export' and upsert every record
+into the local store by key. Importing the same file twice leaves the store
+unchanged, and entries not present in the dump are kept.
+
+With --format csv, read observations for one series (--series-id) from a CSV
+file obtained offline or from a third party. The date and value columns are
+detected from common headers, including FRED's own CSV downloads, or named
+with --date-col and --value-col. Empty cells and "." are stored as missing.
+Data is stored under the canonical key for the series unless --key is given.`,
+	Example: `  reserve cache import backup.jsonl
+  reserve cache import cpi.csv --format csv --series-id CPIAUCSL
+  reserve cache import legacy.csv --format csv --series-id GDP --date-col period --value-col gdp --nan-sentinel NA`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		csvMode := globalFlags.Format == render.FormatCSV
+		if !csvMode && (cacheImportSeriesID != "" || cacheImportDateCol != "" || cacheImportValueCol != "" || cacheImportKey != "" || cacheImportNaNSentinel != "") {
+			return fmt.Errorf("CSV import flags require --format csv")
+		}
+		if csvMode && strings.TrimSpace(cacheImportSeriesID) == "" {
+			return fmt.Errorf("--series-id is required with --format csv")
+		}
+
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		if csvMode {
+			seriesID := resolveSeriesID(deps, cacheImportSeriesID)
+			data, err := store.ImportCSV(args[0], seriesID, cacheImportDateCol, cacheImportValueCol, cacheImportNaNSentinel)
+			if err != nil {
+				return fmt.Errorf("importing %s: %w", args[0], err)
+			}
+			key := cacheImportKey
+			if key == "" {
+				key = store.ObsKey(seriesID, "", "", "", "", "")
+			}
+			if err := deps.Store.PutObs(key, data); err != nil {
+				return fmt.Errorf("storing %s: %w", seriesID, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Imported %d observation(s) for %s from %s\n", len(data.Obs), seriesID, args[0])
+			fmt.Fprintf(cmd.OutOrStdout(), "  Key: %s\n", key)
+			return nil
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("opening import file: %w", err)
+		}
+		defer f.Close()
+
+		counts, err := deps.Store.ImportAllCounts(f)
+		if err != nil {
+			return fmt.Errorf("importing %s: %w", args[0], err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Imported %s\n", args[0])
+		for _, name := range store.ExportBuckets {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %-12s %d record(s)\n", name+":", counts[name])
+		}
+		return nil
+	},
+}
+
 // ─── Registration ─────────────────────────────────────────────────────────────
 
 func init() {
@@ -333,16 +843,87 @@ func init() {
 	cacheCmd.AddCommand(cachePathCmd)
 	cacheCmd.AddCommand(cacheInventoryCmd)
 	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheDeleteCmd)
 	cacheCmd.AddCommand(cacheCompactCmd)
+	cacheCmd.AddCommand(cacheWarmCmd)
+	cacheCmd.AddCommand(cacheVerifyCmd)
+	cacheCmd.AddCommand(cacheBackupCmd)
+	cacheCmd.AddCommand(cacheRestoreCmd)
 	cacheCmd.AddCommand(cacheResetBackfillCmd)
+	cacheCmd.AddCommand(cacheExportCmd)
+	cacheCmd.AddCommand(cacheImportCmd)
 
+	cacheWarmCmd.Flags().StringVar(&cacheWarmStart, "start", "", "observation start date YYYY-MM-DD (default: all history)")
+	cacheWarmCmd.Flags().StringVar(&cacheWarmEnd, "end", "", "observation end date YYYY-MM-DD")
+	cacheInventoryCmd.Flags().StringVar(&cacheInventoryStale, "stale", "", "only list series whose newest cached data is older than this (e.g. 7d, 36h)")
+	cacheExportCmd.Flags().BoolVar(&cacheExportAll, "all", false, "with --format csv, export every cached series to a file per series in the --out directory")
+	cacheExportCmd.Flags().StringVar(&cacheExportNaNSentinel, "nan-sentinel", "empty", "CSV representation of missing values: empty|dot")
+	cacheImportCmd.Flags().StringVar(&cacheImportSeriesID, "series-id", "", "series ID to store CSV observations under (required with --format csv)")
+	cacheImportCmd.Flags().StringVar(&cacheImportDateCol, "date-col", "", "CSV header of the date column (default: auto-detect)")
+	cacheImportCmd.Flags().StringVar(&cacheImportValueCol, "value-col", "", "CSV header of the value column (default: auto-detect)")
+	cacheImportCmd.Flags().StringVar(&cacheImportKey, "key", "", "override the obs key the CSV is stored under (default: series:<ID>)")
+	cacheImportCmd.Flags().StringVar(&cacheImportNaNSentinel, "nan-sentinel", "", "extra CSV token to treat as missing, e.g. NA (empty and . always are)")
+	cacheRestoreCmd.Flags().StringVar(&cacheRestoreFrom, "from", "", "backup archive written by 'reserve cache backup'")
 	cacheClearCmd.Flags().BoolVar(&cacheClearAll, "all", false, "clear all buckets")
-	cacheClearCmd.Flags().StringVar(&cacheClearBucket, "bucket", "", "clear a specific bucket: obs|series_meta")
+	cacheClearCmd.Flags().StringVar(&cacheClearBucket, "bucket", "", "clear a specific bucket: obs|series_meta|results")
 	cacheClearCmd.Flags().StringVar(&cacheClearSeries, "series", "", "clear cached observation sets for a specific series ID (metadata is preserved)")
+	_ = cacheClearCmd.RegisterFlagCompletionFunc("series", completeSeriesIDFlag)
 }
 
 // ─── Helpers ──────────────────────────────────────────────────────────────────
 
+// resolveNaNSentinel maps the --nan-sentinel flag to the text written for
+// missing values in CSV exports.
+func resolveNaNSentinel(name string) (string, error) {
+	switch strings.ToLower(name) {
+	case "", "empty":
+		return "", nil
+	case "dot":
+		return ".", nil
+	default:
+		return "", fmt.Errorf("invalid --nan-sentinel %q: must be empty or dot", name)
+	}
+}
+
+// exportAllSeriesCSV writes one <SERIES_ID>.csv per cached series into dir.
+func exportAllSeriesCSV(out io.Writer, s *store.Store, dir, nanSentinel string) error {
+	keys, err := s.ListObsKeys("")
+	if err != nil {
+		return fmt.Errorf("reading cache: %w", err)
+	}
+	seen := map[string]bool{}
+	var ids []string
+	for _, key := range keys {
+		id, _, _ := strings.Cut(strings.TrimPrefix(key, "series:"), "|")
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("no cached observations to export")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	for _, id := range ids {
+		path := filepath.Join(dir, id+".csv")
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", path, err)
+		}
+		if err := s.ExportCSV(id, f, nanSentinel); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("exporting %s: %w", id, err)
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "✓ Exported %d series to %s\n", len(ids), dir)
+	return nil
+}
+
 func humanBytes(b int64) string {
 	switch {
 	case b >= 1<<20:
@@ -456,6 +1037,35 @@ func buildCacheInventory(s interface {
 	return rows, nil
 }
 
+// filterStaleInventory keeps only the series whose most recently fetched
+// observation set is older than threshold.
+func filterStaleInventory(s interface {
+	ListObsKeys(string) ([]string, error)
+	Age(string) (time.Duration, error)
+}, rows []cacheInventoryRow, threshold time.Duration) ([]cacheInventoryRow, error) {
+	stale := rows[:0]
+	for _, row := range rows {
+		keys, err := s.ListObsKeys(row.SeriesID)
+		if err != nil {
+			return nil, err
+		}
+		newest := time.Duration(-1)
+		for _, key := range keys {
+			age, err := s.Age(key)
+			if err != nil {
+				return nil, err
+			}
+			if newest < 0 || age < newest {
+				newest = age
+			}
+		}
+		if newest > threshold {
+			stale = append(stale, row)
+		}
+	}
+	return stale, nil
+}
+
 func normalizeFrequency(freq string) string {
 	switch strings.ToLower(strings.TrimSpace(freq)) {
 	case "daily":
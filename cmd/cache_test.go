@@ -5,13 +5,20 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/store"
 )
@@ -322,7 +329,7 @@ func TestCacheStatsCommandIncludesMetadata(t *testing.T) {
 	out := buf.String()
 	for _, needle := range []string{
 		"Database: " + dbPath,
-		"Schema:   v2",
+		"Schema:   v4",
 		"File:     ",
 		"obs",
 		"series_meta",
@@ -333,6 +340,242 @@ func TestCacheStatsCommandIncludesMetadata(t *testing.T) {
 	}
 }
 
+func TestCacheExportAllCSVWritesFilePerSeries(t *testing.T) {
+	dir := t.TempDir()
+	isolateCacheCommandConfig(t, dir)
+	dbPath := filepath.Join(dir, "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), monthlySeries("GDP", "2024-01-01", 2)); err != nil {
+		t.Fatalf("PutObs GDP: %v", err)
+	}
+	if err := s.PutObs(store.ObsKey("UNRATE", "2024-01-01", "", "", "", ""), monthlySeries("UNRATE", "2024-01-01", 3)); err != nil {
+		t.Fatalf("PutObs UNRATE: %v", err)
+	}
+	_ = s.Close()
+
+	cfgPath := filepath.Join(dir, "config.json")
+	if err := config.WriteFile(cfgPath, config.File{DBPath: dbPath}); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	orig, _ := os.Getwd()
+	_ = os.Chdir(dir)
+	outDir := filepath.Join(dir, "csv")
+	origFormat, origOut := globalFlags.Format, globalFlags.Out
+	globalFlags.Format = "csv"
+	globalFlags.Out = outDir
+	cacheExportAll = true
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+		globalFlags.Format, globalFlags.Out = origFormat, origOut
+		cacheExportAll = false
+	})
+
+	var buf bytes.Buffer
+	cacheExportCmd.SetOut(&buf)
+	cacheExportCmd.SetErr(&buf)
+	if err := cacheExportCmd.RunE(cacheExportCmd, nil); err != nil {
+		t.Fatalf("cache export --all: %v", err)
+	}
+
+	for id, rows := range map[string]int{"GDP": 2, "UNRATE": 3} {
+		data, err := os.ReadFile(filepath.Join(outDir, id+".csv"))
+		if err != nil {
+			t.Fatalf("expected %s.csv: %v", id, err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if lines[0] != "date,value,value_raw" || len(lines) != rows+1 {
+			t.Fatalf("unexpected %s.csv:\n%s", id, data)
+		}
+	}
+	if !strings.Contains(buf.String(), "Exported 2 series") {
+		t.Fatalf("unexpected confirmation: %q", buf.String())
+	}
+}
+
+func TestCacheImportCSVStoresUnderCanonicalKey(t *testing.T) {
+	dir := t.TempDir()
+	isolateCacheCommandConfig(t, dir)
+	dbPath := filepath.Join(dir, "reserve.db")
+	if err := config.WriteFile(filepath.Join(dir, "config.json"), config.File{DBPath: dbPath}); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	csvPath := filepath.Join(dir, "cpi.csv")
+	if err := os.WriteFile(csvPath, []byte("observation_date,CPIAUCSL\n2024-01-01,308.4\n2024-02-01,.\n"), 0600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+
+	orig, _ := os.Getwd()
+	_ = os.Chdir(dir)
+	origFormat := globalFlags.Format
+	globalFlags.Format = "csv"
+	cacheImportSeriesID = "cpiaucsl"
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+		globalFlags.Format = origFormat
+		cacheImportSeriesID = ""
+	})
+
+	var buf bytes.Buffer
+	cacheImportCmd.SetOut(&buf)
+	cacheImportCmd.SetErr(&buf)
+	if err := cacheImportCmd.RunE(cacheImportCmd, []string{csvPath}); err != nil {
+		t.Fatalf("cache import --format csv: %v", err)
+	}
+
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+	data, found, err := s.GetObs(store.ObsKey("CPIAUCSL", "", "", "", "", ""))
+	if err != nil || !found {
+		t.Fatalf("GetObs: found=%v err=%v", found, err)
+	}
+	if len(data.Obs) != 2 || data.Obs[0].Value != 308.4 || !data.Obs[1].IsMissing() {
+		t.Fatalf("unexpected imported observations: %+v", data.Obs)
+	}
+	if !strings.Contains(buf.String(), "Imported 2 observation(s) for CPIAUCSL") {
+		t.Fatalf("unexpected confirmation: %q", buf.String())
+	}
+}
+
+func TestCacheVerifyCommandReportsCorruptKey(t *testing.T) {
+	dir := t.TempDir()
+	isolateCacheCommandConfig(t, dir)
+	dbPath := filepath.Join(dir, "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), monthlySeries("GDP", "2024-01-01", 2)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	_ = s.Close()
+
+	cfgPath := filepath.Join(dir, "config.json")
+	if err := config.WriteFile(cfgPath, config.File{DBPath: dbPath}); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	orig, _ := os.Getwd()
+	_ = os.Chdir(dir)
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+
+	var buf bytes.Buffer
+	cacheVerifyCmd.SetOut(&buf)
+	cacheVerifyCmd.SetErr(&buf)
+	if err := cacheVerifyCmd.RunE(cacheVerifyCmd, nil); err != nil {
+		t.Fatalf("cache verify on a healthy store: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "✓ Verification passed") {
+		t.Fatalf("expected pass verdict:\n%s", buf.String())
+	}
+
+	db, err := bolt.Open(dbPath, 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("series_meta")).Put([]byte("GDP"), []byte("not json"))
+	})
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("writing corrupt value: %v", err)
+	}
+
+	buf.Reset()
+	if err := cacheVerifyCmd.RunE(cacheVerifyCmd, nil); err == nil {
+		t.Fatal("expected cache verify to fail on a corrupt value")
+	}
+	out := buf.String()
+	for _, needle := range []string{"series_meta", "GDP", "cache compact"} {
+		if !strings.Contains(out, needle) {
+			t.Errorf("expected %q in output:\n%s", needle, out)
+		}
+	}
+}
+
+func TestCacheBackupRestoreCommandsRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	isolateCacheCommandConfig(t, dir)
+	dbPath := filepath.Join(dir, "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	key := store.ObsKey("GDP", "", "", "", "", "")
+	if err := s.PutObs(key, monthlySeries("GDP", "2024-01-01", 3)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	_ = s.Close()
+	if err := config.WriteFile(filepath.Join(dir, "config.json"), config.File{DBPath: dbPath}); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	orig, _ := os.Getwd()
+	_ = os.Chdir(dir)
+	archive := filepath.Join(dir, "backup.tar.gz")
+	origOut := globalFlags.Out
+	globalFlags.Out = archive
+	t.Cleanup(func() {
+		_ = os.Chdir(orig)
+		globalFlags.Out = origOut
+		cacheRestoreFrom = ""
+	})
+
+	var buf bytes.Buffer
+	cacheBackupCmd.SetOut(&buf)
+	if err := cacheBackupCmd.RunE(cacheBackupCmd, nil); err != nil {
+		t.Fatalf("cache backup: %v", err)
+	}
+	if !strings.Contains(buf.String(), "✓ Backed up") {
+		t.Fatalf("unexpected backup output:\n%s", buf.String())
+	}
+
+	// Drop the data, then restore it from the archive.
+	s, err = store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := s.ClearAll(); err != nil {
+		t.Fatalf("ClearAll: %v", err)
+	}
+	_ = s.Close()
+
+	globalFlags.Out = ""
+	cacheRestoreFrom = archive
+	buf.Reset()
+	cacheRestoreCmd.SetOut(&buf)
+	if err := cacheRestoreCmd.RunE(cacheRestoreCmd, nil); err != nil {
+		t.Fatalf("cache restore: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Previous database kept at "+dbPath+".bak") {
+		t.Fatalf("expected .bak notice:\n%s", buf.String())
+	}
+	s, err = store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open restored: %v", err)
+	}
+	defer s.Close()
+	if _, found, _ := s.GetObs(key); !found {
+		t.Fatal("restored database is missing GDP observations")
+	}
+}
+
+func TestResolveNaNSentinel(t *testing.T) {
+	if got, err := resolveNaNSentinel("empty"); err != nil || got != "" {
+		t.Fatalf("empty: got %q, %v", got, err)
+	}
+	if got, err := resolveNaNSentinel("dot"); err != nil || got != "." {
+		t.Fatalf("dot: got %q, %v", got, err)
+	}
+	if _, err := resolveNaNSentinel("zero"); err == nil {
+		t.Fatal("expected error for unknown sentinel")
+	}
+}
+
 func isolateCacheCommandConfig(t *testing.T, dir string) {
 	t.Helper()
 	t.Setenv(config.EnvAPIKey, "")
@@ -342,3 +585,77 @@ func isolateCacheCommandConfig(t *testing.T, dir string) {
 	t.Setenv("APPDATA", filepath.Join(dir, "appdata"))
 	t.Setenv("LOCALAPPDATA", filepath.Join(dir, "localappdata"))
 }
+
+func TestRunCacheWarmStoresSeriesAndCollectsFailures(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+	for _, id := range []string{"UNRATE", "FEDFUNDS", "BADID"} {
+		if err := s.PutSeriesMeta(model.SeriesMeta{
+			ID:                id,
+			CopyrightStatus:   "public_domain_citation_requested",
+			LastRightsCheckAt: time.Now().UTC(),
+		}); err != nil {
+			t.Fatalf("PutSeriesMeta: %v", err)
+		}
+	}
+
+	var gotStarts []string
+	var mu sync.Mutex
+	client := fred.NewClient("test_key", "https://mock.fred.local/", 5*time.Second, 1000, false)
+	client.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		gotStarts = append(gotStarts, req.URL.Query().Get("observation_start"))
+		mu.Unlock()
+		rec := newResponseRecorder()
+		if req.URL.Query().Get("series_id") == "BADID" {
+			rec.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(rec).Encode(map[string]any{"error_code": 400, "error_message": "Bad Request. The series does not exist."})
+			return rec.Result(), nil
+		}
+		_ = json.NewEncoder(rec).Encode(map[string]any{
+			"observations": []map[string]string{
+				{"date": "2024-01-01", "value": "4.1"},
+				{"date": "2024-02-01", "value": "4.2"},
+			},
+		})
+		return rec.Result(), nil
+	})})
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath, Concurrency: 2}, Client: client, Store: s}
+
+	var out bytes.Buffer
+	err = runCacheWarm(t.Context(), &out, deps, []string{"UNRATE", "FEDFUNDS", "BADID"}, fred.ObsOptions{Start: "2024-01-01"})
+	if err != nil {
+		t.Fatalf("a partial failure should not fail the command: %v", err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "✓ Warmed 2/3 series, ") || !strings.Contains(got, " stored\n") {
+		t.Errorf("unexpected summary:\n%s", got)
+	}
+	if !strings.Contains(got, "✓  UNRATE  2 observations") || !strings.Contains(got, "⚠  BADID:") {
+		t.Errorf("expected stored series and the failure listed:\n%s", got)
+	}
+	for _, start := range gotStarts {
+		if start != "2024-01-01" {
+			t.Errorf("--start should reach FRED, got observation_start=%q", start)
+		}
+	}
+
+	for _, id := range []string{"UNRATE", "FEDFUNDS"} {
+		data, ok, err := s.GetObs(store.ObsKey(id, "2024-01-01", "", "", "", ""))
+		if err != nil || !ok || len(data.Obs) != 2 {
+			t.Errorf("%s: expected 2 stored observations, got ok=%v err=%v data=%+v", id, ok, err, data.Obs)
+		}
+	}
+	if _, ok, _ := s.GetObs(store.ObsKey("BADID", "2024-01-01", "", "", "", "")); ok {
+		t.Error("the failed series should not be stored")
+	}
+
+	out.Reset()
+	if err := runCacheWarm(t.Context(), &out, deps, []string{"BADID"}, fred.ObsOptions{}); err == nil {
+		t.Error("expected an error when no series could be warmed")
+	}
+}
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 	"time"
@@ -52,7 +53,9 @@ var categoryGetCmd = &cobra.Command{
 			return err
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderCategories(cmd.OutOrStdout(), []model.Category{*cat}, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderCategories(w, []model.Category{*cat}, format)
+		})
 	},
 }
 
@@ -82,7 +85,9 @@ var categoryListCmd = &cobra.Command{
 			return err
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderCategories(cmd.OutOrStdout(), cats, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderCategories(w, cats, format)
+		})
 	},
 }
 
@@ -187,7 +192,9 @@ var categorySeriesCmd = &cobra.Command{
 			},
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderTo(globalFlags.Out, result, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.Render(w, result, format)
+		})
 	},
 }
 
@@ -203,6 +210,9 @@ func init() {
 	categoryTreeCmd.Flags().IntVar(&categoryTreeDepth, "depth", 2, "maximum recursion depth")
 	categorySeriesCmd.Flags().IntVar(&categorySeriesLimit, "limit", 20, "max series to return")
 	categorySeriesCmd.Flags().StringVar(&categorySeriesFilter, "filter", "", "filter expression: field=value")
+	for _, c := range []*cobra.Command{categoryGetCmd, categoryListCmd, categorySeriesCmd} {
+		c.Flags().BoolVar(&outputGzip, "gzip", false, outputGzipUsage)
+	}
 }
 
 // ─── Helpers ──────────────────────────────────────────────────────────────────
@@ -5,9 +5,12 @@ package cmd
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -168,3 +171,44 @@ type ioNopCloser struct {
 }
 
 func (ioNopCloser) Close() error { return nil }
+
+func TestCategoryGetGzipJSONL(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	resetGlobalFlag(t, "format")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"categories": []map[string]any{{"id": 32991, "name": "Money Banking and Finance", "parent_id": 0}},
+		})
+	}))
+	defer srv.Close()
+	t.Setenv(config.EnvAPIKey, "abcdefghijklmnopqrstuvwxyz123456")
+	t.Setenv(config.EnvBaseURL, srv.URL+"/")
+	t.Setenv(config.EnvRate, "1000")
+	t.Setenv(config.EnvFormat, "jsonl")
+	outputGzip = true
+	t.Cleanup(func() { outputGzip = false })
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetContext(context.Background())
+	if err := categoryGetCmd.RunE(cmd, []string{"32991"}); err != nil {
+		t.Fatalf("category get: %v", err)
+	}
+	zr, err := gzip.NewReader(&out)
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	plain, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("reading gzip stream: %v", err)
+	}
+	if !strings.Contains(string(plain), `"Money Banking and Finance"`) {
+		t.Errorf("unexpected decompressed output %q", plain)
+	}
+
+	err = writeOutput(cmd, "csv", func(io.Writer) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "--gzip requires --format jsonl") {
+		t.Errorf("expected a format error, got %v", err)
+	}
+}
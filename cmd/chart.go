@@ -4,10 +4,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/chart"
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/pipeline"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +25,10 @@ var chartCmd = &cobra.Command{
 Pipeline examples:
   reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform resample --freq annual --method mean | reserve chart bar
   reserve obs get UNRATE --from cache --format jsonl | reserve chart plot
-  reserve obs get GDP --from cache --format jsonl | reserve transform pct-change | reserve chart plot --title "GDP QoQ Growth"`,
+  reserve obs get GDP --from cache --format jsonl | reserve transform pct-change | reserve chart plot --title "GDP QoQ Growth"
+  reserve obs get FEDFUNDS T10Y2Y UNRATE --format jsonl | reserve chart spark
+  reserve obs get UNRATE --from cache --format jsonl | reserve chart histogram --bins 20
+  reserve chart scatter UNRATE --vs CPIAUCSL --fit`,
 }
 
 // ─── chart bar ───────────────────────────────────────────────────────────────
@@ -28,6 +36,7 @@ Pipeline examples:
 var (
 	chartBarWidth   int
 	chartBarMaxBars int
+	chartBarTheme   string
 )
 
 var chartBarCmd = &cobra.Command{
@@ -43,7 +52,10 @@ monthly or daily series, pipe through transform resample first:
     | reserve chart bar
 
 Negative values are supported — bars extend left from a zero baseline.
-NaN observations are silently skipped.`,
+NaN observations are silently skipped.
+
+--format svg writes a standalone SVG bar chart instead; combine it with --out
+to save the file and --theme dark for a dark background.`,
 	Example: `  # Annual CPI — the natural use case
   reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform resample --freq annual --method mean | reserve chart bar
 
@@ -54,8 +66,15 @@ NaN observations are silently skipped.`,
   reserve obs get FEDFUNDS --from cache --format jsonl | reserve transform resample --freq annual --method mean | reserve chart bar
 
   # Last 10 years only
-  reserve obs get UNRATE --from cache --format jsonl | reserve transform filter --after 2015-01-01 | reserve transform resample --freq annual --method mean | reserve chart bar --max-bars 10`,
+  reserve obs get UNRATE --from cache --format jsonl | reserve transform filter --after 2015-01-01 | reserve transform resample --freq annual --method mean | reserve chart bar --max-bars 10
+
+  # SVG for a report
+  reserve obs get GDPC1 --from cache --format jsonl | reserve transform pct-change --period 4 | reserve transform resample --freq annual --method last | reserve chart bar --format svg --out gdp.svg`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		svg := globalFlags.Format == chartFormatSVG
+		if cmd.Flags().Changed("theme") && !svg {
+			return fmt.Errorf("--theme requires --format svg")
+		}
 		seriesID, obs, err := pipeline.ReadObservations(os.Stdin)
 		if err != nil {
 			return err
@@ -71,9 +90,22 @@ NaN observations are silently skipped.`,
 		if err != nil {
 			return err
 		}
+		if svg {
+			w, closeFn, err := outputWriter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			defer closeFn()
+			return chart.BarSVG(w, seriesID, obs, chart.BarSVGOptions{
+				MaxBars: chartBarMaxBars,
+				Caption: meta.CitationText,
+				Theme:   chartBarTheme,
+			})
+		}
 		if err := chart.Bar(os.Stdout, seriesID, obs, chart.BarOptions{
 			Width:   chartBarWidth,
 			MaxBars: chartBarMaxBars,
+			Color:   useColor(os.Stdout),
 		}); err != nil {
 			return err
 		}
@@ -87,51 +119,342 @@ NaN observations are silently skipped.`,
 // ─── chart plot ──────────────────────────────────────────────────────────────
 
 var (
-	chartPlotWidth  int
-	chartPlotHeight int
-	chartPlotTitle  string
+	chartPlotWidth        int
+	chartPlotHeight       int
+	chartPlotTitle        string
+	chartPlotOverlay      string
+	chartPlotMulti        bool
+	chartPlotSeparateAxes bool
+	chartPlotRecessions   bool
+	chartPlotLog          bool
+	chartPlotTheme        string
 )
 
+// chartFormatSVG is the --format value that makes chart plot and chart bar
+// write an SVG image instead of drawing in the terminal.
+const chartFormatSVG = "svg"
+
+// recessionSeriesID is the NBER-based US recession indicator on FRED:
+// 1 during a recession month, 0 otherwise.
+const recessionSeriesID = "USREC"
+
 var chartPlotCmd = &cobra.Command{
-	Use:   "plot",
+	Use:   "plot [SERIES_ID]",
 	Short: "Multi-line ASCII chart with labeled axes",
 	Long: `Renders a multi-line chart with Y-axis tick labels and X-axis date labels.
 
 NaN values appear as gaps in the curve, not zeros. Width auto-detects from
-$COLUMNS (falls back to 80). Override with --width and --height.`,
+$COLUMNS (falls back to 80). Override with --width and --height.
+
+The plotted series is read from stdin, or from the local store when a
+SERIES_ID argument is given. --overlay draws a second cached series on the
+same axes with dotted glyphs; add --separate-axes to scale it independently
+on a right-hand axis.
+
+--multi plots every series in a multi-series JSONL stream on one chart, each
+in its own line style (─, ┄, ┈, cycling after three) with a legend below.
+When one series would be flattened by the shared Y axis a warning suggests
+--dual-axis, which moves every series after the first to a right-hand axis.
+
+--recessions shades NBER recession periods with ░, using the USREC indicator
+from the local store, or from FRED when it has not been cached.
+
+--log puts the Y axis on a log10 scale, so equal percentage changes are equal
+heights; ticks sit at powers of ten and are labeled in original units. Every
+value must be positive.
+
+--format svg writes a standalone SVG line chart instead, for pasting into
+documents; combine it with --out to save the file and --theme dark for a dark
+background.`,
 	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve chart plot
   reserve obs get CPIAUCSL --from cache --format jsonl | reserve chart plot --height 8
   reserve obs get GDP --from cache --format jsonl | reserve transform pct-change | reserve chart plot --title "GDP QoQ %"
   reserve obs get UNRATE --from cache --format jsonl | reserve window roll --stat mean --window 12 | reserve chart plot
-  reserve obs get FEDFUNDS --start 2015-01-01 --format jsonl | reserve chart plot --width 100 --height 16`,
+  reserve obs get FEDFUNDS --start 2015-01-01 --format jsonl | reserve chart plot --width 100 --height 16
+  reserve chart plot FEDFUNDS --overlay UNRATE
+  reserve chart plot FEDFUNDS --overlay CPIAUCSL --separate-axes
+  reserve obs get UNRATE FEDFUNDS --start 2015-01-01 --format jsonl | reserve chart plot --multi
+  reserve obs get UNRATE GDP --start 2000-01-01 --format jsonl | reserve chart plot --multi --dual-axis
+  reserve chart plot UNRATE --recessions
+  reserve chart plot M2SL --log
+  reserve chart plot UNRATE --format svg --out unrate.svg
+  reserve chart plot UNRATE --format svg --theme dark --out unrate.svg`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		seriesID, obs, err := pipeline.ReadObservations(os.Stdin)
-		if err != nil {
-			return err
+		if chartPlotSeparateAxes && chartPlotOverlay == "" && !chartPlotMulti {
+			return fmt.Errorf("--separate-axes and --dual-axis require --overlay or --multi")
 		}
-		if seriesID == "" {
-			seriesID = "series"
+		if chartPlotMulti && (len(args) > 0 || chartPlotOverlay != "" || chartPlotRecessions || chartPlotLog) {
+			return fmt.Errorf("--multi reads every series from stdin and cannot be combined with SERIES_ID, --overlay, --recessions, or --log")
+		}
+		if chartPlotRecessions && chartPlotOverlay != "" {
+			return fmt.Errorf("--recessions cannot be combined with --overlay")
+		}
+		if chartPlotLog && chartPlotOverlay != "" {
+			return fmt.Errorf("--log cannot be combined with --overlay")
+		}
+		svg := globalFlags.Format == chartFormatSVG
+		if svg && (chartPlotOverlay != "" || chartPlotMulti || chartPlotRecessions || chartPlotLog) {
+			return fmt.Errorf("--format svg does not support --overlay, --multi, --recessions, or --log")
+		}
+		if cmd.Flags().Changed("theme") && !svg {
+			return fmt.Errorf("--theme requires --format svg")
 		}
 		deps, err := buildDeps()
 		if err != nil {
 			return err
 		}
+
+		if chartPlotMulti {
+			return plotMultiSeries(cmd, deps)
+		}
+
+		var seriesID string
+		var obs []model.Observation
+		if len(args) == 1 {
+			seriesID = resolveSeriesID(deps, args[0])
+			if obs, err = cachedObservations(deps, seriesID); err != nil {
+				return err
+			}
+		} else {
+			if seriesID, obs, err = pipeline.ReadObservations(os.Stdin); err != nil {
+				return err
+			}
+			if seriesID == "" {
+				seriesID = "series"
+			}
+		}
 		meta, err := ensureSeriesCompliance(cmd.Context(), deps, seriesID, "display")
 		if err != nil {
 			return err
 		}
 
+		if chartPlotOverlay != "" {
+			overlayID := resolveSeriesID(deps, chartPlotOverlay)
+			if overlayID == seriesID {
+				return fmt.Errorf("--overlay must name a different series than %s", seriesID)
+			}
+			overlayObs, err := cachedObservations(deps, overlayID)
+			if err != nil {
+				return fmt.Errorf("--overlay: %w", err)
+			}
+			overlayMeta, err := ensureSeriesCompliance(cmd.Context(), deps, overlayID, "display")
+			if err != nil {
+				return err
+			}
+			if err := chart.PlotMulti(os.Stdout, map[string][]model.Observation{
+				seriesID:  obs,
+				overlayID: overlayObs,
+			}, chart.PlotOptions{
+				Width:        chartPlotWidth,
+				Height:       chartPlotHeight,
+				Title:        chartPlotTitle,
+				Order:        []string{seriesID, overlayID},
+				SeparateAxes: chartPlotSeparateAxes,
+				Color:        useColor(os.Stdout),
+			}); err != nil {
+				return err
+			}
+			printChartCitations(meta.CitationText, overlayMeta.CitationText)
+			return nil
+		}
+
 		title := chartPlotTitle
 		if title == "" {
 			title = seriesID
 		}
 
+		if svg {
+			w, closeFn, err := outputWriter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			defer closeFn()
+			return chart.PlotSVG(w, seriesID, obs, chart.PlotSVGOptions{
+				Title:   title,
+				Caption: meta.CitationText,
+				Theme:   chartPlotTheme,
+			})
+		}
+
+		var shades []chart.DateRange
+		if chartPlotRecessions && len(obs) > 0 {
+			if shades, err = recessionShades(cmd.Context(), deps, obs); err != nil {
+				return err
+			}
+		}
+
 		// If --width not set and we're in a terminal, auto-detect.
 		// chart.Plot handles width=0 by calling termWidth() internally.
 		if err := chart.Plot(os.Stdout, seriesID, obs, chart.PlotOptions{
-			Width:  chartPlotWidth,
-			Height: chartPlotHeight,
-			Title:  title,
+			Width:    chartPlotWidth,
+			Height:   chartPlotHeight,
+			Title:    title,
+			Color:    useColor(os.Stdout),
+			Shades:   shades,
+			LogScale: chartPlotLog,
+		}); err != nil {
+			return err
+		}
+		if meta.CitationText != "" {
+			fmt.Fprintf(os.Stdout, "\n%s\n", meta.CitationText)
+		}
+		return nil
+	},
+}
+
+// plotMultiSeries renders chart plot --multi: every series in the stdin
+// stream, in the order each first appears, overlaid on one chart.
+func plotMultiSeries(cmd *cobra.Command, deps *app.Deps) error {
+	groups, err := pipeline.ReadObservationGroups(os.Stdin)
+	if err != nil {
+		return err
+	}
+	if len(groups) < 2 {
+		return fmt.Errorf("--multi needs at least 2 series in the input stream (got %d)", len(groups))
+	}
+	multi := make([]chart.SeriesPlotData, len(groups))
+	citations := make([]string, len(groups))
+	for i, g := range groups {
+		meta, err := ensureSeriesCompliance(cmd.Context(), deps, g.SeriesID, "display")
+		if err != nil {
+			return err
+		}
+		multi[i] = chart.SeriesPlotData{SeriesID: g.SeriesID, Obs: g.Obs}
+		citations[i] = meta.CitationText
+	}
+	if !chartPlotSeparateAxes && chart.ScaleMismatch(multi) {
+		fmt.Fprintln(cmd.ErrOrStderr(), "⚠  series ranges differ widely; some lines will look flat on a shared axis (try --dual-axis)")
+	}
+	if err := chart.Plot(os.Stdout, "", nil, chart.PlotOptions{
+		Width:        chartPlotWidth,
+		Height:       chartPlotHeight,
+		Title:        chartPlotTitle,
+		MultiSeries:  multi,
+		SeparateAxes: chartPlotSeparateAxes,
+		Color:        useColor(os.Stdout),
+	}); err != nil {
+		return err
+	}
+	printChartCitations(citations...)
+	return nil
+}
+
+// recessionShades returns the recession periods overlapping obs. USREC is
+// read from the local store when cached and fetched live otherwise.
+func recessionShades(ctx context.Context, deps *app.Deps, obs []model.Observation) ([]chart.DateRange, error) {
+	indicator, err := cachedObservations(deps, recessionSeriesID)
+	if err != nil {
+		if cfgErr := deps.Config.Validate(); cfgErr != nil {
+			return nil, fmt.Errorf("--recessions: %s is not cached (%v) and cannot be fetched: %w", recessionSeriesID, err, cfgErr)
+		}
+		data, _, _, liveErr := liveObsSource{}.get(ctx, deps, recessionSeriesID, fred.ObsOptions{
+			Start: obs[0].Date.Format("2006-01-02"),
+			End:   obs[len(obs)-1].Date.Format("2006-01-02"),
+		})
+		if liveErr != nil {
+			return nil, fmt.Errorf("--recessions: fetching %s: %w", recessionSeriesID, liveErr)
+		}
+		indicator = data.Obs
+	}
+	return chart.IndicatorRanges(indicator), nil
+}
+
+// ─── chart spark ─────────────────────────────────────────────────────────────
+
+var chartSparkWidth int
+
+var chartSparkCmd = &cobra.Command{
+	Use:   "spark",
+	Short: "One-line sparkline per series",
+	Long: `Renders each series in the input stream as a single line of block
+characters (▁▂▃▄▅▆▇█) scaled between its own minimum and maximum.
+
+Multi-series JSONL input prints one line per series, which makes spark a
+compact watchlist view. NaN observations appear as blank cells.`,
+	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve chart spark
+  reserve obs get FEDFUNDS T10Y2Y UNRATE --start 2020-01-01 --format jsonl | reserve chart spark --width 40`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		groups, err := pipeline.ReadObservationGroups(os.Stdin)
+		if err != nil {
+			return err
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+
+		idWidth := 0
+		for _, g := range groups {
+			if len(g.SeriesID) > idWidth {
+				idWidth = len(g.SeriesID)
+			}
+		}
+		var citations []string
+		for _, g := range groups {
+			seriesID := g.SeriesID
+			if seriesID == "" {
+				seriesID = "series"
+			}
+			meta, err := ensureSeriesCompliance(cmd.Context(), deps, seriesID, "display")
+			if err != nil {
+				return err
+			}
+			spark, err := chart.Sparkline(g.Obs, chartSparkWidth)
+			if err != nil {
+				return fmt.Errorf("%s: %w", seriesID, err)
+			}
+			fmt.Fprintf(os.Stdout, "%-*s  %s\n", idWidth, seriesID, spark)
+			citations = append(citations, meta.CitationText)
+		}
+		printChartCitations(citations...)
+		return nil
+	},
+}
+
+// ─── chart histogram ─────────────────────────────────────────────────────────
+
+var (
+	chartHistBins  int
+	chartHistWidth int
+)
+
+var chartHistogramCmd = &cobra.Command{
+	Use:   "histogram",
+	Short: "Distribution of values as horizontal bars, one per bucket",
+	Long: `Renders a histogram of the observation values: the range is split into
+equal-width buckets and each bar's length is the number of values in it.
+Rows are labeled with the bucket midpoint and count.
+
+--bins 0 (the default) picks the bucket count with Sturges' rule,
+ceil(log2 n) + 1. NaN observations are excluded and counted in a note below
+the chart.`,
+	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve chart histogram
+  reserve obs get UNRATE --from cache --format jsonl | reserve chart histogram --bins 20 --width 80
+  reserve obs get SP500 --from cache --format jsonl | reserve transform pct-change | reserve chart histogram`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if chartHistBins < 0 {
+			return fmt.Errorf("--bins must be >= 0")
+		}
+		seriesID, obs, err := pipeline.ReadObservations(os.Stdin)
+		if err != nil {
+			return err
+		}
+		if seriesID == "" {
+			seriesID = "series"
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		meta, err := ensureSeriesCompliance(cmd.Context(), deps, seriesID, "display")
+		if err != nil {
+			return err
+		}
+		if err := chart.Histogram(os.Stdout, seriesID, obs, chartHistBins, chart.BarOptions{
+			Width: chartHistWidth,
+			Color: useColor(os.Stdout),
 		}); err != nil {
 			return err
 		}
@@ -142,18 +465,203 @@ $COLUMNS (falls back to 80). Override with --width and --height.`,
 	},
 }
 
+// ─── chart heatmap ───────────────────────────────────────────────────────────
+
+var chartHeatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "Year × month grid shaded by value, for spotting seasonality",
+	Long: `Renders a monthly or quarterly series as a grid: one row per year, one
+column per month (or quarter). Each cell is shaded ░▒▓█ by where its value
+sits between the series minimum and maximum, so a recurring seasonal pattern
+shows up as a vertical band.
+
+The frequency is detected from the spacing of dates; other frequencies are
+rejected, so resample daily or weekly data first. NaN values show as "." and
+months outside the data, such as the ends of a partial year, are blank.`,
+	Example: `  reserve obs get RSXFSN --start 2015-01-01 --format jsonl | reserve chart heatmap
+  reserve obs get GDP --from cache --format jsonl | reserve transform pct-change | reserve chart heatmap
+  reserve obs get ICSA --from cache --format jsonl | reserve transform resample --freq monthly | reserve chart heatmap`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seriesID, obs, err := pipeline.ReadObservations(os.Stdin)
+		if err != nil {
+			return err
+		}
+		if seriesID == "" {
+			seriesID = "series"
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		meta, err := ensureSeriesCompliance(cmd.Context(), deps, seriesID, "display")
+		if err != nil {
+			return err
+		}
+		if err := chart.HeatMap(os.Stdout, seriesID, obs); err != nil {
+			return err
+		}
+		if meta.CitationText != "" {
+			fmt.Fprintf(os.Stdout, "\n%s\n", meta.CitationText)
+		}
+		return nil
+	},
+}
+
+// ─── chart scatter ───────────────────────────────────────────────────────────
+
+var (
+	chartScatterVs     string
+	chartScatterX      string
+	chartScatterY      string
+	chartScatterFit    bool
+	chartScatterWidth  int
+	chartScatterHeight int
+	chartScatterTitle  string
+)
+
+var chartScatterCmd = &cobra.Command{
+	Use:   "scatter [SERIES_X]",
+	Short: "Scatter one series against another",
+	Long: `Plots one series against another as points, one per date where both have
+a value. Dates present in only one series, or where either value is NaN, are
+dropped.
+
+The series come from the local store (SERIES_X and --vs SERIES_Y) or, with
+--x and --y, from a multi-series JSONL stream on stdin such as the output of
+obs get with several series IDs.
+
+Cells holding several points render as ● rather than •. Add --fit (or its
+alias --trend) to draw the OLS regression line of Y on X underneath the
+points, with its equation and R² in the legend.`,
+	Example: `  reserve chart scatter UNRATE --vs CPIAUCSL
+  reserve chart scatter UNRATE --vs FEDFUNDS --fit
+  reserve chart scatter T10Y2Y --vs UNRATE --fit --width 100 --height 20
+  reserve obs get UNRATE FEDFUNDS --start 2000-01-01 --format jsonl | reserve chart scatter --x UNRATE --y FEDFUNDS --trend`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromStdin := chartScatterX != "" || chartScatterY != ""
+		switch {
+		case fromStdin && (len(args) > 0 || chartScatterVs != ""):
+			return fmt.Errorf("--x and --y read series from stdin and cannot be combined with SERIES_X or --vs")
+		case fromStdin && (chartScatterX == "" || chartScatterY == ""):
+			return fmt.Errorf("--x and --y must be given together")
+		case !fromStdin && len(args) == 0:
+			return fmt.Errorf("give SERIES_X and --vs SERIES_Y, or --x and --y to read from stdin")
+		case !fromStdin && chartScatterVs == "":
+			return fmt.Errorf("--vs is required")
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+
+		var xID, yID string
+		var xObs, yObs []model.Observation
+		if fromStdin {
+			xID, yID = resolveSeriesID(deps, chartScatterX), resolveSeriesID(deps, chartScatterY)
+			if xID == yID {
+				return fmt.Errorf("--y must name a different series than %s", xID)
+			}
+			groups, err := pipeline.ReadObservationGroups(os.Stdin)
+			if err != nil {
+				return err
+			}
+			if xObs, err = streamSeries(groups, xID); err != nil {
+				return fmt.Errorf("--x: %w", err)
+			}
+			if yObs, err = streamSeries(groups, yID); err != nil {
+				return fmt.Errorf("--y: %w", err)
+			}
+		} else {
+			xID, yID = resolveSeriesID(deps, args[0]), resolveSeriesID(deps, chartScatterVs)
+			if xID == yID {
+				return fmt.Errorf("--vs must name a different series than %s", xID)
+			}
+			if xObs, err = cachedObservations(deps, xID); err != nil {
+				return err
+			}
+			if yObs, err = cachedObservations(deps, yID); err != nil {
+				return fmt.Errorf("--vs: %w", err)
+			}
+		}
+		xMeta, err := ensureSeriesCompliance(cmd.Context(), deps, xID, "display")
+		if err != nil {
+			return err
+		}
+		yMeta, err := ensureSeriesCompliance(cmd.Context(), deps, yID, "display")
+		if err != nil {
+			return err
+		}
+		pairs, span := chart.PairByDate(xObs, yObs)
+		if err := chart.Scatter(os.Stdout, xID, yID, pairs, chart.ScatterOptions{
+			Width:  chartScatterWidth,
+			Height: chartScatterHeight,
+			Title:  chartScatterTitle,
+			Span:   span,
+			Fit:    chartScatterFit,
+			Color:  useColor(os.Stdout),
+		}); err != nil {
+			return err
+		}
+		printChartCitations(xMeta.CitationText, yMeta.CitationText)
+		return nil
+	},
+}
+
+// streamSeries returns the observations of seriesID from a multi-series
+// stdin stream.
+func streamSeries(groups []pipeline.ObservationGroup, seriesID string) ([]model.Observation, error) {
+	ids := make([]string, len(groups))
+	for i, g := range groups {
+		if g.SeriesID == seriesID {
+			return g.Obs, nil
+		}
+		ids[i] = g.SeriesID
+	}
+	return nil, fmt.Errorf("series %s is not in the input stream (found %s)", seriesID, strings.Join(ids, ", "))
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+// printChartCitations prints each distinct non-empty citation after a blank
+// line, in the order given.
+func printChartCitations(citations ...string) {
+	seen := map[string]bool{}
+	var out []string
+	for _, c := range citations {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			out = append(out, c)
+		}
+	}
+	if len(out) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stdout)
+	for _, c := range out {
+		fmt.Fprintln(os.Stdout, c)
+	}
+}
+
 // ─── Registration ─────────────────────────────────────────────────────────────
 
 func init() {
 	rootCmd.AddCommand(chartCmd)
 	chartCmd.AddCommand(chartBarCmd)
 	chartCmd.AddCommand(chartPlotCmd)
+	chartCmd.AddCommand(chartSparkCmd)
+	chartCmd.AddCommand(chartHistogramCmd)
+	chartCmd.AddCommand(chartHeatmapCmd)
+	chartCmd.AddCommand(chartScatterCmd)
 
 	// bar flags
 	chartBarCmd.Flags().IntVar(&chartBarWidth, "width", 0,
 		"total chart width in characters (default: auto-detect from $COLUMNS, fallback 80)")
 	chartBarCmd.Flags().IntVar(&chartBarMaxBars, "max-bars", 0,
 		"maximum bars to render — takes the last N if series is longer (0 = no limit)")
+	chartBarCmd.Flags().StringVar(&chartBarTheme, "theme", chart.ThemeLight,
+		"SVG color theme: light or dark (requires --format svg)")
 
 	// plot flags
 	chartPlotCmd.Flags().IntVar(&chartPlotWidth, "width", 0,
@@ -162,8 +670,56 @@ func init() {
 		"chart height in rows (default 12)")
 	chartPlotCmd.Flags().StringVar(&chartPlotTitle, "title", "",
 		"chart title (default: series ID)")
+	chartPlotCmd.Flags().StringVar(&chartPlotOverlay, "overlay", "",
+		"cached series ID to draw on the same axes")
+	_ = chartPlotCmd.RegisterFlagCompletionFunc("overlay", completeSeriesIDFlag)
+	chartPlotCmd.Flags().BoolVar(&chartPlotSeparateAxes, "separate-axes", false,
+		"scale the overlay independently on a right-hand axis (requires --overlay)")
+	chartPlotCmd.Flags().BoolVar(&chartPlotMulti, "multi", false,
+		"plot every series in a multi-series JSONL stream on one chart")
+	chartPlotCmd.Flags().BoolVar(&chartPlotSeparateAxes, "dual-axis", false,
+		"with --multi, scale every series after the first on a right-hand axis")
+	chartPlotCmd.Flags().BoolVar(&chartPlotRecessions, "recessions", false,
+		"shade NBER recession periods (USREC) behind the line")
+	chartPlotCmd.Flags().BoolVar(&chartPlotLog, "log", false,
+		"log10 Y axis with ticks at powers of ten (values must be positive)")
+	chartPlotCmd.Flags().StringVar(&chartPlotTheme, "theme", chart.ThemeLight,
+		"SVG color theme: light or dark (requires --format svg)")
+
+	// spark flags
+	chartSparkCmd.Flags().IntVar(&chartSparkWidth, "width", chart.DefaultSparkWidth,
+		"maximum sparkline width in characters; longer series are averaged into columns")
+
+	// histogram flags
+	chartHistogramCmd.Flags().IntVar(&chartHistBins, "bins", 0,
+		"number of value buckets (0 = Sturges' rule)")
+	chartHistogramCmd.Flags().IntVar(&chartHistWidth, "width", 0,
+		"total chart width in characters (default: auto-detect from $COLUMNS, fallback 80)")
+
+	// scatter flags
+	chartScatterCmd.Flags().StringVar(&chartScatterVs, "vs", "",
+		"cached series ID plotted on the Y axis (required with SERIES_X)")
+	_ = chartScatterCmd.RegisterFlagCompletionFunc("vs", completeSeriesIDFlag)
+	chartScatterCmd.Flags().StringVar(&chartScatterX, "x", "",
+		"series ID from the stdin stream plotted on the X axis (use with --y)")
+	chartScatterCmd.Flags().StringVar(&chartScatterY, "y", "",
+		"series ID from the stdin stream plotted on the Y axis (use with --x)")
+	chartScatterCmd.Flags().BoolVar(&chartScatterFit, "fit", false,
+		"overlay the OLS regression line of Y on X")
+	chartScatterCmd.Flags().BoolVar(&chartScatterFit, "trend", false,
+		"alias for --fit")
+	chartScatterCmd.Flags().IntVar(&chartScatterWidth, "width", 0,
+		"chart width in characters (default: auto-detect from $COLUMNS, fallback 80)")
+	chartScatterCmd.Flags().IntVar(&chartScatterHeight, "height", 12,
+		"chart height in rows (default 12)")
+	chartScatterCmd.Flags().StringVar(&chartScatterTitle, "title", "",
+		"chart title (default: \"<SERIES_Y> vs <SERIES_X>\")")
 
 	chartCmd.SilenceUsage = true
 	chartBarCmd.SilenceUsage = true
 	chartPlotCmd.SilenceUsage = true
+	chartSparkCmd.SilenceUsage = true
+	chartHistogramCmd.SilenceUsage = true
+	chartHeatmapCmd.SilenceUsage = true
+	chartScatterCmd.SilenceUsage = true
 }
@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/transform"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compareStart string
+	compareEnd   string
+	compareFrom  string
+	compareAlign string
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <SERIES_ID1> <SERIES_ID2>",
+	Short: "Show two series side by side with their period-over-period % change",
+	Long: `Fetches two series and prints them side by side on shared dates, with each
+series' percent change from the previous row.
+
+--align right (the default) lines up series of different frequencies at
+period end: each date of the lower-frequency series takes the other series'
+last value within that period. Series on the same dates are simply inner
+joined. --align inner keeps only dates both series report exactly.
+
+--format jsonl emits one wide row per date for downstream tools.`,
+	Example: `  reserve compare UNRATE FEDFUNDS --start 2020-01-01
+  reserve compare GDP DGS10 --start 2015-01-01
+  reserve compare CPIAUCSL PCEPI --from cache --format jsonl`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("compare needs exactly two series IDs, got %d", len(args))
+		}
+		return nil
+	},
+	ValidArgsFunction: completeSeriesIDs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mode := transform.JoinMode(strings.ToLower(compareAlign))
+		if mode != transform.JoinRight && mode != transform.JoinInner {
+			return fmt.Errorf("--align must be right or inner, got %q", compareAlign)
+		}
+		for flag, v := range map[string]string{"--start": compareStart, "--end": compareEnd} {
+			if v == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return fmt.Errorf("%s: invalid date %q, expected YYYY-MM-DD", flag, v)
+			}
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		src, err := resolveObsSource(compareFrom)
+		if err != nil {
+			return err
+		}
+		if err := validateObsSourceConfig(deps, src); err != nil {
+			return err
+		}
+		ids := resolveSeriesIDs(deps, args)
+		if ids[0] == ids[1] {
+			return fmt.Errorf("compare needs two different series, got %s twice", ids[0])
+		}
+
+		opts := fred.ObsOptions{Start: compareStart, End: compareEnd}
+		results, warnings, _ := batchGetObs(cmd.Context(), deps, ids, opts, src)
+		if len(results) != 2 {
+			return fmt.Errorf("compare needs both series: %s", strings.Join(warnings, "; "))
+		}
+		table, err := buildCompareTable(results[0], results[1], mode)
+		if err != nil {
+			return err
+		}
+
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		format := resolveFormat(deps.Config.Format)
+		if err := writeCompareTable(w, format, table, useColor(w)); err != nil {
+			return err
+		}
+		if len(warnings) > 0 {
+			render.PrintFooter(obsFooterWriter(cmd, format), &model.Result{Warnings: warnings}, deps.Config.Verbose)
+		}
+		return nil
+	},
+}
+
+// compareTable is two series joined on date, with each series' percent change
+// from the previous row.
+type compareTable struct {
+	IDs      [2]string
+	Rows     []compareRow
+	Citation string
+}
+
+type compareRow struct {
+	Date   time.Time
+	Values [2]float64
+	Pct    [2]float64 // NaN on the first row
+}
+
+func buildCompareTable(a, b *model.SeriesData, mode transform.JoinMode) (compareTable, error) {
+	t := compareTable{
+		IDs:      [2]string{a.SeriesID, b.SeriesID},
+		Citation: obsCitationFooter([]*model.SeriesData{a, b}),
+	}
+	joined, err := transform.Join(a.Obs, b.Obs, mode)
+	if err != nil {
+		return t, err
+	}
+	if len(joined) == 0 {
+		return t, fmt.Errorf("%s and %s have no dates in common", a.SeriesID, b.SeriesID)
+	}
+	for i, j := range joined {
+		row := compareRow{Date: j.Date, Values: [2]float64{j.A, j.B}, Pct: [2]float64{math.NaN(), math.NaN()}}
+		if i > 0 {
+			prev := t.Rows[i-1].Values
+			for k := range row.Values {
+				if prev[k] != 0 {
+					row.Pct[k] = (row.Values[k] - prev[k]) / math.Abs(prev[k]) * 100
+				}
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+func writeCompareTable(w io.Writer, format string, t compareTable, color bool) error {
+	switch format {
+	case render.FormatJSON, render.FormatJSONL, render.FormatYAML:
+		rows := make([]json.RawMessage, 0, len(t.Rows))
+		for _, r := range t.Rows {
+			b, err := t.rowJSON(r)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, b)
+		}
+		if format == render.FormatJSONL {
+			for _, r := range rows {
+				if _, err := fmt.Fprintf(w, "%s\n", r); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if format == render.FormatYAML {
+			return render.EncodeYAML(w, rows)
+		}
+		out, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", out)
+		return err
+	}
+	a, b := t.IDs[0], t.IDs[1]
+	headers := []string{"DATE", a, b, a + " PCT CHANGE", b + " PCT CHANGE"}
+	printSimpleTable(w, headers, func(add func(...string)) {
+		for _, r := range t.Rows {
+			add(r.Date.Format("2006-01-02"),
+				fmt.Sprintf("%g", r.Values[0]),
+				fmt.Sprintf("%g", r.Values[1]),
+				fmtSignedPctTable(r.Pct[0], color),
+				fmtSignedPctTable(r.Pct[1], color))
+		}
+	})
+	if t.Citation != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, t.Citation)
+	}
+	return nil
+}
+
+// rowJSON encodes r as one wide object keyed by date, each series ID, and
+// <ID>_pct_change, in that order. Missing percent changes are null.
+func (t compareTable) rowJSON(r compareRow) ([]byte, error) {
+	type field struct {
+		key   string
+		value any
+	}
+	num := func(v float64) any {
+		if math.IsNaN(v) {
+			return nil
+		}
+		return v
+	}
+	fields := []field{
+		{"date", r.Date.Format("2006-01-02")},
+		{t.IDs[0], num(r.Values[0])},
+		{t.IDs[1], num(r.Values[1])},
+		{t.IDs[0] + "_pct_change", num(r.Pct[0])},
+		{t.IDs[1] + "_pct_change", num(r.Pct[1])},
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(f.key)
+		v, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+	compareCmd.Flags().StringVar(&compareStart, "start", "", "start date YYYY-MM-DD")
+	compareCmd.Flags().StringVar(&compareEnd, "end", "", "end date YYYY-MM-DD")
+	compareCmd.Flags().StringVar(&compareFrom, "from", "", "data source: live|cache (default: live)")
+	compareCmd.Flags().StringVar(&compareAlign, "align", string(transform.JoinRight),
+		"date alignment: right (period end of the lower-frequency series) or inner (exact dates)")
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/transform"
+)
+
+func compareSeries(id string, dates []time.Time, values ...float64) *model.SeriesData {
+	sd := &model.SeriesData{SeriesID: id}
+	for i, v := range values {
+		sd.Obs = append(sd.Obs, model.Observation{Date: dates[i], Value: v})
+	}
+	return sd
+}
+
+func monthStarts(year, n int) []time.Time {
+	out := make([]time.Time, n)
+	for i := range out {
+		out[i] = time.Date(year, time.Month(i+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return out
+}
+
+func TestCompareTableIdenticalDates(t *testing.T) {
+	dates := monthStarts(2020, 3)
+	a := compareSeries("UNRATE", dates, 4, 5, 5)
+	b := compareSeries("FEDFUNDS", dates, 2, 1, 0.5)
+
+	table, err := buildCompareTable(a, b, transform.JoinRight)
+	if err != nil {
+		t.Fatalf("buildCompareTable: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeCompareTable(&buf, "table", table, false); err != nil {
+		t.Fatalf("writeCompareTable: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"DATE", "UNRATE PCT CHANGE", "FEDFUNDS PCT CHANGE", "2020-03-01", "25.00%", "-50.00%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in table:\n%s", want, out)
+		}
+	}
+}
+
+func TestCompareTableRightAlignsDifferentFrequencies(t *testing.T) {
+	var days []time.Time
+	var vals []float64
+	for d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC); d.Before(time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)); d = d.AddDate(0, 0, 1) {
+		days = append(days, d)
+		vals = append(vals, float64(int(d.Month())*100+d.Day()))
+	}
+	daily := compareSeries("DFF", days, vals...)
+	monthly := compareSeries("UNRATE", monthStarts(2020, 3), 3.5, 3.5, 4.4)
+
+	table, err := buildCompareTable(monthly, daily, transform.JoinRight)
+	if err != nil {
+		t.Fatalf("buildCompareTable: %v", err)
+	}
+	if len(table.Rows) != 3 {
+		t.Fatalf("expected one row per month, got %d", len(table.Rows))
+	}
+	// Each month takes the daily series' month-end value.
+	for i, want := range []float64{131, 229, 331} {
+		if got := table.Rows[i].Values[1]; got != want {
+			t.Errorf("row %d: DFF=%g want %g", i, got, want)
+		}
+	}
+}
+
+func TestCompareTableJSON(t *testing.T) {
+	dates := monthStarts(2020, 2)
+	table, err := buildCompareTable(
+		compareSeries("UNRATE", dates, 4, 5),
+		compareSeries("FEDFUNDS", dates, 2, 1),
+		transform.JoinInner)
+	if err != nil {
+		t.Fatalf("buildCompareTable: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writeCompareTable(&buf, "json", table, false); err != nil {
+		t.Fatalf("writeCompareTable: %v", err)
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0]["UNRATE_pct_change"] != nil {
+		t.Errorf("first row pct change should be null, got %v", rows[0]["UNRATE_pct_change"])
+	}
+	if rows[1]["date"] != "2020-02-01" || rows[1]["FEDFUNDS"] != 1.0 || rows[1]["UNRATE_pct_change"] != 25.0 {
+		t.Errorf("unexpected second row %v", rows[1])
+	}
+	if !strings.HasPrefix(strings.TrimSpace(strings.SplitN(buf.String(), "\n", 3)[1]), `{`) {
+		t.Errorf("expected an indented array of objects:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeCompareTable(&buf, "jsonl", table, false); err != nil {
+		t.Fatalf("writeCompareTable jsonl: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], `{"date":"2020-02-01","UNRATE":5,"FEDFUNDS":1,`) {
+		t.Errorf("unexpected JSONL:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeCompareTable(&buf, "yaml", table, false); err != nil {
+		t.Fatalf("writeCompareTable yaml: %v", err)
+	}
+	if out := buf.String(); !strings.HasPrefix(out, "- date: \"2020-01-01\"\n") || !strings.Contains(out, "UNRATE_pct_change: null") {
+		t.Errorf("unexpected YAML:\n%s", out)
+	}
+}
+
+func TestCompareRequiresTwoSeries(t *testing.T) {
+	err := compareCmd.Args(compareCmd, []string{"UNRATE"})
+	if err == nil || !strings.Contains(err.Error(), "exactly two") {
+		t.Fatalf("expected two-series error, got %v", err)
+	}
+	if err := compareCmd.Args(compareCmd, []string{"UNRATE", "FEDFUNDS"}); err != nil {
+		t.Fatalf("two series should be accepted: %v", err)
+	}
+}
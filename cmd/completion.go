@@ -26,7 +26,12 @@ To load completions in the current shell session:
   reserve completion fish | source
 
 Persist across sessions by adding the source line to your shell profile
-(~/.bashrc, ~/.zshrc, ~/.config/fish/completions/reserve.fish, etc.).`,
+(~/.bashrc, ~/.zshrc, ~/.config/fish/completions/reserve.fish, etc.).
+
+Besides subcommands and flags, commands that take series IDs (obs get,
+compare, chart plot, report --series, ...) complete them from the series
+metadata stored in the local database, so fetch a series once to make it
+completable. Nothing is offered when the database does not exist yet.`,
 	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
 	Args:                  cobra.ExactValidArgs(1),
 	DisableFlagsInUseLine: true,
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/store"
+)
+
+// runCompletion drives the hidden __complete command the shell scripts call
+// on <TAB> and returns the candidate lines, without the trailing directive.
+func runCompletion(t *testing.T, args ...string) []string {
+	t.Helper()
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetArgs(append([]string{"__complete"}, args...))
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetArgs(nil)
+	})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("__complete %v: %v", args, err)
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if !strings.HasPrefix(line, ":") {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func seedCompletionStore(t *testing.T) {
+	t.Helper()
+	s, err := store.Open(os.Getenv(config.EnvDBPath))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	for _, meta := range []model.SeriesMeta{
+		{ID: "UNRATE", Title: "Unemployment Rate"},
+		{ID: "FEDFUNDS", Title: "Federal Funds Effective Rate"},
+		{ID: "GDP", Title: "Gross Domestic Product"},
+	} {
+		if err := s.PutSeriesMeta(meta); err != nil {
+			t.Fatalf("put %s: %v", meta.ID, err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSeriesIDCompletionFromStore(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	seedCompletionStore(t)
+
+	got := strings.Join(runCompletion(t, "obs", "get", ""), "\n")
+	for _, want := range []string{"UNRATE\tUnemployment Rate", "FEDFUNDS\tFederal Funds Effective Rate", "GDP\tGross Domestic Product"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected candidate %q, got:\n%s", want, got)
+		}
+	}
+
+	if got := runCompletion(t, "obs", "get", "GDP", "f"); len(got) != 1 || !strings.HasPrefix(got[0], "FEDFUNDS") {
+		t.Errorf("prefix f should complete to FEDFUNDS only, got %q", got)
+	}
+	if got := runCompletion(t, "compare", "UNRATE", ""); len(got) != 2 || strings.Contains(strings.Join(got, " "), "UNRATE") {
+		t.Errorf("compare's second ID should offer the other two series, got %q", got)
+	}
+	if got := runCompletion(t, "watch", "UNRATE", ""); len(got) != 0 {
+		t.Errorf("watch takes one series, got candidates %q", got)
+	}
+	if got := runCompletion(t, "report", "--series", "UNRATE,G"); len(got) != 1 || !strings.HasPrefix(got[0], "UNRATE,GDP\t") {
+		t.Errorf("a comma-separated --series value should complete its last ID, got %q", got)
+	}
+}
+
+func TestSeriesIDCompletionWithoutStore(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	if got := runCompletion(t, "obs", "get", ""); len(got) != 0 {
+		t.Errorf("no store should mean no candidates, got %q", got)
+	}
+	if _, err := os.Stat(os.Getenv(config.EnvDBPath)); !os.IsNotExist(err) {
+		t.Errorf("completion should not create the database, stat err=%v", err)
+	}
+}
@@ -4,15 +4,22 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/keychain"
 	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/spf13/cobra"
@@ -51,12 +58,16 @@ var configInitCmd = &cobra.Command{
 }
 
 var configGetShowSecrets bool
+var configSetUseKeychain bool
+
+// keychainSet writes to the OS keychain; tests replace it.
+var keychainSet = keychain.Set
 
 var configGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Print the current resolved configuration",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load(globalFlags.APIKey)
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
@@ -89,7 +100,7 @@ var configGetCmd = &cobra.Command{
 		}
 
 		switch format {
-		case render.FormatJSON:
+		case render.FormatJSON, render.FormatYAML:
 			w, closeFn, err := outputWriter(cmd.OutOrStdout())
 			if err != nil {
 				return err
@@ -103,6 +114,7 @@ var configGetCmd = &cobra.Command{
 				Concurrency                        int                     `json:"concurrency"`
 				Rate                               float64                 `json:"rate"`
 				BaseURL                            string                  `json:"base_url"`
+				ProxyURL                           string                  `json:"proxy_url,omitempty"`
 				DBPath                             string                  `json:"db_path"`
 				PersonOrgType                      string                  `json:"person_org_type"`
 				BlockUnknownRights                 bool                    `json:"block_unknown_rights"`
@@ -116,17 +128,20 @@ var configGetCmd = &cobra.Command{
 				Snippet                            config.SnippetSystem    `json:"snippet,omitempty"`
 				RightsRefreshDays                  map[string]int          `json:"rights_refresh_days"`
 				LogComplianceDecisions             bool                    `json:"log_compliance_decisions"`
+				ObservationTimezone                string                  `json:"observation_timezone"`
+				Email                              *config.Email           `json:"email,omitempty"`
 				ConfigFile                         string                  `json:"config_file"`
+				Profile                            string                  `json:"profile,omitempty"`
+				ProfileFile                        string                  `json:"profile_file,omitempty"`
 			}
-			enc := json.NewEncoder(w)
-			enc.SetIndent("", "  ")
-			return enc.Encode(configOut{
+			out := configOut{
 				APIKey:                             apiKey,
 				Format:                             cfg.Format,
 				Timeout:                            cfg.Timeout.String(),
 				Concurrency:                        cfg.Concurrency,
 				Rate:                               cfg.Rate,
 				BaseURL:                            cfg.BaseURL,
+				ProxyURL:                           cfg.RedactedProxyURL(),
 				DBPath:                             cfg.DBPath,
 				PersonOrgType:                      cfg.PersonOrgType,
 				BlockUnknownRights:                 cfg.BlockUnknownRights,
@@ -140,8 +155,18 @@ var configGetCmd = &cobra.Command{
 				Snippet:                            cfg.Snippet,
 				RightsRefreshDays:                  cfg.RightsRefreshDays,
 				LogComplianceDecisions:             cfg.LogComplianceDecisions,
+				ObservationTimezone:                cfg.ObservationTimezone,
+				Email:                              configuredEmail(cfg.Email),
 				ConfigFile:                         src,
-			})
+				Profile:                            cfg.ActiveProfile(),
+				ProfileFile:                        cfg.ProfilePath,
+			}
+			if format == render.FormatYAML {
+				return render.EncodeYAML(w, out)
+			}
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
 		default:
 			_ = result
 			w, closeFn, err := outputWriter(cmd.OutOrStdout())
@@ -157,6 +182,7 @@ var configGetCmd = &cobra.Command{
 				{"concurrency", fmt.Sprintf("%d", cfg.Concurrency)},
 				{"rate", fmt.Sprintf("%.1f req/s", cfg.Rate)},
 				{"base_url", cfg.BaseURL},
+				{"proxy_url", cfg.RedactedProxyURL()},
 				{"db_path", cfg.DBPath},
 				{"person_org_type", cfg.PersonOrgType},
 				{"block_unknown_rights", fmt.Sprintf("%t", cfg.BlockUnknownRights)},
@@ -173,8 +199,21 @@ var configGetCmd = &cobra.Command{
 				{"rights_refresh_days.export", fmt.Sprintf("%d", cfg.RightsRefreshDaysFor("export"))},
 				{"rights_refresh_days.publish", fmt.Sprintf("%d", cfg.RightsRefreshDaysFor("publish"))},
 				{"log_compliance_decisions", fmt.Sprintf("%t", cfg.LogComplianceDecisions)},
+				{"observation_timezone", cfg.ObservationTimezone},
+				{"email.smtp_addr", cfg.Email.SMTPAddr},
+				{"email.from", cfg.Email.From},
+				{"email.to", strings.Join(cfg.Email.To, ", ")},
+				{"email.username", cfg.Email.Username},
 				{"config_file", src},
 			}
+			rows = append(rows, []string{"profile", cfg.ActiveProfile()})
+			if cfg.Profile != "" {
+				profileSrc := "(not found)"
+				if cfg.ProfilePath != "" {
+					profileSrc = cfg.ProfilePath
+				}
+				rows = append(rows, []string{"profile_file", profileSrc})
+			}
 			printKVTableTo(w, rows)
 			return nil
 		}
@@ -188,6 +227,9 @@ var configSetCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := strings.ToLower(args[0])
 		val := args[1]
+		if configSetUseKeychain && key != "api_key" {
+			return fmt.Errorf("--use-keychain only applies to api_key")
+		}
 
 		// Load existing file or start from template
 		var f config.File
@@ -204,6 +246,12 @@ var configSetCmd = &cobra.Command{
 
 		switch key {
 		case "api_key":
+			if configSetUseKeychain {
+				if err := keychainSet(config.KeychainService, config.KeychainAccount, val); err != nil {
+					return err
+				}
+				val = config.KeychainAPIKey
+			}
 			f.APIKey = val
 		case "default_format", "format":
 			f.DefaultFormat = val
@@ -223,6 +271,13 @@ var configSetCmd = &cobra.Command{
 			f.Rate = r
 		case "base_url":
 			f.BaseURL = val
+		case "proxy_url":
+			if val = strings.TrimSpace(val); val != "" {
+				if _, err := fred.ParseProxyURL(val); err != nil {
+					return err
+				}
+			}
+			f.ProxyURL = val
 		case "db_path":
 			f.DBPath = val
 		case "person_org_type":
@@ -271,6 +326,31 @@ var configSetCmd = &cobra.Command{
 			f.Snippet.Home = strings.TrimSpace(val)
 		case "snippet.enabled":
 			f.Snippet.Enabled = splitCSV(val)
+		case "profile":
+			f.Profile = strings.TrimSpace(val)
+		case "email.smtp_addr", "email.from", "email.to", "email.username":
+			if f.Email == nil {
+				f.Email = &config.Email{}
+			}
+			switch key {
+			case "email.smtp_addr":
+				val = strings.TrimSpace(val)
+				if _, _, err := net.SplitHostPort(val); val != "" && err != nil {
+					return fmt.Errorf("email.smtp_addr must be host:port, got %q", val)
+				}
+				f.Email.SMTPAddr = val
+			case "email.from":
+				f.Email.From = strings.TrimSpace(val)
+			case "email.to":
+				f.Email.To = splitCSV(val)
+			case "email.username":
+				f.Email.Username = strings.TrimSpace(val)
+			}
+		case "observation_timezone":
+			if _, err := time.LoadLocation(val); err != nil {
+				return fmt.Errorf("observation_timezone: unknown time zone %q", val)
+			}
+			f.ObservationTimezone = val
 		default:
 			return fmt.Errorf("unknown config key: %q", key)
 		}
@@ -278,6 +358,10 @@ var configSetCmd = &cobra.Command{
 		if err := config.WriteFile(path, f); err != nil {
 			return err
 		}
+		if configSetUseKeychain {
+			fmt.Printf("✓ Stored api_key in the OS keychain; %s now reads it from there\n", path)
+			return nil
+		}
 		fmt.Printf("✓ Set %s in %s\n", key, path)
 		return nil
 	},
@@ -290,8 +374,9 @@ var configGrantCmd = &cobra.Command{
 series ID to granted_series_permissions in config.json.
 
 Use this only when you already have proper permission to use the series.`,
-	Example: `  reserve config grant BAMLC0A0CM`,
-	Args:    cobra.ExactArgs(1),
+	Example:           `  reserve config grant BAMLC0A0CM`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		f, path, err := loadOrTemplateConfig()
 		if err != nil {
@@ -312,8 +397,9 @@ var configRevokeCmd = &cobra.Command{
 	Short: "Remove local permission override for a specific series",
 	Long: `Remove a local permission override for one specific series from
 granted_series_permissions in config.json.`,
-	Example: `  reserve config revoke BAMLC0A0CM`,
-	Args:    cobra.ExactArgs(1),
+	Example:           `  reserve config revoke BAMLC0A0CM`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		f, path, err := loadOrTemplateConfig()
 		if err != nil {
@@ -341,7 +427,7 @@ var configListGrantsCmd = &cobra.Command{
 	Long:    `List the series IDs currently present in granted_series_permissions in config.json.`,
 	Example: `  reserve config list-grants`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.Load(globalFlags.APIKey)
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
@@ -356,6 +442,335 @@ var configListGrantsCmd = &cobra.Command{
 	},
 }
 
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade config.json to the current layout, keeping a backup",
+	Long: `Rewrite config.json in the current canonical layout.
+
+Settings added since the file was created are filled with the defaults that
+'config init' would write; existing values are left untouched. The original
+file is kept next to it as config.json.bak.`,
+	Example: `  reserve config migrate`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.PreferredConfigPath()
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("no config.json found at %s (run 'reserve config init' first)", path)
+		}
+		if err := config.Migrate(path); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Migrated %s\n", path)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Backup saved to %s.bak\n", path)
+		return nil
+	},
+}
+
+var configDoctorNoPing bool
+
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the configuration and report what needs fixing",
+	Long: `Doctor runs a checklist over the resolved configuration:
+
+  config file   config.json was found and parses
+  api key       an API key is set and is not a placeholder
+  db path       the database path is writable
+  FRED API      FRED answers a request for UNRATE's metadata with this key
+
+Each check prints ✓ (pass), ⚠ (works, but worth a look), ✗ (fail) or
+- (skipped). The command exits non-zero when any check fails. Use --no-ping to
+skip the network check.`,
+	Example: `  reserve config doctor
+  reserve --profile work config doctor --no-ping`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := resolveRuntimeConfig()
+		var ping func(context.Context) error
+		if err == nil && !configDoctorNoPing {
+			ping = app.NewClient(cfg).Ping
+		}
+		checks := runConfigDoctor(cmd.Context(), cfg, err, ping)
+		printDoctorChecks(cmd.OutOrStdout(), checks)
+		failed := 0
+		for _, c := range checks {
+			if c.status == doctorFail {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+		}
+		return nil
+	},
+}
+
+const (
+	doctorPass = "✓"
+	doctorWarn = "⚠"
+	doctorFail = "✗"
+	doctorSkip = "-"
+)
+
+// doctorCheck is one line of the config doctor checklist.
+type doctorCheck struct {
+	name   string
+	status string
+	detail string
+}
+
+// runConfigDoctor checks cfg, or reports loadErr when loading it failed. ping
+// is nil when the FRED check should be skipped.
+func runConfigDoctor(ctx context.Context, cfg *config.Config, loadErr error, ping func(context.Context) error) []doctorCheck {
+	if loadErr != nil {
+		return []doctorCheck{
+			{"config file", doctorFail, loadErr.Error()},
+			{"api key", doctorSkip, "config did not load"},
+			{"db path", doctorSkip, "config did not load"},
+			{"FRED API", doctorSkip, "config did not load"},
+		}
+	}
+
+	var checks []doctorCheck
+	switch {
+	case cfg.ConfigPath == "":
+		checks = append(checks, doctorCheck{"config file", doctorWarn, "no config.json found; run 'reserve config init' to create one"})
+	case cfg.Profile != "":
+		checks = append(checks, doctorCheck{"config file", doctorPass, fmt.Sprintf("%s (profile %s)", cfg.ConfigPath, cfg.Profile)})
+	default:
+		checks = append(checks, doctorCheck{"config file", doctorPass, cfg.ConfigPath})
+	}
+
+	keyOK := false
+	switch {
+	case cfg.Validate() != nil:
+		checks = append(checks, doctorCheck{"api key", doctorFail, "not set; run 'reserve config set api_key YOUR_KEY' or export " + config.EnvAPIKey})
+	case isPlaceholderAPIKey(cfg.APIKey):
+		checks = append(checks, doctorCheck{"api key", doctorFail, fmt.Sprintf("%q is a placeholder; replace it with your FRED key", cfg.APIKey)})
+	case !fredAPIKeyPattern.MatchString(cfg.APIKey):
+		keyOK = true
+		checks = append(checks, doctorCheck{"api key", doctorWarn, cfg.RedactedAPIKey() + " does not look like a FRED key (32 lowercase letters and digits)"})
+	default:
+		keyOK = true
+		checks = append(checks, doctorCheck{"api key", doctorPass, cfg.RedactedAPIKey()})
+	}
+
+	if detail, err := checkDBPathWritable(cfg.DBPath); err != nil {
+		checks = append(checks, doctorCheck{"db path", doctorFail, err.Error()})
+	} else {
+		checks = append(checks, doctorCheck{"db path", doctorPass, detail})
+	}
+
+	switch {
+	case ping == nil:
+		checks = append(checks, doctorCheck{"FRED API", doctorSkip, "skipped (--no-ping)"})
+	case !keyOK:
+		checks = append(checks, doctorCheck{"FRED API", doctorSkip, "skipped until the API key is fixed"})
+	default:
+		start := time.Now()
+		if err := ping(ctx); err != nil {
+			checks = append(checks, doctorCheck{"FRED API", doctorFail, err.Error()})
+		} else {
+			checks = append(checks, doctorCheck{"FRED API", doctorPass, fmt.Sprintf("%s reachable (%dms)", cfg.BaseURL, time.Since(start).Milliseconds())})
+		}
+	}
+	return checks
+}
+
+func printDoctorChecks(w io.Writer, checks []doctorCheck) {
+	width := 0
+	for _, c := range checks {
+		width = max(width, len(c.name))
+	}
+	passed := 0
+	for _, c := range checks {
+		fmt.Fprintf(w, "  %s %-*s  %s\n", c.status, width, c.name, c.detail)
+		if c.status == doctorPass {
+			passed++
+		}
+	}
+	fmt.Fprintf(w, "\n%d/%d checks passed\n", passed, len(checks))
+}
+
+// fredAPIKeyPattern matches the shape of keys FRED issues.
+var fredAPIKeyPattern = regexp.MustCompile(`^[a-z0-9]{32}$`)
+
+// isPlaceholderAPIKey reports whether key is template text such as
+// YOUR_KEY_HERE rather than a real key.
+func isPlaceholderAPIKey(key string) bool {
+	upper := strings.ToUpper(key)
+	for _, marker := range []string{"YOUR", "PLACEHOLDER", "CHANGEME", "API_KEY", "XXXX", "<"} {
+		if strings.Contains(upper, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDBPathWritable reports whether the database at path can be written,
+// or created when it does not exist yet, without creating anything itself.
+func checkDBPathWritable(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("no db_path and no home directory to default to; set db_path or %s", config.EnvDBPath)
+	}
+	info, err := os.Stat(path)
+	if err == nil {
+		if info.IsDir() {
+			return "", fmt.Errorf("%s is a directory", path)
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return "", fmt.Errorf("%s is not writable: %w", path, err)
+		}
+		f.Close()
+		return path, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	// The file does not exist: the nearest existing directory above it must
+	// accept new files, since the store creates the rest on first use.
+	dir := filepath.Dir(path)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	f, err := os.CreateTemp(dir, ".reserve-doctor-*")
+	if err != nil {
+		return "", fmt.Errorf("cannot create %s: %w", path, err)
+	}
+	f.Close()
+	_ = os.Remove(f.Name())
+	return path + " (created on first use)", nil
+}
+
+var configProfileBaseURL string
+var configProfileDBPath string
+var configProfileDefaultFormat string
+
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named profiles stored in config.json",
+	Long: `Manage the "profiles" map in config.json. Each profile overrides some of the
+top-level keys, which act as the "default" profile. Select one with --profile
+NAME or RESERVE_PROFILE=NAME.`,
+}
+
+var configProfileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add or update a named profile in config.json",
+	Long: `Add a named profile to the "profiles" map in config.json, or update the keys
+of an existing one. The global --api-key, --timeout, --concurrency, --rate and
+--proxy flags give the profile's values; keys not given keep their current
+profile value, or the top-level value for a new profile.`,
+	Example: `  reserve config profile add work --api-key abcd1234 --rate 10
+  reserve --profile work obs get GDP`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(args[0])
+		if name == config.DefaultProfile {
+			return fmt.Errorf("%q is the top-level keys of config.json; use 'reserve config set' instead", name)
+		}
+		if !config.ValidProfileName(name) {
+			return fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_'", name)
+		}
+		f, path, err := loadOrTemplateConfig()
+		if err != nil {
+			return err
+		}
+		p := f.Profiles[name]
+		before := p
+		if globalFlags.APIKey != "" {
+			p.APIKey = globalFlags.APIKey
+		}
+		if configProfileDefaultFormat != "" {
+			if !config.IsValidFormat(configProfileDefaultFormat) {
+				return fmt.Errorf("--default-format must be one of table, json, jsonl, csv, tsv, md, yaml")
+			}
+			p.DefaultFormat = configProfileDefaultFormat
+		}
+		if globalFlags.Timeout != "" {
+			if d, err := time.ParseDuration(globalFlags.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("--timeout must be a positive duration, got %q", globalFlags.Timeout)
+			}
+			p.Timeout = globalFlags.Timeout
+		}
+		if globalFlags.Concurrency > 0 {
+			p.Concurrency = globalFlags.Concurrency
+		}
+		if globalFlags.Rate > 0 {
+			p.Rate = globalFlags.Rate
+		}
+		if v := strings.TrimSpace(globalFlags.Proxy); v != "" {
+			if _, err := fred.ParseProxyURL(v); err != nil {
+				return err
+			}
+			p.ProxyURL = v
+		}
+		if configProfileBaseURL != "" {
+			p.BaseURL = configProfileBaseURL
+		}
+		if configProfileDBPath != "" {
+			p.DBPath = configProfileDBPath
+		}
+		if p == before {
+			return fmt.Errorf("profile %q unchanged: give at least one of --api-key, --timeout, --concurrency, --rate, --proxy, --base-url, --db-path, --default-format", name)
+		}
+
+		if f.Profiles == nil {
+			f.Profiles = map[string]config.Profile{}
+		}
+		f.Profiles[name] = p
+		if err := config.WriteFile(path, *f); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Saved profile %s in %s\n", name, path)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Use it with: reserve --profile %s ...\n", name)
+		return nil
+	},
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the profiles stored in config.json",
+	Example: `  reserve config profile list`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		f, _, err := loadConfigFile()
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		names := []string{config.DefaultProfile}
+		if f != nil {
+			for name := range f.Profiles {
+				names = append(names, name)
+			}
+			sort.Strings(names[1:])
+		}
+		for _, name := range names {
+			marker := " "
+			if name == cfg.ActiveProfile() {
+				marker = "*"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", marker, name)
+		}
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
 	configCmd.AddCommand(configInitCmd)
@@ -364,8 +779,19 @@ func init() {
 	configCmd.AddCommand(configGrantCmd)
 	configCmd.AddCommand(configRevokeCmd)
 	configCmd.AddCommand(configListGrantsCmd)
+	configCmd.AddCommand(configMigrateCmd)
+	configCmd.AddCommand(configDoctorCmd)
+	configCmd.AddCommand(configProfileCmd)
+	configProfileCmd.AddCommand(configProfileAddCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
 
 	configGetCmd.Flags().BoolVar(&configGetShowSecrets, "show-secrets", false, "show API key in plain text")
+	configSetCmd.Flags().BoolVar(&configSetUseKeychain, "use-keychain", false,
+		"store api_key in the OS keychain and write \"keychain\" to config.json in its place")
+	configDoctorCmd.Flags().BoolVar(&configDoctorNoPing, "no-ping", false, "skip the live FRED request")
+	configProfileAddCmd.Flags().StringVar(&configProfileBaseURL, "base-url", "", "FRED API base URL for this profile")
+	configProfileAddCmd.Flags().StringVar(&configProfileDBPath, "db-path", "", "cache database path for this profile")
+	configProfileAddCmd.Flags().StringVar(&configProfileDefaultFormat, "default-format", "", "default output format for this profile")
 }
 
 // loadConfigFile reads the preferred config.json for editing:
@@ -462,6 +888,15 @@ func setConfigIntMap(dst *map[string]int, nestedKey, key, val string) error {
 	return nil
 }
 
+// configuredEmail is e for `config get --format json`, or nil when no email
+// setting is present.
+func configuredEmail(e config.Email) *config.Email {
+	if e.SMTPAddr == "" && e.From == "" && len(e.To) == 0 && e.Username == "" {
+		return nil
+	}
+	return &e
+}
+
 func splitCSV(input string) []string {
 	parts := strings.Split(input, ",")
 	out := make([]string, 0, len(parts))
@@ -4,8 +4,12 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/derickschaefer/reserve/internal/config"
@@ -48,3 +52,217 @@ func TestConfigGrantAndRevoke(t *testing.T) {
 		t.Fatalf("expected BAMLC0A0CM grant to be removed")
 	}
 }
+
+func TestConfigSetUseKeychain(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := config.WriteFile(path, config.Template()); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	orig, _ := os.Getwd()
+	_ = os.Chdir(dir)
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+
+	var stored []string
+	origSet, origFlag := keychainSet, configSetUseKeychain
+	keychainSet = func(service, account, value string) error {
+		stored = append(stored, service, account, value)
+		return nil
+	}
+	configSetUseKeychain = true
+	t.Cleanup(func() { keychainSet, configSetUseKeychain = origSet, origFlag })
+
+	if err := configSetCmd.RunE(configSetCmd, []string{"api_key", "s3cret"}); err != nil {
+		t.Fatalf("config set: %v", err)
+	}
+	if strings.Join(stored, "|") != config.KeychainService+"|"+config.KeychainAccount+"|s3cret" {
+		t.Errorf("unexpected keychain write: %v", stored)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if strings.Contains(string(data), "s3cret") || !strings.Contains(string(data), `"api_key": "keychain"`) {
+		t.Errorf("config.json should hold the keychain marker, not the key:\n%s", data)
+	}
+
+	if err := configSetCmd.RunE(configSetCmd, []string{"db_path", "/tmp/x.db"}); err == nil {
+		t.Error("expected --use-keychain to be rejected for keys other than api_key")
+	}
+}
+
+func TestConfigSetEmail(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	path := filepath.Join(".", "config.json")
+	if err := config.WriteFile(path, config.Template()); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	for _, kv := range [][2]string{
+		{"email.smtp_addr", "smtp.example.com:587"},
+		{"email.from", "reserve@example.com"},
+		{"email.to", "a@example.com, B@example.com"},
+	} {
+		if err := configSetCmd.RunE(configSetCmd, []string{kv[0], kv[1]}); err != nil {
+			t.Fatalf("config set %s: %v", kv[0], err)
+		}
+	}
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	if !cfg.Email.Configured() || strings.Join(cfg.Email.To, "|") != "a@example.com|B@example.com" {
+		t.Errorf("unexpected email settings: %+v", cfg.Email)
+	}
+
+	if err := configSetCmd.RunE(configSetCmd, []string{"email.smtp_addr", "smtp.example.com"}); err == nil {
+		t.Error("expected email.smtp_addr without a port to be rejected")
+	}
+}
+
+func TestConfigProfileAdd(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	path := filepath.Join(".", "config.json")
+	base := config.Template()
+	base.APIKey = "personalkey"
+	if err := config.WriteFile(path, base); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	for _, name := range []string{"api-key", "rate", "profile"} {
+		resetGlobalFlag(t, name)
+	}
+	t.Cleanup(func() {
+		for _, name := range []string{"api-key", "rate", "profile"} {
+			resetGlobalFlag(t, name)
+		}
+	})
+	if err := rootCmd.PersistentFlags().Set("api-key", "workkey"); err != nil {
+		t.Fatalf("set api-key: %v", err)
+	}
+	if err := rootCmd.PersistentFlags().Set("rate", "10"); err != nil {
+		t.Fatalf("set rate: %v", err)
+	}
+
+	var out bytes.Buffer
+	configProfileAddCmd.SetOut(&out)
+	t.Cleanup(func() { configProfileAddCmd.SetOut(nil) })
+	if err := configProfileAddCmd.RunE(configProfileAddCmd, []string{"work"}); err != nil {
+		t.Fatalf("config profile add: %v", err)
+	}
+	if !strings.Contains(out.String(), "Saved profile work") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+	resetGlobalFlag(t, "api-key")
+	resetGlobalFlag(t, "rate")
+
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	if cfg.APIKey != "personalkey" || cfg.ActiveProfile() != config.DefaultProfile {
+		t.Errorf("top-level keys should stay the default profile, got %q (%s)", cfg.APIKey, cfg.ActiveProfile())
+	}
+	cfg, err = config.LoadProfile("", "work")
+	if err != nil {
+		t.Fatalf("config.LoadProfile: %v", err)
+	}
+	if cfg.APIKey != "workkey" || cfg.Rate != 10 {
+		t.Errorf("expected workkey at 10 req/s, got %q at %v", cfg.APIKey, cfg.Rate)
+	}
+
+	if err := configProfileAddCmd.RunE(configProfileAddCmd, []string{"work"}); err == nil {
+		t.Error("expected an error when no profile value is given")
+	}
+	if err := rootCmd.PersistentFlags().Set("rate", "5"); err != nil {
+		t.Fatalf("set rate: %v", err)
+	}
+	if err := configProfileAddCmd.RunE(configProfileAddCmd, []string{config.DefaultProfile}); err == nil {
+		t.Error("expected the default profile name to be rejected")
+	}
+}
+
+func TestRunConfigDoctor(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		APIKey:     "0123456789abcdef0123456789abcdef",
+		BaseURL:    "https://api.stlouisfed.org/fred/",
+		DBPath:     filepath.Join(dir, "nested", "reserve.db"),
+		ConfigPath: filepath.Join(dir, "config.json"),
+	}
+	pings := 0
+	ok := func(context.Context) error { pings++; return nil }
+
+	checks := runConfigDoctor(t.Context(), cfg, nil, ok)
+	for _, c := range checks {
+		if c.status != doctorPass {
+			t.Errorf("%s: expected pass, got %s %s", c.name, c.status, c.detail)
+		}
+	}
+	if pings != 1 {
+		t.Errorf("expected one ping, got %d", pings)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "nested")); !os.IsNotExist(err) {
+		t.Error("the db path check should not create directories")
+	}
+
+	byName := func(checks []doctorCheck) map[string]doctorCheck {
+		m := map[string]doctorCheck{}
+		for _, c := range checks {
+			m[c.name] = c
+		}
+		return m
+	}
+
+	cfg.APIKey = "YOUR_KEY_HERE"
+	got := byName(runConfigDoctor(t.Context(), cfg, nil, ok))
+	if got["api key"].status != doctorFail || got["FRED API"].status != doctorSkip {
+		t.Errorf("a placeholder key should fail and skip the ping, got %+v", got)
+	}
+
+	cfg.APIKey = "0123456789abcdef0123456789abcdef"
+	got = byName(runConfigDoctor(t.Context(), cfg, nil, func(context.Context) error {
+		return errors.New("HTTP 400: Bad Request. The value for variable api_key is not registered.")
+	}))
+	if got["FRED API"].status != doctorFail || !strings.Contains(got["FRED API"].detail, "not registered") {
+		t.Errorf("a failed ping should fail with FRED's message, got %+v", got["FRED API"])
+	}
+
+	got = byName(runConfigDoctor(t.Context(), cfg, nil, nil))
+	if got["FRED API"].status != doctorSkip {
+		t.Errorf("--no-ping should skip the FRED check, got %+v", got["FRED API"])
+	}
+
+	got = byName(runConfigDoctor(t.Context(), nil, errors.New("parsing config.json: invalid character"), ok))
+	if got["config file"].status != doctorFail || !strings.Contains(got["config file"].detail, "parsing config.json") {
+		t.Errorf("a load error should fail the config check, got %+v", got["config file"])
+	}
+}
+
+func TestCheckDBPathWritable(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "reserve.db")
+	if err := os.WriteFile(existing, nil, 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := checkDBPathWritable(existing); err != nil {
+		t.Errorf("existing writable file: %v", err)
+	}
+	if _, err := checkDBPathWritable(dir); err == nil {
+		t.Error("expected a directory to be rejected")
+	}
+	if _, err := checkDBPathWritable(""); err == nil {
+		t.Error("expected an empty path to be rejected")
+	}
+}
+
+func TestPrintDoctorChecks(t *testing.T) {
+	var out bytes.Buffer
+	printDoctorChecks(&out, []doctorCheck{
+		{"config file", doctorPass, "/tmp/config.json"},
+		{"api key", doctorFail, "not set"},
+	})
+	want := "  ✓ config file  /tmp/config.json\n  ✗ api key      not set\n\n1/2 checks passed\n"
+	if out.String() != want {
+		t.Errorf("got:\n%q\nwant:\n%q", out.String(), want)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Turn an exploration into a shareable artifact",
+	Long: `Export commands write reproducible artifacts built from reserve commands.
+
+export notebook — a runnable shell or Python script that fetches a series and
+                  runs the standard summary, trend, and chart pipeline`,
+}
+
+// ─── export notebook ──────────────────────────────────────────────────────────
+
+var (
+	exportNotebookStart string
+	exportNotebookEnd   string
+	exportNotebookLang  string
+)
+
+// notebookStep is one reserve invocation in a generated notebook. Args are
+// literal except "{SERIES}", which each script language replaces with its own
+// variable reference. OptionalFlags pairs a flag with the parameter that fills
+// it; the flag is emitted only when that parameter is non-empty. A ReadsData
+// step reads the saved observations; the others write them.
+type notebookStep struct {
+	Comment       string
+	Args          []string
+	OptionalFlags [][2]string
+	ReadsData     bool
+}
+
+// notebookSteps is the standard pipeline: fetch once, then summarize, fit a
+// trend, and chart the saved observations.
+var notebookSteps = []notebookStep{
+	{
+		Comment:       "Fetch observations once and keep them as JSONL for the steps below.",
+		Args:          []string{"obs", "get", "{SERIES}", "--format", "jsonl"},
+		OptionalFlags: [][2]string{{"--start", "START"}, {"--end", "END"}},
+	},
+	{Comment: "Summary statistics.", Args: []string{"analyze", "summary"}, ReadsData: true},
+	{Comment: "Linear trend with slope per year and R².", Args: []string{"analyze", "trend"}, ReadsData: true},
+	{Comment: "Terminal chart of the full range.", Args: []string{"chart", "plot"}, ReadsData: true},
+}
+
+type notebookParams struct {
+	SeriesID string
+	Start    string
+	End      string
+	Command  string
+}
+
+var exportNotebookCmd = &cobra.Command{
+	Use:   "notebook <SERIES_ID>",
+	Short: "Generate a runnable analysis script for a series",
+	Long: `Writes a self-contained script that fetches SERIES_ID and runs the standard
+summary, trend, and chart pipeline. The series ID and date range are
+parameters at the top of the script, so it can be edited and re-run or handed
+to someone else as-is.
+
+The script language follows --lang, or the --out extension when --lang is not
+set: .py produces Python (subprocess calls), anything else a POSIX shell
+script. Every generated invocation is checked against reserve's own command
+tree, so the script only uses commands and flags this build understands.`,
+	Example: `  reserve export notebook UNRATE --out analysis.sh
+  reserve export notebook CPIAUCSL --start 2015-01-01 --out analysis.py
+  reserve export notebook GDP --lang py`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for flag, v := range map[string]string{"--start": exportNotebookStart, "--end": exportNotebookEnd} {
+			if v == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return fmt.Errorf("%s: invalid date %q, expected YYYY-MM-DD", flag, v)
+			}
+		}
+		lang := strings.ToLower(exportNotebookLang)
+		if lang == "" {
+			lang = "sh"
+			if strings.EqualFold(filepath.Ext(globalFlags.Out), ".py") {
+				lang = "py"
+			}
+		}
+		if lang != "sh" && lang != "py" {
+			return fmt.Errorf("--lang must be sh or py, got %q", exportNotebookLang)
+		}
+
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		p := notebookParams{
+			SeriesID: resolveSeriesID(deps, args[0]),
+			Start:    exportNotebookStart,
+			End:      exportNotebookEnd,
+			Command:  "reserve export notebook " + strings.Join(args, " "),
+		}
+
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		if err := writeNotebook(w, lang, p); err != nil {
+			return err
+		}
+		if globalFlags.Out != "" {
+			if err := os.Chmod(globalFlags.Out, 0755); err != nil {
+				return fmt.Errorf("marking script executable: %w", err)
+			}
+		}
+		return nil
+	},
+}
+
+// writeNotebook validates every step against the command tree and renders
+// the script in lang ("sh" or "py").
+func writeNotebook(w io.Writer, lang string, p notebookParams) error {
+	for _, step := range notebookSteps {
+		if err := validateInvocation(step.invocation()); err != nil {
+			return fmt.Errorf("notebook step %q: %w", strings.Join(step.Args, " "), err)
+		}
+	}
+	if lang == "py" {
+		return writeNotebookPython(w, p)
+	}
+	return writeNotebookShell(w, p)
+}
+
+// invocation returns the step's arguments with every optional flag present,
+// which is the widest form the script can produce.
+func (s notebookStep) invocation() []string {
+	args := append([]string(nil), s.Args...)
+	for _, f := range s.OptionalFlags {
+		args = append(args, f[0], "x")
+	}
+	return args
+}
+
+// validateInvocation checks that args name a runnable reserve command and
+// that every --flag it passes is defined on that command or inherited.
+func validateInvocation(args []string) error {
+	c, rest, err := rootCmd.Find(args)
+	if err != nil {
+		return err
+	}
+	if c == rootCmd || !c.Runnable() {
+		return fmt.Errorf("%q is not a runnable reserve command", strings.Join(args, " "))
+	}
+	for _, arg := range rest {
+		if !strings.HasPrefix(arg, "--") {
+			continue
+		}
+		name, _, _ := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if c.Flags().Lookup(name) == nil && c.InheritedFlags().Lookup(name) == nil {
+			return fmt.Errorf("unknown flag --%s for %q", name, c.CommandPath())
+		}
+	}
+	return nil
+}
+
+func writeNotebookShell(w io.Writer, p notebookParams) error {
+	var b strings.Builder
+	fmt.Fprintln(&b, "#!/bin/sh")
+	fmt.Fprintf(&b, "# reserve analysis notebook for %s\n", p.SeriesID)
+	fmt.Fprintf(&b, "# Generated by reserve %s on %s with: %s\n", Version, time.Now().UTC().Format("2006-01-02"), p.Command)
+	fmt.Fprintln(&b, "#")
+	fmt.Fprintln(&b, "# Edit the parameters below and re-run. Each step is an ordinary reserve")
+	fmt.Fprintln(&b, "# command, so any of them can be pasted into a terminal on its own.")
+	fmt.Fprintln(&b, "set -eu")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "SERIES='%s'\n", p.SeriesID)
+	fmt.Fprintf(&b, "START='%s'\n", p.Start)
+	fmt.Fprintf(&b, "END='%s'\n", p.End)
+	fmt.Fprintln(&b, `DATA="${SERIES}.jsonl"`)
+	for _, step := range notebookSteps {
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "# %s\n", step.Comment)
+		parts := []string{"reserve"}
+		for _, a := range step.Args {
+			if a == "{SERIES}" {
+				a = `"$SERIES"`
+			}
+			parts = append(parts, a)
+		}
+		for _, f := range step.OptionalFlags {
+			parts = append(parts, fmt.Sprintf(`${%s:+%s "$%s"}`, f[1], f[0], f[1]))
+		}
+		if step.ReadsData {
+			parts = append(parts, `< "$DATA"`)
+		} else {
+			parts = append(parts, `> "$DATA"`)
+		}
+		fmt.Fprintln(&b, strings.Join(parts, " "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeNotebookPython(w io.Writer, p notebookParams) error {
+	var b strings.Builder
+	fmt.Fprintln(&b, "#!/usr/bin/env python3")
+	fmt.Fprintf(&b, "\"\"\"reserve analysis notebook for %s.\n\n", p.SeriesID)
+	fmt.Fprintf(&b, "Generated by reserve %s on %s with: %s\n", Version, time.Now().UTC().Format("2006-01-02"), p.Command)
+	fmt.Fprintln(&b, "Edit the parameters below and re-run; each step shells out to reserve.")
+	fmt.Fprintln(&b, "\"\"\"")
+	fmt.Fprintln(&b, "import subprocess")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "SERIES = %s\n", strconv.Quote(p.SeriesID))
+	fmt.Fprintf(&b, "START = %s\n", strconv.Quote(p.Start))
+	fmt.Fprintf(&b, "END = %s\n", strconv.Quote(p.End))
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "def reserve(*args, data=None):")
+	fmt.Fprintln(&b, `    """Run one reserve command, echoing it first, and return its stdout."""`)
+	fmt.Fprintln(&b, `    print("$ reserve " + " ".join(args), flush=True)`)
+	fmt.Fprintln(&b, `    return subprocess.run(["reserve", *args], input=data, capture_output=True, text=True, check=True).stdout`)
+	for _, step := range notebookSteps {
+		fmt.Fprintln(&b)
+		fmt.Fprintln(&b)
+		fmt.Fprintf(&b, "# %s\n", step.Comment)
+		parts := make([]string, len(step.Args))
+		for i, a := range step.Args {
+			if a == "{SERIES}" {
+				parts[i] = "SERIES"
+			} else {
+				parts[i] = strconv.Quote(a)
+			}
+		}
+		if !step.ReadsData {
+			fmt.Fprintf(&b, "args = [%s]\n", strings.Join(parts, ", "))
+			for _, f := range step.OptionalFlags {
+				fmt.Fprintf(&b, "if %s:\n    args += [%s, %s]\n", f[1], strconv.Quote(f[0]), f[1])
+			}
+			fmt.Fprintln(&b, "data = reserve(*args)")
+			continue
+		}
+		fmt.Fprintf(&b, "print(reserve(%s, data=data))\n", strings.Join(parts, ", "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ─── Registration ─────────────────────────────────────────────────────────────
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportNotebookCmd)
+
+	exportNotebookCmd.Flags().StringVar(&exportNotebookStart, "start", "", "default START parameter in the script (YYYY-MM-DD)")
+	exportNotebookCmd.Flags().StringVar(&exportNotebookEnd, "end", "", "default END parameter in the script (YYYY-MM-DD)")
+	exportNotebookCmd.Flags().StringVar(&exportNotebookLang, "lang", "", "script language: sh|py (default: from --out extension, else sh)")
+}
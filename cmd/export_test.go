@@ -0,0 +1,83 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteNotebookShell(t *testing.T) {
+	var buf bytes.Buffer
+	p := notebookParams{SeriesID: "UNRATE", Start: "2020-01-01", Command: "reserve export notebook UNRATE"}
+	if err := writeNotebook(&buf, "sh", p); err != nil {
+		t.Fatalf("writeNotebook: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"#!/bin/sh\n",
+		"set -eu\n",
+		"SERIES='UNRATE'\n",
+		"START='2020-01-01'\n",
+		"END=''\n",
+		`reserve obs get "$SERIES" --format jsonl ${START:+--start "$START"} ${END:+--end "$END"} > "$DATA"`,
+		`reserve analyze summary < "$DATA"`,
+		`reserve analyze trend < "$DATA"`,
+		`reserve chart plot < "$DATA"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("shell notebook missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteNotebookPython(t *testing.T) {
+	var buf bytes.Buffer
+	p := notebookParams{SeriesID: "CPIAUCSL", End: "2024-12-31", Command: "reserve export notebook CPIAUCSL"}
+	if err := writeNotebook(&buf, "py", p); err != nil {
+		t.Fatalf("writeNotebook: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"#!/usr/bin/env python3\n",
+		"import subprocess\n",
+		`SERIES = "CPIAUCSL"`,
+		`START = ""`,
+		`END = "2024-12-31"`,
+		`args = ["obs", "get", SERIES, "--format", "jsonl"]`,
+		"if END:\n    args += [\"--end\", END]\n",
+		`print(reserve("analyze", "summary", data=data))`,
+		`print(reserve("chart", "plot", data=data))`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("python notebook missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestNotebookStepsMatchCommandTree(t *testing.T) {
+	for _, step := range notebookSteps {
+		if err := validateInvocation(step.invocation()); err != nil {
+			t.Errorf("step %v: %v", step.Args, err)
+		}
+	}
+}
+
+func TestValidateInvocationRejectsUnknownCommandsAndFlags(t *testing.T) {
+	cases := [][]string{
+		{"analyze", "nosuchverb"},
+		{"analyze", "summary", "--nosuchflag"},
+		{"chart", "plot", "--vs=GDP"},
+		{"analyze"},
+	}
+	for _, args := range cases {
+		if err := validateInvocation(args); err == nil {
+			t.Errorf("validateInvocation(%v) = nil, want error", args)
+		}
+	}
+	if err := validateInvocation([]string{"chart", "scatter", "UNRATE", "--vs", "GDP", "--format", "json"}); err != nil {
+		t.Errorf("validateInvocation(chart scatter) = %v, want nil", err)
+	}
+}
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/progress"
 	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/derickschaefer/reserve/internal/store"
 	"github.com/spf13/cobra"
@@ -37,6 +39,8 @@ var (
 	fetchStore    bool
 	fetchStart    string
 	fetchEnd      string
+	fetchMaxAge   string
+	fetchProgress bool
 )
 
 var fetchSeriesCmd = &cobra.Command{
@@ -45,8 +49,11 @@ var fetchSeriesCmd = &cobra.Command{
 	Example: `  reserve fetch series GDP CPIAUCSL UNRATE
   reserve fetch series GDP CPIAUCSL --with-obs --start 2020-01-01
   reserve fetch series GDP --with-obs --format csv --out data.csv
-  reserve fetch series GDP CPIAUCSL UNRATE --store`,
-	Args: cobra.MinimumNArgs(1),
+  reserve fetch series GDP CPIAUCSL UNRATE --store
+  reserve fetch series CPIAUCSL UNRATE --store --max-age 7d
+  reserve fetch series GDP CPIAUCSL UNRATE FEDFUNDS DGS10 --store --progress`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeSeriesIDs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		deps, err := buildDeps()
 		if err != nil {
@@ -85,9 +92,47 @@ var fetchSeriesCmd = &cobra.Command{
 			return nil
 		}
 
-		// With observations
+		// With --max-age, series whose stored copy is still fresh skip the
+		// network entirely.
+		var freshWarnings []string
+		if fetchMaxAge != "" {
+			if !fetchStore {
+				return fmt.Errorf("--max-age requires --store")
+			}
+			maxAge, err := parseAge(fetchMaxAge)
+			if err != nil {
+				return fmt.Errorf("--max-age: %w", err)
+			}
+			if err := deps.RequireStore(); err != nil {
+				return err
+			}
+			ids, freshWarnings, err = staleSeriesIDs(deps.Store, ids, fetchStart, fetchEnd, maxAge)
+			if err != nil {
+				return fmt.Errorf("checking cache age: %w", err)
+			}
+			if len(ids) == 0 {
+				defer deps.Close()
+				if !deps.Config.Quiet {
+					fmt.Fprintln(cmd.OutOrStdout(), "✓ All requested series are fresh in the local store; nothing fetched")
+					for _, w := range freshWarnings {
+						fmt.Fprintf(cmd.OutOrStdout(), "  ⚠  %s\n", w)
+					}
+				}
+				return nil
+			}
+		}
+
+		// With observations. A store refresh revalidates each stored copy with
+		// FRED rather than downloading it again, unless --no-cache.
 		opts := fred.ObsOptions{Start: fetchStart, End: fetchEnd}
-		datas, warnings, _ := batchGetObs(cmd.Context(), deps, ids, opts, liveObsSource{})
+		var src obsSource = liveObsSource{}
+		if fetchStore && !deps.Config.NoCache {
+			src = revalidatingObsSource{}
+		}
+		bar := newFetchProgress(deps, len(ids))
+		datas, warnings, _ := batchGetObs(cmd.Context(), deps, ids, opts, withProgress(src, bar))
+		bar.Done()
+		warnings = append(freshWarnings, warnings...)
 
 		// Persist to local store if --store flag is set.
 		//
@@ -102,10 +147,11 @@ var fetchSeriesCmd = &cobra.Command{
 			defer deps.Close()
 
 			// ── Step 1: collect obs entries keyed by canonical obs key ────────
-			obsEntries := make(map[string]model.SeriesData, len(datas))
-			for _, data := range datas {
-				key := store.ObsKey(data.SeriesID, fetchStart, fetchEnd, "", "", "")
-				obsEntries[key] = *data
+			// An open-ended --start refresh of a series whose full history is
+			// already cached extends that copy instead of adding a partial set.
+			obsEntries, appendEntries, err := partitionObsEntries(deps.Store, datas, fetchStart, fetchEnd)
+			if err != nil {
+				return fmt.Errorf("checking existing cache entries: %w", err)
 			}
 			multiSetWarnings, err := collectStoreWarnings(deps.Store, obsEntries)
 			if err != nil {
@@ -123,6 +169,11 @@ var fetchSeriesCmd = &cobra.Command{
 			if err := deps.Store.PutObsBatch(obsEntries); err != nil {
 				return fmt.Errorf("storing observations: %w", err)
 			}
+			for key, data := range appendEntries {
+				if err := deps.Store.PutObsAppend(key, data); err != nil {
+					return fmt.Errorf("extending stored observations for %s: %w", data.SeriesID, err)
+				}
+			}
 
 			// ── Step 4: single write transaction for all metadata ─────────────
 			if len(metaSlice) > 0 {
@@ -134,7 +185,11 @@ var fetchSeriesCmd = &cobra.Command{
 
 			if !deps.Config.Quiet {
 				fmt.Fprintf(cmd.OutOrStdout(), "✓ Stored %d/%d series to %s\n",
-					len(obsEntries), len(ids), deps.Config.DBPath)
+					len(obsEntries)+len(appendEntries), len(ids), deps.Config.DBPath)
+				if len(appendEntries) > 0 {
+					fmt.Fprintf(cmd.OutOrStdout(), "  Extended the cached full history of %d series from %s\n",
+						len(appendEntries), fetchStart)
+				}
 				for _, w := range warnings {
 					fmt.Fprintf(cmd.OutOrStdout(), "  ⚠  %s\n", w)
 				}
@@ -175,6 +230,49 @@ var fetchSeriesCmd = &cobra.Command{
 	},
 }
 
+// partitionObsEntries keys each fetched series by the obs key it will be
+// stored under (see storedObsKey). Series whose key is an existing full-range
+// copy go to merge, so the fetched window is appended to the cached history
+// rather than stored as a separate set.
+func partitionObsEntries(s interface {
+	ListObsKeys(string) ([]string, error)
+}, datas []*model.SeriesData, start, end string) (put, merge map[string]model.SeriesData, err error) {
+	put = make(map[string]model.SeriesData, len(datas))
+	merge = map[string]model.SeriesData{}
+	for _, data := range datas {
+		key, extend, err := storedObsKey(s, data.SeriesID, start, end)
+		if err != nil {
+			return nil, nil, err
+		}
+		if extend {
+			merge[key] = *data
+		} else {
+			put[key] = *data
+		}
+	}
+	return put, merge, nil
+}
+
+// storedObsKey returns the obs key that fetch series --store writes id under.
+// With a --start and no --end, a series whose full-range copy is already
+// stored is written into that copy, and extend is true; otherwise the key is
+// the canonical one for the requested range.
+func storedObsKey(s interface {
+	ListObsKeys(string) ([]string, error)
+}, id, start, end string) (key string, extend bool, err error) {
+	if start != "" && end == "" {
+		full := store.ObsKey(id, "", "", "", "", "")
+		keys, err := s.ListObsKeys(id)
+		if err != nil {
+			return "", false, err
+		}
+		if slices.Contains(keys, full) {
+			return full, true, nil
+		}
+	}
+	return store.ObsKey(id, start, end, "", "", ""), false, nil
+}
+
 func collectStoreWarnings(s interface {
 	ListObsKeys(string) ([]string, error)
 }, entries map[string]model.SeriesData) ([]string, error) {
@@ -204,6 +302,44 @@ func collectStoreWarnings(s interface {
 	return warnings, nil
 }
 
+// staleSeriesIDs keeps the IDs whose stored observation set for the requested
+// range is missing or older than maxAge, and returns a cache-hit warning for
+// each one that is still fresh. The set checked is the one a --store fetch
+// would write, so an open-ended --start refresh is judged by the full-range
+// copy it extends.
+func staleSeriesIDs(s interface {
+	ListObsKeys(string) ([]string, error)
+	IsStale(string, time.Duration) (bool, error)
+}, ids []string, start, end string, maxAge time.Duration) ([]string, []string, error) {
+	var stale, warnings []string
+	for _, id := range ids {
+		key, _, err := storedObsKey(s, id, start, end)
+		if err != nil {
+			return nil, nil, err
+		}
+		isStale, err := s.IsStale(key, maxAge)
+		if err != nil {
+			return nil, nil, err
+		}
+		if isStale {
+			stale = append(stale, id)
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf(
+			"%s: local copy is newer than --max-age %s; skipped fetch", id, humanAge(maxAge)))
+	}
+	return stale, warnings, nil
+}
+
+// newFetchProgress returns the --progress bar for a batch of n series, or
+// nil (which draws nothing) when the flag is off or output is --quiet.
+func newFetchProgress(deps *app.Deps, n int) *progress.Progress {
+	if !fetchProgress || deps.Config.Quiet {
+		return nil
+	}
+	return progress.Bar(n)
+}
+
 // ─── fetch category ───────────────────────────────────────────────────────────
 
 var (
@@ -340,7 +476,9 @@ var fetchQueryCmd = &cobra.Command{
 			ids[i] = m.ID
 		}
 		opts := fred.ObsOptions{Start: fetchStart, End: fetchEnd}
-		datas, warnings, _ := batchGetObs(cmd.Context(), deps, ids, opts, liveObsSource{})
+		bar := newFetchProgress(deps, len(ids))
+		datas, warnings, _ := batchGetObs(cmd.Context(), deps, ids, opts, withProgress(liveObsSource{}, bar))
+		bar.Done()
 
 		for _, data := range datas {
 			result := &model.Result{
@@ -378,6 +516,8 @@ func init() {
 	fetchSeriesCmd.Flags().BoolVar(&fetchStore, "store", false, "persist observations to local database")
 	fetchSeriesCmd.Flags().StringVar(&fetchStart, "start", "", "observation start date YYYY-MM-DD")
 	fetchSeriesCmd.Flags().StringVar(&fetchEnd, "end", "", "observation end date YYYY-MM-DD")
+	fetchSeriesCmd.Flags().BoolVar(&fetchProgress, "progress", false, "show a progress bar on stderr while observations download")
+	fetchSeriesCmd.Flags().StringVar(&fetchMaxAge, "max-age", "", "with --store, only re-fetch series whose stored copy is older than this (e.g. 24h, 7d)")
 
 	fetchCategoryCmd.Flags().BoolVar(&fetchCategoryRecursive, "recursive", false, "recursively fetch child categories")
 	fetchCategoryCmd.Flags().IntVar(&fetchCategoryDepth, "depth", 1, "max recursion depth (used with --recursive)")
@@ -385,6 +525,7 @@ func init() {
 
 	fetchQueryCmd.Flags().IntVar(&fetchQueryTop, "top", 10, "number of search results to fetch")
 	fetchQueryCmd.Flags().BoolVar(&fetchQueryWithObs, "with-obs", false, "also fetch observations for matched series")
+	fetchQueryCmd.Flags().BoolVar(&fetchProgress, "progress", false, "show a progress bar on stderr while observations download (with --with-obs)")
 	fetchQueryCmd.Flags().StringVar(&fetchStart, "start", "", "observation start date YYYY-MM-DD")
 	fetchQueryCmd.Flags().StringVar(&fetchEnd, "end", "", "observation end date YYYY-MM-DD")
 }
@@ -4,10 +4,16 @@
 package cmd
 
 import (
+	"encoding/json"
+	"net/http"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/derickschaefer/reserve/internal/app"
+	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/store"
 )
@@ -69,3 +75,168 @@ func TestCollectStoreWarningsNoWarningOnExactOverwrite(t *testing.T) {
 		t.Fatalf("expected no warnings, got %v", warnings)
 	}
 }
+
+func TestPartitionObsEntriesMergesIntoFullHistory(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	full := store.ObsKey("UNRATE", "", "", "", "", "")
+	if err := s.PutObs(full, model.SeriesData{
+		SeriesID: "UNRATE",
+		Obs: []model.Observation{
+			{Date: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), Value: 4.4, ValueRaw: "4.4"},
+			{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Value: 4.3, ValueRaw: "4.3"},
+		},
+		Response: &model.ResponseMeta{ETag: `"v1"`},
+	}); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	datas := []*model.SeriesData{
+		{SeriesID: "UNRATE", Obs: []model.Observation{
+			{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Value: 4.2, ValueRaw: "4.2"},
+			{Date: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), Value: 4.1, ValueRaw: "4.1"},
+		}},
+		{SeriesID: "GDP"},
+	}
+
+	put, merge, err := partitionObsEntries(s, datas, "2026-01-01", "")
+	if err != nil {
+		t.Fatalf("partitionObsEntries: %v", err)
+	}
+	if _, ok := merge[full]; !ok || len(merge) != 1 {
+		t.Fatalf("expected UNRATE to merge into %s, got %v", full, merge)
+	}
+	if _, ok := put[store.ObsKey("GDP", "2026-01-01", "", "", "", "")]; !ok || len(put) != 1 {
+		t.Fatalf("expected GDP as a new ranged set, got %v", put)
+	}
+	if err := s.PutObsAppend(full, merge[full]); err != nil {
+		t.Fatalf("PutObsAppend: %v", err)
+	}
+	got, _, err := s.GetObs(full)
+	if err != nil {
+		t.Fatalf("GetObs: %v", err)
+	}
+	if len(got.Obs) != 3 || got.Obs[1].Value != 4.2 || got.Response != nil {
+		t.Fatalf("expected 3 merged rows with the refetched value and no validators, got %+v (response %+v)", got.Obs, got.Response)
+	}
+
+	// A bounded window never merges: it is stored under its own key.
+	put, merge, err = partitionObsEntries(s, datas[:1], "2026-01-01", "2026-02-01")
+	if err != nil || len(merge) != 0 || len(put) != 1 {
+		t.Fatalf("expected a bounded window to be put, got put=%v merge=%v err=%v", put, merge, err)
+	}
+}
+
+func TestStaleSeriesIDsSkipsFreshEntries(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.PutObs(store.ObsKey("CPIAUCSL", "", "", "", "", ""), model.SeriesData{
+		SeriesID: "CPIAUCSL",
+		Obs: []model.Observation{
+			{Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Value: 1, ValueRaw: "1"},
+		},
+	}); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+
+	stale, warnings, err := staleSeriesIDs(s, []string{"CPIAUCSL", "UNRATE"}, "", "", 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("staleSeriesIDs: %v", err)
+	}
+	if len(stale) != 1 || stale[0] != "UNRATE" {
+		t.Fatalf("expected only the uncached series to be fetched, got %v", stale)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "CPIAUCSL") {
+		t.Fatalf("expected a cache-hit warning for CPIAUCSL, got %v", warnings)
+	}
+
+	// An open-ended --start refresh extends the full-range copy, so that
+	// copy's age decides it.
+	stale, warnings, err = staleSeriesIDs(s, []string{"CPIAUCSL"}, "2024-01-01", "", 7*24*time.Hour)
+	if err != nil || len(stale) != 0 || len(warnings) != 1 {
+		t.Fatalf("expected open-ended refresh of a fresh full history to be skipped, got %v %v (err=%v)", stale, warnings, err)
+	}
+
+	// A bounded range is stored under its own key and is fetched until cached.
+	stale, _, err = staleSeriesIDs(s, []string{"CPIAUCSL"}, "2024-01-01", "2024-06-01", 7*24*time.Hour)
+	if err != nil || len(stale) != 1 {
+		t.Fatalf("expected bounded range to be stale, got %v (err=%v)", stale, err)
+	}
+}
+
+func TestRevalidatingObsSourceKeepsStoredCopyOnNotModified(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	key := store.ObsKey("GDP", "", "", "", "", "")
+	if err := s.PutObs(key, model.SeriesData{
+		SeriesID: "GDP",
+		Obs:      []model.Observation{{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 100, ValueRaw: "100"}},
+		Response: &model.ResponseMeta{ETag: `"v1"`},
+	}); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	if err := s.PutSeriesMeta(model.SeriesMeta{
+		ID:                "GDP",
+		CopyrightStatus:   "public_domain_citation_requested",
+		LastRightsCheckAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("PutSeriesMeta: %v", err)
+	}
+
+	var gotIfNoneMatch []string
+	client := fred.NewClient("test_key", "https://mock.fred.local/", 5*time.Second, 1000, false)
+	client.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotIfNoneMatch = append(gotIfNoneMatch, req.Header.Get("If-None-Match"))
+		rec := newResponseRecorder()
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			rec.WriteHeader(http.StatusNotModified)
+			return rec.Result(), nil
+		}
+		rec.Header().Set("ETag", `"v2"`)
+		_ = json.NewEncoder(rec).Encode(map[string]any{
+			"observations": []map[string]string{{"date": "2024-01-01", "value": "101"}},
+		})
+		return rec.Result(), nil
+	})})
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath}, Client: client, Store: s}
+
+	data, cacheHit, _, err := revalidatingObsSource{}.get(t.Context(), deps, "GDP", fred.ObsOptions{})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !cacheHit || len(data.Obs) != 1 || data.Obs[0].Value != 100 {
+		t.Fatalf("304 should return the stored copy as a cache hit, got hit=%v obs=%+v", cacheHit, data.Obs)
+	}
+	if data.Response == nil || data.Response.ETag != `"v1"` {
+		t.Fatalf("stored validators should carry over, got %+v", data.Response)
+	}
+
+	// Once the stored copy has no validators, the request is unconditional.
+	if err := s.PutObs(key, model.SeriesData{SeriesID: "GDP", Obs: data.Obs}); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	data, cacheHit, _, err = revalidatingObsSource{}.get(t.Context(), deps, "GDP", fred.ObsOptions{})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if cacheHit || data.Obs[0].Value != 101 || data.Response == nil || data.Response.ETag != `"v2"` {
+		t.Fatalf("expected a fresh download with new validators, got hit=%v obs=%+v resp=%+v", cacheHit, data.Obs, data.Response)
+	}
+	if len(gotIfNoneMatch) != 2 || gotIfNoneMatch[0] != `"v1"` || gotIfNoneMatch[1] != "" {
+		t.Fatalf("If-None-Match headers sent = %q", gotIfNoneMatch)
+	}
+}
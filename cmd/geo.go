@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/spf13/cobra"
+)
+
+var geoCmd = &cobra.Command{
+	Use:   "geo",
+	Short: "Explore regional (GeoFRED) data",
+	Long: `Commands for GeoFRED regional data: one value per state, county, metro area,
+or other region for a series group on a given date.`,
+}
+
+// ─── geo series ───────────────────────────────────────────────────────────────
+
+var (
+	geoSeriesRegionType string
+	geoSeriesDate       string
+	geoSeriesUnits      string
+	geoSeriesSeason     string
+	geoSeriesFreq       string
+	geoSeriesSort       string
+)
+
+var geoSeriesCmd = &cobra.Command{
+	Use:   "series <GROUP>",
+	Short: "Show a series group's values across regions on one date",
+	Long: `Fetches a GeoFRED series group's cross-section: the value for every region of
+--region-type on --date. The group ID and its units are listed on the GeoFRED
+map for the series (e.g. group 882, "Dollars", is per capita personal income).
+
+Rows are sorted by value, highest first; --sort region or --sort code orders
+them alphabetically instead.`,
+	Example: `  reserve geo series 882 --region-type state --date 2023-01-01 --units Dollars
+  reserve geo series 882 --region-type state --date 2023-01-01 --units Dollars --sort region
+  reserve geo series 882 --region-type state --date 2023-01-01 --units Dollars --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := time.Parse("2006-01-02", geoSeriesDate); err != nil {
+			return fmt.Errorf("--date: invalid date %q, expected YYYY-MM-DD", geoSeriesDate)
+		}
+		switch geoSeriesSort {
+		case "value", "region", "code":
+		default:
+			return fmt.Errorf("--sort must be value, region, or code, got %q", geoSeriesSort)
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.Config.Validate(); err != nil {
+			return err
+		}
+		start := time.Now()
+		data, err := deps.Client.GetRegionalData(cmd.Context(), args[0], fred.RegionalOptions{
+			RegionType: geoSeriesRegionType,
+			Date:       geoSeriesDate,
+			Units:      geoSeriesUnits,
+			Season:     geoSeriesSeason,
+			Frequency:  geoSeriesFreq,
+		})
+		if err != nil {
+			return err
+		}
+		sortRegions(data.Regions, geoSeriesSort)
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+
+		format := resolveFormat(deps.Config.Format)
+		if format == render.FormatTable || format == "" {
+			if !deps.Config.Quiet && data.Title != "" {
+				fmt.Fprintf(w, "%s (%s)\n\n", data.Title, data.Date)
+			}
+			printSimpleTable(w, []string{"REGION", "CODE", "VALUE"}, func(add func(...string)) {
+				for _, r := range data.Regions {
+					add(r.Region, r.Code, strconv.FormatFloat(r.Value, 'f', -1, 64))
+				}
+			})
+			if deps.Config.Verbose {
+				fmt.Fprintf(w, "\n[%d regions • %dms]\n", len(data.Regions), time.Since(start).Milliseconds())
+			}
+			return nil
+		}
+		result := &model.Result{
+			Kind:        model.KindRegional,
+			GeneratedAt: time.Now(),
+			Command:     fmt.Sprintf("geo series %s", args[0]),
+			Data:        data,
+			Stats: model.ResultStats{
+				DurationMs: time.Since(start).Milliseconds(),
+				Items:      len(data.Regions),
+			},
+		}
+		return render.Render(w, result, format)
+	},
+}
+
+// sortRegions orders regions by value (highest first, the default), region
+// name, or code.
+func sortRegions(regions []fred.RegionValue, by string) {
+	switch by {
+	case "region":
+		slices.SortStableFunc(regions, func(a, b fred.RegionValue) int { return cmp.Compare(a.Region, b.Region) })
+	case "code":
+		slices.SortStableFunc(regions, func(a, b fred.RegionValue) int { return cmp.Compare(a.Code, b.Code) })
+	default:
+		slices.SortStableFunc(regions, func(a, b fred.RegionValue) int {
+			return cmp.Or(cmp.Compare(b.Value, a.Value), cmp.Compare(a.Region, b.Region))
+		})
+	}
+}
+
+// ─── Registration ─────────────────────────────────────────────────────────────
+
+func init() {
+	rootCmd.AddCommand(geoCmd)
+	geoCmd.AddCommand(geoSeriesCmd)
+
+	geoSeriesCmd.Flags().StringVar(&geoSeriesRegionType, "region-type", "state", "region type: state|county|msa|bea|frb|censusregion|censusdivision|country")
+	geoSeriesCmd.Flags().StringVar(&geoSeriesDate, "date", "", "observation date (YYYY-MM-DD)")
+	geoSeriesCmd.Flags().StringVar(&geoSeriesUnits, "units", "", "the series group's units, e.g. Dollars or Percent")
+	geoSeriesCmd.Flags().StringVar(&geoSeriesSeason, "season", "NSA", "seasonal adjustment: NSA|SA|SSA|SAAR|NSAAR")
+	geoSeriesCmd.Flags().StringVar(&geoSeriesFreq, "freq", "a", "frequency code: d|w|m|q|sa|a")
+	geoSeriesCmd.Flags().StringVar(&geoSeriesSort, "sort", "value", "row order: value (highest first), region, or code")
+	_ = geoSeriesCmd.MarkFlagRequired("date")
+}
@@ -4,20 +4,26 @@
 package cmd
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/app"
+	"github.com/derickschaefer/reserve/internal/chart"
 	"github.com/derickschaefer/reserve/internal/compliance"
 	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/progress"
 	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
 )
 
 // normaliseIDs upper-cases all series IDs and removes duplicates while
@@ -51,8 +57,12 @@ func resolveSeriesIDs(deps *app.Deps, ids []string) []string {
 	return normaliseIDs(resolved)
 }
 
-// resolveFormat returns the effective format string, falling back to "table".
+// resolveFormat returns the effective format string: "tmpl" when --template
+// is set, else --format, else cfgFormat, falling back to "table".
 func resolveFormat(cfgFormat string) string {
+	if globalFlags.Template != "" {
+		return render.FormatTemplate
+	}
 	if globalFlags.Format != "" {
 		return globalFlags.Format
 	}
@@ -144,12 +154,109 @@ func (liveObsSource) get(ctx context.Context, deps *app.Deps, id string, opts fr
 	return data, false, nil, nil
 }
 
-type cacheObsSource struct{}
+// revalidatingObsSource fetches live observations for writing back to the
+// store. When the stored copy of the same request carries FRED's cache
+// validators, the request is conditional, and a 304 Not Modified returns the
+// stored copy (reported as a cache hit) instead of downloading it again.
+type revalidatingObsSource struct{}
+
+func (revalidatingObsSource) name() string         { return "live" }
+func (revalidatingObsSource) requiresAPIKey() bool { return true }
+
+func (revalidatingObsSource) get(ctx context.Context, deps *app.Deps, id string, opts fred.ObsOptions) (*model.SeriesData, bool, []string, error) {
+	if deps.Store == nil {
+		return liveObsSource{}.get(ctx, deps, id, opts)
+	}
+	meta, err := ensureSeriesCompliance(ctx, deps, id, "display")
+	if err != nil {
+		return nil, false, nil, err
+	}
+	var prev model.ResponseMeta
+	stored, found, err := deps.Store.GetObs(storeObsKey(id, opts))
+	if err != nil {
+		return nil, false, nil, fmt.Errorf("reading cache: %w", err)
+	}
+	if found && stored.Response != nil {
+		prev = *stored.Response
+	}
+	data, notModified, err := deps.Client.GetObservationsIfModified(ctx, id, opts, prev)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	if notModified {
+		data = &stored
+	}
+	data.Meta = &meta
+	return data, notModified, nil, nil
+}
+
+// freshObsSource fetches live observations unless the stored copy of the
+// same request was fetched within maxAge, in which case that copy is returned
+// as a cache hit with a warning and no request is made. A missing entry or a
+// zero maxAge always fetches.
+type freshObsSource struct {
+	maxAge time.Duration
+}
+
+func (freshObsSource) name() string         { return "live" }
+func (freshObsSource) requiresAPIKey() bool { return true }
+
+func (src freshObsSource) get(ctx context.Context, deps *app.Deps, id string, opts fred.ObsOptions) (*model.SeriesData, bool, []string, error) {
+	if deps.Store == nil {
+		return liveObsSource{}.get(ctx, deps, id, opts)
+	}
+	key := storeObsKey(id, opts)
+	stale, err := deps.Store.IsStale(key, src.maxAge)
+	if err != nil {
+		return nil, false, nil, fmt.Errorf("reading cache: %w", err)
+	}
+	if stale {
+		return liveObsSource{}.get(ctx, deps, id, opts)
+	}
+	data, found, err := deps.Store.GetObs(key)
+	if err != nil {
+		return nil, false, nil, fmt.Errorf("reading cache: %w", err)
+	}
+	if !found {
+		return liveObsSource{}.get(ctx, deps, id, opts)
+	}
+	meta, err := ensureSeriesCompliance(ctx, deps, id, "display")
+	if err != nil {
+		return nil, false, nil, err
+	}
+	data.Meta = &meta
+	warning := fmt.Sprintf("%s: local copy is newer than --max-age %s; skipped fetch", id, humanAge(src.maxAge))
+	return &data, true, []string{warning}, nil
+}
+
+// progressObsSource advances a progress bar as each series from the wrapped
+// source completes, whether it succeeded or not.
+type progressObsSource struct {
+	obsSource
+	bar  *progress.Progress
+	done *atomic.Int64
+}
+
+func withProgress(src obsSource, bar *progress.Progress) obsSource {
+	return progressObsSource{obsSource: src, bar: bar, done: new(atomic.Int64)}
+}
+
+func (src progressObsSource) get(ctx context.Context, deps *app.Deps, id string, opts fred.ObsOptions) (*model.SeriesData, bool, []string, error) {
+	data, cache, warn, err := src.obsSource.get(ctx, deps, id, opts)
+	src.bar.Update(int(src.done.Add(1)), id)
+	return data, cache, warn, err
+}
+
+// cacheObsSource reads observations from the local store. When maxAge is set,
+// entries fetched longer ago than that produce a warning (never an error).
+type cacheObsSource struct {
+	maxAge time.Duration
+}
 
 func (cacheObsSource) name() string         { return "cache" }
 func (cacheObsSource) requiresAPIKey() bool { return false }
 
-func (cacheObsSource) get(_ context.Context, deps *app.Deps, id string, opts fred.ObsOptions) (*model.SeriesData, bool, []string, error) {
+func (src cacheObsSource) get(_ context.Context, deps *app.Deps, id string, opts fred.ObsOptions) (*model.SeriesData, bool, []string, error) {
 	if err := deps.RequireStore(); err != nil {
 		return nil, false, nil, fmt.Errorf("source 'cache' unavailable: %w", err)
 	}
@@ -160,13 +267,29 @@ func (cacheObsSource) get(_ context.Context, deps *app.Deps, id string, opts fre
 		if err != nil {
 			return nil, false, nil, fmt.Errorf("reading cache: %w", err)
 		}
+		if !ok && opts.Freq == "" && opts.Units == "" && opts.Agg == "" && opts.RealtimeStart == "" && opts.RealtimeEnd == "" {
+			// A plain date window can be cut from the full cached history.
+			after, before, err := parseObsBounds(opts)
+			if err != nil {
+				return nil, false, nil, err
+			}
+			key = storeObsKey(id, fred.ObsOptions{})
+			data, ok, err = deps.Store.GetObsRange(key, after, before)
+			if err != nil {
+				return nil, false, nil, fmt.Errorf("reading cache: %w", err)
+			}
+		}
 		if ok {
 			meta, err := ensureSeriesCompliance(context.Background(), deps, id, "display")
 			if err != nil {
 				return nil, false, nil, err
 			}
 			data.Meta = &meta
-			return &data, true, nil, nil
+			var warnings []string
+			if warning := src.staleWarning(deps, id, key); warning != "" {
+				warnings = append(warnings, warning)
+			}
+			return &data, true, warnings, nil
 		}
 		return nil, false, nil, fmt.Errorf("no cached observations for %s matching the requested parameters", id)
 	}
@@ -192,9 +315,72 @@ func (cacheObsSource) get(_ context.Context, deps *app.Deps, id string, opts fre
 	if warning != "" {
 		warnings = append(warnings, warning)
 	}
+	if warning := src.staleWarning(deps, id, selected.key); warning != "" {
+		warnings = append(warnings, warning)
+	}
 	return &selected.data, true, warnings, nil
 }
 
+// parseObsBounds parses the Start/End dates in opts; an empty date is a zero
+// time, which leaves that side of the range open.
+func parseObsBounds(opts fred.ObsOptions) (after, before time.Time, err error) {
+	if opts.Start != "" {
+		if after, err = time.Parse("2006-01-02", opts.Start); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %q, expected YYYY-MM-DD", opts.Start)
+		}
+	}
+	if opts.End != "" {
+		if before, err = time.Parse("2006-01-02", opts.End); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %q, expected YYYY-MM-DD", opts.End)
+		}
+	}
+	return after, before, nil
+}
+
+func (src cacheObsSource) staleWarning(deps *app.Deps, id, key string) string {
+	if src.maxAge <= 0 {
+		return ""
+	}
+	age, err := deps.Store.Age(key)
+	if err != nil || age <= src.maxAge {
+		return ""
+	}
+	return fmt.Sprintf("Cached observations for %s were fetched %s ago, older than --max-age %s. Re-fetch to refresh the local copy.",
+		id, humanAge(age), humanAge(src.maxAge))
+}
+
+// parseAge parses a duration flag such as "24h" or "7d". Go duration syntax is
+// accepted as-is; a bare "d" suffix is added for day-scale thresholds.
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid age %q: expected e.g. 24h or 7d", s)
+		}
+		return time.Duration(n * float64(24*time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q: expected e.g. 24h or 7d", s)
+	}
+	return d, nil
+}
+
+// humanAge renders a duration at the coarsest useful unit.
+func humanAge(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%.0fd", d.Hours()/24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%.0fh", d.Hours())
+	case d >= time.Minute:
+		return fmt.Sprintf("%.0fm", d.Minutes())
+	default:
+		return fmt.Sprintf("%.0fs", d.Seconds())
+	}
+}
+
 func ensureSeriesCompliance(ctx context.Context, deps *app.Deps, id, action string) (model.SeriesMeta, error) {
 	meta, _, err := compliance.EnsureSeriesMeta(ctx, deps.Config, deps.Client, deps.Store, id, action)
 	if err != nil {
@@ -224,20 +410,23 @@ func resolveObsSource(name string) (obsSource, error) {
 }
 
 func obsCacheKey(seriesID string, opts fred.ObsOptions) string {
-	if opts.Start == "" && opts.End == "" && opts.Freq == "" && opts.Units == "" && opts.Agg == "" {
+	if opts.Start == "" && opts.End == "" && opts.Freq == "" && opts.Units == "" && opts.Agg == "" &&
+		opts.RealtimeStart == "" && opts.RealtimeEnd == "" {
 		return ""
 	}
 	return storeObsKey(seriesID, opts)
 }
 
 func storeObsKey(seriesID string, opts fred.ObsOptions) string {
-	return fmt.Sprintf("series:%s%s%s%s%s%s",
+	return fmt.Sprintf("series:%s%s%s%s%s%s%s%s",
 		seriesID,
 		optionalObsKeyPart("start", opts.Start),
 		optionalObsKeyPart("end", opts.End),
 		optionalObsKeyPart("freq", opts.Freq),
 		optionalObsKeyPart("units", opts.Units),
 		optionalObsKeyPart("agg", opts.Agg),
+		optionalObsKeyPart("rt_start", opts.RealtimeStart),
+		optionalObsKeyPart("rt_end", opts.RealtimeEnd),
 	)
 }
 
@@ -423,6 +612,89 @@ func outputWriter(defaultWriter io.Writer) (io.Writer, func() error, error) {
 	return f, f.Close, nil
 }
 
+// outputGzip is --gzip on the source commands that render through
+// writeOutput; obs get registers its own.
+var outputGzip bool
+
+const outputGzipUsage = "gzip-compress JSONL output (requires --format jsonl)"
+
+// writeOutput runs write against the command's output: the --out file or
+// cmd's stdout, gzip-compressed with --gzip. The gzip stream and the file are
+// closed once write returns.
+func writeOutput(cmd *cobra.Command, format string, write func(w io.Writer) error) error {
+	if outputGzip && format != render.FormatJSONL {
+		return fmt.Errorf("--gzip requires --format jsonl")
+	}
+	w, closeOut, err := outputWriter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	var zw *gzip.Writer
+	if outputGzip {
+		zw = gzip.NewWriter(w)
+		w = zw
+	}
+	err = write(w)
+	if zw != nil {
+		if cerr := zw.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if cerr := closeOut(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// useColor reports whether output written to w should be colorized, per the
+// global --color flag. The --out file is never colorized, even with
+// --color always.
+func useColor(w io.Writer) bool {
+	if f, ok := w.(*os.File); ok && globalFlags.Out != "" && f.Name() == globalFlags.Out {
+		return false
+	}
+	return chart.ColorEnabled(globalFlags.Color, w)
+}
+
+// resolveRelativeStart turns a --relative-dates spec into a YYYY-MM-DD start
+// date. The calendar day is taken from now in its own location, so a
+// localized "now" can fall in a different day, month, or year than UTC.
+func resolveRelativeStart(spec string, now time.Time) (string, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	invalid := fmt.Errorf("invalid spec %q, expected ytd, qtd, mtd, or <n>d|w|m|y", spec)
+	var start time.Time
+	switch spec {
+	case "ytd":
+		start = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	case "qtd":
+		start = time.Date(today.Year(), (today.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+	case "mtd":
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		if len(spec) < 2 {
+			return "", invalid
+		}
+		n, err := strconv.Atoi(spec[:len(spec)-1])
+		if err != nil || n <= 0 {
+			return "", invalid
+		}
+		switch spec[len(spec)-1] {
+		case 'd':
+			start = today.AddDate(0, 0, -n)
+		case 'w':
+			start = today.AddDate(0, 0, -7*n)
+		case 'm':
+			start = today.AddDate(0, -n, 0)
+		case 'y':
+			start = today.AddDate(-n, 0, 0)
+		default:
+			return "", invalid
+		}
+	}
+	return start.Format("2006-01-02"), nil
+}
+
 // parseIntID parses a string as a non-negative integer ID, with a descriptive label for errors.
 func parseIntID(s, label string) (int, error) {
 	var id int
@@ -13,11 +13,14 @@ import (
 	"sync/atomic"
 	"testing"
 	"testing/synctest"
+	"time"
 
 	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/config"
 	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/progress"
+	"github.com/derickschaefer/reserve/internal/store"
 )
 
 func TestOutputWriterDefault(t *testing.T) {
@@ -54,6 +57,24 @@ func TestOutputWriterFile(t *testing.T) {
 	}
 }
 
+func TestUseColorNeverColorsOutFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "summary.txt")
+	globalFlags.Out, globalFlags.Color = p, "always"
+	t.Cleanup(func() { globalFlags.Out, globalFlags.Color = "", "auto" })
+
+	w, closeFn, err := outputWriter(os.Stdout)
+	if err != nil {
+		t.Fatalf("outputWriter file: %v", err)
+	}
+	defer closeFn()
+	if useColor(w) {
+		t.Error("--out file should not be colorized even with --color always")
+	}
+	if !useColor(os.Stdout) {
+		t.Error("--color always should still color stdout")
+	}
+}
+
 func TestParseIntIDAllowsZero(t *testing.T) {
 	got, err := parseIntID("0", "release ID")
 	if err != nil {
@@ -226,6 +247,36 @@ func TestBatchGetObsSynctestDefaultConcurrencyFallback(t *testing.T) {
 	})
 }
 
+func TestBatchGetObsWithProgressCountsEverySeries(t *testing.T) {
+	ids := []string{"A", "B", "C"}
+	src := &testObsSource{
+		started: make(chan string, len(ids)),
+		release: make(chan struct{}, len(ids)),
+		resp: map[string]testObsResponse{
+			"A": {data: &model.SeriesData{SeriesID: "A"}},
+			"B": {err: fmt.Errorf("boom")},
+			"C": {data: &model.SeriesData{SeriesID: "C"}},
+		},
+	}
+	for range ids {
+		src.release <- struct{}{}
+	}
+	var buf strings.Builder
+	bar := progress.New(&buf, len(ids), false)
+	deps := &app.Deps{Config: &config.Config{Concurrency: 1}}
+
+	datas, warnings, _ := batchGetObs(context.Background(), deps, ids, fred.ObsOptions{}, withProgress(src, bar))
+	if len(datas) != 2 || len(warnings) != 1 {
+		t.Fatalf("got %d results and %d warnings, want 2 and 1", len(datas), len(warnings))
+	}
+	// Series finish in any order, but the count steps once per series.
+	for _, want := range []string{"1/3 (", "2/3 (", "3/3 (", "(A)", "(B)", "(C)", "100%"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("progress output missing %q: %q", want, buf.String())
+		}
+	}
+}
+
 func TestBatchGetSeriesSynctestConcurrencyOrderingAndWarnings(t *testing.T) {
 	orig := seriesComplianceLookup
 	t.Cleanup(func() { seriesComplianceLookup = orig })
@@ -338,3 +389,72 @@ func TestBatchGetSeriesSynctestDefaultConcurrencyFallback(t *testing.T) {
 		<-done
 	})
 }
+
+func TestResolveRelativeStartUsesLocalCalendarDay(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+	// 03:00 UTC on New Year's Day is still New Year's Eve in Los Angeles.
+	instant := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+
+	got, err := resolveRelativeStart("ytd", instant)
+	if err != nil {
+		t.Fatalf("resolveRelativeStart UTC: %v", err)
+	}
+	if got != "2026-01-01" {
+		t.Errorf("ytd in UTC: expected 2026-01-01, got %s", got)
+	}
+	got, err = resolveRelativeStart("ytd", instant.In(la))
+	if err != nil {
+		t.Fatalf("resolveRelativeStart LA: %v", err)
+	}
+	if got != "2025-01-01" {
+		t.Errorf("ytd in America/Los_Angeles: expected 2025-01-01, got %s", got)
+	}
+}
+
+func TestResolveRelativeStartSpecs(t *testing.T) {
+	now := time.Date(2026, 5, 20, 12, 0, 0, 0, time.UTC)
+	cases := map[string]string{
+		"qtd": "2026-04-01",
+		"MTD": "2026-05-01",
+		"10d": "2026-05-10",
+		"2w":  "2026-05-06",
+		"3m":  "2026-02-20",
+		"1y":  "2025-05-20",
+	}
+	for spec, want := range cases {
+		got, err := resolveRelativeStart(spec, now)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		if got != want {
+			t.Errorf("%s: expected %s, got %s", spec, want, got)
+		}
+	}
+	for _, spec := range []string{"", "y", "0d", "-1m", "5x", "last"} {
+		if _, err := resolveRelativeStart(spec, now); err == nil {
+			t.Errorf("%q: expected error", spec)
+		}
+	}
+}
+
+func TestObsCacheKeySeparatesVintages(t *testing.T) {
+	current := obsCacheKey("UNRATE", fred.ObsOptions{})
+	if current != "" {
+		t.Fatalf("no parameters should select the canonical set, got %q", current)
+	}
+	opts := fred.ObsOptions{Start: "2020-01-01", RealtimeStart: "2020-06-01", RealtimeEnd: "2020-06-30"}
+	vintage := obsCacheKey("UNRATE", opts)
+	want := store.ObsKeyRealtime("UNRATE", "2020-01-01", "", "", "", "", "2020-06-01", "2020-06-30")
+	if vintage != want {
+		t.Errorf("vintage key = %q, want %q (must match the store key format)", vintage, want)
+	}
+	if vintage == obsCacheKey("UNRATE", fred.ObsOptions{Start: "2020-01-01"}) {
+		t.Error("vintage and current requests must not share a cache key")
+	}
+	if obsCacheKey("UNRATE", fred.ObsOptions{RealtimeEnd: "2020-06-30"}) == "" {
+		t.Error("a realtime window alone must still select an exact key")
+	}
+}
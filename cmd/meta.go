@@ -34,7 +34,8 @@ var metaSeriesCmd = &cobra.Command{
 	Short: "Fetch metadata for one or more series",
 	Example: `  reserve meta series GDP CPIAUCSL
   reserve meta series UNRATE --format json`,
-	Args: cobra.MinimumNArgs(1),
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeSeriesIDs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		deps, err := buildDeps()
 		if err != nil {
@@ -4,15 +4,26 @@
 package cmd
 
 import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/app"
 	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/transform"
+	"github.com/derickschaefer/reserve/internal/util"
+	"github.com/derickschaefer/reserve/internal/watch"
 	"github.com/spf13/cobra"
 )
 
@@ -35,6 +46,17 @@ var (
 	obsAgg   string
 	obsLimit int
 	obsFrom  string
+
+	obsSeriesGroup string
+	obsWithDelta   bool
+	obsGzip        bool
+	obsMaxAge      string
+	obsClampRef    string
+	obsAsReturns   string
+	obsRelDates    string
+
+	obsRealtimeStart string
+	obsRealtimeEnd   string
 )
 
 type latestRow struct {
@@ -75,22 +97,75 @@ var obsGetCmd = &cobra.Command{
 	Example: `  reserve obs get GDP
   reserve obs get CPIAUCSL --start 2020-01-01 --end 2024-12-31
   reserve obs get CPIAUCSL --from cache --format jsonl
+  reserve obs get CPIAUCSL --from cache --max-age 24h
+  reserve obs get CPIAUCSL --max-age 24h
+  reserve obs get DGS10 --from cache --start 2024-01-01
   reserve obs get UNRATE --freq monthly --units pc1
-  reserve obs get GDP CPIAUCSL --format csv --out data.csv`,
-	Args: cobra.MinimumNArgs(1),
+  reserve obs get GDP CPIAUCSL --format csv --out data.csv
+  reserve obs get GDP CPIAUCSL --format xlsx --out data.xlsx
+  reserve obs get GDP --format parquet --out gdp.parquet
+  reserve obs get UNRATE --start 2024-01-01 --with-delta
+  reserve obs get --series-group 'DGS*' --from cache --format jsonl
+  reserve obs get CPIAUCSL --format jsonl --gzip > cpi.jsonl.gz
+  reserve obs get SP500 --from cache --as-returns log --format jsonl
+  reserve obs get UNRATE --relative-dates ytd
+  reserve obs get DGS10 --relative-dates 3m --observation-timezone America/New_York
+  reserve obs get UNRATE --realtime-start 2020-01-01 --realtime-end 2020-12-31 --format jsonl`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if obsSeriesGroup != "" {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeSeriesIDs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		deps, err := buildDeps()
+		src, err := resolveObsSource(obsFrom)
 		if err != nil {
 			return err
 		}
-
-		src, err := resolveObsSource(obsFrom)
+		// Cache reads never write, so they share the database with a
+		// running fetch instead of waiting on its lock.
+		build := buildDeps
+		if _, ok := src.(cacheObsSource); ok {
+			build = buildReadOnlyDeps
+		}
+		deps, err := build()
 		if err != nil {
 			return err
 		}
 		if err := validateObsSourceConfig(deps, src); err != nil {
 			return err
 		}
+		if obsMaxAge != "" {
+			maxAge, err := parseAge(obsMaxAge)
+			if err != nil {
+				return fmt.Errorf("--max-age: %w", err)
+			}
+			// From the cache, an old copy only warns; live, a fresh stored
+			// copy is served instead of fetching.
+			switch s := src.(type) {
+			case cacheObsSource:
+				s.maxAge = maxAge
+				src = s
+			case liveObsSource:
+				src = freshObsSource{maxAge: maxAge}
+			}
+		}
+		if obsClampRef != "" {
+			refID := resolveSeriesID(deps, obsClampRef)
+			lo, hi, err := cachedObservedRange(deps, refID)
+			if err != nil {
+				return fmt.Errorf("--clamp-to-observed-range: %w", err)
+			}
+			src = clampedObsSource{obsSource: src, lo: lo, hi: hi}
+		}
+		if obsAsReturns != "" {
+			method := strings.ToLower(obsAsReturns)
+			if method != "arithmetic" && method != "log" {
+				return fmt.Errorf("--as-returns: must be arithmetic or log, got %q", obsAsReturns)
+			}
+			src = returnsObsSource{obsSource: src, method: method}
+		}
 
 		// Validate date flags if provided
 		if obsStart != "" {
@@ -103,18 +178,51 @@ var obsGetCmd = &cobra.Command{
 				return fmt.Errorf("--end: invalid date %q, expected YYYY-MM-DD", obsEnd)
 			}
 		}
+		for flag, v := range map[string]string{"--realtime-start": obsRealtimeStart, "--realtime-end": obsRealtimeEnd} {
+			if v == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return fmt.Errorf("%s: invalid date %q, expected YYYY-MM-DD", flag, v)
+			}
+		}
+		if obsRealtimeStart != "" && obsRealtimeEnd != "" && obsRealtimeEnd < obsRealtimeStart {
+			return fmt.Errorf("--realtime-end %s is before --realtime-start %s", obsRealtimeEnd, obsRealtimeStart)
+		}
+		startDate := obsStart
+		if obsRelDates != "" {
+			if obsStart != "" {
+				return fmt.Errorf("--relative-dates and --start are mutually exclusive")
+			}
+			if startDate, err = resolveRelativeStart(obsRelDates, deps.Config.Now()); err != nil {
+				return fmt.Errorf("--relative-dates: %w", err)
+			}
+		}
 
 		opts := fred.ObsOptions{
-			Start: obsStart,
+			Start: startDate,
 			End:   obsEnd,
 			Freq:  obsFreq,
 			Units: obsUnits,
 			Agg:   obsAgg,
 			Limit: obsLimit,
+
+			RealtimeStart: obsRealtimeStart,
+			RealtimeEnd:   obsRealtimeEnd,
+		}
+		if err := opts.Validate(); err != nil {
+			return err
 		}
 
 		start := time.Now()
 		ids := resolveSeriesIDs(deps, args)
+		if obsSeriesGroup != "" {
+			matched, err := resolveSeriesGroup(deps, src, obsSeriesGroup)
+			if err != nil {
+				return err
+			}
+			ids = normaliseIDs(append(ids, matched...))
+		}
 		format := resolveFormat(deps.Config.Format)
 		commandFrom := ""
 		if obsFrom != "" {
@@ -124,6 +232,71 @@ var obsGetCmd = &cobra.Command{
 			fmt.Fprintf(cmd.ErrOrStderr(), "DEBUG obs.get source=%s ids=%d\n", src.name(), len(ids))
 		}
 
+		if obsGzip && format != render.FormatJSONL {
+			return fmt.Errorf("--gzip requires --format jsonl")
+		}
+		if format == render.FormatXLSX && globalFlags.Out == "" {
+			return fmt.Errorf("--format xlsx writes a binary workbook; use --out FILE.xlsx")
+		}
+		if format == render.FormatParquet && globalFlags.Out == "" {
+			return fmt.Errorf("--format parquet writes a binary file; use --out FILE.parquet")
+		}
+
+		if format == render.FormatJSONL && (obsSeriesGroup != "" || obsGzip) {
+			results, warnings, _ := batchGetObs(cmd.Context(), deps, ids, opts, src)
+			if len(results) == 0 {
+				return fmt.Errorf("no observations retrieved: %s", strings.Join(warnings, "; "))
+			}
+			w, closeOut, err := outputWriter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			var zw *gzip.Writer
+			if obsGzip {
+				zw = gzip.NewWriter(w)
+				w = zw
+			}
+			if err := writeSeriesJSONL(w, results, commandFrom, obsSeriesGroup != ""); err != nil {
+				_ = closeOut()
+				return err
+			}
+			if zw != nil {
+				if err := zw.Close(); err != nil {
+					_ = closeOut()
+					return err
+				}
+			}
+			if err := closeOut(); err != nil {
+				return err
+			}
+			if len(warnings) > 0 {
+				render.PrintFooter(obsFooterWriter(cmd, format), &model.Result{Warnings: warnings}, deps.Config.Verbose)
+			}
+			return nil
+		}
+
+		if obsWithDelta && supportsDeltaColumn(format) {
+			results, warnings, _ := batchGetObs(cmd.Context(), deps, ids, opts, src)
+			if len(results) == 0 {
+				return fmt.Errorf("no observations retrieved: %s", strings.Join(warnings, "; "))
+			}
+			w, closeOut, err := outputWriter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			if err := writeObsWithDelta(w, results, format); err != nil {
+				_ = closeOut()
+				return err
+			}
+			if err := closeOut(); err != nil {
+				return err
+			}
+			if len(warnings) > 0 {
+				render.PrintFooter(obsFooterWriter(cmd, format), &model.Result{Warnings: warnings}, deps.Config.Verbose)
+			}
+			return nil
+		}
+
 		if len(ids) == 1 {
 			data, cacheHit, warnings, err := src.get(cmd.Context(), deps, ids[0], opts)
 			if err != nil {
@@ -150,6 +323,30 @@ var obsGetCmd = &cobra.Command{
 
 		// Multiple series: fetch concurrently, output sequentially
 		results, warnings, anyCache := batchGetObs(cmd.Context(), deps, ids, opts, src)
+		if format == render.FormatXLSX || format == render.FormatParquet {
+			if len(results) == 0 {
+				return fmt.Errorf("no observations retrieved: %s", strings.Join(warnings, "; "))
+			}
+			w, closeOut, err := outputWriter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			write := render.RenderWorkbook
+			if format == render.FormatParquet {
+				write = render.RenderParquet
+			}
+			if err := write(w, results); err != nil {
+				_ = closeOut()
+				return err
+			}
+			if err := closeOut(); err != nil {
+				return err
+			}
+			if len(warnings) > 0 {
+				render.PrintFooter(cmd.OutOrStdout(), &model.Result{Warnings: warnings}, deps.Config.Verbose)
+			}
+			return nil
+		}
 		if format == render.FormatTable || format == "" {
 			printSimpleTable(cmd.OutOrStdout(), []string{"SERIES", "DATE", "VALUE"}, func(add func(...string)) {
 				for _, data := range results {
@@ -192,6 +389,202 @@ var obsGetCmd = &cobra.Command{
 	},
 }
 
+// clampedObsSource wraps another source and bounds every returned value to
+// [lo, hi], typically the observed range of a reference series.
+type clampedObsSource struct {
+	obsSource
+	lo, hi float64
+}
+
+func (src clampedObsSource) get(ctx context.Context, deps *app.Deps, id string, opts fred.ObsOptions) (*model.SeriesData, bool, []string, error) {
+	data, cacheHit, warnings, err := src.obsSource.get(ctx, deps, id, opts)
+	if err != nil || data == nil {
+		return data, cacheHit, warnings, err
+	}
+	clamped := *data
+	if clamped.Obs, err = transform.Clip(data.Obs, src.lo, src.hi); err != nil {
+		return nil, false, nil, err
+	}
+	return &clamped, cacheHit, warnings, nil
+}
+
+// returnsObsSource wraps another source and converts levels to one-period
+// returns: arithmetic uses PctChange(1), log uses LogReturns. Both drop the
+// first observation.
+type returnsObsSource struct {
+	obsSource
+	method string
+}
+
+func (src returnsObsSource) get(ctx context.Context, deps *app.Deps, id string, opts fred.ObsOptions) (*model.SeriesData, bool, []string, error) {
+	data, cacheHit, warnings, err := src.obsSource.get(ctx, deps, id, opts)
+	if err != nil || data == nil {
+		return data, cacheHit, warnings, err
+	}
+	returns := *data
+	if src.method == "log" {
+		returns.Obs, err = transform.LogReturns(data.Obs)
+	} else {
+		returns.Obs, _, err = transform.PctChange(data.Obs, 1, false, transform.PctChangeStandard)
+	}
+	if err != nil {
+		return nil, false, nil, fmt.Errorf("%s: --as-returns: %w", id, err)
+	}
+	return &returns, cacheHit, warnings, nil
+}
+
+// cachedObservedRange returns the non-NaN min/max of a series in the local
+// store, using the same canonical set selection as 'obs get --from cache'.
+func cachedObservedRange(deps *app.Deps, id string) (float64, float64, error) {
+	obs, err := cachedObservations(deps, id)
+	if err != nil {
+		return 0, 0, fmt.Errorf("reference series: %w", err)
+	}
+	lo, hi, err := transform.ObservedRange(obs)
+	if err != nil {
+		return 0, 0, fmt.Errorf("reference series %s has %w", id, err)
+	}
+	return lo, hi, nil
+}
+
+// cachedObservations loads the canonical cached observation set for a series,
+// for commands that pull a secondary series from the store rather than stdin.
+func cachedObservations(deps *app.Deps, id string) ([]model.Observation, error) {
+	if err := deps.RequireStore(); err != nil {
+		return nil, err
+	}
+	keys, err := deps.Store.ListObsKeys(id)
+	if err != nil {
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no cached observations for %s", id)
+	}
+	set, _, err := selectCanonicalObsSet(deps.Store, keys)
+	if err != nil {
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+	return set.data.Obs, nil
+}
+
+// resolveSeriesGroup expands a --series-group glob against the series stored
+// in the local cache. Only the cache source can be enumerated this way.
+func resolveSeriesGroup(deps *app.Deps, src obsSource, pattern string) ([]string, error) {
+	if src.name() != "cache" {
+		return nil, fmt.Errorf("--series-group requires --from cache")
+	}
+	if err := deps.RequireStore(); err != nil {
+		return nil, err
+	}
+	ids, err := deps.Store.MatchSeriesIDs(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no cached series match %q", pattern)
+	}
+	return ids, nil
+}
+
+// writeSeriesJSONL writes each series as its own JSONL block. When labeled is
+// set, every block is preceded by a "// series: <ID>" line; pipeline readers
+// skip "//" lines, so the stream stays consumable by transform and analyze.
+func writeSeriesJSONL(w io.Writer, results []*model.SeriesData, commandFrom string, labeled bool) error {
+	for _, data := range results {
+		if labeled {
+			if _, err := fmt.Fprintf(w, "// series: %s\n", data.SeriesID); err != nil {
+				return err
+			}
+		}
+		result := &model.Result{
+			Kind:        model.KindSeriesData,
+			GeneratedAt: time.Now(),
+			Command:     fmt.Sprintf("obs get %s%s", data.SeriesID, commandFrom),
+			Data:        data,
+			Stats:       model.ResultStats{Items: len(data.Obs)},
+		}
+		if err := render.Render(w, result, render.FormatJSONL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// supportsDeltaColumn reports whether --with-delta applies to format. The delta
+// column is a reading aid, so machine formats (json, jsonl) are left untouched.
+func supportsDeltaColumn(format string) bool {
+	switch format {
+	case render.FormatTable, render.FormatCSV, render.FormatTSV, render.FormatMD, "":
+		return true
+	}
+	return false
+}
+
+// writeObsWithDelta renders observations with an extra delta column holding
+// the change from the previous non-missing value (blank for the first).
+func writeObsWithDelta(w io.Writer, results []*model.SeriesData, format string) error {
+	type deltaRow struct {
+		seriesID, date, raw, delta string
+		value                      float64
+	}
+	var rows []deltaRow
+	for _, data := range results {
+		deltas := transform.DeltaFromPrev(data.Obs)
+		for i, obs := range data.Obs {
+			rows = append(rows, deltaRow{
+				seriesID: data.SeriesID,
+				date:     obs.Date.Format("2006-01-02"),
+				value:    obs.Value,
+				raw:      obs.ValueRaw,
+				delta:    formatDelta(deltas[i], obs.ValueRaw),
+			})
+		}
+	}
+
+	switch format {
+	case render.FormatCSV, render.FormatTSV:
+		cw := csv.NewWriter(w)
+		if format == render.FormatTSV {
+			cw.Comma = '\t'
+		}
+		_ = cw.Write([]string{"series_id", "date", "value", "value_raw", "delta"})
+		for _, r := range rows {
+			_ = cw.Write([]string{r.seriesID, r.date, util.FormatValue(r.value), r.raw, r.delta})
+		}
+		cw.Flush()
+		return cw.Error()
+	case render.FormatMD:
+		fmt.Fprintf(w, "| SERIES | DATE | VALUE | DELTA |\n|--------|------|-------|-------|\n")
+		for _, r := range rows {
+			fmt.Fprintf(w, "| %s | %s | %s | %s |\n", r.seriesID, r.date, r.raw, r.delta)
+		}
+	default:
+		printSimpleTable(w, []string{"SERIES", "DATE", "VALUE", "DELTA"}, func(add func(...string)) {
+			for _, r := range rows {
+				add(r.seriesID, r.date, r.raw, r.delta)
+			}
+		})
+	}
+	if footer := obsCitationFooter(results); footer != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, footer)
+	}
+	return nil
+}
+
+// formatDelta prints d with the same number of decimals as the observation's
+// raw value so float noise from the subtraction does not leak into output.
+func formatDelta(d float64, raw string) string {
+	if math.IsNaN(d) {
+		return ""
+	}
+	decimals := 0
+	if dot := strings.IndexByte(raw, '.'); dot >= 0 {
+		decimals = len(raw) - dot - 1
+	}
+	return fmt.Sprintf("%.*f", decimals, d)
+}
+
 func validateObsSourceConfig(deps *app.Deps, src obsSource) error {
 	if src.requiresAPIKey() {
 		return deps.Config.Validate()
@@ -201,7 +594,7 @@ func validateObsSourceConfig(deps *app.Deps, src obsSource) error {
 
 func obsFooterWriter(cmd *cobra.Command, format string) io.Writer {
 	switch format {
-	case render.FormatJSON, render.FormatJSONL, render.FormatCSV, render.FormatTSV, render.FormatMD:
+	case render.FormatJSON, render.FormatJSONL, render.FormatCSV, render.FormatTSV, render.FormatMD, render.FormatYAML:
 		return cmd.ErrOrStderr()
 	default:
 		return cmd.OutOrStdout()
@@ -215,7 +608,8 @@ var obsLatestCmd = &cobra.Command{
 	Short: "Show the most recent observation for one or more series",
 	Example: `  reserve obs latest GDP
   reserve obs latest UNRATE CPIAUCSL --format table`,
-	Args: cobra.MinimumNArgs(1),
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeSeriesIDs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		deps, err := buildDeps()
 		if err != nil {
@@ -287,19 +681,404 @@ var obsLatestCmd = &cobra.Command{
 	},
 }
 
+// ─── obs watch ────────────────────────────────────────────────────────────────
+
+var (
+	obsWatchInterval time.Duration
+	obsWatchOnce     bool
+)
+
+var obsWatchCmd = &cobra.Command{
+	Use:   "watch <SERIES_ID>",
+	Short: "Print the latest observation of a series whenever it changes",
+	Long: `Polls the latest observation of SERIES_ID every --interval and prints a
+timestamped line with the first value seen and again whenever the latest date
+or value changes, including a revision of the current observation. Polls go
+through the same rate limiter as every other request. A failed poll is
+reported on stderr and retried at the next interval; only a request FRED
+rejects, such as an unknown series or a bad API key, ends the watch. Stop
+with Ctrl-C.
+
+--format jsonl (or json) writes each change as one JSON line, suitable for
+appending to an audit log. --once polls a single time and exits.`,
+	Example: `  reserve obs watch FEDFUNDS --interval 5m
+  reserve obs watch DGS10 --interval 1h --format jsonl >> dgs10.jsonl
+  reserve obs watch UNRATE --once`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !obsWatchOnce && obsWatchInterval < watchMinInterval {
+			return fmt.Errorf("--interval must be at least %s, got %s", watchMinInterval, obsWatchInterval)
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.Config.Validate(); err != nil {
+			return err
+		}
+		id := resolveSeriesIDs(deps, args)[0]
+		if _, err := ensureSeriesCompliance(cmd.Context(), deps, id, "display"); err != nil {
+			return err
+		}
+
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		format := resolveFormat(deps.Config.Format)
+		notify := func(o model.Observation, prev *model.Observation) {
+			if err := writeObsWatchChange(w, format, id, deps.Config.Now(), o, prev); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "⚠  %s: %v\n", id, err)
+			}
+		}
+
+		if obsWatchOnce {
+			obs, err := deps.Client.GetLatestObservation(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			notify(*obs, nil)
+			return nil
+		}
+		if !deps.Config.Quiet {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Watching %s every %s. Press Ctrl-C to stop.\n", id, obsWatchInterval)
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		onError := func(err error) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "⚠  %s: poll failed, retrying next interval: %v\n", id, err)
+		}
+		return watch.Changes(ctx, deps.Client, id, obsWatchInterval, notify, onError)
+	},
+}
+
+// obsWatchRow is one JSONL audit line of obs watch. The previous_* fields are
+// empty on the first line.
+type obsWatchRow struct {
+	ObservedAt    string `json:"observed_at"`
+	SeriesID      string `json:"series_id"`
+	Date          string `json:"date"`
+	Value         any    `json:"value"`
+	ValueRaw      string `json:"value_raw"`
+	PreviousDate  string `json:"previous_date,omitempty"`
+	PreviousValue string `json:"previous_value_raw,omitempty"`
+}
+
+// writeObsWatchChange writes one change line: a JSONL row for the machine
+// formats and a line stamped with at, in at's zone, otherwise.
+func writeObsWatchChange(w io.Writer, format, id string, at time.Time, o model.Observation, prev *model.Observation) error {
+	stamp := at.Format(time.RFC3339)
+	date := o.Date.Format("2006-01-02")
+	switch format {
+	case render.FormatJSON, render.FormatJSONL:
+		row := obsWatchRow{ObservedAt: stamp, SeriesID: id, Date: date, ValueRaw: o.ValueRaw}
+		if !math.IsNaN(o.Value) {
+			row.Value = o.Value
+		}
+		if prev != nil {
+			row.PreviousDate = prev.Date.Format("2006-01-02")
+			row.PreviousValue = prev.ValueRaw
+		}
+		return json.NewEncoder(w).Encode(row)
+	}
+	line := fmt.Sprintf("%s  %s  %s = %s", stamp, id, date, o.ValueRaw)
+	switch {
+	case prev == nil:
+	case prev.Date.Equal(o.Date):
+		line += fmt.Sprintf("  (revised from %s)", prev.ValueRaw)
+	default:
+		line += fmt.Sprintf("  (was %s = %s)", prev.Date.Format("2006-01-02"), prev.ValueRaw)
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// ─── obs revisions ────────────────────────────────────────────────────────────
+
+var (
+	obsRevisionsDate    string
+	obsRevisionsBetween []string
+)
+
+var obsRevisionsCmd = &cobra.Command{
+	Use:   "revisions <SERIES_ID>",
+	Short: "Show every published value of one observation, or what changed between two vintages",
+	Long: `With --date, queries FRED across all vintages for the observation of
+SERIES_ID dated --date, and lists each value that was published with the date
+it first became available. CHANGE is the revision relative to the previous
+vintage.
+
+With --between A B, fetches the whole series as FRED published it on vintage
+date A and on vintage date B, and lists every observation whose value differs:
+its date, the old value, the new value, and the delta. Observations first
+published after A are new data, not revisions, and are not listed.`,
+	Example: `  reserve obs revisions UNRATE --date 2020-04-01
+  reserve obs revisions GDP --date 2008-10-01 --format json
+  reserve obs revisions UNRATE --between 2020-05-08 2021-05-08`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completeSeriesIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vintages, err := revisionsVintages(obsRevisionsBetween, args[1:])
+		if err != nil {
+			return err
+		}
+		switch {
+		case obsRevisionsDate != "" && vintages != nil:
+			return fmt.Errorf("--date and --between are mutually exclusive")
+		case obsRevisionsDate == "" && vintages == nil:
+			return fmt.Errorf("--date or --between is required")
+		case obsRevisionsDate != "":
+			if _, err := time.Parse("2006-01-02", obsRevisionsDate); err != nil {
+				return fmt.Errorf("--date: invalid date %q, expected YYYY-MM-DD", obsRevisionsDate)
+			}
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.Config.Validate(); err != nil {
+			return err
+		}
+
+		id := resolveSeriesID(deps, args[0])
+		meta, err := ensureSeriesCompliance(cmd.Context(), deps, id, "display")
+		if err != nil {
+			return err
+		}
+		if vintages != nil {
+			return runVintageComparison(cmd, deps, id, vintages[0], vintages[1], meta.CitationText)
+		}
+		rows, err := deps.Client.GetObservationRevisions(cmd.Context(), id, obsRevisionsDate)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return fmt.Errorf("no observation for %s dated %s", id, obsRevisionsDate)
+		}
+
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		out := obsRevisionsOut{SeriesID: id, Date: obsRevisionsDate, Revisions: rows}
+		switch resolveFormat(deps.Config.Format) {
+		case render.FormatJSON:
+			return writeObsRevisionsJSON(w, out, false)
+		case render.FormatJSONL:
+			return writeObsRevisionsJSON(w, out, true)
+		case render.FormatYAML:
+			return writeObsRevisionsYAML(w, out)
+		default:
+			writeObsRevisionsTable(w, out)
+			if meta.CitationText != "" {
+				fmt.Fprintf(w, "\n%s\n", meta.CitationText)
+			}
+			return nil
+		}
+	},
+}
+
+// revisionsVintages reads the two --between vintage dates. They can be given
+// as --between A,B, or as --between A B, where pflag leaves B as a second
+// positional argument (extra). It returns nil when --between is unset.
+func revisionsVintages(between, extra []string) ([]string, error) {
+	if len(between) == 0 {
+		if len(extra) > 0 {
+			return nil, fmt.Errorf("unexpected argument %q", extra[0])
+		}
+		return nil, nil
+	}
+	dates := append(append([]string(nil), between...), extra...)
+	if len(dates) != 2 {
+		return nil, fmt.Errorf("--between takes two vintage dates, got %d", len(dates))
+	}
+	for _, d := range dates {
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return nil, fmt.Errorf("--between: invalid date %q, expected YYYY-MM-DD", d)
+		}
+	}
+	if dates[1] <= dates[0] {
+		return nil, fmt.Errorf("--between: %s is not after %s", dates[1], dates[0])
+	}
+	return dates, nil
+}
+
+// runVintageComparison prints the observations of id whose value changed
+// between vintage dates a and b.
+func runVintageComparison(cmd *cobra.Command, deps *app.Deps, id, a, b, citation string) error {
+	diffs, err := deps.Client.CompareVintages(cmd.Context(), id, a, b)
+	if err != nil {
+		return err
+	}
+	w, closeFn, err := outputWriter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+	payload := vintageDiffPayload(id, a, b, diffs)
+	switch format := resolveFormat(deps.Config.Format); format {
+	case render.FormatJSON, render.FormatJSONL:
+		enc := json.NewEncoder(w)
+		if format == render.FormatJSON {
+			enc.SetIndent("", "  ")
+		}
+		return enc.Encode(payload)
+	case render.FormatYAML:
+		return render.EncodeYAML(w, payload)
+	}
+
+	fmt.Fprintf(w, "%s  %s → %s\n\n", id, a, b)
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "No observations were revised between these vintages.")
+	} else {
+		printSimpleTable(w, []string{"DATE", "OLD VALUE", "NEW VALUE", "DELTA"}, func(add func(...string)) {
+			for _, d := range diffs {
+				delta := ""
+				if !math.IsNaN(d.Delta) {
+					delta = fmt.Sprintf("%+g", roundRevision(d.Delta))
+				}
+				add(d.Date, d.OldRaw, d.NewRaw, delta)
+			}
+		})
+	}
+	if citation != "" {
+		fmt.Fprintf(w, "\n%s\n", citation)
+	}
+	return nil
+}
+
+// vintageDiffPayload is the JSON and YAML document for --between, with a
+// missing value or delta as null.
+func vintageDiffPayload(id, a, b string, diffs []fred.VintageDiff) any {
+	type change struct {
+		Date     string `json:"date"`
+		OldValue any    `json:"old_value"`
+		NewValue any    `json:"new_value"`
+		Delta    any    `json:"delta"`
+		OldRaw   string `json:"old_value_raw"`
+		NewRaw   string `json:"new_value_raw"`
+	}
+	orNil := func(v float64) any {
+		if math.IsNaN(v) {
+			return nil
+		}
+		return v
+	}
+	payload := struct {
+		SeriesID string   `json:"series_id"`
+		VintageA string   `json:"vintage_a"`
+		VintageB string   `json:"vintage_b"`
+		Changes  []change `json:"changes"`
+	}{SeriesID: id, VintageA: a, VintageB: b, Changes: make([]change, len(diffs))}
+	for i, d := range diffs {
+		payload.Changes[i] = change{
+			Date:     d.Date,
+			OldValue: orNil(d.OldValue),
+			NewValue: orNil(d.NewValue),
+			Delta:    orNil(roundRevision(d.Delta)),
+			OldRaw:   d.OldRaw,
+			NewRaw:   d.NewRaw,
+		}
+	}
+	return payload
+}
+
+type obsRevisionsOut struct {
+	SeriesID  string
+	Date      string
+	Revisions []fred.RevisionRow
+}
+
+func writeObsRevisionsTable(w io.Writer, out obsRevisionsOut) {
+	fmt.Fprintf(w, "%s  %s\n\n", out.SeriesID, out.Date)
+	printSimpleTable(w, []string{"VINTAGE DATE", "VALUE", "CHANGE"}, func(add func(...string)) {
+		for i, r := range out.Revisions {
+			change := ""
+			if i > 0 {
+				if d := r.Value - out.Revisions[i-1].Value; !math.IsNaN(d) {
+					change = fmt.Sprintf("%+g", roundRevision(d))
+				}
+			}
+			add(r.VintageDate, r.ValueRaw, change)
+		}
+	})
+}
+
+// roundRevision trims float noise from the difference of two published
+// values, which carry at most a handful of decimals.
+func roundRevision(d float64) float64 {
+	return math.Round(d*1e6) / 1e6
+}
+
+// writeObsRevisionsJSON writes the revisions with a missing value as null,
+// matching how observation values are encoded.
+func writeObsRevisionsJSON(w io.Writer, out obsRevisionsOut, jsonl bool) error {
+	enc := json.NewEncoder(w)
+	if !jsonl {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(obsRevisionsPayload(out))
+}
+
+// writeObsRevisionsYAML writes the same document as writeObsRevisionsJSON.
+func writeObsRevisionsYAML(w io.Writer, out obsRevisionsOut) error {
+	return render.EncodeYAML(w, obsRevisionsPayload(out))
+}
+
+func obsRevisionsPayload(out obsRevisionsOut) any {
+	type revision struct {
+		VintageDate string `json:"vintage_date"`
+		Value       any    `json:"value"`
+		ValueRaw    string `json:"value_raw"`
+	}
+	payload := struct {
+		SeriesID  string     `json:"series_id"`
+		Date      string     `json:"date"`
+		Revisions []revision `json:"revisions"`
+	}{SeriesID: out.SeriesID, Date: out.Date, Revisions: make([]revision, len(out.Revisions))}
+	for i, r := range out.Revisions {
+		var v any
+		if !math.IsNaN(r.Value) {
+			v = r.Value
+		}
+		payload.Revisions[i] = revision{VintageDate: r.VintageDate, Value: v, ValueRaw: r.ValueRaw}
+	}
+	return payload
+}
+
 func init() {
 	rootCmd.AddCommand(obsCmd)
 	obsCmd.AddCommand(obsGetCmd)
 	obsCmd.AddCommand(obsLatestCmd)
+	obsCmd.AddCommand(obsRevisionsCmd)
+	obsCmd.AddCommand(obsWatchCmd)
+
+	obsRevisionsCmd.Flags().StringVar(&obsRevisionsDate, "date", "", "observation date YYYY-MM-DD whose vintages to list")
+	obsRevisionsCmd.Flags().StringSliceVar(&obsRevisionsBetween, "between", nil, "two vintage dates A B (or A,B): list observations revised between them")
+
+	obsWatchCmd.Flags().DurationVar(&obsWatchInterval, "interval", 5*time.Minute, "time between polls (at least 1m)")
+	obsWatchCmd.Flags().BoolVar(&obsWatchOnce, "once", false, "poll once, print the latest observation, and exit")
 
 	for _, c := range []*cobra.Command{obsGetCmd} {
 		c.Flags().StringVar(&obsStart, "start", "", "start date YYYY-MM-DD")
 		c.Flags().StringVar(&obsEnd, "end", "", "end date YYYY-MM-DD")
+		c.Flags().StringVar(&obsRelDates, "relative-dates", "", "start relative to today in the observation timezone: ytd|qtd|mtd|<n>d|<n>w|<n>m|<n>y")
 		c.Flags().StringVar(&obsFreq, "freq", "", "frequency: daily|weekly|monthly|quarterly|annual")
 		c.Flags().StringVar(&obsUnits, "units", "", "units: lin|chg|ch1|pch|pc1|pca|cch|cca|log")
 		c.Flags().StringVar(&obsAgg, "agg", "", "aggregation: avg|sum|eop")
 		c.Flags().IntVar(&obsLimit, "limit", 0, "max observations (0 = all)")
+		c.Flags().StringVar(&obsRealtimeStart, "realtime-start", "", "vintage window start YYYY-MM-DD: data as FRED published it then")
+		c.Flags().StringVar(&obsRealtimeEnd, "realtime-end", "", "vintage window end YYYY-MM-DD")
 		c.Flags().StringVar(&obsFrom, "from", "", "data source: live|cache (default: live)")
+		c.Flags().StringVar(&obsMaxAge, "max-age", "", "live: serve the stored copy instead of fetching when it is newer than this; --from cache: warn when it is older (e.g. 24h, 7d)")
+		c.Flags().BoolVar(&obsGzip, "gzip", false, "gzip-compress JSONL output (requires --format jsonl)")
+		c.Flags().BoolVar(&obsWithDelta, "with-delta", false, "add a delta column (change from previous observation) to table/csv/tsv/md output")
+		c.Flags().StringVar(&obsSeriesGroup, "series-group", "", "glob of cached series IDs to include (e.g. 'DGS*'; requires --from cache)")
+		c.Flags().StringVar(&obsClampRef, "clamp-to-observed-range", "", "clamp values into the min/max of this cached reference series")
+		c.Flags().StringVar(&obsAsReturns, "as-returns", "", "output one-period returns instead of levels: arithmetic|log (drops the first observation)")
 	}
 }
 
@@ -5,8 +5,13 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"math"
+	"net/http"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +21,7 @@ import (
 	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/derickschaefer/reserve/internal/store"
+	"github.com/derickschaefer/reserve/internal/transform"
 	"github.com/spf13/cobra"
 )
 
@@ -363,3 +369,616 @@ func TestCacheObsSourceFailsClosedWithoutRightsIndex(t *testing.T) {
 		t.Fatalf("expected compliance failure when rights index is missing")
 	}
 }
+
+func TestSeriesGroupEmitsLabeledBlocksPerMatch(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer s.Close()
+
+	for i, id := range []string{"DGS10", "DGS2", "GDP"} {
+		data := model.SeriesData{
+			SeriesID: id,
+			Obs: []model.Observation{{
+				Date:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				Value:    float64(i + 1),
+				ValueRaw: fmt.Sprintf("%d", i+1),
+			}},
+		}
+		if err := s.PutObs(store.ObsKey(id, "", "", "", "", ""), data); err != nil {
+			t.Fatalf("PutObs %s: %v", id, err)
+		}
+		if err := s.PutSeriesMeta(model.SeriesMeta{
+			ID:                id,
+			CopyrightStatus:   "public_domain_citation_requested",
+			CitationText:      "Source: FRED",
+			LastRightsCheckAt: time.Now().UTC(),
+		}); err != nil {
+			t.Fatalf("PutSeriesMeta %s: %v", id, err)
+		}
+	}
+
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath}, Store: s}
+	src, err := resolveObsSource("cache")
+	if err != nil {
+		t.Fatalf("resolveObsSource(cache): %v", err)
+	}
+	ids, err := resolveSeriesGroup(deps, src, "DGS*")
+	if err != nil {
+		t.Fatalf("resolveSeriesGroup: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "DGS10" || ids[1] != "DGS2" {
+		t.Fatalf("expected [DGS10 DGS2], got %v", ids)
+	}
+
+	results, warnings, _ := batchGetObs(t.Context(), deps, ids, fred.ObsOptions{}, src)
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+	var buf bytes.Buffer
+	if err := writeSeriesJSONL(&buf, results, " --from cache", true); err != nil {
+		t.Fatalf("writeSeriesJSONL: %v", err)
+	}
+	out := buf.String()
+	for _, id := range []string{"DGS10", "DGS2"} {
+		if !strings.Contains(out, "// series: "+id+"\n") {
+			t.Fatalf("missing label for %s:\n%s", id, out)
+		}
+		if !strings.Contains(out, `"series_id":"`+id+`"`) {
+			t.Fatalf("missing rows for %s:\n%s", id, out)
+		}
+	}
+	if strings.Contains(out, "GDP") {
+		t.Fatalf("GDP should not match DGS*:\n%s", out)
+	}
+}
+
+func TestSeriesGroupRequiresCacheSource(t *testing.T) {
+	src, err := resolveObsSource("live")
+	if err != nil {
+		t.Fatalf("resolveObsSource(live): %v", err)
+	}
+	if _, err := resolveSeriesGroup(&app.Deps{Config: &config.Config{}}, src, "DGS*"); err == nil {
+		t.Fatal("expected error when --series-group is used with live source")
+	}
+}
+
+func TestWriteObsWithDeltaTable(t *testing.T) {
+	data := &model.SeriesData{
+		SeriesID: "UNRATE",
+		Obs: []model.Observation{
+			{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 3.7, ValueRaw: "3.7"},
+			{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+			{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Value: 3.9, ValueRaw: "3.9"},
+			{Date: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Value: 3.8, ValueRaw: "3.8"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := writeObsWithDelta(&buf, []*model.SeriesData{data}, render.FormatTable); err != nil {
+		t.Fatalf("writeObsWithDelta: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "DELTA") {
+		t.Fatalf("expected DELTA header:\n%s", out)
+	}
+
+	rows := map[string][]string{}
+	for _, line := range strings.Split(out, "\n") {
+		cells := strings.Split(line, "|")
+		if len(cells) != 6 || strings.TrimSpace(cells[1]) != "UNRATE" {
+			continue
+		}
+		rows[strings.TrimSpace(cells[2])] = []string{strings.TrimSpace(cells[3]), strings.TrimSpace(cells[4])}
+	}
+	want := map[string]string{
+		"2024-01-01": "",
+		"2024-02-01": "",
+		"2024-03-01": "0.2",
+		"2024-04-01": "-0.1",
+	}
+	for date, delta := range want {
+		row, ok := rows[date]
+		if !ok {
+			t.Fatalf("missing row for %s:\n%s", date, out)
+		}
+		if row[1] != delta {
+			t.Fatalf("%s delta: got %q want %q", date, row[1], delta)
+		}
+	}
+}
+
+func TestWriteObsWithDeltaCSV(t *testing.T) {
+	data := &model.SeriesData{
+		SeriesID: "GDP",
+		Obs: []model.Observation{
+			{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 100, ValueRaw: "100"},
+			{Date: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Value: 103, ValueRaw: "103"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := writeObsWithDelta(&buf, []*model.SeriesData{data}, render.FormatCSV); err != nil {
+		t.Fatalf("writeObsWithDelta: %v", err)
+	}
+	want := "series_id,date,value,value_raw,delta\nGDP,2024-01-01,100,100,\nGDP,2024-04-01,103,103,3\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected csv:\n%s", buf.String())
+	}
+	if supportsDeltaColumn(render.FormatJSONL) {
+		t.Fatal("jsonl should not receive a delta column")
+	}
+}
+
+func TestCacheObsSourceWarnsWhenOlderThanMaxAge(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer s.Close()
+
+	data := model.SeriesData{
+		SeriesID: "GDP",
+		Obs: []model.Observation{{
+			Date:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Value:    100,
+			ValueRaw: "100",
+		}},
+	}
+	if err := s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), data); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	if err := s.PutSeriesMeta(model.SeriesMeta{
+		ID:                "GDP",
+		CopyrightStatus:   "public_domain_citation_requested",
+		CitationText:      "Source: Bureau of Economic Analysis via FRED",
+		LastRightsCheckAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("PutSeriesMeta: %v", err)
+	}
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath}, Store: s}
+
+	_, _, warnings, err := cacheObsSource{maxAge: time.Hour}.get(t.Context(), deps, "GDP", fred.ObsOptions{})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("fresh data should not warn, got %v", warnings)
+	}
+
+	got, _, warnings, err := cacheObsSource{maxAge: time.Nanosecond}.get(t.Context(), deps, "GDP", fred.ObsOptions{})
+	if err != nil {
+		t.Fatalf("stale data must still be returned, got error: %v", err)
+	}
+	if got == nil || len(got.Obs) != 1 {
+		t.Fatalf("unexpected data: %+v", got)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "--max-age") {
+		t.Fatalf("expected one staleness warning, got %v", warnings)
+	}
+}
+
+func TestFreshObsSourceServesStoredCopyWithinMaxAge(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), model.SeriesData{
+		SeriesID: "GDP",
+		Obs:      []model.Observation{{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 100, ValueRaw: "100"}},
+	}); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	for _, id := range []string{"GDP", "UNRATE"} {
+		if err := s.PutSeriesMeta(model.SeriesMeta{
+			ID:                id,
+			CopyrightStatus:   "public_domain_citation_requested",
+			LastRightsCheckAt: time.Now().UTC(),
+		}); err != nil {
+			t.Fatalf("PutSeriesMeta: %v", err)
+		}
+	}
+
+	requests := 0
+	client := fred.NewClient("test_key", "https://mock.fred.local/", 5*time.Second, 1000, false)
+	client.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		rec := newResponseRecorder()
+		_ = json.NewEncoder(rec).Encode(map[string]any{
+			"observations": []map[string]string{{"date": "2024-01-01", "value": "101"}},
+		})
+		return rec.Result(), nil
+	})})
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath}, Client: client, Store: s}
+
+	cases := []struct {
+		name      string
+		id        string
+		maxAge    time.Duration
+		wantFetch bool
+	}{
+		{"fresh entry", "GDP", time.Hour, false},
+		{"stale entry", "GDP", time.Nanosecond, true},
+		{"zero max age", "GDP", 0, true},
+		{"missing entry", "UNRATE", time.Hour, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests = 0
+			got, cacheHit, warnings, err := freshObsSource{maxAge: tc.maxAge}.get(t.Context(), deps, tc.id, fred.ObsOptions{})
+			if err != nil {
+				t.Fatalf("get: %v", err)
+			}
+			if fetched := requests > 0; fetched != tc.wantFetch {
+				t.Fatalf("fetched = %v, want %v", fetched, tc.wantFetch)
+			}
+			if tc.wantFetch {
+				if cacheHit || len(warnings) != 0 || got.Obs[0].Value != 101 {
+					t.Fatalf("expected live data, got hit=%v warnings=%v obs=%+v", cacheHit, warnings, got.Obs)
+				}
+				return
+			}
+			if !cacheHit || got.Obs[0].Value != 100 {
+				t.Fatalf("expected the stored copy as a cache hit, got hit=%v obs=%+v", cacheHit, got.Obs)
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], "skipped fetch") {
+				t.Fatalf("expected a cache-hit warning, got %v", warnings)
+			}
+		})
+	}
+}
+
+func TestCacheObsSourceCutsDateWindowFromFullHistory(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer s.Close()
+
+	data := model.SeriesData{SeriesID: "GDP"}
+	for i, v := range []float64{100, 101, 102, 103} {
+		data.Obs = append(data.Obs, model.Observation{
+			Date:     time.Date(2024, time.Month(1+3*i), 1, 0, 0, 0, 0, time.UTC),
+			Value:    v,
+			ValueRaw: fmt.Sprint(v),
+		})
+	}
+	if err := s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), data); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	if err := s.PutSeriesMeta(model.SeriesMeta{
+		ID:                "GDP",
+		CopyrightStatus:   "public_domain_citation_requested",
+		CitationText:      "Source: Bureau of Economic Analysis via FRED",
+		LastRightsCheckAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("PutSeriesMeta: %v", err)
+	}
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath}, Store: s}
+
+	got, _, _, err := cacheObsSource{}.get(t.Context(), deps, "GDP", fred.ObsOptions{Start: "2024-04-01", End: "2024-07-01"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got.Obs) != 2 || got.Obs[0].Value != 101 || got.Obs[1].Value != 102 {
+		t.Fatalf("unexpected window: %+v", got.Obs)
+	}
+
+	if _, _, _, err := (cacheObsSource{}).get(t.Context(), deps, "GDP", fred.ObsOptions{Start: "2024-01-01", Units: "pc1"}); err == nil {
+		t.Fatal("expected a miss for a transformed variant that is not cached")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	cases := map[string]time.Duration{
+		"24h": 24 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+		"90m": 90 * time.Minute,
+	}
+	for in, want := range cases {
+		got, err := parseAge(in)
+		if err != nil || got != want {
+			t.Fatalf("parseAge(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, bad := range []string{"", "7x", "-1d", "d"} {
+		if _, err := parseAge(bad); err == nil {
+			t.Fatalf("parseAge(%q): expected error", bad)
+		}
+	}
+}
+
+func TestObsGetFromCacheRunsBesideAnotherReader(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	dbPath := os.Getenv(config.EnvDBPath)
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	if err := s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), model.SeriesData{
+		SeriesID: "GDP",
+		Obs:      []model.Observation{{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 100, ValueRaw: "100"}},
+	}); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	if err := s.PutSeriesMeta(model.SeriesMeta{
+		ID:                "GDP",
+		CopyrightStatus:   "public_domain_citation_requested",
+		CitationText:      "Source: Bureau of Economic Analysis via FRED",
+		LastRightsCheckAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("PutSeriesMeta: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A shared reader would keep a read-write open waiting on the lock.
+	reader, err := store.OpenReadOnly(dbPath)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer reader.Close()
+
+	obsFrom = "cache"
+	t.Cleanup(func() { obsFrom = "" })
+	var out bytes.Buffer
+	obsGetCmd.SetOut(&out)
+	t.Cleanup(func() { obsGetCmd.SetOut(nil) })
+
+	begin := time.Now()
+	if err := obsGetCmd.RunE(obsGetCmd, []string{"GDP"}); err != nil {
+		t.Fatalf("obs get --from cache: %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Errorf("obs get --from cache took %s; it should not wait on the store lock", elapsed)
+	}
+}
+
+func TestHumanAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		30 * time.Second: "30s",
+		90 * time.Second: "2m",
+		5 * time.Hour:    "5h",
+		72 * time.Hour:   "3d",
+	}
+	for d, want := range cases {
+		if got := humanAge(d); got != want {
+			t.Errorf("humanAge(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestClampedObsSourceBoundsToReferenceRange(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer s.Close()
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	ref := model.SeriesData{SeriesID: "REF", Obs: []model.Observation{
+		{Date: day(1), Value: 2, ValueRaw: "2"},
+		{Date: day(2), Value: math.NaN(), ValueRaw: "."},
+		{Date: day(3), Value: 8, ValueRaw: "8"},
+	}}
+	target := model.SeriesData{SeriesID: "GDP", Obs: []model.Observation{
+		{Date: day(1), Value: 1, ValueRaw: "1"},
+		{Date: day(2), Value: 5, ValueRaw: "5"},
+		{Date: day(3), Value: 12, ValueRaw: "12"},
+	}}
+	for _, d := range []model.SeriesData{ref, target} {
+		if err := s.PutObs(store.ObsKey(d.SeriesID, "", "", "", "", ""), d); err != nil {
+			t.Fatalf("PutObs %s: %v", d.SeriesID, err)
+		}
+	}
+	if err := s.PutSeriesMeta(model.SeriesMeta{
+		ID:                "GDP",
+		CopyrightStatus:   "public_domain_citation_requested",
+		CitationText:      "Source: Bureau of Economic Analysis via FRED",
+		LastRightsCheckAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("PutSeriesMeta: %v", err)
+	}
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath}, Store: s}
+
+	lo, hi, err := cachedObservedRange(deps, "REF")
+	if err != nil || lo != 2 || hi != 8 {
+		t.Fatalf("cachedObservedRange = %g, %g, %v", lo, hi, err)
+	}
+	got, _, _, err := clampedObsSource{obsSource: cacheObsSource{}, lo: lo, hi: hi}.get(t.Context(), deps, "GDP", fred.ObsOptions{})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	for i, want := range []float64{2, 5, 8} {
+		if got.Obs[i].Value != want {
+			t.Fatalf("obs[%d] = %g, want %g", i, got.Obs[i].Value, want)
+		}
+	}
+
+	if _, _, err := cachedObservedRange(deps, "MISSING"); err == nil {
+		t.Fatal("expected error for uncached reference")
+	}
+	empty := model.SeriesData{SeriesID: "EMPTY", Obs: []model.Observation{{Date: day(1), Value: math.NaN(), ValueRaw: "."}}}
+	if err := s.PutObs(store.ObsKey("EMPTY", "", "", "", "", ""), empty); err != nil {
+		t.Fatalf("PutObs EMPTY: %v", err)
+	}
+	if _, _, err := cachedObservedRange(deps, "EMPTY"); err == nil || !strings.Contains(err.Error(), "no valid") {
+		t.Fatalf("expected no-valid-values error, got %v", err)
+	}
+}
+
+func TestReturnsObsSourceMatchesTransforms(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "reserve.db")
+	s, err := store.Open(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer s.Close()
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	levels := model.SeriesData{SeriesID: "SP500", Obs: []model.Observation{
+		{Date: day(1), Value: 100, ValueRaw: "100"},
+		{Date: day(2), Value: 104, ValueRaw: "104"},
+		{Date: day(3), Value: math.NaN(), ValueRaw: "."},
+		{Date: day(4), Value: 99, ValueRaw: "99"},
+		{Date: day(5), Value: 101.5, ValueRaw: "101.5"},
+	}}
+	if err := s.PutObs(store.ObsKey("SP500", "", "", "", "", ""), levels); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	if err := s.PutSeriesMeta(model.SeriesMeta{
+		ID:                "SP500",
+		CopyrightStatus:   "public_domain_citation_requested",
+		CitationText:      "Source: S&P Dow Jones Indices via FRED",
+		LastRightsCheckAt: time.Now().UTC(),
+	}); err != nil {
+		t.Fatalf("PutSeriesMeta: %v", err)
+	}
+	deps := &app.Deps{Config: &config.Config{DBPath: dbPath}, Store: s}
+
+	pct, _, err := transform.PctChange(levels.Obs, 1, false, transform.PctChangeStandard)
+	if err != nil {
+		t.Fatalf("PctChange: %v", err)
+	}
+	logRet, err := transform.LogReturns(levels.Obs)
+	if err != nil {
+		t.Fatalf("LogReturns: %v", err)
+	}
+	for method, want := range map[string][]model.Observation{"arithmetic": pct, "log": logRet} {
+		got, _, _, err := returnsObsSource{obsSource: cacheObsSource{}, method: method}.get(t.Context(), deps, "SP500", fred.ObsOptions{})
+		if err != nil {
+			t.Fatalf("%s get: %v", method, err)
+		}
+		if len(got.Obs) != len(want) {
+			t.Fatalf("%s: expected %d observations, got %d", method, len(want), len(got.Obs))
+		}
+		for i := range want {
+			g, w := got.Obs[i], want[i]
+			if !g.Date.Equal(w.Date) || g.ValueRaw != w.ValueRaw || (g.Value != w.Value && !(math.IsNaN(g.Value) && math.IsNaN(w.Value))) {
+				t.Errorf("%s obs[%d] = %+v, want %+v", method, i, g, w)
+			}
+		}
+	}
+	if got := logRet[0].Value; math.Abs(got-100*math.Log(1.04)) > 1e-9 {
+		t.Errorf("log return = %g, want %g", got, 100*math.Log(1.04))
+	}
+}
+
+func TestWriteObsRevisions(t *testing.T) {
+	out := obsRevisionsOut{
+		SeriesID: "UNRATE",
+		Date:     "2020-04-01",
+		Revisions: []fred.RevisionRow{
+			{VintageDate: "2020-05-08", Value: 14.7, ValueRaw: "14.7"},
+			{VintageDate: "2020-06-05", Value: 14.8, ValueRaw: "14.8"},
+			{VintageDate: "2021-01-08", Value: math.NaN(), ValueRaw: "."},
+		},
+	}
+
+	var table bytes.Buffer
+	writeObsRevisionsTable(&table, out)
+	for _, want := range []string{"VINTAGE DATE", "2020-05-08", "14.7", "2020-06-05", "+0.1"} {
+		if !strings.Contains(table.String(), want) {
+			t.Errorf("table missing %q\n%s", want, table.String())
+		}
+	}
+
+	var js bytes.Buffer
+	if err := writeObsRevisionsJSON(&js, out, true); err != nil {
+		t.Fatalf("writeObsRevisionsJSON: %v", err)
+	}
+	want := `{"series_id":"UNRATE","date":"2020-04-01","revisions":[` +
+		`{"vintage_date":"2020-05-08","value":14.7,"value_raw":"14.7"},` +
+		`{"vintage_date":"2020-06-05","value":14.8,"value_raw":"14.8"},` +
+		`{"vintage_date":"2021-01-08","value":null,"value_raw":"."}]}` + "\n"
+	if js.String() != want {
+		t.Errorf("jsonl:\n got  %s want %s", js.String(), want)
+	}
+}
+
+func TestRevisionsVintages(t *testing.T) {
+	cases := []struct {
+		between, extra []string
+		want           []string
+		err            string
+	}{
+		{nil, nil, nil, ""},
+		{[]string{"2020-05-08", "2021-05-08"}, nil, []string{"2020-05-08", "2021-05-08"}, ""},
+		{[]string{"2020-05-08"}, []string{"2021-05-08"}, []string{"2020-05-08", "2021-05-08"}, ""},
+		{[]string{"2020-05-08"}, nil, nil, "two vintage dates"},
+		{[]string{"2021-05-08", "2020-05-08"}, nil, nil, "not after"},
+		{[]string{"2020-05-08", "May 2021"}, nil, nil, "invalid date"},
+		{nil, []string{"2021-05-08"}, nil, "unexpected argument"},
+	}
+	for _, tc := range cases {
+		got, err := revisionsVintages(tc.between, tc.extra)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%v %v: expected error containing %q, got %v", tc.between, tc.extra, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("%v %v: got %v, %v; want %v", tc.between, tc.extra, got, err, tc.want)
+		}
+	}
+}
+
+func TestWriteObsWatchChange(t *testing.T) {
+	at := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	prev := model.Observation{Date: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), Value: 4.33, ValueRaw: "4.33"}
+	o := model.Observation{Date: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Value: 4.08, ValueRaw: "4.08"}
+
+	var buf bytes.Buffer
+	if err := writeObsWatchChange(&buf, "table", "FEDFUNDS", at, o, &prev); err != nil {
+		t.Fatalf("writeObsWatchChange: %v", err)
+	}
+	if want := "2026-10-16T18:00:00Z  FEDFUNDS  2026-09-01 = 4.08  (was 2026-08-01 = 4.33)\n"; buf.String() != want {
+		t.Errorf("table:\n got  %q\n want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	revised := prev
+	revised.ValueRaw = "4.35"
+	if err := writeObsWatchChange(&buf, "table", "FEDFUNDS", at, revised, &prev); err != nil {
+		t.Fatalf("writeObsWatchChange: %v", err)
+	}
+	if !strings.HasSuffix(buf.String(), "(revised from 4.33)\n") {
+		t.Errorf("expected a revision note, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeObsWatchChange(&buf, "jsonl", "FEDFUNDS", at, o, nil); err != nil {
+		t.Fatalf("writeObsWatchChange jsonl: %v", err)
+	}
+	want := `{"observed_at":"2026-10-16T18:00:00Z","series_id":"FEDFUNDS","date":"2026-09-01","value":4.08,"value_raw":"4.08"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("jsonl:\n got  %s want %s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := writeObsWatchChange(&buf, "table", "FEDFUNDS", at.In(time.FixedZone("EDT", -4*3600)), o, nil); err != nil {
+		t.Fatalf("writeObsWatchChange: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "2026-10-16T14:00:00-04:00  FEDFUNDS") {
+		t.Errorf("expected a stamp in the observation timezone, got %q", buf.String())
+	}
+}
+
+func TestObsWatchRejectsShortInterval(t *testing.T) {
+	origInterval, origOnce := obsWatchInterval, obsWatchOnce
+	obsWatchInterval, obsWatchOnce = 10*time.Second, false
+	t.Cleanup(func() { obsWatchInterval, obsWatchOnce = origInterval, origOnce })
+
+	err := obsWatchCmd.RunE(obsWatchCmd, []string{"FEDFUNDS"})
+	if err == nil || !strings.Contains(err.Error(), "--interval") {
+		t.Fatalf("expected --interval error, got %v", err)
+	}
+}
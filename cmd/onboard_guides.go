@@ -14,17 +14,24 @@ type onboardCommandGuide struct {
 
 var onboardCommandRegistry = []onboardCommandGuide{
 	{Name: "alias", Category: "setup", Summary: "Manage local friendly names for long FRED series IDs.", Build: buildAliasGuide},
+	{Name: "align", Category: "source", Summary: "Resample and join two series onto a shared date grid, emitted as multi-series JSONL.", Build: buildAlignGuide},
 	{Name: "analyze", Category: "pipeline", Summary: "Terminal statistical summaries and trend fitting for JSONL observation streams.", Build: buildAnalyzeGuide},
 	{Name: "cache", Category: "maintenance", Summary: "Inspect and maintain the local embedded key-value cache file (bbolt).", Build: buildCacheGuide},
 	{Name: "category", Category: "discovery", Summary: "Explore the FRED category tree and list series under categories.", Build: buildCategoryGuide},
-	{Name: "chart", Category: "pipeline", Summary: "Render JSONL observation streams as ASCII charts via chart bar or chart plot.", Build: buildChartGuide},
+	{Name: "chart", Category: "pipeline", Summary: "Render JSONL observation streams as ASCII charts via chart bar, chart plot, chart spark, chart histogram, chart scatter, or chart heatmap.", Build: buildChartGuide},
+	{Name: "compare", Category: "source", Summary: "Fetch two series and show them side by side on shared dates with period-over-period % change.", Build: buildCompareGuide},
 	{Name: "completion", Category: "support", Summary: "Generate shell completion scripts for bash, zsh, fish, and PowerShell.", Build: buildCompletionGuide},
 	{Name: "config", Category: "setup", Summary: "Create, inspect, and update reserve configuration and API key settings.", Build: buildConfigGuide},
+	{Name: "export", Category: "support", Summary: "Generate shareable artifacts such as a runnable analysis script for a series.", Build: buildExportGuide},
 	{Name: "fetch", Category: "ingest", Summary: "Pull metadata or observations from FRED and optionally persist them locally.", Build: buildFetchGuide},
+	{Name: "geo", Category: "discovery", Summary: "Show GeoFRED regional cross-sections: one value per state, county, or metro area for a series group.", Build: buildGeoGuide},
 	{Name: "onboard", Category: "support", Summary: "Emit machine-readable onboarding JSON for the whole program or a specific command.", Build: buildOnboardSelfGuide},
 	{Name: "meta", Category: "discovery", Summary: "Batch metadata lookup across series, categories, releases, sources, and tags.", Build: buildMetaGuide},
 	{Name: "obs", Category: "source", Summary: "Fetch live FRED observations directly from the API.", Build: buildObsGuide},
+	{Name: "pipeline", Category: "pipeline", Summary: "Peek at the first or last rows of a JSONL observation stream.", Build: buildPipelineGuide},
 	{Name: "release", Category: "discovery", Summary: "Browse FRED data releases, release dates, and release-linked series.", Build: buildReleaseGuide},
+	{Name: "report", Category: "support", Summary: "Generate an HTML or Markdown report with charts, summary statistics, and trends for several series.", Build: buildReportGuide},
+	{Name: "schedule", Category: "ingest", Summary: "Store reserve commands with cron expressions and run them unattended, optionally as a background daemon.", Build: buildScheduleGuide},
 	{Name: "search", Category: "discovery", Summary: "Run global full-text search across FRED series.", Build: buildSearchGuide},
 	{Name: "series", Category: "discovery", Summary: "Fetch, search, and inspect FRED series metadata and relationships.", Build: buildSeriesGuide},
 	{Name: "snippet", Category: "setup", Summary: "Store and run reusable local pipeline command snippets from filesystem-backed libraries.", Build: buildSnippetGuide},
@@ -33,6 +40,7 @@ var onboardCommandRegistry = []onboardCommandGuide{
 	{Name: "transform", Category: "pipeline", Summary: "Apply stateless JSONL-to-JSONL transformations to observation streams.", Build: buildTransformGuide},
 	{Name: "update", Category: "support", Summary: "Check a remote release manifest for newer versions and release highlights.", Build: buildUpdateGuide},
 	{Name: "version", Category: "support", Summary: "Report build, Go, and platform metadata for provenance and packaging.", Build: buildVersionGuide},
+	{Name: "watch", Category: "source", Summary: "Poll a series and report each newly published observation to stdout or email.", Build: buildWatchGuide},
 	{Name: "window", Category: "pipeline", Summary: "Compute rolling-window statistics from JSONL observation streams.", Build: buildWindowGuide},
 }
 
@@ -231,18 +239,24 @@ func buildCommandIndex() []map[string]any {
 
 func buildGlobalFlags() map[string]any {
 	return map[string]any{
-		"--format":      "table|json|jsonl|csv|tsv|md  (default: table for terminal, jsonl when piped for pipeline commands)",
-		"--out":         "write output to file instead of stdout",
-		"--api-key":     "FRED API key override (also: FRED_API_KEY env, config.json)",
-		"--timeout":     "HTTP request timeout e.g. 30s, 2m  (default: 30s)",
-		"--concurrency": "max parallel requests for batch operations  (default: 8)",
-		"--rate":        "API requests/sec client-side limit  (default: 2.0)",
-		"--verbose":     "show timing and cache stats after output",
-		"--debug":       "log HTTP requests with API key redacted",
-		"--quiet":       "suppress all non-error output",
-		"--no-cache":    "bypass local database reads",
-		"--refresh":     "force re-fetch and overwrite cached entries",
-		"--ai-onboard":  "emit AI onboarding for the addressed command instead of executing it",
+		"--format":                  "table|json|jsonl|csv|tsv|md|yaml  (default: table for terminal, jsonl when piped for pipeline commands); `chart plot` and `chart bar` also accept svg; `obs get` also accepts xlsx and parquet with --out",
+		"--template":                "Go text/template run per observation (.SeriesID .Date .Value .ValueRaw .IsNaN; .Date has time.Time methods), per series for metadata (.ID .Title .Frequency ...), or once per result (.Data); inline or @FILE; helpers formatFloat, isNaN; overrides --format (tmpl, alias template)",
+		"--out":                     "write output to file instead of stdout",
+		"--api-key":                 "FRED API key override (also: FRED_API_KEY env, config.json)",
+		"--proxy":                   "http, https, or socks5 proxy URL for FRED requests (also: RESERVE_PROXY_URL env, proxy_url in config.json)",
+		"--timeout":                 "HTTP request timeout e.g. 30s, 2m  (default: 30s)",
+		"--concurrency":             "max parallel requests for batch operations  (default: 8)",
+		"--rate":                    "API requests/sec client-side limit  (default: 2.0)",
+		"--circuit-break-threshold": "consecutive FRED failures (5xx or network) that open the circuit breaker  (default: 5)",
+		"--circuit-break-timeout":   "how long requests fail fast once the breaker opens, before one probe request  (default: 30s)",
+		"--verbose":                 "show timing and cache stats after output",
+		"--debug":                   "log HTTP requests with API key redacted",
+		"--quiet":                   "suppress all non-error output",
+		"--no-cache":                "bypass local database reads",
+		"--refresh":                 "force re-fetch and overwrite cached entries",
+		"--observation-timezone":    "IANA zone anchoring \"now\" for relative dates such as obs get --relative-dates ytd  (default: UTC)",
+		"--color":                   "auto|always|never (or on|off)  colorize charts (bars green at or above the series mean, red below, yellow at zero; plot lines blue) and summary change %  (default: auto; plain when piped, written with --out, or NO_COLOR is set)",
+		"--ai-onboard":              "emit AI onboarding for the addressed command instead of executing it",
 	}
 }
 
@@ -294,26 +308,44 @@ func buildAnalyzeGuide() map[string]any {
 		"Statistical summaries, trend models, comparisons, and experimental regime detection for JSONL observation streams.",
 		"`analyze` is a terminal pipeline command family. It consumes JSONL from stdin and prints human-oriented output or JSON summaries.",
 		"Use `analyze summary` for descriptive statistics, add `--by-series` when one JSONL stream contains several series IDs, use `analyze trend` when you need slope, direction, and fit quality, use `analyze compare` when you want pairwise series comparison, and use `analyze regime` for experimental change-point detection.",
-		"Terminal pipeline stage: JSONL in, summary/comparison/regime output out.",
-		"Reads JSONL observations from stdin. Does not emit JSONL for downstream reserve commands.",
+		"Terminal pipeline stage: JSONL in, summary/comparison/regime output out. `roll-corr` (and `decompose --emit`) are the exceptions and emit JSONL observations.",
+		"Reads JSONL observations from stdin. Only `roll-corr` and `decompose --emit` emit JSONL for downstream reserve commands.",
 		map[string]any{
-			"summary": "reserve analyze summary [--by-series] [--window N]",
-			"trend":   "reserve analyze trend [--method linear|theil-sen] [--confidence]",
-			"compare": "reserve analyze compare --against <SERIES_ID> [--series <SERIES_ID>]",
-			"regime":  "reserve analyze regime --method cusum [--threshold N]",
+			"summary":   "reserve analyze summary [--by-series] [--window N] [--spark] [--robust] [--percentiles P,P,...] [--ddof 0|1] [--by-period month|quarter|year] [--stream]",
+			"trend":     "reserve analyze trend [--method linear|theil-sen|poly] [--degree 2|3] [--confidence] [--rolling N] [--cache-results]",
+			"compare":   "reserve analyze compare --against <SERIES_ID> [--series <SERIES_ID>]",
+			"xcorr":     "reserve analyze xcorr --with <SERIES_ID> [--series <SERIES_ID>] [--max-lag N]",
+			"roll-corr": "reserve analyze roll-corr --with <SERIES_ID> [--series <SERIES_ID>] [--window N]",
+			"regime":    "reserve analyze regime --method cusum [--threshold N] [--cache-results]",
+			"subseries": "reserve analyze subseries",
+			"half-life": "reserve analyze half-life",
+			"decompose": "reserve analyze decompose [--period N] [--model additive|multiplicative] [--emit trend|seasonal|residual]",
+			"laspeyres": "reserve analyze laspeyres --base YYYY-MM-DD --components \"A,B,C\" --weights \"w1,w2,w3\"",
 		},
 		map[string]any{
-			"summary": "global `--format` plus optional `--by-series` and `--window N`",
-			"trend":   "--method linear|theil-sen, --confidence for slope uncertainty",
-			"compare": "--against <SERIES_ID> and optional --series <SERIES_ID>",
-			"regime":  "--method cusum and optional --threshold N (experimental)",
+			"summary":   "global `--format` plus optional `--by-series`, `--window N`, `--spark` for a sparkline column, `--robust` for MAD, IQR, and trimmed-mean columns, `--percentiles 5,50,95,99` to report those cut points (0-100) instead of P25/median/P75, `--ddof 0` for population instead of sample std, `--by-period month|quarter|year` for one row per calendar period across years, and `--stream` to summarize a long series in constant memory (approximate quantiles within 0.1%; MAD and trimmed mean null)",
+			"trend":     "--method linear|theil-sen|poly, --degree 2|3 for the poly fit (coefficients, R², convex/concave curvature), --confidence for slope significance (t-test with a Student t 95% CI for linear, Mann-Kendall with Sen's interval for theil-sen), --rolling N for one linear or theil-sen trend per trailing N-observation window (null until the window fills), --cache-results to reuse stored output for identical input",
+			"compare":   "--against <SERIES_ID> and optional --series <SERIES_ID>",
+			"xcorr":     "--with <SERIES_ID>, optional --series <SERIES_ID>, --max-lag N periods in each direction (default 12)",
+			"roll-corr": "--with <SERIES_ID>, optional --series <SERIES_ID>, --window N aligned observations (default 36, minimum 3)",
+			"regime":    "--method cusum and optional --threshold N (experimental); --cache-results to reuse stored output for identical input",
+			"subseries": "global `--format`; expects monthly input",
+			"half-life": "global `--format`; annualizes using the detected observation frequency (e.g. 12 periods a year for monthly, 252 for business-daily)",
+			"decompose": "--period N (default 12), --model additive|multiplicative, --emit to write one component as JSONL",
+			"laspeyres": "--base, --components, --weights (all required); components load from the local store, not stdin",
 		},
 		[]string{
 			"summary table",
 			"rolling summary table when `--window` is used",
 			"JSON summary object when `--format json`",
 			"comparison table or JSON object",
+			"lead-lag table with the best lag, its correlation, the leading series, and one row per lag",
+			"rolling correlation as JSONL observations under the primary series ID (table on a terminal)",
 			"regime table with change points and segments",
+			"month × year seasonal subseries table or JSON object",
+			"mean-reversion half-life table or JSON object (null half-life when not mean-reverting)",
+			"trend/seasonal/residual decomposition table or JSON object, or one component as JSONL with `--emit`",
+			"Laspeyres composite with per-component contributions that sum to the composite change",
 		},
 		[]string{
 			"When you already have a single observation stream and want descriptive statistics or a trend estimate.",
@@ -322,7 +354,7 @@ func buildAnalyzeGuide() map[string]any {
 			"When the next step is interpretation, reporting, or comparison rather than more pipeline transformation.",
 		},
 		[]string{
-			"When you need downstream JSONL for another reserve pipeline stage.",
+			"When you need downstream JSONL for another reserve pipeline stage, other than a rolling correlation or a decomposition component.",
 			"When you want grouped multi-series trend fits; `trend` still operates on one series stream at a time.",
 			"When you need a source-producing command; `analyze` only consumes JSONL.",
 		},
@@ -331,19 +363,29 @@ func buildAnalyzeGuide() map[string]any {
 			"Summarize several indicators fetched together in one batched pipeline.",
 			"Estimate whether a post-2020 trend is up, down, or flat.",
 			"Compare unemployment against fed funds over a shared date range.",
+			"Check whether the yield curve leads industrial production, and by how many months.",
+			"Chart how the stock-bond correlation has shifted over time.",
 			"Inspect an experimental regime change-point snapshot for a monthly series.",
 		},
 		[]string{
 			"reserve obs get CPIAUCSL --from cache --format jsonl | reserve analyze summary",
 			"reserve obs get FEDFUNDS DRCCLACBS T10Y2Y UNRATE --start 2008-01-01 --end 2008-12-31 --format jsonl | reserve analyze summary --by-series",
+			"reserve obs get UNRATE --from cache --format jsonl | reserve analyze summary --percentiles 5,50,95,99",
 			"reserve obs get UNRATE --start 2020-01-01 --format jsonl | reserve analyze trend --method theil-sen",
+			"reserve obs get CPIAUCSL --from cache --format jsonl | reserve analyze trend --rolling 60 --format jsonl",
 			"reserve obs get UNRATE FEDFUNDS --start 2010-01-01 --format jsonl | reserve analyze compare --against FEDFUNDS",
+			"reserve obs get INDPRO T10Y3M --freq monthly --format jsonl | reserve analyze xcorr --series INDPRO --with T10Y3M --max-lag 24",
+			"reserve obs get SP500 DGS10 --freq monthly --format jsonl | reserve analyze roll-corr --with DGS10 --window 36 | reserve chart plot",
 			"reserve obs get UNRATE --start 2010-01-01 --format jsonl | reserve analyze regime --method cusum --threshold 5",
+			"reserve obs get RSXFSN --start 2015-01-01 --format jsonl | reserve analyze decompose --emit seasonal | reserve chart plot",
 		},
 		[]string{
-			"`analyze` is terminal. Do not pipe its output into another reserve command.",
+			"`analyze` is terminal. Do not pipe its output into another reserve command; the exceptions are `analyze roll-corr`, which always writes its rolling correlation as JSONL, and `analyze decompose --emit`, which writes a component as JSONL.",
+			"`analyze roll-corr` output keeps the primary series ID, and its first window-1 values are null.",
+			"`analyze decompose` needs at least two full periods; trend and residual are null for the first and last half-period.",
 			"`analyze summary --by-series` is the supported way to summarize batched multi-series JSONL input.",
 			"`analyze compare` expects two aligned series IDs and prints pairwise comparison statistics.",
+			"`analyze xcorr` counts lags in observations, so both series need the same frequency; a positive best lag means the `--with` series leads.",
 			"`analyze regime` is experimental and may need threshold tuning for noisy monthly data.",
 		},
 		[]string{"obs", "transform", "window", "chart", "compare", "regime"},
@@ -359,21 +401,40 @@ func buildCacheGuide() map[string]any {
 		"Reads the configured local embedded key-value cache file (bbolt) and writes human-readable status or confirmation text.",
 		map[string]any{
 			"stats":     "reserve cache stats",
-			"inventory": "reserve cache inventory",
-			"clear":     "reserve cache clear --all | --bucket obs|series_meta | --series <ID>",
+			"inventory": "reserve cache inventory [--stale 7d]",
+			"clear":     "reserve cache clear --all | --bucket obs|series_meta|results | --series <ID>",
+			"delete":    "reserve cache delete <SERIES_ID>",
 			"compact":   "reserve cache compact",
+			"warm":      "reserve cache warm <SERIES_ID...> [--start YYYY-MM-DD] [--end YYYY-MM-DD]",
+			"verify":    "reserve cache verify",
+			"backup":    "reserve cache backup --out <FILE.tar.gz>",
+			"restore":   "reserve cache restore --from <FILE.tar.gz>",
+			"export":    "reserve cache export [--out backup.jsonl] | reserve cache export <SERIES_ID...> --format csv [--nan-sentinel dot] | reserve cache export --all --format csv --out <DIR>",
+			"import":    "reserve cache import <FILE> | reserve cache import <FILE.csv> --format csv --series-id <ID> [--date-col NAME] [--value-col NAME] [--key KEY] [--nan-sentinel TOKEN]",
 		},
 		map[string]any{
 			"stats":     "no command-specific flags",
-			"inventory": "primarily uses global `--format`",
-			"clear":     "--all | --bucket obs|series_meta | --series <ID>",
+			"inventory": "global `--format`; --stale AGE lists only series fetched longer ago than AGE",
+			"clear":     "--all | --bucket obs|series_meta|results | --series <ID>",
+			"delete":    "no command-specific flags; removes observations and metadata",
 			"compact":   "no command-specific flags",
+			"warm":      "--start, --end (default: all history); global `--concurrency` bounds parallel downloads; failed series become warnings",
+			"verify":    "global `--format json|jsonl` for a machine-readable report; exits non-zero when corruption is found",
+			"backup":    "global `--out` names the archive (required); compacts first",
+			"restore":   "--from <FILE> (required); keeps the current db as <db_path>.bak; rejects archives from a newer schema",
+			"export":    "uses global `--out` (a directory with --all); --format csv exports series as date,value,value_raw; --all; --nan-sentinel empty|dot",
+			"import":    "JSONL dumps need no flags; --format csv requires --series-id and accepts --date-col --value-col --key --nan-sentinel",
 		},
 		[]string{"maintenance table/text", "inventory coverage table", "status messages"},
 		[]string{
 			"When you need to inspect local DB size and bucket counts.",
 			"When you need to see which series, date ranges, and gaps exist locally before further analysis.",
 			"When local cache maintenance is needed after deleting data or repeated rewrites.",
+			"When a write may have been interrupted and you need to confirm every stored entry still decodes; use `cache verify`.",
+			"When you need to back up the local store or move it to another machine; use `cache export` and `cache import`.",
+			"When you want a fast whole-database snapshot to share with a team; use `cache backup` and `cache restore`.",
+			"When cached series should be handed to R, Python, or a spreadsheet without re-fetching; use `cache export --format csv`.",
+			"When a list of series should be stored before offline work; use `cache warm`.",
 		},
 		[]string{
 			"When you want live FRED data or metadata; use discovery/source commands instead.",
@@ -383,6 +444,7 @@ func buildCacheGuide() map[string]any {
 			"See what is stored locally.",
 			"Check whether a cached series is complete enough for analysis or needs a refill.",
 			"Clear one cache bucket or one series without deleting the entire DB.",
+			"Evict a stale series completely before re-fetching it.",
 		},
 		[]string{
 			"reserve cache stats",
@@ -390,9 +452,13 @@ func buildCacheGuide() map[string]any {
 			"reserve cache clear --series GDP",
 			"reserve cache clear --bucket obs",
 			"reserve cache compact",
+			"reserve cache warm UNRATE FEDFUNDS CPIAUCSL DGS10",
+			"reserve cache verify",
+			"reserve cache backup --out reserve-backup.tar.gz",
+			"reserve cache export --out backup.jsonl",
 		},
 		[]string{
-			"These commands require a working local DB path; they do not talk to the FRED API.",
+			"These commands require a working local DB path; apart from `cache warm`, they do not talk to the FRED API.",
 			"`cache clear` is destructive for local state. It does not affect upstream FRED data.",
 		},
 		[]string{"fetch", "config"},
@@ -438,6 +504,7 @@ func buildCategoryGuide() map[string]any {
 		[]string{
 			"`root` is valid for get/list/tree but not for `category series`.",
 			"Category browsing is metadata discovery; it does not fetch observation streams.",
+			"`category get|list|series` accept `--gzip` to compress `--format jsonl` output; `tree` does not.",
 		},
 		[]string{"series", "search", "fetch", "meta"},
 	)
@@ -445,39 +512,65 @@ func buildCategoryGuide() map[string]any {
 
 func buildChartGuide() map[string]any {
 	return makeGuide(
-		"Render a JSONL observation stream as an ASCII bar chart or ASCII plot.",
+		"Render a JSONL observation stream as an ASCII bar chart, ASCII plot, one-line sparkline, histogram, or seasonal heatmap, or scatter one series against another.",
 		"`chart` is a terminal pipeline command family for visual inspection in the terminal.",
-		"Use `chart bar` for low-frequency comparisons and `chart plot` for continuous time-series shape.",
+		"Use `chart bar` for low-frequency comparisons, `chart plot` for continuous time-series shape, `chart spark` for a compact one-line view per series, `chart histogram` for the distribution of values, `chart scatter` to see whether two indicators co-move, and `chart heatmap` to spot seasonality in a monthly or quarterly series.",
 		"Terminal pipeline stage: JSONL in, terminal chart out.",
-		"Reads JSONL observations from stdin. Supports exactly two verbs: `bar` and `plot`.",
+		"Reads JSONL observations from stdin; `chart plot SERIES_ID`, `--overlay`, and `chart scatter SERIES_X --vs SERIES_Y` load series from the local store instead; `chart scatter --x --y` picks two series out of a multi-series stdin stream. Supports exactly six verbs: `bar`, `plot`, `spark`, `histogram`, `scatter`, and `heatmap`.",
 		map[string]any{
-			"bar":  "reserve chart bar [--width N] [--max-bars N]",
-			"plot": "reserve chart plot [--width N] [--height N] [--title TEXT]",
+			"bar":       "reserve chart bar [--width N] [--max-bars N] [--format svg [--theme light|dark] --out FILE]",
+			"plot":      "reserve chart plot [SERIES_ID] [--width N] [--height N] [--title TEXT] [--overlay SERIES_ID [--separate-axes]] [--multi [--dual-axis]] [--recessions] [--log] [--format svg [--theme light|dark] --out FILE]",
+			"spark":     "reserve chart spark [--width N]",
+			"histogram": "reserve chart histogram [--bins N] [--width N]",
+			"scatter":   "reserve chart scatter (<SERIES_X> --vs <SERIES_Y> | --x <SERIES_X> --y <SERIES_Y>) [--fit|--trend] [--width N] [--height N] [--title TEXT]",
+			"heatmap":   "reserve chart heatmap",
 		},
 		map[string]any{
-			"bar":  "--width N --max-bars N",
-			"plot": "--width N --height N --title TEXT",
+			"bar":       "--width N --max-bars N; --format svg writes an SVG image, --theme dark gives it a dark background",
+			"plot":      "--width N --height N --title TEXT; --overlay SERIES_ID draws a cached series on the same axes, --separate-axes gives it a right-hand scale; --multi overlays every series in a multi-series stdin stream with a legend, --dual-axis moves all but the first to a right-hand scale; --recessions shades NBER recessions from USREC; --log uses a log10 Y axis; --format svg writes an SVG image, --theme dark gives it a dark background",
+			"spark":     "--width N (maximum characters per sparkline)",
+			"histogram": "--bins N (0 = Sturges' rule) --width N",
+			"scatter":   "--vs SERIES_Y with a cached SERIES_X, or --x and --y to read both from stdin; --fit (alias --trend) --width N --height N --title TEXT",
+			"heatmap":   "none; the grid is one row per year with month or quarter columns",
 		},
-		[]string{"terminal ASCII bar chart", "terminal ASCII plot"},
+		[]string{"terminal ASCII bar chart", "terminal ASCII plot", "standalone SVG bar or line chart (--format svg)", "one sparkline line per series", "terminal ASCII histogram with a missing-value note", "terminal ASCII scatter plot with optional OLS fit line", "year × month (or quarter) grid shaded by value"},
 		[]string{
 			"When you want a quick visual sanity check directly in the terminal.",
 			"When the series is already in JSONL and you want a terminal endpoint instead of a numeric summary.",
 		},
 		[]string{
 			"When you need machine-readable output for another reserve command.",
-			"When you expect a `line` subcommand; only `bar` and `plot` exist.",
+			"When you expect a `line` subcommand; only `bar`, `plot`, `spark`, `histogram`, `scatter`, and `heatmap` exist.",
 		},
 		[]string{
 			"Visualize resampled annual data as bars.",
 			"Plot a monthly series after smoothing or filtering.",
+			"Eyeball two cached series on the same axes.",
+			"Check whether two cached indicators move together, with a regression line.",
+			"Inspect a distribution before choosing a normalization.",
+			"Spot which months run high or low in a not-seasonally-adjusted series.",
 		},
 		[]string{
 			"reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform resample --freq annual --method mean | reserve chart bar",
 			"reserve obs get UNRATE --from cache --format jsonl | reserve chart plot --height 8",
-		},
-		[]string{
-			"There is no `reserve chart line` command. The supported verbs are only `bar` and `plot`.",
+			"reserve chart plot FEDFUNDS --overlay UNRATE",
+			"reserve obs get UNRATE FEDFUNDS --start 2015-01-01 --format jsonl | reserve chart plot --multi",
+			"reserve chart plot UNRATE --recessions",
+			"reserve chart plot M2SL --log",
+			"reserve chart plot UNRATE --format svg --out unrate.svg",
+			"reserve obs get FEDFUNDS --from cache --format jsonl | reserve transform resample --freq annual --method mean | reserve chart bar --format svg --theme dark --out fedfunds.svg",
+			"reserve chart scatter UNRATE --vs CPIAUCSL --fit",
+			"reserve obs get UNRATE FEDFUNDS --start 2000-01-01 --format jsonl | reserve chart scatter --x UNRATE --y FEDFUNDS --trend",
+			"reserve obs get UNRATE --from cache --format jsonl | reserve chart histogram --bins 20",
+			"reserve obs get RSXFSN --start 2015-01-01 --format jsonl | reserve chart heatmap",
+		},
+		[]string{
+			"There is no `reserve chart line` command. The supported verbs are only `bar`, `plot`, `spark`, `histogram`, `scatter`, and `heatmap`.",
 			"For dense monthly or daily data, resample or filter first so the chart stays legible.",
+			"`chart plot --multi` warns on stderr when one series would look flat on the shared axis; add `--dual-axis` to give the others a right-hand scale.",
+			"`--recessions` reads USREC from the local store and only calls FRED when it is not cached; it cannot be combined with `--overlay`.",
+			"`--log` fails on zero or negative values; plot levels, not changes, on a log axis.",
+			"`chart heatmap` only accepts monthly or quarterly data; resample daily or weekly series first. Shades are relative to the series' own min and max.",
 		},
 		[]string{"obs", "transform", "window", "analyze"},
 	)
@@ -499,6 +592,7 @@ func buildCompletionGuide() map[string]any {
 		[]string{"shell completion script text"},
 		[]string{
 			"When you want shell tab-completion for reserve subcommands and flags.",
+			"When you want series IDs you have already fetched to complete on <TAB>.",
 		},
 		[]string{
 			"When you want command help or onboarding content; use `help` or `onboard` instead.",
@@ -513,7 +607,7 @@ func buildCompletionGuide() map[string]any {
 		},
 		[]string{
 			"Completion output is shell script text, not JSON.",
-			"This command configures the shell environment only; it does not interact with FRED or the local cache.",
+			"Generating the script does not contact FRED; series-ID candidates are read from the local store, read-only, each time you press <TAB>.",
 		},
 		[]string{"config", "version", "onboard"},
 	)
@@ -529,23 +623,30 @@ func buildConfigGuide() map[string]any {
 		map[string]any{
 			"init":        "reserve config init",
 			"get":         "reserve config get [--show-secrets]",
-			"set":         "reserve config set <key> <value>",
+			"set":         "reserve config set <key> <value> [--use-keychain]",
 			"grant":       "reserve config grant <SERIES_ID>",
 			"revoke":      "reserve config revoke <SERIES_ID>",
 			"list-grants": "reserve config list-grants",
+			"migrate":     "reserve config migrate",
+			"profile":     "reserve config profile add <name> [--api-key K] [--rate N] ... | reserve config profile list",
+			"doctor":      "reserve config doctor [--no-ping]",
 		},
 		map[string]any{
 			"init":        "no command-specific flags",
 			"get":         "--show-secrets",
-			"set":         "key must be one of api_key|default_format|timeout|concurrency|rate|base_url|db_path|person_org_type|block_unknown_rights|block_ambiguous_rights|block_preapproval_required_in_commercial|require_citation_on_display|require_citation_on_export|allow_override_with_permission_record|rights_refresh_days.default|rights_refresh_days.export|rights_refresh_days.publish|log_compliance_decisions",
+			"set":         "key must be one of api_key|default_format|timeout|concurrency|rate|base_url|db_path|person_org_type|block_unknown_rights|block_ambiguous_rights|block_preapproval_required_in_commercial|require_citation_on_display|require_citation_on_export|allow_override_with_permission_record|rights_refresh_days.default|rights_refresh_days.export|rights_refresh_days.publish|log_compliance_decisions|observation_timezone|profile|proxy_url|email.smtp_addr|email.from|email.to|email.username; --use-keychain (api_key only) stores the key in the OS keychain and writes \"keychain\" to config.json",
 			"grant":       "series ID only; the user must already have proper permission",
 			"revoke":      "series ID only",
 			"list-grants": "no command-specific flags",
+			"migrate":     "no command-specific flags; keeps the original as config.json.bak",
+			"doctor":      "--no-ping skips the live FRED request; exits non-zero when any check fails",
+			"profile":     "add takes the global --api-key, --timeout, --concurrency, --rate, --proxy plus --base-url, --db-path, --default-format; list marks the active profile with *",
 		},
 		[]string{"config template", "effective config view", "confirmation text"},
 		[]string{
 			"When setting up reserve on a new machine or environment.",
 			"When you need to inspect which config value is taking effect.",
+			"When a command reports a missing API key or cannot reach FRED; run `config doctor` first.",
 			"When the human user needs to manually record a legitimate per-series permission override they already possess.",
 		},
 		[]string{
@@ -556,19 +657,29 @@ func buildConfigGuide() map[string]any {
 		[]string{
 			"Initialize the user `config.json` for a new install.",
 			"Change the default DB path or inspect active settings.",
+			"Switch between personal, team, and CI API keys with a named profile.",
 			"Manually record or remove a legitimate series permission override.",
+			"Bring an older config.json up to the current layout after upgrading reserve.",
 		},
 		[]string{
 			"reserve config init",
 			"reserve config get",
+			"reserve config doctor",
 			"reserve config set db_path ~/.reserve/reserve.db",
+			"reserve --profile prod config get",
+			"reserve config profile add work --api-key WORK_KEY --rate 10",
+			"reserve config set api_key YOUR_KEY --use-keychain",
 			"reserve config grant BAMLC0A0CM",
 			"reserve config revoke BAMLC0A0CM",
 			"reserve config list-grants",
+			"reserve config migrate",
 		},
 		[]string{
-			"API key precedence is: `--api-key`, then `FRED_API_KEY`, then local `./config.json`, then the user config file.",
-			"DB path precedence is: `RESERVE_DB_PATH`, then local `./config.json`, then the user config file, then the default `~/.reserve/reserve.db`.",
+			"API key precedence is: `--api-key`, then `FRED_API_KEY`, then the selected profile's `config.<name>.json`, then its `profiles` entry in config.json, then local `./config.json`, then the user config file.",
+			"DB path precedence is: `RESERVE_DB_PATH`, then the selected profile's `config.<name>.json`, then its `profiles` entry, then local `./config.json`, then the user config file, then the default `~/.reserve/reserve.db`.",
+			"`RESERVE_FORMAT`, `RESERVE_TIMEOUT`, `RESERVE_CONCURRENCY`, `RESERVE_RATE`, and `RESERVE_BASE_URL` override the config files and are overridden by `--format`, `--timeout`, `--concurrency`, and `--rate`; an invalid value prints a warning and is ignored.",
+			"Proxy precedence is: `--proxy`, then `RESERVE_PROXY_URL`, then `proxy_url` in the profile, local, and user config files; an invalid proxy URL fails at startup.",
+			"`--profile <name>` (then `RESERVE_PROFILE`, then the `profile` key in config.json) overlays the `profiles.<name>` entry of config.json and then `config.<name>.json` from beside config.json; the top-level keys are the `default` profile. An undefined profile silently leaves the base config in effect, so check `config get` for `profile` and `profile_file`.",
 			"AI should not generate, execute, or recommend `config grant` unless the user explicitly says they already have proper permission. In those cases, instruct the user to run the command manually.",
 		},
 		[]string{"obs", "fetch", "cache"},
@@ -583,12 +694,12 @@ func buildFetchGuide() map[string]any {
 		"Top-level retrieval command, not a JSONL pipeline operator.",
 		"Talks to the live FRED API. Writes result envelopes or cache-side effects depending on the verb and flags. Batch fetch operations use bounded concurrency and a shared rate limiter.",
 		map[string]any{
-			"series":   "reserve fetch series <SERIES_ID...> [--store]",
+			"series":   "reserve fetch series <SERIES_ID...> [--store [--max-age 7d]] [--progress]",
 			"category": "reserve fetch category <CATEGORY_ID|root>",
 			"query":    "reserve fetch query <search-query> [--limit N]",
 		},
 		map[string]any{
-			"series":   "--store",
+			"series":   "--store; --max-age AGE skips series whose stored copy is fresher than AGE; --progress draws a stderr progress bar on a terminal",
 			"category": "no command-specific flags",
 			"query":    "--limit N",
 		},
@@ -613,12 +724,53 @@ func buildFetchGuide() map[string]any {
 		[]string{
 			"`fetch` is about accumulating local data; use `obs get` for immediate live observations without persistence.",
 			"`fetch series --store` is the handoff into `obs get --from cache` and other local-cache workflows.",
+			"Re-running `fetch series --store` revalidates stored series with FRED (ETag / Last-Modified) and keeps the stored copy when FRED reports it unchanged; add `--no-cache` to force a full download.",
+			"`fetch series --store --start DATE` (no `--end`) on a series whose full history is stored appends the fetched window to that copy rather than storing a separate set.",
 			"For agentic use, prefer one multi-series `fetch series` call over many single-series fetches. reserve already provides bounded concurrency and a shared rate limiter for the batch.",
 		},
 		[]string{"obs", "cache", "search", "series"},
 	)
 }
 
+func buildGeoGuide() map[string]any {
+	return makeGuide(
+		"Show a GeoFRED series group's values across regions on one date.",
+		"`geo` reads GeoFRED, FRED's regional data service, where a series group ties together the same measure for every state, county, or metro area.",
+		"Use it when you want a cross-section (all regions, one date) rather than a time series for one region.",
+		"Discovery command, not a JSONL pipeline stage.",
+		"Returns one row per region with its name, code, and value, sorted by value unless --sort says otherwise.",
+		map[string]any{
+			"series": "reserve geo series <GROUP> --date YYYY-MM-DD [--region-type state] [--units U]",
+		},
+		map[string]any{
+			"series": "--date YYYY-MM-DD (required), --region-type state|county|msa|..., --units U, --season NSA|SA|..., --freq a|q|m|..., --sort value|region|code",
+		},
+		[]string{"regional cross-section"},
+		[]string{
+			"When you want to compare states or counties on the same measure and date.",
+			"When you need a ranked table of regions, such as income by state.",
+		},
+		[]string{
+			"When you need a single region's history; fetch its own series ID with `obs get`.",
+			"When you need JSONL observation rows for `transform` or `analyze`.",
+		},
+		[]string{
+			"Rank states by per capita personal income for a given year.",
+			"Export a county-level cross-section as CSV or JSON.",
+		},
+		[]string{
+			"reserve geo series 882 --region-type state --date 2023-01-01 --units Dollars",
+			"reserve geo series 882 --region-type state --date 2023-01-01 --units Dollars --sort region",
+		},
+		[]string{
+			"GeoFRED requires the group's units (e.g. `Dollars`); they are shown on the series' GeoFRED map.",
+			"Series group IDs are GeoFRED identifiers, not FRED series IDs.",
+			"Regions reported without a numeric value are left out of the output.",
+		},
+		[]string{"obs", "series", "search"},
+	)
+}
+
 func buildOnboardSelfGuide() map[string]any {
 	return makeGuide(
 		"Emit machine-readable onboarding JSON for reserve itself.",
@@ -716,16 +868,20 @@ func buildObsGuide() map[string]any {
 	return makeGuide(
 		"Fetch observation data from live FRED or from the local cache through one canonical command family.",
 		"`obs` is the canonical observation retrieval command family for both live API reads and local cached reads.",
-		"Use `obs get` for observation ranges, optionally selecting origin with `--from`, and `obs latest` for the most recent live point per series. `obs get` accepts multiple series IDs and fetches them concurrently under one bounded, rate-limited batch request path.",
+		"Use `obs get` for observation ranges, optionally selecting origin with `--from`, `obs latest` for the most recent live point per series, `obs revisions` for the publication history of one data point or the values revised between two vintages, and `obs watch` to print the latest point of a series each time it changes. `obs get` accepts multiple series IDs and fetches them concurrently under one bounded, rate-limited batch request path.",
 		"Source command: emits observations that often feed downstream pipelines.",
-		"`obs get` can emit table, JSON, JSONL, CSV, TSV, or Markdown. `--from live` is the default; `--from cache` reads from the local embedded key-value cache (bbolt). If multiple cached observation sets exist and no exact parameters are provided, reserve chooses a canonical local set and warns. When piping, explicitly use `--format jsonl`.",
+		"`obs get` can emit table, JSON, JSONL, CSV, TSV, or Markdown, or with `--out` an Excel workbook (`--format xlsx`, one sheet per series plus a Metadata sheet) or a Parquet file (`--format parquet`, columns series_id, date, value, value_raw; NaN values are null). `--from live` is the default; `--from cache` reads from the local embedded key-value cache (bbolt). If multiple cached observation sets exist and no exact parameters are provided, reserve chooses a canonical local set and warns. With `--from cache`, `--start`/`--end` that match no cached key are cut from the full cached history instead. When piping, explicitly use `--format jsonl`.",
 		map[string]any{
-			"get":    "reserve obs get <SERIES_ID...> [--from live|cache] [--start YYYY-MM-DD] [--end YYYY-MM-DD] [--freq M|Q|A] [--units ...] [--agg avg|sum|eop] [--limit N]",
-			"latest": "reserve obs latest <SERIES_ID...>",
+			"get":       "reserve obs get <SERIES_ID...> [--from live|cache] [--series-group GLOB] [--with-delta] [--gzip] [--max-age 24h] [--clamp-to-observed-range REF_ID] [--as-returns arithmetic|log] [--start YYYY-MM-DD | --relative-dates ytd|3m|1y] [--end YYYY-MM-DD] [--freq M|Q|A] [--units ...] [--agg avg|sum|eop] [--limit N] [--realtime-start YYYY-MM-DD] [--realtime-end YYYY-MM-DD]",
+			"latest":    "reserve obs latest <SERIES_ID...>",
+			"revisions": "reserve obs revisions <SERIES_ID> --date YYYY-MM-DD | --between YYYY-MM-DD YYYY-MM-DD",
+			"watch":     "reserve obs watch <SERIES_ID> [--interval 5m] [--once]",
 		},
 		map[string]any{
-			"get":    "--from --start --end --freq --units --agg --limit",
-			"latest": "no command-specific flags",
+			"get":       "--from --series-group --with-delta --gzip --max-age --clamp-to-observed-range --as-returns --start --relative-dates --end --freq --units --agg --limit --realtime-start --realtime-end (vintage: data as published during that window)",
+			"latest":    "no command-specific flags",
+			"revisions": "--date YYYY-MM-DD (the observation date whose vintages to list) or --between A B (two vintage dates to compare); one is required",
+			"watch":     "--interval DURATION (time between polls, at least 1m; default 5m) --once (poll a single time and exit)",
 		},
 		[]string{"observation result envelope", "JSONL observation rows when `--format jsonl`"},
 		[]string{
@@ -741,17 +897,25 @@ func buildObsGuide() map[string]any {
 			"Fetch a date-bounded observation range.",
 			"Fetch several indicators together for one comparative analysis window.",
 			"Get the latest reading for one or more known series IDs.",
+			"Reproduce a historical forecast with the data as it was published at the time.",
 		},
 		[]string{
 			"reserve obs get CPIAUCSL --start 2020-01-01 --format jsonl",
 			"reserve obs get FEDFUNDS DRCCLACBS T10Y2Y UNRATE --start 2008-01-01 --end 2008-12-31 --format jsonl | reserve analyze summary --by-series",
 			"reserve obs latest FEDFUNDS UNRATE",
+			"reserve obs get UNRATE --realtime-start 2020-01-01 --realtime-end 2020-12-31 --format jsonl",
+			"reserve obs revisions UNRATE --date 2020-04-01",
+			"reserve obs revisions UNRATE --between 2020-05-08 2021-05-08",
+			"reserve obs watch FEDFUNDS --interval 5m --format jsonl",
+			"reserve obs get GDP CPIAUCSL --format xlsx --out data.xlsx",
+			"reserve obs get GDP --format parquet --out gdp.parquet",
 		},
 		[]string{
 			"`obs get` defaults to table format even when piped. Always add `--format jsonl` before `| reserve transform ...`.",
 			"If you fetch multiple series at once and pipe them, use downstream commands that understand the grouping you need. `reserve analyze summary --by-series` is the direct per-series summary path.",
 			"For agentic use, prefer one multi-series `obs get` call over many one-series calls when the date range and options are the same.",
-			"If multiple cached observation sets exist for a series, bare `--from cache` chooses one canonical local set and warns. Add explicit date parameters when you need a precise cached variant.",
+			"If multiple cached observation sets exist for a series, bare `--from cache` chooses one canonical local set and warns. Add explicit date parameters when you need a precise cached variant. Vintage (`--realtime-*`) sets are keyed apart from current data.",
+			"`--max-age AGE` on a live `obs get` serves the stored copy of the same request instead of fetching when it is newer than AGE; with `--from cache` it only warns when the copy is older.",
 			"For agentic use, prefer live reads for one-off answers, inspect `cache inventory` before storing more local series data, and ask the user before deleting or rebuilding cached series with `cache clear --series`.",
 		},
 		[]string{"transform", "window", "analyze", "chart", "fetch", "cache"},
@@ -766,16 +930,18 @@ func buildReleaseGuide() map[string]any {
 		"Discovery command, not a JSONL pipeline stage.",
 		"Returns release metadata, release dates, or series metadata linked to the selected release.",
 		map[string]any{
-			"list":   "reserve release list [--limit N]",
-			"get":    "reserve release get <RELEASE_ID>",
-			"dates":  "reserve release dates <RELEASE_ID> [--limit N]",
-			"series": "reserve release series <RELEASE_ID> [--limit N]",
+			"list":     "reserve release list [--limit N]",
+			"get":      "reserve release get <RELEASE_ID>",
+			"dates":    "reserve release dates <RELEASE_ID> [--limit N]",
+			"calendar": "reserve release calendar [--release N] [--days 30] [--limit N]",
+			"series":   "reserve release series <RELEASE_ID> [--limit N]",
 		},
 		map[string]any{
-			"list":   "--limit N",
-			"get":    "no command-specific flags",
-			"dates":  "--limit N",
-			"series": "--limit N",
+			"list":     "--limit N",
+			"get":      "no command-specific flags",
+			"dates":    "--limit N",
+			"calendar": "--release N, --days N, --limit N",
+			"series":   "--limit N",
 		},
 		[]string{"release metadata", "release dates", "series metadata"},
 		[]string{
@@ -789,14 +955,17 @@ func buildReleaseGuide() map[string]any {
 		[]string{
 			"List all releases and inspect one by ID.",
 			"Find the series associated with a named release.",
+			"See which releases are due in the coming week with `release calendar --days 7`.",
 		},
 		[]string{
 			"reserve release list --limit 20",
+			"reserve release calendar --days 7",
 			"reserve release series 10 --limit 20",
 		},
 		[]string{
 			"`release` helps locate relevant series, but it does not fetch observation data itself.",
 			"Release IDs are numeric and distinct from source IDs or category IDs.",
+			"Every `release` verb accepts `--gzip` to compress `--format jsonl` output.",
 		},
 		[]string{"source", "series", "search", "meta"},
 	)
@@ -835,6 +1004,7 @@ func buildSearchGuide() map[string]any {
 		[]string{
 			"`search` is discovery only. Use the resulting series IDs with `series`, `obs`, or `fetch` commands.",
 			"Search results are metadata-oriented and not directly pipeline-ready observation data.",
+			"`--gzip` compresses `--format jsonl` output.",
 		},
 		[]string{"series", "obs", "fetch", "tag", "category"},
 	)
@@ -880,6 +1050,7 @@ func buildSeriesGuide() map[string]any {
 		[]string{
 			"`series` is metadata-oriented. Use `obs get` for observation values.",
 			"The currently supported verbs are `get`, `search`, `tags`, and `categories`.",
+			"Every `series` verb accepts `--gzip` to compress `--format jsonl` output.",
 		},
 		[]string{"search", "obs", "fetch", "tag", "category", "meta"},
 	)
@@ -922,6 +1093,7 @@ func buildSourceGuide() map[string]any {
 		[]string{
 			"Source IDs are numeric and distinct from release IDs and category IDs.",
 			"`source` helps you find releases and provenance; it does not fetch observations directly.",
+			"Every `source` verb accepts `--gzip` to compress `--format jsonl` output.",
 		},
 		[]string{"release", "series", "meta", "search"},
 	)
@@ -964,6 +1136,7 @@ func buildTagGuide() map[string]any {
 		[]string{
 			"`tag series` returns series metadata, not observations.",
 			"`--all` on `tag series` changes the semantics from matching any tag to matching every provided tag.",
+			"Every `tag` verb accepts `--gzip` to compress `--format jsonl` output.",
 		},
 		[]string{"search", "series", "category", "meta"},
 	)
@@ -975,24 +1148,30 @@ func buildTransformGuide() map[string]any {
 		"`transform` is the core pipeline workhorse for reshaping, filtering, and deriving series values.",
 		"Use it between a source command (`obs get`) and a terminal command (`analyze` or `chart`).",
 		"Mid-pipeline stage: JSONL in, JSONL out.",
-		"Reads one JSONL observation stream from stdin and writes transformed JSONL to stdout unless output is a terminal table.",
+		"Reads one JSONL observation stream from stdin and writes transformed JSONL to stdout unless output is a terminal table. `--format csv` writes `date,value,value_raw` rows at full precision, with missing values as empty cells, for spreadsheets.",
 		map[string]any{
-			"pct-change": "reserve transform pct-change [--period N]",
-			"diff":       "reserve transform diff [--order 1|2]",
-			"log":        "reserve transform log",
-			"index":      "reserve transform index --base 100 --at YYYY-MM-DD",
-			"normalize":  "reserve transform normalize [--method zscore|minmax]",
-			"resample":   "reserve transform resample --freq monthly|quarterly|annual --method mean|last|sum",
-			"filter":     "reserve transform filter [--after YYYY-MM-DD] [--before YYYY-MM-DD] [--min N] [--max N] [--drop-missing]",
+			"pct-change":    "reserve transform pct-change [--period N] [--method standard|log] [--keep-length]",
+			"annualize":     "reserve transform annualize [--periods N]",
+			"diff":          "reserve transform diff [--order 1|2]",
+			"seasonal-diff": "reserve transform seasonal-diff [--lag N]",
+			"log":           "reserve transform log",
+			"index":         "reserve transform index --base 100 --at YYYY-MM-DD",
+			"rebase":        "reserve transform rebase [--to first|last|max|min|mean]",
+			"normalize":     "reserve transform normalize [--method zscore|minmax|robust]",
+			"resample":      "reserve transform resample --freq daily|weekly|monthly|quarterly|annual --method mean|first|last|sum|ffill|linear [--fill-missing]",
+			"filter":        "reserve transform filter [--start|--after YYYY-MM-DD] [--end|--before YYYY-MM-DD] [--min N] [--max N] [--drop-missing]",
 		},
 		map[string]any{
-			"pct-change": "--period N",
-			"diff":       "--order 1|2",
-			"log":        "no command-specific flags",
-			"index":      "--base 100 --at YYYY-MM-DD",
-			"normalize":  "--method zscore|minmax",
-			"resample":   "--freq monthly|quarterly|annual --method mean|last|sum",
-			"filter":     "--after --before --min --max --drop-missing",
+			"pct-change":    "--period N, --method standard|log, --keep-length",
+			"annualize":     "--periods N (default: detected from date spacing)",
+			"diff":          "--order 1|2",
+			"seasonal-diff": "--lag N (default 12)",
+			"log":           "no command-specific flags",
+			"index":         "--base 100 --at YYYY-MM-DD",
+			"rebase":        "--to first|last|max|min|mean (default first)",
+			"normalize":     "--method zscore|minmax|robust (median/IQR, outlier-resistant)",
+			"resample":      "--freq daily|weekly|monthly|quarterly|annual --method mean|first|last|sum (downsample) or ffill|linear (upsample), --fill-missing",
+			"filter":        "--start --end (inclusive) or --after --before (exclusive), --min --max --drop-missing",
 		},
 		[]string{"JSONL observation rows", "table preview when output is a terminal"},
 		[]string{
@@ -1005,7 +1184,10 @@ func buildTransformGuide() map[string]any {
 		},
 		[]string{
 			"Convert raw levels to percent change or differences.",
+			"Remove additive seasonality with a year-over-year difference (`seasonal-diff --lag 12`).",
+			"Turn month-over-month percent changes into annualized rates (`pct-change | annualize`).",
 			"Filter dates or resample monthly data to annual summaries.",
+			"Express a series as a percent of its peak (`rebase --to max`) without choosing an anchor date.",
 		},
 		[]string{
 			"reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform pct-change --period 12",
@@ -1014,6 +1196,10 @@ func buildTransformGuide() map[string]any {
 		[]string{
 			"`transform` is not where rolling windows live. Use `reserve window roll` for that.",
 			"Transforms auto-detect terminal output and may render a table; for downstream chaining, keep the output in JSONL form.",
+			"`annualize` expects percent rates, not levels: run `pct-change` first. Without `--periods` it fails on irregularly spaced input.",
+			"`filter --after`/`--before` exclude the boundary date; use `--start`/`--end` to keep it.",
+			"`pct-change --method log` returns log differences as fractions, not percents: 0.01 is roughly 1%.",
+			"`pct-change` drops its first `--period` rows; pass `--keep-length` to get NaN rows instead when chaining it or lining it up with `window roll` output.",
 		},
 		[]string{"obs", "window", "analyze", "chart"},
 	)
@@ -1095,22 +1281,306 @@ func buildUpdateGuide() map[string]any {
 	)
 }
 
+func buildReportGuide() map[string]any {
+	return makeGuide(
+		"Write a stakeholder-ready HTML or Markdown report covering several series.",
+		"`report` loads each series (cache first, live otherwise) and writes one document with a summary statistics table, a linear trend table, a chart per series, and the time it was generated.",
+		"Use it when the audience wants a finished document rather than JSONL or terminal tables.",
+		"Terminal command outside the JSONL pipeline: it fetches its own data and writes a document to stdout or `--out`.",
+		"HTML (the default) is a standalone page with inline SVG charts. Markdown has pipe tables and text charts in fenced code blocks.",
+		map[string]any{
+			"report": "reserve report --series <SERIES_ID>[,<SERIES_ID>...] [<SERIES_ID>...] [--start YYYY-MM-DD] [--end YYYY-MM-DD] [--title TEXT] [--format html|md] [--out FILE]",
+		},
+		map[string]any{
+			"report": "--series (comma-separated or repeated; IDs may also be arguments) --start --end --title --format html|md (default: html, or md when --out ends in .md)",
+		},
+		[]string{"HTML document", "Markdown document"},
+		[]string{
+			"When a recurring summary of a handful of indicators goes to people who do not run reserve.",
+			"When you want charts and tables for several series in one file.",
+		},
+		[]string{
+			"When another tool will consume the numbers; use `obs get --format jsonl` with `analyze`.",
+			"When you need a reproducible script rather than a finished document; use `export notebook`.",
+		},
+		[]string{
+			"Report unemployment, the policy rate, and CPI since 2020 as a web page.",
+			"Produce a Markdown summary to paste into a wiki.",
+		},
+		[]string{
+			"reserve report --series UNRATE FEDFUNDS CPIAUCSL --start 2020-01-01 --out report.html",
+			"reserve report --series GDP,PAYEMS --out report.md",
+		},
+		[]string{
+			"Trends are ordinary least-squares lines; the slope is in series units per year.",
+			"Titles, units, and citations come from cached series metadata, which compliance checks store on first use.",
+			"A series is read from the cache only when its full history is stored (`fetch series --store`).",
+		},
+		[]string{"analyze", "chart", "export"},
+	)
+}
+
+func buildExportGuide() map[string]any {
+	return makeGuide(
+		"Generate a runnable analysis script that reproduces a reserve exploration.",
+		"`export` turns ad-hoc terminal work into artifacts that can be shared and re-run.",
+		"Use `export notebook` to emit a shell or Python script that fetches a series and runs the standard summary, trend, and chart pipeline, with the series and date range as parameters at the top.",
+		"Not part of the JSONL pipeline model; writes a script that itself runs reserve pipelines.",
+		"Writes the script to stdout or to the global `--out` path, which is marked executable. Every generated invocation is validated against the reserve command tree before the script is written.",
+		map[string]any{
+			"notebook": "reserve export notebook <SERIES_ID> [--start YYYY-MM-DD] [--end YYYY-MM-DD] [--lang sh|py]",
+		},
+		map[string]any{
+			"notebook": "--start --end (script defaults for START/END) --lang sh|py (default: from --out extension, else sh)",
+		},
+		[]string{"POSIX shell script", "Python script using subprocess"},
+		[]string{
+			"When you want to hand someone a reproducible version of an analysis.",
+			"When you want a starting script to extend rather than retyping pipelines.",
+		},
+		[]string{
+			"When you want data files rather than a script; use `obs get --out` or `cache export`.",
+		},
+		[]string{
+			"Write a shell notebook for one series.",
+			"Write a Python notebook with a fixed start date.",
+		},
+		[]string{
+			"reserve export notebook UNRATE --out analysis.sh",
+			"reserve export notebook CPIAUCSL --start 2015-01-01 --out analysis.py",
+		},
+		[]string{
+			"The generated script calls live `obs get`, so running it needs an API key.",
+			"The script fetches once into `<SERIES>.jsonl` in the working directory and reuses that file for every step.",
+		},
+		[]string{"obs", "analyze", "chart", "snippet"},
+	)
+}
+
+func buildCompareGuide() map[string]any {
+	return makeGuide(
+		"Fetch two series and show them side by side with each one's percent change from the previous row.",
+		"`compare` is a quick two-series view: it fetches both series itself, joins them on date, and prints one row per shared date.",
+		"Use it to eyeball how two indicators moved together, including series of different frequencies such as monthly UNRATE against daily DFF.",
+		"Source command like `obs get`; with `--format jsonl` it emits wide rows rather than the one-observation-per-line pipeline schema.",
+		"Table output has DATE, both series, and both percent-change columns. JSON is an array of wide objects and JSONL one object per line, keyed `date`, `<ID1>`, `<ID2>`, `<ID1>_pct_change`, `<ID2>_pct_change`.",
+		map[string]any{
+			"compare": "reserve compare <SERIES_ID1> <SERIES_ID2> [--start YYYY-MM-DD] [--end YYYY-MM-DD] [--from live|cache] [--align right|inner]",
+		},
+		map[string]any{
+			"compare": "--start --end --from live|cache --align right|inner (default: right)",
+		},
+		[]string{"side-by-side table", "wide JSON/JSONL rows"},
+		[]string{
+			"When you want two series on one screen without building a pipeline.",
+			"When the series have different frequencies and you want them lined up at period end.",
+		},
+		[]string{
+			"When you need correlation, lead-lag, or rolling statistics; use `analyze compare`, `analyze xcorr`, or `analyze roll-corr`.",
+			"When you need the observations as a pipeline stream; use `obs get --format jsonl`.",
+		},
+		[]string{
+			"Compare unemployment against the policy rate since 2020.",
+			"Line up quarterly GDP with the daily 10-year yield at quarter end.",
+		},
+		[]string{
+			"reserve compare UNRATE FEDFUNDS --start 2020-01-01",
+			"reserve compare GDP DGS10 --start 2015-01-01 --format jsonl",
+		},
+		[]string{
+			"`--align right` takes the higher-frequency series' last value within each period of the lower-frequency one; `--align inner` keeps only exact date matches and can return nothing for mixed frequencies.",
+			"The first row's percent change is empty because there is no previous row.",
+			"JSONL rows are wide, so pipe them to tools like jq rather than to `transform` or `analyze`.",
+		},
+		[]string{"obs", "analyze", "chart"},
+	)
+}
+
+func buildAlignGuide() map[string]any {
+	return makeGuide(
+		"Put two series on one date grid and stream both as JSONL.",
+		"`align` loads two series (cache first, live otherwise), resamples both to a common frequency, cuts them to the range they share, and joins their dates.",
+		"Use it ahead of pipeline verbs that pair series by date, such as `analyze xcorr` or `chart plot --multi`, when the inputs come at different frequencies.",
+		"Source command that feeds the pipeline: it writes the standard observation JSONL, one block of rows per series.",
+		"JSONL rows with series_id, date, value, value_raw, and citation_text. Both series report the same dates; a date one side lacks has a null value.",
+		map[string]any{
+			"align": "reserve align <SERIES_ID1> <SERIES_ID2> [--method inner|outer|left] [--freq monthly|quarterly|annual] [--agg-method mean|last|sum]",
+		},
+		map[string]any{
+			"align": "--method inner|outer|left (default: inner) --freq monthly|quarterly|annual (default: the coarser series' frequency) --agg-method mean|last|sum (default: mean)",
+		},
+		[]string{"multi-series JSONL"},
+		[]string{
+			"When a monthly and a quarterly series need matching dates before a pairwise analysis.",
+			"When you want the resample and join steps in one command instead of two `transform resample` runs.",
+		},
+		[]string{
+			"When you want to read the two series side by side; use `compare`.",
+			"When one series is enough; use `obs get` and `transform resample`.",
+		},
+		[]string{
+			"Align quarterly GDP with monthly CPI averaged to quarters.",
+			"Keep every unemployment month and attach the 10-year yield's month-end value.",
+		},
+		[]string{
+			"reserve align GDP CPIAUCSL --method inner --freq quarterly --agg-method mean",
+			"reserve align UNRATE DGS10 --method left --agg-method last | reserve analyze xcorr --with DGS10",
+		},
+		[]string{
+			"Dates are period starts after resampling, so a quarterly row is dated the first day of the quarter.",
+			"Both series are cut to the range where each has values before the join, so `outer` only adds dates missing from one side inside that range.",
+			"A series is read from the cache only when its full history is stored (`fetch series --store`); date-windowed cache entries are not used.",
+		},
+		[]string{"compare", "transform", "analyze"},
+	)
+}
+
+func buildWatchGuide() map[string]any {
+	return makeGuide(
+		"Poll a series and report each newly published observation.",
+		"`watch` is a long-running loop over `obs latest`: it remembers the latest date it has seen and reports only later ones.",
+		"Use it to refresh a dashboard or alert someone when FRED publishes a new data point for a series.",
+		"Long-running source command; it runs until Ctrl-C and is not a JSONL pipeline stage, though `--format jsonl` emits one observation row per new data point.",
+		"Prints a timestamped line per new observation, a JSONL observation row with `--format jsonl`, or sends an email with `--notify email`. A startup line goes to stderr unless `--quiet`.",
+		map[string]any{
+			"watch": "reserve watch <SERIES_ID> [--interval 1h] [--notify stdout|email] [--since YYYY-MM-DD]",
+		},
+		map[string]any{
+			"watch": "--interval duration (default 1h, minimum 1m) --notify stdout|email (default: stdout) --since YYYY-MM-DD",
+		},
+		[]string{"notice lines", "JSONL observation rows", "email messages"},
+		[]string{
+			"When you want to know as soon as a new FEDFUNDS, UNRATE, or CPI value is published.",
+			"When a long-running process should append new observations to a file with `--out`.",
+		},
+		[]string{
+			"When you want the latest value once; use `obs latest`.",
+			"When you want to refresh stored history on a schedule; use `schedule add` with `fetch series --store`.",
+		},
+		[]string{
+			"Print a line whenever FEDFUNDS gets a new observation.",
+			"Email the team when a new unemployment rate is released.",
+		},
+		[]string{
+			"reserve watch FEDFUNDS --interval 1h",
+			"reserve watch UNRATE --interval 6h --notify email",
+		},
+		[]string{
+			"Without `--since`, the newest cached observation is the starting point; with no cache, the first poll only records the current latest date.",
+			"`--since` reports any observation dated after that day, so it can fire on the first poll for data already published.",
+			"`--notify email` needs an `email` section in config.json (`smtp_addr`, `from`, `to`, optional `username`) and reads the password from RESERVE_SMTP_PASSWORD.",
+			"A failed poll is reported on stderr and retried at the next interval; only a request FRED rejects (unknown series, bad API key) stops the watch with an error.",
+		},
+		[]string{"obs", "fetch", "config"},
+	)
+}
+
+func buildScheduleGuide() map[string]any {
+	return makeGuide(
+		"Run reserve commands on cron schedules.",
+		"`schedule` keeps named jobs (a cron expression plus a reserve command line) in the local database, and `schedule run` starts each as a separate reserve process when it comes due.",
+		"Use it for unattended refreshes such as fetching and storing key series every weekday morning, without an external cron setup.",
+		"Long-running ingest command; jobs are usually `fetch ... --store` so later cache reads see fresh data. It is not a JSONL pipeline stage.",
+		"`add` and `delete` print a confirmation; `list` prints a table (or JSON with `--format json`) with each job's next fire time, last run, and status; `run` logs job starts, output, and failures to stderr or `--log-file`.",
+		map[string]any{
+			"add":    "reserve schedule add --name <NAME> --cron \"<EXPR>\" --cmd \"<reserve command>\"",
+			"list":   "reserve schedule list",
+			"delete": "reserve schedule delete <NAME>",
+			"run":    "reserve schedule run [--daemon] [--pid-file PATH] [--log-file PATH]",
+			"stop":   "reserve schedule stop [--pid-file PATH]",
+		},
+		map[string]any{
+			"add":  "--name NAME --cron EXPR --cmd COMMAND (all required)",
+			"run":  "--daemon --pid-file PATH --log-file PATH (defaults: schedule.pid and schedule.log next to the database)",
+			"stop": "--pid-file PATH",
+		},
+		[]string{"confirmation lines", "job tables", "JSON job lists", "scheduler log lines"},
+		[]string{
+			"When a set of series should be fetched and stored on a fixed timetable.",
+			"When a machine without system cron access should keep the local cache current.",
+		},
+		[]string{
+			"When you want to be told as soon as one series publishes; use `watch`.",
+			"When you need a one-off refresh; run `fetch series --store` directly.",
+		},
+		[]string{
+			"Fetch and store CPI and unemployment every weekday at 08:00.",
+			"Refresh a weekly rates cache in the background and check its last status.",
+		},
+		[]string{
+			"reserve schedule add --name daily-macro --cron \"0 8 * * 1-5\" --cmd \"fetch series CPIAUCSL UNRATE --store\"",
+			"reserve schedule run --daemon",
+			"reserve schedule list",
+		},
+		[]string{
+			"Cron expressions use five fields (minute hour day-of-month month day-of-week) in the scheduler machine's local time; `@hourly`, `@daily`, `@weekly`, `@monthly`, and `@yearly` also work.",
+			"Jobs are stored in the database's schedules bucket; `cache export` and `cache import` carry them, and `cache clear --all` does not remove them.",
+			"A job still running when it comes due again is skipped for that firing rather than started twice.",
+			"`schedule`, `watch`, and `obs watch` without `--once` cannot be scheduled because they never finish.",
+			"Only one scheduler runs per PID file; `schedule run` refuses to start while another is alive.",
+		},
+		[]string{"fetch", "watch", "cache"},
+	)
+}
+
+func buildPipelineGuide() map[string]any {
+	return makeGuide(
+		"Peek at the first or last rows of a JSONL observation stream.",
+		"`pipeline` holds stream utilities that pass observation rows through unchanged, like `head` and `tail` for reserve pipelines.",
+		"Use `pipeline head` to sample the start of a stream without reading all of it, and `pipeline tail` to see the most recent rows after a transform.",
+		"Mid-pipeline or terminal stage: JSONL in, JSONL out.",
+		"Reads JSONL observations from stdin and writes the selected rows to stdout verbatim. Blank and `//` comment lines are dropped and do not count toward N.",
+		map[string]any{
+			"head": "reserve pipeline head [--n N]",
+			"tail": "reserve pipeline tail [--n N]",
+		},
+		map[string]any{
+			"head": "--n N (default 10)",
+			"tail": "--n N (default 10)",
+		},
+		[]string{"JSONL observation rows"},
+		[]string{
+			"When you are debugging a pipeline and only need to see a few rows.",
+			"When you want the most recent N observations after a transform.",
+		},
+		[]string{
+			"When you need rows within a date range; use `transform filter` or `obs get --start/--end`.",
+			"When you want a formatted table; the output is always JSONL.",
+		},
+		[]string{
+			"Show the first five rows of a fetched series.",
+			"Keep only the latest ten rows of a transformed stream.",
+		},
+		[]string{
+			"reserve obs get CPIAUCSL --format jsonl | reserve pipeline head --n 5",
+			"reserve obs get UNRATE --from cache --format jsonl | reserve transform diff | reserve pipeline tail --n 10",
+		},
+		[]string{
+			"`head` stops reading stdin after N rows, so the upstream command may exit with a broken pipe.",
+			"Rows with missing values (`value: null`) count toward N like any other row.",
+		},
+		[]string{"obs", "transform", "window", "analyze"},
+	)
+}
+
 func buildWindowGuide() map[string]any {
 	return makeGuide(
 		"Compute rolling-window statistics from a JSONL observation stream.",
 		"`window` is a dedicated pipeline family for rolling calculations and is intentionally separate from `transform`.",
 		"Use it for moving averages, rolling standard deviations, and other windowed statistics over one series stream.",
 		"Mid-pipeline stage: JSONL in, JSONL out.",
-		"Reads JSONL observations from stdin and emits JSONL observations containing the rolling statistic.",
+		"Reads JSONL observations from stdin and emits JSONL observations containing the rolling statistic, or `date,value,value_raw` CSV rows with `--format csv`.",
 		map[string]any{
-			"roll": "reserve window roll --stat mean|std|min|max|sum --window N [--min-periods M]",
+			"roll": "reserve window roll --stat mean|median|std|var|min|max|sum|ewm-mean --window N [--min-periods M] [--ddof 0|1] [--alpha A]",
 		},
 		map[string]any{
-			"roll": "--stat mean|std|min|max|sum --window N --min-periods M",
+			"roll": "--stat mean|median|std|var|min|max|sum|ewm-mean --window N --min-periods M --ddof 0|1 (std and var; default 1 = sample) --alpha A (ewm-mean decay in (0, 1]; default 0.3)",
 		},
 		[]string{"JSONL observation rows", "table preview when output is a terminal"},
 		[]string{
 			"When you need rolling means, rolling volatility, or other windowed metrics.",
+			"When you want to smooth a spiky series robustly with a rolling median (`--stat median`).",
+			"When recent periods should count more than older ones in a moving average (`--stat ewm-mean --alpha A`).",
 			"When you want to smooth a series before trend analysis or charting.",
 		},
 		[]string{
@@ -0,0 +1,62 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/derickschaefer/reserve/internal/pipeline"
+	"github.com/spf13/cobra"
+)
+
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline",
+	Short: "Inspect JSONL observation streams (reads JSONL from stdin)",
+	Long: `Pipeline utilities pass JSONL observation rows through unchanged.
+
+Use them to peek at a stream while building or debugging a pipeline:
+  reserve obs get CPIAUCSL --format jsonl | reserve pipeline head --n 5
+  reserve obs get CPIAUCSL --format jsonl | reserve transform pct-change | reserve pipeline tail --n 10`,
+}
+
+// ─── pipeline head ────────────────────────────────────────────────────────────
+
+var pipelineHeadN int
+
+var pipelineHeadCmd = &cobra.Command{
+	Use:   "head",
+	Short: "Emit the first N rows and stop reading stdin",
+	Example: `  reserve obs get CPIAUCSL --format jsonl | reserve pipeline head --n 5
+  reserve obs get UNRATE --from cache --format jsonl | reserve pipeline head | reserve analyze summary`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pipeline.Head(os.Stdin, cmd.OutOrStdout(), pipelineHeadN)
+	},
+}
+
+// ─── pipeline tail ────────────────────────────────────────────────────────────
+
+var pipelineTailN int
+
+var pipelineTailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Emit the last N rows once stdin closes",
+	Example: `  reserve obs get CPIAUCSL --format jsonl | reserve pipeline tail --n 10
+  reserve obs get GDP --from cache --format jsonl | reserve transform pct-change --period 4 | reserve pipeline tail --n 8`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pipeline.Tail(os.Stdin, cmd.OutOrStdout(), pipelineTailN)
+	},
+}
+
+// ─── Registration ─────────────────────────────────────────────────────────────
+
+func init() {
+	rootCmd.AddCommand(pipelineCmd)
+	pipelineCmd.AddCommand(pipelineHeadCmd)
+	pipelineCmd.AddCommand(pipelineTailCmd)
+
+	pipelineHeadCmd.Flags().IntVar(&pipelineHeadN, "n", 10, "number of rows to emit")
+	pipelineTailCmd.Flags().IntVar(&pipelineTailN, "n", 10, "number of rows to emit")
+}
@@ -5,8 +5,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/derickschaefer/reserve/internal/fred"
 	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/spf13/cobra"
@@ -40,23 +42,19 @@ var releaseListCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		w, closeFn, err := outputWriter(cmd.OutOrStdout())
-		if err != nil {
-			return err
-		}
-		defer closeFn()
-
 		format := resolveFormat(deps.Config.Format)
-		if !deps.Config.Quiet {
-			fmt.Fprintf(w, "Total releases: %d\n\n", len(releases))
-		}
-		if err := render.RenderReleases(w, releases, format); err != nil {
-			return err
-		}
-		if deps.Config.Verbose {
-			fmt.Fprintf(w, "\n[%d items • %dms]\n", len(releases), time.Since(start).Milliseconds())
-		}
-		return nil
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			if !deps.Config.Quiet {
+				fmt.Fprintf(w, "Total releases: %d\n\n", len(releases))
+			}
+			if err := render.RenderReleases(w, releases, format); err != nil {
+				return err
+			}
+			if deps.Config.Verbose {
+				fmt.Fprintf(w, "\n[%d items • %dms]\n", len(releases), time.Since(start).Milliseconds())
+			}
+			return nil
+		})
 	},
 }
 
@@ -84,14 +82,10 @@ var releaseGetCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		w, closeFn, err := outputWriter(cmd.OutOrStdout())
-		if err != nil {
-			return err
-		}
-		defer closeFn()
-
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderReleases(w, []model.Release{*rel}, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderReleases(w, []model.Release{*rel}, format)
+		})
 	},
 }
 
@@ -121,28 +115,98 @@ var releaseDatesCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		format := resolveFormat(deps.Config.Format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			if format == render.FormatTable || format == "" {
+				printSimpleTable(w, []string{"RELEASE ID", "RELEASE NAME", "DATE"}, func(add func(...string)) {
+					for _, d := range dates {
+						add(fmt.Sprintf("%d", d.ReleaseID), d.ReleaseName, d.Date)
+					}
+				})
+				return nil
+			}
+			result := &model.Result{
+				Kind:        model.KindRelease,
+				GeneratedAt: time.Now(),
+				Command:     fmt.Sprintf("release dates %d", id),
+				Data:        dates,
+			}
+			return render.Render(w, result, format)
+		})
+	},
+}
+
+// ─── release calendar ─────────────────────────────────────────────────────────
+
+var (
+	releaseCalendarRelease int
+	releaseCalendarDays    int
+	releaseCalendarLimit   int
+)
+
+var releaseCalendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "List upcoming release dates across all releases",
+	Long: `Lists the release dates FRED has scheduled from today through the next --days
+days, in date order. Without --release every release is included; with it,
+only that release's schedule is shown.`,
+	Example: `  reserve release calendar
+  reserve release calendar --days 7
+  reserve release calendar --release 50 --days 90`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if releaseCalendarDays < 1 {
+			return fmt.Errorf("--days must be >= 1, got %d", releaseCalendarDays)
+		}
+		deps, err := buildDeps()
 		if err != nil {
 			return err
 		}
-		defer closeFn()
-
-		format := resolveFormat(deps.Config.Format)
-		if format == render.FormatTable || format == "" {
-			printSimpleTable(w, []string{"RELEASE ID", "RELEASE NAME", "DATE"}, func(add func(...string)) {
-				for _, d := range dates {
-					add(fmt.Sprintf("%d", d.ReleaseID), d.ReleaseName, d.Date)
-				}
-			})
-			return nil
+		if err := deps.Config.Validate(); err != nil {
+			return err
 		}
-		result := &model.Result{
-			Kind:        model.KindRelease,
-			GeneratedAt: time.Now(),
-			Command:     fmt.Sprintf("release dates %d", id),
-			Data:        dates,
+		today := deps.Config.Now()
+		opts := fred.UpcomingReleaseOptions{
+			Start: today.Format("2006-01-02"),
+			End:   today.AddDate(0, 0, releaseCalendarDays).Format("2006-01-02"),
+			Limit: releaseCalendarLimit,
 		}
-		return render.Render(w, result, format)
+		dates, err := deps.Client.GetReleaseDatesUpcoming(cmd.Context(), releaseCalendarRelease, opts)
+		if err != nil {
+			return err
+		}
+		// release/dates for a single release does not carry the name.
+		if releaseCalendarRelease > 0 && len(dates) > 0 && dates[0].ReleaseName == "" {
+			rel, err := deps.Client.GetRelease(cmd.Context(), releaseCalendarRelease)
+			if err != nil {
+				return err
+			}
+			for i := range dates {
+				dates[i].ReleaseName = rel.Name
+			}
+		}
+		format := resolveFormat(deps.Config.Format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			if format == render.FormatTable || format == "" {
+				if len(dates) == 0 {
+					fmt.Fprintf(w, "No releases scheduled between %s and %s.\n", opts.Start, opts.End)
+					return nil
+				}
+				printSimpleTable(w, []string{"DATE", "RELEASE NAME", "RELEASE ID"}, func(add func(...string)) {
+					for _, d := range dates {
+						add(d.Date, d.ReleaseName, fmt.Sprintf("%d", d.ReleaseID))
+					}
+				})
+				return nil
+			}
+			result := &model.Result{
+				Kind:        model.KindRelease,
+				GeneratedAt: time.Now(),
+				Command:     "release calendar",
+				Data:        dates,
+			}
+			return render.Render(w, result, format)
+		})
 	},
 }
 
@@ -184,7 +248,9 @@ var releaseSeriesCmd = &cobra.Command{
 			},
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderTo(globalFlags.Out, result, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.Render(w, result, format)
+		})
 	},
 }
 
@@ -195,9 +261,16 @@ func init() {
 	releaseCmd.AddCommand(releaseListCmd)
 	releaseCmd.AddCommand(releaseGetCmd)
 	releaseCmd.AddCommand(releaseDatesCmd)
+	releaseCmd.AddCommand(releaseCalendarCmd)
 	releaseCmd.AddCommand(releaseSeriesCmd)
 
 	releaseListCmd.Flags().IntVar(&releaseListLimit, "limit", 0, "max releases (0 = all)")
 	releaseDatesCmd.Flags().IntVar(&releaseDatesLimit, "limit", 20, "max dates to show")
+	releaseCalendarCmd.Flags().IntVar(&releaseCalendarRelease, "release", 0, "only this release ID (0 = all releases)")
+	releaseCalendarCmd.Flags().IntVar(&releaseCalendarDays, "days", 30, "how many days ahead to look")
+	releaseCalendarCmd.Flags().IntVar(&releaseCalendarLimit, "limit", 0, "max dates to return (0 = up to 1000)")
 	releaseSeriesCmd.Flags().IntVar(&releaseSeriesLimit, "limit", 20, "max series to return")
+	for _, c := range []*cobra.Command{releaseListCmd, releaseGetCmd, releaseDatesCmd, releaseCalendarCmd, releaseSeriesCmd} {
+		c.Flags().BoolVar(&outputGzip, "gzip", false, outputGzipUsage)
+	}
 }
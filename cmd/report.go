@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportSeries []string
+	reportStart  string
+	reportEnd    string
+	reportTitle  string
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report --series <SERIES_ID>... [flags]",
+	Short: "Write an HTML or Markdown summary report for several series",
+	Long: `Generates a document for stakeholders: a summary statistics table and a
+linear trend table covering every series, then a chart of each one, stamped
+with the time it was generated.
+
+Series are read from the local cache when their full history is stored there
+and fetched live otherwise. --format html (the default) embeds SVG charts and
+writes a standalone page; --format md writes Markdown with text charts. When
+--format is not given, an --out path ending in .md selects Markdown.
+
+Series IDs can follow --series or be given as arguments.`,
+	Example: `  reserve report --series UNRATE FEDFUNDS CPIAUCSL --start 2020-01-01 --out report.html
+  reserve report --series GDP,PAYEMS --format md --out report.md
+  reserve report UNRATE T10Y2Y --title "Labor and rates" --start 2015-01-01 --end 2024-12-31 --out labor.html`,
+	ValidArgsFunction: completeSeriesIDs(0),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := resolveReportFormat(globalFlags.Format, globalFlags.Out)
+		if err != nil {
+			return err
+		}
+		for flag, v := range map[string]string{"--start": reportStart, "--end": reportEnd} {
+			if v == "" {
+				continue
+			}
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return fmt.Errorf("%s: invalid date %q, expected YYYY-MM-DD", flag, v)
+			}
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		ids := resolveSeriesIDs(deps, normaliseIDs(append(append([]string{}, reportSeries...), args...)))
+		if len(ids) == 0 {
+			return fmt.Errorf("report needs at least one series: use --series <SERIES_ID>")
+		}
+		for _, id := range ids {
+			if _, err := ensureSeriesCompliance(cmd.Context(), deps, id, "display"); err != nil {
+				return err
+			}
+		}
+
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		opts := report.ReportOptions{Start: reportStart, End: reportEnd, Format: format, Title: reportTitle}
+		if err := report.Generate(deps, ids, opts, w); err != nil {
+			_ = closeFn()
+			return err
+		}
+		if err := closeFn(); err != nil {
+			return err
+		}
+		if globalFlags.Out != "" && !deps.Config.Quiet {
+			fmt.Fprintf(cmd.ErrOrStderr(), "✓ Report for %s written to %s\n", strings.Join(ids, ", "), globalFlags.Out)
+		}
+		return nil
+	},
+}
+
+// resolveReportFormat picks html or md from --format, falling back to the
+// --out extension and then html.
+func resolveReportFormat(format, out string) (string, error) {
+	switch format {
+	case report.FormatHTML, report.FormatMarkdown:
+		return format, nil
+	case "":
+		if ext := strings.ToLower(filepath.Ext(out)); ext == ".md" || ext == ".markdown" {
+			return report.FormatMarkdown, nil
+		}
+		return report.FormatHTML, nil
+	}
+	return "", fmt.Errorf("report supports --format html or md, got %q", format)
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.Flags().StringSliceVar(&reportSeries, "series", nil, "series IDs to include (comma-separated or repeated)")
+	reportCmd.Flags().StringVar(&reportStart, "start", "", "start date YYYY-MM-DD")
+	reportCmd.Flags().StringVar(&reportEnd, "end", "", "end date YYYY-MM-DD")
+	reportCmd.Flags().StringVar(&reportTitle, "title", "", `report heading (default "Series report")`)
+	_ = reportCmd.RegisterFlagCompletionFunc("series", completeSeriesIDFlag)
+}
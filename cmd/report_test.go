@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/derickschaefer/reserve/internal/report"
+)
+
+func TestResolveReportFormat(t *testing.T) {
+	cases := []struct {
+		format, out, want string
+	}{
+		{"", "", report.FormatHTML},
+		{"", "summary.html", report.FormatHTML},
+		{"", "summary.MD", report.FormatMarkdown},
+		{"md", "summary.html", report.FormatMarkdown},
+		{"html", "", report.FormatHTML},
+	}
+	for _, tc := range cases {
+		got, err := resolveReportFormat(tc.format, tc.out)
+		if err != nil || got != tc.want {
+			t.Errorf("resolveReportFormat(%q, %q) = %q, %v; want %q", tc.format, tc.out, got, err, tc.want)
+		}
+	}
+	if _, err := resolveReportFormat("csv", ""); err == nil {
+		t.Error("expected an error for --format csv")
+	}
+}
+
+func TestReportAcceptsHTMLFormat(t *testing.T) {
+	if !acceptsCommandFormat(reportCmd, report.FormatHTML) {
+		t.Error("report should accept --format html")
+	}
+	if acceptsCommandFormat(obsGetCmd, report.FormatHTML) {
+		t.Error("obs get should not accept --format html")
+	}
+}
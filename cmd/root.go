@@ -12,25 +12,37 @@ import (
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/app"
+	"github.com/derickschaefer/reserve/internal/chart"
 	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/report"
+	"github.com/derickschaefer/reserve/internal/store"
 	"github.com/spf13/cobra"
 )
 
 // globalFlags holds the parsed values of all persistent (global) flags.
 // Commands read from this struct via the deps they receive.
 var globalFlags struct {
-	APIKey      string
-	Format      string
-	Out         string
-	NoCache     bool
-	Refresh     bool
-	Timeout     string
-	Concurrency int
-	Rate        float64
-	Quiet       bool
-	Verbose     bool
-	Debug       bool
-	AIOnboard   bool
+	APIKey           string
+	Profile          string
+	Format           string
+	Template         string
+	Out              string
+	NoCache          bool
+	Refresh          bool
+	Timeout          string
+	Concurrency      int
+	Rate             float64
+	CircuitThreshold int
+	CircuitTimeout   string
+	Quiet            bool
+	Verbose          bool
+	Debug            bool
+	AIOnboard        bool
+	Color            string
+	ObsTimezone      string
+	Proxy            string
 }
 
 // rootCmd is the base command. Running `reserve` with no subcommand
@@ -167,7 +179,7 @@ func flagConsumesNextArg(arg string) bool {
 		return false
 	}
 	switch arg {
-	case "--api-key", "--format", "--out", "--timeout", "--concurrency", "--rate", "--topic":
+	case "--api-key", "--format", "--out", "--timeout", "--concurrency", "--rate", "--topic", "--color", "--observation-timezone":
 		return true
 	default:
 		return false
@@ -177,7 +189,32 @@ func flagConsumesNextArg(arg string) bool {
 // buildDeps resolves config and constructs the dependency container.
 // Called at the start of each command's RunE.
 func buildDeps() (*app.Deps, error) {
-	cfg, err := config.Load(globalFlags.APIKey)
+	cfg, err := resolveRuntimeConfig()
+	if err != nil {
+		return nil, err
+	}
+	return app.New(cfg), nil
+}
+
+// buildReadOnlyDeps is buildDeps for commands that never write to the store,
+// letting them run alongside other readers of the same database.
+func buildReadOnlyDeps() (*app.Deps, error) {
+	cfg, err := resolveRuntimeConfig()
+	if err != nil {
+		return nil, err
+	}
+	return app.NewReadOnly(cfg), nil
+}
+
+// loadConfig loads config.json and the --profile overlay, with --api-key on
+// top; global flags other than those two are not applied.
+func loadConfig() (*config.Config, error) {
+	return config.LoadProfile(globalFlags.APIKey, globalFlags.Profile)
+}
+
+// resolveRuntimeConfig loads config.json and applies global flag overrides.
+func resolveRuntimeConfig() (*config.Config, error) {
+	cfg, err := loadConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -188,6 +225,11 @@ func buildDeps() (*app.Deps, error) {
 	cfg.Quiet = globalFlags.Quiet
 	cfg.Verbose = globalFlags.Verbose
 	cfg.Debug = globalFlags.Debug
+	if !cfg.Quiet {
+		for _, w := range cfg.Warnings {
+			fmt.Fprintf(os.Stderr, "⚠  %s\n", w)
+		}
+	}
 
 	if err := validateGlobalFlagOverrides(nil, nil); err != nil {
 		return nil, err
@@ -206,14 +248,42 @@ func buildDeps() (*app.Deps, error) {
 	if globalFlags.Rate > 0 {
 		cfg.Rate = globalFlags.Rate
 	}
-
-	deps := app.New(cfg)
-	return deps, nil
+	if globalFlags.CircuitThreshold > 0 {
+		cfg.CircuitBreakThreshold = globalFlags.CircuitThreshold
+	}
+	if globalFlags.CircuitTimeout != "" {
+		d, _ := parseCircuitBreakTimeout()
+		cfg.CircuitBreakTimeout = d
+	}
+	if globalFlags.ObsTimezone != "" {
+		cfg.ObservationTimezone = globalFlags.ObsTimezone
+	}
+	if globalFlags.Proxy != "" {
+		cfg.ProxyURL = strings.TrimSpace(globalFlags.Proxy)
+	}
+	return cfg, nil
 }
 
-func validateGlobalFlagOverrides(_ *cobra.Command, _ []string) error {
-	if globalFlags.Format != "" && !config.IsValidFormat(globalFlags.Format) {
-		return fmt.Errorf("--format must be one of table, json, jsonl, csv, tsv, md")
+// formatTemplateAlias is accepted for --format tmpl.
+const formatTemplateAlias = "template"
+
+func validateGlobalFlagOverrides(cmd *cobra.Command, _ []string) error {
+	if globalFlags.Format == formatTemplateAlias && globalFlags.Template != "" {
+		globalFlags.Format = render.FormatTemplate
+	}
+	if globalFlags.Template != "" {
+		if globalFlags.Format != "" && globalFlags.Format != render.FormatTemplate {
+			return fmt.Errorf("--template cannot be combined with --format %s", globalFlags.Format)
+		}
+		tmpl, err := render.ParseTemplate(globalFlags.Template)
+		if err != nil {
+			return fmt.Errorf("--template: %w", err)
+		}
+		render.SetTemplate(tmpl)
+	} else if globalFlags.Format == render.FormatTemplate || globalFlags.Format == formatTemplateAlias {
+		return fmt.Errorf("--format %s requires --template, e.g. --template '{{.Date}} {{.Value}}' or --template @file.tmpl", globalFlags.Format)
+	} else if globalFlags.Format != "" && !config.IsValidFormat(globalFlags.Format) && !acceptsCommandFormat(cmd, globalFlags.Format) {
+		return fmt.Errorf("--format must be one of table, json, jsonl, csv, tsv, md, yaml (or svg for chart plot and bar, xlsx or parquet for obs get)")
 	}
 	if globalFlags.Timeout != "" {
 		if _, err := parseGlobalTimeout(); err != nil {
@@ -226,9 +296,58 @@ func validateGlobalFlagOverrides(_ *cobra.Command, _ []string) error {
 	if rootCmd.PersistentFlags().Changed("rate") && globalFlags.Rate <= 0 {
 		return fmt.Errorf("--rate must be > 0")
 	}
+	if rootCmd.PersistentFlags().Changed("circuit-break-threshold") && globalFlags.CircuitThreshold <= 0 {
+		return fmt.Errorf("--circuit-break-threshold must be > 0")
+	}
+	if globalFlags.CircuitTimeout != "" {
+		if _, err := parseCircuitBreakTimeout(); err != nil {
+			return err
+		}
+	}
+	if globalFlags.ObsTimezone != "" {
+		if _, err := time.LoadLocation(globalFlags.ObsTimezone); err != nil {
+			return fmt.Errorf("--observation-timezone: unknown time zone %q", globalFlags.ObsTimezone)
+		}
+	}
+	if globalFlags.Proxy != "" {
+		if _, err := fred.ParseProxyURL(globalFlags.Proxy); err != nil {
+			return fmt.Errorf("--proxy: %w", err)
+		}
+	}
+	switch globalFlags.Color {
+	case "", chart.ColorAuto, chart.ColorAlways, chart.ColorNever, chart.ColorOn, chart.ColorOff:
+	default:
+		return fmt.Errorf("--color must be one of auto, always, never (or on, off)")
+	}
 	return nil
 }
 
+// acceptsCommandFormat reports whether format is an output format specific to
+// cmd rather than a general result format. A nil cmd (validation from inside
+// buildDeps) defers to the command's own pre-run check.
+func acceptsCommandFormat(cmd *cobra.Command, format string) bool {
+	var paths []string
+	switch format {
+	case chartFormatSVG:
+		paths = []string{"reserve chart plot", "reserve chart bar"}
+	case render.FormatXLSX, render.FormatParquet:
+		paths = []string{"reserve obs get"}
+	case report.FormatHTML:
+		paths = []string{"reserve report"}
+	default:
+		return false
+	}
+	if cmd == nil {
+		return true
+	}
+	for _, p := range paths {
+		if cmd.CommandPath() == p {
+			return true
+		}
+	}
+	return false
+}
+
 func parseGlobalTimeout() (time.Duration, error) {
 	d, err := time.ParseDuration(globalFlags.Timeout)
 	if err != nil {
@@ -240,6 +359,74 @@ func parseGlobalTimeout() (time.Duration, error) {
 	return d, nil
 }
 
+func parseCircuitBreakTimeout() (time.Duration, error) {
+	d, err := time.ParseDuration(globalFlags.CircuitTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("--circuit-break-timeout: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("--circuit-break-timeout must be > 0")
+	}
+	return d, nil
+}
+
+// completeSeriesIDs returns a completion function that offers the series IDs
+// in the local store for the first maxArgs positional arguments (0 = every
+// argument). The store is opened only when the shell asks for completions,
+// so ordinary runs pay nothing for it.
+func completeSeriesIDs(maxArgs int) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if maxArgs > 0 && len(args) >= maxArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return storedSeriesCompletions(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeSeriesIDFlag completes a flag that takes series IDs. A value being
+// built up as a comma-separated list completes its last element.
+func completeSeriesIDFlag(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	return storedSeriesCompletions(nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// storedSeriesCompletions lists the cached series IDs that start with
+// toComplete (case-insensitively, after its last comma) and are not already
+// in args, each described by its title. Any failure to read the store yields
+// no candidates rather than an error on the user's command line.
+func storedSeriesCompletions(args []string, toComplete string) []cobra.Completion {
+	cfg, err := loadConfig()
+	if err != nil || cfg.DBPath == "" {
+		return nil
+	}
+	s, err := store.OpenReadOnly(cfg.DBPath)
+	if err != nil {
+		return nil
+	}
+	defer s.Close()
+	metas, err := s.ListSeriesMeta()
+	if err != nil {
+		return nil
+	}
+
+	listed := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		listed, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+	taken := make(map[string]bool)
+	for _, id := range append(args, strings.Split(listed, ",")...) {
+		taken[strings.ToUpper(strings.TrimSpace(id))] = true
+	}
+	prefix := strings.ToUpper(toComplete)
+	var out []cobra.Completion
+	for _, meta := range metas {
+		if taken[meta.ID] || !strings.HasPrefix(meta.ID, prefix) {
+			continue
+		}
+		out = append(out, cobra.CompletionWithDesc(listed+meta.ID, meta.Title))
+	}
+	return out
+}
+
 func init() {
 	rootCmd.PersistentPreRunE = validateGlobalFlagOverrides
 
@@ -247,8 +434,12 @@ func init() {
 
 	pf.StringVar(&globalFlags.APIKey, "api-key", "",
 		"FRED API key (overrides env FRED_API_KEY and config.json)")
+	pf.StringVar(&globalFlags.Profile, "profile", "",
+		"config profile: apply profiles.<name> of config.json and config.<name>.json (overrides env RESERVE_PROFILE and the \"profile\" key)")
 	pf.StringVar(&globalFlags.Format, "format", "",
-		"output format: table|json|jsonl|csv|tsv|md (default: table)")
+		"output format: table|json|jsonl|csv|tsv|md|yaml (default: table)")
+	pf.StringVar(&globalFlags.Template, "template", "",
+		"Go text/template applied to each observation, series, or result, inline or @FILE")
 	pf.StringVar(&globalFlags.Out, "out", "",
 		"write output to <filename> instead of stdout")
 	pf.BoolVar(&globalFlags.NoCache, "no-cache", false,
@@ -258,15 +449,25 @@ func init() {
 	pf.StringVar(&globalFlags.Timeout, "timeout", "",
 		"HTTP request timeout (e.g. 30s, 2m)")
 	pf.IntVar(&globalFlags.Concurrency, "concurrency", 0,
-		"max parallel requests for batch operations (default: 8)")
+		"max parallel requests for batch operations and HTTP connection pool size (default: 8)")
 	pf.Float64Var(&globalFlags.Rate, "rate", 0,
 		"max API requests per second (default: 2.0)")
+	pf.IntVar(&globalFlags.CircuitThreshold, "circuit-break-threshold", 0,
+		"consecutive FRED failures that pause all requests (default: 5)")
+	pf.StringVar(&globalFlags.CircuitTimeout, "circuit-break-timeout", "",
+		"how long requests fail fast once the circuit opens (e.g. 30s, 2m; default: 30s)")
+	pf.StringVar(&globalFlags.Proxy, "proxy", "",
+		"route FRED requests through this proxy, e.g. http://proxy.corp.com:8080 (overrides env RESERVE_PROXY_URL and config.json)")
 	pf.BoolVar(&globalFlags.Quiet, "quiet", false,
 		"suppress all non-error output")
 	pf.BoolVar(&globalFlags.Verbose, "verbose", false,
 		"show cache/timing stats after output")
 	pf.BoolVar(&globalFlags.Debug, "debug", false,
 		"log HTTP requests and responses (API key redacted)")
+	pf.StringVar(&globalFlags.ObsTimezone, "observation-timezone", "",
+		"IANA time zone anchoring \"now\" for relative dates (default: UTC)")
+	pf.StringVar(&globalFlags.Color, "color", chart.ColorAuto,
+		"colorize charts and tables: auto|always|never, or on|off (auto honors NO_COLOR; --out files are never colored)")
 	pf.BoolVar(&globalFlags.AIOnboard, "ai-onboard", false,
 		"emit AI onboarding for the addressed command instead of executing it")
 }
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/spf13/cobra"
 )
 
 func TestBuildDepsRejectsInvalidGlobalOverrides(t *testing.T) {
@@ -27,6 +28,16 @@ func TestBuildDepsRejectsInvalidGlobalOverrides(t *testing.T) {
 		{name: "concurrency negative", flag: "concurrency", value: "-1", wantErr: "--concurrency must be > 0"},
 		{name: "rate zero", flag: "rate", value: "0", wantErr: "--rate must be > 0"},
 		{name: "rate negative", flag: "rate", value: "-1", wantErr: "--rate must be > 0"},
+		{name: "circuit threshold zero", flag: "circuit-break-threshold", value: "0", wantErr: "--circuit-break-threshold must be > 0"},
+		{name: "circuit timeout syntax", flag: "circuit-break-timeout", value: "30", wantErr: "--circuit-break-timeout"},
+		{name: "circuit timeout zero", flag: "circuit-break-timeout", value: "0s", wantErr: "--circuit-break-timeout must be > 0"},
+		{name: "color", flag: "color", value: "sometimes", wantErr: "--color must be one of"},
+		{name: "observation timezone", flag: "observation-timezone", value: "Mars/Olympus_Mons", wantErr: "--observation-timezone"},
+		{name: "proxy without scheme", flag: "proxy", value: "proxy.corp.com:8080", wantErr: "--proxy"},
+		{name: "template syntax", flag: "template", value: "{{.Date", wantErr: "--template"},
+		{name: "template file", flag: "template", value: "@does-not-exist.tmpl", wantErr: "--template"},
+		{name: "tmpl without template", flag: "format", value: "tmpl", wantErr: "--format tmpl requires --template"},
+		{name: "template without template", flag: "format", value: "template", wantErr: "--format template requires --template, e.g."},
 	}
 
 	for _, tc := range cases {
@@ -53,11 +64,161 @@ func TestBuildDepsRejectsInvalidGlobalOverrides(t *testing.T) {
 	}
 }
 
+func TestProxyFlagOverridesEnv(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	t.Setenv(config.EnvProxyURL, "http://env-proxy:3128")
+	resetGlobalFlag(t, "proxy")
+	if err := rootCmd.PersistentFlags().Set("proxy", "http://flag-proxy:8080"); err != nil {
+		t.Fatalf("set proxy: %v", err)
+	}
+	t.Cleanup(func() { resetGlobalFlag(t, "proxy") })
+
+	deps, err := buildDeps()
+	if err != nil {
+		t.Fatalf("buildDeps: %v", err)
+	}
+	defer deps.Close()
+	if deps.Config.ProxyURL != "http://flag-proxy:8080" {
+		t.Errorf("--proxy should override RESERVE_PROXY_URL, got %q", deps.Config.ProxyURL)
+	}
+}
+
+func TestSVGFormatAcceptedOnlyByChartPlotAndBar(t *testing.T) {
+	resetGlobalFlag(t, "format")
+	if err := rootCmd.PersistentFlags().Set("format", "svg"); err != nil {
+		t.Fatalf("set format: %v", err)
+	}
+	t.Cleanup(func() { resetGlobalFlag(t, "format") })
+
+	for _, c := range []*cobra.Command{chartPlotCmd, chartBarCmd} {
+		if err := validateGlobalFlagOverrides(c, nil); err != nil {
+			t.Errorf("%s should accept --format svg: %v", c.CommandPath(), err)
+		}
+	}
+	for _, c := range []*cobra.Command{obsGetCmd, chartScatterCmd} {
+		if err := validateGlobalFlagOverrides(c, nil); err == nil || !strings.Contains(err.Error(), "--format") {
+			t.Errorf("%s should reject --format svg, got %v", c.CommandPath(), err)
+		}
+	}
+}
+
+func TestBinaryFormatsAcceptedOnlyByObsGet(t *testing.T) {
+	for _, format := range []string{"xlsx", "parquet"} {
+		resetGlobalFlag(t, "format")
+		if err := rootCmd.PersistentFlags().Set("format", format); err != nil {
+			t.Fatalf("set format: %v", err)
+		}
+
+		if err := validateGlobalFlagOverrides(obsGetCmd, nil); err != nil {
+			t.Errorf("obs get should accept --format %s: %v", format, err)
+		}
+		for _, c := range []*cobra.Command{chartPlotCmd, seriesGetCmd} {
+			if err := validateGlobalFlagOverrides(c, nil); err == nil || !strings.Contains(err.Error(), "--format") {
+				t.Errorf("%s should reject --format %s, got %v", c.CommandPath(), format, err)
+			}
+		}
+	}
+	t.Cleanup(func() { resetGlobalFlag(t, "format") })
+}
+
+func TestTemplateRejectsOtherFormats(t *testing.T) {
+	resetGlobalFlag(t, "format")
+	resetGlobalFlag(t, "template")
+	t.Cleanup(func() {
+		resetGlobalFlag(t, "format")
+		resetGlobalFlag(t, "template")
+	})
+	if err := rootCmd.PersistentFlags().Set("template", "{{.Date}},{{.Value}}"); err != nil {
+		t.Fatalf("set template: %v", err)
+	}
+
+	if err := validateGlobalFlagOverrides(obsGetCmd, nil); err != nil {
+		t.Fatalf("--template alone should be accepted: %v", err)
+	}
+	if got := resolveFormat("json"); got != "tmpl" {
+		t.Errorf("resolveFormat with --template = %q, want tmpl", got)
+	}
+	if err := rootCmd.PersistentFlags().Set("format", "csv"); err != nil {
+		t.Fatalf("set format: %v", err)
+	}
+	if err := validateGlobalFlagOverrides(obsGetCmd, nil); err == nil || !strings.Contains(err.Error(), "--template cannot be combined") {
+		t.Errorf("expected --template/--format conflict, got %v", err)
+	}
+	if err := rootCmd.PersistentFlags().Set("format", "template"); err != nil {
+		t.Fatalf("set format: %v", err)
+	}
+	if err := validateGlobalFlagOverrides(obsGetCmd, nil); err != nil {
+		t.Errorf("--format template should be accepted with --template: %v", err)
+	}
+}
+
+func TestProfileFlagSelectsProfileFile(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	resetGlobalFlag(t, "profile")
+	t.Cleanup(func() { resetGlobalFlag(t, "profile") })
+	if err := os.WriteFile(config.DefaultConfigFile, []byte(`{"api_key": "basekey"}`), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.WriteFile(config.ProfileFileName("prod"), []byte(`{"api_key": "prodkey"}`), 0600); err != nil {
+		t.Fatalf("write profile: %v", err)
+	}
+
+	cfg, err := resolveRuntimeConfig()
+	if err != nil {
+		t.Fatalf("resolveRuntimeConfig: %v", err)
+	}
+	if cfg.APIKey != "basekey" {
+		t.Errorf("without --profile: expected basekey, got %q", cfg.APIKey)
+	}
+	if err := rootCmd.PersistentFlags().Set("profile", "prod"); err != nil {
+		t.Fatalf("set profile: %v", err)
+	}
+	cfg, err = resolveRuntimeConfig()
+	if err != nil {
+		t.Fatalf("resolveRuntimeConfig: %v", err)
+	}
+	if cfg.APIKey != "prodkey" || cfg.Profile != "prod" {
+		t.Errorf("with --profile prod: expected prodkey from profile prod, got %q from %q", cfg.APIKey, cfg.Profile)
+	}
+}
+
+func TestGlobalFlagsOverrideEnvSettings(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	resetGlobalFlag(t, "rate")
+	t.Cleanup(func() { resetGlobalFlag(t, "rate") })
+	t.Setenv(config.EnvRate, "5")
+	t.Setenv(config.EnvConcurrency, "3")
+
+	cfg, err := resolveRuntimeConfig()
+	if err != nil {
+		t.Fatalf("resolveRuntimeConfig: %v", err)
+	}
+	if cfg.Rate != 5 || cfg.Concurrency != 3 {
+		t.Errorf("env settings should apply without flags, got rate %v concurrency %d", cfg.Rate, cfg.Concurrency)
+	}
+	if err := rootCmd.PersistentFlags().Set("rate", "9"); err != nil {
+		t.Fatalf("set rate: %v", err)
+	}
+	cfg, err = resolveRuntimeConfig()
+	if err != nil {
+		t.Fatalf("resolveRuntimeConfig: %v", err)
+	}
+	if cfg.Rate != 9 || cfg.Concurrency != 3 {
+		t.Errorf("--rate should override %s, got rate %v concurrency %d", config.EnvRate, cfg.Rate, cfg.Concurrency)
+	}
+}
+
 func isolateBuildDepsConfig(t *testing.T) {
 	t.Helper()
 	dir := t.TempDir()
 	t.Setenv(config.EnvAPIKey, "")
 	t.Setenv(config.EnvDBPath, filepath.Join(dir, "reserve.db"))
+	t.Setenv(config.EnvProxyURL, "")
+	t.Setenv(config.EnvProfile, "")
+	for _, name := range []string{config.EnvFormat, config.EnvTimeout, config.EnvConcurrency, config.EnvRate, config.EnvBaseURL} {
+		t.Setenv(name, "")
+	}
+	t.Setenv(config.EnvSMTPPassword, "")
 	t.Setenv("HOME", filepath.Join(dir, "home"))
 	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "xdg"))
 	t.Setenv("APPDATA", filepath.Join(dir, "appdata"))
@@ -0,0 +1,538 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/schedule"
+	"github.com/derickschaefer/reserve/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// scheduleDaemonEnv marks the detached child started by 'schedule run
+// --daemon', which then runs the scheduler in the foreground.
+const scheduleDaemonEnv = "RESERVE_SCHEDULE_DAEMON"
+
+var scheduleNameRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+var (
+	scheduleAddName    string
+	scheduleAddCron    string
+	scheduleAddCommand string
+	scheduleRunDaemon  bool
+	scheduleRunPIDFile string
+	scheduleRunLogFile string
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run reserve commands on a cron schedule",
+	Long: `Stores reserve commands with a cron expression in the local database and runs
+them when they come due, for unattended data refreshes.
+
+Cron expressions have five fields (minute hour day-of-month month
+day-of-week) and are evaluated in the local time zone of the machine running
+'reserve schedule run'. A job whose previous run is still in progress when it
+comes due again is skipped for that firing.`,
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a scheduled job",
+	Example: `  reserve schedule add --name daily-macro --cron "0 8 * * 1-5" --cmd "fetch series CPIAUCSL UNRATE --store"
+  reserve schedule add --name weekly-rates --cron "@weekly" --cmd "fetch series DGS10 FEDFUNDS --store"`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimSpace(scheduleAddName)
+		if !scheduleNameRE.MatchString(name) {
+			return fmt.Errorf("--name %q is invalid: use letters, numbers, dot, underscore, or hyphen", name)
+		}
+		job, err := schedule.NewCronJob(name, scheduleAddCron, scheduleAddCommand)
+		if err != nil {
+			return err
+		}
+		if err := validateScheduledArgs(job.Args); err != nil {
+			return err
+		}
+
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		if _, found, err := deps.Store.GetScheduledJob(name); err != nil {
+			return err
+		} else if found {
+			return fmt.Errorf("scheduled job %q already exists; delete it first with 'reserve schedule delete %s'", name, name)
+		}
+		err = deps.Store.PutScheduledJob(model.ScheduledJob{
+			Name:      name,
+			Cron:      strings.TrimSpace(scheduleAddCron),
+			Command:   strings.TrimSpace(scheduleAddCommand),
+			CreatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return fmt.Errorf("saving scheduled job: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Scheduled %s: reserve %s\n", name, strings.Join(job.Args, " "))
+		fmt.Fprintf(cmd.OutOrStdout(), "  Next run: %s\n", fmtScheduleTime(job.Cron.Next(time.Now())))
+		return nil
+	},
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List scheduled jobs with their next fire time",
+	Example: `  reserve schedule list`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deps, err := buildReadOnlyDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		jobs, err := deps.Store.ListScheduledJobs()
+		if err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No scheduled jobs.")
+			return nil
+		}
+		return writeScheduleList(cmd.OutOrStdout(), resolveFormat(deps.Config.Format), jobs, time.Now())
+	},
+}
+
+var scheduleDeleteCmd = &cobra.Command{
+	Use:     "delete <NAME>",
+	Aliases: []string{"rm", "remove"},
+	Short:   "Delete a scheduled job",
+	Example: `  reserve schedule delete daily-macro`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.RequireStore(); err != nil {
+			return err
+		}
+		defer deps.Close()
+
+		removed, err := deps.Store.DeleteScheduledJob(args[0])
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return fmt.Errorf("scheduled job %q not found", args[0])
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Deleted scheduled job %s\n", args[0])
+		return nil
+	},
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run scheduled jobs as they come due",
+	Long: `Runs each scheduled job when its cron expression comes due, until stopped.
+Each job runs as a separate reserve process with the same --profile; its
+output and any failure are logged, and its last run time and status are
+recorded for 'reserve schedule list'.
+
+Jobs added or deleted while the scheduler runs take effect within a minute.
+The scheduler only opens the local database briefly, so scheduled commands
+and interactive use can write to it as usual.
+
+--daemon starts the scheduler in the background and returns. Its PID is
+written to --pid-file and its output appended to --log-file, both next to
+the database by default. Stop it with 'reserve schedule stop'.`,
+	Example: `  reserve schedule run
+  reserve schedule run --daemon
+  reserve schedule run --daemon --log-file /var/log/reserve-schedule.log`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := resolveRuntimeConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.DBPath == "" {
+			return fmt.Errorf("schedule run needs the local database: set db_path in config.json or RESERVE_DB_PATH")
+		}
+		pidFile, logFile := schedulePaths(cfg.DBPath)
+		if scheduleRunDaemon && os.Getenv(scheduleDaemonEnv) == "" {
+			return startScheduleDaemon(cmd, pidFile, logFile)
+		}
+
+		if err := claimSchedulePIDFile(pidFile); err != nil {
+			return err
+		}
+		defer releaseSchedulePIDFile(pidFile)
+
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("locating reserve executable: %w", err)
+		}
+		var extra []string
+		if globalFlags.Profile != "" {
+			extra = append(extra, "--profile", globalFlags.Profile)
+		}
+		runner := &scheduleRunner{dbPath: cfg.DBPath, exe: exe, extraArgs: extra, log: cmd.ErrOrStderr()}
+		s := &schedule.Scheduler{Clock: schedule.RealClock, Load: runner.load, Exec: runner.exec, Logf: runner.logf}
+
+		if jobs, err := runner.load(); err != nil {
+			return err
+		} else if !cfg.Quiet {
+			runner.logf("scheduler started with %d job(s) (PID %d)", len(jobs), os.Getpid())
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		err = s.Run(ctx)
+		if !cfg.Quiet {
+			runner.logf("scheduler stopped")
+		}
+		return err
+	},
+}
+
+var scheduleStopCmd = &cobra.Command{
+	Use:     "stop",
+	Short:   "Stop a scheduler started with 'schedule run --daemon'",
+	Example: `  reserve schedule stop`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := resolveRuntimeConfig()
+		if err != nil {
+			return err
+		}
+		pidFile, _ := schedulePaths(cfg.DBPath)
+		pid, err := readSchedulePIDFile(pidFile)
+		if errors.Is(err, fs.ErrNotExist) || (err == nil && !processAlive(pid)) {
+			return fmt.Errorf("no scheduler is running (PID file %s)", pidFile)
+		}
+		if err != nil {
+			return err
+		}
+		if err := stopProcess(pid); err != nil {
+			return fmt.Errorf("stopping scheduler PID %d: %w", pid, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Stopped scheduler (PID %d)\n", pid)
+		return nil
+	},
+}
+
+// validateScheduledArgs rejects commands that cannot run unattended: the
+// long-running schedule and watch commands, obs watch without --once, and
+// anything that would not parse as a reserve invocation. The command is
+// resolved the way cobra resolves it, so leading global flags do not hide it.
+func validateScheduledArgs(args []string) error {
+	if err := validateInvocation(args); err != nil {
+		return err
+	}
+	c, rest, err := rootCmd.Find(args)
+	if err != nil {
+		return err
+	}
+	path := strings.TrimPrefix(c.CommandPath(), rootCmd.Name()+" ")
+	switch {
+	case path == "watch" || strings.HasPrefix(path, "schedule"):
+		return fmt.Errorf("%q runs until stopped and cannot be scheduled", path)
+	case path == "obs watch" && !onceFlagSet(rest):
+		return fmt.Errorf("%q runs until stopped and cannot be scheduled without --once", path)
+	}
+	return nil
+}
+
+// onceFlagSet reports whether args turn on --once.
+func onceFlagSet(args []string) bool {
+	once := false
+	for _, arg := range args {
+		if arg == "--once" {
+			once = true
+		} else if v, ok := strings.CutPrefix(arg, "--once="); ok {
+			once, _ = strconv.ParseBool(v)
+		}
+	}
+	return once
+}
+
+// schedulePaths returns the --pid-file and --log-file paths, defaulting to
+// files next to the database.
+func schedulePaths(dbPath string) (pidFile, logFile string) {
+	pidFile, logFile = scheduleRunPIDFile, scheduleRunLogFile
+	if pidFile == "" {
+		pidFile = filepath.Join(filepath.Dir(dbPath), "schedule.pid")
+	}
+	if logFile == "" {
+		logFile = filepath.Join(filepath.Dir(dbPath), "schedule.log")
+	}
+	return pidFile, logFile
+}
+
+// startScheduleDaemon re-runs this command line as a detached child that
+// logs to logFile, and records the child's PID before returning.
+func startScheduleDaemon(cmd *cobra.Command, pidFile, logFile string) error {
+	if pid, err := readSchedulePIDFile(pidFile); err == nil && processAlive(pid) {
+		return fmt.Errorf("a scheduler is already running (PID %d); stop it with 'reserve schedule stop'", pid)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating reserve executable: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(logFile), 0700); err != nil {
+		return fmt.Errorf("creating log directory: %w", err)
+	}
+	logf, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	defer logf.Close()
+
+	child := exec.Command(exe, os.Args[1:]...)
+	child.Env = append(os.Environ(), scheduleDaemonEnv+"=1")
+	child.Stdout, child.Stderr = logf, logf
+	child.SysProcAttr = daemonSysProcAttr()
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("starting scheduler: %w", err)
+	}
+	pid := child.Process.Pid
+	if err := writeSchedulePIDFile(pidFile, pid); err != nil {
+		_ = child.Process.Kill()
+		return err
+	}
+	_ = child.Process.Release()
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Scheduler started in the background (PID %d)\n", pid)
+	fmt.Fprintf(cmd.OutOrStdout(), "  PID file: %s\n", pidFile)
+	fmt.Fprintf(cmd.OutOrStdout(), "  Log:      %s\n", logFile)
+	return nil
+}
+
+// claimSchedulePIDFile records this process in pidFile, failing if another
+// live scheduler already holds it. The daemon parent writes the child's PID
+// before the child starts, so a file naming this process is already ours.
+func claimSchedulePIDFile(pidFile string) error {
+	pid, err := readSchedulePIDFile(pidFile)
+	if err == nil && pid != os.Getpid() && processAlive(pid) {
+		return fmt.Errorf("a scheduler is already running (PID %d); stop it with 'reserve schedule stop'", pid)
+	}
+	return writeSchedulePIDFile(pidFile, os.Getpid())
+}
+
+// releaseSchedulePIDFile removes pidFile if it still names this process.
+func releaseSchedulePIDFile(pidFile string) {
+	if pid, err := readSchedulePIDFile(pidFile); err == nil && pid == os.Getpid() {
+		_ = os.Remove(pidFile)
+	}
+}
+
+func readSchedulePIDFile(path string) (int, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("PID file %s is corrupt", path)
+	}
+	return pid, nil
+}
+
+func writeSchedulePIDFile(path string, pid int) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating PID file directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(pid)+"\n"), 0600); err != nil {
+		return fmt.Errorf("writing PID file: %w", err)
+	}
+	return nil
+}
+
+// scheduleRunner loads jobs from and records runs in the database at dbPath,
+// opening it only for the moment each needs.
+type scheduleRunner struct {
+	dbPath    string
+	exe       string
+	extraArgs []string
+
+	mu   sync.Mutex
+	log  io.Writer
+	last []schedule.CronJob
+}
+
+// load reads the scheduled jobs. While a scheduled command holds the
+// database, the jobs from the previous successful load are reused.
+func (r *scheduleRunner) load() ([]schedule.CronJob, error) {
+	s, err := store.OpenReadOnly(r.dbPath)
+	if err != nil {
+		if s, err = store.Open(r.dbPath); err != nil {
+			return r.lastJobs(err)
+		}
+	}
+	stored, err := s.ListScheduledJobs()
+	s.Close()
+	if err != nil {
+		return r.lastJobs(err)
+	}
+	jobs := make([]schedule.CronJob, 0, len(stored))
+	for _, sj := range stored {
+		job, err := schedule.NewCronJob(sj.Name, sj.Cron, sj.Command)
+		if err != nil {
+			r.logf("%s: %v", sj.Name, err)
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	r.mu.Lock()
+	r.last = jobs
+	r.mu.Unlock()
+	return jobs, nil
+}
+
+func (r *scheduleRunner) lastJobs(err error) ([]schedule.CronJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.last == nil {
+		return nil, err
+	}
+	return r.last, nil
+}
+
+// exec runs one firing of job as a child reserve process and records its
+// outcome.
+func (r *scheduleRunner) exec(ctx context.Context, job schedule.CronJob, at time.Time) error {
+	args := append(append([]string{}, job.Args...), r.extraArgs...)
+	r.logf("%s: running reserve %s", job.Name, strings.Join(job.Args, " "))
+	child := exec.CommandContext(ctx, r.exe, args...)
+	child.Stdout, child.Stderr = r.syncedLog(), r.syncedLog()
+	start := time.Now()
+	runErr := child.Run()
+
+	status := "ok"
+	if runErr != nil {
+		status = "failed: " + runErr.Error()
+	} else {
+		r.logf("%s: finished in %s", job.Name, time.Since(start).Round(time.Second))
+	}
+	s, err := store.Open(r.dbPath)
+	if err == nil {
+		err = s.RecordScheduledRun(job.Name, at, status)
+		s.Close()
+	}
+	if err != nil {
+		r.logf("%s: recording run: %v", job.Name, err)
+	}
+	return runErr
+}
+
+func (r *scheduleRunner) logf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fmt.Fprintf(r.log, "%s  %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+}
+
+// syncedLog serializes child output with the scheduler's own log lines.
+func (r *scheduleRunner) syncedLog() io.Writer {
+	return writerFunc(func(p []byte) (int, error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.log.Write(p)
+	})
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+// scheduleListRow is one 'schedule list' entry.
+type scheduleListRow struct {
+	model.ScheduledJob
+	NextFire *time.Time `json:"next_fire,omitempty"`
+}
+
+func writeScheduleList(w io.Writer, format string, jobs []model.ScheduledJob, now time.Time) error {
+	rows := make([]scheduleListRow, len(jobs))
+	for i, j := range jobs {
+		rows[i].ScheduledJob = j
+		if c, err := schedule.ParseCron(j.Cron); err == nil {
+			if next := c.Next(now); !next.IsZero() {
+				rows[i].NextFire = &next
+			}
+		}
+	}
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+	if format == render.FormatYAML {
+		return render.EncodeYAML(w, rows)
+	}
+	printSimpleTable(w, []string{"NAME", "CRON", "COMMAND", "NEXT_FIRE", "LAST_RUN", "STATUS"}, func(add func(...string)) {
+		for _, r := range rows {
+			var next time.Time
+			if r.NextFire != nil {
+				next = *r.NextFire
+			}
+			last, status := "-", "-"
+			if !r.LastRunAt.IsZero() {
+				last, status = fmtScheduleTime(r.LastRunAt.Local()), r.LastStatus
+			}
+			add(r.Name, r.Cron, r.Command, fmtScheduleTime(next), last, status)
+		}
+	})
+	return nil
+}
+
+func fmtScheduleTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleDeleteCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+	scheduleCmd.AddCommand(scheduleStopCmd)
+
+	scheduleAddCmd.Flags().StringVar(&scheduleAddName, "name", "", "unique job name (required)")
+	scheduleAddCmd.Flags().StringVar(&scheduleAddCron, "cron", "", `five-field cron expression, e.g. "0 8 * * 1-5" (required)`)
+	scheduleAddCmd.Flags().StringVar(&scheduleAddCommand, "cmd", "", `reserve command to run, e.g. "fetch series UNRATE --store" (required)`)
+	for _, name := range []string{"name", "cron", "cmd"} {
+		_ = scheduleAddCmd.MarkFlagRequired(name)
+	}
+	scheduleRunCmd.Flags().BoolVar(&scheduleRunDaemon, "daemon", false, "start the scheduler in the background and return")
+	for _, c := range []*cobra.Command{scheduleRunCmd, scheduleStopCmd} {
+		c.Flags().StringVar(&scheduleRunPIDFile, "pid-file", "", "scheduler PID file (default: schedule.pid next to the database)")
+	}
+	scheduleRunCmd.Flags().StringVar(&scheduleRunLogFile, "log-file", "", "with --daemon, append scheduler output here (default: schedule.log next to the database)")
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/spf13/cobra"
+)
+
+func setScheduleAddFlags(t *testing.T, name, cron, command string) {
+	t.Helper()
+	origName, origCron, origCommand := scheduleAddName, scheduleAddCron, scheduleAddCommand
+	scheduleAddName, scheduleAddCron, scheduleAddCommand = name, cron, command
+	t.Cleanup(func() { scheduleAddName, scheduleAddCron, scheduleAddCommand = origName, origCron, origCommand })
+}
+
+func TestScheduleAddListDelete(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	setScheduleAddFlags(t, "daily-macro", "0 8 * * 1-5", "fetch series CPIAUCSL UNRATE --store")
+	if err := scheduleAddCmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("schedule add: %v", err)
+	}
+	if !strings.Contains(out.String(), "✓ Scheduled daily-macro: reserve fetch series CPIAUCSL UNRATE --store") {
+		t.Errorf("unexpected add output:\n%s", out.String())
+	}
+	if err := scheduleAddCmd.RunE(cmd, nil); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate name error, got %v", err)
+	}
+
+	out.Reset()
+	if err := scheduleListCmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("schedule list: %v", err)
+	}
+	for _, want := range []string{"NEXT FIRE", "daily-macro", "0 8 * * 1-5", "fetch series CPIAUCSL UNRATE --store", " 08:00 "} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in list:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := scheduleDeleteCmd.RunE(cmd, []string{"daily-macro"}); err != nil {
+		t.Fatalf("schedule delete: %v", err)
+	}
+	if err := scheduleDeleteCmd.RunE(cmd, []string{"daily-macro"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	out.Reset()
+	if err := scheduleListCmd.RunE(cmd, nil); err != nil {
+		t.Fatalf("schedule list: %v", err)
+	}
+	if !strings.Contains(out.String(), "No scheduled jobs.") {
+		t.Errorf("expected empty list, got:\n%s", out.String())
+	}
+}
+
+func TestScheduleAddRejectsInvalidJobs(t *testing.T) {
+	cases := []struct {
+		name, cron, command, want string
+	}{
+		{"bad name", "@daily", "fetch series GDP --store", "--name"},
+		{"nightly", "0 25 * * *", "fetch series GDP --store", "hour"},
+		{"nightly", "@daily", "fetch series GDP --bogus", "unknown flag --bogus"},
+		{"nightly", "@daily", "watch UNRATE", "cannot be scheduled"},
+		{"nightly", "@daily", "reserve schedule run", "cannot be scheduled"},
+		{"nightly", "@daily", "--format json watch UNRATE", "cannot be scheduled"},
+		{"nightly", "@daily", "obs watch UNRATE", "without --once"},
+		{"nightly", "@daily", "--format json obs watch UNRATE --once=false", "without --once"},
+	}
+	for _, tc := range cases {
+		setScheduleAddFlags(t, tc.name, tc.cron, tc.command)
+		err := scheduleAddCmd.RunE(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%q %q %q: expected error containing %q, got %v", tc.name, tc.cron, tc.command, tc.want, err)
+		}
+	}
+}
+
+func TestValidateScheduledArgsAllowsOneShotObsWatch(t *testing.T) {
+	for _, args := range [][]string{
+		{"obs", "watch", "UNRATE", "--once"},
+		{"--format", "json", "obs", "watch", "UNRATE", "--once"},
+	} {
+		if err := validateScheduledArgs(args); err != nil {
+			t.Errorf("validateScheduledArgs(%v) = %v, want nil", args, err)
+		}
+	}
+}
+
+func TestWriteScheduleListJSON(t *testing.T) {
+	jobs := []model.ScheduledJob{
+		{Name: "daily", Cron: "0 8 * * *", Command: "fetch series GDP --store", LastRunAt: time.Date(2026, 1, 5, 8, 0, 0, 0, time.UTC), LastStatus: "ok"},
+		{Name: "never", Cron: "0 0 30 2 *", Command: "fetch series GDP --store"},
+	}
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	if err := writeScheduleList(&buf, "json", jobs, now); err != nil {
+		t.Fatalf("writeScheduleList: %v", err)
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(rows) != 2 || rows[0]["next_fire"] != "2026-01-06T08:00:00Z" || rows[0]["last_status"] != "ok" {
+		t.Errorf("unexpected first row: %v", rows)
+	}
+	if _, ok := rows[1]["next_fire"]; ok {
+		t.Errorf("a job that never fires should have no next_fire: %v", rows[1])
+	}
+}
+
+func TestSchedulePIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "schedule.pid")
+	if err := claimSchedulePIDFile(path); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if pid, err := readSchedulePIDFile(path); err != nil || pid != os.Getpid() {
+		t.Fatalf("PID file holds %d (err=%v), want %d", pid, err, os.Getpid())
+	}
+	// Claiming again from the same process is fine: the daemon parent
+	// writes the child's PID before the child claims the file.
+	if err := claimSchedulePIDFile(path); err != nil {
+		t.Fatalf("reclaim: %v", err)
+	}
+	releaseSchedulePIDFile(path)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("release should remove the PID file, stat err=%v", err)
+	}
+
+	if err := writeSchedulePIDFile(path, os.Getppid()); err != nil {
+		t.Fatal(err)
+	}
+	if err := claimSchedulePIDFile(path); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("expected a live scheduler to block the claim, got %v", err)
+	}
+}
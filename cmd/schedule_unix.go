@@ -0,0 +1,28 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build !windows
+
+package cmd
+
+import (
+	"errors"
+	"syscall"
+)
+
+// daemonSysProcAttr starts the scheduler in its own session so it outlives
+// the terminal that launched it.
+func daemonSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
+
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// stopProcess sends SIGTERM so the scheduler can wait for running jobs and
+// remove its PID file.
+func stopProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
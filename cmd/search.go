@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -65,7 +66,9 @@ Use --type to restrict to a specific entity type:
 					Items:      len(metas),
 				},
 			}
-			return render.RenderTo(globalFlags.Out, result, format)
+			return writeOutput(cmd, format, func(w io.Writer) error {
+				return render.Render(w, result, format)
+			})
 
 		case "tag":
 			tags, err := deps.Client.SearchTags(cmd.Context(), query, fred.SearchTagsOptions{
@@ -74,7 +77,9 @@ Use --type to restrict to a specific entity type:
 			if err != nil {
 				return err
 			}
-			return render.RenderTags(cmd.OutOrStdout(), tags, format)
+			return writeOutput(cmd, format, func(w io.Writer) error {
+				return render.RenderTags(w, tags, format)
+			})
 
 		case "all":
 			// Best-effort: run series search and tag search, combine
@@ -93,30 +98,36 @@ Use --type to restrict to a specific entity type:
 				warnings = append(warnings, fmt.Sprintf("tag search: %v", tagErr))
 			}
 
-			if len(metas) > 0 {
-				if !deps.Config.Quiet {
-					fmt.Fprintf(cmd.OutOrStdout(), "── Series ──\n")
-				}
-				result := &model.Result{
-					Kind:     model.KindSearchResult,
-					Command:  fmt.Sprintf("search %q --type all", query),
-					Warnings: warnings,
-					Data:     &model.SearchResult{Query: query, Type: "series", Series: metas},
+			err := writeOutput(cmd, format, func(w io.Writer) error {
+				if len(metas) > 0 {
+					if !deps.Config.Quiet {
+						fmt.Fprintf(w, "── Series ──\n")
+					}
+					result := &model.Result{
+						Kind:     model.KindSearchResult,
+						Command:  fmt.Sprintf("search %q --type all", query),
+						Warnings: warnings,
+						Data:     &model.SearchResult{Query: query, Type: "series", Series: metas},
+					}
+					if err := render.Render(w, result, format); err != nil {
+						return err
+					}
 				}
-				if err := render.RenderTo(globalFlags.Out, result, format); err != nil {
-					return err
-				}
-			}
-			if len(tags) > 0 {
-				if !deps.Config.Quiet {
-					fmt.Fprintf(cmd.OutOrStdout(), "\n── Tags ──\n")
-				}
-				if err := render.RenderTags(cmd.OutOrStdout(), tags, format); err != nil {
-					return err
+				if len(tags) > 0 {
+					if !deps.Config.Quiet {
+						fmt.Fprintf(w, "\n── Tags ──\n")
+					}
+					if err := render.RenderTags(w, tags, format); err != nil {
+						return err
+					}
 				}
+				return nil
+			})
+			if err != nil {
+				return err
 			}
 			for _, w := range warnings {
-				fmt.Fprintf(cmd.OutOrStdout(), "⚠  %s\n", w)
+				fmt.Fprintf(obsFooterWriter(cmd, format), "⚠  %s\n", w)
 			}
 			return nil
 
@@ -130,4 +141,5 @@ func init() {
 	rootCmd.AddCommand(searchCmd)
 	searchCmd.Flags().StringVar(&searchType, "type", "series", "entity type: series|tag|all")
 	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "max results")
+	searchCmd.Flags().BoolVar(&outputGzip, "gzip", false, outputGzipUsage)
 }
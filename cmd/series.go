@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 	"time"
@@ -33,7 +34,8 @@ var seriesGetCmd = &cobra.Command{
 	Short: "Fetch metadata for one or more series",
 	Example: `  reserve series get GDP
   reserve series get GDP CPIAUCSL UNRATE --format json`,
-	Args: cobra.MinimumNArgs(1),
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeSeriesIDs(0),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		deps, err := buildDeps()
 		if err != nil {
@@ -62,10 +64,12 @@ var seriesGetCmd = &cobra.Command{
 				},
 			}
 			format := resolveFormat(deps.Config.Format)
-			if err := render.RenderTo(globalFlags.Out, result, format); err != nil {
+			if err := writeOutput(cmd, format, func(w io.Writer) error {
+				return render.Render(w, result, format)
+			}); err != nil {
 				return err
 			}
-			render.PrintFooter(cmd.OutOrStdout(), result, deps.Config.Verbose)
+			render.PrintFooter(obsFooterWriter(cmd, format), result, deps.Config.Verbose)
 			return nil
 		}
 
@@ -85,10 +89,12 @@ var seriesGetCmd = &cobra.Command{
 			},
 		}
 		format := resolveFormat(deps.Config.Format)
-		if err := render.RenderTo(globalFlags.Out, result, format); err != nil {
+		if err := writeOutput(cmd, format, func(w io.Writer) error {
+			return render.Render(w, result, format)
+		}); err != nil {
 			return err
 		}
-		render.PrintFooter(cmd.OutOrStdout(), result, deps.Config.Verbose)
+		render.PrintFooter(obsFooterWriter(cmd, format), result, deps.Config.Verbose)
 		return nil
 	},
 }
@@ -142,10 +148,12 @@ var seriesSearchCmd = &cobra.Command{
 		}
 
 		format := resolveFormat(deps.Config.Format)
-		if err := render.RenderTo(globalFlags.Out, result, format); err != nil {
+		if err := writeOutput(cmd, format, func(w io.Writer) error {
+			return render.Render(w, result, format)
+		}); err != nil {
 			return err
 		}
-		render.PrintFooter(cmd.OutOrStdout(), result, deps.Config.Verbose)
+		render.PrintFooter(obsFooterWriter(cmd, format), result, deps.Config.Verbose)
 		return nil
 	},
 }
@@ -157,7 +165,8 @@ var seriesTagsCmd = &cobra.Command{
 	Short: "List tags associated with a series",
 	Example: `  reserve series tags CPIAUCSL
   reserve series tags GDP --format json`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		deps, err := buildDeps()
 		if err != nil {
@@ -190,7 +199,9 @@ var seriesTagsCmd = &cobra.Command{
 				Items:      len(tags),
 			},
 		}
-		return render.RenderTo(globalFlags.Out, result, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.Render(w, result, format)
+		})
 	},
 }
 
@@ -201,7 +212,8 @@ var seriesCategoriesCmd = &cobra.Command{
 	Short: "List categories a series belongs to",
 	Example: `  reserve series categories GDP
   reserve series categories UNRATE --format json`,
-	Args: cobra.ExactArgs(1),
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		deps, err := buildDeps()
 		if err != nil {
@@ -234,7 +246,9 @@ var seriesCategoriesCmd = &cobra.Command{
 				Items:      len(cats),
 			},
 		}
-		return render.RenderTo(globalFlags.Out, result, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.Render(w, result, format)
+		})
 	},
 }
 
@@ -247,6 +261,10 @@ func init() {
 	seriesCmd.AddCommand(seriesTagsCmd)
 	seriesCmd.AddCommand(seriesCategoriesCmd)
 
+	for _, c := range []*cobra.Command{seriesGetCmd, seriesSearchCmd, seriesTagsCmd, seriesCategoriesCmd} {
+		c.Flags().BoolVar(&outputGzip, "gzip", false, outputGzipUsage)
+	}
+
 	seriesSearchCmd.Flags().StringSliceVar(&seriesSearchTags, "tag", nil, "filter by tag (repeatable)")
 	seriesSearchCmd.Flags().IntVar(&seriesSearchLimit, "limit", 20, "max results")
 }
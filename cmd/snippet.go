@@ -10,7 +10,7 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/render"
 	snlib "github.com/derickschaefer/reserve/internal/snippet"
 	"github.com/spf13/cobra"
 )
@@ -89,12 +89,12 @@ var snippetListCmd = &cobra.Command{
 			return nil
 		}
 
-		cfg, err := config.Load(globalFlags.APIKey)
+		cfg, err := loadConfig()
 		if err != nil {
 			return err
 		}
 		format := resolveFormat(cfg.Format)
-		if format == "json" {
+		if format == "json" || format == render.FormatYAML {
 			type row struct {
 				Library     string `json:"library"`
 				Name        string `json:"name"`
@@ -106,6 +106,9 @@ var snippetListCmd = &cobra.Command{
 				s := values[r]
 				out = append(out, row{Library: r.Library, Name: r.Name, Description: s.Description, Command: s.Command})
 			}
+			if format == render.FormatYAML {
+				return render.EncodeYAML(cmd.OutOrStdout(), out)
+			}
 			enc := json.NewEncoder(cmd.OutOrStdout())
 			enc.SetIndent("", "  ")
 			return enc.Encode(out)
@@ -241,7 +244,7 @@ func snippetPreview(command string) string {
 }
 
 func snippetSettings() (home string, enabled []string, err error) {
-	cfg, err := config.Load(globalFlags.APIKey)
+	cfg, err := loadConfig()
 	if err != nil {
 		return "", nil, err
 	}
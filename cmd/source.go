@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/model"
@@ -39,11 +40,13 @@ var sourceListCmd = &cobra.Command{
 			return err
 		}
 		format := resolveFormat(deps.Config.Format)
-		if err := render.RenderSources(cmd.OutOrStdout(), sources, format); err != nil {
+		if err := writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderSources(w, sources, format)
+		}); err != nil {
 			return err
 		}
 		if deps.Config.Verbose {
-			fmt.Fprintf(cmd.OutOrStdout(), "\n[%d items • %dms]\n", len(sources), time.Since(start).Milliseconds())
+			fmt.Fprintf(obsFooterWriter(cmd, format), "\n[%d items • %dms]\n", len(sources), time.Since(start).Milliseconds())
 		}
 		return nil
 	},
@@ -74,7 +77,9 @@ var sourceGetCmd = &cobra.Command{
 			return err
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderSources(cmd.OutOrStdout(), []model.Source{*src}, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderSources(w, []model.Source{*src}, format)
+		})
 	},
 }
 
@@ -105,7 +110,9 @@ var sourceReleasesCmd = &cobra.Command{
 			return err
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderReleases(cmd.OutOrStdout(), releases, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderReleases(w, releases, format)
+		})
 	},
 }
 
@@ -118,4 +125,7 @@ func init() {
 	sourceCmd.AddCommand(sourceReleasesCmd)
 
 	sourceReleasesCmd.Flags().IntVar(&sourceReleasesLimit, "limit", 0, "max releases (0 = all)")
+	for _, c := range []*cobra.Command{sourceListCmd, sourceGetCmd, sourceReleasesCmd} {
+		c.Flags().BoolVar(&outputGzip, "gzip", false, outputGzipUsage)
+	}
 }
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/fred"
@@ -44,7 +45,9 @@ var tagSearchCmd = &cobra.Command{
 			return err
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderTags(cmd.OutOrStdout(), tags, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderTags(w, tags, format)
+		})
 	},
 }
 
@@ -89,7 +92,9 @@ var tagSeriesCmd = &cobra.Command{
 			},
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderTo(globalFlags.Out, result, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.Render(w, result, format)
+		})
 	},
 }
 
@@ -116,7 +121,9 @@ var tagRelatedCmd = &cobra.Command{
 			return err
 		}
 		format := resolveFormat(deps.Config.Format)
-		return render.RenderTags(cmd.OutOrStdout(), tags, format)
+		return writeOutput(cmd, format, func(w io.Writer) error {
+			return render.RenderTags(w, tags, format)
+		})
 	},
 }
 
@@ -132,4 +139,7 @@ func init() {
 	tagSeriesCmd.Flags().IntVar(&tagSeriesLimit, "limit", 20, "max series to return")
 	tagSeriesCmd.Flags().BoolVar(&tagSeriesAll, "all", false, "series must match ALL tags (default: any)")
 	tagRelatedCmd.Flags().IntVar(&tagRelatedLimit, "limit", 20, "max tags to return")
+	for _, c := range []*cobra.Command{tagSearchCmd, tagSeriesCmd, tagRelatedCmd} {
+		c.Flags().BoolVar(&outputGzip, "gzip", false, outputGzipUsage)
+	}
 }
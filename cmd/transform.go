@@ -28,19 +28,68 @@ Pipeline example:
 
 // ─── pct-change ───────────────────────────────────────────────────────────────
 
-var transformPctPeriod int
+var (
+	transformPctPeriod     int
+	transformPctKeepLength bool
+	transformPctMethod     string
+)
 
 var transformPctCmd = &cobra.Command{
 	Use:   "pct-change",
 	Short: "Percent change from N periods ago: (v[t]-v[t-N])/|v[t-N]| * 100",
 	Example: `  reserve obs get GDP --from cache --format jsonl | reserve transform pct-change
-  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform pct-change --period 12`,
+  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform pct-change --period 12
+  reserve obs get GDP --from cache --format jsonl | reserve transform pct-change --method log
+  reserve obs get GDP --from cache --format jsonl | reserve transform pct-change --keep-length | reserve transform pct-change --keep-length`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
+		if err != nil {
+			return err
+		}
+		out, warnings, err := transform.PctChange(obs, transformPctPeriod, transformPctKeepLength,
+			transform.PctChangeMethod(transformPctMethod))
+		if err != nil {
+			return err
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "⚠  %s\n", w)
+		}
+		return writeTransformOutput(cmd, seriesID, out, citation)
+	},
+}
+
+// ─── annualize ────────────────────────────────────────────────────────────────
+
+var transformAnnualizePeriods int
+
+var transformAnnualizeCmd = &cobra.Command{
+	Use:   "annualize",
+	Short: "Compound periodic % rates to annual: ((1+r/100)^N - 1) * 100",
+	Long: `Treats each input value as a percent rate for one period, such as the output
+of 'transform pct-change', and compounds it to an annual percent rate.
+
+--periods sets the number of periods per year. Without it, the frequency is
+detected from the spacing of the input dates: 12 for monthly, 4 for
+quarterly, 52 for weekly, 252 for business-daily, and 365 for daily data.`,
+	Example: `  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform pct-change | reserve transform annualize
+  reserve obs get GDPC1 --from cache --format jsonl | reserve transform pct-change | reserve transform annualize --periods 4`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
 		if err != nil {
 			return err
 		}
-		out, err := transform.PctChange(obs, transformPctPeriod)
+		periods := transformAnnualizePeriods
+		if !cmd.Flags().Changed("periods") {
+			freq, perYear := model.DetectFrequency(obs)
+			if freq == model.FrequencyIrregular {
+				return fmt.Errorf("annualize: cannot detect a regular frequency from the input dates; pass --periods")
+			}
+			periods = int(math.Round(perYear))
+			if globalFlags.Verbose {
+				fmt.Fprintf(os.Stderr, "annualize: detected %s data, using --periods %d\n", freq, periods)
+			}
+		}
+		out, err := transform.Annualize(obs, periods)
 		if err != nil {
 			return err
 		}
@@ -70,6 +119,28 @@ var transformDiffCmd = &cobra.Command{
 	},
 }
 
+// ─── seasonal-diff ────────────────────────────────────────────────────────────
+
+var transformSeasonalLag int
+
+var transformSeasonalDiffCmd = &cobra.Command{
+	Use:   "seasonal-diff",
+	Short: "Seasonal difference: v[t] - v[t-lag]",
+	Example: `  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform seasonal-diff
+  reserve obs get GDP --from cache --format jsonl | reserve transform seasonal-diff --lag 4`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
+		if err != nil {
+			return err
+		}
+		out, err := transform.SeasonalDiff(obs, transformSeasonalLag)
+		if err != nil {
+			return err
+		}
+		return writeTransformOutput(cmd, seriesID, out, citation)
+	},
+}
+
 // ─── log ──────────────────────────────────────────────────────────────────────
 
 var transformLogCmd = &cobra.Command{
@@ -95,9 +166,18 @@ var transformNormMethod string
 
 var transformNormCmd = &cobra.Command{
 	Use:   "normalize",
-	Short: "Normalize observations: zscore (default) or minmax",
+	Short: "Normalize observations: zscore (default), minmax, or robust",
+	Long: `Rescales the series to a common scale.
+
+  zscore  subtract the mean, divide by the standard deviation
+  minmax  map the minimum to 0 and the maximum to 1
+  robust  subtract the median, divide by the interquartile range (P75-P25)
+
+robust suits series with outliers, such as a 2020 spike, which would
+otherwise dominate the mean and standard deviation.`,
 	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve transform normalize
-  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform normalize --method minmax`,
+  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform normalize --method minmax
+  reserve obs get ICSA --from cache --format jsonl | reserve transform normalize --method robust`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
 		if err != nil {
@@ -119,8 +199,14 @@ var (
 )
 
 var transformIndexCmd = &cobra.Command{
-	Use:     "index",
-	Short:   "Re-index series so value at --at date equals --base",
+	Use:   "index",
+	Short: "Re-index series so value at --at date equals --base",
+	Long: `Scales the series so its value at the --at date equals --base.
+
+When no observation is dated exactly --at, a monthly, quarterly, or annual
+series anchors at its observation for the period containing --at (2020-01-15
+uses January 2020), and other series at the nearest observation within one
+typical spacing. A warning names the date actually used.`,
 	Example: `  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform index --base 100 --at 2010-01-01`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if transformIndexAt == "" {
@@ -138,6 +224,35 @@ var transformIndexCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if used, err := transform.IndexAnchor(obs, anchor); err == nil && !used.Equal(anchor) {
+			fmt.Fprintf(os.Stderr, "⚠  --at %s has no exact observation; anchored at %s\n", transformIndexAt, used.Format("2006-01-02"))
+		}
+		return writeTransformOutput(cmd, seriesID, out, citation)
+	},
+}
+
+// ─── rebase ───────────────────────────────────────────────────────────────────
+
+var transformRebaseTo string
+
+var transformRebaseCmd = &cobra.Command{
+	Use:   "rebase",
+	Short: "Re-scale series so its first, last, max, min, or mean equals 100",
+	Long: `Scales the series so a reference value equals 100, without naming an
+anchor date as index does: the first or last non-missing value, or the
+maximum, minimum, or mean. --to max expresses each value as a percent of the
+series peak.`,
+	Example: `  reserve obs get INDPRO --from cache --format jsonl | reserve transform rebase --to max
+  reserve obs get CPIAUCSL --from cache --start 2020-01-01 --format jsonl | reserve transform rebase --to first`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
+		if err != nil {
+			return err
+		}
+		out, err := transform.Rebase(obs, transform.RebaseMode(transformRebaseTo))
+		if err != nil {
+			return err
+		}
 		return writeTransformOutput(cmd, seriesID, out, citation)
 	},
 }
@@ -145,24 +260,37 @@ var transformIndexCmd = &cobra.Command{
 // ─── resample ─────────────────────────────────────────────────────────────────
 
 var (
-	transformResampleFreq   string
-	transformResampleMethod string
+	transformResampleFreq        string
+	transformResampleMethod      string
+	transformResampleFillMissing bool
 )
 
 var transformResampleCmd = &cobra.Command{
 	Use:   "resample",
-	Short: "Downsample to lower frequency: monthly, quarterly, or annual",
+	Short: "Change frequency: daily, weekly, monthly, quarterly, or annual",
+	Long: `Converts a series to daily, weekly, monthly, quarterly, or annual observations.
+Weekly periods are ISO weeks dated by their Monday; daily periods are dated by
+the observation itself.
+
+When --freq is lower than the input's frequency the series is downsampled,
+aggregating each period with --method mean, first, last, or sum. Periods with
+no observations at all are left out unless --fill-missing is set, which emits
+a NaN row for each so the output is a complete calendar grid. When --freq is
+higher, the series is upsampled: --method ffill repeats each value until the
+next one, and --method linear steps evenly between them. Upsampling needs a
+series of detectable frequency, and a method that does not match the
+direction is rejected. Upsampling targets monthly, quarterly, or annual only.`,
 	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve transform resample --freq quarterly --method mean
-  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform resample --freq annual --method last`,
+  reserve obs get CPIAUCSL --from cache --format jsonl | reserve transform resample --freq annual --method last
+  reserve obs get DGS10 --from cache --format jsonl | reserve transform resample --freq monthly --method first --fill-missing
+  reserve obs get DGS10 --from cache --format jsonl | reserve transform resample --freq weekly --method mean
+  reserve obs get GDP --from cache --format jsonl | reserve transform resample --freq monthly --method ffill`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
 		if err != nil {
 			return err
 		}
-		out, err := transform.Resample(obs,
-			transform.ResampleFreq(transformResampleFreq),
-			transform.ResampleMethod(transformResampleMethod),
-		)
+		out, err := resampleObservations(obs, transform.ResampleFreq(transformResampleFreq), transformResampleMethod, transformResampleFillMissing)
 		if err != nil {
 			return err
 		}
@@ -170,9 +298,56 @@ var transformResampleCmd = &cobra.Command{
 	},
 }
 
+// resampleFreqRank orders frequencies from finest to coarsest. Irregular
+// series have no rank.
+var resampleFreqRank = map[string]int{
+	model.FrequencyDaily:     0,
+	model.FrequencyWeekly:    1,
+	model.FrequencyMonthly:   2,
+	model.FrequencyQuarterly: 3,
+	model.FrequencyAnnual:    4,
+}
+
+// resampleObservations downsamples obs with transform.Resample, or upsamples
+// it with transform.Upsample when freq is finer than the detected input
+// frequency. The method has to fit the direction. fillMissing only matters
+// when downsampling; upsampled output already covers every period.
+func resampleObservations(obs []model.Observation, freq transform.ResampleFreq, method string, fillMissing bool) ([]model.Observation, error) {
+	switch freq {
+	case transform.ResampleDaily, transform.ResampleWeekly,
+		transform.ResampleMonthly, transform.ResampleQuarterly, transform.ResampleAnnual:
+	default:
+		return nil, fmt.Errorf("--freq must be daily, weekly, monthly, quarterly, or annual, got %q", freq)
+	}
+	target := resampleFreqRank[string(freq)]
+	source, _ := model.DetectFrequency(obs)
+	sourceRank, known := resampleFreqRank[source]
+	upsample := method == transform.UpsampleFFill || method == transform.UpsampleLinear
+	fine := freq == transform.ResampleDaily || freq == transform.ResampleWeekly
+
+	switch {
+	case !upsample && known && sourceRank > target && fine:
+		return nil, fmt.Errorf("--freq %s is higher than the input's %s frequency, and %s output cannot be interpolated; resample to %s or coarser", freq, source, freq, source)
+	case !upsample && known && sourceRank > target:
+		return nil, fmt.Errorf("--freq %s is higher than the input's %s frequency; upsample with --method ffill or linear", freq, source)
+	case !upsample:
+		return transform.ResampleWithOptions(obs, freq, transform.ResampleMethod(method),
+			transform.ResampleOptions{FillMissing: fillMissing})
+	case fine:
+		return nil, fmt.Errorf("--method %s upsamples, which supports --freq monthly, quarterly, or annual, not %s", method, freq)
+	case !known:
+		return nil, fmt.Errorf("--method %s upsamples, but the input's frequency is %s; upsampling needs a regular series", method, source)
+	case sourceRank <= target:
+		return nil, fmt.Errorf("--method %s upsamples, but the input is %s, not lower than --freq %s; use --method mean, first, last, or sum", method, source, freq)
+	}
+	return transform.Upsample(obs, freq, method)
+}
+
 // ─── filter ───────────────────────────────────────────────────────────────────
 
 var (
+	transformFilterStart  string
+	transformFilterEnd    string
 	transformFilterAfter  string
 	transformFilterBefore string
 	transformFilterMin    float64
@@ -183,7 +358,14 @@ var (
 var transformFilterCmd = &cobra.Command{
 	Use:   "filter",
 	Short: "Filter observations by date range or value bounds",
-	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve transform filter --after 2020-01-01
+	Long: `Keeps the observations that pass every given bound.
+
+--start and --end are inclusive, like obs get and FRED's observation_start
+and observation_end: --start 2020-01-01 keeps the 2020-01-01 observation.
+--after and --before are exclusive and drop an observation dated exactly on
+the bound. Use one of each pair, not both.`,
+	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve transform filter --start 2020-01-01
+  reserve obs get UNRATE --from cache --format jsonl | reserve transform filter --after 2020-01-01 --end 2020-12-01
   reserve obs get GDP --from cache --format jsonl | reserve transform filter --min 20000 --max 25000`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
@@ -195,15 +377,8 @@ var transformFilterCmd = &cobra.Command{
 			MinValue:    math.NaN(),
 			MaxValue:    math.NaN(),
 		}
-		if transformFilterAfter != "" {
-			if opts.After, err = time.Parse("2006-01-02", transformFilterAfter); err != nil {
-				return fmt.Errorf("--after: invalid date %q", transformFilterAfter)
-			}
-		}
-		if transformFilterBefore != "" {
-			if opts.Before, err = time.Parse("2006-01-02", transformFilterBefore); err != nil {
-				return fmt.Errorf("--before: invalid date %q", transformFilterBefore)
-			}
+		if err := parseFilterDates(&opts, transformFilterStart, transformFilterEnd, transformFilterAfter, transformFilterBefore); err != nil {
+			return err
 		}
 		if cmd.Flags().Changed("min") {
 			opts.MinValue = transformFilterMin
@@ -216,6 +391,40 @@ var transformFilterCmd = &cobra.Command{
 	},
 }
 
+// parseFilterDates sets the date bounds on opts. The inclusive --start/--end
+// and exclusive --after/--before flags each set the same bound, so a pair
+// may not be combined.
+func parseFilterDates(opts *transform.FilterOptions, start, end, after, before string) error {
+	if start != "" && after != "" {
+		return fmt.Errorf("use --start (inclusive) or --after (exclusive), not both")
+	}
+	if end != "" && before != "" {
+		return fmt.Errorf("use --end (inclusive) or --before (exclusive), not both")
+	}
+	bounds := []struct {
+		flag, value string
+		date        *time.Time
+		inclusive   *bool
+		isInclusive bool
+	}{
+		{"--start", start, &opts.After, &opts.AfterInclusive, true},
+		{"--after", after, &opts.After, &opts.AfterInclusive, false},
+		{"--end", end, &opts.Before, &opts.BeforeInclusive, true},
+		{"--before", before, &opts.Before, &opts.BeforeInclusive, false},
+	}
+	for _, b := range bounds {
+		if b.value == "" {
+			continue
+		}
+		d, err := time.Parse("2006-01-02", b.value)
+		if err != nil {
+			return fmt.Errorf("%s: invalid date %q", b.flag, b.value)
+		}
+		*b.date, *b.inclusive = d, b.isInclusive
+	}
+	return nil
+}
+
 // ─── window roll ──────────────────────────────────────────────────────────────
 
 var windowCmd = &cobra.Command{
@@ -227,19 +436,29 @@ var (
 	windowRollWindow     int
 	windowRollMinPeriods int
 	windowRollStat       string
+	windowRollDDOF       int
+	windowRollAlpha      float64
 )
 
 var windowRollCmd = &cobra.Command{
 	Use:   "roll",
-	Short: "Rolling window statistic: mean, std, min, max, or sum",
+	Short: "Rolling window statistic: mean, median, std, var, min, max, sum, or ewm-mean",
 	Example: `  reserve obs get UNRATE --from cache --format jsonl | reserve window roll --stat mean --window 12
-  reserve obs get GDP --from cache --format jsonl | reserve window roll --stat std --window 4 --min-periods 2`,
+  reserve obs get GDP --from cache --format jsonl | reserve window roll --stat std --window 4 --min-periods 2
+  reserve obs get GDP --from cache --format jsonl | reserve window roll --stat std --window 4 --ddof 0
+  reserve obs get ICSA --from cache --format jsonl | reserve window roll --stat median --window 5
+  reserve obs get UNRATE --from cache --format jsonl | reserve window roll --stat ewm-mean --alpha 0.3 --window 12`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		seriesID, obs, citation, err := pipeline.ReadObservationsWithCitation(os.Stdin)
 		if err != nil {
 			return err
 		}
-		out, err := transform.Roll(obs, windowRollWindow, windowRollMinPeriods, transform.RollStat(windowRollStat))
+		stat := transform.RollStat(windowRollStat)
+		if cmd.Flags().Changed("alpha") && stat != transform.RollEWMMean {
+			return fmt.Errorf("--alpha requires --stat ewm-mean")
+		}
+		out, err := transform.RollWithOptions(obs, windowRollWindow, windowRollMinPeriods, stat,
+			transform.RollOptions{DDOF: windowRollDDOF, Alpha: windowRollAlpha})
 		if err != nil {
 			return err
 		}
@@ -252,10 +471,13 @@ var windowRollCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(transformCmd)
 	transformCmd.AddCommand(transformPctCmd)
+	transformCmd.AddCommand(transformAnnualizeCmd)
 	transformCmd.AddCommand(transformDiffCmd)
+	transformCmd.AddCommand(transformSeasonalDiffCmd)
 	transformCmd.AddCommand(transformLogCmd)
 	transformCmd.AddCommand(transformNormCmd)
 	transformCmd.AddCommand(transformIndexCmd)
+	transformCmd.AddCommand(transformRebaseCmd)
 	transformCmd.AddCommand(transformResampleCmd)
 	transformCmd.AddCommand(transformFilterCmd)
 
@@ -264,22 +486,34 @@ func init() {
 
 	// pct-change flags
 	transformPctCmd.Flags().IntVar(&transformPctPeriod, "period", 1, "lag period (1 = MoM, 12 = YoY)")
+	transformPctCmd.Flags().StringVar(&transformPctMethod, "method", string(transform.PctChangeStandard), "standard ((v[t]-v[t-N])/|v[t-N]|*100, in percent) or log (ln v[t] - ln v[t-N], as a fraction, not percent)")
+	transformPctCmd.Flags().BoolVar(&transformPctKeepLength, "keep-length", false, "emit NaN for the leading rows instead of dropping them")
+	transformAnnualizeCmd.Flags().IntVar(&transformAnnualizePeriods, "periods", 0, "periods per year, e.g. 12 for monthly rates (default: detected from date spacing)")
 
 	// diff flags
 	transformDiffCmd.Flags().IntVar(&transformDiffOrder, "order", 1, "difference order: 1 or 2")
 
+	// seasonal-diff flags
+	transformSeasonalDiffCmd.Flags().IntVar(&transformSeasonalLag, "lag", 12, "seasonal lag in observations (12 = monthly YoY, 4 = quarterly YoY)")
+
 	// normalize flags
-	transformNormCmd.Flags().StringVar(&transformNormMethod, "method", "zscore", "normalization method: zscore|minmax")
+	transformNormCmd.Flags().StringVar(&transformNormMethod, "method", "zscore", "normalization method: zscore|minmax|robust")
 
 	// index flags
 	transformIndexCmd.Flags().Float64Var(&transformIndexBase, "base", 100, "base value at anchor date")
 	transformIndexCmd.Flags().StringVar(&transformIndexAt, "at", "", "anchor date YYYY-MM-DD (required)")
 
+	// rebase flags
+	transformRebaseCmd.Flags().StringVar(&transformRebaseTo, "to", "first", "reference scaled to 100: first|last|max|min|mean")
+
 	// resample flags
-	transformResampleCmd.Flags().StringVar(&transformResampleFreq, "freq", "quarterly", "target frequency: monthly|quarterly|annual")
-	transformResampleCmd.Flags().StringVar(&transformResampleMethod, "method", "mean", "aggregation method: mean|last|sum")
+	transformResampleCmd.Flags().StringVar(&transformResampleFreq, "freq", "quarterly", "target frequency: daily|weekly|monthly|quarterly|annual")
+	transformResampleCmd.Flags().StringVar(&transformResampleMethod, "method", "mean", "mean|first|last|sum to downsample, ffill|linear to upsample")
+	transformResampleCmd.Flags().BoolVar(&transformResampleFillMissing, "fill-missing", false, "emit NaN rows for periods with no observations when downsampling")
 
 	// filter flags
+	transformFilterCmd.Flags().StringVar(&transformFilterStart, "start", "", "keep obs with date >= YYYY-MM-DD")
+	transformFilterCmd.Flags().StringVar(&transformFilterEnd, "end", "", "keep obs with date <= YYYY-MM-DD")
 	transformFilterCmd.Flags().StringVar(&transformFilterAfter, "after", "", "keep obs with date > YYYY-MM-DD")
 	transformFilterCmd.Flags().StringVar(&transformFilterBefore, "before", "", "keep obs with date < YYYY-MM-DD")
 	transformFilterCmd.Flags().Float64Var(&transformFilterMin, "min", 0, "keep obs with value >= min")
@@ -289,22 +523,34 @@ func init() {
 	// window roll flags
 	windowRollCmd.Flags().IntVar(&windowRollWindow, "window", 12, "window size (number of observations)")
 	windowRollCmd.Flags().IntVar(&windowRollMinPeriods, "min-periods", 1, "minimum non-NaN values required in window")
-	windowRollCmd.Flags().StringVar(&windowRollStat, "stat", "mean", "statistic: mean|std|min|max|sum")
+	windowRollCmd.Flags().StringVar(&windowRollStat, "stat", "mean", "statistic: mean|median|std|var|min|max|sum|ewm-mean")
+	windowRollCmd.Flags().IntVar(&windowRollDDOF, "ddof", 1, "with --stat std: 1 for sample std (n-1), 0 for population std (n)")
+	windowRollCmd.Flags().Float64Var(&windowRollAlpha, "alpha", 0.3, "with --stat ewm-mean: weight decay in (0, 1]; each earlier value weighs (1-alpha) times the next")
 }
 
 // ─── Output helper ────────────────────────────────────────────────────────────
 
-// writeTransformOutput writes obs to stdout in JSONL (pipeline) or table (terminal).
+// writeTransformOutput writes obs to stdout in JSONL (pipeline) or table
+// (terminal). --format csv writes plain date,value,value_raw rows at full
+// precision for spreadsheets.
 func writeTransformOutput(cmd *cobra.Command, seriesID string, obs []model.Observation, citation string) error {
 	format := resolveFormat("")
 	// If no explicit format and stdout is a terminal, use table
-	if globalFlags.Format == "" {
+	if globalFlags.Format == "" && globalFlags.Template == "" {
 		if pipeline.IsTTY() {
 			format = render.FormatTable
 		} else {
 			format = render.FormatJSONL
 		}
 	}
+	if format == render.FormatCSV {
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		return pipeline.WriteCSV(w, seriesID, obs)
+	}
 
 	result := buildSeriesDataResult("transform", &model.SeriesData{
 		SeriesID: seriesID,
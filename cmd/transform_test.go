@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/transform"
+	"github.com/spf13/cobra"
+)
+
+func quarterStarts(year, n int) []time.Time {
+	out := make([]time.Time, n)
+	for i := range out {
+		out[i] = time.Date(year, time.Month(3*i+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return out
+}
+
+func TestResampleObservationsRoutesByFrequency(t *testing.T) {
+	quarterly := compareSeries("GDP", quarterStarts(2020, 4), 100, 103, 106, 109).Obs
+	monthly := compareSeries("UNRATE", monthStarts(2020, 12), 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12).Obs
+
+	up, err := resampleObservations(quarterly, transform.ResampleMonthly, "linear", false)
+	if err != nil {
+		t.Fatalf("upsample: %v", err)
+	}
+	if len(up) != 10 || up[1].Value != 101 || up[9].Value != 109 {
+		t.Errorf("unexpected upsampled series %+v", up)
+	}
+
+	down, err := resampleObservations(monthly, transform.ResampleQuarterly, "sum", false)
+	if err != nil {
+		t.Fatalf("downsample: %v", err)
+	}
+	if len(down) != 4 || down[0].Value != 6 {
+		t.Errorf("unexpected downsampled series %+v", down)
+	}
+
+	cases := []struct {
+		obs    []model.Observation
+		freq   transform.ResampleFreq
+		method string
+		want   string
+	}{
+		{quarterly, transform.ResampleMonthly, "mean", "upsample with --method ffill or linear"},
+		{monthly, transform.ResampleQuarterly, "ffill", "not lower than --freq quarterly"},
+		{monthly, transform.ResampleMonthly, "ffill", "not lower than --freq monthly"},
+		{quarterly[:1], transform.ResampleMonthly, "ffill", "needs a regular series"},
+		{quarterly, "hourly", "ffill", "--freq must be"},
+		{monthly, transform.ResampleWeekly, "mean", "cannot be interpolated"},
+		{monthly, transform.ResampleWeekly, "ffill", "supports --freq monthly, quarterly, or annual"},
+	}
+	for _, tc := range cases {
+		_, err := resampleObservations(tc.obs, tc.freq, tc.method, false)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s → %s via %s: expected error containing %q, got %v", tc.obs[0].Date.Format("2006-01"), tc.freq, tc.method, tc.want, err)
+		}
+	}
+}
+
+func TestParseFilterDates(t *testing.T) {
+	var opts transform.FilterOptions
+	if err := parseFilterDates(&opts, "2020-01-01", "", "", "2020-12-01"); err != nil {
+		t.Fatal(err)
+	}
+	if !opts.AfterInclusive || opts.After.Format("2006-01-02") != "2020-01-01" {
+		t.Errorf("--start should set an inclusive lower bound, got %+v", opts)
+	}
+	if opts.BeforeInclusive || opts.Before.Format("2006-01-02") != "2020-12-01" {
+		t.Errorf("--before should set an exclusive upper bound, got %+v", opts)
+	}
+
+	for _, tc := range []struct{ start, end, after, before, want string }{
+		{"2020-01-01", "", "2019-01-01", "", "not both"},
+		{"", "2020-01-01", "", "2021-01-01", "not both"},
+		{"", "2020-13-01", "", "", "--end: invalid date"},
+	} {
+		err := parseFilterDates(&transform.FilterOptions{}, tc.start, tc.end, tc.after, tc.before)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("expected error containing %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestWriteTransformOutputCSV(t *testing.T) {
+	origFormat := globalFlags.Format
+	globalFlags.Format = render.FormatCSV
+	t.Cleanup(func() { globalFlags.Format = origFormat })
+
+	obs := []model.Observation{
+		{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 2.0 / 3, ValueRaw: "0.666667"},
+		{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+	}
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	if err := writeTransformOutput(cmd, "CPIAUCSL", obs, "Source: BLS"); err != nil {
+		t.Fatalf("writeTransformOutput: %v", err)
+	}
+	want := "date,value,value_raw\n2024-01-01,0.6666666666666666,0.666667\n2024-02-01,,.\n"
+	if buf.String() != want {
+		t.Errorf("csv:\n got  %q\n want %q", buf.String(), want)
+	}
+}
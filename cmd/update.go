@@ -22,6 +22,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/spf13/cobra"
 )
 
@@ -548,6 +549,8 @@ func renderUpdateCheck(cmd *cobra.Command, result updateCheckResult) error {
 		}
 		_, err = fmt.Fprintf(w, "%s\n", b)
 		return err
+	case render.FormatYAML:
+		return render.EncodeYAML(w, result)
 	default:
 		return renderUpdateCheckText(w, result)
 	}
@@ -577,6 +580,8 @@ func renderUpdateApply(cmd *cobra.Command, result updateApplyResult) error {
 		}
 		_, err = fmt.Fprintf(w, "%s\n", b)
 		return err
+	case render.FormatYAML:
+		return render.EncodeYAML(w, result)
 	default:
 		return renderUpdateApplyText(w, result)
 	}
@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/derickschaefer/reserve/internal/render"
 	"github.com/spf13/cobra"
 )
 
@@ -78,6 +79,9 @@ Examples:
 			fmt.Fprintf(cmd.OutOrStdout(), "%s\n", b)
 			return nil
 
+		case render.FormatYAML:
+			return render.EncodeYAML(cmd.OutOrStdout(), info)
+
 		default:
 			// Plain text — one value per line, grep/awk friendly.
 			fmt.Fprintf(cmd.OutOrStdout(), "reserve %s\n", info.Version)
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/render"
+	"github.com/derickschaefer/reserve/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+// watchMinInterval keeps polling well inside the FRED rate limit; no series
+// publishes more often than daily.
+const watchMinInterval = time.Minute
+
+var (
+	watchInterval time.Duration
+	watchNotify   string
+	watchSince    string
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <SERIES_ID>",
+	Short: "Poll a series and report each newly published observation",
+	Long: `Polls the latest observation of a series every --interval and reports each
+observation dated later than any seen before, until interrupted with Ctrl-C.
+
+The starting point is the newest cached observation of the series when the
+local cache has one; otherwise the first poll records the current latest date
+and only later publications are reported. --since reports any observation
+dated after that day, including one already published.
+
+A failed poll is reported on stderr and retried at the next interval; watch
+exits only when FRED rejects the request itself, as for an unknown series or
+a bad API key.
+
+--notify stdout prints one line per new observation (one JSONL row with
+--format jsonl). --notify email sends a message through the SMTP server in
+the "email" section of config.json; the password is read from
+RESERVE_SMTP_PASSWORD.`,
+	Example: `  reserve watch FEDFUNDS --interval 1h
+  reserve watch UNRATE --interval 6h --notify email
+  reserve watch CPIAUCSL --since 2026-09-01 --format jsonl`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSeriesIDs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if watchInterval < watchMinInterval {
+			return fmt.Errorf("--interval must be at least %s, got %s", watchMinInterval, watchInterval)
+		}
+		if watchNotify != "stdout" && watchNotify != "email" {
+			return fmt.Errorf("--notify must be stdout or email, got %q", watchNotify)
+		}
+		var opts watch.WatchOptions
+		opts.Interval = watchInterval
+		if watchSince != "" {
+			since, err := time.Parse("2006-01-02", watchSince)
+			if err != nil {
+				return fmt.Errorf("--since: invalid date %q, expected YYYY-MM-DD", watchSince)
+			}
+			opts.Since = since
+		}
+		deps, err := buildDeps()
+		if err != nil {
+			return err
+		}
+		if err := deps.Config.Validate(); err != nil {
+			return err
+		}
+		if watchNotify == "email" && !deps.Config.Email.Configured() {
+			return fmt.Errorf("--notify email needs email.smtp_addr, email.from, and email.to in config.json")
+		}
+		id := resolveSeriesIDs(deps, args)[0]
+		if _, err := ensureSeriesCompliance(cmd.Context(), deps, id, "display"); err != nil {
+			return err
+		}
+		if deps.Store != nil && opts.Since.IsZero() {
+			if obs, err := cachedObservations(deps, id); err == nil {
+				opts.Last = latestObservationDate(obs)
+			}
+		}
+
+		w, closeFn, err := outputWriter(cmd.OutOrStdout())
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+		format := resolveFormat(deps.Config.Format)
+		notify := func(o model.Observation) {
+			if err := writeWatchNotice(w, format, id, deps.Config.Now(), o); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "⚠  %s: %v\n", id, err)
+			}
+		}
+		if watchNotify == "email" {
+			e := deps.Config.Email
+			mailer := watch.Mailer{Addr: e.SMTPAddr, From: e.From, To: e.To, Username: e.Username, Password: e.Password}
+			notify = func(o model.Observation) {
+				subject := fmt.Sprintf("reserve: new %s observation for %s", id, o.Date.Format("2006-01-02"))
+				if err := mailer.Send(subject, watchNoticeText(deps.Config.Now(), id, o)); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "⚠  %s: %v\n", id, err)
+				}
+			}
+		}
+
+		if !deps.Config.Quiet {
+			known := "first poll"
+			if !opts.Last.IsZero() {
+				known = opts.Last.Format("2006-01-02")
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Watching %s every %s (latest known: %s). Press Ctrl-C to stop.\n", id, watchInterval, known)
+		}
+		opts.OnError = func(err error) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "⚠  %s: poll failed, retrying next interval: %v\n", id, err)
+		}
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return watch.Watch(ctx, deps.Client, id, opts, notify)
+	},
+}
+
+// latestObservationDate is the date of the last non-NaN observation, or zero.
+func latestObservationDate(obs []model.Observation) time.Time {
+	var last time.Time
+	for _, o := range obs {
+		if !math.IsNaN(o.Value) && o.Date.After(last) {
+			last = o.Date
+		}
+	}
+	return last
+}
+
+// watchNoticeText is a notice line stamped with at, which callers take from
+// Config.Now so the stamp is in the observation timezone.
+func watchNoticeText(at time.Time, id string, o model.Observation) string {
+	return fmt.Sprintf("%s  %s new observation %s = %s", at.Format(time.RFC3339), id, o.Date.Format("2006-01-02"), o.ValueRaw)
+}
+
+// writeWatchNotice writes one notice line: a JSONL observation row for the
+// machine formats and a timestamped line otherwise.
+func writeWatchNotice(w io.Writer, format, id string, at time.Time, o model.Observation) error {
+	switch format {
+	case render.FormatJSON, render.FormatJSONL:
+		return render.Render(w, &model.Result{
+			Kind:        model.KindSeriesData,
+			GeneratedAt: at,
+			Command:     "watch " + id,
+			Data:        &model.SeriesData{SeriesID: id, Obs: []model.Observation{o}},
+			Stats:       model.ResultStats{Items: 1},
+		}, render.FormatJSONL)
+	}
+	_, err := fmt.Fprintln(w, watchNoticeText(at, id, o))
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", time.Hour, "time between polls (at least 1m)")
+	watchCmd.Flags().StringVar(&watchNotify, "notify", "stdout", "where to report new observations: stdout|email")
+	watchCmd.Flags().StringVar(&watchSince, "since", "", "report only observations dated after YYYY-MM-DD, including one already published")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+func setWatchFlags(t *testing.T, interval time.Duration, notify string) {
+	t.Helper()
+	origInterval, origNotify, origSince := watchInterval, watchNotify, watchSince
+	watchInterval, watchNotify, watchSince = interval, notify, ""
+	t.Cleanup(func() { watchInterval, watchNotify, watchSince = origInterval, origNotify, origSince })
+}
+
+func TestWatchRejectsShortInterval(t *testing.T) {
+	setWatchFlags(t, 10*time.Second, "stdout")
+	err := watchCmd.RunE(watchCmd, []string{"FEDFUNDS"})
+	if err == nil || !strings.Contains(err.Error(), "--interval") {
+		t.Fatalf("expected --interval error, got %v", err)
+	}
+}
+
+func TestWatchEmailNeedsConfig(t *testing.T) {
+	isolateBuildDepsConfig(t)
+	t.Setenv(config.EnvAPIKey, "abcdefghijklmnopqrstuvwxyz123456")
+	setWatchFlags(t, time.Hour, "email")
+	err := watchCmd.RunE(watchCmd, []string{"FEDFUNDS"})
+	if err == nil || !strings.Contains(err.Error(), "email.smtp_addr") {
+		t.Fatalf("expected email config error, got %v", err)
+	}
+}
+
+func TestWriteWatchNotice(t *testing.T) {
+	o := model.Observation{Date: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Value: 4.08, ValueRaw: "4.08"}
+	at := time.Date(2026, 10, 16, 14, 0, 3, 0, time.FixedZone("EDT", -4*3600))
+
+	var buf bytes.Buffer
+	if err := writeWatchNotice(&buf, "table", "FEDFUNDS", at, o); err != nil {
+		t.Fatalf("writeWatchNotice: %v", err)
+	}
+	if buf.String() != "2026-10-16T14:00:03-04:00  FEDFUNDS new observation 2026-09-01 = 4.08\n" {
+		t.Errorf("unexpected notice %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := writeWatchNotice(&buf, "jsonl", "FEDFUNDS", at, o); err != nil {
+		t.Fatalf("writeWatchNotice jsonl: %v", err)
+	}
+	var row map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &row); err != nil {
+		t.Fatalf("expected one JSONL row: %v\n%s", err, buf.String())
+	}
+	if row["series_id"] != "FEDFUNDS" || row["date"] != "2026-09-01" || row["value"] != 4.08 {
+		t.Errorf("unexpected row %v", row)
+	}
+}
+
+func TestLatestObservationDateSkipsMissing(t *testing.T) {
+	obs := []model.Observation{
+		{Date: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Value: 4.33},
+		{Date: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN()},
+	}
+	if got := latestObservationDate(obs).Format("2006-01-02"); got != "2026-07-01" {
+		t.Errorf("latestObservationDate = %s, want 2026-07-01", got)
+	}
+}
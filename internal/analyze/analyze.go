@@ -2,50 +2,125 @@
 // Licensed under the MIT License. See LICENSE file for details.
 
 // Package analyze computes statistical summaries and trend analysis over
-// slices of Observations. All functions are pure; no I/O.
+// slices of Observations. All functions are pure except StreamSummarize and
+// StreamSummarizeDDOF, which read a JSONL stream.
 package analyze
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/pipeline"
+	"github.com/derickschaefer/reserve/internal/transform"
 )
 
 // ─── Summary ──────────────────────────────────────────────────────────────────
 
 // Summary holds descriptive statistics for a series.
 type Summary struct {
-	AnalysisVersion string   `json:"analysis_version"`
-	SeriesID        string   `json:"series_id"`
-	CitationText    string   `json:"citation_text,omitempty"`
-	SourceName      string   `json:"source_name,omitempty"`
-	SourceNames     []string `json:"source_names,omitempty"`
-	StartDate       string   `json:"start_date,omitempty"`
-	EndDate         string   `json:"end_date,omitempty"`
-	Count           int      `json:"count"` // total observations
-	NObs            int      `json:"n_obs"` // alias for count in machine-consumption pipelines
-	MissingCount    int      `json:"missing_count"`
-	MissingPct      float64  `json:"missing_pct"` // percent missing
-	Mean            float64  `json:"mean"`
-	Std             float64  `json:"std"`
-	Min             float64  `json:"min"`
-	P25             float64  `json:"p25"`
-	Median          float64  `json:"median"`
-	P75             float64  `json:"p75"`
-	Max             float64  `json:"max"`
-	Skew            float64  `json:"skew"`
-	First           float64  `json:"first"`      // first non-NaN value
-	Last            float64  `json:"last"`       // last non-NaN value
-	Change          float64  `json:"change"`     // Last - First
-	ChangePct       float64  `json:"change_pct"` // (Last-First)/First * 100
+	AnalysisVersion string       `json:"analysis_version"`
+	SeriesID        string       `json:"series_id"`
+	CitationText    string       `json:"citation_text,omitempty"`
+	SourceName      string       `json:"source_name,omitempty"`
+	SourceNames     []string     `json:"source_names,omitempty"`
+	StartDate       string       `json:"start_date,omitempty"`
+	EndDate         string       `json:"end_date,omitempty"`
+	Frequency       string       `json:"frequency,omitempty"`
+	Period          string       `json:"period,omitempty"`
+	Count           int          `json:"count"` // total observations
+	NObs            int          `json:"n_obs"` // alias for count in machine-consumption pipelines
+	MissingCount    int          `json:"missing_count"`
+	MissingPct      float64      `json:"missing_pct"` // percent missing
+	Mean            float64      `json:"mean"`
+	Std             float64      `json:"std"`
+	Min             float64      `json:"min"`
+	P25             float64      `json:"p25"`
+	Median          float64      `json:"median"`
+	P75             float64      `json:"p75"`
+	Max             float64      `json:"max"`
+	Skew            float64      `json:"skew"`
+	MAD             float64      `json:"mad"`                   // median absolute deviation from the median, unscaled
+	IQR             float64      `json:"iqr"`                   // P75 - P25
+	TrimmedMean     float64      `json:"trimmed_mean"`          // mean after dropping the lowest and highest 10%
+	First           float64      `json:"first"`                 // first non-NaN value
+	Last            float64      `json:"last"`                  // last non-NaN value
+	Change          float64      `json:"change"`                // Last - First
+	ChangePct       float64      `json:"change_pct"`            // (Last-First)/First * 100
+	CAGR            float64      `json:"cagr"`                  // (Last/First)^(1/years) - 1, as a fraction; NaN (null in JSON) if First <= 0 or under a year
+	Percentiles     []Percentile `json:"percentiles,omitempty"` // requested cut points, in request order
+	Spark           string       `json:"spark,omitempty"`       // one-line sparkline, set by callers that request one
+}
+
+// Percentile is one requested cut point of a Summary.
+type Percentile struct {
+	P     float64 `json:"p"` // 0-100
+	Value float64 `json:"value"`
+}
+
+// Label names the cut point for table headers: P5, P50, P99.5.
+func (p Percentile) Label() string {
+	return "P" + strconv.FormatFloat(p.P, 'f', -1, 64)
+}
+
+// MarshalJSON encodes a NaN value, from an all-missing series, as null.
+func (p Percentile) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		P     float64  `json:"p"`
+		Value *float64 `json:"value"`
+	}{p.P, nanToNil(p.Value)})
+}
+
+// MarshalJSON encodes NaN statistics as null. CAGR is undefined for any
+// series under a year long, and every statistic is NaN for an all-missing
+// series, so these are routine rather than errors.
+func (s Summary) MarshalJSON() ([]byte, error) {
+	type plain Summary
+	return json.Marshal(struct {
+		plain
+		Mean        *float64 `json:"mean"`
+		Std         *float64 `json:"std"`
+		Min         *float64 `json:"min"`
+		P25         *float64 `json:"p25"`
+		Median      *float64 `json:"median"`
+		P75         *float64 `json:"p75"`
+		Max         *float64 `json:"max"`
+		Skew        *float64 `json:"skew"`
+		MAD         *float64 `json:"mad"`
+		IQR         *float64 `json:"iqr"`
+		TrimmedMean *float64 `json:"trimmed_mean"`
+		First       *float64 `json:"first"`
+		Last        *float64 `json:"last"`
+		Change      *float64 `json:"change"`
+		ChangePct   *float64 `json:"change_pct"`
+		CAGR        *float64 `json:"cagr"`
+	}{
+		plain(s),
+		nanToNil(s.Mean), nanToNil(s.Std), nanToNil(s.Min), nanToNil(s.P25),
+		nanToNil(s.Median), nanToNil(s.P75), nanToNil(s.Max), nanToNil(s.Skew),
+		nanToNil(s.MAD), nanToNil(s.IQR), nanToNil(s.TrimmedMean),
+		nanToNil(s.First), nanToNil(s.Last), nanToNil(s.Change), nanToNil(s.ChangePct),
+		nanToNil(s.CAGR),
+	})
 }
 
 // Summarize computes descriptive statistics over obs.
 // NaN values are excluded from all numeric computations but counted.
-func Summarize(seriesID string, obs []model.Observation) Summary {
+// Each of cuts (0-100) adds an entry to Percentiles; P25, Median, and P75
+// are always computed. Std is the sample standard deviation.
+func Summarize(seriesID string, obs []model.Observation, cuts ...float64) Summary {
+	return SummarizeDDOF(seriesID, obs, 1, cuts...)
+}
+
+// SummarizeDDOF is Summarize with Std computed over n-ddof degrees of
+// freedom, so ddof 0 gives the population standard deviation. Skew keeps its
+// sample-adjusted definition either way.
+func SummarizeDDOF(seriesID string, obs []model.Observation, ddof int, cuts ...float64) Summary {
 	s := Summary{
 		AnalysisVersion: "1.0",
 		SeriesID:        seriesID,
@@ -75,6 +150,7 @@ func Summarize(seriesID string, obs []model.Observation) Summary {
 	if s.Count > 0 {
 		s.MissingPct = float64(s.MissingCount) / float64(s.Count) * 100
 	}
+	s.Frequency, _ = model.DetectFrequency(obs)
 	if len(vals) == 0 {
 		s.Mean = math.NaN()
 		s.Std = math.NaN()
@@ -84,10 +160,15 @@ func Summarize(seriesID string, obs []model.Observation) Summary {
 		s.P25 = math.NaN()
 		s.P75 = math.NaN()
 		s.Skew = math.NaN()
+		s.MAD = math.NaN()
+		s.IQR = math.NaN()
+		s.TrimmedMean = math.NaN()
 		s.First = math.NaN()
 		s.Last = math.NaN()
 		s.Change = math.NaN()
 		s.ChangePct = math.NaN()
+		s.CAGR = math.NaN()
+		s.Percentiles = Percentiles(nil, cuts)
 		return s
 	}
 
@@ -99,22 +180,27 @@ func Summarize(seriesID string, obs []model.Observation) Summary {
 	s.Min = sorted[0]
 	s.Max = sorted[len(sorted)-1]
 	s.Mean = sumF(vals) / float64(len(vals))
-	s.Std = stddevF(vals, s.Mean)
-	s.Median = percentile(sorted, 50)
-	s.P25 = percentile(sorted, 25)
-	s.P75 = percentile(sorted, 75)
-	s.Skew = skewness(vals, s.Mean, s.Std)
+	s.Std = stddevF(vals, s.Mean, ddof)
+	s.Median = Quantile(sorted, 50)
+	s.P25 = Quantile(sorted, 25)
+	s.P75 = Quantile(sorted, 75)
+	s.Percentiles = Percentiles(sorted, cuts)
+	s.Skew = skewness(vals, s.Mean, stddevF(vals, s.Mean, 1))
+	s.IQR = s.P75 - s.P25
+	s.MAD = medianAbsDeviation(sorted, s.Median)
+	s.TrimmedMean = trimmedMean(sorted, 0.10)
 
 	// First and last non-NaN values in original order
+	var firstDate, lastDate time.Time
 	for _, o := range obs {
 		if !math.IsNaN(o.Value) {
-			s.First = o.Value
+			s.First, firstDate = o.Value, o.Date
 			break
 		}
 	}
 	for i := len(obs) - 1; i >= 0; i-- {
 		if !math.IsNaN(obs[i].Value) {
-			s.Last = obs[i].Value
+			s.Last, lastDate = obs[i].Value, obs[i].Date
 			break
 		}
 	}
@@ -124,18 +210,271 @@ func Summarize(seriesID string, obs []model.Observation) Summary {
 	} else {
 		s.ChangePct = math.NaN()
 	}
+	s.CAGR = cagr(s.First, s.Last, lastDate.Sub(firstDate).Hours()/24/365.25)
 
 	return s
 }
 
+// cagr returns the compound annual growth rate from first to last over years.
+// It is NaN when first is not positive or the span is under a year, where an
+// annualized rate is either undefined or dominated by noise.
+func cagr(first, last, years float64) float64 {
+	if first <= 0 || years < 1 {
+		return math.NaN()
+	}
+	return math.Pow(last/first, 1/years) - 1
+}
+
+// ─── Streaming summary ────────────────────────────────────────────────────────
+
+// sketchAccuracy is the relative accuracy of the quantile sketch a streaming
+// summary keeps: every quantile it reports is within 0.1% of the exact one.
+const sketchAccuracy = 0.001
+
+// streamFrequencyObs is how many leading observations a streaming summary
+// keeps to detect the series frequency.
+const streamFrequencyObs = 256
+
+// StreamSummarize reads a JSONL observation stream from r and summarizes it
+// without holding the observations in memory. See StreamSummarizeDDOF.
+func StreamSummarize(r io.Reader) (*Summary, error) {
+	return StreamSummarizeDDOF(r, 1)
+}
+
+// StreamSummarizeDDOF is SummarizeDDOF over a JSONL stream read one row at a
+// time. Count, missing, mean, std, skew, min, max, first, last, and the
+// change statistics are exact (up to floating-point rounding); mean and std
+// use Welford's online algorithm. P25, median, P75, IQR, and each of cuts
+// come from a log-bucketed sketch and are within 0.1% of the exact value.
+// MAD and the trimmed mean need every value at once and are NaN. Frequency
+// is detected from the first 256 observations.
+func StreamSummarizeDDOF(r io.Reader, ddof int, cuts ...float64) (*Summary, error) {
+	acc := newStreamSummary(ddof)
+	seriesID, prov, err := pipeline.ScanObservations(r, func(o model.Observation) error {
+		acc.add(o)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s := acc.summary(seriesID, cuts)
+	s.CitationText = prov.CitationText
+	s.SourceName = prov.SourceName
+	s.SourceNames = prov.SourceNames
+	return &s, nil
+}
+
+// streamSummary accumulates the running statistics of StreamSummarizeDDOF.
+type streamSummary struct {
+	ddof                int
+	count, missing, n   int
+	mean, m2, m3        float64
+	min, max            float64
+	first, last         float64
+	firstDate, lastDate time.Time
+	startDate, endDate  time.Time
+	head                []model.Observation
+	sketch              *quantileSketch
+}
+
+func newStreamSummary(ddof int) *streamSummary {
+	return &streamSummary{ddof: ddof, sketch: newQuantileSketch(sketchAccuracy)}
+}
+
+func (a *streamSummary) add(o model.Observation) {
+	a.count++
+	if len(a.head) < streamFrequencyObs {
+		a.head = append(a.head, o)
+	}
+	if a.count == 1 || o.Date.Before(a.startDate) {
+		a.startDate = o.Date
+	}
+	if a.count == 1 || o.Date.After(a.endDate) {
+		a.endDate = o.Date
+	}
+	if math.IsNaN(o.Value) {
+		a.missing++
+		return
+	}
+	v := o.Value
+	if a.n == 0 {
+		a.min, a.max = v, v
+		a.first, a.firstDate = v, o.Date
+	}
+	a.min, a.max = math.Min(a.min, v), math.Max(a.max, v)
+	a.last, a.lastDate = v, o.Date
+	a.sketch.add(v)
+
+	// Welford's update, extended to the third central moment for skew.
+	n1 := float64(a.n)
+	a.n++
+	n := float64(a.n)
+	delta := v - a.mean
+	deltaN := delta / n
+	term := delta * deltaN * n1
+	a.mean += deltaN
+	a.m3 += term*deltaN*(n-2) - 3*deltaN*a.m2
+	a.m2 += term
+}
+
+func (a *streamSummary) summary(seriesID string, cuts []float64) Summary {
+	s := Summary{
+		AnalysisVersion: "1.0",
+		SeriesID:        seriesID,
+		Count:           a.count,
+		NObs:            a.count,
+		MissingCount:    a.missing,
+	}
+	if a.count == 0 {
+		return s
+	}
+	s.StartDate = a.startDate.Format("2006-01-02")
+	s.EndDate = a.endDate.Format("2006-01-02")
+	s.MissingPct = float64(a.missing) / float64(a.count) * 100
+	s.Frequency, _ = model.DetectFrequency(a.head)
+	s.MAD = math.NaN()
+	s.TrimmedMean = math.NaN()
+	if a.n == 0 {
+		s.Mean, s.Std, s.Min, s.Max = math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		s.Median, s.P25, s.P75, s.Skew, s.IQR = math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		s.First, s.Last, s.Change, s.ChangePct, s.CAGR = math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		s.Percentiles = Percentiles(nil, cuts)
+		return s
+	}
+
+	n := float64(a.n)
+	s.Min, s.Max = a.min, a.max
+	s.Mean = a.mean
+	if a.n >= 2 {
+		s.Std = math.Sqrt(a.m2 / (n - float64(a.ddof)))
+	}
+	if sd := math.Sqrt(a.m2 / (n - 1)); a.n >= 3 && sd != 0 {
+		s.Skew = a.m3 / (sd * sd * sd) * n / ((n - 1) * (n - 2))
+	}
+	s.P25 = a.quantile(25)
+	s.Median = a.quantile(50)
+	s.P75 = a.quantile(75)
+	s.IQR = s.P75 - s.P25
+	if len(cuts) > 0 {
+		s.Percentiles = make([]Percentile, len(cuts))
+		for i, p := range cuts {
+			s.Percentiles[i] = Percentile{P: p, Value: a.quantile(p)}
+		}
+	}
+
+	s.First, s.Last = a.first, a.last
+	s.Change = s.Last - s.First
+	if s.First != 0 {
+		s.ChangePct = s.Change / math.Abs(s.First) * 100
+	} else {
+		s.ChangePct = math.NaN()
+	}
+	s.CAGR = cagr(s.First, s.Last, a.lastDate.Sub(a.firstDate).Hours()/24/365.25)
+	return s
+}
+
+// quantile is Quantile over the sketch, clamped to the exact min and max so
+// the extremes are never widened by bucket rounding.
+func (a *streamSummary) quantile(p float64) float64 {
+	return math.Max(a.min, math.Min(a.max, a.sketch.quantile(p)))
+}
+
+// quantileSketch is a log-bucketed histogram in the style of DDSketch. A
+// nonzero value v is counted in bucket ceil(log_gamma |v|) of its sign, and
+// reading a bucket back gives a value within the sketch's relative accuracy
+// of everything counted in it. Memory grows with the log of the value range,
+// not with the number of values.
+type quantileSketch struct {
+	gamma, logGamma float64
+	pos, neg        map[int]int
+	zeros, n        int
+}
+
+func newQuantileSketch(accuracy float64) *quantileSketch {
+	gamma := (1 + accuracy) / (1 - accuracy)
+	return &quantileSketch{gamma: gamma, logGamma: math.Log(gamma), pos: map[int]int{}, neg: map[int]int{}}
+}
+
+func (q *quantileSketch) add(v float64) {
+	q.n++
+	switch {
+	case v > 0:
+		q.pos[q.bucket(v)]++
+	case v < 0:
+		q.neg[q.bucket(-v)]++
+	default:
+		q.zeros++
+	}
+}
+
+func (q *quantileSketch) bucket(v float64) int {
+	return int(math.Ceil(math.Log(v) / q.logGamma))
+}
+
+// bucketValue is the point of bucket i whose relative distance to both of
+// the bucket's bounds is the sketch accuracy.
+func (q *quantileSketch) bucketValue(i int) float64 {
+	return 2 * math.Pow(q.gamma, float64(i)) / (q.gamma + 1)
+}
+
+// quantile interpolates between the two nearest ranks, as Quantile does.
+func (q *quantileSketch) quantile(p float64) float64 {
+	if q.n == 0 || math.IsNaN(p) {
+		return math.NaN()
+	}
+	p = math.Max(0, math.Min(100, p))
+	idx := p / 100 * float64(q.n-1)
+	lo := int(idx)
+	frac := idx - float64(lo)
+	if frac == 0 || lo+1 >= q.n {
+		return q.valueAtRank(lo)
+	}
+	return q.valueAtRank(lo)*(1-frac) + q.valueAtRank(lo+1)*frac
+}
+
+// valueAtRank returns the approximate value of 0-based rank k in ascending
+// order: negative buckets from the largest magnitude down, then zeros, then
+// positive buckets from the smallest up.
+func (q *quantileSketch) valueAtRank(k int) float64 {
+	negKeys := sortedKeys(q.neg)
+	for i := len(negKeys) - 1; i >= 0; i-- {
+		if k < q.neg[negKeys[i]] {
+			return -q.bucketValue(negKeys[i])
+		}
+		k -= q.neg[negKeys[i]]
+	}
+	if k < q.zeros {
+		return 0
+	}
+	k -= q.zeros
+	posKeys := sortedKeys(q.pos)
+	for _, b := range posKeys {
+		if k < q.pos[b] {
+			return q.bucketValue(b)
+		}
+		k -= q.pos[b]
+	}
+	return q.bucketValue(posKeys[len(posKeys)-1])
+}
+
+func sortedKeys(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
 // ─── Trend ────────────────────────────────────────────────────────────────────
 
 // TrendMethod selects the regression algorithm.
 type TrendMethod string
 
 const (
-	TrendLinear   TrendMethod = "linear"
-	TrendTheilSen TrendMethod = "theil-sen"
+	TrendLinear     TrendMethod = "linear"
+	TrendTheilSen   TrendMethod = "theil-sen"
+	TrendPolynomial TrendMethod = "poly"
 )
 
 // TrendResult holds the output of a trend analysis.
@@ -145,7 +484,8 @@ type TrendResult struct {
 	SourceName   string           `json:"source_name,omitempty"`
 	SourceNames  []string         `json:"source_names,omitempty"`
 	Method       TrendMethod      `json:"method"`
-	Slope        float64          `json:"slope"` // units per day
+	Date         string           `json:"date,omitempty"` // window end date, set by RollingTrend
+	Slope        float64          `json:"slope"`          // units per day
 	Intercept    float64          `json:"intercept"`
 	R2           float64          `json:"r2"`
 	Direction    string           `json:"direction"`      // "up", "down", "flat"
@@ -153,8 +493,30 @@ type TrendResult struct {
 	Confidence   *TrendConfidence `json:"confidence,omitempty"`
 }
 
+// PolyTrendResult holds a least-squares polynomial trend. Coefficients[k]
+// multiplies x^k, where x is days since the first non-NaN observation, as
+// with TrendResult.Slope.
+type PolyTrendResult struct {
+	SeriesID     string      `json:"series_id"`
+	CitationText string      `json:"citation_text,omitempty"`
+	SourceName   string      `json:"source_name,omitempty"`
+	SourceNames  []string    `json:"source_names,omitempty"`
+	Method       TrendMethod `json:"method"`
+	Degree       int         `json:"degree"`
+	Coefficients []float64   `json:"coefficients"` // constant term first
+	R2           float64     `json:"r2"`
+	Curvature    string      `json:"curvature"` // "convex", "concave", or "none"
+}
+
+// TrendConfidence reports how far a fitted slope is from zero. For linear
+// trends the test is the OLS t-test with n-2 degrees of freedom. For
+// Theil-Sen trends it is Mann-Kendall: SlopeTStat is the Kendall Z score, the
+// interval is Sen's distribution-free one, and SlopeStdErr is the interval's
+// half-width over 1.96.
 type TrendConfidence struct {
+	Test              string  `json:"test"` // "t" or "mann-kendall"
 	SlopeStdErr       float64 `json:"slope_stderr"`
+	SlopeTStat        float64 `json:"slope_t_stat"`
 	SlopePValue       float64 `json:"slope_p_value"`
 	SlopeCI95Low      float64 `json:"slope_ci95_low"`
 	SlopeCI95High     float64 `json:"slope_ci95_high"`
@@ -258,33 +620,202 @@ func Trend(seriesID string, obs []model.Observation, method TrendMethod) (TrendR
 	return tr, nil
 }
 
-// AddTrendConfidence computes confidence metadata for linear OLS trend results.
-// For unsupported methods, it returns nil.
-func AddTrendConfidence(tr TrendResult, obs []model.Observation) *TrendConfidence {
-	if tr.Method != TrendLinear {
-		return nil
+// RollingTrend fits a trend over each trailing window of window observations
+// and returns one result per observation, dated at the window's last
+// observation. NaN observations count toward the window but are left out of
+// the fit. The first window-1 results, and any window with fewer than 2
+// non-NaN observations, have NaN slope, intercept, and R² and no direction.
+func RollingTrend(seriesID string, obs []model.Observation, window int, method TrendMethod) ([]TrendResult, error) {
+	if window < 2 {
+		return nil, fmt.Errorf("rolling trend: window must be >= 2, got %d", window)
+	}
+	if method == TrendPolynomial {
+		return nil, fmt.Errorf("rolling trend: method %q is not supported", method)
+	}
+	if len(obs) < window {
+		return nil, fmt.Errorf("rolling trend: need at least %d observations, got %d", window, len(obs))
+	}
+	out := make([]TrendResult, len(obs))
+	for i, o := range obs {
+		tr := TrendResult{SeriesID: seriesID, Method: method}
+		var err error
+		if i+1 >= window {
+			tr, err = Trend(seriesID, obs[i+1-window:i+1], method)
+		}
+		if i+1 < window || err != nil {
+			nan := math.NaN()
+			tr.Slope, tr.Intercept, tr.R2, tr.SlopePerYear, tr.Direction = nan, nan, nan, nan, ""
+		}
+		tr.Date = o.Date.Format("2006-01-02")
+		out[i] = tr
+	}
+	return out, nil
+}
+
+// TrendPoly fits a polynomial of degree 2 or 3 to the observations by least
+// squares. As in Trend, x is days since the first non-NaN observation and NaN
+// observations are excluded. Curvature is the sign of the second derivative
+// at the middle of the fitted range, which for degree 2 is the same
+// everywhere.
+func TrendPoly(obs []model.Observation, degree int) (PolyTrendResult, error) {
+	tr := PolyTrendResult{Method: TrendPolynomial, Degree: degree}
+	if degree < 2 || degree > 3 {
+		return tr, fmt.Errorf("trend: poly degree must be 2 or 3, got %d", degree)
+	}
+	pts := trendPoints(obs)
+	if len(pts) < degree+2 {
+		return tr, fmt.Errorf("trend: degree %d needs at least %d non-NaN observations, got %d", degree, degree+2, len(pts))
+	}
+
+	// Solve the normal equations on x rescaled to [0, 1]; raw day counts
+	// raised to the sixth power swamp float64 precision.
+	var span float64
+	for _, p := range pts {
+		span = math.Max(span, math.Abs(p.x))
+	}
+	if span == 0 {
+		return tr, fmt.Errorf("trend: observations span no time")
+	}
+	m := degree + 1
+	a := make([][]float64, m)
+	for i := range a {
+		a[i] = make([]float64, m+1)
+	}
+	for _, p := range pts {
+		u := p.x / span
+		pow := make([]float64, 2*m-1)
+		pow[0] = 1
+		for k := 1; k < len(pow); k++ {
+			pow[k] = pow[k-1] * u
+		}
+		for i := 0; i < m; i++ {
+			for j := 0; j < m; j++ {
+				a[i][j] += pow[i+j]
+			}
+			a[i][m] += pow[i] * p.y
+		}
+	}
+	scaled, err := solveLinear(a)
+	if err != nil {
+		return tr, fmt.Errorf("trend: poly fit: %w", err)
 	}
+	tr.Coefficients = make([]float64, m)
+	for k, c := range scaled {
+		tr.Coefficients[k] = c / math.Pow(span, float64(k))
+	}
+
+	var yMean float64
+	for _, p := range pts {
+		yMean += p.y
+	}
+	yMean /= float64(len(pts))
+	var ssTot, ssRes float64
+	for _, p := range pts {
+		d := p.y - polyEval(scaled, p.x/span)
+		ssRes += d * d
+		ssTot += (p.y - yMean) * (p.y - yMean)
+	}
+	tr.R2 = 1
+	if ssTot > 0 {
+		tr.R2 = math.Max(0, math.Min(1, 1-ssRes/ssTot))
+	}
+
+	// Second derivative in scaled units at u = 0.5; its sign is unchanged
+	// by the rescaling.
+	second := 2 * scaled[2]
+	if degree == 3 {
+		second += 6 * scaled[3] * 0.5
+	}
+	switch {
+	case second > 0:
+		tr.Curvature = "convex"
+	case second < 0:
+		tr.Curvature = "concave"
+	default:
+		tr.Curvature = "none"
+	}
+	return tr, nil
+}
+
+// trendPoints pairs each non-NaN value with its days since the first non-NaN
+// observation, the x axis every trend fit uses.
+func trendPoints(obs []model.Observation) []point {
 	var pts []point
 	var t0 int64
-	first := true
 	for _, o := range obs {
 		if math.IsNaN(o.Value) {
 			continue
 		}
-		unix := o.Date.Unix()
-		if first {
-			t0 = unix
-			first = false
+		if len(pts) == 0 {
+			t0 = o.Date.Unix()
 		}
-		x := float64(unix-t0) / 86400
-		pts = append(pts, point{x, o.Value})
+		pts = append(pts, point{float64(o.Date.Unix()-t0) / 86400, o.Value})
 	}
+	return pts
+}
+
+// polyEval evaluates the polynomial with coefficients c (constant first) at x.
+func polyEval(c []float64, x float64) float64 {
+	var y float64
+	for k := len(c) - 1; k >= 0; k-- {
+		y = y*x + c[k]
+	}
+	return y
+}
+
+// solveLinear solves the n×n system held in the augmented matrix a by
+// Gaussian elimination with partial pivoting. a is overwritten.
+func solveLinear(a [][]float64) ([]float64, error) {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, fmt.Errorf("singular system; too few distinct dates")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for r := col + 1; r < n; r++ {
+			f := a[r][col] / a[col][col]
+			for c := col; c <= n; c++ {
+				a[r][c] -= f * a[col][c]
+			}
+		}
+	}
+	x := make([]float64, n)
+	for r := n - 1; r >= 0; r-- {
+		sum := a[r][n]
+		for c := r + 1; c < n; c++ {
+			sum -= a[r][c] * x[c]
+		}
+		x[r] = sum / a[r][r]
+	}
+	return x, nil
+}
+
+// AddTrendConfidence computes the significance and 95% confidence interval of
+// a linear or Theil-Sen trend slope. It returns nil for other methods and for
+// fewer than 3 observations.
+func AddTrendConfidence(tr TrendResult, obs []model.Observation) *TrendConfidence {
+	pts := trendPoints(obs)
 	if len(pts) < 3 {
 		return nil
 	}
+	switch tr.Method {
+	case TrendLinear:
+		return olsConfidence(tr, pts)
+	case TrendTheilSen:
+		return senConfidence(tr, pts)
+	}
+	return nil
+}
+
+func olsConfidence(tr TrendResult, pts []point) *TrendConfidence {
 	xMean := meanPts(pts, func(p point) float64 { return p.x })
-	var sxx float64
-	var rss float64
+	var sxx, rss float64
 	for _, p := range pts {
 		dx := p.x - xMean
 		sxx += dx * dx
@@ -295,58 +826,88 @@ func AddTrendConfidence(tr TrendResult, obs []model.Observation) *TrendConfidenc
 		return nil
 	}
 	df := float64(len(pts) - 2)
-	if df <= 0 {
-		return nil
-	}
-	s2 := rss / df
-	stderr := math.Sqrt(s2 / sxx)
+	stderr := math.Sqrt(rss / df / sxx)
+	c := &TrendConfidence{Test: "t", SlopeStdErr: stderr}
 	if stderr == 0 {
-		return &TrendConfidence{
-			SlopeStdErr:       0,
-			SlopePValue:       0,
-			SlopeCI95Low:      tr.Slope,
-			SlopeCI95High:     tr.Slope,
-			SlopeYearCI95Low:  tr.SlopePerYear,
-			SlopeYearCI95High: tr.SlopePerYear,
-		}
-	}
-	tAbs := math.Abs(tr.Slope / stderr)
-	// Normal approximation for two-sided p-value.
-	p := 2 * (1 - normalCDF(tAbs))
-	z95 := 1.96
-	ciLow := tr.Slope - z95*stderr
-	ciHigh := tr.Slope + z95*stderr
-	return &TrendConfidence{
-		SlopeStdErr:       stderr,
-		SlopePValue:       p,
-		SlopeCI95Low:      ciLow,
-		SlopeCI95High:     ciHigh,
-		SlopeYearCI95Low:  ciLow * 365.25,
-		SlopeYearCI95High: ciHigh * 365.25,
+		// A perfect fit has no t statistic; it is significant unless flat.
+		if tr.Slope == 0 {
+			c.SlopePValue = 1
+		}
+		c.setInterval(tr.Slope, tr.Slope)
+		return c
 	}
+	c.SlopeTStat = tr.Slope / stderr
+	c.SlopePValue = studentTPValue(c.SlopeTStat, df)
+	crit := studentTCritical95(df)
+	c.setInterval(tr.Slope-crit*stderr, tr.Slope+crit*stderr)
+	return c
 }
 
-func Compare(lhsSeriesID string, lhs []model.Observation, rhsSeriesID string, rhs []model.Observation) (CompareResult, error) {
-	res := CompareResult{SeriesID: lhsSeriesID, AgainstSeriesID: rhsSeriesID}
-	rhsByDate := make(map[time.Time]float64, len(rhs))
-	for _, o := range rhs {
-		if math.IsNaN(o.Value) {
-			continue
-		}
-		rhsByDate[o.Date] = o.Value
+// senConfidence pairs the Mann-Kendall trend test with Sen's interval: the
+// slopes ranked (N-C)/2 and (N+C)/2+1 of the N sorted pairwise slopes, where
+// C is 1.96 standard deviations of the Kendall S statistic.
+func senConfidence(tr TrendResult, pts []point) *TrendConfidence {
+	slopes := pairwiseSlopes(pts)
+	if len(slopes) == 0 {
+		return nil
 	}
-	var x, y []float64
-	for _, o := range lhs {
-		if math.IsNaN(o.Value) {
-			continue
+	var s float64
+	for i := range pts {
+		for j := i + 1; j < len(pts); j++ {
+			switch d := pts[j].y - pts[i].y; {
+			case d > 0:
+				s++
+			case d < 0:
+				s--
+			}
 		}
-		v, ok := rhsByDate[o.Date]
-		if !ok || math.IsNaN(v) {
-			continue
+	}
+	sd := math.Sqrt(kendallVarS(pts))
+	c := &TrendConfidence{Test: "mann-kendall", SlopePValue: 1}
+	if sd > 0 {
+		// Continuity-corrected Z score.
+		switch {
+		case s > 0:
+			c.SlopeTStat = (s - 1) / sd
+		case s < 0:
+			c.SlopeTStat = (s + 1) / sd
 		}
-		x = append(x, o.Value)
-		y = append(y, v)
+		c.SlopePValue = 2 * (1 - normalCDF(math.Abs(c.SlopeTStat)))
+	}
+	n := float64(len(slopes))
+	half := 1.96 * sd
+	lo := int(math.Floor((n - half) / 2)) // 1-based rank, so index lo-1
+	hi := int(math.Ceil((n + half) / 2))  // rank hi+1, index hi
+	lo = max(lo-1, 0)
+	hi = min(hi, len(slopes)-1)
+	c.setInterval(slopes[lo], slopes[hi])
+	c.SlopeStdErr = (c.SlopeCI95High - c.SlopeCI95Low) / (2 * 1.96)
+	return c
+}
+
+// kendallVarS is the variance of the Mann-Kendall S statistic under no trend,
+// corrected for tied values.
+func kendallVarS(pts []point) float64 {
+	n := float64(len(pts))
+	v := n * (n - 1) * (2*n + 5)
+	ties := map[float64]float64{}
+	for _, p := range pts {
+		ties[p.y]++
 	}
+	for _, t := range ties {
+		v -= t * (t - 1) * (2*t + 5)
+	}
+	return v / 18
+}
+
+func (c *TrendConfidence) setInterval(low, high float64) {
+	c.SlopeCI95Low, c.SlopeCI95High = low, high
+	c.SlopeYearCI95Low, c.SlopeYearCI95High = low*365.25, high*365.25
+}
+
+func Compare(lhsSeriesID string, lhs []model.Observation, rhsSeriesID string, rhs []model.Observation) (CompareResult, error) {
+	res := CompareResult{SeriesID: lhsSeriesID, AgainstSeriesID: rhsSeriesID}
+	_, x, y := alignByDate(lhs, rhs)
 	if len(x) < 2 {
 		return res, fmt.Errorf("compare: need at least 2 aligned non-NaN observations, got %d", len(x))
 	}
@@ -374,10 +935,192 @@ func Compare(lhsSeriesID string, lhs []model.Observation, rhsSeriesID string, rh
 	}
 	res.DeltaMean = sumF(diffs) / float64(len(diffs))
 	res.DeltaLast = diffs[len(diffs)-1]
-	res.TrackingError = stddevF(diffs, res.DeltaMean)
+	res.TrackingError = stddevF(diffs, res.DeltaMean, 1)
 	return res, nil
 }
 
+// alignByDate inner-joins a and b on date, dropping NaN on either side, and
+// returns the shared dates and paired values in a's order.
+func alignByDate(a, b []model.Observation) (dates []time.Time, x, y []float64) {
+	bByDate := make(map[time.Time]float64, len(b))
+	for _, o := range b {
+		if !math.IsNaN(o.Value) {
+			bByDate[o.Date] = o.Value
+		}
+	}
+	for _, o := range a {
+		if math.IsNaN(o.Value) {
+			continue
+		}
+		if v, ok := bByDate[o.Date]; ok {
+			dates = append(dates, o.Date)
+			x = append(x, o.Value)
+			y = append(y, v)
+		}
+	}
+	return dates, x, y
+}
+
+// Correlation returns the Pearson correlation of a and b over the dates they
+// share, ignoring NaN, and the number of pairs it used. It is NaN when fewer
+// than two pairs remain or either side is constant.
+func Correlation(a, b []model.Observation) (float64, int) {
+	_, x, y := alignByDate(a, b)
+	return pearson(x, y), len(x)
+}
+
+// RollingCorrelation inner-joins a and b on date and returns, for each shared
+// date, the Pearson correlation over the trailing window of aligned pairs
+// ending there. The first window-1 dates are NaN, as is any window where
+// either side is constant.
+func RollingCorrelation(a, b []model.Observation, window int) ([]model.Observation, error) {
+	if window < 3 {
+		return nil, fmt.Errorf("rolling correlation: window must be >= 3, got %d", window)
+	}
+	dates, x, y := alignByDate(a, b)
+	if len(dates) < window {
+		return nil, fmt.Errorf("rolling correlation: need at least %d aligned observations, got %d", window, len(dates))
+	}
+	out := make([]model.Observation, len(dates))
+	for i, d := range dates {
+		r := math.NaN()
+		if i+1 >= window {
+			r = pearson(x[i+1-window:i+1], y[i+1-window:i+1])
+		}
+		out[i] = derivedObs(d, r)
+	}
+	return out, nil
+}
+
+func pearson(x, y []float64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+	mx := sumF(x) / float64(len(x))
+	my := sumF(y) / float64(len(y))
+	var cov, vx, vy float64
+	for i := range x {
+		dx, dy := x[i]-mx, y[i]-my
+		cov += dx * dy
+		vx += dx * dx
+		vy += dy * dy
+	}
+	if vx == 0 || vy == 0 {
+		return math.NaN()
+	}
+	return cov / math.Sqrt(vx*vy)
+}
+
+// ─── Cross-correlation ────────────────────────────────────────────────────────
+
+// XCorrMinPairs is the fewest aligned pairs a lag needs for its correlation
+// to be reported; lags with fewer are NaN.
+const XCorrMinPairs = 3
+
+// XCorrLag is the correlation of one lag of a cross-correlation.
+type XCorrLag struct {
+	Lag         int     `json:"lag"`
+	Correlation float64 `json:"correlation"`
+	Pairs       int     `json:"pairs"`
+}
+
+// MarshalJSON encodes an undefined correlation as null.
+func (l XCorrLag) MarshalJSON() ([]byte, error) {
+	type plain XCorrLag
+	return json.Marshal(struct {
+		plain
+		Correlation *float64 `json:"correlation"`
+	}{plain(l), nanToNil(l.Correlation)})
+}
+
+// XCorrResult holds the correlation of a series with lagged copies of another.
+// At lag k the second series is shifted k periods later, so a positive BestLag
+// means the second series leads the first by BestLag periods, and a negative
+// one that the first leads.
+type XCorrResult struct {
+	SeriesID         string     `json:"series_id"`
+	CitationText     string     `json:"citation_text,omitempty"`
+	SourceName       string     `json:"source_name,omitempty"`
+	SourceNames      []string   `json:"source_names,omitempty"`
+	WithSeriesID     string     `json:"with_series_id"`
+	WithCitationText string     `json:"with_citation_text,omitempty"`
+	WithSourceName   string     `json:"with_source_name,omitempty"`
+	WithSourceNames  []string   `json:"with_source_names,omitempty"`
+	MaxLag           int        `json:"max_lag"`
+	Lags             []XCorrLag `json:"lags"`
+	BestLag          int        `json:"best_lag"`
+	BestCorrelation  float64    `json:"best_correlation"`
+}
+
+// Leader returns the ID of the series that leads, or "" when the strongest
+// correlation is at lag 0.
+func (r XCorrResult) Leader() string {
+	switch {
+	case r.BestLag > 0:
+		return r.WithSeriesID
+	case r.BestLag < 0:
+		return r.SeriesID
+	}
+	return ""
+}
+
+// LeadPeriods returns how many periods the leader leads by.
+func (r XCorrResult) LeadPeriods() int {
+	if r.BestLag < 0 {
+		return -r.BestLag
+	}
+	return r.BestLag
+}
+
+// MarshalJSON adds the leader and lead_periods derived from BestLag.
+func (r XCorrResult) MarshalJSON() ([]byte, error) {
+	type plain XCorrResult
+	return json.Marshal(struct {
+		plain
+		Leader      string `json:"leader"`
+		LeadPeriods int    `json:"lead_periods"`
+	}{plain(r), r.Leader(), r.LeadPeriods()})
+}
+
+// CrossCorrelation correlates a with b shifted by each lag from -maxLag to
+// +maxLag periods, inner-joining on date at every lag. The best lag has the
+// largest absolute correlation, ties going to the lag nearest zero. Both
+// series must share one regular frequency for a period to mean the same
+// thing on each side. Series IDs are left for the caller to fill in.
+func CrossCorrelation(a, b []model.Observation, maxLag int) (XCorrResult, error) {
+	res := XCorrResult{MaxLag: maxLag, BestCorrelation: math.NaN()}
+	if maxLag < 0 {
+		return res, fmt.Errorf("xcorr: max lag must be >= 0, got %d", maxLag)
+	}
+	found := false
+	for lag := -maxLag; lag <= maxLag; lag++ {
+		r, n := Correlation(a, transform.Shift(b, lag))
+		if n < XCorrMinPairs {
+			r = math.NaN()
+		}
+		res.Lags = append(res.Lags, XCorrLag{Lag: lag, Correlation: r, Pairs: n})
+		if math.IsNaN(r) {
+			continue
+		}
+		better := math.Abs(r) > math.Abs(res.BestCorrelation)
+		tie := math.Abs(r) == math.Abs(res.BestCorrelation) && absInt(lag) < absInt(res.BestLag)
+		if !found || better || tie {
+			res.BestLag, res.BestCorrelation, found = lag, r, true
+		}
+	}
+	if !found {
+		return res, fmt.Errorf("xcorr: no lag has %d aligned non-NaN pairs with variation on both sides", XCorrMinPairs)
+	}
+	return res, nil
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 func RegimeCUSUM(seriesID string, obs []model.Observation, threshold float64) (RegimeResult, error) {
 	result := RegimeResult{
 		SeriesID:  seriesID,
@@ -402,7 +1145,7 @@ func RegimeCUSUM(seriesID string, obs []model.Observation, threshold float64) (R
 		diffs = append(diffs, clean[i].Value-clean[i-1].Value)
 	}
 	mean := sumF(diffs) / float64(len(diffs))
-	std := stddevF(diffs, mean)
+	std := stddevF(diffs, mean, 1)
 	if std == 0 {
 		result.Segments = []RegimeSegment{segmentForRange(clean, 0, len(clean)-1)}
 		return result, nil
@@ -469,8 +1212,14 @@ func regimeMinGap(n int) int {
 	return 6
 }
 
-// SummarizeWindows returns rolling-window summaries across a single series.
-func SummarizeWindows(seriesID string, obs []model.Observation, window int) []Summary {
+// SummarizeWindows returns rolling-window summaries across a single series,
+// each with the percentiles at cuts.
+func SummarizeWindows(seriesID string, obs []model.Observation, window int, cuts ...float64) []Summary {
+	return SummarizeWindowsDDOF(seriesID, obs, window, 1, cuts...)
+}
+
+// SummarizeWindowsDDOF is SummarizeWindows using SummarizeDDOF.
+func SummarizeWindowsDDOF(seriesID string, obs []model.Observation, window, ddof int, cuts ...float64) []Summary {
 	if window <= 0 || len(obs) < window {
 		return nil
 	}
@@ -479,11 +1228,586 @@ func SummarizeWindows(seriesID string, obs []model.Observation, window int) []Su
 	out := make([]Summary, 0, len(sorted)-window+1)
 	for i := window; i <= len(sorted); i++ {
 		w := sorted[i-window : i]
-		out = append(out, Summarize(seriesID, w))
+		out = append(out, SummarizeDDOF(seriesID, w, ddof, cuts...))
 	}
 	return out
 }
 
+// SummarizeGrouped summarizes obs separately for each calendar period:
+// "month" groups January observations across all years together (and so on
+// for each month), "quarter" groups Q1 through Q4 likewise, and "year" groups
+// by calendar year. Rows come back in calendar order with Period set to the
+// group label; periods with no observations are left out.
+func SummarizeGrouped(seriesID string, obs []model.Observation, period string) ([]Summary, error) {
+	return SummarizeGroupedDDOF(seriesID, obs, period, 1)
+}
+
+// SummarizeGroupedDDOF is SummarizeGrouped using SummarizeDDOF.
+func SummarizeGroupedDDOF(seriesID string, obs []model.Observation, period string, ddof int, cuts ...float64) ([]Summary, error) {
+	var key func(time.Time) (int, string)
+	switch period {
+	case "month":
+		key = func(t time.Time) (int, string) { return int(t.Month()), t.Month().String()[:3] }
+	case "quarter":
+		key = func(t time.Time) (int, string) {
+			q := (int(t.Month())-1)/3 + 1
+			return q, fmt.Sprintf("Q%d", q)
+		}
+	case "year":
+		key = func(t time.Time) (int, string) { return t.Year(), strconv.Itoa(t.Year()) }
+	default:
+		return nil, fmt.Errorf("summary: unknown period %q; valid periods are month, quarter, year", period)
+	}
+
+	groups := make(map[int][]model.Observation)
+	labels := make(map[int]string)
+	for _, o := range obs {
+		k, label := key(o.Date)
+		groups[k] = append(groups[k], o)
+		labels[k] = label
+	}
+	keys := make([]int, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	out := make([]Summary, 0, len(keys))
+	for _, k := range keys {
+		s := SummarizeDDOF(seriesID, groups[k], ddof, cuts...)
+		s.Period = labels[k]
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// ─── Seasonal Subseries ───────────────────────────────────────────────────────
+
+// SubseriesMonth is one row of a seasonal subseries matrix: a calendar month
+// with its value in each year (aligned with SubseriesResult.Years) and the
+// mean across years. Missing cells are NaN in Go and null in JSON.
+type SubseriesMonth struct {
+	Month  int       `json:"month"`
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"`
+	Mean   float64   `json:"mean"`
+}
+
+// MarshalJSON encodes NaN cells as null, since JSON has no NaN literal.
+func (m SubseriesMonth) MarshalJSON() ([]byte, error) {
+	values := make([]*float64, len(m.Values))
+	for i := range m.Values {
+		values[i] = nanToNil(m.Values[i])
+	}
+	return json.Marshal(struct {
+		Month  int        `json:"month"`
+		Name   string     `json:"name"`
+		Values []*float64 `json:"values"`
+		Mean   *float64   `json:"mean"`
+	}{m.Month, m.Name, values, nanToNil(m.Mean)})
+}
+
+// SubseriesResult pivots a monthly series into a month × year matrix.
+type SubseriesResult struct {
+	AnalysisVersion string           `json:"analysis_version"`
+	SeriesID        string           `json:"series_id"`
+	CitationText    string           `json:"citation_text,omitempty"`
+	SourceName      string           `json:"source_name,omitempty"`
+	SourceNames     []string         `json:"source_names,omitempty"`
+	Years           []int            `json:"years"`
+	Months          []SubseriesMonth `json:"months"` // always 12 rows, January first
+}
+
+// SeasonalSubseries pivots monthly observations into a 12-row matrix with one
+// column per calendar year, plus each month's mean across years.
+// It returns an error if obs is empty or holds more than one value per month.
+func SeasonalSubseries(seriesID string, obs []model.Observation) (SubseriesResult, error) {
+	res := SubseriesResult{AnalysisVersion: "1.0", SeriesID: seriesID}
+	if len(obs) == 0 {
+		return res, fmt.Errorf("subseries: no observations")
+	}
+
+	type cell struct {
+		year  int
+		month time.Month
+	}
+	cells := make(map[cell]float64, len(obs))
+	yearSet := map[int]bool{}
+	for _, o := range obs {
+		k := cell{o.Date.Year(), o.Date.Month()}
+		if _, dup := cells[k]; dup {
+			return res, fmt.Errorf("subseries: expected monthly observations, found more than one value for %s", o.Date.Format("2006-01"))
+		}
+		cells[k] = o.Value
+		yearSet[k.year] = true
+	}
+	for y := range yearSet {
+		res.Years = append(res.Years, y)
+	}
+	sort.Ints(res.Years)
+
+	res.Months = make([]SubseriesMonth, 12)
+	for m := time.January; m <= time.December; m++ {
+		row := SubseriesMonth{
+			Month:  int(m),
+			Name:   m.String()[:3],
+			Values: make([]float64, len(res.Years)),
+		}
+		var valid []float64
+		for i, y := range res.Years {
+			v, ok := cells[cell{y, m}]
+			if !ok {
+				v = math.NaN()
+			}
+			row.Values[i] = v
+			if !math.IsNaN(v) {
+				valid = append(valid, v)
+			}
+		}
+		row.Mean = math.NaN()
+		if len(valid) > 0 {
+			row.Mean = sumF(valid) / float64(len(valid))
+		}
+		res.Months[m-1] = row
+	}
+	return res, nil
+}
+
+func nanToNil(v float64) *float64 {
+	if math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+// ─── Half-Life ────────────────────────────────────────────────────────────────
+
+// HalfLifeResult reports the speed of mean reversion estimated from an AR(1)
+// fit of Δy[t] on y[t-1]. HalfLifePeriods and HalfLifeYears are NaN in Go and
+// null in JSON when the series is not mean-reverting.
+type HalfLifeResult struct {
+	AnalysisVersion string   `json:"analysis_version"`
+	SeriesID        string   `json:"series_id"`
+	CitationText    string   `json:"citation_text,omitempty"`
+	SourceName      string   `json:"source_name,omitempty"`
+	SourceNames     []string `json:"source_names,omitempty"`
+	Count           int      `json:"count"` // (y[t-1], Δy[t]) pairs used in the fit
+	Beta            float64  `json:"beta"`
+	Intercept       float64  `json:"intercept"`
+	MeanReverting   bool     `json:"mean_reverting"`
+	HalfLifePeriods float64  `json:"half_life_periods"`
+	PeriodsPerYear  float64  `json:"periods_per_year"` // from model.DetectFrequency
+	HalfLifeYears   float64  `json:"half_life_years"`
+	Note            string   `json:"note,omitempty"`
+}
+
+// MarshalJSON encodes the NaN half-life of a non-reverting series as null.
+func (r HalfLifeResult) MarshalJSON() ([]byte, error) {
+	type plain HalfLifeResult
+	return json.Marshal(struct {
+		plain
+		HalfLifePeriods *float64 `json:"half_life_periods"`
+		HalfLifeYears   *float64 `json:"half_life_years"`
+	}{plain(r), nanToNil(r.HalfLifePeriods), nanToNil(r.HalfLifeYears)})
+}
+
+// HalfLife regresses Δy[t] on y[t-1] and, when the slope β lies in (-1, 0),
+// returns the half-life of reversion −ln(2)/ln(1+β) in periods and years.
+// Pairs involving a NaN value are skipped. β ≥ 0 is reported as not
+// mean-reverting; β ≤ −1 overshoots the mean and has no half-life either.
+func HalfLife(seriesID string, obs []model.Observation) (HalfLifeResult, error) {
+	res := HalfLifeResult{
+		AnalysisVersion: "1.0",
+		SeriesID:        seriesID,
+		HalfLifePeriods: math.NaN(),
+		HalfLifeYears:   math.NaN(),
+	}
+
+	var pts []point
+	for i := 1; i < len(obs); i++ {
+		prev, cur := obs[i-1], obs[i]
+		if math.IsNaN(prev.Value) || math.IsNaN(cur.Value) {
+			continue
+		}
+		pts = append(pts, point{x: prev.Value, y: cur.Value - prev.Value})
+	}
+	if len(pts) < 3 {
+		return res, fmt.Errorf("half-life: need at least 3 consecutive valid observation pairs, got %d", len(pts))
+	}
+	res.Count = len(pts)
+	res.Beta, res.Intercept = olsRegress(pts)
+
+	_, res.PeriodsPerYear = model.DetectFrequency(obs)
+
+	switch {
+	case res.Beta >= 0:
+		res.Note = "not mean-reverting (beta >= 0)"
+	case res.Beta <= -1:
+		res.Note = "overshoots the mean each period (beta <= -1); half-life undefined"
+	default:
+		res.MeanReverting = true
+		res.HalfLifePeriods = -math.Ln2 / math.Log(1+res.Beta)
+		if res.PeriodsPerYear > 0 {
+			res.HalfLifeYears = res.HalfLifePeriods / res.PeriodsPerYear
+		}
+	}
+	return res, nil
+}
+
+// ─── Decomposition ────────────────────────────────────────────────────────────
+
+// Decomposition models accepted by Decompose.
+const (
+	DecomposeAdditive       = "additive"
+	DecomposeMultiplicative = "multiplicative"
+)
+
+// DecomposeResult holds the three components of a classical decomposition,
+// each aligned one-to-one with the input observations. Trend and residual are
+// NaN for the half-period at each end, where the centered moving average is
+// undefined, and wherever the averaging window touches a missing value.
+type DecomposeResult struct {
+	AnalysisVersion string
+	SeriesID        string
+	CitationText    string
+	SourceName      string
+	SourceNames     []string
+	Period          int
+	Model           string
+	Trend           []model.Observation
+	Seasonal        []model.Observation
+	Residual        []model.Observation
+}
+
+type decomposeRow struct {
+	Date     string   `json:"date"`
+	Trend    *float64 `json:"trend"`
+	Seasonal *float64 `json:"seasonal"`
+	Residual *float64 `json:"residual"`
+}
+
+// MarshalJSON encodes the components row by row, one object per date, with
+// undefined trend and residual values as null.
+func (r DecomposeResult) MarshalJSON() ([]byte, error) {
+	rows := make([]decomposeRow, len(r.Trend))
+	for i := range rows {
+		rows[i] = decomposeRow{
+			Date:     r.Trend[i].Date.Format("2006-01-02"),
+			Trend:    nanToNil(r.Trend[i].Value),
+			Seasonal: nanToNil(r.Seasonal[i].Value),
+			Residual: nanToNil(r.Residual[i].Value),
+		}
+	}
+	return json.Marshal(struct {
+		AnalysisVersion string         `json:"analysis_version"`
+		SeriesID        string         `json:"series_id"`
+		CitationText    string         `json:"citation_text,omitempty"`
+		SourceName      string         `json:"source_name,omitempty"`
+		SourceNames     []string       `json:"source_names,omitempty"`
+		Period          int            `json:"period"`
+		Model           string         `json:"model"`
+		Components      []decomposeRow `json:"components"`
+	}{r.AnalysisVersion, r.SeriesID, r.CitationText, r.SourceName, r.SourceNames, r.Period, r.Model, rows})
+}
+
+// Decompose splits obs into trend, seasonal, and residual components by
+// classical decomposition. The trend is a centered moving average over period
+// observations (a 2×period average when period is even); the seasonal
+// component is the mean detrended value at each position in the cycle,
+// normalized to sum to zero (additive) or average one (multiplicative), with
+// NaN detrended values skipped; the residual is what remains.
+// Observations must be in date order and evenly spaced.
+func Decompose(obs []model.Observation, period int, modelName string) (DecomposeResult, error) {
+	res := DecomposeResult{AnalysisVersion: "1.0", Period: period, Model: modelName}
+	multiplicative := false
+	switch modelName {
+	case DecomposeAdditive:
+	case DecomposeMultiplicative:
+		multiplicative = true
+	default:
+		return res, fmt.Errorf("decompose: unknown model %q (use additive or multiplicative)", modelName)
+	}
+	if period < 2 {
+		return res, fmt.Errorf("decompose: period must be >= 2, got %d", period)
+	}
+	if len(obs) < 2*period {
+		return res, fmt.Errorf("decompose: need at least %d observations (two full periods), got %d", 2*period, len(obs))
+	}
+	if multiplicative {
+		for _, o := range obs {
+			if !math.IsNaN(o.Value) && o.Value <= 0 {
+				return res, fmt.Errorf("decompose: multiplicative model requires positive values, found %g on %s",
+					o.Value, o.Date.Format("2006-01-02"))
+			}
+		}
+	}
+
+	n := len(obs)
+	trend := centeredMovingAverage(obs, period)
+
+	sums := make([]float64, period)
+	counts := make([]int, period)
+	for i := range obs {
+		d := obs[i].Value - trend[i]
+		if multiplicative {
+			d = obs[i].Value / trend[i]
+		}
+		if math.IsNaN(d) {
+			continue
+		}
+		sums[i%period] += d
+		counts[i%period]++
+	}
+	index := make([]float64, period)
+	var valid []float64
+	for k := range index {
+		index[k] = math.NaN()
+		if counts[k] > 0 {
+			index[k] = sums[k] / float64(counts[k])
+			valid = append(valid, index[k])
+		}
+	}
+	if len(valid) == 0 {
+		return res, fmt.Errorf("decompose: no complete %d-period window to estimate the trend", period)
+	}
+	adjust := sumF(valid) / float64(len(valid))
+	for k := range index {
+		if multiplicative {
+			index[k] /= adjust
+		} else {
+			index[k] -= adjust
+		}
+	}
+
+	res.Trend = make([]model.Observation, n)
+	res.Seasonal = make([]model.Observation, n)
+	res.Residual = make([]model.Observation, n)
+	for i, o := range obs {
+		s := index[i%period]
+		r := o.Value - trend[i] - s
+		if multiplicative {
+			r = o.Value / (trend[i] * s)
+		}
+		res.Trend[i] = derivedObs(o.Date, trend[i])
+		res.Seasonal[i] = derivedObs(o.Date, s)
+		res.Residual[i] = derivedObs(o.Date, r)
+	}
+	return res, nil
+}
+
+// centeredMovingAverage returns the period-length moving average centered on
+// each observation. An even period is centered with a 2×period average, which
+// gives the two end points half weight. Positions whose window runs off either
+// end of obs or includes a NaN are NaN.
+func centeredMovingAverage(obs []model.Observation, period int) []float64 {
+	half := period / 2
+	weights := make([]float64, 2*half+1)
+	for j := range weights {
+		weights[j] = 1 / float64(period)
+	}
+	if period%2 == 0 {
+		weights[0] /= 2
+		weights[len(weights)-1] /= 2
+	}
+	out := make([]float64, len(obs))
+	for i := range obs {
+		out[i] = math.NaN()
+		if i < half || i+half >= len(obs) {
+			continue
+		}
+		var sum float64
+		for j, wt := range weights {
+			sum += wt * obs[i-half+j].Value // a NaN in the window propagates
+		}
+		out[i] = sum
+	}
+	return out
+}
+
+// derivedObs builds an observation for a computed value, with the "." raw
+// marker FRED uses for missing values when v is NaN.
+func derivedObs(date time.Time, v float64) model.Observation {
+	raw := "."
+	if !math.IsNaN(v) {
+		raw = fmt.Sprintf("%g", v)
+	}
+	return model.Observation{Date: date, Value: v, ValueRaw: raw}
+}
+
+// ─── Laspeyres ────────────────────────────────────────────────────────────────
+
+// WeightedSeries is one component of a fixed-weight index.
+type WeightedSeries struct {
+	SeriesID string
+	Weight   float64 // base-period weight; weights are normalized to sum to 1
+	Obs      []model.Observation
+}
+
+// IndexPoint is one dated value of a composite index.
+type IndexPoint struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// IndexContribution is one component's share of the composite change from
+// the base date to EndDate. Contributions sum to the composite change.
+type IndexContribution struct {
+	SeriesID      string  `json:"series_id"`
+	Weight        float64 `json:"weight"`          // normalized
+	Rebased       float64 `json:"rebased"`         // component index at EndDate, base = 100
+	Contribution  float64 `json:"contribution"`    // index points
+	ShareOfChange float64 `json:"share_of_change"` // percent of the composite change; NaN/null when it is zero
+}
+
+// MarshalJSON encodes an undefined share of a zero composite change as null.
+func (c IndexContribution) MarshalJSON() ([]byte, error) {
+	type plain IndexContribution
+	return json.Marshal(struct {
+		plain
+		ShareOfChange *float64 `json:"share_of_change"`
+	}{plain(c), nanToNil(c.ShareOfChange)})
+}
+
+// IndexResult holds a Laspeyres composite and its component contributions.
+type IndexResult struct {
+	AnalysisVersion string              `json:"analysis_version"`
+	Method          string              `json:"method"`
+	BaseDate        string              `json:"base_date"` // shared observation every component is anchored at
+	EndDate         string              `json:"end_date"`  // latest date every component reports
+	CompositeEnd    float64             `json:"composite_end"`
+	CompositeChange float64             `json:"composite_change"` // CompositeEnd - 100, index points
+	Components      []IndexContribution `json:"components"`
+	Composite       []IndexPoint        `json:"composite"`
+}
+
+// Laspeyres builds a fixed-base-weight composite index from components. Base
+// is resolved once to an observation date every component reports, each
+// component is rebased to 100 there with transform.Index, and the composite
+// at each date is Σ wᵢ·Rᵢ over the dates where every component has a value.
+// Each component's contribution at the latest such date is wᵢ·(Rᵢ − 100), so
+// contributions reconcile exactly to the composite change from base.
+func Laspeyres(components []WeightedSeries, base time.Time) (IndexResult, error) {
+	res := IndexResult{
+		AnalysisVersion: "1.0",
+		Method:          "laspeyres",
+		BaseDate:        base.Format("2006-01-02"),
+	}
+	if len(components) == 0 {
+		return res, fmt.Errorf("laspeyres: no components")
+	}
+
+	var totalWeight float64
+	seen := map[string]bool{}
+	for _, c := range components {
+		if seen[c.SeriesID] {
+			return res, fmt.Errorf("laspeyres: component %s listed twice", c.SeriesID)
+		}
+		seen[c.SeriesID] = true
+		if math.IsNaN(c.Weight) || math.IsInf(c.Weight, 0) || c.Weight <= 0 {
+			return res, fmt.Errorf("laspeyres: weight for %s must be positive, got %g", c.SeriesID, c.Weight)
+		}
+		totalWeight += c.Weight
+	}
+
+	// Anchor every component at one shared observation date: the
+	// transform.IndexAnchor match for base among the dates all components
+	// report, so no component is rebased at a date the others lack.
+	shared, err := sharedAnchor(components, base)
+	if err != nil {
+		return res, err
+	}
+	res.BaseDate = shared.Format("2006-01-02")
+
+	// Rebase every component and keep only dates all of them report.
+	rebased := make([]map[time.Time]float64, len(components))
+	counts := map[time.Time]int{}
+	for i, c := range components {
+		idx, err := transform.Index(c.Obs, 100, shared)
+		if err != nil {
+			return res, fmt.Errorf("laspeyres: %s: %w", c.SeriesID, err)
+		}
+		rebased[i] = make(map[time.Time]float64, len(idx))
+		for _, o := range idx {
+			if !math.IsNaN(o.Value) {
+				rebased[i][o.Date] = o.Value
+				counts[o.Date]++
+			}
+		}
+	}
+	var dates []time.Time
+	for d, n := range counts {
+		if n == len(components) {
+			dates = append(dates, d)
+		}
+	}
+	if len(dates) == 0 {
+		return res, fmt.Errorf("laspeyres: components share no observation dates")
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	end := dates[len(dates)-1]
+	if !end.After(shared) {
+		return res, fmt.Errorf("laspeyres: components share no observations after base date %s", res.BaseDate)
+	}
+
+	for _, d := range dates {
+		var v float64
+		for i, c := range components {
+			v += c.Weight / totalWeight * rebased[i][d]
+		}
+		res.Composite = append(res.Composite, IndexPoint{Date: d.Format("2006-01-02"), Value: v})
+	}
+	res.EndDate = end.Format("2006-01-02")
+	res.CompositeEnd = res.Composite[len(res.Composite)-1].Value
+	res.CompositeChange = res.CompositeEnd - 100
+
+	for i, c := range components {
+		w := c.Weight / totalWeight
+		contrib := IndexContribution{
+			SeriesID:      c.SeriesID,
+			Weight:        w,
+			Rebased:       rebased[i][end],
+			Contribution:  w * (rebased[i][end] - 100),
+			ShareOfChange: math.NaN(),
+		}
+		if res.CompositeChange != 0 {
+			contrib.ShareOfChange = contrib.Contribution / res.CompositeChange * 100
+		}
+		res.Components = append(res.Components, contrib)
+	}
+	return res, nil
+}
+
+// sharedAnchor resolves base against the observation dates every component
+// reports, using the same exact/period/nearest rules as transform.Index.
+func sharedAnchor(components []WeightedSeries, base time.Time) (time.Time, error) {
+	counts := map[time.Time]int{}
+	for _, c := range components {
+		seen := map[time.Time]bool{}
+		for _, o := range c.Obs {
+			if !seen[o.Date] {
+				seen[o.Date] = true
+				counts[o.Date]++
+			}
+		}
+	}
+	var common []model.Observation
+	for d, n := range counts {
+		if n == len(components) {
+			common = append(common, model.Observation{Date: d})
+		}
+	}
+	if len(common) == 0 {
+		return time.Time{}, fmt.Errorf("laspeyres: components share no observation dates")
+	}
+	sort.Slice(common, func(i, j int) bool { return common[i].Date.Before(common[j].Date) })
+	at, err := transform.IndexAnchor(common, base)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("laspeyres: base date %s not found among the dates every component reports",
+			base.Format("2006-01-02"))
+	}
+	return at, nil
+}
+
 // ─── Math helpers ─────────────────────────────────────────────────────────────
 
 func sumF(vals []float64) float64 {
@@ -494,7 +1818,9 @@ func sumF(vals []float64) float64 {
 	return s
 }
 
-func stddevF(vals []float64, m float64) float64 {
+// stddevF divides the squared deviations by n-ddof: 1 for the sample
+// standard deviation, 0 for the population one. Fewer than two values give 0.
+func stddevF(vals []float64, m float64, ddof int) float64 {
 	if len(vals) < 2 {
 		return 0
 	}
@@ -503,14 +1829,18 @@ func stddevF(vals []float64, m float64) float64 {
 		d := v - m
 		sq += d * d
 	}
-	return math.Sqrt(sq / float64(len(vals)-1))
+	return math.Sqrt(sq / float64(len(vals)-ddof))
 }
 
-func percentile(sorted []float64, p float64) float64 {
+// Quantile returns the p-th percentile (0-100) of sorted, an ascending slice
+// without NaNs, interpolating linearly between the two nearest ranks. p
+// outside [0, 100] is clamped; an empty slice gives NaN.
+func Quantile(sorted []float64, p float64) float64 {
 	n := len(sorted)
-	if n == 0 {
+	if n == 0 || math.IsNaN(p) {
 		return math.NaN()
 	}
+	p = math.Max(0, math.Min(100, p))
 	idx := p / 100 * float64(n-1)
 	lo := int(idx)
 	hi := lo + 1
@@ -521,6 +1851,35 @@ func percentile(sorted []float64, p float64) float64 {
 	return sorted[lo]*(1-frac) + sorted[hi]*frac
 }
 
+// Percentiles returns the Quantile of sorted at each of cuts, in order.
+func Percentiles(sorted []float64, cuts []float64) []Percentile {
+	if len(cuts) == 0 {
+		return nil
+	}
+	out := make([]Percentile, len(cuts))
+	for i, p := range cuts {
+		out[i] = Percentile{P: p, Value: Quantile(sorted, p)}
+	}
+	return out
+}
+
+// medianAbsDeviation returns the median of |v - median| over sorted.
+func medianAbsDeviation(sorted []float64, median float64) float64 {
+	dev := make([]float64, len(sorted))
+	for i, v := range sorted {
+		dev[i] = math.Abs(v - median)
+	}
+	sort.Float64s(dev)
+	return Quantile(dev, 50)
+}
+
+// trimmedMean returns the mean of sorted after dropping ⌊frac·n⌋ values from
+// each end.
+func trimmedMean(sorted []float64, frac float64) float64 {
+	k := int(frac * float64(len(sorted)))
+	return sumF(sorted[k:len(sorted)-k]) / float64(len(sorted)-2*k)
+}
+
 func skewness(vals []float64, mean, std float64) float64 {
 	n := float64(len(vals))
 	if n < 3 || std == 0 {
@@ -536,6 +1895,23 @@ func skewness(vals []float64, mean, std float64) float64 {
 
 type point struct{ x, y float64 }
 
+// LinearFit regresses y on x by ordinary least squares, the same fit Trend
+// uses with elapsed days as x, and returns the slope, intercept, and R².
+func LinearFit(x, y []float64) (slope, intercept, rsq float64, err error) {
+	if len(x) != len(y) {
+		return 0, 0, 0, fmt.Errorf("linear fit: x and y lengths differ (%d vs %d)", len(x), len(y))
+	}
+	if len(x) < 2 {
+		return 0, 0, 0, fmt.Errorf("linear fit: need at least 2 points, got %d", len(x))
+	}
+	pts := make([]point, len(x))
+	for i := range x {
+		pts[i] = point{x[i], y[i]}
+	}
+	slope, intercept = olsRegress(pts)
+	return slope, intercept, r2(pts, slope, intercept), nil
+}
+
 func olsRegress(pts []point) (slope, intercept float64) {
 	n := float64(len(pts))
 	var xSum, ySum, xySum, x2Sum float64
@@ -555,6 +1931,16 @@ func olsRegress(pts []point) (slope, intercept float64) {
 }
 
 func theilSenSlope(pts []point) float64 {
+	slopes := pairwiseSlopes(pts)
+	if len(slopes) == 0 {
+		return 0
+	}
+	return Quantile(slopes, 50)
+}
+
+// pairwiseSlopes returns the sorted slopes between every pair of points with
+// distinct x.
+func pairwiseSlopes(pts []point) []float64 {
 	var slopes []float64
 	for i := 0; i < len(pts); i++ {
 		for j := i + 1; j < len(pts); j++ {
@@ -565,11 +1951,8 @@ func theilSenSlope(pts []point) float64 {
 			slopes = append(slopes, (pts[j].y-pts[i].y)/dx)
 		}
 	}
-	if len(slopes) == 0 {
-		return 0
-	}
 	sort.Float64s(slopes)
-	return percentile(slopes, 50)
+	return slopes
 }
 
 func r2(pts []point, slope, intercept float64) float64 {
@@ -603,6 +1986,80 @@ func normalCDF(x float64) float64 {
 	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
 }
 
+// studentTPValue is the two-sided p-value of t under a Student t distribution
+// with df degrees of freedom: I_{df/(df+t²)}(df/2, 1/2).
+func studentTPValue(t, df float64) float64 {
+	if math.IsInf(t, 0) {
+		return 0
+	}
+	return regIncBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// studentTCritical95 is the t value with a two-sided p-value of 0.05, found by
+// bisection since the p-value falls monotonically in |t|.
+func studentTCritical95(df float64) float64 {
+	lo, hi := 0.0, 1.0
+	for studentTPValue(hi, df) > 0.05 {
+		hi *= 2
+	}
+	for i := 0; i < 100; i++ {
+		mid := (lo + hi) / 2
+		if studentTPValue(mid, df) > 0.05 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// regIncBeta is the regularized incomplete beta function I_x(a, b), evaluated
+// with the Lentz continued fraction on whichever side converges quickly.
+func regIncBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	if x < (a+1)/(a+b+2) {
+		return front * betaCF(a, b, x) / a
+	}
+	return 1 - front*betaCF(b, a, 1-x)/b
+}
+
+func betaCF(a, b, x float64) float64 {
+	const tiny = 1e-300
+	clamp := func(v float64) float64 {
+		if math.Abs(v) < tiny {
+			return tiny
+		}
+		return v
+	}
+	c, d := 1.0, 1/clamp(1-(a+b)*x/(a+1))
+	h := d
+	for m := 1; m <= 300; m++ {
+		fm := float64(m)
+		num := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		h *= d * c
+		num = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 / clamp(1+num*d)
+		c = clamp(1 + num/c)
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
+
 func segmentForRange(obs []model.Observation, start, end int) RegimeSegment {
 	seg := RegimeSegment{
 		StartDate: obs[start].Date.Format("2006-01-02"),
@@ -4,12 +4,17 @@
 package analyze_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"math"
+	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/analyze"
 	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/pipeline"
 )
 
 // ─── Helpers ──────────────────────────────────────────────────────────────────
@@ -64,6 +69,15 @@ func TestSummarizeBasicCounts(t *testing.T) {
 	}
 }
 
+func TestSummarizeReportsFrequency(t *testing.T) {
+	if got := analyze.Summarize("TEST", makeObs(2020, 1, 1, 2, 3, 4)).Frequency; got != model.FrequencyMonthly {
+		t.Errorf("monthly Frequency: got %q", got)
+	}
+	if got := analyze.Summarize("TEST", makeAnnual(2010, 1, 2, 3)).Frequency; got != model.FrequencyAnnual {
+		t.Errorf("annual Frequency: got %q", got)
+	}
+}
+
 func TestSummarizeMeanAndStd(t *testing.T) {
 	// Values 1,2,3,4,5: mean=3, population-style std via sample formula
 	obs := makeObs(2020, 1, 1.0, 2.0, 3.0, 4.0, 5.0)
@@ -79,6 +93,95 @@ func TestSummarizeMeanAndStd(t *testing.T) {
 	}
 }
 
+func TestSummarizeDDOF(t *testing.T) {
+	obs := makeObs(2020, 1, 1.0, 2.0, 3.0)
+	sample := analyze.SummarizeDDOF("TEST", obs, 1)
+	population := analyze.SummarizeDDOF("TEST", obs, 0)
+	if !approxEqual(sample.Std, 1.0, 1e-9) {
+		t.Errorf("ddof=1: expected 1.0, got %g", sample.Std)
+	}
+	if !approxEqual(population.Std, math.Sqrt(2.0/3.0), 1e-9) {
+		t.Errorf("ddof=0: expected %g, got %g", math.Sqrt(2.0/3.0), population.Std)
+	}
+	if sample.Skew != population.Skew {
+		t.Errorf("skew should not depend on ddof: %g vs %g", sample.Skew, population.Skew)
+	}
+	if got := analyze.Summarize("TEST", obs).Std; got != sample.Std {
+		t.Errorf("Summarize should default to ddof=1, got %g", got)
+	}
+}
+
+func TestSummarizeGroupedByMonth(t *testing.T) {
+	// Three years of monthly data: month m of year y is 10*y + m, so each
+	// calendar month's mean across 2020-2022 is 10*2021 + m.
+	var vals []float64
+	for y := 2020; y <= 2022; y++ {
+		for m := 1; m <= 12; m++ {
+			vals = append(vals, float64(10*y+m))
+		}
+	}
+	vals[12+6] = math.NaN() // July 2021 missing
+	obs := makeObs(2020, 1, vals...)
+
+	rows, err := analyze.SummarizeGrouped("UNRATE", obs, "month")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 12 {
+		t.Fatalf("expected 12 rows, got %d", len(rows))
+	}
+	if rows[0].Period != "Jan" || rows[11].Period != "Dec" {
+		t.Errorf("periods should run Jan..Dec, got %s..%s", rows[0].Period, rows[11].Period)
+	}
+	for i, r := range rows {
+		m := float64(i + 1)
+		want := 10*2021 + m
+		if i == 6 {
+			// July 2021 is excluded: mean of 2020 and 2022 Julys.
+			want = (10*2020 + m + 10*2022 + m) / 2
+		}
+		if !approxEqual(r.Mean, want, 1e-9) {
+			t.Errorf("%s mean: expected %g, got %g", r.Period, want, r.Mean)
+		}
+		if r.SeriesID != "UNRATE" {
+			t.Errorf("%s series: expected UNRATE, got %q", r.Period, r.SeriesID)
+		}
+	}
+	if rows[6].Count != 3 || rows[6].MissingCount != 1 {
+		t.Errorf("Jul: expected count 3 with 1 missing, got %d/%d", rows[6].Count, rows[6].MissingCount)
+	}
+}
+
+func TestSummarizeGroupedByQuarterAndYear(t *testing.T) {
+	obs := makeObs(2020, 1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15)
+
+	quarters, err := analyze.SummarizeGrouped("TEST", obs, "quarter")
+	if err != nil {
+		t.Fatalf("quarter: %v", err)
+	}
+	if len(quarters) != 4 || quarters[0].Period != "Q1" || quarters[0].Count != 6 {
+		t.Fatalf("expected Q1..Q4 with 6 Q1 observations, got %+v", quarters)
+	}
+	if !approxEqual(quarters[0].Mean, (1+2+3+13+14+15)/6.0, 1e-9) {
+		t.Errorf("Q1 mean: got %g", quarters[0].Mean)
+	}
+
+	years, err := analyze.SummarizeGrouped("TEST", obs, "year")
+	if err != nil {
+		t.Fatalf("year: %v", err)
+	}
+	if len(years) != 2 || years[0].Period != "2020" || years[1].Period != "2021" || years[1].Count != 3 {
+		t.Errorf("expected 2020 and 2021 rows, got %+v", years)
+	}
+}
+
+func TestSummarizeGroupedUnknownPeriod(t *testing.T) {
+	_, err := analyze.SummarizeGrouped("TEST", makeObs(2020, 1, 1, 2), "week")
+	if err == nil || !strings.Contains(err.Error(), "unknown period") {
+		t.Errorf("expected unknown period error, got %v", err)
+	}
+}
+
 func TestSummarizeMinMax(t *testing.T) {
 	obs := makeObs(2020, 1, 5.0, 2.0, 8.0, 1.0, 9.0, 3.0)
 	s := analyze.Summarize("TEST", obs)
@@ -125,6 +228,64 @@ func TestSummarizePercentiles(t *testing.T) {
 	}
 }
 
+func TestSummarizeRequestedPercentiles(t *testing.T) {
+	// 0..100 in steps of 1: the p-th percentile is exactly p.
+	vals := make([]float64, 101)
+	for i := range vals {
+		vals[i] = float64(i)
+	}
+	obs := makeObs(2000, 1, vals...)
+	s := analyze.Summarize("TEST", obs, 99, 5, 50, 95)
+	want := []struct {
+		label string
+		value float64
+	}{{"P99", 99}, {"P5", 5}, {"P50", 50}, {"P95", 95}}
+	if len(s.Percentiles) != len(want) {
+		t.Fatalf("expected %d percentiles, got %+v", len(want), s.Percentiles)
+	}
+	for i, w := range want {
+		got := s.Percentiles[i]
+		if got.Label() != w.label || !approxEqual(got.Value, w.value, 1e-9) {
+			t.Errorf("percentile %d: expected %s=%g, got %s=%g", i, w.label, w.value, got.Label(), got.Value)
+		}
+	}
+	if s.Median != 50 || s.P25 != 25 {
+		t.Errorf("default quartiles should still be computed, got P25=%g median=%g", s.P25, s.Median)
+	}
+	if plain := analyze.Summarize("TEST", obs); plain.Percentiles != nil {
+		t.Errorf("expected no percentiles without cut points, got %+v", plain.Percentiles)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	sorted := []float64{10, 20, 30, 40}
+	cases := []struct{ p, want float64 }{
+		{0, 10}, {100, 40}, {50, 25}, {99.5, 39.85}, {-5, 10}, {120, 40},
+	}
+	for _, c := range cases {
+		if got := analyze.Quantile(sorted, c.p); !approxEqual(got, c.want, 1e-9) {
+			t.Errorf("Quantile(%g): expected %g, got %g", c.p, c.want, got)
+		}
+	}
+	if got := analyze.Quantile(nil, 50); !math.IsNaN(got) {
+		t.Errorf("Quantile of empty slice: expected NaN, got %g", got)
+	}
+	if label := (analyze.Percentile{P: 99.5}).Label(); label != "P99.5" {
+		t.Errorf("expected label P99.5, got %s", label)
+	}
+}
+
+func TestSummaryPercentilesJSONNullForMissing(t *testing.T) {
+	s := analyze.Summarize("TEST", makeObs(2020, 1, math.NaN()), 5)
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"percentiles":[{"p":5,"value":null}]`) {
+		t.Errorf("expected null percentile value, got %s", b)
+	}
+}
+
 func TestSummarizeFirstLast(t *testing.T) {
 	// First and last should be the first/last non-NaN in original order
 	obs := []model.Observation{
@@ -164,6 +325,83 @@ func TestSummarizeChangeZeroFirst(t *testing.T) {
 	}
 }
 
+func TestSummarizeCAGR(t *testing.T) {
+	// Doubling over ten years ≈ 7.18% a year.
+	s := analyze.Summarize("TEST", makeAnnual(2000, 100, 130, 160, 190, 200, 210, 220, 180, 170, 190, 200))
+	if want := math.Pow(2, 0.1) - 1; !approxEqual(s.CAGR, want, 1e-4) {
+		t.Errorf("CAGR: expected ≈%.5f, got %.5f", want, s.CAGR)
+	}
+}
+
+func TestSummarizeCAGRUsesDatesNotCount(t *testing.T) {
+	// Three observations, irregularly spaced, with a leading NaN: the span
+	// runs from 2001 to 2005 (4 years), not 2 periods.
+	obs := []model.Observation{
+		{Date: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN()},
+		{Date: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC), Value: 100},
+		{Date: time.Date(2001, 3, 1, 0, 0, 0, 0, time.UTC), Value: 105},
+		{Date: time.Date(2005, 1, 1, 0, 0, 0, 0, time.UTC), Value: 146.41},
+	}
+	s := analyze.Summarize("TEST", obs)
+	if !approxEqual(s.CAGR, 0.10, 1e-4) {
+		t.Errorf("CAGR: expected ≈0.10, got %g", s.CAGR)
+	}
+}
+
+func TestSummarizeCAGRUndefined(t *testing.T) {
+	cases := map[string][]model.Observation{
+		"under a year":   makeObs(2020, 1, 100, 110, 120),
+		"first zero":     makeAnnual(2000, 0, 10, 20),
+		"first negative": makeAnnual(2000, -5, 10, 20),
+		"all missing":    makeAnnual(2000, math.NaN(), math.NaN()),
+	}
+	for name, obs := range cases {
+		s := analyze.Summarize("TEST", obs)
+		if !isNaN(s.CAGR) {
+			t.Errorf("%s: expected NaN CAGR, got %g", name, s.CAGR)
+		}
+		b, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", name, err)
+		}
+		if !strings.Contains(string(b), `"cagr":null`) {
+			t.Errorf("%s: expected null cagr in JSON, got %s", name, b)
+		}
+	}
+}
+
+func TestSummarizeRobustStats(t *testing.T) {
+	// One outlier among ten values, with a NaN that must be skipped.
+	obs := makeObs(2020, 1, 1, 2, 3, 4, math.NaN(), 5, 6, 7, 8, 9, 100)
+	s := analyze.Summarize("TEST", obs)
+	// Median 5.5; |v - 5.5| sorted = 0.5,0.5,1.5,1.5,2.5,2.5,3.5,3.5,4.5,94.5 → MAD 2.5.
+	if !approxEqual(s.MAD, 2.5, 1e-9) {
+		t.Errorf("MAD: expected 2.5, got %g", s.MAD)
+	}
+	if !approxEqual(s.IQR, s.P75-s.P25, 1e-12) || !approxEqual(s.IQR, 4.5, 1e-9) {
+		t.Errorf("IQR: expected 4.5 (P75-P25), got %g", s.IQR)
+	}
+	// 10% of 10 values trims one from each end: mean of 2..9 = 5.5.
+	if !approxEqual(s.TrimmedMean, 5.5, 1e-9) {
+		t.Errorf("TrimmedMean: expected 5.5, got %g", s.TrimmedMean)
+	}
+	if s.Mean < 14 {
+		t.Errorf("outlier should pull the plain mean well above the trimmed mean, got %g", s.Mean)
+	}
+}
+
+func TestSummarizeRobustStatsShortSeries(t *testing.T) {
+	// Fewer than 10 values trims nothing.
+	s := analyze.Summarize("TEST", makeObs(2020, 1, 1, 2, 6))
+	if !approxEqual(s.TrimmedMean, 3, 1e-9) {
+		t.Errorf("TrimmedMean: expected plain mean 3, got %g", s.TrimmedMean)
+	}
+	empty := analyze.Summarize("TEST", makeObs(2020, 1, math.NaN()))
+	if !isNaN(empty.MAD) || !isNaN(empty.IQR) || !isNaN(empty.TrimmedMean) {
+		t.Errorf("all-missing series should have NaN robust stats, got %+v", empty)
+	}
+}
+
 func TestSummarizeSkew(t *testing.T) {
 	// Symmetric series should have skew near 0
 	obs := makeObs(2020, 1, 1.0, 2.0, 3.0, 4.0, 5.0)
@@ -382,6 +620,91 @@ func TestTrendSeriesIDPreserved(t *testing.T) {
 	}
 }
 
+// polyObs samples c[0] + c[1]x + c[2]x² + ... every step days from 2000-01-01.
+func polyObs(n, step int, c ...float64) []model.Observation {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]model.Observation, n)
+	for i := range out {
+		x := float64(i * step)
+		var y float64
+		for k := len(c) - 1; k >= 0; k-- {
+			y = y*x + c[k]
+		}
+		out[i] = model.Observation{Date: start.AddDate(0, 0, i*step), Value: y}
+	}
+	return out
+}
+
+func TestTrendPolyRecoversQuadratic(t *testing.T) {
+	want := []float64{5, 0.01, -2e-6}
+	obs := polyObs(40, 91, want...)
+	obs[7].Value = math.NaN()
+	tr, err := analyze.TrendPoly(obs, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.Method != analyze.TrendPolynomial || tr.Degree != 2 || len(tr.Coefficients) != 3 {
+		t.Fatalf("unexpected result shape: %+v", tr)
+	}
+	for k, c := range want {
+		if !approxEqual(tr.Coefficients[k], c, math.Abs(c)*1e-6) {
+			t.Errorf("coefficient %d: expected %g, got %g", k, c, tr.Coefficients[k])
+		}
+	}
+	if !approxEqual(tr.R2, 1, 1e-9) {
+		t.Errorf("R2: expected 1 for an exact fit, got %g", tr.R2)
+	}
+	if tr.Curvature != "concave" {
+		t.Errorf("Curvature: expected concave, got %q", tr.Curvature)
+	}
+}
+
+func TestTrendPolyCubicAndConvex(t *testing.T) {
+	want := []float64{1, -0.02, 3e-5, 1e-9}
+	tr, err := analyze.TrendPoly(polyObs(30, 30, want...), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for k, c := range want {
+		if !approxEqual(tr.Coefficients[k], c, math.Abs(c)*1e-5) {
+			t.Errorf("coefficient %d: expected %g, got %g", k, c, tr.Coefficients[k])
+		}
+	}
+	if tr.Curvature != "convex" {
+		t.Errorf("Curvature: expected convex, got %q", tr.Curvature)
+	}
+}
+
+func TestTrendPolyR2Range(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	obs := polyObs(60, 30, 10)
+	for i := range obs {
+		obs[i].Value += rng.NormFloat64()
+	}
+	tr, err := analyze.TrendPoly(obs, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tr.R2 < 0 || tr.R2 > 1 {
+		t.Errorf("R2 out of [0,1]: %g", tr.R2)
+	}
+	if tr.R2 > 0.5 {
+		t.Errorf("R2 should be low for noise around a constant, got %g", tr.R2)
+	}
+}
+
+func TestTrendPolyErrors(t *testing.T) {
+	obs := polyObs(10, 30, 1, 2, 3)
+	for _, degree := range []int{1, 4} {
+		if _, err := analyze.TrendPoly(obs, degree); err == nil {
+			t.Errorf("expected error for degree %d", degree)
+		}
+	}
+	if _, err := analyze.TrendPoly(obs[:3], 2); err == nil {
+		t.Error("expected error for too few observations")
+	}
+}
+
 func TestTrendMethodPreserved(t *testing.T) {
 	obs := makeAnnual(2010, 1.0, 2.0, 3.0, 4.0, 5.0)
 	tr, err := analyze.Trend("TEST", obs, analyze.TrendTheilSen)
@@ -393,6 +716,73 @@ func TestTrendMethodPreserved(t *testing.T) {
 	}
 }
 
+// makeDaily builds daily observations starting 2020-01-01.
+func makeDaily(values ...float64) []model.Observation {
+	out := make([]model.Observation, len(values))
+	for i, v := range values {
+		out[i] = model.Observation{Date: time.Date(2020, 1, 1+i, 0, 0, 0, 0, time.UTC), Value: v}
+	}
+	return out
+}
+
+func TestRollingTrendLinearSeries(t *testing.T) {
+	obs := makeDaily(1, 3, 5, 7, 9, 11, 13, 15)
+	got, err := analyze.RollingTrend("TEST", obs, 4, analyze.TrendLinear)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(obs) {
+		t.Fatalf("expected %d results, got %d", len(obs), len(got))
+	}
+	for i, tr := range got {
+		if tr.Date != obs[i].Date.Format("2006-01-02") {
+			t.Errorf("[%d] Date = %s, want %s", i, tr.Date, obs[i].Date.Format("2006-01-02"))
+		}
+		if i < 3 {
+			if !isNaN(tr.Slope) || tr.Direction != "" {
+				t.Errorf("[%d] expected NaN slope before a full window, got %+v", i, tr)
+			}
+			continue
+		}
+		if !approxEqual(tr.Slope, 2, 1e-9) || tr.Direction != "up" {
+			t.Errorf("[%d] expected slope 2 up, got %g %s", i, tr.Slope, tr.Direction)
+		}
+	}
+}
+
+func TestRollingTrendSkipsNaN(t *testing.T) {
+	obs := makeDaily(1, 3, math.NaN(), 7, math.NaN(), math.NaN(), 13)
+	got, err := analyze.RollingTrend("TEST", obs, 3, analyze.TrendLinear)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The windows ending at 2 and 3 each keep 2 points on the line.
+	for _, i := range []int{2, 3} {
+		if !approxEqual(got[i].Slope, 2, 1e-9) {
+			t.Errorf("[%d] slope = %g, want 2 from the non-NaN points", i, got[i].Slope)
+		}
+	}
+	// Later windows hold only one non-NaN point.
+	for _, i := range []int{4, 5, 6} {
+		if !isNaN(got[i].Slope) {
+			t.Errorf("[%d] expected NaN slope for a window with one point, got %g", i, got[i].Slope)
+		}
+	}
+}
+
+func TestRollingTrendRejectsBadInput(t *testing.T) {
+	obs := makeDaily(1, 2, 3)
+	if _, err := analyze.RollingTrend("TEST", obs, 1, analyze.TrendLinear); err == nil {
+		t.Error("expected error for window < 2")
+	}
+	if _, err := analyze.RollingTrend("TEST", obs, 4, analyze.TrendLinear); err == nil {
+		t.Error("expected error for window longer than the series")
+	}
+	if _, err := analyze.RollingTrend("TEST", obs, 2, analyze.TrendPolynomial); err == nil {
+		t.Error("expected error for poly method")
+	}
+}
+
 func TestTrendConfidenceLinear(t *testing.T) {
 	obs := makeAnnual(2010, 1, 2, 3, 4, 5, 6)
 	tr, err := analyze.Trend("TEST", obs, analyze.TrendLinear)
@@ -411,6 +801,182 @@ func TestTrendConfidenceLinear(t *testing.T) {
 	}
 }
 
+func TestTrendConfidenceUsesStudentT(t *testing.T) {
+	obs := makeAnnual(2010, 1.2, 1.9, 3.3, 3.8, 5.1, 6.2, 6.8, 8.1, 9.2, 9.7, 11.3, 11.9)
+	tr, err := analyze.Trend("TEST", obs, analyze.TrendLinear)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conf := analyze.AddTrendConfidence(tr, obs)
+	if conf == nil || conf.Test != "t" {
+		t.Fatalf("expected t-test confidence, got %+v", conf)
+	}
+	if got := conf.SlopeTStat; math.Abs(got-tr.Slope/conf.SlopeStdErr) > 1e-9 {
+		t.Errorf("SlopeTStat = %g, want slope/stderr = %g", got, tr.Slope/conf.SlopeStdErr)
+	}
+	// 12 points leave 10 degrees of freedom; t(0.975, 10) = 2.228.
+	if crit := (conf.SlopeCI95High - tr.Slope) / conf.SlopeStdErr; math.Abs(crit-2.228) > 0.001 {
+		t.Errorf("CI half-width = %g standard errors, want 2.228", crit)
+	}
+	if conf.SlopePValue > 1e-6 {
+		t.Errorf("strong trend should be significant, p = %g", conf.SlopePValue)
+	}
+}
+
+func TestTrendConfidenceFlatSeries(t *testing.T) {
+	obs := makeAnnual(2010, 4, 4, 4, 4, 4)
+	tr, _ := analyze.Trend("TEST", obs, analyze.TrendLinear)
+	conf := analyze.AddTrendConfidence(tr, obs)
+	if conf == nil || conf.SlopePValue != 1 {
+		t.Fatalf("flat series should have p = 1, got %+v", conf)
+	}
+}
+
+func TestTrendConfidencePerfectFit(t *testing.T) {
+	obs := makeAnnual(2010, 1, 2, 3, 4, 5, 6)
+	tr, _ := analyze.Trend("TEST", obs, analyze.TrendLinear)
+	conf := analyze.AddTrendConfidence(tr, obs)
+	if conf == nil {
+		t.Fatal("expected confidence metadata for linear trend")
+	}
+	if conf.SlopePValue > 1e-9 {
+		t.Errorf("perfect fit should have p ≈ 0, got %g", conf.SlopePValue)
+	}
+	// Leap years keep annual dates from being exactly linear in days.
+	if width := conf.SlopeCI95High - conf.SlopeCI95Low; width > 0.01*math.Abs(tr.Slope) {
+		t.Errorf("perfect fit should have a very narrow CI, got [%g, %g]", conf.SlopeCI95Low, conf.SlopeCI95High)
+	}
+}
+
+func TestTrendConfidenceContainsTrueSlope(t *testing.T) {
+	// y = 2 per year plus fixed noise.
+	noise := []float64{0.3, -0.4, 0.1, -0.2, 0.5, -0.3, 0.2, -0.1, 0.4, -0.5}
+	vals := make([]float64, len(noise))
+	for i, e := range noise {
+		vals[i] = 2*float64(i) + e
+	}
+	obs := makeAnnual(2010, vals...)
+	tr, _ := analyze.Trend("TEST", obs, analyze.TrendLinear)
+	conf := analyze.AddTrendConfidence(tr, obs)
+	if conf == nil {
+		t.Fatal("expected confidence metadata for linear trend")
+	}
+	if conf.SlopeYearCI95Low > 2 || conf.SlopeYearCI95High < 2 {
+		t.Errorf("CI [%g, %g] per year should contain the true slope 2", conf.SlopeYearCI95Low, conf.SlopeYearCI95High)
+	}
+	if conf.SlopeYearCI95High-conf.SlopeYearCI95Low <= 0 {
+		t.Errorf("noisy series should have a non-degenerate CI, got [%g, %g]", conf.SlopeYearCI95Low, conf.SlopeYearCI95High)
+	}
+}
+
+func TestTrendConfidenceTheilSen(t *testing.T) {
+	obs := makeAnnual(2010, 1, 3, 2, 4, 6, 5, 7, 9, 8, 10)
+	tr, err := analyze.Trend("TEST", obs, analyze.TrendTheilSen)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conf := analyze.AddTrendConfidence(tr, obs)
+	if conf == nil || conf.Test != "mann-kendall" {
+		t.Fatalf("expected Mann-Kendall confidence, got %+v", conf)
+	}
+	if conf.SlopeCI95Low > tr.Slope || conf.SlopeCI95High < tr.Slope {
+		t.Errorf("Sen interval [%g, %g] should contain slope %g", conf.SlopeCI95Low, conf.SlopeCI95High, tr.Slope)
+	}
+	if conf.SlopeCI95Low <= 0 {
+		t.Errorf("rising series should have a positive lower bound, got %g", conf.SlopeCI95Low)
+	}
+	if conf.SlopeTStat <= 0 || conf.SlopePValue >= 0.01 {
+		t.Errorf("expected a significant upward Z, got z=%g p=%g", conf.SlopeTStat, conf.SlopePValue)
+	}
+}
+
+func streamJSONL(t *testing.T, obs []model.Observation) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := pipeline.WriteJSONL(&buf, "TEST", obs); err != nil {
+		t.Fatalf("WriteJSONL: %v", err)
+	}
+	return &buf
+}
+
+func TestStreamSummarizeMatchesSummarize(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	obs := make([]model.Observation, 18000)
+	for i := range obs {
+		v := 100 + 20*math.Sin(float64(i)/500) + rng.NormFloat64()*5
+		if i%97 == 0 {
+			v = math.NaN()
+		}
+		obs[i] = model.Observation{Date: time.Date(1975, 1, 1+i, 0, 0, 0, 0, time.UTC), Value: v}
+	}
+	cuts := []float64{5, 95}
+	want := analyze.SummarizeDDOF("TEST", obs, 1, cuts...)
+	got, err := analyze.StreamSummarizeDDOF(streamJSONL(t, obs), 1, cuts...)
+	if err != nil {
+		t.Fatalf("StreamSummarize: %v", err)
+	}
+
+	if got.Count != want.Count || got.MissingCount != want.MissingCount || got.SeriesID != "TEST" {
+		t.Errorf("counts: got %d/%d %q, want %d/%d TEST", got.Count, got.MissingCount, got.SeriesID, want.Count, want.MissingCount)
+	}
+	if got.StartDate != want.StartDate || got.EndDate != want.EndDate || got.Frequency != want.Frequency {
+		t.Errorf("span: got %s..%s %s, want %s..%s %s", got.StartDate, got.EndDate, got.Frequency, want.StartDate, want.EndDate, want.Frequency)
+	}
+	exact := map[string][2]float64{
+		"mean": {got.Mean, want.Mean}, "std": {got.Std, want.Std},
+		"min": {got.Min, want.Min}, "max": {got.Max, want.Max},
+		"skew": {got.Skew, want.Skew}, "first": {got.First, want.First},
+		"last": {got.Last, want.Last}, "cagr": {got.CAGR, want.CAGR},
+	}
+	for name, v := range exact {
+		if !approxEqual(v[0], v[1], 1e-9*math.Max(1, math.Abs(v[1]))) {
+			t.Errorf("%s: got %.12g, want %.12g", name, v[0], v[1])
+		}
+	}
+	approx := map[string][2]float64{
+		"p25": {got.P25, want.P25}, "median": {got.Median, want.Median}, "p75": {got.P75, want.P75},
+		"p5":  {got.Percentiles[0].Value, want.Percentiles[0].Value},
+		"p95": {got.Percentiles[1].Value, want.Percentiles[1].Value},
+	}
+	for name, v := range approx {
+		if rel := math.Abs(v[0]-v[1]) / math.Abs(v[1]); rel > 0.005 {
+			t.Errorf("%s: got %g, want %g (relative error %.4f)", name, v[0], v[1], rel)
+		}
+	}
+	if !isNaN(got.MAD) || !isNaN(got.TrimmedMean) {
+		t.Errorf("MAD and trimmed mean should be NaN when streaming, got %g and %g", got.MAD, got.TrimmedMean)
+	}
+}
+
+func TestStreamSummarizeNegativeAndMissing(t *testing.T) {
+	obs := makeObs(2020, 1, -8, math.NaN(), -2, 0, 3, 9)
+	got, err := analyze.StreamSummarize(streamJSONL(t, obs))
+	if err != nil {
+		t.Fatalf("StreamSummarize: %v", err)
+	}
+	want := analyze.Summarize("TEST", obs)
+	if got.Min != -8 || got.Max != 9 || got.MissingCount != 1 {
+		t.Errorf("got min %g max %g missing %d", got.Min, got.Max, got.MissingCount)
+	}
+	for _, v := range [][2]float64{{got.P25, want.P25}, {got.Median, want.Median}, {got.P75, want.P75}} {
+		if !approxEqual(v[0], v[1], 0.005*math.Abs(v[1])) {
+			t.Errorf("quantile: got %g, want %g", v[0], v[1])
+		}
+	}
+
+	allMissing := makeObs(2020, 1, math.NaN(), math.NaN())
+	got, err = analyze.StreamSummarize(streamJSONL(t, allMissing))
+	if err != nil {
+		t.Fatalf("StreamSummarize: %v", err)
+	}
+	if got.Count != 2 || !isNaN(got.Mean) || !isNaN(got.Median) {
+		t.Errorf("all-missing stream: got %+v", got)
+	}
+	if _, err := analyze.StreamSummarize(strings.NewReader("")); err == nil {
+		t.Error("expected error for an empty stream")
+	}
+}
+
 func TestSummarizeWindows(t *testing.T) {
 	obs := makeObs(2020, 1, 1, 2, 3, 4)
 	w := analyze.SummarizeWindows("TEST", obs, 2)
@@ -531,6 +1097,124 @@ func TestCompareAligned(t *testing.T) {
 	}
 }
 
+func TestCorrelationSkipsNaNAndUnmatchedDates(t *testing.T) {
+	a := makeObs(2020, 1, 1, 2, math.NaN(), 4, 5)
+	b := makeObs(2020, 2, 2, 4, 6, 8)
+	r, n := analyze.Correlation(a, b)
+	if n != 3 {
+		t.Fatalf("pairs=%d want 3", n)
+	}
+	if !approxEqual(r, 1, 1e-9) {
+		t.Errorf("r=%g want 1", r)
+	}
+	if r, _ := analyze.Correlation(a, makeObs(2020, 1, 7, 7, 7, 7, 7)); !math.IsNaN(r) {
+		t.Errorf("constant series should give NaN, got %g", r)
+	}
+}
+
+func TestCrossCorrelationFindsLeader(t *testing.T) {
+	// b is a shifted three periods ahead: b leads a.
+	vals := []float64{1, 4, 2, 8, 5, 7, 3, 9, 6, 2, 8, 4, 7, 1, 5, 9}
+	a := makeObs(2020, 1, vals[:13]...)
+	b := makeObs(2020, 1, vals[3:]...)
+	res, err := analyze.CrossCorrelation(a, b, 4)
+	if err != nil {
+		t.Fatalf("CrossCorrelation: %v", err)
+	}
+	if len(res.Lags) != 9 || res.Lags[0].Lag != -4 {
+		t.Fatalf("unexpected lag grid: %+v", res.Lags)
+	}
+	// With b leading, a_t matches b_{t-3}, so the best lag is +3.
+	if res.BestLag != 3 || !approxEqual(res.BestCorrelation, 1, 1e-9) {
+		t.Fatalf("best lag %d r=%g, want +3 r=1", res.BestLag, res.BestCorrelation)
+	}
+	res.SeriesID, res.WithSeriesID = "A", "B"
+	if res.Leader() != "B" || res.LeadPeriods() != 3 {
+		t.Errorf("leader %q by %d, want B by 3", res.Leader(), res.LeadPeriods())
+	}
+
+	swapped, err := analyze.CrossCorrelation(b, a, 4)
+	if err != nil {
+		t.Fatalf("CrossCorrelation swapped: %v", err)
+	}
+	if swapped.BestLag != -3 {
+		t.Errorf("swapped best lag %d, want -3", swapped.BestLag)
+	}
+}
+
+func TestCrossCorrelationRejectsBadInput(t *testing.T) {
+	a := makeObs(2020, 1, 1, 2, 3, 4)
+	if _, err := analyze.CrossCorrelation(a, a, -1); err == nil {
+		t.Error("expected error for negative max lag")
+	}
+	if _, err := analyze.CrossCorrelation(a, makeObs(2021, 1, 1, 2, 3, 4), 1); err == nil {
+		t.Error("expected error when the series never overlap")
+	}
+}
+
+func TestXCorrResultJSONNullsUndefinedLags(t *testing.T) {
+	res := analyze.XCorrResult{
+		SeriesID: "A", WithSeriesID: "B", BestLag: -2, BestCorrelation: 0.5,
+		Lags: []analyze.XCorrLag{{Lag: -2, Correlation: 0.5, Pairs: 10}, {Lag: 2, Correlation: math.NaN(), Pairs: 1}},
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	out := string(b)
+	for _, want := range []string{`"leader":"A"`, `"lead_periods":2`, `"correlation":null`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in %s", want, out)
+		}
+	}
+}
+
+func TestRollingCorrelationTracksRegimeChange(t *testing.T) {
+	a := makeObs(2020, 1, 1, 2, 3, 4, 5, 6, 7, 8)
+	// b moves with a for four months, then against it.
+	b := makeObs(2020, 1, 2, 4, 6, 8, 7, 6, 5, 4)
+	out, err := analyze.RollingCorrelation(a, b, 4)
+	if err != nil {
+		t.Fatalf("RollingCorrelation: %v", err)
+	}
+	if len(out) != 8 {
+		t.Fatalf("expected 8 outputs, got %d", len(out))
+	}
+	for i := 0; i < 3; i++ {
+		if !math.IsNaN(out[i].Value) || out[i].ValueRaw != "." {
+			t.Errorf("out[%d]: expected NaN warm-up, got %g (%q)", i, out[i].Value, out[i].ValueRaw)
+		}
+	}
+	if !approxEqual(out[3].Value, 1, 1e-9) {
+		t.Errorf("out[3]: expected 1, got %g", out[3].Value)
+	}
+	if !approxEqual(out[7].Value, -1, 1e-9) {
+		t.Errorf("out[7]: expected -1, got %g", out[7].Value)
+	}
+	if !out[7].Date.Equal(a[7].Date) {
+		t.Errorf("out[7] dated %s, want %s", out[7].Date, a[7].Date)
+	}
+}
+
+func TestRollingCorrelationAlignsOnDate(t *testing.T) {
+	a := makeObs(2020, 1, 1, 2, math.NaN(), 4, 5)
+	b := makeObs(2020, 2, 3, 5, 4, 6)
+	out, err := analyze.RollingCorrelation(a, b, 3)
+	if err != nil {
+		t.Fatalf("RollingCorrelation: %v", err)
+	}
+	// Shared non-NaN dates: Feb, Apr, May.
+	if len(out) != 3 || out[0].Date.Month() != time.February {
+		t.Fatalf("unexpected alignment: %+v", out)
+	}
+	if _, err := analyze.RollingCorrelation(a, b, 4); err == nil {
+		t.Error("expected error when the window exceeds the aligned length")
+	}
+	if _, err := analyze.RollingCorrelation(a, b, 2); err == nil {
+		t.Error("expected error for a window below 3")
+	}
+}
+
 func TestRegimeCUSUMBasic(t *testing.T) {
 	obs := makeObs(2020, 1, 1, 1, 1, 1, 10, 10, 10, 10)
 	res, err := analyze.RegimeCUSUM("X", obs, 2.0)
@@ -569,3 +1253,346 @@ func TestRegimeCUSUMRespectsMinGap(t *testing.T) {
 		}
 	}
 }
+
+// ─── Seasonal Subseries ───────────────────────────────────────────────────────
+
+func TestSeasonalSubseriesTwoYearMatrix(t *testing.T) {
+	values := make([]float64, 24)
+	for i := range values {
+		values[i] = float64(i + 1) // 2020: 1..12, 2021: 13..24
+	}
+	values[14] = math.NaN() // 2021-03 missing
+	res, err := analyze.SeasonalSubseries("TEST", makeObs(2020, 1, values...))
+	if err != nil {
+		t.Fatalf("SeasonalSubseries: %v", err)
+	}
+	if len(res.Years) != 2 || res.Years[0] != 2020 || res.Years[1] != 2021 {
+		t.Fatalf("years: expected [2020 2021], got %v", res.Years)
+	}
+	if len(res.Months) != 12 {
+		t.Fatalf("expected 12 month rows, got %d", len(res.Months))
+	}
+	for i, row := range res.Months {
+		if len(row.Values) != 2 {
+			t.Fatalf("month %d: expected 2 columns, got %d", i+1, len(row.Values))
+		}
+	}
+
+	jan := res.Months[0]
+	if jan.Name != "Jan" || jan.Values[0] != 1 || jan.Values[1] != 13 || !approxEqual(jan.Mean, 7, 1e-9) {
+		t.Errorf("Jan row unexpected: %+v", jan)
+	}
+	dec := res.Months[11]
+	if dec.Values[0] != 12 || dec.Values[1] != 24 || !approxEqual(dec.Mean, 18, 1e-9) {
+		t.Errorf("Dec row unexpected: %+v", dec)
+	}
+	mar := res.Months[2]
+	if mar.Values[0] != 3 || !isNaN(mar.Values[1]) {
+		t.Errorf("Mar row: expected [3 NaN], got %v", mar.Values)
+	}
+	if !approxEqual(mar.Mean, 3, 1e-9) {
+		t.Errorf("Mar mean should skip the missing cell: got %g", mar.Mean)
+	}
+}
+
+func TestSeasonalSubseriesPartialYearIsMissing(t *testing.T) {
+	res, err := analyze.SeasonalSubseries("TEST", makeObs(2020, 11, 1, 2, 3))
+	if err != nil {
+		t.Fatalf("SeasonalSubseries: %v", err)
+	}
+	if !isNaN(res.Months[0].Values[0]) || res.Months[0].Values[1] != 3 {
+		t.Errorf("Jan: expected [NaN 3], got %v", res.Months[0].Values)
+	}
+	if !isNaN(res.Months[5].Mean) {
+		t.Errorf("Jun has no data; mean should be NaN, got %g", res.Months[5].Mean)
+	}
+}
+
+func TestSeasonalSubseriesRejectsNonMonthly(t *testing.T) {
+	obs := []model.Observation{
+		{Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Value: 1},
+		{Date: time.Date(2020, 1, 8, 0, 0, 0, 0, time.UTC), Value: 2},
+	}
+	if _, err := analyze.SeasonalSubseries("TEST", obs); err == nil {
+		t.Fatal("expected error for weekly data")
+	}
+	if _, err := analyze.SeasonalSubseries("TEST", nil); err == nil {
+		t.Fatal("expected error for empty input")
+	}
+}
+
+// ─── HalfLife ─────────────────────────────────────────────────────────────────
+
+func TestHalfLifeRecoversAR1(t *testing.T) {
+	// y[t] = 10 + 0.9·(y[t-1] − 10) + ε  ⇒  β = −0.1, half-life = −ln2/ln(0.9) ≈ 6.58.
+	const phi = 0.9
+	rng := rand.New(rand.NewSource(42))
+	values := make([]float64, 2000)
+	values[0] = 10
+	for i := 1; i < len(values); i++ {
+		values[i] = 10 + phi*(values[i-1]-10) + rng.NormFloat64()*0.5
+	}
+	res, err := analyze.HalfLife("TEST", makeObs(1900, 1, values...))
+	if err != nil {
+		t.Fatalf("HalfLife: %v", err)
+	}
+	want := -math.Ln2 / math.Log(phi)
+	if !res.MeanReverting {
+		t.Fatalf("expected mean-reverting, got beta=%v", res.Beta)
+	}
+	if !approxEqual(res.HalfLifePeriods, want, 1.0) {
+		t.Errorf("half-life periods: expected ≈%.2f, got %.2f", want, res.HalfLifePeriods)
+	}
+	if res.PeriodsPerYear != 12 {
+		t.Errorf("periods per year: expected 12, got %v", res.PeriodsPerYear)
+	}
+	if !approxEqual(res.HalfLifeYears, res.HalfLifePeriods/res.PeriodsPerYear, 1e-9) {
+		t.Errorf("half-life years inconsistent: %v", res.HalfLifeYears)
+	}
+}
+
+func TestHalfLifeTrendIsNotMeanReverting(t *testing.T) {
+	res, err := analyze.HalfLife("TEST", makeAnnual(2000, 1, 2, math.NaN(), 4, 5, 6, 7))
+	if err != nil {
+		t.Fatalf("HalfLife: %v", err)
+	}
+	if res.MeanReverting || !isNaN(res.HalfLifePeriods) {
+		t.Errorf("expected not mean-reverting with NaN half-life, got %+v", res)
+	}
+	if res.Count != 4 {
+		t.Errorf("expected 4 valid pairs, got %d", res.Count)
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"half_life_periods":null`) {
+		t.Errorf("expected null half-life in JSON, got %s", b)
+	}
+}
+
+func TestHalfLifeTooFewPairs(t *testing.T) {
+	if _, err := analyze.HalfLife("TEST", makeObs(2020, 1, 1, 2, math.NaN(), 3)); err == nil {
+		t.Error("expected error for fewer than 3 valid pairs")
+	}
+}
+
+// ─── Laspeyres ────────────────────────────────────────────────────────────────
+
+func TestLaspeyresContributionsReconcile(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	res, err := analyze.Laspeyres([]analyze.WeightedSeries{
+		{SeriesID: "A", Weight: 60, Obs: makeObs(2020, 1, 100, 105, 110)},
+		{SeriesID: "B", Weight: 40, Obs: makeObs(2019, 12, 48, 50, 47, 45, 44)},
+	}, base)
+	if err != nil {
+		t.Fatalf("Laspeyres: %v", err)
+	}
+	// B's 2020-04 value has no A counterpart, so the last common date is 2020-03.
+	if res.EndDate != "2020-03-01" || len(res.Composite) != 3 {
+		t.Fatalf("expected 3 common dates ending 2020-03-01, got %d ending %s", len(res.Composite), res.EndDate)
+	}
+	// 0.6·110 + 0.4·90 = 102
+	if !approxEqual(res.CompositeEnd, 102, 1e-9) || !approxEqual(res.CompositeChange, 2, 1e-9) {
+		t.Errorf("composite: expected 102 (+2), got %v (%+v)", res.CompositeEnd, res.CompositeChange)
+	}
+	var sum float64
+	for _, c := range res.Components {
+		sum += c.Contribution
+	}
+	if !approxEqual(sum, res.CompositeChange, 1e-9) {
+		t.Errorf("contributions sum to %v, composite change is %v", sum, res.CompositeChange)
+	}
+	a, b := res.Components[0], res.Components[1]
+	if !approxEqual(a.Weight, 0.6, 1e-12) || !approxEqual(a.Contribution, 6, 1e-9) || !approxEqual(b.Contribution, -4, 1e-9) {
+		t.Errorf("unexpected contributions: %+v %+v", a, b)
+	}
+	if !approxEqual(a.ShareOfChange, 300, 1e-9) {
+		t.Errorf("A share of change: expected 300%%, got %v", a.ShareOfChange)
+	}
+	if !approxEqual(res.Composite[0].Value, 100, 1e-9) {
+		t.Errorf("composite at base should be 100, got %v", res.Composite[0].Value)
+	}
+}
+
+func TestLaspeyresErrors(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string][]analyze.WeightedSeries{
+		"no components":  nil,
+		"zero weight":    {{SeriesID: "A", Weight: 0, Obs: makeObs(2020, 1, 1, 2)}},
+		"duplicate":      {{SeriesID: "A", Weight: 1, Obs: makeObs(2020, 1, 1, 2)}, {SeriesID: "A", Weight: 1, Obs: makeObs(2020, 1, 1, 2)}},
+		"base missing":   {{SeriesID: "A", Weight: 1, Obs: makeObs(2020, 2, 1, 2)}},
+		"no overlap":     {{SeriesID: "A", Weight: 1, Obs: makeObs(2020, 1, 1, 2)}, {SeriesID: "B", Weight: 1, Obs: makeObs(2019, 12, 1, 2)}},
+		"base value NaN": {{SeriesID: "A", Weight: 1, Obs: makeObs(2020, 1, math.NaN(), 2)}},
+	}
+	for name, comps := range cases {
+		if _, err := analyze.Laspeyres(comps, base); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLaspeyresNonOverlappingComponents(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := analyze.Laspeyres([]analyze.WeightedSeries{
+		{SeriesID: "A", Weight: 1, Obs: makeObs(2020, 1, 100, 101, 102)},
+		{SeriesID: "B", Weight: 1, Obs: makeObs(2021, 1, 50, 51, 52)},
+	}, base)
+	if err == nil || !strings.Contains(err.Error(), "share no observation dates") {
+		t.Fatalf("expected a no-shared-dates error, got %v", err)
+	}
+}
+
+func TestLaspeyresMisalignedComponentsShareOneBase(t *testing.T) {
+	// Mid-February anchors the monthly series at 2020-02-01 and the quarterly
+	// one at 2020-01-01 on their own; both must be anchored at one shared date.
+	base := time.Date(2020, 2, 15, 0, 0, 0, 0, time.UTC)
+	quarterly := []model.Observation{
+		{Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Value: 50},
+		{Date: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), Value: 55},
+		{Date: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), Value: 60},
+	}
+	res, err := analyze.Laspeyres([]analyze.WeightedSeries{
+		{SeriesID: "M", Weight: 1, Obs: makeObs(2020, 1, 100, 200, 300, 110, 400, 500, 120)},
+		{SeriesID: "Q", Weight: 1, Obs: quarterly},
+	}, base)
+	if err != nil {
+		t.Fatalf("Laspeyres: %v", err)
+	}
+	if res.BaseDate != "2020-01-01" || res.EndDate != "2020-07-01" {
+		t.Fatalf("expected base 2020-01-01 and end 2020-07-01, got %s and %s", res.BaseDate, res.EndDate)
+	}
+	if !approxEqual(res.Composite[0].Value, 100, 1e-9) {
+		t.Errorf("composite at shared base should be 100, got %v", res.Composite[0].Value)
+	}
+	// M: 120/100·100 = 120, Q: 60/50·100 = 120.
+	if !approxEqual(res.Components[0].Rebased, 120, 1e-9) || !approxEqual(res.Components[1].Rebased, 120, 1e-9) {
+		t.Errorf("unexpected rebased values: %+v", res.Components)
+	}
+}
+
+func TestLinearFit(t *testing.T) {
+	slope, intercept, rsq, err := analyze.LinearFit([]float64{1, 2, 3, 4}, []float64{3, 5, 7, 9})
+	if err != nil {
+		t.Fatalf("LinearFit: %v", err)
+	}
+	if !approxEqual(slope, 2, 1e-9) || !approxEqual(intercept, 1, 1e-9) || !approxEqual(rsq, 1, 1e-9) {
+		t.Errorf("expected slope=2 intercept=1 r2=1, got %g %g %g", slope, intercept, rsq)
+	}
+	if _, _, _, err := analyze.LinearFit([]float64{1}, []float64{1}); err == nil {
+		t.Error("expected error for a single point")
+	}
+	if _, _, _, err := analyze.LinearFit([]float64{1, 2}, []float64{1}); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
+
+// ─── Decompose ────────────────────────────────────────────────────────────────
+
+func TestDecomposeAdditiveRecoversComponents(t *testing.T) {
+	// y = 100 + t + s[t mod 4], with a zero-sum seasonal pattern.
+	pattern := []float64{3, -1, -4, 2}
+	values := make([]float64, 16)
+	for i := range values {
+		values[i] = 100 + float64(i) + pattern[i%4]
+	}
+	res, err := analyze.Decompose(makeObs(2020, 1, values...), 4, analyze.DecomposeAdditive)
+	if err != nil {
+		t.Fatalf("Decompose: %v", err)
+	}
+	if len(res.Trend) != 16 || len(res.Seasonal) != 16 || len(res.Residual) != 16 {
+		t.Fatalf("components should align with input, got %d/%d/%d", len(res.Trend), len(res.Seasonal), len(res.Residual))
+	}
+	for _, i := range []int{0, 1, 14, 15} {
+		if !isNaN(res.Trend[i].Value) || !isNaN(res.Residual[i].Value) || res.Trend[i].ValueRaw != "." {
+			t.Errorf("index %d: trend and residual should be NaN at the ends, got %+v / %+v", i, res.Trend[i], res.Residual[i])
+		}
+	}
+	for i := 2; i < 14; i++ {
+		if !approxEqual(res.Trend[i].Value, 100+float64(i), 1e-9) {
+			t.Errorf("trend[%d]: expected %v, got %v", i, 100+float64(i), res.Trend[i].Value)
+		}
+		if !approxEqual(res.Residual[i].Value, 0, 1e-9) {
+			t.Errorf("residual[%d]: expected 0, got %v", i, res.Residual[i].Value)
+		}
+	}
+	for i := range values {
+		if !approxEqual(res.Seasonal[i].Value, pattern[i%4], 1e-9) {
+			t.Errorf("seasonal[%d]: expected %v, got %v", i, pattern[i%4], res.Seasonal[i].Value)
+		}
+		if !res.Seasonal[i].Date.Equal(res.Trend[i].Date) {
+			t.Errorf("index %d: component dates differ", i)
+		}
+	}
+}
+
+func TestDecomposeMultiplicative(t *testing.T) {
+	pattern := []float64{1.2, 0.8, 1.1, 0.9}
+	values := make([]float64, 12)
+	for i := range values {
+		values[i] = 50 * pattern[i%4]
+	}
+	res, err := analyze.Decompose(makeObs(2020, 1, values...), 4, analyze.DecomposeMultiplicative)
+	if err != nil {
+		t.Fatalf("Decompose: %v", err)
+	}
+	for i := 2; i < 10; i++ {
+		if !approxEqual(res.Trend[i].Value, 50, 1e-9) || !approxEqual(res.Residual[i].Value, 1, 1e-9) {
+			t.Errorf("index %d: expected trend 50 and residual 1, got %v and %v", i, res.Trend[i].Value, res.Residual[i].Value)
+		}
+	}
+	for i := range values {
+		if !approxEqual(res.Seasonal[i].Value, pattern[i%4], 1e-9) {
+			t.Errorf("seasonal[%d]: expected %v, got %v", i, pattern[i%4], res.Seasonal[i].Value)
+		}
+	}
+}
+
+func TestDecomposeSkipsNaNInSeasonalAverage(t *testing.T) {
+	values := []float64{1, 5, 1, 5, 1, 5, math.NaN(), 5, 1, 5, 1, 5}
+	res, err := analyze.Decompose(makeObs(2020, 1, values...), 2, analyze.DecomposeAdditive)
+	if err != nil {
+		t.Fatalf("Decompose: %v", err)
+	}
+	if !approxEqual(res.Seasonal[0].Value, -2, 1e-9) || !approxEqual(res.Seasonal[1].Value, 2, 1e-9) {
+		t.Errorf("expected seasonal [-2 2], got [%v %v]", res.Seasonal[0].Value, res.Seasonal[1].Value)
+	}
+	for i := 5; i <= 7; i++ {
+		if !isNaN(res.Trend[i].Value) {
+			t.Errorf("trend[%d]: window touches the missing value, expected NaN, got %v", i, res.Trend[i].Value)
+		}
+	}
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), `"trend":null`) {
+		t.Errorf("expected null trend in JSON, got %s", b)
+	}
+}
+
+func TestDecomposeErrors(t *testing.T) {
+	cases := map[string]func() error{
+		"unknown model": func() error {
+			_, err := analyze.Decompose(makeObs(2020, 1, 1, 2, 3, 4), 2, "log")
+			return err
+		},
+		"period too small": func() error {
+			_, err := analyze.Decompose(makeObs(2020, 1, 1, 2, 3, 4), 1, analyze.DecomposeAdditive)
+			return err
+		},
+		"too few observations": func() error {
+			_, err := analyze.Decompose(makeObs(2020, 1, 1, 2, 3), 2, analyze.DecomposeAdditive)
+			return err
+		},
+		"multiplicative non-positive": func() error {
+			_, err := analyze.Decompose(makeObs(2020, 1, 1, 2, 0, 4), 2, analyze.DecomposeMultiplicative)
+			return err
+		},
+	}
+	for name, run := range cases {
+		if run() == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
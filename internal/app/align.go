@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/transform"
+)
+
+// AlignSeries puts two series on one date grid. Each is read from the local
+// store when a full history is cached there, and fetched live otherwise. Both
+// are resampled to freq with aggMethod, cut to the date range they have in
+// common, and joined by method (inner, outer, or left). An empty freq picks
+// the coarser of the two series' own frequencies, monthly at the finest.
+//
+// The returned series are in ids order and report the same dates; a date
+// missing from one side under an outer or left join has a NaN value.
+func AlignSeries(ctx context.Context, deps *Deps, ids []string, method transform.JoinMode, freq transform.ResampleFreq, aggMethod transform.ResampleMethod) ([]*model.SeriesData, error) {
+	if len(ids) != 2 {
+		return nil, fmt.Errorf("align: expected 2 series, got %d", len(ids))
+	}
+	switch method {
+	case transform.JoinInner, transform.JoinOuter, transform.JoinLeft:
+	default:
+		return nil, fmt.Errorf("align: unknown method %q (use inner, outer, or left)", method)
+	}
+	switch freq {
+	case "", transform.ResampleMonthly, transform.ResampleQuarterly, transform.ResampleAnnual:
+	default:
+		return nil, fmt.Errorf("align: unknown frequency %q (use monthly, quarterly, or annual)", freq)
+	}
+	switch aggMethod {
+	case transform.ResampleMean, transform.ResampleLast, transform.ResampleSum:
+	default:
+		return nil, fmt.Errorf("align: unknown aggregation method %q (use mean, last, or sum)", aggMethod)
+	}
+
+	loaded := make([][]model.Observation, len(ids))
+	for i, id := range ids {
+		obs, err := LoadObservations(ctx, deps, id)
+		if err != nil {
+			return nil, err
+		}
+		loaded[i] = obs
+	}
+	if freq == "" {
+		freq = coarsestResampleFreq(loaded)
+	}
+
+	resampled := make([][]model.Observation, len(ids))
+	lo, hi := time.Time{}, time.Time{}
+	for i, obs := range loaded {
+		r, err := transform.Resample(obs, freq, aggMethod)
+		if err != nil {
+			return nil, fmt.Errorf("align: %s: %w", ids[i], err)
+		}
+		first, last, ok := observedDates(r)
+		if !ok {
+			return nil, fmt.Errorf("align: %s has no observed values", ids[i])
+		}
+		if lo.IsZero() || first.After(lo) {
+			lo = first
+		}
+		if hi.IsZero() || last.Before(hi) {
+			hi = last
+		}
+		resampled[i] = r
+	}
+	if lo.After(hi) {
+		return nil, fmt.Errorf("align: %s and %s have no dates in common", ids[0], ids[1])
+	}
+	for i := range resampled {
+		resampled[i] = transform.Filter(resampled[i], transform.DateWindow(lo, hi))
+	}
+
+	rows, err := transform.Join(resampled[0], resampled[1], method)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*model.SeriesData, len(ids))
+	for i, id := range ids {
+		byDate := make(map[time.Time]model.Observation, len(resampled[i]))
+		for _, o := range resampled[i] {
+			byDate[o.Date] = o
+		}
+		data := &model.SeriesData{SeriesID: id, Obs: make([]model.Observation, len(rows))}
+		for j, row := range rows {
+			o, ok := byDate[row.Date]
+			if !ok {
+				o = model.Observation{Date: row.Date, Value: math.NaN(), ValueRaw: "."}
+			}
+			data.Obs[j] = o
+		}
+		out[i] = data
+	}
+	return out, nil
+}
+
+// coarsestResampleFreq maps each series' detected frequency onto a resample
+// target and returns the coarsest. Anything finer than monthly, and irregular
+// series, count as monthly.
+func coarsestResampleFreq(series [][]model.Observation) transform.ResampleFreq {
+	rank := map[string]int{model.FrequencyQuarterly: 1, model.FrequencyAnnual: 2}
+	best := 0
+	for _, obs := range series {
+		freq, _ := model.DetectFrequency(obs)
+		if rank[freq] > best {
+			best = rank[freq]
+		}
+	}
+	return []transform.ResampleFreq{transform.ResampleMonthly, transform.ResampleQuarterly, transform.ResampleAnnual}[best]
+}
+
+// observedDates returns the first and last dates that carry a value.
+func observedDates(obs []model.Observation) (time.Time, time.Time, bool) {
+	var first, last time.Time
+	found := false
+	for _, o := range obs {
+		if math.IsNaN(o.Value) {
+			continue
+		}
+		if !found {
+			first = o.Date
+			found = true
+		}
+		last = o.Date
+	}
+	return first, last, found
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package app
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/store"
+	"github.com/derickschaefer/reserve/internal/transform"
+)
+
+func monthStart(year, month int) time.Time {
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// alignTestDeps caches quarterly GDP for 2020Q1–2021Q1 and monthly CPI for
+// Dec 2019–Dec 2020 with all of 2020Q3 missing.
+func alignTestDeps(t *testing.T) (*Deps, []model.Observation) {
+	t.Helper()
+	s, err := store.Open(filepath.Join(t.TempDir(), "reserve.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	var gdp, cpi []model.Observation
+	for i, v := range []float64{100, 90, 95, 98, 101} {
+		gdp = append(gdp, model.Observation{Date: monthStart(2020, 1+3*i), Value: v, ValueRaw: "x"})
+	}
+	for m := 0; m <= 12; m++ {
+		date := monthStart(2019, 12+m)
+		if date.Month() >= 7 && date.Month() <= 9 {
+			continue
+		}
+		cpi = append(cpi, model.Observation{Date: date, Value: 250 + float64(m)})
+	}
+	for id, obs := range map[string][]model.Observation{"GDP": gdp, "CPIAUCSL": cpi} {
+		if err := s.PutObs(store.ObsKey(id, "", "", "", "", ""), model.SeriesData{SeriesID: id, Obs: obs}); err != nil {
+			t.Fatalf("put %s: %v", id, err)
+		}
+	}
+	return &Deps{Config: &config.Config{}, Store: s}, cpi
+}
+
+func TestAlignSeriesJoinMethods(t *testing.T) {
+	deps, _ := alignTestDeps(t)
+	cases := []struct {
+		ids    []string
+		method transform.JoinMode
+		dates  int
+	}{
+		{[]string{"GDP", "CPIAUCSL"}, transform.JoinInner, 3},
+		{[]string{"GDP", "CPIAUCSL"}, transform.JoinOuter, 4},
+		{[]string{"GDP", "CPIAUCSL"}, transform.JoinLeft, 4},
+		{[]string{"CPIAUCSL", "GDP"}, transform.JoinLeft, 3},
+	}
+	for _, tc := range cases {
+		got, err := AlignSeries(context.Background(), deps, tc.ids, tc.method, "", transform.ResampleMean)
+		if err != nil {
+			t.Fatalf("%v %s: %v", tc.ids, tc.method, err)
+		}
+		if len(got) != 2 || got[0].SeriesID != tc.ids[0] || got[1].SeriesID != tc.ids[1] {
+			t.Fatalf("%v %s: unexpected series %+v", tc.ids, tc.method, got)
+		}
+		if len(got[0].Obs) != tc.dates || len(got[1].Obs) != tc.dates {
+			t.Fatalf("%v %s: expected %d dates, got %d and %d", tc.ids, tc.method, tc.dates, len(got[0].Obs), len(got[1].Obs))
+		}
+		for i := range got[0].Obs {
+			if !got[0].Obs[i].Date.Equal(got[1].Obs[i].Date) {
+				t.Errorf("%v %s: row %d dates differ", tc.ids, tc.method, i)
+			}
+		}
+		// The common range is 2020Q1–2020Q4: GDP's 2021Q1 and CPI's 2019Q4 drop.
+		if first := got[0].Obs[0].Date; !first.Equal(monthStart(2020, 1)) {
+			t.Errorf("%v %s: first date %s, want 2020-01-01", tc.ids, tc.method, first.Format("2006-01-02"))
+		}
+		if last := got[0].Obs[tc.dates-1].Date; !last.Equal(monthStart(2020, 10)) {
+			t.Errorf("%v %s: last date %s, want 2020-10-01", tc.ids, tc.method, last.Format("2006-01-02"))
+		}
+	}
+
+	outer, _ := AlignSeries(context.Background(), deps, []string{"GDP", "CPIAUCSL"}, transform.JoinOuter, "", transform.ResampleMean)
+	if q3 := outer[1].Obs[2]; !q3.Date.Equal(monthStart(2020, 7)) || !math.IsNaN(q3.Value) || q3.ValueRaw != "." {
+		t.Errorf("outer join should leave CPI 2020Q3 empty, got %+v", q3)
+	}
+}
+
+func TestAlignSeriesMatchesResample(t *testing.T) {
+	deps, cpi := alignTestDeps(t)
+	got, err := AlignSeries(context.Background(), deps, []string{"GDP", "CPIAUCSL"}, transform.JoinInner, transform.ResampleQuarterly, transform.ResampleLast)
+	if err != nil {
+		t.Fatalf("AlignSeries: %v", err)
+	}
+	want, err := transform.Resample(cpi, transform.ResampleQuarterly, transform.ResampleLast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byDate := map[time.Time]model.Observation{}
+	for _, o := range want {
+		byDate[o.Date] = o
+	}
+	for _, o := range got[1].Obs {
+		if w := byDate[o.Date]; o != w {
+			t.Errorf("%s: aligned %+v, transform resample %+v", o.Date.Format("2006-01-02"), o, w)
+		}
+	}
+	// GDP is already quarterly, so resampling keeps its values.
+	if got[0].Obs[0].Value != 100 || got[0].Obs[2].Value != 98 {
+		t.Errorf("unexpected GDP values %+v", got[0].Obs)
+	}
+}
+
+func TestAlignSeriesRejects(t *testing.T) {
+	deps, _ := alignTestDeps(t)
+	ctx := context.Background()
+	if _, err := AlignSeries(ctx, deps, []string{"GDP"}, transform.JoinInner, "", transform.ResampleMean); err == nil {
+		t.Error("expected an error for one series")
+	}
+	if _, err := AlignSeries(ctx, deps, []string{"GDP", "CPIAUCSL"}, transform.JoinRight, "", transform.ResampleMean); err == nil {
+		t.Error("expected an error for the right join")
+	}
+	if _, err := AlignSeries(ctx, deps, []string{"GDP", "CPIAUCSL"}, transform.JoinInner, "weekly", transform.ResampleMean); err == nil {
+		t.Error("expected an error for a weekly target")
+	}
+	if _, err := AlignSeries(ctx, deps, []string{"GDP", "UNRATE"}, transform.JoinInner, "", transform.ResampleMean); err == nil {
+		t.Error("expected an error for an uncached series without an API key")
+	}
+}
@@ -6,10 +6,12 @@
 package app
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/derickschaefer/reserve/internal/config"
 	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/store"
 )
 
@@ -24,19 +26,46 @@ type Deps struct {
 // Store open failures are non-fatal: Deps.Store will be nil and live API
 // commands still work. Commands that require persistence call RequireStore().
 func New(cfg *config.Config) *Deps {
-	client := fred.NewClient(
-		cfg.APIKey,
-		cfg.BaseURL,
-		cfg.Timeout,
-		cfg.Rate,
-		cfg.Debug,
-	)
+	return newDeps(cfg, store.Open)
+}
+
+// NewReadOnly is New for commands that only inspect the store. The database
+// is opened with a shared lock so concurrent inspections do not contend with
+// each other. If that is not possible (no database yet, or one that still
+// needs migrating) it falls back to a normal read-write open.
+func NewReadOnly(cfg *config.Config) *Deps {
+	return newDeps(cfg, func(path string) (*store.Store, error) {
+		if s, err := store.OpenReadOnly(path); err == nil {
+			return s, nil
+		}
+		return store.Open(path)
+	})
+}
+
+// NewClient returns the FRED client described by cfg without opening the
+// store, for commands that only talk to the API.
+func NewClient(cfg *config.Config) *fred.Client {
+	return fred.NewClientWithOptions(fred.ClientOptions{
+		APIKey:       cfg.APIKey,
+		BaseURL:      cfg.BaseURL,
+		Timeout:      cfg.Timeout,
+		RatePerSec:   cfg.Rate,
+		MaxIdleConns: cfg.Concurrency, // one pooled connection per batch worker
+
+		CircuitBreakThreshold: cfg.CircuitBreakThreshold,
+		CircuitBreakTimeout:   cfg.CircuitBreakTimeout,
+		ProxyURL:              cfg.ProxyURL,
+		Debug:                 cfg.Debug,
+	})
+}
+
+func newDeps(cfg *config.Config, open func(string) (*store.Store, error)) *Deps {
 	d := &Deps{
 		Config: cfg,
-		Client: client,
+		Client: NewClient(cfg),
 	}
 	if cfg.DBPath != "" {
-		if s, err := store.Open(cfg.DBPath); err == nil {
+		if s, err := open(cfg.DBPath); err == nil {
 			d.Store = s
 		}
 		// Silently continue — live API commands work without the store.
@@ -63,3 +92,26 @@ func (d *Deps) Close() {
 		d.Store.Close()
 	}
 }
+
+// LoadObservations returns the full history of id from the local store, or
+// fetches it live when none is stored or the cache is bypassed. Date-windowed
+// cache entries are not consulted.
+func LoadObservations(ctx context.Context, d *Deps, id string) ([]model.Observation, error) {
+	if d.Store != nil && !d.Config.NoCache && !d.Config.Refresh {
+		data, ok, err := d.Store.GetObs(store.ObsKey(id, "", "", "", "", ""))
+		if err != nil {
+			return nil, fmt.Errorf("reading cache: %w", err)
+		}
+		if ok && len(data.Obs) > 0 {
+			return data.Obs, nil
+		}
+	}
+	if d.Config.APIKey == "" {
+		return nil, fmt.Errorf("no cached observations for %s and no API key to fetch them", id)
+	}
+	data, err := d.Client.GetObservations(ctx, id, fred.ObsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return data.Obs, nil
+}
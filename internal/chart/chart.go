@@ -2,14 +2,26 @@
 // Licensed under the MIT License. See LICENSE file for details.
 
 // Package chart provides ASCII terminal chart rendering for time series data.
-// Two renderers are available:
+// Six terminal renderers are available:
 //
 //   - Bar: horizontal bar chart, one bar per observation — best for low-frequency
 //     or resampled series (annual, quarterly)
-//   - Plot: multi-line ASCII chart with labeled axes — best for continuous series
+//   - Plot: multi-line ASCII chart with labeled axes — best for continuous series;
+//     PlotMulti overlays several series on the same chart
+//   - Sparkline: single-line block chart — compact enough to sit in a table cell
+//   - Scatter: one series against another, joined on date, with an optional
+//     OLS fit line
+//   - Histogram: horizontal bars counting values per bucket — the shape of a
+//     series' distribution rather than its path over time
+//   - HeatMap: a year × month (or quarter) grid of shaded cells — seasonality
+//     at a glance
 //
-// Both renderers handle NaN values gracefully (as gaps, not zeros) and require
-// no external dependencies beyond the Go standard library.
+// PlotSVG and BarSVG render the same charts as Plot and Bar as standalone SVG
+// images for pasting into documents.
+//
+// All renderers handle NaN values gracefully (as gaps, not zeros) and require
+// no external dependencies beyond the Go standard library. Bar and Plot can
+// wrap their glyphs in ANSI color codes; see ColorEnabled.
 package chart
 
 import (
@@ -17,9 +29,12 @@ import (
 	"io"
 	"math"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/derickschaefer/reserve/internal/analyze"
 	"github.com/derickschaefer/reserve/internal/model"
 )
 
@@ -34,6 +49,9 @@ type BarOptions struct {
 	// If the series has more observations than MaxBars, it is resampled
 	// by taking the last value of each bucket. If 0, no limit is applied.
 	MaxBars int
+	// Color paints bars at or above the series mean green, bars below it
+	// red, and zero bars yellow.
+	Color bool
 }
 
 // Bar renders a horizontal bar chart of obs to w, one bar per observation.
@@ -86,14 +104,15 @@ func Bar(w io.Writer, seriesID string, obs []model.Observation, opts BarOptions)
 		}
 	}
 
-	// Date label width — use the longest date string in the series
-	dateFmt := "2006-01-02"
-	if isMonthly(valid) {
-		dateFmt = "2006-01"
-	}
-	if isAnnual(valid) {
-		dateFmt = "2006"
+	// Bars are colored against the mean of the plotted values.
+	mean := 0.0
+	for _, o := range valid {
+		mean += o.Value
 	}
+	mean /= float64(len(valid))
+
+	// Date label width — use the longest date string in the series
+	dateFmt := dateLabelLayout(valid)
 	dateWidth := len(valid[0].Date.Format(dateFmt))
 
 	// Value label width
@@ -136,6 +155,9 @@ func Bar(w io.Writer, seriesID string, obs []model.Observation, opts BarOptions)
 		var bar string
 		if hasNeg {
 			bar = buildBiBar(o.Value, minVal, maxVal, barAreaWidth, zeroPos)
+			if opts.Color {
+				bar = colorBlocks(bar, barColor(o.Value, mean))
+			}
 		} else {
 			barLen := int(math.Round((o.Value - minVal) / valRange * float64(barAreaWidth)))
 			if barLen < 1 {
@@ -145,6 +167,9 @@ func Bar(w io.Writer, seriesID string, obs []model.Observation, opts BarOptions)
 				barLen = barAreaWidth
 			}
 			bar = strings.Repeat("█", barLen)
+			if opts.Color {
+				bar = colorBlocks(bar, barColor(o.Value, mean))
+			}
 		}
 
 		fmt.Fprintf(w, "%-*s  %*s  %s\n",
@@ -191,32 +216,208 @@ func buildBiBar(val, minVal, maxVal float64, barAreaWidth, zeroPos int) string {
 	return string(buf)
 }
 
-// isMonthly returns true if observations appear to be monthly frequency.
-func isMonthly(obs []model.Observation) bool {
-	if len(obs) < 2 {
-		return false
+// ─── Histogram ───────────────────────────────────────────────────────────────
+
+// SturgesBins returns Sturges' rule bin count for n values: ⌈log₂ n⌉ + 1.
+func SturgesBins(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return int(math.Ceil(math.Log2(float64(n)))) + 1
+}
+
+// Histogram renders the distribution of obs to w as horizontal bars, one per
+// equal-width value bucket, labeled with the bucket midpoint and its count.
+// bins <= 0 picks the bin count with SturgesBins. NaN values are excluded and
+// counted in a note below the chart. Only opts.Width and opts.Color apply.
+//
+// Output example:
+//
+//	UNRATE  distribution of 12 values, 4 bins
+//	3.81  6  ████████████████████
+//	4.44  3  ██████████
+//	5.06  2  ███████
+//	5.69  1  ███
+func Histogram(w io.Writer, seriesID string, obs []model.Observation, bins int, opts BarOptions) error {
+	totalWidth := opts.Width
+	if totalWidth <= 0 {
+		totalWidth = termWidth()
 	}
-	// Check if day component is always 1 and months differ
+
+	var values []float64
+	missing := 0
 	for _, o := range obs {
-		if o.Date.Day() != 1 {
-			return false
+		if math.IsNaN(o.Value) {
+			missing++
+			continue
 		}
+		values = append(values, o.Value)
+	}
+	if len(values) == 0 {
+		return fmt.Errorf("chart histogram: no non-NaN observations to render")
+	}
+	if bins <= 0 {
+		bins = SturgesBins(len(values))
+	}
+
+	minVal, maxVal := minMax(values)
+	if minVal == maxVal {
+		bins = 1 // a constant series has one bucket
 	}
-	return true
+	binWidth := (maxVal - minVal) / float64(bins)
+	counts := make([]int, bins)
+	for _, v := range values {
+		i := bins - 1
+		if binWidth > 0 {
+			i = int((v - minVal) / binWidth)
+		}
+		if i >= bins {
+			i = bins - 1 // the maximum falls on the last edge
+		}
+		counts[i]++
+	}
+
+	labels := make([]string, bins)
+	labelWidth, countWidth, maxCount := 0, 0, 0
+	for i, c := range counts {
+		labels[i] = formatBarValue(minVal + (float64(i)+0.5)*binWidth)
+		labelWidth = max(labelWidth, len(labels[i]))
+		countWidth = max(countWidth, len(strconv.Itoa(c)))
+		maxCount = max(maxCount, c)
+	}
+	barAreaWidth := max(totalWidth-labelWidth-countWidth-4, 4)
+
+	fmt.Fprintf(w, "%s  distribution of %d values, %d bins\n", seriesID, len(values), bins)
+	for i, c := range counts {
+		barLen := int(math.Round(float64(c) / float64(maxCount) * float64(barAreaWidth)))
+		if c > 0 && barLen < 1 {
+			barLen = 1 // a non-empty bucket is always visible
+		}
+		bar := strings.Repeat("█", barLen)
+		if opts.Color {
+			bar = colorBlocks(bar, ansiCyan)
+		}
+		fmt.Fprintf(w, "%*s  %*d  %s\n", labelWidth, labels[i], countWidth, c, bar)
+	}
+	if missing > 0 {
+		fmt.Fprintf(w, "\n%d missing (NaN) values excluded\n", missing)
+	}
+	return nil
 }
 
-// isAnnual returns true if observations appear to be annual frequency.
-func isAnnual(obs []model.Observation) bool {
+// ─── HeatMap ─────────────────────────────────────────────────────────────────
+
+// HeatShades are the HeatMap cell glyphs from the lowest value to the highest.
+const HeatShades = " ░▒▓█"
+
+// HeatMap renders a monthly or quarterly series as a grid with one row per
+// year and one column per month (or quarter), each cell shaded by where its
+// value sits in the series' range. NaN values show as "." and periods with no
+// observation, such as the ends of a partial year, are left blank. The
+// frequency is detected from the median spacing of dates; other frequencies
+// are an error.
+//
+// Output example:
+//
+//	UNRATE  monthly heatmap (2020-01 to 2021-12)
+//	      Jan Feb Mar Apr May Jun Jul Aug Sep Oct Nov Dec
+//	2020          ███ ▓▓▓ ▓▓▓ ▒▒▒ ▒▒▒ ░░░ ░░░ ░░░ ░░░  .
+//	2021  ░░░ ░░░ ░░░ ░░░ ░░░ ░░░
+//	      " ░▒▓█" = 3.5 to 14.7; . = missing
+func HeatMap(w io.Writer, seriesID string, obs []model.Observation) error {
 	if len(obs) < 2 {
-		return false
+		return fmt.Errorf("chart heatmap: need at least 2 observations (got %d)", len(obs))
+	}
+	sorted := append([]model.Observation(nil), obs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+	gaps := make([]float64, len(sorted)-1)
+	for i := 1; i < len(sorted); i++ {
+		gaps[i-1] = sorted[i].Date.Sub(sorted[i-1].Date).Hours() / 24
 	}
-	for i := 1; i < len(obs) && i < 5; i++ {
-		months := int(obs[i].Date.Sub(obs[i-1].Date).Hours() / 24 / 28)
-		if months < 10 {
-			return false
+	sort.Float64s(gaps)
+	medianGap := gaps[len(gaps)/2]
+
+	var freq string
+	var columns []string
+	var periodOf func(time.Time) int
+	switch {
+	case medianGap >= 28 && medianGap <= 31:
+		freq = "monthly"
+		for m := time.January; m <= time.December; m++ {
+			columns = append(columns, m.String()[:3])
 		}
+		periodOf = func(d time.Time) int { return int(d.Month()) - 1 }
+	case medianGap >= 89 && medianGap <= 92:
+		freq = "quarterly"
+		columns = []string{"Q1", "Q2", "Q3", "Q4"}
+		periodOf = func(d time.Time) int { return (int(d.Month()) - 1) / 3 }
+	default:
+		return fmt.Errorf("chart heatmap: expected monthly or quarterly observations, got a median spacing of %.0f days", medianGap)
 	}
-	return true
+
+	type cell struct{ year, period int }
+	values := make(map[cell]float64, len(sorted))
+	var valid []float64
+	for _, o := range sorted {
+		k := cell{o.Date.Year(), periodOf(o.Date)}
+		if _, dup := values[k]; dup {
+			return fmt.Errorf("chart heatmap: more than one %s value for %s", freq, o.Date.Format("2006-01"))
+		}
+		values[k] = o.Value
+		if !math.IsNaN(o.Value) {
+			valid = append(valid, o.Value)
+		}
+	}
+	if len(valid) == 0 {
+		return fmt.Errorf("chart heatmap: no non-NaN observations to render")
+	}
+	minVal, maxVal := minMax(valid)
+	shades := []rune(HeatShades)
+	shadeOf := func(v float64) rune {
+		if maxVal == minVal {
+			return shades[len(shades)/2]
+		}
+		i := int((v - minVal) / (maxVal - minVal) * float64(len(shades)))
+		return shades[min(i, len(shades)-1)]
+	}
+
+	const cellWidth = 3
+	fmt.Fprintf(w, "%s  %s heatmap (%s to %s)\n", seriesID, freq,
+		sorted[0].Date.Format("2006-01"), sorted[len(sorted)-1].Date.Format("2006-01"))
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = fmt.Sprintf("%-*s", cellWidth, c)
+	}
+	fmt.Fprintf(w, "      %s\n", strings.TrimRight(strings.Join(header, " "), " "))
+	for year := sorted[0].Date.Year(); year <= sorted[len(sorted)-1].Date.Year(); year++ {
+		cells := make([]string, len(columns))
+		for p := range columns {
+			v, ok := values[cell{year, p}]
+			switch {
+			case !ok:
+				cells[p] = strings.Repeat(" ", cellWidth)
+			case math.IsNaN(v):
+				cells[p] = " . "
+			default:
+				cells[p] = strings.Repeat(string(shadeOf(v)), cellWidth)
+			}
+		}
+		fmt.Fprintf(w, "%-4d  %s\n", year, strings.TrimRight(strings.Join(cells, " "), " "))
+	}
+	fmt.Fprintf(w, "      %q = %s to %s; . = missing\n", HeatShades, formatFloat(minVal), formatFloat(maxVal))
+	return nil
+}
+
+// dateLabelLayout shortens date labels to what the series' frequency
+// resolves: the month for monthly and quarterly data, the year for annual.
+func dateLabelLayout(obs []model.Observation) string {
+	switch freq, _ := model.DetectFrequency(obs); freq {
+	case model.FrequencyMonthly, model.FrequencyQuarterly:
+		return "2006-01"
+	case model.FrequencyAnnual:
+		return "2006"
+	}
+	return "2006-01-02"
 }
 
 // ─── Plot ─────────────────────────────────────────────────────────────────────
@@ -231,10 +432,68 @@ type PlotOptions struct {
 	Height int
 	// Title overrides the default title (seriesID). Empty = use seriesID.
 	Title string
+	// Order fixes the order PlotMulti draws its series in, and so their line
+	// styles. Empty = sorted series IDs.
+	Order []string
+	// MultiSeries makes Plot overlay every listed series, in order, instead
+	// of drawing its seriesID and obs arguments. See PlotMulti.
+	MultiSeries []SeriesPlotData
+	// SeparateAxes gives a multi-series chart a second scale: the first
+	// series is labelled on the left axis and the rest share the right.
+	SeparateAxes bool
+	// Color draws the line in blue; overlaid series cycle through yellow,
+	// magenta, and green.
+	Color bool
+	// Shades marks date ranges (e.g. recessions) by filling the empty cells of
+	// every Plot column they cover with ░. Ignored by PlotMulti.
+	Shades []DateRange
+	// LogScale places Plot rows on a log10 scale, with ticks at powers of ten
+	// labelled in original units. Every value must be positive. Ignored by
+	// PlotMulti.
+	LogScale bool
+}
+
+// SeriesPlotData is one series of a multi-series plot.
+type SeriesPlotData struct {
+	SeriesID string
+	Obs      []model.Observation
+}
+
+// DateRange is an inclusive span of dates.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ShadeGlyph fills the background of shaded Plot columns.
+const ShadeGlyph = '░'
+
+// IndicatorRanges returns the contiguous runs of obs, in order, whose value is
+// exactly 1 — the convention of indicator series such as USREC. Each range
+// runs from the first to the last observation date of its run; NaN and any
+// other value end a run.
+func IndicatorRanges(obs []model.Observation) []DateRange {
+	var out []DateRange
+	inRun := false
+	for _, o := range obs {
+		if o.Value == 1 {
+			if !inRun {
+				out = append(out, DateRange{Start: o.Date})
+				inRun = true
+			}
+			out[len(out)-1].End = o.Date
+			continue
+		}
+		inRun = false
+	}
+	return out
 }
 
 // Plot renders a multi-line ASCII chart of obs to w.
 func Plot(w io.Writer, seriesID string, obs []model.Observation, opts PlotOptions) error {
+	if len(opts.MultiSeries) > 0 {
+		return plotSeries(w, opts.MultiSeries, opts)
+	}
 	width := opts.Width
 	if width <= 0 {
 		width = termWidth()
@@ -258,6 +517,18 @@ func Plot(w io.Writer, seriesID string, obs []model.Observation, opts PlotOption
 	if len(validVals) < 2 {
 		return fmt.Errorf("chart plot: need at least 2 non-NaN observations (got %d)", len(validVals))
 	}
+	format := formatFloat
+	if opts.LogScale {
+		logObs, err := log10Obs(obs)
+		if err != nil {
+			return err
+		}
+		obs = logObs
+		for i, v := range validVals {
+			validVals[i] = math.Log10(v)
+		}
+		format = formatPow10
+	}
 
 	minVal, maxVal := validVals[0], validVals[0]
 	for _, v := range validVals[1:] {
@@ -271,12 +542,10 @@ func Plot(w io.Writer, seriesID string, obs []model.Observation, opts PlotOption
 
 	// Y-axis label width: measure the widest tick label
 	ticks := yTicks(minVal, maxVal, height)
-	yLabelWidth := 0
-	for _, t := range ticks {
-		if l := len(formatFloat(t)); l > yLabelWidth {
-			yLabelWidth = l
-		}
+	if opts.LogScale {
+		ticks = logTicks(minVal, maxVal, height)
 	}
+	yLabelWidth := labelWidth(ticks, format)
 	yAxisWidth := yLabelWidth + 2 // label + " ┤" or " ┼"
 
 	// Plot body width (number of data columns)
@@ -291,6 +560,7 @@ func Plot(w io.Writer, seriesID string, obs []model.Observation, opts PlotOption
 	// Build the grid: grid[row][col] = true means draw a character here
 	// row 0 = top (maxVal), row height-1 = bottom (minVal)
 	grid := buildGrid(cols, minVal, maxVal, height)
+	shadeGrid(grid, shadedCols(obs, plotWidth, opts.Shades))
 
 	// Print title + date range header
 	dateFirst := obs[0].Date.Format("2006-01")
@@ -300,30 +570,29 @@ func Plot(w io.Writer, seriesID string, obs []model.Observation, opts PlotOption
 	// Print rows top to bottom
 	for row := 0; row < height; row++ {
 		// Y-axis label: print on rows that have a tick
-		label := ""
-		for _, t := range ticks {
-			if math.Abs(rowForValue(t, minVal, maxVal, height)-float64(row)) < 0.5 {
-				label = formatFloat(t)
-				break
-			}
-		}
+		label := tickLabel(ticks, minVal, maxVal, height, row, format)
 		labelPadded := fmt.Sprintf("%*s", yLabelWidth, label)
 
 		// Axis character
 		axisCh := "┤"
-		if label != "" && math.Abs(minVal) < 1e-9 && row == height-1 {
+		if label != "" && !opts.LogScale && math.Abs(minVal) < 1e-9 && row == height-1 {
 			axisCh = "┼"
 		} else if label == "" {
 			axisCh = " "
 		}
 
 		// Build the data row
-		var rowSB strings.Builder
-		for col := 0; col < plotWidth; col++ {
-			rowSB.WriteRune(grid[row][col])
+		rowStr := string(grid[row])
+		if opts.Color {
+			rowStr = colorCells(grid[row], func(_ int, r rune) string {
+				if r == ShadeGlyph {
+					return ""
+				}
+				return ansiBlue
+			})
 		}
 
-		fmt.Fprintf(w, "%s%s%s\n", labelPadded, axisCh, rowSB.String())
+		fmt.Fprintf(w, "%s%s%s\n", labelPadded, axisCh, rowStr)
 	}
 
 	// Bottom axis line
@@ -337,6 +606,559 @@ func Plot(w io.Writer, seriesID string, obs []model.Observation, opts PlotOption
 	return nil
 }
 
+// ─── Sparkline ────────────────────────────────────────────────────────────────
+
+// DefaultSparkWidth is the sparkline width used when Sparkline is given width <= 0.
+const DefaultSparkWidth = 24
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders obs as a one-line chart of block-eighth characters scaled
+// over the non-NaN min/max. Series longer than width are averaged into width
+// columns; shorter series get one character per observation. NaN columns
+// render as a space and a flat series renders at mid height.
+func Sparkline(obs []model.Observation, width int) (string, error) {
+	if width <= 0 {
+		width = DefaultSparkWidth
+	}
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, o := range obs {
+		if math.IsNaN(o.Value) {
+			continue
+		}
+		minVal = math.Min(minVal, o.Value)
+		maxVal = math.Max(maxVal, o.Value)
+	}
+	if math.IsInf(minVal, 1) {
+		return "", fmt.Errorf("chart spark: no non-NaN observations")
+	}
+
+	n := width
+	if len(obs) < n {
+		n = len(obs)
+	}
+	var sb strings.Builder
+	for _, v := range sampleCols(obs, n) {
+		switch {
+		case math.IsNaN(v):
+			sb.WriteRune(' ')
+		case maxVal == minVal:
+			sb.WriteRune(sparkBlocks[len(sparkBlocks)/2-1])
+		default:
+			idx := int(math.Round((v - minVal) / (maxVal - minVal) * float64(len(sparkBlocks)-1)))
+			sb.WriteRune(sparkBlocks[idx])
+		}
+	}
+	return sb.String(), nil
+}
+
+// Spark writes the Sparkline of obs to w on a line of its own, for status
+// lines and scripts that want only the chart.
+func Spark(w io.Writer, obs []model.Observation, width int) error {
+	line, err := Sparkline(obs, width)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, line)
+	return err
+}
+
+// PlotMulti renders several series on one chart sharing a date axis. The
+// first series is drawn with box-drawing lines and the others with the
+// dashed glyph pairs of overlayStyles, cycling once those run out; a legend
+// line maps each glyph to its series ID. All share one y-scale unless
+// opts.SeparateAxes is set. Columns are placed by date, so series of
+// different frequencies line up; a single-entry map falls back to Plot.
+func PlotMulti(w io.Writer, series map[string][]model.Observation, opts PlotOptions) error {
+	ids := opts.Order
+	if len(ids) == 0 {
+		for id := range series {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+	}
+	list := make([]SeriesPlotData, 0, len(ids))
+	for _, id := range ids {
+		obs, ok := series[id]
+		if !ok {
+			return fmt.Errorf("chart plot: no observations for series %s", id)
+		}
+		list = append(list, SeriesPlotData{SeriesID: id, Obs: obs})
+	}
+	return plotSeries(w, list, opts)
+}
+
+// plotSeries draws series in order on shared date axes; see PlotMulti.
+func plotSeries(w io.Writer, series []SeriesPlotData, opts PlotOptions) error {
+	opts.MultiSeries = nil
+	switch len(series) {
+	case 0:
+		return fmt.Errorf("chart plot: no series to plot")
+	case 1:
+		return Plot(w, series[0].SeriesID, series[0].Obs, opts)
+	}
+	ids := make([]string, len(series))
+	seen := map[string]bool{}
+	for i, s := range series {
+		if seen[s.SeriesID] {
+			return fmt.Errorf("chart plot: series %s listed twice", s.SeriesID)
+		}
+		seen[s.SeriesID] = true
+		ids[i] = s.SeriesID
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		width = termWidth()
+	}
+	height := opts.Height
+	if height <= 0 {
+		height = 12
+	}
+	title := opts.Title
+	if title == "" {
+		title = strings.Join(ids, " vs ")
+	}
+
+	// Series 0 is scaled on the left axis; the rest share the left axis too,
+	// or the right one when SeparateAxes is set.
+	minA, maxA, err := valueRange(series[0].Obs)
+	if err != nil {
+		return fmt.Errorf("chart plot: %s: %w", ids[0], err)
+	}
+	minB, maxB := math.Inf(1), math.Inf(-1)
+	tMin, tMax := dateRange(series[0].Obs)
+	for _, s := range series[1:] {
+		lo, hi, err := valueRange(s.Obs)
+		if err != nil {
+			return fmt.Errorf("chart plot: %s: %w", s.SeriesID, err)
+		}
+		minB, maxB = math.Min(minB, lo), math.Max(maxB, hi)
+		first, last := dateRange(s.Obs)
+		if first.Before(tMin) {
+			tMin = first
+		}
+		if last.After(tMax) {
+			tMax = last
+		}
+	}
+	if !opts.SeparateAxes {
+		minA, maxA = math.Min(minA, minB), math.Max(maxA, maxB)
+		minB, maxB = minA, maxA
+	}
+
+	ticksA := yTicks(minA, maxA, height)
+	leftWidth := labelWidth(ticksA, formatFloat)
+	var ticksB []float64
+	rightWidth := 0
+	if opts.SeparateAxes {
+		ticksB = yTicks(minB, maxB, height)
+		rightWidth = labelWidth(ticksB, formatFloat) + 1 // "├" + label
+	}
+	plotWidth := width - (leftWidth + 2) - rightWidth
+	if plotWidth < 10 {
+		plotWidth = 10
+	}
+
+	grid := buildGrid(sampleColsByDate(series[0].Obs, tMin, tMax, plotWidth), minA, maxA, height)
+	owner := make([][]int, height) // index of the series drawn in each cell
+	for r := range grid {
+		owner[r] = make([]int, plotWidth)
+	}
+	for i, s := range series[1:] {
+		overlayGrid(grid, owner, i+1, sampleColsByDate(s.Obs, tMin, tMax, plotWidth), minB, maxB, height)
+	}
+
+	fmt.Fprintf(w, "%s  (%s to %s)\n", title, tMin.Format("2006-01"), tMax.Format("2006-01"))
+	for row := 0; row < height; row++ {
+		label := tickLabel(ticksA, minA, maxA, height, row, formatFloat)
+		axisCh := "┤"
+		if label != "" && math.Abs(minA) < 1e-9 && row == height-1 {
+			axisCh = "┼"
+		} else if label == "" {
+			axisCh = " "
+		}
+		body := string(grid[row])
+		if opts.Color {
+			cells := owner[row]
+			body = colorCells(grid[row], func(i int, _ rune) string { return seriesColor(cells[i]) })
+		}
+		line := fmt.Sprintf("%*s%s%s", leftWidth, label, axisCh, body)
+		if opts.SeparateAxes {
+			if right := tickLabel(ticksB, minB, maxB, height, row, formatFloat); right != "" {
+				line += "├" + right
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "%s└%s\n", strings.Repeat(" ", leftWidth), strings.Repeat("─", plotWidth))
+	axisDates := []model.Observation{{Date: tMin}, {Date: tMin.Add(tMax.Sub(tMin) / 2)}, {Date: tMax}}
+	fmt.Fprintf(w, "%s %s\n", strings.Repeat(" ", leftWidth), xAxisLabels(axisDates, plotWidth))
+
+	legend := make([]string, len(ids))
+	for i, id := range ids {
+		entry := string(seriesStyle(i).flat) + " " + id
+		if opts.SeparateAxes {
+			if i == 0 {
+				entry += " (left axis)"
+			} else {
+				entry += " (right axis)"
+			}
+		}
+		if opts.Color {
+			entry = colorize(entry, seriesColor(i))
+		}
+		legend[i] = entry
+	}
+	fmt.Fprintf(w, "%s %s\n", strings.Repeat(" ", leftWidth), strings.Join(legend, "   "))
+	return nil
+}
+
+// ScaleMismatchRatio is how many times wider the combined value range of a
+// multi-series plot may be than one series' own range before ScaleMismatch
+// reports that a shared axis flattens that series.
+const ScaleMismatchRatio = 10
+
+// ScaleMismatch reports whether any series, plotted on one axis shared with
+// the others, would span less than 1/ScaleMismatchRatio of its height —
+// a sign the chart wants SeparateAxes. Flat series are ignored.
+func ScaleMismatch(series []SeriesPlotData) bool {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	var spans []float64
+	for _, s := range series {
+		minVal, maxVal, err := valueRange(s.Obs)
+		if err != nil {
+			continue
+		}
+		lo, hi = math.Min(lo, minVal), math.Max(hi, maxVal)
+		if maxVal > minVal {
+			spans = append(spans, maxVal-minVal)
+		}
+	}
+	for _, span := range spans {
+		if span*ScaleMismatchRatio < hi-lo {
+			return true
+		}
+	}
+	return false
+}
+
+// ─── Scatter ──────────────────────────────────────────────────────────────────
+
+// ScatterOptions controls scatter plot rendering.
+type ScatterOptions struct {
+	// Width is the total character width of the chart (including Y-axis label).
+	// If 0, auto-detects from $COLUMNS, falls back to 80.
+	Width int
+	// Height is the number of data rows in the chart body. If 0, defaults to 12.
+	Height int
+	// Title overrides the default title ("<yLabel> vs <xLabel>").
+	Title string
+	// Span, when set, is shown in the title as the dates the points cover.
+	Span DateRange
+	// Fit overlays the OLS regression line of y on x.
+	Fit bool
+	// Color draws points in cyan and the fit line in yellow.
+	Color bool
+}
+
+// PairByDate joins x and y on date, in y's order, returning an [x, y] pair
+// for every date where both have a non-NaN value and the span of those dates.
+func PairByDate(x, y []model.Observation) ([][2]float64, DateRange) {
+	xByDate := make(map[time.Time]float64, len(x))
+	for _, o := range x {
+		if !math.IsNaN(o.Value) {
+			xByDate[o.Date] = o.Value
+		}
+	}
+	var pairs [][2]float64
+	var span DateRange
+	for _, o := range y {
+		xv, ok := xByDate[o.Date]
+		if !ok || math.IsNaN(o.Value) {
+			continue
+		}
+		if len(pairs) == 0 || o.Date.Before(span.Start) {
+			span.Start = o.Date
+		}
+		if len(pairs) == 0 || o.Date.After(span.End) {
+			span.End = o.Date
+		}
+		pairs = append(pairs, [2]float64{xv, o.Value})
+	}
+	return pairs, span
+}
+
+// Scatter plots each [x, y] pair as a point, labelling the axes xLabel and
+// yLabel ("x" and "y" when empty). A cell holding one point renders as •,
+// several as ●; the fit line, when requested, is drawn underneath in ·.
+// Use PairByDate to build pairs from two series.
+func Scatter(w io.Writer, xLabel, yLabel string, pairs [][2]float64, opts ScatterOptions) error {
+	var xs, ys []float64
+	for _, p := range pairs {
+		if math.IsNaN(p[0]) || math.IsNaN(p[1]) {
+			continue
+		}
+		xs = append(xs, p[0])
+		ys = append(ys, p[1])
+	}
+	if len(xs) < 2 {
+		return fmt.Errorf("chart scatter: need at least 2 points where both series have values (got %d)", len(xs))
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		width = termWidth()
+	}
+	height := opts.Height
+	if height <= 0 {
+		height = 12
+	}
+	if xLabel == "" {
+		xLabel = "x"
+	}
+	if yLabel == "" {
+		yLabel = "y"
+	}
+	title := opts.Title
+	if title == "" {
+		title = yLabel + " vs " + xLabel
+	}
+
+	minX, maxX := minMax(xs)
+	minY, maxY := minMax(ys)
+	ticks := yTicks(minY, maxY, height)
+	yLabelWidth := labelWidth(ticks, formatFloat)
+	plotWidth := width - (yLabelWidth + 2)
+	if plotWidth < 10 {
+		plotWidth = 10
+	}
+
+	colOf := func(v float64) int {
+		if maxX == minX {
+			return plotWidth / 2
+		}
+		return int(math.Round((v - minX) / (maxX - minX) * float64(plotWidth-1)))
+	}
+	grid := make([][]rune, height)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", plotWidth))
+	}
+
+	var slope, intercept, rsq float64
+	if opts.Fit {
+		var err error
+		if slope, intercept, rsq, err = analyze.LinearFit(xs, ys); err != nil {
+			return fmt.Errorf("chart scatter: %w", err)
+		}
+		for col := 0; col < plotWidth; col++ {
+			xv := minX + (maxX-minX)*float64(col)/float64(plotWidth-1)
+			r := int(math.Round(rowForValue(slope*xv+intercept, minY, maxY, height)))
+			if r >= 0 && r < height {
+				grid[r][col] = '·'
+			}
+		}
+	}
+	rowOf := rowIndexes(ys, minY, maxY, height)
+	counts := make(map[[2]int]int, len(xs))
+	for i := range xs {
+		cell := [2]int{rowOf[i], colOf(xs[i])}
+		counts[cell]++
+		grid[cell[0]][cell[1]] = '•'
+		if counts[cell] > 1 {
+			grid[cell[0]][cell[1]] = '●'
+		}
+	}
+
+	if opts.Span.Start.IsZero() {
+		fmt.Fprintf(w, "%s  (n=%d)\n", title, len(xs))
+	} else {
+		fmt.Fprintf(w, "%s  (n=%d, %s to %s)\n", title, len(xs), opts.Span.Start.Format("2006-01"), opts.Span.End.Format("2006-01"))
+	}
+	for row := 0; row < height; row++ {
+		label := tickLabel(ticks, minY, maxY, height, row, formatFloat)
+		axisCh := "┤"
+		if label == "" {
+			axisCh = " "
+		}
+		body := string(grid[row])
+		if opts.Color {
+			body = colorCells(grid[row], func(_ int, r rune) string {
+				if r == '·' {
+					return ansiYellow
+				}
+				return ansiCyan
+			})
+		}
+		fmt.Fprintf(w, "%*s%s%s\n", yLabelWidth, label, axisCh, body)
+	}
+	indent := strings.Repeat(" ", yLabelWidth)
+	fmt.Fprintf(w, "%s└%s\n", indent, strings.Repeat("─", plotWidth))
+	fmt.Fprintf(w, "%s %s\n", indent,
+		placeAxisLabels(plotWidth, formatFloat(minX), formatFloat((minX+maxX)/2), formatFloat(maxX)))
+
+	legend := fmt.Sprintf("x: %s   y: %s", xLabel, yLabel)
+	if opts.Fit {
+		legend += fmt.Sprintf("   · OLS fit y = %sx %s %s (R²=%.2f)",
+			formatFloat(slope), signOf(intercept), formatFloat(math.Abs(intercept)), rsq)
+	}
+	fmt.Fprintf(w, "%s %s\n", indent, legend)
+	return nil
+}
+
+// minMax returns the smallest and largest of vs, which must be non-empty.
+func minMax(vs []float64) (float64, float64) {
+	lo, hi := vs[0], vs[0]
+	for _, v := range vs[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return lo, hi
+}
+
+func signOf(v float64) string {
+	if v < 0 {
+		return "-"
+	}
+	return "+"
+}
+
+// ─── Color ────────────────────────────────────────────────────────────────────
+
+// Color modes accepted by ColorEnabled. ColorOn and ColorOff are aliases
+// for ColorAlways and ColorNever.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+	ColorOn     = "on"
+	ColorOff    = "off"
+)
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+// ColorEnabled reports whether output written to w should carry ANSI color.
+// "always" and "never" are unconditional; "auto" (or "") colors only when w
+// is a terminal and the NO_COLOR environment variable is unset or empty.
+func ColorEnabled(mode string, w io.Writer) bool {
+	switch mode {
+	case ColorAlways, ColorOn:
+		return true
+	case ColorNever, ColorOff:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// ColorSigned wraps s in green when v is positive and red when v is negative.
+// Zero and NaN are returned unchanged.
+func ColorSigned(s string, v float64) string {
+	if code := signColor(v); code != "" {
+		return colorize(s, code)
+	}
+	return s
+}
+
+func signColor(v float64) string {
+	switch {
+	case v > 0:
+		return ansiGreen
+	case v < 0:
+		return ansiRed
+	}
+	return ""
+}
+
+// barColor is green for a value at or above mean and red below it. Zero is
+// yellow, so a zero bar (drawn as one block in a non-negative chart) is not
+// mistaken for a small reading.
+func barColor(v, mean float64) string {
+	switch {
+	case v == 0:
+		return ansiYellow
+	case v >= mean:
+		return ansiGreen
+	}
+	return ansiRed
+}
+
+func colorize(s, code string) string {
+	if code == "" || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// colorBlocks colors every non-space run in s, leaving padding and the zero
+// line untouched so the escape codes never change the visible layout.
+func colorBlocks(s, code string) string {
+	if code == "" {
+		return s
+	}
+	var sb strings.Builder
+	inRun := false
+	for _, r := range s {
+		paint := r != ' ' && r != '│'
+		if paint && !inRun {
+			sb.WriteString(code)
+		} else if !paint && inRun {
+			sb.WriteString(ansiReset)
+		}
+		inRun = paint
+		sb.WriteRune(r)
+	}
+	if inRun {
+		sb.WriteString(ansiReset)
+	}
+	return sb.String()
+}
+
+// seriesColors are the line colors of a multi-series plot, by series index.
+var seriesColors = []string{ansiBlue, ansiYellow, ansiMagenta, ansiGreen}
+
+func seriesColor(i int) string { return seriesColors[i%len(seriesColors)] }
+
+// colorCells wraps each non-space cell of row in the code chosen by codeAt,
+// opening and closing escapes only where the code changes.
+func colorCells(row []rune, codeAt func(i int, r rune) string) string {
+	var sb strings.Builder
+	cur := ""
+	for i, r := range row {
+		code := ""
+		if r != ' ' {
+			code = codeAt(i, r)
+		}
+		if code != cur {
+			if cur != "" {
+				sb.WriteString(ansiReset)
+			}
+			sb.WriteString(code)
+			cur = code
+		}
+		sb.WriteRune(r)
+	}
+	if cur != "" {
+		sb.WriteString(ansiReset)
+	}
+	return sb.String()
+}
+
 // ─── Grid building ────────────────────────────────────────────────────────────
 
 // sampleCols reduces obs to exactly n columns by sampling.
@@ -366,6 +1188,90 @@ func sampleCols(obs []model.Observation, n int) []float64 {
 	return cols
 }
 
+// sampleColsByDate places obs into n columns by date over [tMin, tMax],
+// averaging observations that share a column. Columns that no observation
+// falls in are interpolated between their valid neighbours so lower-frequency
+// series still draw as a line; columns holding only NaN stay NaN (gaps).
+func sampleColsByDate(obs []model.Observation, tMin, tMax time.Time, n int) []float64 {
+	sums := make([]float64, n)
+	counts := make([]int, n)
+	seen := make([]bool, n)
+	span := tMax.Sub(tMin)
+	for _, o := range obs {
+		col := 0
+		if span > 0 {
+			col = int(math.Round(float64(o.Date.Sub(tMin)) / float64(span) * float64(n-1)))
+		}
+		seen[col] = true
+		if !math.IsNaN(o.Value) {
+			sums[col] += o.Value
+			counts[col]++
+		}
+	}
+
+	cols := make([]float64, n)
+	for c := range cols {
+		cols[c] = math.NaN()
+		if counts[c] > 0 {
+			cols[c] = sums[c] / float64(counts[c])
+		}
+	}
+	prev := -1
+	for c := 0; c < n; c++ {
+		if !seen[c] {
+			continue
+		}
+		if prev >= 0 && c-prev > 1 && !math.IsNaN(cols[prev]) && !math.IsNaN(cols[c]) {
+			for k := prev + 1; k < c; k++ {
+				cols[k] = cols[prev] + (cols[c]-cols[prev])*float64(k-prev)/float64(c-prev)
+			}
+		}
+		prev = c
+	}
+	return cols
+}
+
+// shadedCols reports, for each of the n columns sampleCols would produce from
+// obs, whether the dates in that column's bucket overlap any of shades.
+func shadedCols(obs []model.Observation, n int, shades []DateRange) []bool {
+	if len(shades) == 0 {
+		return nil
+	}
+	total := len(obs)
+	out := make([]bool, n)
+	for col := 0; col < n; col++ {
+		lo := col * total / n
+		hi := (col+1)*total/n - 1
+		if hi >= total {
+			hi = total - 1
+		}
+		if hi < lo {
+			hi = lo // empty bucket: take the date of the next observation
+		}
+		for _, sh := range shades {
+			if !obs[lo].Date.After(sh.End) && !obs[hi].Date.Before(sh.Start) {
+				out[col] = true
+				break
+			}
+		}
+	}
+	return out
+}
+
+// shadeGrid fills the blank cells of every shaded column with ShadeGlyph.
+func shadeGrid(grid [][]rune, shaded []bool) {
+	for col, on := range shaded {
+		if !on {
+			continue
+		}
+		for r := range grid {
+			if grid[r][col] == ' ' {
+				grid[r][col] = ShadeGlyph
+			}
+		}
+	}
+}
+
 // rowForValue returns the float row index (0=top=max) for a given value.
 func rowForValue(v, minVal, maxVal float64, height int) float64 {
 	if maxVal == minVal {
@@ -386,21 +1292,7 @@ func buildGrid(cols []float64, minVal, maxVal float64, height int) [][]rune {
 	}
 
 	// For each column, find the row index of its value
-	rowOf := make([]int, len(cols))
-	for col, v := range cols {
-		if math.IsNaN(v) {
-			rowOf[col] = -1 // sentinel: gap
-		} else {
-			r := int(math.Round(rowForValue(v, minVal, maxVal, height)))
-			if r < 0 {
-				r = 0
-			}
-			if r >= height {
-				r = height - 1
-			}
-			rowOf[col] = r
-		}
-	}
+	rowOf := rowIndexes(cols, minVal, maxVal, height)
 
 	// Draw each column
 	for col := 0; col < len(cols); col++ {
@@ -475,8 +1367,123 @@ func buildGrid(cols []float64, minVal, maxVal float64, height int) [][]rune {
 	return grid
 }
 
+// rowIndexes maps each column value to its grid row, or -1 for a NaN gap.
+func rowIndexes(cols []float64, minVal, maxVal float64, height int) []int {
+	rowOf := make([]int, len(cols))
+	for col, v := range cols {
+		if math.IsNaN(v) {
+			rowOf[col] = -1
+			continue
+		}
+		r := int(math.Round(rowForValue(v, minVal, maxVal, height)))
+		if r < 0 {
+			r = 0
+		}
+		if r >= height {
+			r = height - 1
+		}
+		rowOf[col] = r
+	}
+	return rowOf
+}
+
+// overlayStyle is the glyph pair an overlaid series is drawn with: flat
+// where its line runs level and step where it moves between rows.
+type overlayStyle struct{ flat, step rune }
+
+// overlayStyles are assigned to the series of a multi-series plot by index,
+// cycling. Series 0 is drawn by buildGrid with the full set of box-drawing
+// glyphs; its style here applies when the cycle comes back round.
+var overlayStyles = []overlayStyle{
+	{flat: '─', step: '╸'},
+	{flat: '┄', step: '╌'},
+	{flat: '┈', step: '╎'},
+}
+
+func seriesStyle(i int) overlayStyle { return overlayStyles[i%len(overlayStyles)] }
+
+// overlayGrid draws series index onto grid in its overlayStyle without
+// overwriting cells an earlier series has drawn, recording index in owner.
+func overlayGrid(grid [][]rune, owner [][]int, index int, cols []float64, minVal, maxVal float64, height int) {
+	style := seriesStyle(index)
+	rowOf := rowIndexes(cols, minVal, maxVal, height)
+	for col, r := range rowOf {
+		if r < 0 || grid[r][col] != ' ' {
+			continue
+		}
+		prevRow, nextRow := -1, -1
+		if col > 0 {
+			prevRow = rowOf[col-1]
+		}
+		if col < len(rowOf)-1 {
+			nextRow = rowOf[col+1]
+		}
+		ch := style.step
+		if (prevRow >= 0 || nextRow >= 0) && (prevRow < 0 || prevRow == r) && (nextRow < 0 || nextRow == r) {
+			ch = style.flat
+		}
+		grid[r][col] = ch
+		owner[r][col] = index
+	}
+}
+
 // ─── Axis helpers ─────────────────────────────────────────────────────────────
 
+// tickLabel returns the tick that falls on row, formatted with format, or ""
+// if none does.
+func tickLabel(ticks []float64, minVal, maxVal float64, height, row int, format func(float64) string) string {
+	for _, t := range ticks {
+		if math.Abs(rowForValue(t, minVal, maxVal, height)-float64(row)) < 0.5 {
+			return format(t)
+		}
+	}
+	return ""
+}
+
+// labelWidth returns the width of the widest tick formatted with format.
+func labelWidth(ticks []float64, format func(float64) string) int {
+	width := 0
+	for _, t := range ticks {
+		if l := len(format(t)); l > width {
+			width = l
+		}
+	}
+	return width
+}
+
+// valueRange returns the non-NaN min/max of obs, requiring at least two
+// valid values as Plot does.
+func valueRange(obs []model.Observation) (float64, float64, error) {
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	n := 0
+	for _, o := range obs {
+		if math.IsNaN(o.Value) {
+			continue
+		}
+		minVal = math.Min(minVal, o.Value)
+		maxVal = math.Max(maxVal, o.Value)
+		n++
+	}
+	if n < 2 {
+		return 0, 0, fmt.Errorf("need at least 2 non-NaN observations (got %d)", n)
+	}
+	return minVal, maxVal, nil
+}
+
+// dateRange returns the earliest and latest observation dates.
+func dateRange(obs []model.Observation) (time.Time, time.Time) {
+	var lo, hi time.Time
+	for i, o := range obs {
+		if i == 0 || o.Date.Before(lo) {
+			lo = o.Date
+		}
+		if i == 0 || o.Date.After(hi) {
+			hi = o.Date
+		}
+	}
+	return lo, hi
+}
+
 // yTicks returns 3–5 evenly-spaced tick values for the Y axis.
 func yTicks(minVal, maxVal float64, height int) []float64 {
 	if maxVal == minVal {
@@ -493,15 +1500,81 @@ func yTicks(minVal, maxVal float64, height int) []float64 {
 	return ticks
 }
 
+// logTicks returns tick positions in log10 space for a log-scale axis over
+// [minLog, maxLog]: every power of ten in range, thinned to at most five;
+// failing two of those, the 1-2-5 multiples in range; failing that, the
+// linear yTicks of the log range.
+func logTicks(minLog, maxLog float64, height int) []float64 {
+	const eps = 1e-9
+	var powers []float64
+	for e := math.Ceil(minLog - eps); e <= maxLog+eps; e++ {
+		powers = append(powers, e)
+	}
+	if len(powers) >= 2 {
+		return thinTicks(powers, 5)
+	}
+	var steps []float64
+	for e := math.Floor(minLog); e <= math.Ceil(maxLog); e++ {
+		for _, m := range []float64{1, 2, 5} {
+			if t := e + math.Log10(m); t >= minLog-eps && t <= maxLog+eps {
+				steps = append(steps, t)
+			}
+		}
+	}
+	if len(steps) >= 2 {
+		return thinTicks(steps, 5)
+	}
+	return yTicks(minLog, maxLog, height)
+}
+
+// thinTicks keeps every k-th tick, starting with the first, so that at most
+// max remain.
+func thinTicks(ticks []float64, max int) []float64 {
+	if len(ticks) <= max {
+		return ticks
+	}
+	k := (len(ticks) + max - 1) / max
+	var out []float64
+	for i := 0; i < len(ticks); i += k {
+		out = append(out, ticks[i])
+	}
+	return out
+}
+
+// log10Obs returns a copy of obs with each value replaced by its log10. NaN
+// stays NaN; a zero or negative value has no logarithm and is an error.
+func log10Obs(obs []model.Observation) ([]model.Observation, error) {
+	out := make([]model.Observation, len(obs))
+	for i, o := range obs {
+		if !math.IsNaN(o.Value) && o.Value <= 0 {
+			return nil, fmt.Errorf("chart plot: log scale needs positive values, got %s on %s",
+				formatFloat(o.Value), o.Date.Format("2006-01-02"))
+		}
+		out[i] = o
+		out[i].Value = math.Log10(o.Value)
+	}
+	return out, nil
+}
+
+// formatPow10 labels a log-scale tick with its value in original units.
+func formatPow10(t float64) string {
+	return formatFloat(math.Pow(10, t))
+}
+
 // xAxisLabels builds a padded string with start, middle, and end date labels.
 func xAxisLabels(obs []model.Observation, plotWidth int) string {
 	if len(obs) == 0 {
 		return ""
 	}
-	startLabel := obs[0].Date.Format("2006-01")
-	endLabel := obs[len(obs)-1].Date.Format("2006-01")
-	midLabel := obs[len(obs)/2].Date.Format("2006-01")
+	return placeAxisLabels(plotWidth,
+		obs[0].Date.Format("2006-01"),
+		obs[len(obs)/2].Date.Format("2006-01"),
+		obs[len(obs)-1].Date.Format("2006-01"))
+}
 
+// placeAxisLabels lays out start at the left edge, mid centred, and end flush
+// right within plotWidth columns.
+func placeAxisLabels(plotWidth int, startLabel, midLabel, endLabel string) string {
 	// Position: start at left, mid centred, end at right
 	midPos := plotWidth/2 - len(midLabel)/2
 	endPos := plotWidth - len(endLabel)
@@ -4,10 +4,15 @@
 package chart_test
 
 import (
+	"bytes"
 	"math"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/derickschaefer/reserve/internal/chart"
 	"github.com/derickschaefer/reserve/internal/model"
@@ -242,6 +247,18 @@ func TestBarDateFormatMonthly(t *testing.T) {
 	}
 }
 
+func TestBarDateFormatMonthEnd(t *testing.T) {
+	// Month-end dates are still monthly data
+	observations := obs("2024-01-31", 3.5, "2024-02-29", 3.7, "2024-03-31", 3.8, "2024-04-30", 3.9)
+	var buf strings.Builder
+	_ = chart.Bar(&buf, "TEST", observations, chart.BarOptions{Width: 60})
+	out := buf.String()
+
+	if !strings.Contains(out, "2024-02 ") || strings.Contains(out, "2024-02-29") {
+		t.Errorf("month-end series should use YYYY-MM format:\n%s", out)
+	}
+}
+
 // ─── Plot tests ───────────────────────────────────────────────────────────────
 
 func TestPlotBasic(t *testing.T) {
@@ -393,6 +410,861 @@ func TestPlotXAxisLabels(t *testing.T) {
 	}
 }
 
+func TestIndicatorRanges(t *testing.T) {
+	usrec := monthlyObs(2020, 1, 0, 1, 1, 0, math.NaN(), 1, 0, 1)
+	got := chart.IndicatorRanges(usrec)
+	want := []chart.DateRange{
+		{Start: usrec[1].Date, End: usrec[2].Date},
+		{Start: usrec[5].Date, End: usrec[5].Date},
+		{Start: usrec[7].Date, End: usrec[7].Date},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d ranges, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Start.Equal(want[i].Start) || !got[i].End.Equal(want[i].End) {
+			t.Errorf("range %d = %v..%v, want %v..%v", i, got[i].Start, got[i].End, want[i].Start, want[i].End)
+		}
+	}
+}
+
+func TestPlotShadesOnlyCoveredColumns(t *testing.T) {
+	observations := monthlyObs(2020, 1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	shade := chart.DateRange{Start: observations[3].Date, End: observations[5].Date}
+	var buf strings.Builder
+	err := chart.Plot(&buf, "UNRATE", observations, chart.PlotOptions{
+		Width: 14, Height: 5, Shades: []chart.DateRange{shade},
+	})
+	if err != nil {
+		t.Fatalf("Plot returned error: %v", err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	axis := []rune(lines[6])
+	body := -1
+	for i, r := range axis {
+		if r == '└' {
+			body = i + 1
+			break
+		}
+	}
+	if body < 0 {
+		t.Fatalf("no bottom axis in output:\n%s", buf.String())
+	}
+	shadedCells := 0
+	for _, line := range lines[1:6] {
+		for col, r := range []rune(line)[body:] {
+			if r != chart.ShadeGlyph {
+				continue
+			}
+			shadedCells++
+			if col < 3 || col > 5 {
+				t.Errorf("column %d shaded outside the range:\n%s", col, buf.String())
+			}
+		}
+	}
+	if shadedCells == 0 {
+		t.Errorf("expected shaded cells:\n%s", buf.String())
+	}
+
+	var plain strings.Builder
+	_ = chart.Plot(&plain, "UNRATE", observations, chart.PlotOptions{Width: 14, Height: 5})
+	if strings.ContainsRune(plain.String(), chart.ShadeGlyph) {
+		t.Error("no Shades should mean no shading")
+	}
+}
+
+// ─── PlotMulti tests ──────────────────────────────────────────────────────────
+
+func TestPlotLogScaleTicksAtPowersOfTen(t *testing.T) {
+	observations := annualObs(2000, 1, 10, 100, 1000, 10000)
+	var buf strings.Builder
+	err := chart.Plot(&buf, "M2SL", observations, chart.PlotOptions{Width: 40, Height: 9, LogScale: true})
+	if err != nil {
+		t.Fatalf("Plot returned error: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+
+	// Five decades over nine rows put a tick on every other row, top to bottom.
+	// Equal ratios are equal steps, so each point lands on its own tick row.
+	var labels []string
+	for _, line := range lines[1:10] {
+		label, body, ok := strings.Cut(line, "┤")
+		if !ok {
+			continue
+		}
+		labels = append(labels, strings.TrimSpace(label))
+		if strings.TrimSpace(body) == "" {
+			t.Errorf("tick row %q has no point:\n%s", label, buf.String())
+		}
+	}
+	want := []string{"10.0K", "1.0K", "100.0", "10.0", "1.0"}
+	if strings.Join(labels, ",") != strings.Join(want, ",") {
+		t.Errorf("tick labels = %v, want %v:\n%s", labels, want, buf.String())
+	}
+}
+
+func TestPlotLogScaleRejectsNonPositive(t *testing.T) {
+	observations := annualObs(2000, 3, 0, 5)
+	err := chart.Plot(&strings.Builder{}, "X", observations, chart.PlotOptions{Width: 40, LogScale: true})
+	if err == nil || !strings.Contains(err.Error(), "2001-01-01") {
+		t.Errorf("expected error naming the non-positive observation, got %v", err)
+	}
+}
+
+func TestPlotMultiOverlayLegendAndGlyphs(t *testing.T) {
+	series := map[string][]model.Observation{
+		"FEDFUNDS": monthlyObs(2020, 1, 1.5, 0.7, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1),
+		"UNRATE":   monthlyObs(2020, 1, 3.5, 4.4, 14.7, 13.3, 11.1, 8.4, 6.9, 6.9),
+	}
+	height := 8
+	var buf strings.Builder
+	err := chart.PlotMulti(&buf, series, chart.PlotOptions{
+		Width:  60,
+		Height: height,
+		Order:  []string{"UNRATE", "FEDFUNDS"},
+	})
+	if err != nil {
+		t.Fatalf("PlotMulti returned error: %v", err)
+	}
+	out := buf.String()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	// header + rows + bottom axis + x labels + legend
+	if len(lines) != height+4 {
+		t.Fatalf("expected %d lines, got %d:\n%s", height+4, len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "UNRATE vs FEDFUNDS") {
+		t.Errorf("expected default title from Order, got %q", lines[0])
+	}
+	if legend := lines[len(lines)-1]; !strings.Contains(legend, "─ UNRATE") || !strings.Contains(legend, "┄ FEDFUNDS") {
+		t.Errorf("legend should map glyphs to series, got %q", legend)
+	}
+	if !strings.Contains(out, "┄") {
+		t.Errorf("expected dotted overlay glyphs:\n%s", out)
+	}
+	if strings.Contains(out, "right axis") {
+		t.Error("shared-axis chart should not label a right axis")
+	}
+}
+
+func TestPlotMultiSeparateAxes(t *testing.T) {
+	series := map[string][]model.Observation{
+		"A": annualObs(2000, 1, 2, 3, 4, 5),
+		"B": annualObs(2000, 1000, 1500, 1200, 1800, 2000),
+	}
+	var buf strings.Builder
+	err := chart.PlotMulti(&buf, series, chart.PlotOptions{Width: 60, Height: 8, SeparateAxes: true})
+	if err != nil {
+		t.Fatalf("PlotMulti returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "├2.0K") || !strings.Contains(out, "├1.0K") {
+		t.Errorf("expected right-axis ticks for B:\n%s", out)
+	}
+	if !strings.Contains(out, "─ A (left axis)") || !strings.Contains(out, "┄ B (right axis)") {
+		t.Errorf("expected axis assignment in legend:\n%s", out)
+	}
+}
+
+func TestPlotMultiSeriesSharedScale(t *testing.T) {
+	multi := []chart.SeriesPlotData{
+		{SeriesID: "UNRATE", Obs: monthlyObs(2020, 1, 3.5, 4.4, 14.7, 13.3, 11.1, 8.4)},
+		{SeriesID: "U6RATE", Obs: monthlyObs(2020, 1, 6.9, 8.7, 22.9, 21.2, 18.0, 15.1)},
+	}
+	var buf strings.Builder
+	// seriesID and obs are ignored when MultiSeries is set.
+	err := chart.Plot(&buf, "", nil, chart.PlotOptions{Width: 60, Height: 8, MultiSeries: multi})
+	if err != nil {
+		t.Fatalf("Plot returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "UNRATE vs U6RATE") {
+		t.Errorf("expected title from MultiSeries order, got %q", strings.SplitN(out, "\n", 2)[0])
+	}
+	if !strings.Contains(out, "22.9┤") || !strings.Contains(out, "3.5┤") {
+		t.Errorf("shared axis should span both series:\n%s", out)
+	}
+	if strings.Contains(out, "├") {
+		t.Errorf("shared-scale chart should have no right axis:\n%s", out)
+	}
+	if chart.ScaleMismatch(multi) {
+		t.Error("series of similar scale should not report a mismatch")
+	}
+}
+
+func TestPlotMultiSeriesDifferentScale(t *testing.T) {
+	multi := []chart.SeriesPlotData{
+		{SeriesID: "UNRATE", Obs: annualObs(2000, 4, 6, 5, 9, 7)},
+		{SeriesID: "GDP", Obs: annualObs(2000, 10000, 11000, 12500, 13000, 14500)},
+	}
+	if !chart.ScaleMismatch(multi) {
+		t.Fatal("expected a mismatch when one range is a sliver of the other")
+	}
+	var buf strings.Builder
+	err := chart.Plot(&buf, "", nil, chart.PlotOptions{Width: 60, Height: 8, MultiSeries: multi, SeparateAxes: true})
+	if err != nil {
+		t.Fatalf("Plot returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "9.0┤") || !strings.Contains(out, "├14.5K") {
+		t.Errorf("expected UNRATE on the left axis and GDP on the right:\n%s", out)
+	}
+	// On its own axis UNRATE fills the chart height rather than hugging the floor.
+	lines := strings.Split(out, "\n")
+	if !strings.ContainsAny(lines[1], "─╭╮╰╯│") {
+		t.Errorf("expected UNRATE's peak on the top row:\n%s", out)
+	}
+}
+
+func TestPlotMultiSeriesLegend(t *testing.T) {
+	multi := []chart.SeriesPlotData{
+		{SeriesID: "A", Obs: monthlyObs(2020, 1, 1, 1, 1, 2, 2, 2)},
+		{SeriesID: "B", Obs: monthlyObs(2020, 1, 5, 5, 5, 4, 4, 4)},
+		{SeriesID: "C", Obs: monthlyObs(2020, 1, 8, 8, 8, 7, 7, 7)},
+	}
+	var buf strings.Builder
+	if err := chart.Plot(&buf, "", nil, chart.PlotOptions{Width: 50, Height: 8, MultiSeries: multi}); err != nil {
+		t.Fatalf("Plot returned error: %v", err)
+	}
+	out := buf.String()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	legend := lines[len(lines)-1]
+	for _, entry := range []string{"─ A", "┄ B", "┈ C"} {
+		if !strings.Contains(legend, entry) {
+			t.Errorf("legend missing %q: %q", entry, legend)
+		}
+	}
+	for _, glyph := range []string{"┄", "╌", "┈", "╎"} {
+		if !strings.Contains(out, glyph) {
+			t.Errorf("expected %q in the chart body:\n%s", glyph, out)
+		}
+	}
+}
+
+func TestPlotMultiSeriesCyclesStyles(t *testing.T) {
+	series := map[string][]model.Observation{
+		"A": monthlyObs(2020, 1, 1, 2, 3, 4),
+		"B": monthlyObs(2020, 1, 11, 12, 13, 14),
+		"C": monthlyObs(2020, 1, 21, 22, 23, 24),
+		"D": monthlyObs(2020, 1, 31, 31, 32, 32),
+		"E": monthlyObs(2020, 1, 41, 41, 42, 42),
+	}
+	var plain, colored strings.Builder
+	opts := chart.PlotOptions{Width: 50, Height: 12}
+	if err := chart.PlotMulti(&plain, series, opts); err != nil {
+		t.Fatalf("PlotMulti returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(plain.String(), "\n"), "\n")
+	legend := lines[len(lines)-1]
+	// The fourth and fifth series reuse the first and second styles.
+	for _, entry := range []string{"─ A", "┄ B", "┈ C", "─ D", "┄ E"} {
+		if !strings.Contains(legend, entry) {
+			t.Errorf("legend missing %q: %q", entry, legend)
+		}
+	}
+	opts.Color = true
+	if err := chart.PlotMulti(&colored, series, opts); err != nil {
+		t.Fatalf("PlotMulti returned error: %v", err)
+	}
+	if !strings.Contains(colored.String(), "\x1b[35m") {
+		t.Errorf("expected the third series in magenta:\n%q", colored.String())
+	}
+	if got := stripANSI(colored.String()); got != plain.String() {
+		t.Errorf("colored chart misaligned once escapes are removed:\n%s\nwant:\n%s", got, plain.String())
+	}
+}
+
+func TestPlotMultiSeriesRejectsDuplicates(t *testing.T) {
+	multi := []chart.SeriesPlotData{
+		{SeriesID: "A", Obs: annualObs(2000, 1, 2)},
+		{SeriesID: "A", Obs: annualObs(2000, 3, 4)},
+	}
+	if err := chart.Plot(&strings.Builder{}, "", nil, chart.PlotOptions{MultiSeries: multi}); err == nil {
+		t.Error("expected error for a repeated series ID")
+	}
+}
+
+// ─── Sparkline tests ──────────────────────────────────────────────────────────
+
+func TestSparklineScalesMinToMax(t *testing.T) {
+	got, err := chart.Sparkline(annualObs(2000, 0, 1, 2, 3, 4, 5, 6, 7), 0)
+	if err != nil {
+		t.Fatalf("Sparkline: %v", err)
+	}
+	if got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("expected full block ramp, got %q", got)
+	}
+}
+
+func TestSparklineNaNIsSpace(t *testing.T) {
+	got, err := chart.Sparkline(annualObs(2000, 1, math.NaN(), 3), 10)
+	if err != nil {
+		t.Fatalf("Sparkline: %v", err)
+	}
+	if got != "▁ █" {
+		t.Errorf("expected %q, got %q", "▁ █", got)
+	}
+}
+
+func TestSparklineWidthDownsamples(t *testing.T) {
+	values := make([]float64, 120)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	got, err := chart.Sparkline(monthlyObs(2010, 1, values...), 12)
+	if err != nil {
+		t.Fatalf("Sparkline: %v", err)
+	}
+	runes := []rune(got)
+	if len(runes) != 12 {
+		t.Fatalf("expected 12 columns, got %d (%q)", len(runes), got)
+	}
+	if runes[0] != '▁' || runes[11] != '█' {
+		t.Errorf("expected rising ramp, got %q", got)
+	}
+}
+
+func TestSparklineFlatSeries(t *testing.T) {
+	got, err := chart.Sparkline(annualObs(2000, 5, 5, 5), 0)
+	if err != nil {
+		t.Fatalf("Sparkline: %v", err)
+	}
+	if got != "▄▄▄" {
+		t.Errorf("expected mid-height blocks, got %q", got)
+	}
+}
+
+func TestSparklineAllNaN(t *testing.T) {
+	if _, err := chart.Sparkline(annualObs(2000, math.NaN(), math.NaN()), 0); err == nil {
+		t.Error("expected error for all-NaN input")
+	}
+}
+
+func TestSparklineWidthBeyondObsAndWidthOne(t *testing.T) {
+	observations := annualObs(2000, 0, 1, 2, 3, 4, 5, 6, 7)
+	got, err := chart.Sparkline(observations, 40)
+	if err != nil {
+		t.Fatalf("Sparkline: %v", err)
+	}
+	if n := len([]rune(got)); n != 8 {
+		t.Errorf("width above the observation count should use one column per observation, got %d", n)
+	}
+	got, err = chart.Sparkline(observations, 1)
+	if err != nil {
+		t.Fatalf("Sparkline: %v", err)
+	}
+	if got != "▅" {
+		t.Errorf("width 1 should average everything into one block, got %q", got)
+	}
+}
+
+func TestSparkWritesLine(t *testing.T) {
+	var buf strings.Builder
+	if err := chart.Spark(&buf, annualObs(2000, 1, 2, 4, 8), 0); err != nil {
+		t.Fatalf("Spark: %v", err)
+	}
+	if got := buf.String(); !strings.HasSuffix(got, "█\n") || strings.Count(got, "\n") != 1 {
+		t.Errorf("expected one line ending in a full block, got %q", got)
+	}
+	if err := chart.Spark(&buf, nil, 0); err == nil {
+		t.Error("expected error for empty input")
+	}
+}
+
+// ─── Histogram tests ──────────────────────────────────────────────────────────
+
+// histogramRows returns the bar rows of Histogram output as (count, bar length)
+// pairs, skipping the header and any trailing note.
+func histogramRows(t *testing.T, out string) [][2]int {
+	t.Helper()
+	var rows [][2]int
+	for _, line := range strings.Split(out, "\n")[1:] {
+		f := strings.Fields(line)
+		if len(f) < 2 {
+			break
+		}
+		n, err := strconv.Atoi(f[1])
+		if err != nil {
+			t.Fatalf("bad count in %q", line)
+		}
+		bar := 0
+		if len(f) == 3 {
+			bar = utf8.RuneCountInString(f[2])
+		}
+		rows = append(rows, [2]int{n, bar})
+	}
+	return rows
+}
+
+func TestHistogramUniformBinsAreEqual(t *testing.T) {
+	var buf strings.Builder
+	observations := annualObs(2000, 1, 2, 3, 4, 5, 6, 7, 8)
+	if err := chart.Histogram(&buf, "X", observations, 4, chart.BarOptions{Width: 40}); err != nil {
+		t.Fatalf("Histogram: %v", err)
+	}
+	rows := histogramRows(t, buf.String())
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 bins, got %d\n%s", len(rows), buf.String())
+	}
+	for i, r := range rows {
+		if r != rows[0] {
+			t.Errorf("bin %d = %v, want %v like bin 0\n%s", i, r, rows[0], buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), "1.88  2") {
+		t.Errorf("expected rows labeled with the bin midpoint\n%s", buf.String())
+	}
+}
+
+func TestHistogramRightSkewed(t *testing.T) {
+	var buf strings.Builder
+	observations := annualObs(2000, 1, 1, 1, 1, 1, 2, 2, 3, 10)
+	if err := chart.Histogram(&buf, "X", observations, 3, chart.BarOptions{Width: 40}); err != nil {
+		t.Fatalf("Histogram: %v", err)
+	}
+	rows := histogramRows(t, buf.String())
+	want := []int{8, 0, 1}
+	for i, r := range rows {
+		if r[0] != want[i] {
+			t.Errorf("bin %d count = %d, want %d\n%s", i, r[0], want[i], buf.String())
+		}
+	}
+	if rows[0][1] <= rows[2][1] || rows[1][1] != 0 || rows[2][1] < 1 {
+		t.Errorf("bar lengths should follow counts, with an empty middle bin: %v", rows)
+	}
+}
+
+func TestHistogramNaNNote(t *testing.T) {
+	var buf strings.Builder
+	observations := annualObs(2000, 1, math.NaN(), 2, math.NaN(), 3)
+	if err := chart.Histogram(&buf, "X", observations, 0, chart.BarOptions{Width: 40}); err != nil {
+		t.Fatalf("Histogram: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "distribution of 3 values") || !strings.Contains(out, "2 missing (NaN) values excluded") {
+		t.Errorf("expected NaN values excluded and counted\n%s", out)
+	}
+}
+
+func TestSturgesBins(t *testing.T) {
+	for _, tc := range []struct{ n, want int }{{1, 1}, {2, 2}, {8, 4}, {9, 5}, {100, 8}, {1000, 11}} {
+		if got := chart.SturgesBins(tc.n); got != tc.want {
+			t.Errorf("SturgesBins(%d) = %d, want %d", tc.n, got, tc.want)
+		}
+	}
+	var buf strings.Builder
+	values := make([]float64, 100)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	if err := chart.Histogram(&buf, "X", monthlyObs(2000, 1, values...), 0, chart.BarOptions{Width: 60}); err != nil {
+		t.Fatalf("Histogram: %v", err)
+	}
+	if got := len(histogramRows(t, buf.String())); got != 8 {
+		t.Errorf("--bins 0 on 100 values should use 8 bins, got %d", got)
+	}
+}
+
+func TestHistogramAllNaN(t *testing.T) {
+	var buf strings.Builder
+	if err := chart.Histogram(&buf, "X", annualObs(2000, math.NaN(), math.NaN()), 0, chart.BarOptions{}); err == nil {
+		t.Error("expected error for all-NaN input")
+	}
+}
+
+// ─── HeatMap tests ────────────────────────────────────────────────────────────
+
+// heatRow returns the cells of the heatmap row for year, one string per
+// column, padded to the full width of the header.
+func heatRow(t *testing.T, out string, year string, columns int) []string {
+	t.Helper()
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, year+"  ") {
+			continue
+		}
+		runes := []rune(strings.TrimPrefix(line, year+"  "))
+		for len(runes) < columns*4 {
+			runes = append(runes, ' ')
+		}
+		cells := make([]string, columns)
+		for i := range cells {
+			cells[i] = string(runes[i*4 : i*4+3])
+		}
+		return cells
+	}
+	t.Fatalf("no row for %s:\n%s", year, out)
+	return nil
+}
+
+func TestHeatMapSeasonalPattern(t *testing.T) {
+	// Low in January rising to a December peak, repeated for two years.
+	var values []float64
+	for y := 0; y < 2; y++ {
+		for m := 0; m < 12; m++ {
+			values = append(values, float64(m))
+		}
+	}
+	var buf strings.Builder
+	if err := chart.HeatMap(&buf, "RSXFSN", monthlyObs(2020, 1, values...)); err != nil {
+		t.Fatalf("HeatMap returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "RSXFSN  monthly heatmap (2020-01 to 2021-12)") {
+		t.Errorf("unexpected header %q", strings.SplitN(out, "\n", 2)[0])
+	}
+	if !strings.Contains(out, "Jan Feb Mar") || !strings.Contains(out, "Dec") {
+		t.Errorf("expected month column headers:\n%s", out)
+	}
+	for _, year := range []string{"2020", "2021"} {
+		row := heatRow(t, out, year, 12)
+		if row[11] != "███" || row[0] != "   " {
+			t.Errorf("%s: expected December darkest and January lightest, got %q", year, row)
+		}
+		if strings.IndexRune(chart.HeatShades, []rune(row[6])[0]) <= strings.IndexRune(chart.HeatShades, []rune(row[2])[0]) {
+			t.Errorf("%s: July should be darker than March, got %q", year, row)
+		}
+	}
+}
+
+func TestHeatMapNaNCells(t *testing.T) {
+	var buf strings.Builder
+	if err := chart.HeatMap(&buf, "X", monthlyObs(2020, 1, 1, math.NaN(), 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)); err != nil {
+		t.Fatalf("HeatMap returned error: %v", err)
+	}
+	if row := heatRow(t, buf.String(), "2020", 12); row[1] != " . " {
+		t.Errorf("expected NaN cell rendered as '.', got %q", row[1])
+	}
+}
+
+func TestHeatMapPartialYears(t *testing.T) {
+	// October 2019 through March 2021, peaking in the first quarter shown.
+	values := []float64{20, 20, 20}
+	for i := 0; i < 15; i++ {
+		values = append(values, float64(i))
+	}
+	var buf strings.Builder
+	if err := chart.HeatMap(&buf, "X", monthlyObs(2019, 10, values...)); err != nil {
+		t.Fatalf("HeatMap returned error: %v", err)
+	}
+	out := buf.String()
+	first := heatRow(t, out, "2019", 12)
+	for m := 0; m < 9; m++ {
+		if first[m] != "   " {
+			t.Errorf("2019 month %d has no data and should be blank, got %q", m+1, first[m])
+		}
+	}
+	if first[9] != "███" || first[11] != "███" {
+		t.Errorf("October to December 2019 should be darkest, got %q", first)
+	}
+	last := heatRow(t, out, "2021", 12)
+	if last[2] == "   " || last[3] != "   " {
+		t.Errorf("2021 should end after a shaded March, got %q", last)
+	}
+}
+
+func TestHeatMapQuarterly(t *testing.T) {
+	obs := []model.Observation{}
+	for i, v := range []float64{1, 2, 3, 4, 1, 2, 3, 4} {
+		obs = append(obs, model.Observation{Date: time.Date(2020, time.Month(1+3*i), 1, 0, 0, 0, 0, time.UTC), Value: v})
+	}
+	var buf strings.Builder
+	if err := chart.HeatMap(&buf, "GDP", obs); err != nil {
+		t.Fatalf("HeatMap returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "quarterly heatmap") || !strings.Contains(out, "Q1  Q2  Q3  Q4") {
+		t.Errorf("expected quarterly columns:\n%s", out)
+	}
+	if row := heatRow(t, out, "2021", 4); row[3] != "███" {
+		t.Errorf("expected Q4 darkest, got %q", row)
+	}
+}
+
+func TestHeatMapRejectsOtherFrequencies(t *testing.T) {
+	var buf strings.Builder
+	err := chart.HeatMap(&buf, "X", annualObs(2000, 1, 2, 3, 4))
+	if err == nil || !strings.Contains(err.Error(), "monthly or quarterly") {
+		t.Errorf("expected frequency error for annual data, got %v", err)
+	}
+	var daily []model.Observation
+	for i := 0; i < 30; i++ {
+		daily = append(daily, model.Observation{Date: time.Date(2020, 1, 1+i, 0, 0, 0, 0, time.UTC), Value: float64(i)})
+	}
+	if err := chart.HeatMap(&buf, "X", daily); err == nil {
+		t.Error("expected frequency error for daily data")
+	}
+}
+
+// ─── Scatter tests ────────────────────────────────────────────────────────────
+
+func TestScatterJoinsOnDateAndDropsNaN(t *testing.T) {
+	x := monthlyObs(2020, 1, 1, 2, math.NaN(), 4, 5)
+	y := monthlyObs(2020, 2, 20, 30, 40, 50, 60) // starts a month later
+	var buf strings.Builder
+	pairs, span := chart.PairByDate(x, y)
+	err := chart.Scatter(&buf, "X", "Y", pairs, chart.ScatterOptions{Width: 50, Height: 8, Span: span})
+	if err != nil {
+		t.Fatalf("Scatter returned error: %v", err)
+	}
+	out := buf.String()
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	// Common dates Feb, Apr, May (March x is NaN).
+	if !strings.HasPrefix(lines[0], "Y vs X  (n=3, 2020-02 to 2020-05)") {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if got := strings.Count(out, "•") + strings.Count(out, "●"); got != 3 {
+		t.Errorf("expected 3 points, got %d:\n%s", got, out)
+	}
+	if !strings.Contains(lines[len(lines)-1], "x: X   y: Y") {
+		t.Errorf("legend should name both axes, got %q", lines[len(lines)-1])
+	}
+	if strings.Contains(out, "OLS") {
+		t.Error("fit legend should be absent without Fit")
+	}
+}
+
+func TestScatterFitLine(t *testing.T) {
+	x := annualObs(2000, 1, 2, 3, 4, 5, 6)
+	y := annualObs(2000, 3, 5, 7, 9, 11, 13) // y = 2x + 1 exactly
+	var buf strings.Builder
+	pairs, _ := chart.PairByDate(x, y)
+	err := chart.Scatter(&buf, "", "", pairs, chart.ScatterOptions{Width: 60, Height: 10, Fit: true})
+	if err != nil {
+		t.Fatalf("Scatter returned error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "OLS fit y = 2.0x + 1.0 (R²=1.00)") {
+		t.Errorf("expected fit equation in legend:\n%s", out)
+	}
+	if !strings.Contains(out, "·") {
+		t.Errorf("expected fit line glyphs:\n%s", out)
+	}
+}
+
+func TestScatterStackedPoints(t *testing.T) {
+	x := annualObs(2000, 1, 1, 5)
+	y := annualObs(2000, 2, 2, 8)
+	var buf strings.Builder
+	pairs, _ := chart.PairByDate(x, y)
+	if err := chart.Scatter(&buf, "", "", pairs, chart.ScatterOptions{Width: 40, Height: 6}); err != nil {
+		t.Fatalf("Scatter returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "●") {
+		t.Errorf("coincident points should render as ●:\n%s", buf.String())
+	}
+}
+
+func TestScatterTooFewPairs(t *testing.T) {
+	x := annualObs(2000, 1, 2)
+	y := annualObs(2001, 5, 6) // overlaps on 2001 only
+	var buf strings.Builder
+	pairs, _ := chart.PairByDate(x, y)
+	if err := chart.Scatter(&buf, "", "", pairs, chart.ScatterOptions{}); err == nil {
+		t.Error("expected error when fewer than 2 dates overlap")
+	}
+}
+
+func TestScatterNoPoints(t *testing.T) {
+	if err := chart.Scatter(&strings.Builder{}, "X", "Y", nil, chart.ScatterOptions{}); err == nil {
+		t.Error("expected error for zero points")
+	}
+}
+
+// scatterPoints returns the (row, column) of every point glyph in the body
+// of a Scatter chart, top row first.
+func scatterPoints(out string, height int) [][2]int {
+	lines := strings.Split(out, "\n")
+	var pts [][2]int
+	for r, line := range lines[1 : height+1] {
+		for c, ch := range []rune(line) {
+			if ch == '•' || ch == '●' {
+				pts = append(pts, [2]int{r, c})
+			}
+		}
+	}
+	return pts
+}
+
+func TestScatterPositiveCorrelation(t *testing.T) {
+	pairs := [][2]float64{{1, 10}, {2, 20}, {3, 30}, {4, 40}, {5, 50}}
+	var buf strings.Builder
+	if err := chart.Scatter(&buf, "UNRATE", "FEDFUNDS", pairs, chart.ScatterOptions{Width: 40, Height: 8}); err != nil {
+		t.Fatalf("Scatter returned error: %v", err)
+	}
+	pts := scatterPoints(buf.String(), 8)
+	if len(pts) != 5 {
+		t.Fatalf("expected 5 points, got %d:\n%s", len(pts), buf.String())
+	}
+	// Rising to the right: the rightmost point is on the top row and the
+	// leftmost on the bottom row.
+	if left, right := pts[len(pts)-1], pts[0]; right[1] <= left[1] || right[0] != 0 || left[0] != 7 {
+		t.Errorf("expected points from bottom-left to top-right:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(buf.String(), "FEDFUNDS vs UNRATE  (n=5)\n") {
+		t.Errorf("unexpected header without a span: %q", strings.SplitN(buf.String(), "\n", 2)[0])
+	}
+}
+
+func TestScatterNegativeCorrelation(t *testing.T) {
+	pairs := [][2]float64{{1, 50}, {2, 40}, {3, 30}, {4, 20}, {5, 10}}
+	var buf strings.Builder
+	if err := chart.Scatter(&buf, "X", "Y", pairs, chart.ScatterOptions{Width: 40, Height: 8}); err != nil {
+		t.Fatalf("Scatter returned error: %v", err)
+	}
+	pts := scatterPoints(buf.String(), 8)
+	// Falling to the right: the top-row point is the leftmost.
+	if top, bottom := pts[0], pts[len(pts)-1]; top[0] != 0 || bottom[0] != 7 || top[1] >= bottom[1] {
+		t.Errorf("expected points from top-left to bottom-right:\n%s", buf.String())
+	}
+}
+
+func TestScatterIdenticalX(t *testing.T) {
+	pairs := [][2]float64{{3, 1}, {3, 4}, {3, 9}}
+	var buf strings.Builder
+	if err := chart.Scatter(&buf, "X", "Y", pairs, chart.ScatterOptions{Width: 40, Height: 8}); err != nil {
+		t.Fatalf("Scatter returned error: %v", err)
+	}
+	pts := scatterPoints(buf.String(), 8)
+	if len(pts) != 3 {
+		t.Fatalf("expected 3 points, got %d:\n%s", len(pts), buf.String())
+	}
+	for _, p := range pts[1:] {
+		if p[1] != pts[0][1] {
+			t.Errorf("identical x values should share one column:\n%s", buf.String())
+		}
+	}
+}
+
+// ─── Color tests ──────────────────────────────────────────────────────────────
+
+func TestBarColorMixedSigns(t *testing.T) {
+	observations := annualObs(2018, 2.9, -3.4, 5.7)
+	var plain, colored strings.Builder
+	if err := chart.Bar(&plain, "GDP", observations, chart.BarOptions{Width: 60}); err != nil {
+		t.Fatalf("Bar returned error: %v", err)
+	}
+	if err := chart.Bar(&colored, "GDP", observations, chart.BarOptions{Width: 60, Color: true}); err != nil {
+		t.Fatalf("Bar returned error: %v", err)
+	}
+	if strings.Contains(plain.String(), "\x1b[") {
+		t.Error("Color=false output should carry no escape codes")
+	}
+	lines := strings.Split(colored.String(), "\n")
+	if !strings.Contains(lines[1], "\x1b[32m█") {
+		t.Errorf("positive bar should be green, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "\x1b[31m█") {
+		t.Errorf("negative bar should be red, got %q", lines[2])
+	}
+	if got := stripANSI(colored.String()); got != plain.String() {
+		t.Errorf("colored bar misaligned once escapes are removed:\n%s\nwant:\n%s", got, plain.String())
+	}
+}
+
+func TestBarColorAgainstMean(t *testing.T) {
+	var out strings.Builder
+	if err := chart.Bar(&out, "X", annualObs(2020, 1, 5, 9), chart.BarOptions{Width: 40, Color: true}); err != nil {
+		t.Fatalf("Bar returned error: %v", err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if !strings.Contains(lines[1], "\x1b[31m█") {
+		t.Errorf("bar below the mean should be red, got %q", lines[1])
+	}
+	for _, line := range lines[2:4] {
+		if !strings.Contains(line, "\x1b[32m█") {
+			t.Errorf("bar at or above the mean should be green, got %q", line)
+		}
+	}
+}
+
+func TestBarColorZeroIsYellow(t *testing.T) {
+	var out strings.Builder
+	if err := chart.Bar(&out, "X", annualObs(2020, 0, 2), chart.BarOptions{Width: 40, Color: true}); err != nil {
+		t.Fatalf("Bar returned error: %v", err)
+	}
+	lines := strings.Split(out.String(), "\n")
+	if !strings.Contains(lines[1], "\x1b[33m█") {
+		t.Errorf("zero bar should be yellow, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "\x1b[32m█") {
+		t.Errorf("positive bar should be green, got %q", lines[2])
+	}
+}
+
+func TestPlotColorKeepsLayout(t *testing.T) {
+	observations := monthlyObs(2020, 1, 3.5, 4.4, 14.7, 13.3, 11.1, 8.4)
+	var plain, colored strings.Builder
+	opts := chart.PlotOptions{Width: 50, Height: 6}
+	if err := chart.Plot(&plain, "UNRATE", observations, opts); err != nil {
+		t.Fatalf("Plot returned error: %v", err)
+	}
+	opts.Color = true
+	if err := chart.Plot(&colored, "UNRATE", observations, opts); err != nil {
+		t.Fatalf("Plot returned error: %v", err)
+	}
+	if !strings.Contains(colored.String(), "\x1b[34m") {
+		t.Error("expected blue line when Color is set")
+	}
+	if got := stripANSI(colored.String()); got != plain.String() {
+		t.Errorf("colored plot misaligned once escapes are removed:\n%s\nwant:\n%s", got, plain.String())
+	}
+}
+
+func TestPlotMultiColorDistinguishesSeries(t *testing.T) {
+	series := map[string][]model.Observation{
+		"A": monthlyObs(2020, 1, 1, 2, 3, 4, 5, 6),
+		"B": monthlyObs(2020, 1, 6, 5, 4, 3, 2, 1),
+	}
+	var plain, colored strings.Builder
+	opts := chart.PlotOptions{Width: 40, Height: 6, Order: []string{"A", "B"}}
+	if err := chart.PlotMulti(&plain, series, opts); err != nil {
+		t.Fatalf("PlotMulti returned error: %v", err)
+	}
+	opts.Color = true
+	if err := chart.PlotMulti(&colored, series, opts); err != nil {
+		t.Fatalf("PlotMulti returned error: %v", err)
+	}
+	out := colored.String()
+	if !strings.Contains(out, "\x1b[34m") || !strings.Contains(out, "\x1b[33m") {
+		t.Errorf("expected blue and yellow series:\n%q", out)
+	}
+	if got := stripANSI(out); got != plain.String() {
+		t.Errorf("colored overlay misaligned once escapes are removed:\n%s\nwant:\n%s", got, plain.String())
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	if !chart.ColorEnabled(chart.ColorAlways, &buf) {
+		t.Error("always should enable color for any writer")
+	}
+	if chart.ColorEnabled(chart.ColorNever, os.Stdout) {
+		t.Error("never should disable color")
+	}
+	if chart.ColorEnabled(chart.ColorAuto, &buf) {
+		t.Error("auto should disable color for a non-terminal writer")
+	}
+	if !chart.ColorEnabled(chart.ColorOn, &buf) || chart.ColorEnabled(chart.ColorOff, os.Stdout) {
+		t.Error("on and off should behave like always and never")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if chart.ColorEnabled(chart.ColorAuto, os.Stdout) {
+		t.Error("auto should honor NO_COLOR")
+	}
+}
+
+func TestColorSigned(t *testing.T) {
+	if got := chart.ColorSigned("1.00%", 1); got != "\x1b[32m1.00%\x1b[0m" {
+		t.Errorf("positive: got %q", got)
+	}
+	if got := chart.ColorSigned("-1.00%", -1); got != "\x1b[31m-1.00%\x1b[0m" {
+		t.Errorf("negative: got %q", got)
+	}
+	if got := chart.ColorSigned(".", math.NaN()); got != "." {
+		t.Errorf("NaN should be left plain, got %q", got)
+	}
+}
+
 // ─── Utilities ────────────────────────────────────────────────────────────────
 
 // nonEmptyLines returns lines with at least one non-space character.
@@ -405,3 +1277,11 @@ func nonEmptyLines(s string) []string {
 	}
 	return out
 }
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// stripANSI removes color escape sequences so colored output can be compared
+// with its plain rendering.
+func stripANSI(s string) string {
+	return ansiEscape.ReplaceAllString(s, "")
+}
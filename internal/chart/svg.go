@@ -0,0 +1,345 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package chart
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+// ─── SVG ──────────────────────────────────────────────────────────────────────
+
+// SVG color themes, selected by the Theme option.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// svgTheme is the palette an SVG chart is drawn with.
+type svgTheme struct {
+	background string
+	text       string // title
+	label      string // tick and date labels, axes
+	caption    string
+	grid       string
+	series     string // the line, and positive bars
+	negative   string // negative bars
+}
+
+var svgThemes = map[string]svgTheme{
+	ThemeLight: {"#ffffff", "#000000", "#444444", "#666666", "#dddddd", "#1f77b4", "#d62728"},
+	ThemeDark:  {"#1e1e1e", "#f0f0f0", "#bbbbbb", "#999999", "#3a3a3a", "#4fa3e0", "#ef6b6b"},
+}
+
+// PlotSVGOptions controls standalone SVG line chart rendering.
+type PlotSVGOptions struct {
+	// Width and Height are the image size in pixels. If 0, default to 800×400.
+	Width  int
+	Height int
+	// Title overrides the default title (seriesID). Empty = use seriesID.
+	Title string
+	// Caption is a small line under the chart, such as a source citation.
+	Caption string
+	// FontFamily is the CSS font-family for all text. Empty = sans-serif.
+	FontFamily string
+	// Theme is ThemeLight or ThemeDark. Empty = ThemeLight.
+	Theme string
+}
+
+// SVGOptions is the original name of PlotSVGOptions, kept for callers of SVG.
+type SVGOptions = PlotSVGOptions
+
+// BarSVGOptions controls standalone SVG bar chart rendering.
+type BarSVGOptions struct {
+	// Width is the image width in pixels. If 0, default to 800.
+	Width int
+	// Height is the image height in pixels. If 0, it grows with the number
+	// of bars, 20 pixels each.
+	Height int
+	// Title overrides the default title (seriesID). Empty = use seriesID.
+	Title string
+	// Caption is a small line under the chart, such as a source citation.
+	Caption string
+	// MaxBars keeps only the last MaxBars observations. If 0, no limit.
+	MaxBars int
+	// FontFamily is the CSS font-family for all text. Empty = sans-serif.
+	FontFamily string
+	// Theme is ThemeLight or ThemeDark. Empty = ThemeLight.
+	Theme string
+}
+
+// SVG plot area margins, in pixels.
+const (
+	svgMarginTop    = 40
+	svgMarginRight  = 20
+	svgMarginBottom = 40
+	svgMarginLeft   = 64
+)
+
+// svgBarHeight is the row height per bar when BarSVGOptions.Height is 0.
+const svgBarHeight = 20
+
+// SVG renders obs as a standalone SVG line chart. It is the original name of
+// PlotSVG and behaves identically.
+func SVG(w io.Writer, seriesID string, obs []model.Observation, opts SVGOptions) error {
+	return PlotSVG(w, seriesID, obs, opts)
+}
+
+// PlotSVG renders obs as a standalone SVG line chart with Y gridlines, tick
+// labels, and start/middle/end date labels. NaN values break the line. Series
+// longer than the plot is wide are averaged into one point per pixel column,
+// as Plot does for character columns.
+func PlotSVG(w io.Writer, seriesID string, obs []model.Observation, opts PlotSVGOptions) error {
+	width := opts.Width
+	if width <= 0 {
+		width = 800
+	}
+	height := opts.Height
+	if height <= 0 {
+		height = 400
+	}
+	title := opts.Title
+	if title == "" {
+		title = seriesID
+	}
+	theme, err := lookupSVGTheme(opts.Theme)
+	if err != nil {
+		return err
+	}
+
+	minVal, maxVal, err := valueRange(obs)
+	if err != nil {
+		return fmt.Errorf("chart svg: %w", err)
+	}
+
+	left, top := float64(svgMarginLeft), float64(svgMarginTop)
+	plotW := float64(width - svgMarginLeft - svgMarginRight)
+	plotH := float64(height - svgMarginTop - svgMarginBottom)
+	if plotW < 10 || plotH < 10 {
+		return fmt.Errorf("chart svg: %dx%d is too small to draw", width, height)
+	}
+
+	values := make([]float64, len(obs))
+	for i, o := range obs {
+		values[i] = o.Value
+	}
+	if len(values) > int(plotW) {
+		values = sampleCols(obs, int(plotW))
+	}
+
+	xAt := func(i int) float64 {
+		return left + float64(i)*plotW/float64(len(values)-1)
+	}
+	yAt := func(v float64) float64 {
+		if maxVal == minVal {
+			return top + plotH/2
+		}
+		return top + (maxVal-v)/(maxVal-minVal)*plotH
+	}
+
+	var b strings.Builder
+	svgOpen(&b, width, height, opts.FontFamily, theme)
+	fmt.Fprintf(&b, `<text x="%s" y="%s" font-size="14" font-weight="bold" fill="%s">%s  (%s to %s)</text>`+"\n",
+		svgNum(left), svgNum(top/2+5), theme.text, html.EscapeString(title),
+		obs[0].Date.Format("2006-01"), obs[len(obs)-1].Date.Format("2006-01"))
+
+	// Gridlines and Y tick labels.
+	for _, t := range yTicks(minVal, maxVal, int(plotH)) {
+		y := svgNum(yAt(t))
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+			svgNum(left), y, svgNum(left+plotW), y, theme.grid)
+		fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`+"\n",
+			svgNum(left-6), y, theme.label, html.EscapeString(formatFloat(t)))
+	}
+
+	// Axes.
+	fmt.Fprintf(&b, `<path d="M%s %sV%sH%s" fill="none" stroke="%s"/>`+"\n",
+		svgNum(left), svgNum(top), svgNum(top+plotH), svgNum(left+plotW), theme.label)
+
+	// X date labels: start, middle, end.
+	labelY := svgNum(top + plotH + 20)
+	for _, l := range []struct {
+		x      float64
+		anchor string
+		obs    model.Observation
+	}{
+		{left, "start", obs[0]},
+		{left + plotW/2, "middle", obs[len(obs)/2]},
+		{left + plotW, "end", obs[len(obs)-1]},
+	} {
+		fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="%s" fill="%s">%s</text>`+"\n",
+			svgNum(l.x), labelY, l.anchor, theme.label, l.obs.Date.Format("2006-01"))
+	}
+
+	// The line: one subpath per run of valid values, so NaN leaves a gap.
+	// A run of a single value has no length and is drawn as a dot instead.
+	var path strings.Builder
+	var dots []int
+	runStart := -1
+	for i := 0; i <= len(values); i++ {
+		if i < len(values) && !math.IsNaN(values[i]) {
+			if runStart < 0 {
+				runStart = i
+				fmt.Fprintf(&path, "M%s %s", svgNum(xAt(i)), svgNum(yAt(values[i])))
+			} else {
+				fmt.Fprintf(&path, "L%s %s", svgNum(xAt(i)), svgNum(yAt(values[i])))
+			}
+			continue
+		}
+		if runStart >= 0 && i-runStart == 1 {
+			dots = append(dots, runStart)
+		}
+		runStart = -1
+	}
+	fmt.Fprintf(&b, `<path d="%s" fill="none" stroke="%s" stroke-width="1.5" stroke-linejoin="round"/>`+"\n", path.String(), theme.series)
+	for _, i := range dots {
+		fmt.Fprintf(&b, `<circle cx="%s" cy="%s" r="2" fill="%s"/>`+"\n", svgNum(xAt(i)), svgNum(yAt(values[i])), theme.series)
+	}
+
+	svgClose(&b, left, height, opts.Caption, theme)
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// BarSVG renders obs as a standalone SVG horizontal bar chart, one bar per
+// observation, labeled with its date on the left and value on the right, like
+// Bar. Bars grow from zero; when values change sign a vertical zero baseline
+// is drawn and negative bars extend left of it. NaN observations are skipped.
+func BarSVG(w io.Writer, seriesID string, obs []model.Observation, opts BarSVGOptions) error {
+	theme, err := lookupSVGTheme(opts.Theme)
+	if err != nil {
+		return err
+	}
+	var valid []model.Observation
+	for _, o := range obs {
+		if !math.IsNaN(o.Value) {
+			valid = append(valid, o)
+		}
+	}
+	if len(valid) == 0 {
+		return fmt.Errorf("chart bar svg: no non-NaN observations to render")
+	}
+	if opts.MaxBars > 0 && len(valid) > opts.MaxBars {
+		valid = valid[len(valid)-opts.MaxBars:]
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		width = 800
+	}
+	height := opts.Height
+	if height <= 0 {
+		height = svgMarginTop + svgMarginBottom + len(valid)*svgBarHeight
+	}
+	title := opts.Title
+	if title == "" {
+		title = seriesID
+	}
+
+	dateFmt := dateLabelLayout(valid)
+	valWidth := 0
+	for _, o := range valid {
+		if l := len(formatBarValue(o.Value)); l > valWidth {
+			valWidth = l
+		}
+	}
+
+	// Bars sit between the date labels and the value labels, assuming about
+	// 7 pixels per character at the 12px font size.
+	left := float64(len(valid[0].Date.Format(dateFmt))*7 + 16)
+	right := float64(width) - float64(valWidth*7+16)
+	top := float64(svgMarginTop)
+	plotW := right - left
+	rowH := float64(height-svgMarginTop-svgMarginBottom) / float64(len(valid))
+	if plotW < 10 || rowH < 2 {
+		return fmt.Errorf("chart bar svg: %dx%d is too small for %d bars", width, height, len(valid))
+	}
+
+	lo, hi := 0.0, 0.0
+	for _, o := range valid {
+		lo = math.Min(lo, o.Value)
+		hi = math.Max(hi, o.Value)
+	}
+	if lo == hi {
+		hi = 1 // every value is zero
+	}
+	xAt := func(v float64) float64 {
+		return left + (v-lo)/(hi-lo)*plotW
+	}
+	zeroX := xAt(0)
+
+	var b strings.Builder
+	svgOpen(&b, width, height, opts.FontFamily, theme)
+	fmt.Fprintf(&b, `<text x="%s" y="%s" font-size="14" font-weight="bold" fill="%s">%s  (%s to %s)</text>`+"\n",
+		svgNum(left), svgNum(top/2+5), theme.text, html.EscapeString(title),
+		valid[0].Date.Format(dateFmt), valid[len(valid)-1].Date.Format(dateFmt))
+
+	for i, o := range valid {
+		y := top + float64(i)*rowH
+		mid := svgNum(y + rowH/2)
+		x, barW := zeroX, xAt(o.Value)-zeroX
+		fill := theme.series
+		if o.Value < 0 {
+			x, barW = xAt(o.Value), -barW
+			fill = theme.negative
+		}
+		fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`+"\n",
+			svgNum(left-8), mid, theme.label, o.Date.Format(dateFmt))
+		fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+			svgNum(x), svgNum(y+rowH*0.15), svgNum(barW), svgNum(rowH*0.7), fill)
+		fmt.Fprintf(&b, `<text x="%s" y="%s" text-anchor="end" dominant-baseline="middle" fill="%s">%s</text>`+"\n",
+			svgNum(float64(width-8)), mid, theme.label, html.EscapeString(formatBarValue(o.Value)))
+	}
+	if lo < 0 {
+		fmt.Fprintf(&b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n",
+			svgNum(zeroX), svgNum(top), svgNum(zeroX), svgNum(top+rowH*float64(len(valid))), theme.label)
+	}
+
+	svgClose(&b, left, height, opts.Caption, theme)
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func lookupSVGTheme(name string) (svgTheme, error) {
+	if name == "" {
+		name = ThemeLight
+	}
+	theme, ok := svgThemes[name]
+	if !ok {
+		return svgTheme{}, fmt.Errorf("chart svg: unknown theme %q (want %s or %s)", name, ThemeLight, ThemeDark)
+	}
+	return theme, nil
+}
+
+// svgOpen writes the <svg> root element and the background. Colors and fonts
+// are attributes rather than CSS, so the image renders the same anywhere.
+func svgOpen(b *strings.Builder, width, height int, font string, theme svgTheme) {
+	if font == "" {
+		font = "sans-serif"
+	}
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s" font-size="12">`+"\n",
+		width, height, width, height, html.EscapeString(font))
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, theme.background)
+}
+
+// svgClose writes the optional caption and closes the <svg> element.
+func svgClose(b *strings.Builder, left float64, height int, caption string, theme svgTheme) {
+	if caption != "" {
+		fmt.Fprintf(b, `<text x="%s" y="%d" font-size="10" fill="%s">%s</text>`+"\n",
+			svgNum(left), height-6, theme.caption, html.EscapeString(caption))
+	}
+	b.WriteString("</svg>\n")
+}
+
+// svgNum formats a coordinate with at most two decimals and no trailing zeros.
+func svgNum(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
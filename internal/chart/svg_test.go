@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package chart_test
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/derickschaefer/reserve/internal/chart"
+)
+
+// svgElements decodes out as XML, failing the test if it is malformed, and
+// returns the element names in document order.
+func svgElements(t *testing.T, out string) []string {
+	t.Helper()
+	dec := xml.NewDecoder(strings.NewReader(out))
+	var names []string
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return names
+		}
+		if err != nil {
+			t.Fatalf("invalid SVG: %v\n%s", err, out)
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			names = append(names, se.Name.Local)
+		}
+	}
+}
+
+func TestSVGBasic(t *testing.T) {
+	var buf strings.Builder
+	observations := monthlyObs(2020, 1, 3.5, 4.4, 14.7, 13.3, 11.1, 8.4)
+	if err := chart.PlotSVG(&buf, "UNRATE", observations, chart.PlotSVGOptions{Caption: "Source: BLS & FRED"}); err != nil {
+		t.Fatalf("PlotSVG returned error: %v", err)
+	}
+	out := buf.String()
+	names := svgElements(t, out)
+	if len(names) == 0 || names[0] != "svg" {
+		t.Fatalf("expected an <svg> root, got %v", names)
+	}
+	for _, want := range []string{
+		`width="800" height="400"`,
+		"UNRATE  (2020-01 to 2020-06)",
+		">14.7<", // top tick via formatFloat
+		">3.5<",  // bottom tick
+		">2020-01<", ">2020-06<",
+		"Source: BLS &amp; FRED",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG missing %q\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "<line ") < 3 {
+		t.Errorf("expected gridlines\n%s", out)
+	}
+}
+
+func TestSVGNaNBreaksLine(t *testing.T) {
+	var buf strings.Builder
+	observations := monthlyObs(2020, 1, 1, 2, math.NaN(), 4, 5, math.NaN(), 7)
+	if err := chart.PlotSVG(&buf, "X", observations, chart.PlotSVGOptions{}); err != nil {
+		t.Fatalf("PlotSVG returned error: %v", err)
+	}
+	out := buf.String()
+	svgElements(t, out)
+	var line string
+	for _, l := range strings.Split(out, "\n") {
+		if strings.Contains(l, `stroke="#1f77b4"`) && strings.HasPrefix(l, "<path") {
+			line = l
+		}
+	}
+	if got := strings.Count(line, "M"); got != 3 {
+		t.Errorf("expected a new subpath after each gap, got %d: %s", got, line)
+	}
+	if got := strings.Count(out, "<circle"); got != 1 {
+		t.Errorf("expected the isolated last point as one dot, got %d", got)
+	}
+}
+
+func TestSVGLargeValuesUseSuffixes(t *testing.T) {
+	var buf strings.Builder
+	observations := annualObs(2000, 1500, 2500000)
+	if err := chart.PlotSVG(&buf, "GDP", observations, chart.PlotSVGOptions{}); err != nil {
+		t.Fatalf("PlotSVG returned error: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, ">2.5M<") || !strings.Contains(out, ">1.5K<") {
+		t.Errorf("expected K/M tick labels\n%s", out)
+	}
+}
+
+func TestSVGSamplesLongSeries(t *testing.T) {
+	values := make([]float64, 5000)
+	for i := range values {
+		values[i] = float64(i % 100)
+	}
+	var buf strings.Builder
+	if err := chart.PlotSVG(&buf, "X", monthlyObs(1900, 1, values...), chart.PlotSVGOptions{Width: 200}); err != nil {
+		t.Fatalf("PlotSVG returned error: %v", err)
+	}
+	if n := strings.Count(buf.String(), "L"); n > 200 {
+		t.Errorf("expected at most one point per pixel column, got %d segments", n)
+	}
+}
+
+func TestSVGErrors(t *testing.T) {
+	var buf strings.Builder
+	if err := chart.PlotSVG(&buf, "X", monthlyObs(2020, 1, 1), chart.PlotSVGOptions{}); err == nil {
+		t.Error("expected error for a single observation")
+	}
+	if err := chart.PlotSVG(&buf, "X", monthlyObs(2020, 1, 1, 2), chart.PlotSVGOptions{Width: 50, Height: 50}); err == nil {
+		t.Error("expected error for a size with no room to plot")
+	}
+}
+
+func TestSVGMatchesPlotSVG(t *testing.T) {
+	observations := monthlyObs(2020, 1, 3.5, 4.4, 14.7, 13.3)
+	var legacy, plot strings.Builder
+	if err := chart.SVG(&legacy, "UNRATE", observations, chart.SVGOptions{Title: "Unemployment"}); err != nil {
+		t.Fatalf("SVG returned error: %v", err)
+	}
+	if err := chart.PlotSVG(&plot, "UNRATE", observations, chart.PlotSVGOptions{Title: "Unemployment"}); err != nil {
+		t.Fatalf("PlotSVG returned error: %v", err)
+	}
+	if legacy.String() != plot.String() {
+		t.Error("SVG and PlotSVG should render the same chart")
+	}
+}
+
+func TestBarSVGOneRectPerValidObservation(t *testing.T) {
+	var buf strings.Builder
+	observations := annualObs(2020, 3.5, math.NaN(), 8.1, 5.4)
+	if err := chart.BarSVG(&buf, "UNRATE", observations, chart.BarSVGOptions{}); err != nil {
+		t.Fatalf("BarSVG returned error: %v", err)
+	}
+	out := buf.String()
+	names := svgElements(t, out)
+	if len(names) == 0 || names[0] != "svg" {
+		t.Fatalf("expected an <svg> root, got %v", names)
+	}
+	// One background rect plus one per non-NaN value; the NaN year draws nothing.
+	if got := strings.Count(out, "<rect"); got != 4 {
+		t.Errorf("expected 4 <rect> elements, got %d\n%s", got, out)
+	}
+	if strings.Contains(out, ">2021<") {
+		t.Errorf("NaN observation should not be labeled\n%s", out)
+	}
+	for _, want := range []string{">2020<", ">2023<", ">8.10<", "UNRATE  (2020 to 2023)", `height="140"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("BarSVG missing %q\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<line") {
+		t.Errorf("all-positive chart should have no zero baseline\n%s", out)
+	}
+}
+
+func TestBarSVGNegativeValuesDrawBaseline(t *testing.T) {
+	var buf strings.Builder
+	if err := chart.BarSVG(&buf, "GDPG", annualObs(2019, 2.3, -2.8, 5.9), chart.BarSVGOptions{MaxBars: 2}); err != nil {
+		t.Fatalf("BarSVG returned error: %v", err)
+	}
+	out := buf.String()
+	svgElements(t, out)
+	if got := strings.Count(out, "<line"); got != 1 {
+		t.Errorf("expected one zero baseline, got %d\n%s", got, out)
+	}
+	if !strings.Contains(out, `fill="#d62728"`) {
+		t.Errorf("negative bar should use the negative color\n%s", out)
+	}
+	if strings.Contains(out, ">2019<") {
+		t.Errorf("--max-bars 2 should keep only the last two bars\n%s", out)
+	}
+}
+
+func TestSVGThemeAndFont(t *testing.T) {
+	var buf strings.Builder
+	opts := chart.PlotSVGOptions{Theme: chart.ThemeDark, FontFamily: `"IBM Plex Sans", sans-serif`}
+	if err := chart.PlotSVG(&buf, "X", monthlyObs(2020, 1, 1, 2, 3), opts); err != nil {
+		t.Fatalf("PlotSVG returned error: %v", err)
+	}
+	out := buf.String()
+	svgElements(t, out)
+	if !strings.Contains(out, `fill="#1e1e1e"`) || !strings.Contains(out, `font-family="&#34;IBM Plex Sans&#34;, sans-serif"`) {
+		t.Errorf("expected dark background and escaped font family\n%s", out)
+	}
+	if strings.Contains(out, "<style") || strings.Contains(out, "href=") {
+		t.Errorf("SVG should be self-contained\n%s", out)
+	}
+	if err := chart.BarSVG(&buf, "X", annualObs(2020, 1), chart.BarSVGOptions{Theme: "sepia"}); err == nil {
+		t.Error("expected error for an unknown theme")
+	}
+}
@@ -82,7 +82,8 @@ func EnsureSeriesMeta(ctx context.Context, cfg *config.Config, client SeriesClie
 	}
 	enriched := EnrichSeriesMeta(*meta, tags)
 	enriched.PermissionOnFile = cfg.HasGrantedSeriesPermission(seriesID)
-	if s != nil {
+	// A read-only store serves the refreshed record without keeping it.
+	if s != nil && !s.ReadOnly() {
 		if err := s.PutSeriesMeta(enriched); err != nil {
 			return model.SeriesMeta{}, true, fmt.Errorf("storing local rights index for %s: %w", seriesID, err)
 		}
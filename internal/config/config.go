@@ -4,9 +4,16 @@
 // Package config handles loading and resolving reserve configuration.
 // Resolution order (first non-empty value wins):
 //  1. CLI flag --api-key
-//  2. Environment variable FRED_API_KEY
-//  3. config.json in the current working directory
-//  4. config.json in the per-user config directory
+//  2. Environment variable FRED_API_KEY (RESERVE_FORMAT, RESERVE_TIMEOUT,
+//     RESERVE_CONCURRENCY, RESERVE_RATE and RESERVE_BASE_URL for the others)
+//  3. config.<profile>.json beside either config.json, when a profile is selected
+//  4. the selected entry of "profiles" in either config.json
+//  5. config.json in the current working directory
+//  6. config.json in the per-user config directory
+//
+// The profile itself is chosen first: --profile, then RESERVE_PROFILE, then
+// the "profile" key of config.json. The top-level keys are the "default"
+// profile.
 package config
 
 import (
@@ -14,24 +21,51 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"math"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // observation_timezone must resolve on hosts without a zoneinfo database
+
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/keychain"
 )
 
 const (
-	DefaultConfigFile  = "config.json"
-	DefaultFormat      = "table"
-	DefaultTimeout     = 30 * time.Second
-	DefaultConcurrency = 8
-	DefaultRate        = 2.0
-	EnvAPIKey          = "FRED_API_KEY"
-	EnvDBPath          = "RESERVE_DB_PATH"
-	DefaultPersonOrg   = "student"
+	DefaultConfigFile          = "config.json"
+	DefaultFormat              = "table"
+	DefaultTimeout             = 30 * time.Second
+	DefaultConcurrency         = 8
+	DefaultRate                = 2.0
+	EnvAPIKey                  = "FRED_API_KEY"
+	EnvDBPath                  = "RESERVE_DB_PATH"
+	EnvProxyURL                = "RESERVE_PROXY_URL"
+	EnvSMTPPassword            = "RESERVE_SMTP_PASSWORD"
+	EnvProfile                 = "RESERVE_PROFILE"
+	EnvFormat                  = "RESERVE_FORMAT"
+	EnvTimeout                 = "RESERVE_TIMEOUT"
+	EnvConcurrency             = "RESERVE_CONCURRENCY"
+	EnvRate                    = "RESERVE_RATE"
+	EnvBaseURL                 = "RESERVE_BASE_URL"
+	DefaultProfile             = "default"
+	DefaultPersonOrg           = "student"
+	DefaultObservationTimezone = "UTC"
+
+	// KeychainAPIKey as the api_key value means the key is held in the OS
+	// keychain under KeychainService and KeychainAccount.
+	KeychainAPIKey  = "keychain"
+	KeychainService = "reserve"
+	KeychainAccount = "fred-api-key"
 )
 
+// keychainGet reads the OS keychain; tests replace it.
+var keychainGet = keychain.Get
+
 var defaultRightsRefreshDays = map[string]int{
 	"default": 30,
 	"export":  7,
@@ -92,6 +126,34 @@ func (s *Snippet) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Email holds the SMTP settings `reserve watch --notify email` sends with.
+// The password is read from RESERVE_SMTP_PASSWORD and never stored.
+type Email struct {
+	SMTPAddr string   `json:"smtp_addr,omitempty"` // host:port
+	From     string   `json:"from,omitempty"`
+	To       []string `json:"to,omitempty"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"-"`
+}
+
+// Configured reports whether e has everything needed to send mail.
+func (e Email) Configured() bool {
+	return e.SMTPAddr != "" && e.From != "" && len(e.To) > 0
+}
+
+// Profile is one entry of the "profiles" map in config.json. Empty fields
+// keep the value from the top-level keys.
+type Profile struct {
+	APIKey        string  `json:"api_key,omitempty"`
+	DefaultFormat string  `json:"default_format,omitempty"`
+	Timeout       string  `json:"timeout,omitempty"`
+	Concurrency   int     `json:"concurrency,omitempty"`
+	Rate          float64 `json:"rate,omitempty"`
+	BaseURL       string  `json:"base_url,omitempty"`
+	ProxyURL      string  `json:"proxy_url,omitempty"`
+	DBPath        string  `json:"db_path,omitempty"`
+}
+
 // File is the on-disk representation of config.json.
 type File struct {
 	APIKey                               string             `json:"api_key"`
@@ -100,6 +162,7 @@ type File struct {
 	Concurrency                          int                `json:"concurrency"`
 	Rate                                 float64            `json:"rate"`
 	BaseURL                              string             `json:"base_url"`
+	ProxyURL                             string             `json:"proxy_url,omitempty"`
 	DBPath                               string             `json:"db_path"`
 	PersonOrgType                        string             `json:"person_org_type"`
 	BlockUnknownRights                   bool               `json:"block_unknown_rights"`
@@ -114,6 +177,10 @@ type File struct {
 	LegacySnippets                       map[string]Snippet `json:"snippets,omitempty"`
 	RightsRefreshDays                    map[string]int     `json:"rights_refresh_days"`
 	LogComplianceDecisions               bool               `json:"log_compliance_decisions"`
+	ObservationTimezone                  string             `json:"observation_timezone,omitempty"`
+	Email                                *Email             `json:"email,omitempty"`
+	Profile                              string             `json:"profile,omitempty"` // profile applied when --profile is not given; ignored inside profile files
+	Profiles                             map[string]Profile `json:"profiles,omitempty"`
 }
 
 // Config is the fully-resolved runtime configuration.
@@ -124,7 +191,10 @@ type Config struct {
 	Timeout                              time.Duration
 	Concurrency                          int
 	Rate                                 float64
+	CircuitBreakThreshold                int           // 0 = fred.DefaultCircuitBreakThreshold
+	CircuitBreakTimeout                  time.Duration // 0 = fred.DefaultCircuitBreakTimeout
 	BaseURL                              string
+	ProxyURL                             string // HTTP(S) or SOCKS5 proxy for FRED requests (empty for none)
 	DBPath                               string
 	PersonOrgType                        string
 	BlockUnknownRights                   bool
@@ -138,7 +208,12 @@ type Config struct {
 	Snippet                              SnippetSystem
 	RightsRefreshDays                    map[string]int
 	LogComplianceDecisions               bool
-	ConfigPath                           string // path of the config.json that was loaded (empty if none found)
+	ObservationTimezone                  string // IANA name used to localise "now"; reference dates stay UTC
+	Email                                Email
+	ConfigPath                           string   // path of the config.json that was loaded (empty if none found)
+	Profile                              string   // selected profile name (empty for the default profile)
+	ProfilePath                          string   // path of the last file that defined the profile (empty if none found)
+	Warnings                             []string // invalid environment values that were ignored
 
 	// Runtime overrides set from CLI flags after Load()
 	NoCache bool
@@ -151,6 +226,20 @@ type Config struct {
 // Load resolves configuration from all sources.
 // flagAPIKey is the value of --api-key (empty string if not set).
 func Load(flagAPIKey string) (*Config, error) {
+	return LoadProfile(flagAPIKey, "")
+}
+
+// profileNamePattern keeps a profile name to a plain file-name fragment.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// LoadProfile is Load with a named profile. flagProfile is the value of
+// --profile; when empty, RESERVE_PROFILE and then the "profile" key of
+// config.json select one. The profile's entry in the "profiles" map of the
+// per-user and then the local config.json is applied on top of both base
+// files, followed by its config.<name>.json beside either of them; each sets
+// only the keys it contains. A profile defined nowhere leaves the base
+// configuration as is, and "default" selects the base configuration itself.
+func LoadProfile(flagAPIKey, flagProfile string) (*Config, error) {
 	cfg := &Config{
 		Format:                               DefaultFormat,
 		Timeout:                              DefaultTimeout,
@@ -166,11 +255,18 @@ func Load(flagAPIKey string) (*Config, error) {
 		AllowOverrideWithPermissionRecord:    true,
 		RightsRefreshDays:                    cloneRightsRefreshDays(defaultRightsRefreshDays),
 		LogComplianceDecisions:               true,
+		ObservationTimezone:                  DefaultObservationTimezone,
 	}
 
 	// Layer 1: per-user config.json (lowest file priority)
+	var bases []*File
+	var basePaths []string
 	if f, path, err := loadUserFile(); err == nil {
 		applyFile(cfg, f, path)
+		if f.Profile != "" {
+			cfg.Profile = f.Profile
+		}
+		bases, basePaths = append(bases, f), append(basePaths, path)
 	} else if !errors.Is(err, fs.ErrNotExist) {
 		return nil, err
 	}
@@ -178,10 +274,40 @@ func Load(flagAPIKey string) (*Config, error) {
 	// Layer 2: local config.json overrides per-user config
 	if f, path, err := loadLocalFile(); err == nil {
 		applyFile(cfg, f, path)
+		if f.Profile != "" {
+			cfg.Profile = f.Profile
+		}
+		bases, basePaths = append(bases, f), append(basePaths, path)
 	} else if !errors.Is(err, fs.ErrNotExist) {
 		return nil, err
 	}
 
+	// Layer 2b: the selected profile, --profile taking priority over
+	// RESERVE_PROFILE and both over config.json
+	if v := strings.TrimSpace(os.Getenv(EnvProfile)); v != "" {
+		cfg.Profile = v
+	}
+	if flagProfile != "" {
+		cfg.Profile = flagProfile
+	}
+	if cfg.Profile == DefaultProfile {
+		cfg.Profile = ""
+	}
+	if cfg.Profile != "" {
+		if !profileNamePattern.MatchString(cfg.Profile) {
+			return nil, fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_'", cfg.Profile)
+		}
+		for i, f := range bases {
+			if p, ok := f.Profiles[cfg.Profile]; ok {
+				applyProfileEntry(cfg, p)
+				cfg.ProfilePath = basePaths[i]
+			}
+		}
+		if err := applyProfiles(cfg); err != nil {
+			return nil, err
+		}
+	}
+
 	// Layer 3: environment variable
 	if v := os.Getenv(EnvAPIKey); v != "" {
 		cfg.APIKey = v
@@ -189,12 +315,26 @@ func Load(flagAPIKey string) (*Config, error) {
 	if v := os.Getenv(EnvDBPath); v != "" {
 		cfg.DBPath = v
 	}
+	if v := strings.TrimSpace(os.Getenv(EnvProxyURL)); v != "" {
+		cfg.ProxyURL = v
+	}
+	applyEnvSettings(cfg)
+	cfg.Email.Password = os.Getenv(EnvSMTPPassword)
 
 	// Layer 4: CLI flag (highest priority)
 	if flagAPIKey != "" {
 		cfg.APIKey = flagAPIKey
 	}
 
+	// Only a key that survives every layer as "keychain" touches the keychain.
+	if cfg.APIKey == KeychainAPIKey {
+		key, err := keychainGet(KeychainService, KeychainAccount)
+		if err != nil {
+			return nil, fmt.Errorf("api_key is %q but reading it from the OS keychain failed: %w", KeychainAPIKey, err)
+		}
+		cfg.APIKey = key
+	}
+
 	// Set default DB path if still unset
 	if cfg.DBPath == "" {
 		home, err := os.UserHomeDir()
@@ -219,7 +359,8 @@ func (c *Config) Validate() error {
 				"  1. CLI flag:        reserve --api-key YOUR_KEY ...\n" +
 				"  2. Environment:     export FRED_API_KEY=YOUR_KEY\n" +
 				"  3. config.json:     {\"api_key\": \"YOUR_KEY\"}\n\n" +
-				"Get a free key at https://fred.stlouisfed.org/docs/api/api_key.html",
+				"Get a free key at https://fred.stlouisfed.org/docs/api/api_key.html\n" +
+				"Run 'reserve config doctor' to check the rest of your setup.",
 		)
 	}
 	return nil
@@ -234,6 +375,16 @@ func (c *Config) RedactedAPIKey() string {
 	return c.APIKey[:2] + "****" + c.APIKey[len(c.APIKey)-2:]
 }
 
+// RedactedProxyURL returns the proxy URL with any password masked, or ""
+// when no proxy is set. Safe for logging and display.
+func (c *Config) RedactedProxyURL() string {
+	u, err := url.Parse(c.ProxyURL)
+	if c.ProxyURL == "" || err != nil {
+		return c.ProxyURL
+	}
+	return u.Redacted()
+}
+
 // UserConfigPath returns the per-user config.json path for reserve.
 func UserConfigPath() (string, error) {
 	dir, err := os.UserConfigDir()
@@ -355,6 +506,9 @@ func applyFile(cfg *Config, f *File, path string) {
 	if f.BaseURL != "" {
 		cfg.BaseURL = f.BaseURL
 	}
+	if v := strings.TrimSpace(f.ProxyURL); v != "" {
+		cfg.ProxyURL = v
+	}
 	if f.DBPath != "" {
 		cfg.DBPath = f.DBPath
 	}
@@ -382,6 +536,180 @@ func applyFile(cfg *Config, f *File, path string) {
 		cfg.RightsRefreshDays = cloneRightsRefreshDays(f.RightsRefreshDays)
 	}
 	cfg.LogComplianceDecisions = f.LogComplianceDecisions
+	if tz := strings.TrimSpace(f.ObservationTimezone); tz != "" {
+		cfg.ObservationTimezone = tz
+	}
+	if f.Email != nil {
+		cfg.Email = Email{
+			SMTPAddr: strings.TrimSpace(f.Email.SMTPAddr),
+			From:     strings.TrimSpace(f.Email.From),
+			To:       trimmedNonEmpty(f.Email.To),
+			Username: strings.TrimSpace(f.Email.Username),
+		}
+	}
+}
+
+// applyEnvSettings applies RESERVE_FORMAT, RESERVE_TIMEOUT,
+// RESERVE_CONCURRENCY, RESERVE_RATE and RESERVE_BASE_URL. An invalid value is
+// ignored, keeping the value from the config files, and recorded in
+// cfg.Warnings.
+func applyEnvSettings(cfg *Config) {
+	ignore := func(name, value, want string) {
+		cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("ignoring %s=%q: %s", name, value, want))
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvFormat)); v != "" {
+		if IsValidFormat(v) {
+			cfg.Format = v
+		} else {
+			ignore(EnvFormat, v, "must be one of table, json, jsonl, csv, tsv, md, yaml")
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvTimeout)); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.Timeout = d
+		} else {
+			ignore(EnvTimeout, v, "must be a positive duration such as 30s or 2m")
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvConcurrency)); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Concurrency = n
+		} else {
+			ignore(EnvConcurrency, v, "must be a positive integer")
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvRate)); v != "" {
+		if r, err := strconv.ParseFloat(v, 64); err == nil && r > 0 && !math.IsInf(r, 0) {
+			cfg.Rate = r
+		} else {
+			ignore(EnvRate, v, "must be a positive number of requests per second")
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv(EnvBaseURL)); v != "" {
+		if u, err := url.Parse(v); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+			cfg.BaseURL = v
+		} else {
+			ignore(EnvBaseURL, v, "must be an http or https URL")
+		}
+	}
+}
+
+// ProfileFileName returns the file name of the named profile: config.<name>.json.
+func ProfileFileName(name string) string {
+	return strings.TrimSuffix(DefaultConfigFile, ".json") + "." + name + ".json"
+}
+
+// ActiveProfile returns the selected profile name, or DefaultProfile when
+// none is selected.
+func (c *Config) ActiveProfile() string {
+	if c.Profile == "" {
+		return DefaultProfile
+	}
+	return c.Profile
+}
+
+// ValidProfileName reports whether name can be used as a profile name.
+func ValidProfileName(name string) bool {
+	return profileNamePattern.MatchString(name)
+}
+
+// applyProfileEntry copies the non-empty fields of a "profiles" entry into cfg.
+func applyProfileEntry(cfg *Config, p Profile) {
+	if p.APIKey != "" {
+		cfg.APIKey = p.APIKey
+	}
+	if p.DefaultFormat != "" {
+		cfg.Format = p.DefaultFormat
+	}
+	if p.Timeout != "" {
+		if d, err := time.ParseDuration(p.Timeout); err == nil {
+			cfg.Timeout = d
+		}
+	}
+	if p.Concurrency > 0 {
+		cfg.Concurrency = p.Concurrency
+	}
+	if p.Rate > 0 {
+		cfg.Rate = p.Rate
+	}
+	if p.BaseURL != "" {
+		cfg.BaseURL = p.BaseURL
+	}
+	if v := strings.TrimSpace(p.ProxyURL); v != "" {
+		cfg.ProxyURL = v
+	}
+	if p.DBPath != "" {
+		cfg.DBPath = p.DBPath
+	}
+}
+
+// applyProfiles overlays cfg.Profile's file from the per-user config
+// directory and then the working directory.
+func applyProfiles(cfg *Config) error {
+	var dirs []string
+	if user, err := UserConfigPath(); err == nil {
+		dirs = append(dirs, filepath.Dir(user))
+	}
+	local, err := filepath.Abs(DefaultConfigFile)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(local); !slices.Contains(dirs, dir) {
+		dirs = append(dirs, dir)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, ProfileFileName(cfg.Profile))
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+		}
+		f, err := parseFile(data)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+		}
+		raw, err := parseRawConfig(data)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+		}
+		applyProfileFile(cfg, &f, raw, path)
+	}
+	return nil
+}
+
+// applyProfileFile is applyFile for a partial file: parseFile fills absent
+// keys with defaults, and applyFile copies booleans and lists even when they
+// are zero, so every key the profile does not set is restored afterwards.
+// ConfigPath keeps naming the base config.json.
+func applyProfileFile(cfg *Config, f *File, raw map[string]json.RawMessage, path string) {
+	base := *cfg
+	applyFile(cfg, f, path)
+	cfg.ConfigPath = base.ConfigPath
+	cfg.ProfilePath = path
+	restore := map[string]func(){
+		"person_org_type":        func() { cfg.PersonOrgType = base.PersonOrgType },
+		"block_unknown_rights":   func() { cfg.BlockUnknownRights = base.BlockUnknownRights },
+		"block_ambiguous_rights": func() { cfg.BlockAmbiguousRights = base.BlockAmbiguousRights },
+		"block_preapproval_required_in_commercial": func() {
+			cfg.BlockPreapprovalRequiredInCommercial = base.BlockPreapprovalRequiredInCommercial
+		},
+		"require_citation_on_display": func() { cfg.RequireCitationOnDisplay = base.RequireCitationOnDisplay },
+		"require_citation_on_export":  func() { cfg.RequireCitationOnExport = base.RequireCitationOnExport },
+		"allow_override_with_permission_record": func() {
+			cfg.AllowOverrideWithPermissionRecord = base.AllowOverrideWithPermissionRecord
+		},
+		"granted_series_permissions": func() { cfg.GrantedSeriesPermissions = base.GrantedSeriesPermissions },
+		"snippet":                    func() { cfg.Snippet = base.Snippet },
+		"rights_refresh_days":        func() { cfg.RightsRefreshDays = base.RightsRefreshDays },
+		"log_compliance_decisions":   func() { cfg.LogComplianceDecisions = base.LogComplianceDecisions },
+	}
+	for key, undo := range restore {
+		if _, ok := raw[key]; !ok {
+			undo()
+		}
+	}
 }
 
 // Template returns a File populated with sensible defaults, suitable for
@@ -419,6 +747,71 @@ func WriteFile(path string, f File) error {
 	return os.WriteFile(path, append(data, '\n'), 0600)
 }
 
+// Migrate rewrites the config file at path in the current shape: fields added
+// since the file was written are filled with their Template defaults, existing
+// values are kept, and keys are emitted in canonical order. The original file
+// is preserved alongside it as path + ".bak".
+func Migrate(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config.json: %w", err)
+	}
+	raw, err := parseRawConfig(data)
+	if err != nil {
+		return fmt.Errorf("parsing config.json: %w", err)
+	}
+	f, err := parseFile(data)
+	if err != nil {
+		return fmt.Errorf("parsing config.json: %w", err)
+	}
+	f = withTemplateDefaults(raw, f)
+	if err := validateFile(f); err != nil {
+		return fmt.Errorf("validating migrated config: %w", err)
+	}
+
+	if err := os.WriteFile(path+".bak", data, 0600); err != nil {
+		return fmt.Errorf("writing config backup: %w", err)
+	}
+	if err := WriteFile(path, f); err != nil {
+		_ = os.WriteFile(path, data, 0600)
+		return fmt.Errorf("writing migrated config: %w", err)
+	}
+	return nil
+}
+
+// withTemplateDefaults fills general settings that are absent from raw with
+// the values `config init` would write. Compliance defaults are handled by
+// withMissingDefaults during parsing.
+func withTemplateDefaults(raw map[string]json.RawMessage, f File) File {
+	tmpl := Template()
+	if _, ok := raw["default_format"]; !ok {
+		f.DefaultFormat = tmpl.DefaultFormat
+	}
+	if _, ok := raw["timeout"]; !ok {
+		f.Timeout = tmpl.Timeout
+	}
+	if _, ok := raw["concurrency"]; !ok {
+		f.Concurrency = tmpl.Concurrency
+	}
+	if _, ok := raw["rate"]; !ok {
+		f.Rate = tmpl.Rate
+	}
+	if _, ok := raw["base_url"]; !ok {
+		f.BaseURL = tmpl.BaseURL
+	}
+	return f
+}
+
+// Now returns the current time in the observation timezone. Only the "now"
+// anchor is localised; observation reference dates are always UTC midnight.
+func (c *Config) Now() time.Time {
+	loc, err := time.LoadLocation(c.ObservationTimezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return time.Now().In(loc)
+}
+
 func (c *Config) RightsRefreshDaysFor(action string) int {
 	if days, ok := c.RightsRefreshDays[action]; ok && days > 0 {
 		return days
@@ -537,6 +930,17 @@ func normalizeSnippets(in map[string]Snippet) map[string]Snippet {
 	return out
 }
 
+// trimmedNonEmpty trims each entry and drops empty ones, keeping case.
+func trimmedNonEmpty(in []string) []string {
+	var out []string
+	for _, item := range in {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func normalizeStringSlice(in []string) []string {
 	if len(in) == 0 {
 		return nil
@@ -654,7 +1058,7 @@ func mergeRightsRefreshDays(in map[string]int) map[string]int {
 
 func validateRuntime(cfg *Config) error {
 	if cfg.Format != "" && !IsValidFormat(cfg.Format) {
-		return fmt.Errorf("config.json: default_format must be one of table, json, jsonl, csv, tsv, md")
+		return fmt.Errorf("config.json: default_format must be one of table, json, jsonl, csv, tsv, md, yaml")
 	}
 	if cfg.Timeout <= 0 {
 		return fmt.Errorf("config.json: timeout must be > 0")
@@ -673,22 +1077,34 @@ func validateRuntime(cfg *Config) error {
 			return fmt.Errorf("config.json: rights_refresh_days.%s must be > 0", action)
 		}
 	}
+	if cfg.ObservationTimezone != "" {
+		if _, err := time.LoadLocation(cfg.ObservationTimezone); err != nil {
+			return fmt.Errorf("config.json: observation_timezone: unknown time zone %q", cfg.ObservationTimezone)
+		}
+	}
+	if cfg.ProxyURL != "" {
+		if _, err := fred.ParseProxyURL(cfg.ProxyURL); err != nil {
+			return fmt.Errorf("proxy_url (config.json or %s): %w", EnvProxyURL, err)
+		}
+	}
 	return nil
 }
 
 func IsValidFormat(format string) bool {
-	return slices.Contains([]string{"table", "json", "jsonl", "csv", "tsv", "md"}, format)
+	return slices.Contains([]string{"table", "json", "jsonl", "csv", "tsv", "md", "yaml"}, format)
 }
 
 func validateFile(f File) error {
 	f = canonicalizeFile(f)
 	cfg := &Config{
-		Format:            f.DefaultFormat,
-		Timeout:           DefaultTimeout,
-		Concurrency:       DefaultConcurrency,
-		Rate:              DefaultRate,
-		PersonOrgType:     f.PersonOrgType,
-		RightsRefreshDays: mergeRightsRefreshDays(f.RightsRefreshDays),
+		Format:              f.DefaultFormat,
+		Timeout:             DefaultTimeout,
+		Concurrency:         DefaultConcurrency,
+		Rate:                DefaultRate,
+		PersonOrgType:       f.PersonOrgType,
+		RightsRefreshDays:   mergeRightsRefreshDays(f.RightsRefreshDays),
+		ObservationTimezone: strings.TrimSpace(f.ObservationTimezone),
+		ProxyURL:            strings.TrimSpace(f.ProxyURL),
 	}
 	if f.Timeout != "" {
 		d, err := time.ParseDuration(f.Timeout)
@@ -725,6 +1141,35 @@ func validateRawFile(raw map[string]json.RawMessage, f File) error {
 	if _, ok := raw["rate"]; ok && f.Rate < 0 {
 		return fmt.Errorf("config.json: rate must be > 0")
 	}
+	return validateProfiles(f.Profiles)
+}
+
+// validateProfiles checks the names and values of the "profiles" map.
+func validateProfiles(profiles map[string]Profile) error {
+	for name, p := range profiles {
+		if !profileNamePattern.MatchString(name) || name == DefaultProfile {
+			return fmt.Errorf("config.json: invalid profile name %q: use letters, digits, '-' and '_' (%q is the top-level keys)", name, DefaultProfile)
+		}
+		if p.DefaultFormat != "" && !IsValidFormat(p.DefaultFormat) {
+			return fmt.Errorf("config.json: profiles.%s.default_format must be one of table, json, jsonl, csv, tsv, md, yaml", name)
+		}
+		if p.Timeout != "" {
+			if d, err := time.ParseDuration(p.Timeout); err != nil || d <= 0 {
+				return fmt.Errorf("config.json: profiles.%s.timeout must be a positive duration, got %q", name, p.Timeout)
+			}
+		}
+		if p.Concurrency < 0 {
+			return fmt.Errorf("config.json: profiles.%s.concurrency must be > 0", name)
+		}
+		if p.Rate < 0 {
+			return fmt.Errorf("config.json: profiles.%s.rate must be > 0", name)
+		}
+		if v := strings.TrimSpace(p.ProxyURL); v != "" {
+			if _, err := fred.ParseProxyURL(v); err != nil {
+				return fmt.Errorf("config.json: profiles.%s.proxy_url: %w", name, err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/derickschaefer/reserve/internal/keychain"
+)
+
+// useKeychain runs the test in an empty directory with a config.json holding
+// apiKey, and answers keychain reads with key and err.
+func useKeychain(t *testing.T, apiKey, key string, err error) *[]string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, ".config"))
+	t.Setenv("APPDATA", filepath.Join(dir, "AppData", "Roaming"))
+	orig, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+	if err := WriteFile(filepath.Join(dir, DefaultConfigFile), File{APIKey: apiKey}); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	var lookups []string
+	origGet := keychainGet
+	keychainGet = func(service, account string) (string, error) {
+		lookups = append(lookups, service+"/"+account)
+		return key, err
+	}
+	t.Cleanup(func() { keychainGet = origGet })
+	return &lookups
+}
+
+func TestLoadReadsAPIKeyFromKeychain(t *testing.T) {
+	lookups := useKeychain(t, KeychainAPIKey, "fromkeychain", nil)
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.APIKey != "fromkeychain" {
+		t.Errorf("APIKey: expected fromkeychain, got %q", cfg.APIKey)
+	}
+	if len(*lookups) != 1 || (*lookups)[0] != KeychainService+"/"+KeychainAccount {
+		t.Errorf("expected one lookup of %s/%s, got %v", KeychainService, KeychainAccount, *lookups)
+	}
+}
+
+func TestLoadSkipsKeychainForPlainKey(t *testing.T) {
+	lookups := useKeychain(t, "plainkey", "", nil)
+	if _, err := Load(""); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(*lookups) != 0 {
+		t.Errorf("keychain should not be read for a plain api_key, got %v", *lookups)
+	}
+}
+
+func TestLoadSkipsKeychainWhenFlagOverrides(t *testing.T) {
+	lookups := useKeychain(t, KeychainAPIKey, "", nil)
+	cfg, err := Load("flagkey")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.APIKey != "flagkey" || len(*lookups) != 0 {
+		t.Errorf("--api-key should win without a keychain read, got %q after %v", cfg.APIKey, *lookups)
+	}
+}
+
+func TestLoadReportsKeychainFailure(t *testing.T) {
+	useKeychain(t, KeychainAPIKey, "", keychain.ErrNotFound)
+	_, err := Load("")
+	if !errors.Is(err, keychain.ErrNotFound) || !strings.Contains(err.Error(), "keychain") {
+		t.Errorf("expected a wrapped ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package fred
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Circuit breaker defaults, used when ClientOptions leaves them zero.
+const (
+	DefaultCircuitBreakThreshold = 5
+	DefaultCircuitBreakTimeout   = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned, without a network call, while the circuit
+// breaker is open because FRED has been failing.
+var ErrCircuitOpen = errors.New("FRED circuit breaker open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker stops a Client from hammering a failing FRED. It counts
+// consecutive failed attempts across every goroutine sharing the Client; at
+// threshold it opens and rejects requests for timeout. The first request
+// after that is a half-open probe: its success closes the circuit, its
+// failure reopens it. Other requests fail fast while the probe is in flight.
+type circuitBreaker struct {
+	threshold int
+	timeout   time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(threshold int, timeout time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = DefaultCircuitBreakThreshold
+	}
+	if timeout <= 0 {
+		timeout = DefaultCircuitBreakTimeout
+	}
+	return &circuitBreaker{threshold: threshold, timeout: timeout, now: time.Now}
+}
+
+// allow reports whether a request may go to the network. Every nil return
+// must be followed by exactly one of success, failure, or abandon.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen:
+		wait := b.timeout - b.now().Sub(b.openedAt)
+		if wait > 0 {
+			return fmt.Errorf("%w after %d consecutive failures; retrying in %s",
+				ErrCircuitOpen, b.threshold, wait.Round(time.Second))
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return nil
+	case circuitHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w; a probe request is in flight", ErrCircuitOpen)
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// success closes the circuit and resets the failure count.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = circuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// failure counts a failed attempt, opening the circuit at the threshold or
+// reopening it when the half-open probe fails.
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+// abandon releases an allowed request that ended without telling us anything
+// about FRED's health, such as a cancelled context.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package fred
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable time source for the breaker.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestBreaker(threshold int, timeout time.Duration) (*circuitBreaker, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := newCircuitBreaker(threshold, timeout)
+	b.now = clock.now
+	return b, clock
+}
+
+// fail runs n allowed attempts that all fail.
+func fail(t *testing.T, b *circuitBreaker, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("attempt %d rejected early: %v", i+1, err)
+		}
+		b.failure()
+	}
+}
+
+func TestCircuitBreakerOpensAfterThreshold(t *testing.T) {
+	b, clock := newTestBreaker(3, 30*time.Second)
+	fail(t, b, 3)
+
+	err := b.allow()
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow after 3 failures = %v, want ErrCircuitOpen", err)
+	}
+	clock.advance(29 * time.Second)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow before timeout = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestCircuitBreakerSuccessResetsCount(t *testing.T) {
+	b, _ := newTestBreaker(3, 30*time.Second)
+	fail(t, b, 2)
+	if err := b.allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.success()
+	// Two more failures are not three consecutive ones.
+	fail(t, b, 2)
+	if err := b.allow(); err != nil {
+		t.Errorf("circuit opened without %d consecutive failures: %v", 3, err)
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	b, clock := newTestBreaker(2, 30*time.Second)
+	fail(t, b, 2)
+	clock.advance(30 * time.Second)
+
+	// Exactly one probe goes through; others fail fast while it is in flight.
+	if err := b.allow(); err != nil {
+		t.Fatalf("probe rejected after timeout: %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second request during probe = %v, want ErrCircuitOpen", err)
+	}
+
+	// A failed probe reopens the circuit for a full timeout.
+	b.failure()
+	clock.advance(29 * time.Second)
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow after failed probe = %v, want ErrCircuitOpen", err)
+	}
+
+	// A successful probe closes it.
+	clock.advance(time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("second probe rejected: %v", err)
+	}
+	b.success()
+	for i := 0; i < 3; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("closed circuit rejected request %d: %v", i+1, err)
+		}
+		b.success()
+	}
+}
+
+func TestCircuitBreakerAbandonedProbeFreesSlot(t *testing.T) {
+	b, clock := newTestBreaker(1, time.Second)
+	fail(t, b, 1)
+	clock.advance(time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatal(err)
+	}
+	b.abandon()
+	if err := b.allow(); err != nil {
+		t.Errorf("probe slot not released after abandon: %v", err)
+	}
+}
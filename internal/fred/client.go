@@ -3,11 +3,13 @@
 
 // Package fred implements the HTTP client for the Federal Reserve Bank of
 // St. Louis (FRED) API. All methods are context-aware, respect the shared
-// rate limiter, and retry on transient errors (429, 5xx).
+// rate limiter, and retry on transient errors (429, 5xx). A circuit breaker
+// shared by all of a Client's goroutines stops retrying once FRED keeps failing.
 //
 // The package is split across multiple files, each covering one FRED resource:
 //
-//	client.go   — Client struct, NewClient, low-level get()
+//	client.go   — Client struct, constructors, low-level get()
+//	circuit.go  — circuit breaker shared by a Client's requests
 //	series.go   — series endpoints
 //	category.go — category endpoints
 //	release.go  — release endpoints
@@ -28,6 +30,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/derickschaefer/reserve/internal/model"
 	"golang.org/x/time/rate"
 )
 
@@ -42,28 +45,120 @@ type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	limiter    *rate.Limiter
+	breaker    *circuitBreaker
 	debug      bool
 }
 
+// geoPrefix marks an endpoint as part of the GeoFRED API, which sits beside
+// the FRED API rather than under it: fred/series becomes geofred/series/data.
+const geoPrefix = "geofred/"
+
+// endpointURL resolves endpoint against the base URL. GeoFRED endpoints swap
+// the trailing fred/ of the base for geofred/.
+func (c *Client) endpointURL(endpoint string) string {
+	if rest, ok := strings.CutPrefix(endpoint, geoPrefix); ok {
+		return strings.TrimSuffix(c.baseURL, "fred/") + geoPrefix + rest
+	}
+	return c.baseURL + endpoint
+}
+
+// ClientOptions configures NewClientWithOptions.
+type ClientOptions struct {
+	APIKey  string
+	BaseURL string // empty = the public FRED endpoint
+	Timeout time.Duration
+	// RatePerSec is the shared request rate limit.
+	RatePerSec float64
+	// MaxIdleConns is the number of keep-alive connections held open to the
+	// API host. Size it to the number of concurrent workers so batch fetches
+	// reuse connections instead of dialing per request. If 0, defaults to 2.
+	MaxIdleConns int
+	// CircuitBreakThreshold consecutive failed attempts open the circuit
+	// breaker for CircuitBreakTimeout. If 0, defaults to
+	// DefaultCircuitBreakThreshold and DefaultCircuitBreakTimeout.
+	CircuitBreakThreshold int
+	CircuitBreakTimeout   time.Duration
+	// ProxyURL routes every request through an HTTP, HTTPS, or SOCKS5 proxy.
+	// Validate it with ParseProxyURL before building the client: an invalid
+	// value makes every request fail. Empty means no proxy.
+	ProxyURL string
+	Debug    bool
+}
+
+// idleConnTimeout is how long a pooled connection may sit unused before it
+// is closed.
+const idleConnTimeout = 90 * time.Second
+
 // NewClient creates a Client with the given API key, base URL, timeout,
-// rate limit (requests/sec), and debug flag.
+// rate limit (requests/sec), and debug flag, using the default pool size.
 func NewClient(apiKey, baseURL string, timeout time.Duration, ratePerSec float64, debug bool) *Client {
+	return NewClientWithOptions(ClientOptions{
+		APIKey:     apiKey,
+		BaseURL:    baseURL,
+		Timeout:    timeout,
+		RatePerSec: ratePerSec,
+		Debug:      debug,
+	})
+}
+
+// NewClientWithOptions creates a Client from opts. Every request goes to a
+// single host, so the transport keeps up to MaxIdleConns connections to it.
+func NewClientWithOptions(opts ClientOptions) *Client {
+	baseURL := opts.BaseURL
 	if baseURL == "" {
 		baseURL = defaultBaseURL
 	}
-	burst := int(ratePerSec)
+	burst := int(opts.RatePerSec)
 	if burst < 1 {
 		burst = 1
 	}
+	poolSize := opts.MaxIdleConns
+	if poolSize <= 0 {
+		poolSize = http.DefaultMaxIdleConnsPerHost
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = poolSize
+	transport.MaxIdleConnsPerHost = poolSize
+	transport.IdleConnTimeout = idleConnTimeout
+	if opts.ProxyURL != "" {
+		proxy, err := ParseProxyURL(opts.ProxyURL)
+		if err != nil {
+			// Never fall back to a direct connection the caller did not ask for.
+			transport.Proxy = func(*http.Request) (*url.URL, error) { return nil, err }
+		} else {
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
 	return &Client{
 		baseURL: baseURL,
-		apiKey:  apiKey,
+		apiKey:  opts.APIKey,
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:   opts.Timeout,
+			Transport: transport,
 		},
-		limiter: rate.NewLimiter(rate.Limit(ratePerSec), burst),
-		debug:   debug,
+		limiter: rate.NewLimiter(rate.Limit(opts.RatePerSec), burst),
+		breaker: newCircuitBreaker(opts.CircuitBreakThreshold, opts.CircuitBreakTimeout),
+		debug:   opts.Debug,
+	}
+}
+
+// ParseProxyURL parses and checks a proxy URL such as
+// http://proxy.corp.com:8080. The scheme must be http, https, or socks5 and a
+// host is required.
+func ParseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: scheme must be http, https, or socks5", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", raw)
 	}
+	return u, nil
 }
 
 // SetHTTPClient overrides the HTTP client used for requests.
@@ -74,12 +169,41 @@ func (c *Client) SetHTTPClient(httpClient *http.Client) {
 	}
 }
 
+// PingSeriesID is the series whose metadata Ping requests.
+const PingSeriesID = "UNRATE"
+
+// Ping checks that FRED is reachable and accepts the API key with one small
+// request for the metadata of PingSeriesID.
+func (c *Client) Ping(ctx context.Context) error {
+	params := url.Values{}
+	params.Set("series_id", PingSeriesID)
+	var raw struct {
+		Seriess []json.RawMessage `json:"seriess"`
+	}
+	if err := c.get(ctx, "series", params, &raw); err != nil {
+		return err
+	}
+	if len(raw.Seriess) == 0 {
+		return fmt.Errorf("series %s: empty response", PingSeriesID)
+	}
+	return nil
+}
+
 // get performs a GET request to the FRED API, handling rate limiting and retries.
 func (c *Client) get(ctx context.Context, endpoint string, params url.Values, out interface{}) error {
+	_, _, err := c.getConditional(ctx, endpoint, params, model.ResponseMeta{}, out)
+	return err
+}
+
+// getConditional is get with HTTP revalidation. Validators in cond are sent
+// as If-None-Match / If-Modified-Since; if FRED answers 304 Not Modified, out
+// is left untouched and notModified is true. Otherwise the response's own
+// validators are returned, zero when FRED sent none.
+func (c *Client) getConditional(ctx context.Context, endpoint string, params url.Values, cond model.ResponseMeta, out interface{}) (meta model.ResponseMeta, notModified bool, err error) {
 	params.Set("api_key", c.apiKey)
 	params.Set("file_type", "json")
 
-	reqURL := c.baseURL + endpoint + "?" + params.Encode()
+	reqURL := c.endpointURL(endpoint) + "?" + params.Encode()
 
 	if c.debug {
 		safe := strings.Replace(reqURL, c.apiKey, "REDACTED", 1)
@@ -89,27 +213,45 @@ func (c *Client) get(ctx context.Context, endpoint string, params url.Values, ou
 	var lastErr error
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		if err := c.limiter.Wait(ctx); err != nil {
-			return err
+			return meta, false, err
 		}
 		if attempt > 0 {
 			backoff := time.Duration(math.Pow(2, float64(attempt-1))*500) * time.Millisecond
 			slog.Debug("retrying after backoff", "attempt", attempt, "backoff", backoff)
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return meta, false, ctx.Err()
 			case <-time.After(backoff):
 			}
 		}
 
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 		if err != nil {
-			return fmt.Errorf("building request: %w", err)
+			return meta, false, fmt.Errorf("building request: %w", err)
 		}
 		req.Header.Set("Accept", "application/json")
 		req.Header.Set("User-Agent", "reserve-cli/1.0")
+		if cond.ETag != "" {
+			req.Header.Set("If-None-Match", cond.ETag)
+		}
+		if cond.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cond.LastModified)
+		}
+
+		if err := c.breaker.allow(); err != nil {
+			if lastErr != nil {
+				return meta, false, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+			return meta, false, err
+		}
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			if ctx.Err() != nil {
+				c.breaker.abandon()
+				return meta, false, ctx.Err()
+			}
+			c.breaker.failure()
 			lastErr = fmt.Errorf("http: %w", err)
 			continue
 		}
@@ -117,9 +259,15 @@ func (c *Client) get(ctx context.Context, endpoint string, params url.Values, ou
 		body, err := io.ReadAll(resp.Body)
 		resp.Body.Close()
 		if err != nil {
+			c.breaker.failure()
 			lastErr = fmt.Errorf("reading body: %w", err)
 			continue
 		}
+		if resp.StatusCode >= 500 {
+			c.breaker.failure()
+		} else {
+			c.breaker.success()
+		}
 
 		if c.debug {
 			slog.Debug("fred response", "status", resp.StatusCode, "bytes", len(body))
@@ -131,7 +279,7 @@ func (c *Client) get(ctx context.Context, endpoint string, params url.Values, ou
 				slog.Debug("fred 429 retry-after", "wait", ra)
 				select {
 				case <-ctx.Done():
-					return ctx.Err()
+					return meta, false, ctx.Err()
 				case <-time.After(ra):
 				}
 			}
@@ -142,25 +290,42 @@ func (c *Client) get(ctx context.Context, endpoint string, params url.Values, ou
 			continue
 		}
 
+		if resp.StatusCode == http.StatusNotModified && !cond.IsZero() {
+			return cond, true, nil
+		}
 		if resp.StatusCode != http.StatusOK {
 			var apiErr struct {
 				Error string `json:"error_message"`
 			}
 			_ = json.Unmarshal(body, &apiErr)
 			if apiErr.Error != "" {
-				return fmt.Errorf("API error: %s", apiErr.Error)
+				return meta, false, &APIError{StatusCode: resp.StatusCode, Message: "API error: " + apiErr.Error}
 			}
-			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+			return meta, false, &APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))}
 		}
 
 		if err := json.Unmarshal(body, out); err != nil {
-			return fmt.Errorf("decoding response: %w", err)
+			return meta, false, fmt.Errorf("decoding response: %w", err)
 		}
-		return nil
+		meta = model.ResponseMeta{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}
+		return meta, false, nil
 	}
-	return fmt.Errorf("after %d attempts: %w", maxRetries, lastErr)
+	return meta, false, fmt.Errorf("after %d attempts: %w", maxRetries, lastErr)
+}
+
+// APIError is a response FRED rejected outright, such as 400 for an unknown
+// series or 403 for a bad API key. Unlike a 429, a 5xx, or a network error,
+// repeating the same request will not succeed.
+type APIError struct {
+	StatusCode int
+	Message    string
 }
 
+func (e *APIError) Error() string { return e.Message }
+
 func parseRetryAfter(v string) time.Duration {
 	v = strings.TrimSpace(v)
 	if v == "" {
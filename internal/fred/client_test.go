@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package fred
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientRoutesThroughProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the target.
+		proxiedHost = r.URL.Host
+		json.NewEncoder(w).Encode(map[string]any{
+			"seriess": []map[string]any{{"id": r.URL.Query().Get("series_id")}},
+		})
+	}))
+	defer proxy.Close()
+
+	c := NewClientWithOptions(ClientOptions{
+		APIKey:     "test_key",
+		BaseURL:    "http://fred.invalid/fred/",
+		Timeout:    5 * time.Second,
+		RatePerSec: 1000,
+		ProxyURL:   proxy.URL,
+	})
+	meta, err := c.GetSeries(context.Background(), "UNRATE")
+	if err != nil {
+		t.Fatalf("GetSeries through proxy: %v", err)
+	}
+	if meta.ID != "UNRATE" {
+		t.Errorf("series ID %q, want UNRATE", meta.ID)
+	}
+	if proxiedHost != "fred.invalid" {
+		t.Errorf("proxy saw host %q, want fred.invalid", proxiedHost)
+	}
+}
+
+func TestParseProxyURL(t *testing.T) {
+	for _, raw := range []string{"http://proxy.corp.com:8080", "https://proxy:443", "socks5://127.0.0.1:1080"} {
+		if _, err := ParseProxyURL(raw); err != nil {
+			t.Errorf("ParseProxyURL(%q): %v", raw, err)
+		}
+	}
+	for _, raw := range []string{"proxy.corp.com:8080", "ftp://proxy:21", "http://", "http://[::1"} {
+		if _, err := ParseProxyURL(raw); err == nil {
+			t.Errorf("ParseProxyURL(%q): expected error", raw)
+		}
+	}
+}
+
+func TestInvalidProxyNeverConnectsDirectly(t *testing.T) {
+	c := NewClientWithOptions(ClientOptions{ProxyURL: "ftp://proxy:21"})
+	transport := c.httpClient.Transport.(*http.Transport)
+	req := httptest.NewRequest(http.MethodGet, "https://api.stlouisfed.org/fred/series", nil)
+	if u, err := transport.Proxy(req); err == nil || !strings.Contains(err.Error(), "proxy") {
+		t.Fatalf("expected proxy error instead of a direct connection, got %v, %v", u, err)
+	}
+}
+
+func TestPing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/series" || r.URL.Query().Get("series_id") != PingSeriesID {
+			t.Errorf("unexpected ping request %s", r.URL)
+		}
+		if r.URL.Query().Get("api_key") != "good_key" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]any{"error_code": 400, "error_message": "Bad Request. The value for variable api_key is not registered."})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"seriess": []map[string]any{{"id": PingSeriesID}}})
+	}))
+	defer srv.Close()
+
+	if err := NewClient("good_key", srv.URL+"/", 5*time.Second, 1000, false).Ping(context.Background()); err != nil {
+		t.Errorf("Ping with a valid key: %v", err)
+	}
+	err := NewClient("bad_key", srv.URL+"/", 5*time.Second, 1000, false).Ping(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "api_key is not registered") {
+		t.Errorf("Ping with a rejected key should surface FRED's message, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package fred
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RegionalOptions selects the cross-section GetRegionalData returns.
+type RegionalOptions struct {
+	RegionType string // state, county, msa, bea, frb, censusregion, ...
+	Date       string // YYYY-MM-DD
+	Units      string // the series group's units, e.g. "Dollars"
+	Season     string // NSA, SA, SSA, SAAR, NSAAR; empty = NSA
+	Frequency  string // a, q, m, ...; empty = a
+}
+
+// RegionValue is one region's value in a regional cross-section.
+type RegionValue struct {
+	Region string  `json:"region"`
+	Code   string  `json:"code"`
+	Value  float64 `json:"value"`
+}
+
+// RegionalData is a GeoFRED cross-section: one value per region on Date.
+type RegionalData struct {
+	Title      string        `json:"title"`
+	RegionType string        `json:"region_type"`
+	Units      string        `json:"units"`
+	Frequency  string        `json:"frequency"`
+	Date       string        `json:"date"`
+	Regions    []RegionValue `json:"regions"`
+}
+
+// GetRegionalData fetches the values of a GeoFRED series group for every
+// region of opts.RegionType on opts.Date. Regions FRED reports without a
+// numeric value are left out.
+func (c *Client) GetRegionalData(ctx context.Context, seriesGroup string, opts RegionalOptions) (RegionalData, error) {
+	if opts.RegionType == "" || opts.Date == "" {
+		return RegionalData{}, fmt.Errorf("regional data %s: region type and date are required", seriesGroup)
+	}
+	params := url.Values{}
+	params.Set("series_group", seriesGroup)
+	params.Set("region_type", strings.ToLower(opts.RegionType))
+	params.Set("date", opts.Date)
+	season := opts.Season
+	if season == "" {
+		season = "NSA"
+	}
+	params.Set("season", strings.ToUpper(season))
+	freq := opts.Frequency
+	if freq == "" {
+		freq = "a"
+	}
+	params.Set("frequency", strings.ToLower(freq))
+	if opts.Units != "" {
+		params.Set("units", opts.Units)
+	}
+
+	var raw struct {
+		Meta struct {
+			Title     string `json:"title"`
+			Region    string `json:"region"`
+			Units     string `json:"units"`
+			Frequency string `json:"frequency"`
+			Data      map[string][]struct {
+				Region string          `json:"region"`
+				Code   json.RawMessage `json:"code"`
+				Value  json.RawMessage `json:"value"`
+			} `json:"data"`
+		} `json:"meta"`
+	}
+	if err := c.get(ctx, geoPrefix+"regional/data", params, &raw); err != nil {
+		return RegionalData{}, fmt.Errorf("regional data %s: %w", seriesGroup, err)
+	}
+
+	out := RegionalData{
+		Title:      raw.Meta.Title,
+		RegionType: raw.Meta.Region,
+		Units:      raw.Meta.Units,
+		Frequency:  raw.Meta.Frequency,
+		Date:       opts.Date,
+	}
+	// data is keyed by date; a single-date request has one entry, which may
+	// carry a different day than asked for when the period starts elsewhere.
+	for date, rows := range raw.Meta.Data {
+		out.Date = date
+		for _, r := range rows {
+			v, ok := geoNumber(r.Value)
+			if !ok {
+				continue
+			}
+			out.Regions = append(out.Regions, RegionValue{
+				Region: r.Region,
+				Code:   geoString(r.Code),
+				Value:  v,
+			})
+		}
+	}
+	return out, nil
+}
+
+// geoNumber reads a GeoFRED value, which arrives as either a JSON number or
+// a numeric string.
+func geoNumber(raw json.RawMessage) (float64, bool) {
+	s := geoString(raw)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// geoString unquotes a JSON string, or returns a bare number as written.
+func geoString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return strings.TrimSpace(string(raw))
+}
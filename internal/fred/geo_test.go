@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package fred
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetRegionalData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/geofred/regional/data" {
+			t.Errorf("path = %s, want /geofred/regional/data", r.URL.Path)
+		}
+		q := r.URL.Query()
+		for key, want := range map[string]string{
+			"series_group": "882",
+			"region_type":  "state",
+			"date":         "2013-01-01",
+			"units":        "Dollars",
+			"season":       "NSA",
+			"frequency":    "a",
+		} {
+			if got := q.Get(key); got != want {
+				t.Errorf("%s = %q, want %q", key, got, want)
+			}
+		}
+		// GeoFRED mixes string and numeric values; "." marks a missing one.
+		_, _ = w.Write([]byte(`{"meta":{"title":"Per Capita Personal Income by State (Dollars)",
+			"region":"state","units":"Dollars","frequency":"Annual","data":{"2013-01-01":[
+			{"region":"Alabama","code":"01","value":"36132","series_id":"ALPCPI"},
+			{"region":"Alaska","code":"02","value":50150,"series_id":"AKPCPI"},
+			{"region":"Arizona","code":"04","value":".","series_id":"AZPCPI"}]}}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL+"/fred/", time.Second, 0, false)
+	got, err := c.GetRegionalData(context.Background(), "882", RegionalOptions{
+		RegionType: "State",
+		Date:       "2013-01-01",
+		Units:      "Dollars",
+	})
+	if err != nil {
+		t.Fatalf("GetRegionalData: %v", err)
+	}
+	if got.Title != "Per Capita Personal Income by State (Dollars)" || got.RegionType != "state" || got.Date != "2013-01-01" {
+		t.Errorf("unexpected metadata %+v", got)
+	}
+	want := []RegionValue{
+		{Region: "Alabama", Code: "01", Value: 36132},
+		{Region: "Alaska", Code: "02", Value: 50150},
+	}
+	if len(got.Regions) != len(want) {
+		t.Fatalf("got %d regions, want %d: %+v", len(got.Regions), len(want), got.Regions)
+	}
+	for i := range want {
+		if got.Regions[i] != want[i] {
+			t.Errorf("[%d] = %+v, want %+v", i, got.Regions[i], want[i])
+		}
+	}
+}
+
+func TestGetRegionalDataRequiresRegionTypeAndDate(t *testing.T) {
+	c := NewClient("key", "http://127.0.0.1:1/fred/", time.Second, 0, false)
+	if _, err := c.GetRegionalData(context.Background(), "882", RegionalOptions{RegionType: "state"}); err == nil {
+		t.Error("expected an error without a date")
+	}
+}
@@ -4,9 +4,11 @@
 package fred
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
 
 	"github.com/derickschaefer/reserve/internal/model"
@@ -152,6 +154,73 @@ func (c *Client) GetReleaseDates(ctx context.Context, releaseID int, limit int)
 	return dates, nil
 }
 
+// UpcomingReleaseOptions bounds GetReleaseDatesUpcoming.
+type UpcomingReleaseOptions struct {
+	Start string // YYYY-MM-DD, first date to include
+	End   string // YYYY-MM-DD, last date to include
+	Limit int
+}
+
+// GetReleaseDatesUpcoming fetches scheduled release dates between opts.Start
+// and opts.End, including dates FRED has not published data for yet. A
+// releaseID of 0 covers every release. Results are sorted by date, then by
+// release name.
+func (c *Client) GetReleaseDatesUpcoming(ctx context.Context, releaseID int, opts UpcomingReleaseOptions) ([]ReleaseDate, error) {
+	params := url.Values{}
+	params.Set("include_release_dates_with_no_data", "true")
+	params.Set("sort_order", "asc")
+	if opts.Start != "" {
+		params.Set("realtime_start", opts.Start)
+	}
+	if opts.End != "" {
+		params.Set("realtime_end", opts.End)
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	} else {
+		params.Set("limit", "1000")
+	}
+	endpoint := "releases/dates"
+	if releaseID > 0 {
+		endpoint = "release/dates"
+		params.Set("release_id", strconv.Itoa(releaseID))
+	} else {
+		params.Set("order_by", "release_date")
+	}
+
+	var raw struct {
+		ReleaseDates []struct {
+			ReleaseID   int    `json:"release_id"`
+			ReleaseName string `json:"release_name"`
+			Date        string `json:"date"`
+		} `json:"release_dates"`
+	}
+	if err := c.get(ctx, endpoint, params, &raw); err != nil {
+		if releaseID > 0 {
+			return nil, fmt.Errorf("upcoming release dates %d: %w", releaseID, err)
+		}
+		return nil, fmt.Errorf("upcoming release dates: %w", err)
+	}
+
+	dates := make([]ReleaseDate, 0, len(raw.ReleaseDates))
+	for _, d := range raw.ReleaseDates {
+		// The realtime window is not a strict bound on release/dates, so
+		// trim to the requested range here.
+		if (opts.Start != "" && d.Date < opts.Start) || (opts.End != "" && d.Date > opts.End) {
+			continue
+		}
+		dates = append(dates, ReleaseDate{
+			ReleaseID:   d.ReleaseID,
+			ReleaseName: d.ReleaseName,
+			Date:        d.Date,
+		})
+	}
+	slices.SortStableFunc(dates, func(a, b ReleaseDate) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.ReleaseName, b.ReleaseName))
+	})
+	return dates, nil
+}
+
 // GetReleaseSeries fetches the series belonging to a release.
 func (c *Client) GetReleaseSeries(ctx context.Context, releaseID int, limit int) ([]model.SeriesMeta, error) {
 	params := url.Values{}
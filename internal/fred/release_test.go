@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package fred
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetReleaseDatesUpcomingAllReleases(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/dates" {
+			t.Errorf("path = %s, want /releases/dates", r.URL.Path)
+		}
+		q := r.URL.Query()
+		for key, want := range map[string]string{
+			"include_release_dates_with_no_data": "true",
+			"realtime_start":                     "2026-03-01",
+			"realtime_end":                       "2026-03-31",
+			"order_by":                           "release_date",
+		} {
+			if got := q.Get(key); got != want {
+				t.Errorf("%s = %q, want %q", key, got, want)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"release_dates": []map[string]any{
+				{"release_id": 50, "release_name": "Employment Situation", "date": "2026-03-06"},
+				{"release_id": 10, "release_name": "Consumer Price Index", "date": "2026-03-11"},
+				{"release_id": 9, "release_name": "Advance Retail Sales", "date": "2026-03-06"},
+				{"release_id": 53, "release_name": "Gross Domestic Product", "date": "2026-04-30"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL+"/", time.Second, 0, false)
+	got, err := c.GetReleaseDatesUpcoming(context.Background(), 0, UpcomingReleaseOptions{
+		Start: "2026-03-01",
+		End:   "2026-03-31",
+	})
+	if err != nil {
+		t.Fatalf("GetReleaseDatesUpcoming: %v", err)
+	}
+	want := []ReleaseDate{
+		{ReleaseID: 9, ReleaseName: "Advance Retail Sales", Date: "2026-03-06"},
+		{ReleaseID: 50, ReleaseName: "Employment Situation", Date: "2026-03-06"},
+		{ReleaseID: 10, ReleaseName: "Consumer Price Index", Date: "2026-03-11"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d dates, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGetReleaseDatesUpcomingSingleRelease(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/release/dates" {
+			t.Errorf("path = %s, want /release/dates", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("release_id"); got != "50" {
+			t.Errorf("release_id = %q, want 50", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"release_dates": []map[string]any{
+				{"release_id": 50, "date": "2026-04-03"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL+"/", time.Second, 0, false)
+	got, err := c.GetReleaseDatesUpcoming(context.Background(), 50, UpcomingReleaseOptions{Start: "2026-03-01"})
+	if err != nil {
+		t.Fatalf("GetReleaseDatesUpcoming: %v", err)
+	}
+	if len(got) != 1 || got[0].ReleaseID != 50 || got[0].Date != "2026-04-03" {
+		t.Errorf("unexpected dates %+v", got)
+	}
+}
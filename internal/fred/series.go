@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +26,12 @@ type ObsOptions struct {
 	Units string // lin|chg|ch1|pch|pc1|pca|cch|cca|log
 	Agg   string // avg|sum|eop
 	Limit int
+
+	// RealtimeStart and RealtimeEnd (YYYY-MM-DD) request a vintage: the
+	// observations as FRED published them during that window, rather than
+	// the latest revision.
+	RealtimeStart string
+	RealtimeEnd   string
 }
 
 // freqMap maps CLI-friendly frequency names to FRED API values.
@@ -40,8 +47,48 @@ var aggMap = map[string]string{
 	"eop": "eop", "end": "eop",
 }
 
+// validUnits lists the data transformations FRED accepts for the units
+// parameter.
+var validUnits = map[string]bool{
+	"lin": true, "chg": true, "ch1": true, "pch": true, "pc1": true,
+	"pca": true, "cch": true, "cca": true, "log": true,
+}
+
+// Validate checks Freq, Units, and Agg against the values FRED accepts, so a
+// typo fails immediately with the valid choices instead of coming back as an
+// opaque API error. Empty fields are left to FRED's defaults.
+func (o ObsOptions) Validate() error {
+	if o.Freq != "" {
+		if _, ok := freqMap[strings.ToLower(o.Freq)]; !ok {
+			return fmt.Errorf("unknown frequency %q; valid values are daily, weekly, monthly, quarterly, annual (or d, w, m, q, a)", o.Freq)
+		}
+	}
+	if o.Units != "" && !validUnits[strings.ToLower(o.Units)] {
+		return fmt.Errorf("unknown units %q; valid values are lin, chg, ch1, pch, pc1, pca, cch, cca, log", o.Units)
+	}
+	if o.Agg != "" {
+		if _, ok := aggMap[strings.ToLower(o.Agg)]; !ok {
+			return fmt.Errorf("unknown aggregation %q; valid values are avg, sum, eop", o.Agg)
+		}
+	}
+	return nil
+}
+
 // GetObservations fetches time series observations for a single series.
+// Any cache validators FRED sends are kept in the result's Response.
 func (c *Client) GetObservations(ctx context.Context, seriesID string, opts ObsOptions) (*model.SeriesData, error) {
+	data, _, err := c.GetObservationsIfModified(ctx, seriesID, opts, model.ResponseMeta{})
+	return data, err
+}
+
+// GetObservationsIfModified is GetObservations that revalidates a copy the
+// caller already holds: prev's validators are sent as conditional headers,
+// and if FRED reports the data unchanged it returns (nil, true, nil) without
+// reading a body. With zero prev it always fetches.
+func (c *Client) GetObservationsIfModified(ctx context.Context, seriesID string, opts ObsOptions, prev model.ResponseMeta) (*model.SeriesData, bool, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, false, fmt.Errorf("observations %s: %w", seriesID, err)
+	}
 	params := url.Values{}
 	params.Set("series_id", strings.ToUpper(seriesID))
 	if opts.Start != "" {
@@ -51,21 +98,23 @@ func (c *Client) GetObservations(ctx context.Context, seriesID string, opts ObsO
 		params.Set("observation_end", opts.End)
 	}
 	if opts.Freq != "" {
-		if v, ok := freqMap[strings.ToLower(opts.Freq)]; ok {
-			params.Set("frequency", v)
-		}
+		params.Set("frequency", freqMap[strings.ToLower(opts.Freq)])
 	}
 	if opts.Units != "" {
 		params.Set("units", strings.ToLower(opts.Units))
 	}
 	if opts.Agg != "" {
-		if v, ok := aggMap[strings.ToLower(opts.Agg)]; ok {
-			params.Set("aggregation_method", v)
-		}
+		params.Set("aggregation_method", aggMap[strings.ToLower(opts.Agg)])
 	}
 	if opts.Limit > 0 {
 		params.Set("limit", strconv.Itoa(opts.Limit))
 	}
+	if opts.RealtimeStart != "" {
+		params.Set("realtime_start", opts.RealtimeStart)
+	}
+	if opts.RealtimeEnd != "" {
+		params.Set("realtime_end", opts.RealtimeEnd)
+	}
 
 	var raw struct {
 		Observations []struct {
@@ -76,8 +125,12 @@ func (c *Client) GetObservations(ctx context.Context, seriesID string, opts ObsO
 		} `json:"observations"`
 	}
 
-	if err := c.get(ctx, "series/observations", params, &raw); err != nil {
-		return nil, fmt.Errorf("observations %s: %w", seriesID, err)
+	respMeta, notModified, err := c.getConditional(ctx, "series/observations", params, prev, &raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("observations %s: %w", seriesID, err)
+	}
+	if notModified {
+		return nil, true, nil
 	}
 
 	obs := make([]model.Observation, 0, len(raw.Observations))
@@ -95,10 +148,125 @@ func (c *Client) GetObservations(ctx context.Context, seriesID string, opts ObsO
 		})
 	}
 
-	return &model.SeriesData{
+	data := &model.SeriesData{
 		SeriesID: strings.ToUpper(seriesID),
 		Obs:      obs,
-	}, nil
+	}
+	if !respMeta.IsZero() {
+		data.Response = &respMeta
+	}
+	return data, false, nil
+}
+
+// Realtime bounds that span every vintage FRED holds.
+const (
+	realtimeAllStart = "1776-07-04"
+	realtimeAllEnd   = "9999-12-31"
+)
+
+// RevisionRow is one published value of a single observation.
+type RevisionRow struct {
+	VintageDate string  `json:"vintage_date"` // first date the value was available
+	Value       float64 `json:"value"`
+	ValueRaw    string  `json:"value_raw"`
+}
+
+// GetObservationRevisions returns every value FRED has published for the
+// observation of seriesID dated date (YYYY-MM-DD), oldest vintage first.
+// Consecutive vintages that repeat the same value are collapsed into the
+// first, so each row is a distinct revision.
+func (c *Client) GetObservationRevisions(ctx context.Context, seriesID, date string) ([]RevisionRow, error) {
+	params := url.Values{}
+	params.Set("series_id", strings.ToUpper(seriesID))
+	params.Set("observation_start", date)
+	params.Set("observation_end", date)
+	params.Set("realtime_start", realtimeAllStart)
+	params.Set("realtime_end", realtimeAllEnd)
+
+	var raw struct {
+		Observations []struct {
+			Date          string `json:"date"`
+			Value         string `json:"value"`
+			RealtimeStart string `json:"realtime_start"`
+		} `json:"observations"`
+	}
+	if err := c.get(ctx, "series/observations", params, &raw); err != nil {
+		return nil, fmt.Errorf("revisions %s %s: %w", seriesID, date, err)
+	}
+
+	obs := raw.Observations
+	sort.SliceStable(obs, func(i, j int) bool { return obs[i].RealtimeStart < obs[j].RealtimeStart })
+	rows := make([]RevisionRow, 0, len(obs))
+	for _, o := range obs {
+		if o.Date != date {
+			continue
+		}
+		if n := len(rows); n > 0 && rows[n-1].ValueRaw == o.Value {
+			continue
+		}
+		rows = append(rows, RevisionRow{
+			VintageDate: o.RealtimeStart,
+			Value:       util.ParseObsValue(o.Value),
+			ValueRaw:    o.Value,
+		})
+	}
+	return rows, nil
+}
+
+// VintageDiff is one observation whose published value changed between two
+// vintages. A value missing in either vintage is NaN, and so is Delta.
+type VintageDiff struct {
+	Date     string  `json:"date"`
+	OldValue float64 `json:"old_value"`
+	OldRaw   string  `json:"old_value_raw"`
+	NewValue float64 `json:"new_value"`
+	NewRaw   string  `json:"new_value_raw"`
+	Delta    float64 `json:"delta"` // NewValue - OldValue
+}
+
+// CompareVintages fetches seriesID as FRED published it on dateA and on
+// dateB (YYYY-MM-DD) and returns, in date order, the observations present in
+// the dateA vintage whose value differs in the dateB one. Observations first
+// published after dateA are new data rather than revisions and are left out.
+func (c *Client) CompareVintages(ctx context.Context, seriesID, dateA, dateB string) ([]VintageDiff, error) {
+	asOf := func(date string) (*model.SeriesData, error) {
+		data, err := c.GetObservations(ctx, seriesID, ObsOptions{RealtimeStart: date, RealtimeEnd: date})
+		if err != nil {
+			return nil, fmt.Errorf("vintage %s: %w", date, err)
+		}
+		return data, nil
+	}
+	old, err := asOf(dateA)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := asOf(dateB)
+	if err != nil {
+		return nil, err
+	}
+
+	newByDate := make(map[string]model.Observation, len(latest.Obs))
+	for _, o := range latest.Obs {
+		newByDate[o.Date.Format("2006-01-02")] = o
+	}
+	var diffs []VintageDiff
+	for _, o := range old.Obs {
+		date := o.Date.Format("2006-01-02")
+		n, ok := newByDate[date]
+		if !ok || n.ValueRaw == o.ValueRaw {
+			continue
+		}
+		diffs = append(diffs, VintageDiff{
+			Date:     date,
+			OldValue: o.Value,
+			OldRaw:   o.ValueRaw,
+			NewValue: n.Value,
+			NewRaw:   n.ValueRaw,
+			Delta:    n.Value - o.Value,
+		})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Date < diffs[j].Date })
+	return diffs, nil
 }
 
 // GetLatestObservation returns the most recent observation for a series.
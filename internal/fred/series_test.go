@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package fred
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObsOptionsValidate(t *testing.T) {
+	valid := []ObsOptions{
+		{},
+		{Units: "pc1"},
+		{Units: "PCH"},
+		{Units: "log", Freq: "quarterly", Agg: "eop"},
+		{Freq: "A", Agg: "average"},
+	}
+	for _, opts := range valid {
+		if err := opts.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v, want nil", opts, err)
+		}
+	}
+
+	invalid := []struct {
+		opts ObsOptions
+		want string
+	}{
+		{ObsOptions{Units: "pctch"}, `unknown units "pctch"; valid values are lin, chg, ch1, pch, pc1, pca, cch, cca, log`},
+		{ObsOptions{Freq: "hourly"}, `unknown frequency "hourly"`},
+		{ObsOptions{Agg: "median"}, `unknown aggregation "median"; valid values are avg, sum, eop`},
+	}
+	for _, tc := range invalid {
+		err := tc.opts.Validate()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Validate(%+v) = %v, want error containing %q", tc.opts, err, tc.want)
+		}
+	}
+}
+
+func TestGetObservationsRejectsBadUnitsBeforeRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL)
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL+"/", time.Second, 0, false)
+	_, err := c.GetObservations(context.Background(), "GDP", ObsOptions{Units: "pctch"})
+	if err == nil || !strings.Contains(err.Error(), "unknown units") {
+		t.Fatalf("expected unknown units error, got %v", err)
+	}
+}
+
+func TestCompareVintages(t *testing.T) {
+	vintages := map[string]string{
+		"2020-05-08": `[{"date":"2020-03-01","value":"4.4"},{"date":"2020-04-01","value":"14.7"}]`,
+		"2021-05-08": `[{"date":"2020-03-01","value":"4.4"},{"date":"2020-04-01","value":"14.8"},{"date":"2020-05-01","value":"13.3"}]`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("realtime_start") != q.Get("realtime_end") {
+			t.Errorf("realtime window %s..%s should be a single vintage", q.Get("realtime_start"), q.Get("realtime_end"))
+		}
+		_, _ = w.Write([]byte(`{"observations":` + vintages[q.Get("realtime_start")] + `}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key", srv.URL+"/", time.Second, 100, false)
+	diffs, err := c.CompareVintages(context.Background(), "UNRATE", "2020-05-08", "2021-05-08")
+	if err != nil {
+		t.Fatalf("CompareVintages: %v", err)
+	}
+	// March is unchanged and May was first published after the old vintage.
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 revised observation, got %+v", diffs)
+	}
+	d := diffs[0]
+	if d.Date != "2020-04-01" || d.OldRaw != "14.7" || d.NewRaw != "14.8" {
+		t.Errorf("unexpected diff %+v", d)
+	}
+	if math.Abs(d.Delta-0.1) > 1e-9 {
+		t.Errorf("delta = %g, want 0.1", d.Delta)
+	}
+}
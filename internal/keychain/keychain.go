@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package keychain stores and retrieves secrets in the operating system's
+// credential store: the macOS Keychain, the Linux Secret Service, or the
+// Windows Credential Manager. The platform is chosen at build time.
+package keychain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotFound is returned by Get when no secret is stored for the service
+// and account.
+var ErrNotFound = errors.New("keychain: item not found")
+
+// ErrUnsupported is returned on platforms without a supported credential store.
+var ErrUnsupported = errors.New("keychain: not supported on this platform")
+
+// store is one platform's credential store.
+type store interface {
+	get(service, account string) (string, error)
+	set(service, account, value string) error
+}
+
+// Get returns the secret stored for service and account.
+func Get(service, account string) (string, error) {
+	return platform.get(service, account)
+}
+
+// Set stores value for service and account, replacing any existing secret.
+func Set(service, account, value string) error {
+	if value == "" {
+		return fmt.Errorf("keychain: refusing to store an empty secret")
+	}
+	return platform.set(service, account, value)
+}
+
+// exitError reports a command that ran but exited non-zero.
+type exitError struct {
+	name   string
+	code   int
+	stderr string
+}
+
+func (e *exitError) Error() string {
+	if e.stderr != "" {
+		return fmt.Sprintf("%s exited with status %d: %s", e.name, e.code, e.stderr)
+	}
+	return fmt.Sprintf("%s exited with status %d", e.name, e.code)
+}
+
+// exitCode returns the status of a command that exited non-zero, or -1 for
+// any other error.
+func exitCode(err error) int {
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return -1
+}
+
+// runCommand runs name with args, writing stdin to it, and returns its
+// stdout. A non-zero exit is reported as *exitError. Tests replace it to
+// stand in for the platform tools.
+var runCommand = func(stdin, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			return stdout.String(), &exitError{name: name, code: exit.ExitCode(), stderr: strings.TrimSpace(stderr.String())}
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,8 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build linux
+
+package keychain
+
+var platform store = secretToolStore{}
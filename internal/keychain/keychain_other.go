@@ -0,0 +1,14 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+//go:build !darwin && !linux && !windows
+
+package keychain
+
+var platform store = unsupportedStore{}
+
+type unsupportedStore struct{}
+
+func (unsupportedStore) get(service, account string) (string, error) { return "", ErrUnsupported }
+
+func (unsupportedStore) set(service, account, value string) error { return ErrUnsupported }
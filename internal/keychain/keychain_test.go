@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+// call records one invocation of the mocked runCommand.
+type call struct {
+	stdin string
+	argv  []string
+}
+
+// mockCommand replaces runCommand for the test, answering every call with
+// out and err and recording what was run.
+func mockCommand(t *testing.T, out string, err error) *[]call {
+	t.Helper()
+	var calls []call
+	orig := runCommand
+	runCommand = func(stdin, name string, args ...string) (string, error) {
+		calls = append(calls, call{stdin: stdin, argv: append([]string{name}, args...)})
+		return out, err
+	}
+	t.Cleanup(func() { runCommand = orig })
+	return &calls
+}
+
+func TestSecurityGet(t *testing.T) {
+	calls := mockCommand(t, "abc123\n", nil)
+	got, err := securityStore{}.get("reserve", "fred-api-key")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got != "abc123" {
+		t.Errorf("expected abc123, got %q", got)
+	}
+	want := []string{"security", "find-generic-password", "-s", "reserve", "-a", "fred-api-key", "-w"}
+	if len(*calls) != 1 || !reflect.DeepEqual((*calls)[0].argv, want) {
+		t.Errorf("expected %v, got %+v", want, *calls)
+	}
+}
+
+func TestSecurityGetNotFound(t *testing.T) {
+	mockCommand(t, "", &exitError{name: "security", code: securityNotFound})
+	if _, err := (securityStore{}).get("reserve", "fred-api-key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSecuritySetUpdatesExisting(t *testing.T) {
+	calls := mockCommand(t, "", nil)
+	if err := (securityStore{}).set("reserve", "fred-api-key", "s3cret"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	want := []string{"security", "add-generic-password", "-U", "-s", "reserve", "-a", "fred-api-key", "-w", "s3cret"}
+	if len(*calls) != 1 || !reflect.DeepEqual((*calls)[0].argv, want) {
+		t.Errorf("expected %v, got %+v", want, *calls)
+	}
+}
+
+func TestSecretToolGet(t *testing.T) {
+	calls := mockCommand(t, "abc123", nil)
+	got, err := secretToolStore{}.get("reserve", "fred-api-key")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got != "abc123" {
+		t.Errorf("expected abc123, got %q", got)
+	}
+	want := []string{"secret-tool", "lookup", "service", "reserve", "account", "fred-api-key"}
+	if len(*calls) != 1 || !reflect.DeepEqual((*calls)[0].argv, want) {
+		t.Errorf("expected %v, got %+v", want, *calls)
+	}
+}
+
+func TestSecretToolGetNotFound(t *testing.T) {
+	mockCommand(t, "", &exitError{name: "secret-tool", code: 1})
+	if _, err := (secretToolStore{}).get("reserve", "fred-api-key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSecretToolSetUsesStdin(t *testing.T) {
+	calls := mockCommand(t, "", nil)
+	if err := (secretToolStore{}).set("reserve", "fred-api-key", "s3cret"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	c := (*calls)[0]
+	if c.stdin != "s3cret" {
+		t.Errorf("secret should be sent on stdin, got %q", c.stdin)
+	}
+	for _, arg := range c.argv {
+		if arg == "s3cret" {
+			t.Errorf("secret must not appear in argv: %v", c.argv)
+		}
+	}
+}
+
+func TestSecretToolMissingBinary(t *testing.T) {
+	mockCommand(t, "", fmt.Errorf("exec: %q: %w", "secret-tool", exec.ErrNotFound))
+	_, err := secretToolStore{}.get("reserve", "fred-api-key")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected an install hint, got %v", err)
+	}
+	if !errors.Is(err, exec.ErrNotFound) {
+		t.Errorf("expected the exec error to be wrapped, got %v", err)
+	}
+}
+
+func TestSetRejectsEmptySecret(t *testing.T) {
+	calls := mockCommand(t, "", nil)
+	if err := Set("reserve", "fred-api-key", ""); err == nil {
+		t.Error("expected error for an empty secret")
+	}
+	if len(*calls) != 0 {
+		t.Errorf("no command should run for an empty secret, got %+v", *calls)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package keychain
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// secretToolStore uses the freedesktop Secret Service (GNOME Keyring,
+// KWallet) over D-Bus through secret-tool(1), from libsecret. Items are
+// keyed by "service" and "account" attributes.
+type secretToolStore struct{}
+
+func (secretToolStore) get(service, account string) (string, error) {
+	out, err := runCommand("", "secret-tool", "lookup", "service", service, "account", account)
+	// secret-tool lookup exits 1 with no output when nothing matches.
+	if exitCode(err) == 1 && out == "" {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", secretToolError(err)
+	}
+	return strings.TrimRight(out, "\r\n"), nil
+}
+
+// set sends the secret on stdin, so it never appears in the process list.
+func (secretToolStore) set(service, account, value string) error {
+	label := fmt.Sprintf("%s (%s)", service, account)
+	if _, err := runCommand(value, "secret-tool", "store", "--label", label, "service", service, "account", account); err != nil {
+		return secretToolError(err)
+	}
+	return nil
+}
+
+func secretToolError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("keychain: secret-tool not found; install libsecret-tools (or your distribution's libsecret package): %w", err)
+	}
+	return fmt.Errorf("keychain: %w", err)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package keychain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// securityStore uses the macOS Keychain through the security(1) tool.
+type securityStore struct{}
+
+// securityNotFound is the status security exits with when no item matches.
+const securityNotFound = 44
+
+func (securityStore) get(service, account string) (string, error) {
+	out, err := runCommand("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	if exitCode(err) == securityNotFound {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("keychain: %w", err)
+	}
+	return strings.TrimRight(out, "\r\n"), nil
+}
+
+// set passes the secret as an argument: security only reads a password from
+// the terminal, never from stdin.
+func (securityStore) set(service, account, value string) error {
+	if _, err := runCommand("", "security", "add-generic-password", "-U", "-s", service, "-a", account, "-w", value); err != nil {
+		return fmt.Errorf("keychain: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// Observation frequencies reported by DetectFrequency.
+const (
+	FrequencyDaily     = "daily"
+	FrequencyWeekly    = "weekly"
+	FrequencyMonthly   = "monthly"
+	FrequencyQuarterly = "quarterly"
+	FrequencyAnnual    = "annual"
+	FrequencyIrregular = "irregular"
+)
+
+// DetectFrequency infers how often obs is observed from the most common gap
+// between consecutive dates, and returns the frequency with its periods per
+// year. Gaps are bucketed first, so months of 28 to 31 days count as one
+// monthly gap and weekends in a business-daily series count as daily.
+// Business-daily series (no weekend dates) have 252 periods a year. Anything
+// else, or fewer than two dates, is irregular, with periods per year from the
+// median gap (0 when there is no gap).
+func DetectFrequency(obs []Observation) (string, float64) {
+	if len(obs) < 2 {
+		return FrequencyIrregular, 0
+	}
+	counts := map[string]int{}
+	gaps := make([]float64, 0, len(obs)-1)
+	weekend := false
+	for i, o := range obs {
+		if wd := o.Date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			weekend = true
+		}
+		if i == 0 {
+			continue
+		}
+		days := o.Date.Sub(obs[i-1].Date).Hours() / 24
+		if days < 0 {
+			days = -days
+		}
+		gaps = append(gaps, days)
+		counts[gapFrequency(days)]++
+	}
+
+	freq := FrequencyIrregular
+	best := 0
+	for _, f := range []string{FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyQuarterly, FrequencyAnnual, FrequencyIrregular} {
+		if counts[f] > best {
+			freq, best = f, counts[f]
+		}
+	}
+	switch freq {
+	case FrequencyDaily:
+		if weekend {
+			return freq, 365.25
+		}
+		return freq, 252
+	case FrequencyWeekly:
+		return freq, 52
+	case FrequencyMonthly:
+		return freq, 12
+	case FrequencyQuarterly:
+		return freq, 4
+	case FrequencyAnnual:
+		return freq, 1
+	}
+	sort.Float64s(gaps)
+	if median := gaps[len(gaps)/2]; median > 0 {
+		return freq, 365.25 / median
+	}
+	return freq, 0
+}
+
+// gapFrequency buckets a gap between consecutive dates, in days.
+func gapFrequency(days float64) string {
+	switch {
+	case days >= 1 && days <= 4: // 3 over a weekend, 4 over a long one
+		return FrequencyDaily
+	case days >= 6 && days <= 8:
+		return FrequencyWeekly
+	case days >= 28 && days <= 31:
+		return FrequencyMonthly
+	case days >= 89 && days <= 92:
+		return FrequencyQuarterly
+	case days >= 365 && days <= 366:
+		return FrequencyAnnual
+	}
+	return FrequencyIrregular
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func datedObs(start time.Time, n int, step func(time.Time) time.Time) []Observation {
+	out := []Observation{{Date: start, Value: 1}}
+	for len(out) < n {
+		out = append(out, Observation{Date: step(out[len(out)-1].Date), Value: 1})
+	}
+	return out
+}
+
+func TestDetectFrequency(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	businessDays := func(d time.Time) time.Time {
+		d = d.AddDate(0, 0, 1)
+		for d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			d = d.AddDate(0, 0, 1)
+		}
+		return d
+	}
+	cases := []struct {
+		name string
+		obs  []Observation
+		freq string
+		ppy  float64
+	}{
+		{"business daily", datedObs(jan, 60, businessDays), FrequencyDaily, 252},
+		{"calendar daily", datedObs(jan, 60, func(d time.Time) time.Time { return d.AddDate(0, 0, 1) }), FrequencyDaily, 365.25},
+		{"weekly", datedObs(jan, 20, func(d time.Time) time.Time { return d.AddDate(0, 0, 7) }), FrequencyWeekly, 52},
+		{"monthly", datedObs(jan, 24, func(d time.Time) time.Time { return d.AddDate(0, 1, 0) }), FrequencyMonthly, 12},
+		{"quarterly", datedObs(jan, 12, func(d time.Time) time.Time { return d.AddDate(0, 3, 0) }), FrequencyQuarterly, 4},
+		{"annual", datedObs(jan, 10, func(d time.Time) time.Time { return d.AddDate(1, 0, 0) }), FrequencyAnnual, 1},
+		{"semiannual", datedObs(jan, 10, func(d time.Time) time.Time { return d.AddDate(0, 6, 0) }), FrequencyIrregular, 365.25 / 182},
+		{"single", datedObs(jan, 1, nil), FrequencyIrregular, 0},
+	}
+	for _, c := range cases {
+		freq, ppy := DetectFrequency(c.obs)
+		if freq != c.freq || ppy != c.ppy {
+			t.Errorf("%s: got %s %g, want %s %g", c.name, freq, ppy, c.freq, c.ppy)
+		}
+	}
+}
+
+func TestDetectFrequencyToleratesGaps(t *testing.T) {
+	obs := datedObs(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 12, func(d time.Time) time.Time { return d.AddDate(0, 1, 0) })
+	obs = append(obs[:5], obs[7:]...) // two missing months
+	if freq, _ := DetectFrequency(obs); freq != FrequencyMonthly {
+		t.Errorf("monthly series with a hole detected as %s", freq)
+	}
+}
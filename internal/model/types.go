@@ -107,6 +107,22 @@ type SeriesData struct {
 	SeriesID string        `json:"series_id"`
 	Meta     *SeriesMeta   `json:"meta,omitempty"`
 	Obs      []Observation `json:"observations"`
+
+	// Response holds the cache validators FRED sent with Obs, if any. It is
+	// persisted with cached observations but never rendered.
+	Response *ResponseMeta `json:"-"`
+}
+
+// ResponseMeta holds the HTTP cache validators of a FRED response, so a later
+// request for the same data can ask for it only if it has changed.
+type ResponseMeta struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// IsZero reports whether no validators were recorded.
+func (m ResponseMeta) IsZero() bool {
+	return m.ETag == "" && m.LastModified == ""
 }
 
 // ─── Result Envelope ─────────────────────────────────────────────────────────
@@ -136,6 +152,7 @@ const (
 	KindSeriesData   = "series_data"
 	KindCategory     = "category"
 	KindRelease      = "release"
+	KindRegional     = "regional"
 	KindSource       = "source"
 	KindTag          = "tag"
 	KindTable        = "table"
@@ -149,3 +166,16 @@ type SearchResult struct {
 	Type   string       `json:"type"`
 	Series []SeriesMeta `json:"series,omitempty"`
 }
+
+// ─── Scheduled Jobs ──────────────────────────────────────────────────────────
+
+// ScheduledJob is a reserve command run on a cron schedule by
+// 'reserve schedule run'. Command holds the arguments after "reserve".
+type ScheduledJob struct {
+	Name       string    `json:"name"`
+	Cron       string    `json:"cron"`
+	Command    string    `json:"command"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastRunAt  time.Time `json:"last_run_at,omitempty"`
+	LastStatus string    `json:"last_status,omitempty"`
+}
@@ -7,11 +7,15 @@ package pipeline
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,6 +70,10 @@ func ReadObservationsWithProvenance(r io.Reader) (string, []model.Observation, P
 }
 
 func readObservationsInternal(r io.Reader) (string, []model.Observation, string, error) {
+	r, err := decompressIfGzip(r)
+	if err != nil {
+		return "", nil, "", err
+	}
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 
@@ -102,6 +110,10 @@ func readObservationsInternal(r io.Reader) (string, []model.Observation, string,
 // ReadObservationGroups reads JSONL records from r and groups them by series_id.
 // The returned slice preserves the order in which each distinct series first appeared.
 func ReadObservationGroups(r io.Reader) ([]ObservationGroup, error) {
+	r, err := decompressIfGzip(r)
+	if err != nil {
+		return nil, err
+	}
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 
@@ -155,18 +167,39 @@ func ReadObservationGroups(r io.Reader) ([]ObservationGroup, error) {
 }
 
 func readObservationsWithProvenance(r io.Reader) (string, []model.Observation, Provenance, error) {
+	var obs []model.Observation
+	seriesID, prov, err := ScanObservations(r, func(o model.Observation) error {
+		obs = append(obs, o)
+		return nil
+	})
+	if err != nil {
+		return "", nil, Provenance{}, err
+	}
+	return seriesID, obs, prov, nil
+}
+
+// ScanObservations reads JSONL records from r one line at a time and calls fn
+// for each observation, so a caller that keeps running totals never holds the
+// whole stream in memory. It returns the first series_id in the stream and
+// its provenance, taken from the first row that carries each field. An error
+// from fn stops the scan and is returned as is.
+func ScanObservations(r io.Reader, fn func(model.Observation) error) (string, Provenance, error) {
+	r, err := decompressIfGzip(r)
+	if err != nil {
+		return "", Provenance{}, err
+	}
 	scanner := bufio.NewScanner(r)
 	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
 
-	var obs []model.Observation
 	seriesID := ""
 	lineNum := 0
+	count := 0
 	var prov Provenance
 
 	for scanner.Scan() {
 		rec, observation, skip, err := parseObservationLine(scanner.Text(), &lineNum)
 		if err != nil {
-			return "", nil, Provenance{}, err
+			return "", Provenance{}, err
 		}
 		if skip {
 			continue
@@ -183,15 +216,37 @@ func readObservationsWithProvenance(r io.Reader) (string, []model.Observation, P
 		if len(prov.SourceNames) == 0 && len(rec.SourceNames) > 0 {
 			prov.SourceNames = normalizeSourceNames(rec.SourceNames)
 		}
-		obs = append(obs, observation)
+		if err := fn(observation); err != nil {
+			return "", Provenance{}, err
+		}
+		count++
 	}
 	if err := scanner.Err(); err != nil {
-		return "", nil, Provenance{}, fmt.Errorf("reading input: %w", err)
+		return "", Provenance{}, fmt.Errorf("reading input: %w", err)
 	}
-	if len(obs) == 0 {
-		return "", nil, Provenance{}, fmt.Errorf("no observations read from input (is stdin empty?)")
+	if count == 0 {
+		return "", Provenance{}, fmt.Errorf("no observations read from input (is stdin empty?)")
 	}
-	return seriesID, obs, prov, nil
+	return seriesID, prov, nil
+}
+
+// gzipMagic is the two-byte header that starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decompressIfGzip peeks at the first bytes of r and, when they carry the gzip
+// magic number, returns a reader over the decompressed stream. Plain input is
+// returned unchanged (behind a buffered reader that has not consumed anything).
+func decompressIfGzip(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(gzipMagic))
+	if err != nil || !bytes.Equal(head, gzipMagic) {
+		return br, nil
+	}
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing input: %w", err)
+	}
+	return zr, nil
 }
 
 func normalizeSourceNames(in []string) []string {
@@ -283,6 +338,111 @@ func WriteJSONL(w io.Writer, seriesID string, obs []model.Observation) error {
 	return nil
 }
 
+// WriteCSV writes observations as CSV to w with the header date,value,value_raw,
+// for opening a single series in a spreadsheet. Values keep full precision
+// and NaN is an empty cell. It takes the same arguments as WriteJSONL so
+// callers can switch between the two; seriesID is not written, as the file
+// holds one series.
+func WriteCSV(w io.Writer, seriesID string, obs []model.Observation) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"date", "value", "value_raw"}); err != nil {
+		return err
+	}
+	for _, o := range obs {
+		val := ""
+		if !math.IsNaN(o.Value) {
+			val = strconv.FormatFloat(o.Value, 'g', -1, 64)
+		}
+		if err := cw.Write([]string{o.Date.Format("2006-01-02"), val, o.ValueRaw}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSONLGzip writes observations as gzip-compressed JSONL to w.
+// The readers in this package detect and decompress such streams automatically.
+func WriteJSONLGzip(w io.Writer, seriesID string, obs []model.Observation) error {
+	zw := gzip.NewWriter(w)
+	if err := WriteJSONL(zw, seriesID, obs); err != nil {
+		zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// Head copies the first n observation rows from r to w and stops reading as
+// soon as the n-th row has been written, so an upstream producer sees the pipe
+// close early. Blank and "//" comment lines are dropped and do not count.
+func Head(r io.Reader, w io.Writer, n int) error {
+	if n < 1 {
+		return fmt.Errorf("head: n must be >= 1, got %d", n)
+	}
+	r, err := decompressIfGzip(r)
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+
+	written := 0
+	for written < n && scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		written++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+	return nil
+}
+
+// Tail copies the last n observation rows from r to w once r is exhausted.
+// Rows are held in a fixed-size ring buffer, so memory stays bounded by n.
+// Blank and "//" comment lines are dropped and do not count.
+func Tail(r io.Reader, w io.Writer, n int) error {
+	if n < 1 {
+		return fmt.Errorf("tail: n must be >= 1, got %d", n)
+	}
+	r, err := decompressIfGzip(r)
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+
+	ring := make([]string, n)
+	seen := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		ring[seen%n] = line
+		seen++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+
+	start, count := 0, seen
+	if seen > n {
+		start, count = seen%n, n
+	}
+	for i := 0; i < count; i++ {
+		if _, err := fmt.Fprintln(w, ring[(start+i)%n]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // IsTTY returns true if stdout is a terminal (not a pipe).
 func IsTTY() bool {
 	fi, err := os.Stdout.Stat()
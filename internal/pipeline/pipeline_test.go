@@ -5,6 +5,7 @@ package pipeline_test
 
 import (
 	"bytes"
+	"io"
 	"math"
 	"strings"
 	"testing"
@@ -420,6 +421,35 @@ func TestWriteEmptySlice(t *testing.T) {
 	}
 }
 
+// ─── WriteCSV ─────────────────────────────────────────────────────────────────
+
+func TestWriteCSV(t *testing.T) {
+	observations := []model.Observation{
+		mkobs(2024, 6, 15, 1.0/3, "0.333"),
+		mkobs(2024, 7, 1, math.NaN(), "."),
+	}
+	var buf bytes.Buffer
+	if err := pipeline.WriteCSV(&buf, "TEST", observations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "date,value,value_raw\n" +
+		"2024-06-15,0.3333333333333333,0.333\n" +
+		"2024-07-01,,.\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV:\n got  %q\n want %q", buf.String(), want)
+	}
+}
+
+func TestWriteCSVEmptySliceWritesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := pipeline.WriteCSV(&buf, "TEST", nil); err != nil {
+		t.Fatalf("WriteCSV with nil slice should not error: %v", err)
+	}
+	if buf.String() != "date,value,value_raw\n" {
+		t.Errorf("nil slice should produce only the header, got: %q", buf.String())
+	}
+}
+
 // ─── Round-trip ───────────────────────────────────────────────────────────────
 
 func TestRoundTrip(t *testing.T) {
@@ -502,3 +532,163 @@ func TestRoundTripSeriesIDPreserved(t *testing.T) {
 		t.Errorf("series_id not preserved: expected FEDFUNDS, got %q", sid)
 	}
 }
+
+// ─── Gzip ─────────────────────────────────────────────────────────────────────
+
+func TestGzipRoundTrip(t *testing.T) {
+	original := []model.Observation{
+		mkobs(2020, 1, 1, 3.5, "3.5"),
+		mkobs(2020, 2, 1, math.NaN(), "."),
+		mkobs(2020, 3, 1, 4.2, "4.2"),
+	}
+	var buf bytes.Buffer
+	if err := pipeline.WriteJSONLGzip(&buf, "CPIAUCSL", original); err != nil {
+		t.Fatalf("WriteJSONLGzip: %v", err)
+	}
+	if b := buf.Bytes(); len(b) < 2 || b[0] != 0x1f || b[1] != 0x8b {
+		t.Fatalf("output does not start with gzip magic bytes")
+	}
+
+	sid, result, err := pipeline.ReadObservations(&buf)
+	if err != nil {
+		t.Fatalf("ReadObservations: %v", err)
+	}
+	if sid != "CPIAUCSL" {
+		t.Errorf("series_id: expected CPIAUCSL, got %q", sid)
+	}
+	if len(result) != 3 || result[0].Value != 3.5 || !isNaN(result[1].Value) || result[2].Value != 4.2 {
+		t.Fatalf("unexpected observations after gzip round trip: %+v", result)
+	}
+}
+
+func TestGzipDetectionLeavesPlainInputAlone(t *testing.T) {
+	input := jsonl(`{"series_id":"UNRATE","date":"2020-01-01","value":3.5}`)
+	groups, err := pipeline.ReadObservationGroups(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadObservationGroups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].SeriesID != "UNRATE" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+}
+
+func TestGzipEmptyStreamError(t *testing.T) {
+	var buf bytes.Buffer
+	if err := pipeline.WriteJSONLGzip(&buf, "EMPTY", nil); err != nil {
+		t.Fatalf("WriteJSONLGzip: %v", err)
+	}
+	_, _, err := pipeline.ReadObservations(&buf)
+	if err == nil || !strings.Contains(err.Error(), "no observations") {
+		t.Fatalf("expected no-observations error, got %v", err)
+	}
+}
+
+func TestGzipTruncatedStreamError(t *testing.T) {
+	var buf bytes.Buffer
+	obs := []model.Observation{mkobs(2020, 1, 1, 1, "1"), mkobs(2020, 2, 1, 2, "2")}
+	if err := pipeline.WriteJSONLGzip(&buf, "TRUNC", obs); err != nil {
+		t.Fatalf("WriteJSONLGzip: %v", err)
+	}
+	truncated := buf.Bytes()[:buf.Len()-6]
+	if _, _, err := pipeline.ReadObservations(bytes.NewReader(truncated)); err == nil {
+		t.Fatal("expected error reading truncated gzip stream")
+	}
+	if _, _, err := pipeline.ReadObservations(bytes.NewReader(truncated[:5])); err == nil {
+		t.Fatal("expected error reading gzip stream truncated inside the header")
+	}
+}
+
+// ─── Head / Tail ──────────────────────────────────────────────────────────────
+
+func headTailInput() string {
+	return jsonl(
+		`{"series_id":"UNRATE","date":"2020-01-01","value":3.5}`,
+		`{"series_id":"UNRATE","date":"2020-02-01","value":null,"value_raw":"."}`,
+		`// comment lines are not rows`,
+		`{"series_id":"UNRATE","date":"2020-03-01","value":4.4}`,
+		``,
+		`{"series_id":"UNRATE","date":"2020-04-01","value":14.8}`,
+	)
+}
+
+func TestHeadFirstN(t *testing.T) {
+	var buf bytes.Buffer
+	if err := pipeline.Head(strings.NewReader(headTailInput()), &buf, 2); err != nil {
+		t.Fatalf("Head: %v", err)
+	}
+	lines := nonEmptyLines(buf.String())
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 rows, got %d: %v", len(lines), lines)
+	}
+	// The null-valued row counts toward n like any other row.
+	if !strings.Contains(lines[1], `"value":null`) {
+		t.Errorf("second row should be the NaN row, got %s", lines[1])
+	}
+}
+
+func TestTailLastN(t *testing.T) {
+	var buf bytes.Buffer
+	if err := pipeline.Tail(strings.NewReader(headTailInput()), &buf, 3); err != nil {
+		t.Fatalf("Tail: %v", err)
+	}
+	lines := nonEmptyLines(buf.String())
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 rows, got %d: %v", len(lines), lines)
+	}
+	for i, date := range []string{"2020-02-01", "2020-03-01", "2020-04-01"} {
+		if !strings.Contains(lines[i], date) {
+			t.Errorf("row %d: expected %s, got %s", i, date, lines[i])
+		}
+	}
+}
+
+func TestHeadTailNLargerThanInputEmitsAll(t *testing.T) {
+	for name, fn := range map[string]func(r io.Reader, w io.Writer, n int) error{
+		"head": pipeline.Head,
+		"tail": pipeline.Tail,
+	} {
+		var buf bytes.Buffer
+		if err := fn(strings.NewReader(headTailInput()), &buf, 50); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		lines := nonEmptyLines(buf.String())
+		if len(lines) != 4 {
+			t.Fatalf("%s: expected all 4 rows, got %d", name, len(lines))
+		}
+		if !strings.Contains(lines[0], "2020-01-01") || !strings.Contains(lines[3], "2020-04-01") {
+			t.Errorf("%s: rows out of order: %v", name, lines)
+		}
+	}
+}
+
+func TestHeadTailZeroNError(t *testing.T) {
+	if err := pipeline.Head(strings.NewReader(headTailInput()), io.Discard, 0); err == nil {
+		t.Error("head: expected error for n=0")
+	}
+	if err := pipeline.Tail(strings.NewReader(headTailInput()), io.Discard, 0); err == nil {
+		t.Error("tail: expected error for n=0")
+	}
+}
+
+// endlessRows yields observation rows forever; reading it to EOF never ends.
+type endlessRows struct{ reads int }
+
+func (e *endlessRows) Read(p []byte) (int, error) {
+	e.reads++
+	row := `{"series_id":"X","date":"2020-01-01","value":1}` + "\n"
+	return copy(p, row), nil
+}
+
+func TestHeadStopsReadingAfterN(t *testing.T) {
+	src := &endlessRows{}
+	var buf bytes.Buffer
+	if err := pipeline.Head(src, &buf, 5); err != nil {
+		t.Fatalf("Head: %v", err)
+	}
+	if got := len(nonEmptyLines(buf.String())); got != 5 {
+		t.Fatalf("expected 5 rows, got %d", got)
+	}
+	if src.reads > 10 {
+		t.Errorf("head kept consuming input: %d reads for 5 rows", src.reads)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package progress draws a single-line terminal progress bar for batch
+// operations. It writes to stderr so stdout stays clean for piped output.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	barWidth = 20
+	// maxLabel caps the label so a long series ID does not wrap the line.
+	maxLabel = 16
+)
+
+// Progress is an in-place progress bar. A nil *Progress is valid and draws
+// nothing, and its methods are safe to call from several goroutines.
+type Progress struct {
+	mu      sync.Mutex
+	w       io.Writer
+	total   int
+	quiet   bool
+	lastLen int
+}
+
+// Bar returns a bar over total items on stderr. It stays silent when stderr
+// is not a terminal, such as in CI logs.
+func Bar(total int) *Progress {
+	return New(os.Stderr, total, !isTerminal(os.Stderr))
+}
+
+// New returns a bar over total items drawn on w. With quiet set, nothing is
+// written.
+func New(w io.Writer, total int, quiet bool) *Progress {
+	return &Progress{w: w, total: total, quiet: quiet}
+}
+
+// Update redraws the bar with done items finished, the latest being label.
+func (p *Progress) Update(done int, label string) {
+	if p == nil || p.quiet || p.total <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	line := render(done, p.total, label)
+	pad := ""
+	if n := len([]rune(line)); n < p.lastLen {
+		pad = strings.Repeat(" ", p.lastLen-n)
+	}
+	p.lastLen = len([]rune(line))
+	fmt.Fprintf(p.w, "\r%s%s", line, pad)
+}
+
+// Done erases the bar, leaving the cursor at the start of a clean line.
+func (p *Progress) Done() {
+	if p == nil || p.quiet {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.lastLen > 0 {
+		fmt.Fprintf(p.w, "\r%s\r", strings.Repeat(" ", p.lastLen))
+		p.lastLen = 0
+	}
+}
+
+// render formats one frame, e.g. "[===>                ] 3/20 (FEDFUNDS) 15%".
+func render(done, total int, label string) string {
+	done = min(max(done, 0), total)
+	filled := barWidth * done / total
+	bar := strings.Repeat("=", filled)
+	if filled > 0 && filled < barWidth {
+		bar = bar[:filled-1] + ">"
+	}
+	bar += strings.Repeat(" ", barWidth-filled)
+
+	line := fmt.Sprintf("[%s] %d/%d", bar, done, total)
+	if label != "" {
+		line += " (" + truncate(label) + ")"
+	}
+	return fmt.Sprintf("%s %d%%", line, 100*done/total)
+}
+
+func truncate(label string) string {
+	r := []rune(label)
+	if len(r) <= maxLabel {
+		return label
+	}
+	return string(r[:maxLabel-1]) + "…"
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
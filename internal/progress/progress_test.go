@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestUpdatePercentage(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, 20, false)
+	p.Update(3, "FEDFUNDS")
+	want := "\r[==>                 ] 3/20 (FEDFUNDS) 15%"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	for _, tc := range []struct {
+		done, total int
+		want        string
+	}{
+		{0, 7, "0/7 0%"},
+		{1, 3, "1/3 33%"},
+		{7, 7, "7/7 100%"},
+		{9, 7, "7/7 100%"}, // clamped
+	} {
+		line := render(tc.done, tc.total, "")
+		if !strings.HasSuffix(line, tc.want) {
+			t.Errorf("render(%d, %d) = %q, want suffix %q", tc.done, tc.total, line, tc.want)
+		}
+	}
+	if line := render(7, 7, ""); !strings.HasPrefix(line, "["+strings.Repeat("=", barWidth)+"]") {
+		t.Errorf("a finished bar should be full, got %q", line)
+	}
+}
+
+func TestLabelTruncation(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, 2, false)
+	p.Update(1, "BAMLH0A0HYM2EYEXTRA")
+	if !strings.Contains(buf.String(), "(BAMLH0A0HYM2EYE…)") {
+		t.Errorf("long label should be cut to %d runes, got %q", maxLabel, buf.String())
+	}
+	if got := truncate("GDP"); got != "GDP" {
+		t.Errorf("short label should be unchanged, got %q", got)
+	}
+}
+
+func TestUpdateOverwritesAndDoneClears(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, 10, false)
+	p.Update(1, "CPIAUCSL")
+	first := len(buf.String())
+	buf.Reset()
+	p.Update(2, "GDP")
+	if second := buf.String(); len(second) != first || !strings.HasSuffix(second, " ") {
+		t.Errorf("a shorter frame should be padded over the previous one, got %q", second)
+	}
+	buf.Reset()
+	p.Done()
+	if got := buf.String(); strings.Trim(got, "\r ") != "" || !strings.HasSuffix(got, "\r") {
+		t.Errorf("Done should blank the line, got %q", got)
+	}
+}
+
+func TestQuietWritesNothing(t *testing.T) {
+	var buf bytes.Buffer
+	p := New(&buf, 5, true)
+	p.Update(2, "UNRATE")
+	p.Done()
+	if buf.Len() != 0 {
+		t.Errorf("quiet bar wrote %q", buf.String())
+	}
+	var nilBar *Progress
+	nilBar.Update(1, "GDP")
+	nilBar.Done()
+}
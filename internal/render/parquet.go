@@ -0,0 +1,336 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+// ─── Parquet ──────────────────────────────────────────────────────────────────
+
+// Observations are written as one uncompressed row group with a single PLAIN
+// data page per column, the simplest layout every Parquet reader accepts. The
+// file metadata and page headers are Thrift compact-protocol structs, encoded
+// by the small thriftWriter below, so the format needs no dependency.
+//
+// Schema:
+//
+//	series_id  BYTE_ARRAY (STRING)  required
+//	date       INT32 (DATE)         required
+//	value      DOUBLE               optional (null for NaN)
+//	value_raw  BYTE_ARRAY (STRING)  required
+
+const parquetMagic = "PAR1"
+
+// Parquet enum values used below, from parquet.thrift.
+const (
+	pqTypeInt32     = 1
+	pqTypeDouble    = 5
+	pqTypeByteArray = 6
+
+	pqRequired = 0
+	pqOptional = 1
+
+	pqConvertedUTF8 = 0
+	pqConvertedDate = 6
+
+	pqLogicalString = 1 // LogicalType union field IDs
+	pqLogicalDate   = 6
+
+	pqEncodingPlain = 0
+	pqEncodingRLE   = 3
+
+	pqPageData          = 0
+	pqCodecUncompressed = 0
+)
+
+type parquetColumn struct {
+	name      string
+	physical  int32
+	optional  bool
+	converted int32 // -1 = none
+	logical   int16 // 0 = none
+	values    []byte
+	defLevels []bool // optional columns only: true = value present
+}
+
+// RenderParquet writes the observations of every series in series to w as
+// one Parquet file, one row per observation.
+func RenderParquet(w io.Writer, series []*model.SeriesData) error {
+	cols := []*parquetColumn{
+		{name: "series_id", physical: pqTypeByteArray, converted: pqConvertedUTF8, logical: pqLogicalString},
+		{name: "date", physical: pqTypeInt32, converted: pqConvertedDate, logical: pqLogicalDate},
+		{name: "value", physical: pqTypeDouble, optional: true, converted: -1},
+		{name: "value_raw", physical: pqTypeByteArray, converted: pqConvertedUTF8, logical: pqLogicalString},
+	}
+	var seriesBuf, dateBuf, valueBuf, rawBuf bytes.Buffer
+	var present []bool
+	rows := 0
+	for _, sd := range series {
+		for _, o := range sd.Obs {
+			putByteArray(&seriesBuf, sd.SeriesID)
+			days := int32(math.Floor(float64(o.Date.Unix()) / 86400)) // DATE counts days since the Unix epoch
+			_ = binary.Write(&dateBuf, binary.LittleEndian, days)
+			if math.IsNaN(o.Value) {
+				present = append(present, false)
+			} else {
+				present = append(present, true)
+				_ = binary.Write(&valueBuf, binary.LittleEndian, o.Value)
+			}
+			putByteArray(&rawBuf, o.ValueRaw)
+			rows++
+		}
+	}
+	if rows == 0 {
+		return fmt.Errorf("parquet: no observations to write")
+	}
+	cols[0].values = seriesBuf.Bytes()
+	cols[1].values = dateBuf.Bytes()
+	cols[2].values, cols[2].defLevels = valueBuf.Bytes(), present
+	cols[3].values = rawBuf.Bytes()
+
+	var out bytes.Buffer
+	out.WriteString(parquetMagic)
+	chunks := make([]func(t *thriftWriter), len(cols))
+	var groupSize int64
+	for i, c := range cols {
+		offset := int64(out.Len())
+		page := c.values
+		if c.optional {
+			page = append(rleBools(c.defLevels), c.values...)
+		}
+		var header thriftWriter
+		header.i32(1, pqPageData)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(page)))
+		header.structBegin(5) // DataPageHeader
+		header.i32(1, int32(rows))
+		header.i32(2, pqEncodingPlain)
+		header.i32(3, pqEncodingRLE)
+		header.i32(4, pqEncodingRLE)
+		header.structEnd()
+		header.stop()
+		out.Write(header.Bytes())
+		out.Write(page)
+
+		size := int64(out.Len()) - offset
+		groupSize += size
+		c := c
+		chunks[i] = func(t *thriftWriter) {
+			t.i64(2, offset) // file_offset
+			t.structBegin(3) // ColumnMetaData
+			t.i32(1, c.physical)
+			t.listI32(2, []int32{pqEncodingPlain, pqEncodingRLE})
+			t.listString(3, []string{c.name})
+			t.i32(4, pqCodecUncompressed)
+			t.i64(5, int64(rows))
+			t.i64(6, size)
+			t.i64(7, size)
+			t.i64(9, offset) // data_page_offset
+			t.structEnd()
+		}
+	}
+
+	var meta thriftWriter
+	meta.i32(1, 1) // version
+	meta.listBegin(2, thriftStruct, len(cols)+1)
+	meta.elemBegin() // root
+	meta.str(4, "schema")
+	meta.i32(5, int32(len(cols)))
+	meta.elemEnd()
+	for _, c := range cols {
+		meta.elemBegin()
+		meta.i32(1, c.physical)
+		repetition := int32(pqRequired)
+		if c.optional {
+			repetition = pqOptional
+		}
+		meta.i32(3, repetition)
+		meta.str(4, c.name)
+		if c.converted >= 0 {
+			meta.i32(6, c.converted)
+		}
+		if c.logical != 0 {
+			meta.structBegin(10) // LogicalType union
+			meta.structBegin(c.logical)
+			meta.structEnd()
+			meta.structEnd()
+		}
+		meta.elemEnd()
+	}
+	meta.i64(3, int64(rows))
+	meta.listBegin(4, thriftStruct, 1)
+	meta.elemBegin() // RowGroup
+	meta.listBegin(1, thriftStruct, len(chunks))
+	for _, chunk := range chunks {
+		meta.elemBegin()
+		chunk(&meta)
+		meta.elemEnd()
+	}
+	meta.i64(2, groupSize)
+	meta.i64(3, int64(rows))
+	meta.elemEnd()
+	meta.str(6, "reserve")
+	meta.stop()
+
+	out.Write(meta.Bytes())
+	_ = binary.Write(&out, binary.LittleEndian, uint32(meta.Len()))
+	out.WriteString(parquetMagic)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+func renderParquet(w io.Writer, result *model.Result) error {
+	sd, ok := result.Data.(*model.SeriesData)
+	if result.Kind != model.KindSeriesData || !ok {
+		return fmt.Errorf("parquet output is only available for observations")
+	}
+	return RenderParquet(w, []*model.SeriesData{sd})
+}
+
+// putByteArray appends s in PLAIN BYTE_ARRAY encoding: a 4-byte little-endian
+// length, then the bytes.
+func putByteArray(b *bytes.Buffer, s string) {
+	_ = binary.Write(b, binary.LittleEndian, uint32(len(s)))
+	b.WriteString(s)
+}
+
+// rleBools encodes definition levels (bit width 1) in the RLE/bit-packing
+// hybrid as a sequence of RLE runs, prefixed with their 4-byte length as
+// data page v1 requires.
+func rleBools(levels []bool) []byte {
+	var runs bytes.Buffer
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		putUvarint(&runs, uint64(j-i)<<1) // low bit 0 = RLE run
+		if levels[i] {
+			runs.WriteByte(1)
+		} else {
+			runs.WriteByte(0)
+		}
+		i = j
+	}
+	out := make([]byte, 4, 4+runs.Len())
+	binary.LittleEndian.PutUint32(out, uint32(runs.Len()))
+	return append(out, runs.Bytes()...)
+}
+
+func putUvarint(b *bytes.Buffer, v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	b.Write(tmp[:binary.PutUvarint(tmp[:], v)])
+}
+
+// ─── Thrift compact protocol ──────────────────────────────────────────────────
+
+// Compact-protocol field type IDs.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes just enough of the Thrift compact protocol for Parquet
+// metadata: i32, i64, binary, lists, and nested structs. Field IDs are
+// delta-encoded against the last ID written at the current nesting level.
+type thriftWriter struct {
+	bytes.Buffer
+	lastID []int16 // stack of last field IDs, one per open struct
+}
+
+func (t *thriftWriter) last() *int16 {
+	if len(t.lastID) == 0 {
+		t.lastID = []int16{0}
+	}
+	return &t.lastID[len(t.lastID)-1]
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	last := t.last()
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.WriteByte(typ)
+		t.varint(int64(id))
+	}
+	*last = id
+}
+
+// varint writes a zigzag-encoded signed varint.
+func (t *thriftWriter) varint(v int64) {
+	putUvarint(&t.Buffer, uint64((v<<1)^(v>>63)))
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(v)
+}
+
+func (t *thriftWriter) str(id int16, s string) {
+	t.field(id, thriftBinary)
+	putUvarint(&t.Buffer, uint64(len(s)))
+	t.WriteString(s)
+}
+
+func (t *thriftWriter) listBegin(id int16, elemType byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.WriteByte(byte(n)<<4 | elemType)
+	} else {
+		t.WriteByte(0xF0 | elemType)
+		putUvarint(&t.Buffer, uint64(n))
+	}
+}
+
+func (t *thriftWriter) listI32(id int16, vs []int32) {
+	t.listBegin(id, thriftI32, len(vs))
+	for _, v := range vs {
+		t.varint(int64(v))
+	}
+}
+
+func (t *thriftWriter) listString(id int16, vs []string) {
+	t.listBegin(id, thriftBinary, len(vs))
+	for _, v := range vs {
+		putUvarint(&t.Buffer, uint64(len(v)))
+		t.WriteString(v)
+	}
+}
+
+// structBegin opens a struct-typed field; elemBegin opens a struct that is a
+// list element and so has no field header. Both are closed by their *End.
+func (t *thriftWriter) structBegin(id int16) {
+	t.field(id, thriftStruct)
+	t.elemBegin()
+}
+
+func (t *thriftWriter) elemBegin() {
+	t.last()
+	t.lastID = append(t.lastID, 0)
+}
+
+func (t *thriftWriter) structEnd() { t.elemEnd() }
+
+func (t *thriftWriter) elemEnd() {
+	t.WriteByte(0) // stop
+	t.lastID = t.lastID[:len(t.lastID)-1]
+}
+
+// stop ends the top-level struct.
+func (t *thriftWriter) stop() { t.WriteByte(0) }
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+func TestRenderParquet_FramingAndColumns(t *testing.T) {
+	result := &model.Result{
+		Kind: model.KindSeriesData,
+		Data: &model.SeriesData{
+			SeriesID: "GDP",
+			Obs: []model.Observation{
+				{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 28623.5, ValueRaw: "28623.5"},
+				{Date: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+			},
+		},
+	}
+	var buf bytes.Buffer
+	if err := Render(&buf, result, FormatParquet); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	b := buf.Bytes()
+	if !bytes.HasPrefix(b, []byte("PAR1")) || !bytes.HasSuffix(b, []byte("PAR1")) {
+		t.Fatalf("missing PAR1 magic at start or end")
+	}
+	footer := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	if footer <= 0 || footer > len(b)-12 {
+		t.Fatalf("footer length %d does not fit a %d-byte file", footer, len(b))
+	}
+	meta := string(b[len(b)-8-footer : len(b)-8])
+	for _, col := range []string{"series_id", "date", "value", "value_raw"} {
+		if !strings.Contains(meta, col) {
+			t.Errorf("footer missing column %q", col)
+		}
+	}
+
+	// 2024-01-01 is day 19723 of the Unix epoch.
+	day := make([]byte, 4)
+	binary.LittleEndian.PutUint32(day, 19723)
+	if !bytes.Contains(b, day) {
+		t.Errorf("date column missing day 19723")
+	}
+	value := make([]byte, 8)
+	binary.LittleEndian.PutUint64(value, math.Float64bits(28623.5))
+	if !bytes.Contains(b, value) {
+		t.Errorf("value column missing 28623.5")
+	}
+	nan := make([]byte, 8)
+	binary.LittleEndian.PutUint64(nan, math.Float64bits(math.NaN()))
+	if bytes.Contains(b, nan) {
+		t.Errorf("NaN should be written as null, not stored as a double")
+	}
+}
+
+func TestRLEBools_RunsWithLengthPrefix(t *testing.T) {
+	got := rleBools([]bool{true, true, true, false, true})
+	want := []byte{6, 0, 0, 0, 3 << 1, 1, 1 << 1, 0, 1 << 1, 1}
+	if !bytes.Equal(got, want) {
+		t.Errorf("rleBools = %v, want %v", got, want)
+	}
+}
+
+func TestRenderParquet_RejectsNonObservations(t *testing.T) {
+	var buf bytes.Buffer
+	err := Render(&buf, &model.Result{Kind: model.KindSeriesMeta, Data: &model.SeriesMeta{ID: "GDP"}}, FormatParquet)
+	if err == nil {
+		t.Fatal("expected an error for non-observation results")
+	}
+	if err := RenderParquet(&buf, []*model.SeriesData{{SeriesID: "GDP"}}); err == nil {
+		t.Fatal("expected an error when there are no observations")
+	}
+}
@@ -28,6 +28,16 @@ const (
 	FormatCSV   = "csv"
 	FormatTSV   = "tsv"
 	FormatMD    = "md"
+	FormatYAML  = "yaml"
+	// FormatXLSX writes an Excel workbook. It is binary, so it is only
+	// offered for observations written to a file.
+	FormatXLSX = "xlsx"
+	// FormatParquet writes a columnar Parquet file, likewise binary and
+	// observation-only.
+	FormatParquet = "parquet"
+	// FormatTemplate runs output through the user's Go template; see
+	// SetTemplate.
+	FormatTemplate = "tmpl"
 )
 
 // Render writes result to w in the specified format.
@@ -43,10 +53,18 @@ func Render(w io.Writer, result *model.Result, format string) error {
 		return renderDelimited(w, result, '\t')
 	case FormatMD:
 		return renderMarkdown(w, result)
+	case FormatYAML:
+		return renderYAML(w, result)
+	case FormatXLSX:
+		return renderXLSX(w, result)
+	case FormatParquet:
+		return renderParquet(w, result)
+	case FormatTemplate:
+		return renderTemplate(w, result)
 	case FormatTable, "":
 		return renderTable(w, result)
 	default:
-		return fmt.Errorf("unknown format %q: choose table|json|jsonl|csv|tsv|md", format)
+		return fmt.Errorf("unknown format %q: choose table|json|jsonl|csv|tsv|md|yaml", format)
 	}
 }
 
@@ -524,10 +542,14 @@ func renderTagsTable(w io.Writer, tags []model.Tag) error {
 // RenderReleases writes a release slice using the given format.
 func RenderReleases(w io.Writer, releases []model.Release, format string) error {
 	switch format {
+	case FormatTemplate:
+		return renderTemplate(w, &model.Result{Kind: model.KindRelease, Data: releases})
 	case FormatJSON, FormatJSONL:
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		return enc.Encode(releases)
+	case FormatYAML:
+		return EncodeYAML(w, releases)
 	case FormatCSV, FormatTSV:
 		sep := ','
 		if format == FormatTSV {
@@ -549,10 +571,14 @@ func RenderReleases(w io.Writer, releases []model.Release, format string) error
 // RenderSources writes a source slice using the given format.
 func RenderSources(w io.Writer, sources []model.Source, format string) error {
 	switch format {
+	case FormatTemplate:
+		return renderTemplate(w, &model.Result{Kind: model.KindSource, Data: sources})
 	case FormatJSON, FormatJSONL:
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		return enc.Encode(sources)
+	case FormatYAML:
+		return EncodeYAML(w, sources)
 	case FormatCSV, FormatTSV:
 		sep := ','
 		if format == FormatTSV {
@@ -574,10 +600,14 @@ func RenderSources(w io.Writer, sources []model.Source, format string) error {
 // RenderCategories writes a category slice using the given format.
 func RenderCategories(w io.Writer, cats []model.Category, format string) error {
 	switch format {
+	case FormatTemplate:
+		return renderTemplate(w, &model.Result{Kind: model.KindCategory, Data: cats})
 	case FormatJSON, FormatJSONL:
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		return enc.Encode(cats)
+	case FormatYAML:
+		return EncodeYAML(w, cats)
 	default:
 		return renderCategoriesTable(w, cats)
 	}
@@ -586,10 +616,14 @@ func RenderCategories(w io.Writer, cats []model.Category, format string) error {
 // RenderTags writes a tag slice using the given format.
 func RenderTags(w io.Writer, tags []model.Tag, format string) error {
 	switch format {
+	case FormatTemplate:
+		return renderTemplate(w, &model.Result{Kind: model.KindTag, Data: tags})
 	case FormatJSON, FormatJSONL:
 		enc := json.NewEncoder(w)
 		enc.SetIndent("", "  ")
 		return enc.Encode(tags)
+	case FormatYAML:
+		return EncodeYAML(w, tags)
 	case FormatCSV, FormatTSV:
 		sep := ','
 		if format == FormatTSV {
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+// ─── Template ─────────────────────────────────────────────────────────────────
+
+// activeTemplate is the template FormatTemplate renders with, set once per
+// invocation from --template by SetTemplate.
+var activeTemplate *template.Template
+
+// TemplateObservation is the value a template sees for each observation. It
+// carries the same fields as a JSONL row, plus IsNaN for missing values.
+type TemplateObservation struct {
+	SeriesID string
+	Date     TemplateDate
+	Value    float64
+	ValueRaw string
+	IsNaN    bool
+}
+
+// TemplateDate is an observation date. It has every time.Time method, so
+// {{.Date.Year}} and {{.Date.Format "Jan 2006"}} work, but prints as
+// YYYY-MM-DD on its own.
+type TemplateDate struct {
+	time.Time
+}
+
+func (d TemplateDate) String() string {
+	return d.Format("2006-01-02")
+}
+
+// templateFuncs are available in every template:
+//
+//	formatFloat  formats a value the way table output does (NaN → ".")
+//	isNaN        reports whether a value is missing
+var templateFuncs = template.FuncMap{
+	"formatFloat": formatValue,
+	"isNaN":       math.IsNaN,
+}
+
+// ParseTemplate parses a Go text/template given inline or, with a leading
+// "@", read from the named file.
+func ParseTemplate(spec string) (*template.Template, error) {
+	text := spec
+	if path, ok := strings.CutPrefix(spec, "@"); ok {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading template: %w", err)
+		}
+		text = string(b)
+	}
+	return template.New("template").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// SetTemplate makes t the template used by FormatTemplate.
+func SetTemplate(t *template.Template) {
+	activeTemplate = t
+}
+
+// RenderTemplate parses tmpl as ParseTemplate does and writes result through
+// it, without changing the template FormatTemplate uses.
+func RenderTemplate(result *model.Result, tmpl string, w io.Writer) error {
+	t, err := ParseTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+	return executeTemplate(w, t, result)
+}
+
+func renderTemplate(w io.Writer, result *model.Result) error {
+	if activeTemplate == nil {
+		return fmt.Errorf("tmpl format needs a template; use --template")
+	}
+	return executeTemplate(w, activeTemplate, result)
+}
+
+// executeTemplate runs t once per observation for series data, once per
+// series for series metadata, and once with the whole Result for anything
+// else. Each execution ends on its own line.
+func executeTemplate(w io.Writer, t *template.Template, result *model.Result) error {
+	switch data := result.Data.(type) {
+	case *model.SeriesData:
+		if result.Kind != model.KindSeriesData {
+			break
+		}
+		for _, o := range data.Obs {
+			row := TemplateObservation{
+				SeriesID: data.SeriesID,
+				Date:     TemplateDate{o.Date},
+				Value:    o.Value,
+				ValueRaw: o.ValueRaw,
+				IsNaN:    math.IsNaN(o.Value),
+			}
+			if err := executeTemplateLine(w, t, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []model.SeriesMeta:
+		for _, meta := range data {
+			if err := executeTemplateLine(w, t, meta); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *model.SeriesMeta:
+		return executeTemplateLine(w, t, data)
+	}
+	return executeTemplateLine(w, t, result)
+}
+
+func executeTemplateLine(w io.Writer, t *template.Template, data any) error {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return fmt.Errorf("template: %w", err)
+	}
+	if buf.Len() > 0 && !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+func useTemplate(t *testing.T, spec string) {
+	t.Helper()
+	tmpl, err := ParseTemplate(spec)
+	if err != nil {
+		t.Fatalf("ParseTemplate: %v", err)
+	}
+	SetTemplate(tmpl)
+	t.Cleanup(func() { SetTemplate(nil) })
+}
+
+func TestRenderTemplate_OneLinePerObservation(t *testing.T) {
+	useTemplate(t, `{{.SeriesID}} {{.Date}} {{if isNaN .Value}}missing{{else}}{{formatFloat .Value}}{{end}}`)
+	result := &model.Result{
+		Kind: model.KindSeriesData,
+		Data: &model.SeriesData{
+			SeriesID: "UNRATE",
+			Obs: []model.Observation{
+				{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 3.7, ValueRaw: "3.7"},
+				{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+				{Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Value: 4, ValueRaw: "4"},
+			},
+		},
+	}
+	var buf strings.Builder
+	if err := Render(&buf, result, FormatTemplate); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	want := "UNRATE 2024-01-01 3.7\nUNRATE 2024-02-01 missing\nUNRATE 2024-03-01 4.0\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderTemplate_WholeResultAndFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tags.tmpl")
+	if err := os.WriteFile(path, []byte("{{range .Data}}{{.Name}};{{end}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	useTemplate(t, "@"+path)
+	var buf strings.Builder
+	if err := RenderTags(&buf, []model.Tag{{Name: "gdp"}, {Name: "usa"}}, FormatTemplate); err != nil {
+		t.Fatalf("RenderTags: %v", err)
+	}
+	if buf.String() != "gdp;usa;\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
+func TestRenderTemplate_Errors(t *testing.T) {
+	var buf strings.Builder
+	result := &model.Result{Kind: model.KindSeriesMeta, Data: &model.SeriesMeta{ID: "GDP"}}
+	if err := Render(&buf, result, FormatTemplate); err == nil {
+		t.Error("expected an error with no template set")
+	}
+	useTemplate(t, "{{.Nope}}")
+	if err := Render(&buf, result, FormatTemplate); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestRenderTemplateDateAndMissing(t *testing.T) {
+	result := &model.Result{
+		Kind: model.KindSeriesData,
+		Data: &model.SeriesData{
+			SeriesID: "UNRATE",
+			Obs: []model.Observation{
+				{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 3.7, ValueRaw: "3.7"},
+				{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+			},
+		},
+	}
+	var buf strings.Builder
+	tmpl := `{{.Date}} {{.Date.Format "Jan 2006"}} {{.Date.Year}} {{if .IsNaN}}NA ({{.ValueRaw}}){{else}}{{.Value}}{{end}}`
+	if err := RenderTemplate(result, tmpl, &buf); err != nil {
+		t.Fatalf("RenderTemplate: %v", err)
+	}
+	want := "2024-01-01 Jan 2024 2024 3.7\n2024-02-01 Feb 2024 2024 NA (.)\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+	if activeTemplate != nil {
+		t.Error("RenderTemplate should not set the --template template")
+	}
+}
+
+func TestRenderTemplateSeriesMetaPerSeries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "meta.tmpl")
+	if err := os.WriteFile(path, []byte("{{.ID}}|{{.Title}}|{{.Frequency}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result := &model.Result{
+		Kind: model.KindSeriesMeta,
+		Data: []model.SeriesMeta{
+			{ID: "GDP", Title: "Gross Domestic Product", Frequency: "Quarterly"},
+			{ID: "UNRATE", Title: "Unemployment Rate", Frequency: "Monthly"},
+		},
+	}
+	var buf strings.Builder
+	if err := RenderTemplate(result, "@"+path, &buf); err != nil {
+		t.Fatalf("RenderTemplate: %v", err)
+	}
+	if want := "GDP|Gross Domestic Product|Quarterly\nUNRATE|Unemployment Rate|Monthly\n"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRenderTemplateSyntaxError(t *testing.T) {
+	err := RenderTemplate(&model.Result{}, "{{.Date", &strings.Builder{})
+	if err == nil || !strings.Contains(err.Error(), "unclosed action") {
+		t.Errorf("expected the parse error to name the problem, got %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+// ─── XLSX ─────────────────────────────────────────────────────────────────────
+
+// An .xlsx workbook is a zip of SpreadsheetML parts. Only the handful of parts
+// Excel needs to open a workbook are written: observation sheets use a date
+// cell style and inline strings, so no shared-string table is required.
+
+// xlsxEpoch is day zero of Excel's 1900 date system, chosen so that serial
+// numbers after February 1900 come out right.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxDateStyle is the cellXfs index of the yyyy-mm-dd date style in
+// xlsxStyles.
+const xlsxDateStyle = 1
+
+// xlsxMetaSheet is the name of the sheet listing series metadata.
+const xlsxMetaSheet = "Metadata"
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>
+<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>
+</styleSheet>
+`
+
+// xlsxCell is one cell of a sheet row. A nil cell is left empty.
+type xlsxCell interface{}
+
+// xlsxDate marks a cell to be written as an Excel date.
+type xlsxDate time.Time
+
+// RenderWorkbook writes series to w as an .xlsx workbook: one sheet of dates
+// and values per series, named by series ID, followed by a Metadata sheet.
+// Dates are real date cells and NaN values are empty cells.
+func RenderWorkbook(w io.Writer, series []*model.SeriesData) error {
+	if len(series) == 0 {
+		return fmt.Errorf("xlsx: no series to write")
+	}
+	names := make([]string, 0, len(series)+1)
+	sheets := make([][][]xlsxCell, 0, len(series)+1)
+	used := map[string]bool{strings.ToLower(xlsxMetaSheet): true}
+	metaRows := [][]xlsxCell{{"series_id", "title", "units", "frequency", "seasonal_adjustment", "last_updated", "citation_text"}}
+	for _, sd := range series {
+		rows := make([][]xlsxCell, 0, len(sd.Obs)+1)
+		rows = append(rows, []xlsxCell{"date", "value"})
+		for _, o := range sd.Obs {
+			var v xlsxCell
+			if !math.IsNaN(o.Value) {
+				v = o.Value
+			}
+			rows = append(rows, []xlsxCell{xlsxDate(o.Date), v})
+		}
+		names = append(names, xlsxSheetName(sd.SeriesID, used))
+		sheets = append(sheets, rows)
+
+		row := []xlsxCell{sd.SeriesID, nil, nil, nil, nil, nil, nil}
+		if m := sd.Meta; m != nil {
+			row = []xlsxCell{sd.SeriesID, m.Title, m.Units, m.Frequency, m.SeasonalAdjustment, m.LastUpdated, m.CitationText}
+		}
+		metaRows = append(metaRows, row)
+	}
+	names = append(names, xlsxMetaSheet)
+	sheets = append(sheets, metaRows)
+
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>
+`},
+		{"xl/workbook.xml", xlsxWorkbook(names)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, rows := range sheets {
+		parts = append(parts, struct{ name, body string }{
+			fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxSheet(rows),
+		})
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return fmt.Errorf("xlsx: %w", err)
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return fmt.Errorf("xlsx: %w", err)
+		}
+	}
+	return zw.Close()
+}
+
+func renderXLSX(w io.Writer, result *model.Result) error {
+	sd, ok := result.Data.(*model.SeriesData)
+	if result.Kind != model.KindSeriesData || !ok {
+		return fmt.Errorf("xlsx output is only available for observations")
+	}
+	return RenderWorkbook(w, []*model.SeriesData{sd})
+}
+
+// xlsxSheetName makes id a valid, unique sheet name: at most 31 characters,
+// none of []:*?/\, and not already in used (compared case-insensitively, as
+// Excel does).
+func xlsxSheetName(id string, used map[string]bool) string {
+	base := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, id)
+	if base == "" {
+		base = "Sheet"
+	}
+	if len(base) > 31 {
+		base = base[:31]
+	}
+	name := base
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		suffix := fmt.Sprintf("~%d", n)
+		name = base
+		if len(name)+len(suffix) > 31 {
+			name = name[:31-len(suffix)]
+		}
+		name += suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func xlsxContentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString("</Types>\n")
+	return b.String()
+}
+
+func xlsxWorkbook(names []string) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range names {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), i+1, i+1)
+	}
+	b.WriteString("</sheets></workbook>\n")
+	return b.String()
+}
+
+// xlsxWorkbookRels links sheet i to rId<i>; the styles part follows them.
+func xlsxWorkbookRels(sheets int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	b.WriteString("</Relationships>\n")
+	return b.String()
+}
+
+func xlsxSheet(rows [][]xlsxCell) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := xlsxColumn(c) + strconv.Itoa(r+1)
+			switch v := cell.(type) {
+			case nil:
+			case string:
+				if v != "" {
+					fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(v))
+				}
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'g', -1, 64))
+			case xlsxDate:
+				days := time.Time(v).Sub(xlsxEpoch).Hours() / 24
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, xlsxDateStyle, strconv.FormatFloat(days, 'f', -1, 64))
+			}
+		}
+		b.WriteString("</row>")
+	}
+	b.WriteString("</sheetData></worksheet>\n")
+	return b.String()
+}
+
+// xlsxColumn returns the column letters for zero-based index c (0 → A).
+func xlsxColumn(c int) string {
+	var s string
+	for c++; c > 0; c = (c - 1) / 26 {
+		s = string(rune('A'+(c-1)%26)) + s
+	}
+	return s
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
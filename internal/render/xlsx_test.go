@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+// readXLSX unzips a workbook into part name → contents and checks every part
+// is well-formed XML.
+func readXLSX(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatalf("not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+		dec := xml.NewDecoder(bytes.NewReader(body))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed XML: %v", f.Name, err)
+			}
+		}
+		parts[f.Name] = string(body)
+	}
+	return parts
+}
+
+func TestRenderXLSX_SeriesData_DateCellsAndEmptyNaN(t *testing.T) {
+	result := &model.Result{
+		Kind: model.KindSeriesData,
+		Data: &model.SeriesData{
+			SeriesID: "GDP",
+			Meta:     &model.SeriesMeta{ID: "GDP", Title: "Gross Domestic Product & Income", CitationText: "Source: BEA via FRED"},
+			Obs: []model.Observation{
+				{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 28623.5, ValueRaw: "28623.5"},
+				{Date: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, result, FormatXLSX); err != nil {
+		t.Fatalf("Render(xlsx): %v", err)
+	}
+	parts := readXLSX(t, buf.Bytes())
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("workbook missing part %s", name)
+		}
+	}
+	if wb := parts["xl/workbook.xml"]; !strings.Contains(wb, `name="GDP"`) || !strings.Contains(wb, `name="Metadata"`) {
+		t.Errorf("sheet names wrong: %s", wb)
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	// 2024-01-01 is Excel serial 45292, styled as a date.
+	if !strings.Contains(sheet, `<c r="A2" s="1"><v>45292</v></c><c r="B2"><v>28623.5</v></c>`) {
+		t.Errorf("first observation not a date cell and value: %s", sheet)
+	}
+	if !strings.Contains(sheet, `<row r="3"><c r="A3" s="1"><v>45383</v></c></row>`) {
+		t.Errorf("NaN observation should leave the value cell empty: %s", sheet)
+	}
+	if meta := parts["xl/worksheets/sheet2.xml"]; !strings.Contains(meta, "Gross Domestic Product &amp; Income") ||
+		!strings.Contains(meta, "Source: BEA via FRED") {
+		t.Errorf("metadata sheet missing escaped title or citation: %s", meta)
+	}
+}
+
+func TestRenderWorkbook_OneSheetPerSeries(t *testing.T) {
+	obs := []model.Observation{{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 1}}
+	var buf bytes.Buffer
+	err := RenderWorkbook(&buf, []*model.SeriesData{
+		{SeriesID: "UNRATE", Obs: obs},
+		{SeriesID: "CPIAUCSL", Obs: obs},
+		{SeriesID: "metadata", Obs: obs},
+	})
+	if err != nil {
+		t.Fatalf("RenderWorkbook: %v", err)
+	}
+	parts := readXLSX(t, buf.Bytes())
+	wb := parts["xl/workbook.xml"]
+	for _, want := range []string{`name="UNRATE" sheetId="1"`, `name="CPIAUCSL" sheetId="2"`, `name="metadata~2" sheetId="3"`, `name="Metadata" sheetId="4"`} {
+		if !strings.Contains(wb, want) {
+			t.Errorf("workbook.xml missing %s: %s", want, wb)
+		}
+	}
+	if _, ok := parts["xl/worksheets/sheet4.xml"]; !ok {
+		t.Error("expected four worksheets")
+	}
+}
+
+func TestRenderXLSX_RejectsNonObservationResults(t *testing.T) {
+	err := Render(&bytes.Buffer{}, &model.Result{Kind: model.KindSeriesMeta, Data: &model.SeriesMeta{ID: "GDP"}}, FormatXLSX)
+	if err == nil || !strings.Contains(err.Error(), "only available for observations") {
+		t.Fatalf("expected xlsx to reject series metadata, got %v", err)
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	for c, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(c); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", c, got, want)
+		}
+	}
+}
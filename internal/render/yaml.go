@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ─── YAML ─────────────────────────────────────────────────────────────────────
+
+// YAML output is the JSON output re-encoded, so field names, key order, null
+// for NaN, and RFC 3339 dates are identical across the two formats.
+
+func renderYAML(w io.Writer, result *model.Result) error {
+	var buf bytes.Buffer
+	if err := renderJSON(&buf, result); err != nil {
+		return err
+	}
+	return jsonToYAML(w, buf.Bytes())
+}
+
+// EncodeYAML writes v as YAML via its JSON encoding, for commands that
+// print their own structures rather than a Result.
+func EncodeYAML(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return jsonToYAML(w, b)
+}
+
+// jsonToYAML parses JSON as YAML (JSON is a subset) into a node tree, which
+// keeps key order, then drops the flow and quoting styles carried over from
+// the JSON syntax so the result is block-style YAML.
+func jsonToYAML(w io.Writer, b []byte) error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("yaml: %w", err)
+	}
+	clearYAMLStyle(&doc)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("yaml: %w", err)
+	}
+	return enc.Close()
+}
+
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearYAMLStyle(c)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package render
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderYAML_SeriesData_NullNaNAndISODates(t *testing.T) {
+	result := &model.Result{
+		Kind:    model.KindSeriesData,
+		Command: "obs get UNRATE",
+		Data: &model.SeriesData{
+			SeriesID: "UNRATE",
+			Obs: []model.Observation{
+				{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 3.7, ValueRaw: "3.7"},
+				{Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+			},
+		},
+	}
+	var buf strings.Builder
+	if err := Render(&buf, result, FormatYAML); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	out := buf.String()
+	if strings.ContainsAny(out, "{}") {
+		t.Errorf("expected block-style YAML\n%s", out)
+	}
+	if !strings.HasPrefix(out, "kind: series_data\n") {
+		t.Errorf("expected envelope key order to match JSON\n%s", out)
+	}
+
+	var doc struct {
+		Kind string `yaml:"kind"`
+		Data struct {
+			SeriesID     string `yaml:"series_id"`
+			Observations []struct {
+				Date     string   `yaml:"date"`
+				Value    *float64 `yaml:"value"`
+				ValueRaw string   `yaml:"value_raw"`
+			} `yaml:"observations"`
+		} `yaml:"data"`
+	}
+	if err := yaml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, out)
+	}
+	obs := doc.Data.Observations
+	if doc.Data.SeriesID != "UNRATE" || len(obs) != 2 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	if obs[0].Date != "2024-01-01T00:00:00Z" || obs[0].Value == nil || *obs[0].Value != 3.7 {
+		t.Errorf("first observation = %+v", obs[0])
+	}
+	if obs[1].Value != nil || obs[1].ValueRaw != "." {
+		t.Errorf("NaN should be null, got %+v", obs[1])
+	}
+}
+
+func TestRenderTags_YAMLSequence(t *testing.T) {
+	var buf strings.Builder
+	if err := RenderTags(&buf, []model.Tag{{Name: "gdp", Popularity: 90}, {Name: "usa"}}, FormatYAML); err != nil {
+		t.Fatalf("RenderTags: %v", err)
+	}
+	if out := buf.String(); !strings.HasPrefix(out, "- name: gdp\n") || !strings.Contains(out, "- name: usa\n") {
+		t.Errorf("expected a YAML sequence of tags\n%s", out)
+	}
+}
+
+func TestRenderYAML_SeriesMetaRoundTrip(t *testing.T) {
+	meta := model.SeriesMeta{
+		ID:                 "UNRATE",
+		Title:              "Unemployment Rate: 16 years & over",
+		Frequency:          "Monthly",
+		Units:              "Percent",
+		SeasonalAdjustment: "Seasonally Adjusted",
+		LastUpdated:        "2024-03-08 07:44:02-06",
+		Popularity:         94,
+		Notes:              "line one\nline two: with a colon",
+		SourceNames:        []string{"U.S. Bureau of Labor Statistics"},
+	}
+	var buf strings.Builder
+	if err := Render(&buf, &model.Result{Kind: model.KindSeriesMeta, Data: &meta}, FormatYAML); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	// Decode the YAML generically and map it back through the JSON field
+	// names, which the YAML keys are meant to share.
+	var doc map[string]any
+	if err := yaml.Unmarshal([]byte(buf.String()), &doc); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, buf.String())
+	}
+	b, err := json.Marshal(doc["data"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got model.SeriesMeta
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, meta) {
+		t.Errorf("round trip mismatch:\ngot  %+v\nwant %+v", got, meta)
+	}
+}
+
+func TestRenderYAML_ObservationsSequence(t *testing.T) {
+	result := &model.Result{
+		Kind: model.KindSeriesData,
+		Data: &model.SeriesData{
+			SeriesID: "GDP",
+			Obs: []model.Observation{
+				{Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN(), ValueRaw: "."},
+				{Date: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Value: 28000, ValueRaw: "28000"},
+			},
+		},
+	}
+	var buf strings.Builder
+	if err := Render(&buf, result, FormatYAML); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(buf.String()), &doc); err != nil {
+		t.Fatal(err)
+	}
+	obs := yamlLookup(t, &doc, "data", "observations")
+	if obs.Kind != yaml.SequenceNode || len(obs.Content) != 2 {
+		t.Fatalf("observations should be a two-item sequence, got kind %v with %d items", obs.Kind, len(obs.Content))
+	}
+	if v := yamlLookup(t, obs.Content[0], "value"); v.Tag != "!!null" {
+		t.Errorf("NaN should be null, got %s %q", v.Tag, v.Value)
+	}
+	if strings.Contains(buf.String(), ".nan") || strings.Contains(buf.String(), "NaN") {
+		t.Errorf("output should not use a NaN literal\n%s", buf.String())
+	}
+}
+
+// yamlLookup follows mapping keys from n, failing the test if one is missing.
+func yamlLookup(t *testing.T, n *yaml.Node, keys ...string) *yaml.Node {
+	t.Helper()
+	if n.Kind == yaml.DocumentNode {
+		n = n.Content[0]
+	}
+	for _, k := range keys {
+		found := false
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == k {
+				n, found = n.Content[i+1], true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("key %q not found", k)
+		}
+	}
+	return n
+}
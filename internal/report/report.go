@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package report renders a multi-series summary document — a chart, summary
+// statistics, and a linear trend for each series — as HTML or Markdown.
+package report
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/analyze"
+	"github.com/derickschaefer/reserve/internal/app"
+	"github.com/derickschaefer/reserve/internal/chart"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/transform"
+)
+
+// Report output formats.
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "md"
+)
+
+//go:embed templates/report.html.tmpl templates/report.md.tmpl
+var templates embed.FS
+
+// ReportOptions controls what Generate includes and how it is written.
+type ReportOptions struct {
+	// Start and End bound the observations, inclusive, as YYYY-MM-DD. Empty
+	// means the whole series.
+	Start string
+	End   string
+	// Format is FormatHTML or FormatMarkdown. Empty = FormatHTML.
+	Format string
+	// Title heads the document. Empty = "Series report".
+	Title string
+	// GeneratedAt is the timestamp printed in the report. Zero = now.
+	GeneratedAt time.Time
+}
+
+// reportData is what the templates render.
+type reportData struct {
+	Title       string
+	GeneratedAt string
+	Period      string
+	Series      []seriesSection
+}
+
+type seriesSection struct {
+	ID       string
+	Title    string
+	Units    string
+	Citation string
+	Summary  analyze.Summary
+	Trend    *analyze.TrendResult // nil when the series is too short to fit
+	SVG      htmltemplate.HTML    // HTML reports
+	Plot     string               // Markdown reports
+}
+
+// Generate loads each series (see app.LoadObservations), cuts it to the
+// options' date range, and writes the report to w. Series metadata such as
+// titles and citations comes from the local store when it is cached there.
+func Generate(deps *app.Deps, seriesIDs []string, opts ReportOptions, w io.Writer) error {
+	if len(seriesIDs) == 0 {
+		return fmt.Errorf("report: no series given")
+	}
+	format := opts.Format
+	if format == "" {
+		format = FormatHTML
+	}
+	if format != FormatHTML && format != FormatMarkdown {
+		return fmt.Errorf("report: unknown format %q (use html or md)", format)
+	}
+	start, end, err := parseBounds(opts.Start, opts.End)
+	if err != nil {
+		return err
+	}
+
+	generated := opts.GeneratedAt
+	if generated.IsZero() {
+		generated = time.Now()
+	}
+	data := reportData{
+		Title:       opts.Title,
+		GeneratedAt: generated.UTC().Format("2006-01-02 15:04 UTC"),
+		Period:      periodLabel(opts.Start, opts.End),
+	}
+	if data.Title == "" {
+		data.Title = "Series report"
+	}
+
+	for _, id := range seriesIDs {
+		obs, err := app.LoadObservations(context.Background(), deps, id)
+		if err != nil {
+			return fmt.Errorf("report: %s: %w", id, err)
+		}
+		obs = transform.Filter(obs, transform.DateWindow(start, end))
+		if len(obs) == 0 {
+			return fmt.Errorf("report: %s has no observations in %s", id, data.Period)
+		}
+		section := seriesSection{ID: id, Summary: analyze.Summarize(id, obs)}
+		if deps.Store != nil {
+			if meta, ok, err := deps.Store.GetSeriesMeta(id); err == nil && ok {
+				section.Title, section.Units, section.Citation = meta.Title, meta.Units, meta.CitationText
+			}
+		}
+		if tr, err := analyze.Trend(id, obs, analyze.TrendLinear); err == nil {
+			section.Trend = &tr
+		}
+		if err := drawChart(&section, obs, format); err != nil {
+			return fmt.Errorf("report: %s: %w", id, err)
+		}
+		data.Series = append(data.Series, section)
+	}
+
+	if format == FormatMarkdown {
+		tmpl, err := texttemplate.New("report.md.tmpl").Funcs(templateFuncs).ParseFS(templates, "templates/report.md.tmpl")
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, data)
+	}
+	tmpl, err := htmltemplate.New("report.html.tmpl").Funcs(templateFuncs).ParseFS(templates, "templates/report.html.tmpl")
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
+
+// drawChart renders an inline SVG line chart for HTML, and a text chart for
+// Markdown, where SVG would not display.
+func drawChart(section *seriesSection, obs []model.Observation, format string) error {
+	var b strings.Builder
+	if format == FormatMarkdown {
+		if err := chart.Plot(&b, section.ID, obs, chart.PlotOptions{Width: 80, Height: 12}); err != nil {
+			return err
+		}
+		section.Plot = strings.TrimRight(b.String(), "\n")
+		return nil
+	}
+	if err := chart.PlotSVG(&b, section.ID, obs, chart.PlotSVGOptions{Width: 760, Height: 320, Title: section.ID}); err != nil {
+		return err
+	}
+	// PlotSVG escapes every label it draws, so its output is safe to inline.
+	section.SVG = htmltemplate.HTML(b.String())
+	return nil
+}
+
+var templateFuncs = map[string]any{
+	"num": func(v float64) string { return formatNum(v, 2) },
+	"pct": func(v float64) string {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return "."
+		}
+		return formatNum(v, 2) + "%"
+	},
+	"r2": func(v float64) string { return formatNum(v, 3) },
+}
+
+// formatNum prints v with the given decimals and thousands separators, or
+// "." when it is missing.
+func formatNum(v float64, decimals int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "."
+	}
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	intPart, frac, _ := strings.Cut(s, ".")
+	var b strings.Builder
+	if v < 0 {
+		b.WriteByte('-')
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	if frac != "" {
+		b.WriteByte('.')
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+func parseBounds(start, end string) (time.Time, time.Time, error) {
+	var lo, hi time.Time
+	var err error
+	if start != "" {
+		if lo, err = time.Parse("2006-01-02", start); err != nil {
+			return lo, hi, fmt.Errorf("report: invalid start date %q, expected YYYY-MM-DD", start)
+		}
+	}
+	if end != "" {
+		if hi, err = time.Parse("2006-01-02", end); err != nil {
+			return lo, hi, fmt.Errorf("report: invalid end date %q, expected YYYY-MM-DD", end)
+		}
+	}
+	return lo, hi, nil
+}
+
+func periodLabel(start, end string) string {
+	switch {
+	case start == "" && end == "":
+		return "all available observations"
+	case end == "":
+		return "from " + start
+	case start == "":
+		return "through " + end
+	}
+	return start + " to " + end
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package report
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/app"
+	"github.com/derickschaefer/reserve/internal/config"
+	"github.com/derickschaefer/reserve/internal/model"
+	"github.com/derickschaefer/reserve/internal/store"
+)
+
+// reportTestDeps caches monthly UNRATE and FEDFUNDS for 2019–2021, with
+// metadata for UNRATE only.
+func reportTestDeps(t *testing.T) *app.Deps {
+	t.Helper()
+	s, err := store.Open(filepath.Join(t.TempDir(), "reserve.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	for id, base := range map[string]float64{"UNRATE": 3.5, "FEDFUNDS": 1.5} {
+		var obs []model.Observation
+		for m := 0; m < 36; m++ {
+			obs = append(obs, model.Observation{
+				Date:  time.Date(2019, time.Month(1+m), 1, 0, 0, 0, 0, time.UTC),
+				Value: base + 0.1*float64(m),
+			})
+		}
+		if err := s.PutObs(store.ObsKey(id, "", "", "", "", ""), model.SeriesData{SeriesID: id, Obs: obs}); err != nil {
+			t.Fatalf("put %s: %v", id, err)
+		}
+	}
+	meta := model.SeriesMeta{ID: "UNRATE", Title: "Unemployment Rate", Units: "Percent", CitationText: "U.S. Bureau of Labor Statistics <BLS>"}
+	if err := s.PutSeriesMeta(meta); err != nil {
+		t.Fatalf("put meta: %v", err)
+	}
+	return &app.Deps{Config: &config.Config{}, Store: s}
+}
+
+var reportTestTime = time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+
+func TestGenerateHTML(t *testing.T) {
+	deps := reportTestDeps(t)
+	var buf bytes.Buffer
+	opts := ReportOptions{Start: "2020-01-01", Format: FormatHTML, GeneratedAt: reportTestTime}
+	if err := Generate(deps, []string{"UNRATE", "FEDFUNDS"}, opts, &buf); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>", "<table>", "UNRATE: Unemployment Rate", "FEDFUNDS",
+		"Generated at 2026-03-14 09:30 UTC", "Period: from 2020-01-01",
+		"<td>2020-01-01</td><td>2021-12-01</td><td>24</td>", "<svg",
+		"U.S. Bureau of Labor Statistics &lt;BLS&gt;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("HTML report missing %q", want)
+		}
+	}
+	if n := strings.Count(out, "<svg"); n != 2 {
+		t.Errorf("expected one chart per series, got %d", n)
+	}
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	deps := reportTestDeps(t)
+	var buf bytes.Buffer
+	opts := ReportOptions{Format: FormatMarkdown, Title: "Rates", GeneratedAt: reportTestTime}
+	if err := Generate(deps, []string{"UNRATE", "FEDFUNDS"}, opts, &buf); err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# Rates", "Generated at 2026-03-14 09:30 UTC", "| UNRATE | 2019-01-01 | 2021-12-01 | 36 |",
+		"| FEDFUNDS | up | 1.20 | 1.000 |", "## FEDFUNDS", "```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Markdown report missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "<table>") || strings.Contains(out, "<svg") {
+		t.Error("Markdown report should not contain HTML")
+	}
+}
+
+func TestGenerateRejects(t *testing.T) {
+	deps := reportTestDeps(t)
+	var buf bytes.Buffer
+	if err := Generate(deps, nil, ReportOptions{}, &buf); err == nil {
+		t.Error("expected an error with no series")
+	}
+	if err := Generate(deps, []string{"UNRATE"}, ReportOptions{Format: "pdf"}, &buf); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if err := Generate(deps, []string{"UNRATE"}, ReportOptions{Start: "2030-01-01"}, &buf); err == nil || !strings.Contains(err.Error(), "no observations") {
+		t.Errorf("expected an empty-range error, got %v", err)
+	}
+}
+
+func TestFormatNum(t *testing.T) {
+	for v, want := range map[float64]string{1234567.891: "1,234,567.89", -0.5: "-0.50", 12: "12.00"} {
+		if got := formatNum(v, 2); got != want {
+			t.Errorf("formatNum(%v) = %q, want %q", v, got, want)
+		}
+	}
+}
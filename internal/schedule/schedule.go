@@ -0,0 +1,359 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package schedule parses cron expressions and runs reserve commands on them.
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ─── Cron expressions ─────────────────────────────────────────────────────────
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month,
+// month, and day of week. Each field is a bit set of the values it matches.
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	// When both day fields are restricted, a day matches if either does,
+	// as in Vixie cron; a "*" day field defers to the other one.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] is value min+i
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a standard five-field cron expression such as
+// "0 8 * * 1-5". Fields accept *, lists, ranges, and /steps; months and
+// weekdays also accept three-letter names, and 7 means Sunday. The
+// @hourly, @daily, @weekly, @monthly, and @yearly macros are recognized.
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if m, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("cron %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(parts))
+	}
+	var sets [5]uint64
+	for i, part := range parts {
+		set, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	c := &Cron{minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4]}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1 // 7 is Sunday too
+	}
+	c.domAny = strings.HasPrefix(parts[2], "*")
+	c.dowAny = strings.HasPrefix(parts[4], "*")
+	return c, nil
+}
+
+func parseCronField(s string, f cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(s, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: invalid step %q", f.name, stepStr)
+			}
+			step = n
+		}
+		lo, hi := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+			var err error
+			if lo, err = cronValue(a, f); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(b, f); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s: range %q runs backwards", f.name, rng)
+			}
+		default:
+			v, err := cronValue(rng, f)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			if !hasStep {
+				hi = v // "5/15" means from 5 to the maximum in steps of 15
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func cronValue(s string, f cronField) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s: %q is not in %d-%d", f.name, s, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Matches reports whether c fires at t, taken to the minute in t's location.
+func (c *Cron) Matches(t time.Time) bool {
+	return c.minute&(1<<uint(t.Minute())) != 0 &&
+		c.hour&(1<<uint(t.Hour())) != 0 &&
+		c.month&(1<<uint(t.Month())) != 0 &&
+		c.dayMatches(t)
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// cronSearchYears bounds Next for expressions that can never fire, such as
+// "0 0 30 2 *".
+const cronSearchYears = 5
+
+// Next returns the first minute strictly after after at which c fires, in
+// after's location, or the zero time if it never fires.
+func (c *Cron) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := after.AddDate(cronSearchYears, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// ─── Jobs ─────────────────────────────────────────────────────────────────────
+
+// CronJob is a reserve command bound to its parsed schedule.
+type CronJob struct {
+	Name string
+	Cron *Cron
+	// Args are the reserve arguments, without the program name.
+	Args []string
+}
+
+// NewCronJob parses spec and command into a CronJob. A leading "reserve" in
+// command is dropped.
+func NewCronJob(name, spec, command string) (CronJob, error) {
+	c, err := ParseCron(spec)
+	if err != nil {
+		return CronJob{}, err
+	}
+	args, err := SplitCommand(command)
+	if err != nil {
+		return CronJob{}, err
+	}
+	if len(args) > 0 && args[0] == "reserve" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return CronJob{}, fmt.Errorf("job %s: empty command", name)
+	}
+	return CronJob{Name: name, Cron: c, Args: args}, nil
+}
+
+// SplitCommand splits a command line into arguments on whitespace, honoring
+// single quotes, double quotes, and backslash escapes outside single quotes.
+func SplitCommand(s string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", s)
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// ─── Scheduler ────────────────────────────────────────────────────────────────
+
+// Clock is the time source a Scheduler waits on; tests substitute a fake.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RealClock is the wall clock.
+var RealClock Clock = realClock{}
+
+// Scheduler wakes at the top of every minute and starts each job whose cron
+// matches that minute. A job still running from an earlier firing is skipped
+// rather than started a second time.
+type Scheduler struct {
+	Clock Clock
+	// Load returns the current jobs. It is called every minute so that jobs
+	// added or removed while the scheduler runs take effect.
+	Load func() ([]CronJob, error)
+	// Exec runs one firing of job, scheduled for at. It is called on its own
+	// goroutine.
+	Exec func(ctx context.Context, job CronJob, at time.Time) error
+	// Logf receives load errors, skipped firings, and job failures. Nil
+	// discards them.
+	Logf func(format string, args ...any)
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// Run fires jobs until ctx is cancelled, then waits for running jobs to
+// return. It returns nil on cancellation.
+func (s *Scheduler) Run(ctx context.Context) error {
+	if s.Load == nil || s.Exec == nil {
+		return fmt.Errorf("schedule: Load and Exec are required")
+	}
+	clock := s.Clock
+	if clock == nil {
+		clock = RealClock
+	}
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		now := clock.Now()
+		tick := now.Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-clock.After(tick.Sub(now)):
+		}
+
+		jobs, err := s.Load()
+		if err != nil {
+			s.logf("loading jobs: %v", err)
+			continue
+		}
+		for _, job := range jobs {
+			if !job.Cron.Matches(tick) {
+				continue
+			}
+			if !s.start(job.Name) {
+				s.logf("%s: skipped %s firing, previous run still in progress", job.Name, tick.Format("2006-01-02 15:04"))
+				continue
+			}
+			wg.Add(1)
+			go func(job CronJob) {
+				defer wg.Done()
+				defer s.finish(job.Name)
+				if err := s.Exec(ctx, job, tick); err != nil {
+					s.logf("%s: %v", job.Name, err)
+				}
+			}(job)
+		}
+	}
+}
+
+func (s *Scheduler) start(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running == nil {
+		s.running = make(map[string]bool)
+	}
+	if s.running[name] {
+		return false
+	}
+	s.running[name] = true
+	return true
+}
+
+func (s *Scheduler) finish(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, name)
+}
+
+func (s *Scheduler) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
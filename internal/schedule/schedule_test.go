@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCronNext(t *testing.T) {
+	cases := []struct {
+		expr, after, want string
+	}{
+		{"0 8 * * 1-5", "2026-01-09 08:00", "2026-01-12 08:00"}, // Friday → Monday
+		{"0 8 * * 1-5", "2026-01-12 07:59", "2026-01-12 08:00"},
+		{"*/15 * * * *", "2026-01-01 10:07", "2026-01-01 10:15"},
+		{"30 9 1,15 * *", "2026-01-15 09:30", "2026-02-01 09:30"},
+		{"0 0 1 jan *", "2026-03-01 00:00", "2027-01-01 00:00"},
+		{"0 12 * * sun", "2026-01-05 00:00", "2026-01-11 12:00"},
+		{"0 12 * * 7", "2026-01-05 00:00", "2026-01-11 12:00"},
+		{"5/20 * * * *", "2026-01-01 10:30", "2026-01-01 10:45"},
+		{"@daily", "2026-01-01 10:30", "2026-01-02 00:00"},
+		// Both day fields restricted: either one matches.
+		{"0 0 13 * 5", "2026-01-01 00:00", "2026-01-02 00:00"},
+		{"0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+	}
+	for _, tc := range cases {
+		c, err := ParseCron(tc.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tc.expr, err)
+		}
+		if got := c.Next(at(tc.after)); !got.Equal(at(tc.want)) {
+			t.Errorf("%q after %s: got %s, want %s", tc.expr, tc.after, got.Format("2006-01-02 15:04"), tc.want)
+		}
+	}
+
+	never, _ := ParseCron("0 0 30 2 *")
+	if got := never.Next(at("2026-01-01 00:00")); !got.IsZero() {
+		t.Errorf("February 30 should never fire, got %s", got)
+	}
+}
+
+func TestParseCronRejects(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "0 24 * * *", "0 0 0 * *", "0 0 * 13 *", "0 0 * * 8", "5-1 * * * *", "*/0 * * * *", "0 0 * * fri-mon"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) should fail", expr)
+		}
+	}
+}
+
+func TestNewCronJobSplitsCommand(t *testing.T) {
+	job, err := NewCronJob("daily", "0 8 * * 1-5", `reserve search "consumer price" --limit 5`)
+	if err != nil {
+		t.Fatalf("NewCronJob: %v", err)
+	}
+	if want := []string{"search", "consumer price", "--limit", "5"}; !reflect.DeepEqual(job.Args, want) {
+		t.Errorf("args = %q, want %q", job.Args, want)
+	}
+	if _, err := NewCronJob("bad", "@daily", `fetch series 'GDP`); err == nil {
+		t.Error("unterminated quote should fail")
+	}
+	if _, err := NewCronJob("bad", "@daily", "reserve"); err == nil {
+		t.Error("empty command should fail")
+	}
+}
+
+// ─── Scheduler ────────────────────────────────────────────────────────────────
+
+// fakeClock only moves when the test calls step. Each After call is
+// announced on waiting so the test knows the scheduler is asleep.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiting: make(chan struct{}, 1)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	c.waiting <- struct{}{}
+	return ch
+}
+
+// step moves the clock to the sleeping scheduler's wake-up time, waits for it
+// to go back to sleep, and returns the time it woke at.
+func (c *fakeClock) step() time.Time {
+	c.mu.Lock()
+	timer := c.timers[0]
+	c.timers = c.timers[1:]
+	c.now = timer.at
+	timer.ch <- timer.at
+	c.mu.Unlock()
+	<-c.waiting
+	return timer.at
+}
+
+func (s *Scheduler) busy(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running[name]
+}
+
+func TestSchedulerFiresOnCron(t *testing.T) {
+	job, err := NewCronJob("weekday-mornings", "*/15 10 * * 1-5", "fetch series UNRATE --store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := newFakeClock(at("2026-01-09 10:40").Add(30 * time.Second)) // a Friday
+	var mu sync.Mutex
+	var fired []string
+	s := &Scheduler{
+		Clock: clock,
+		Load:  func() ([]CronJob, error) { return []CronJob{job}, nil },
+		Exec: func(_ context.Context, j CronJob, when time.Time) error {
+			mu.Lock()
+			defer mu.Unlock()
+			fired = append(fired, when.Format("Mon 15:04"))
+			return nil
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+	<-clock.waiting
+
+	for {
+		woke := clock.step()
+		// Let each firing finish so none is skipped as an overlap.
+		for s.busy(job.Name) {
+			time.Sleep(time.Millisecond)
+		}
+		if !woke.Before(at("2026-01-12 10:20")) {
+			break
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := []string{"Fri 10:45", "Mon 10:00", "Mon 10:15"}; !reflect.DeepEqual(fired, want) {
+		t.Errorf("fired at %v, want %v", fired, want)
+	}
+}
+
+func TestSchedulerSkipsOverlappingRun(t *testing.T) {
+	job, err := NewCronJob("every-minute", "* * * * *", "fetch series GDP --store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := newFakeClock(at("2026-01-05 10:00"))
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var fired, logs []string
+	s := &Scheduler{
+		Clock: clock,
+		Load:  func() ([]CronJob, error) { return []CronJob{job}, nil },
+		Exec: func(_ context.Context, j CronJob, when time.Time) error {
+			mu.Lock()
+			fired = append(fired, when.Format("15:04"))
+			first := len(fired) == 1
+			mu.Unlock()
+			if first {
+				<-release
+			}
+			return nil
+		},
+		Logf: func(format string, args ...any) {
+			mu.Lock()
+			defer mu.Unlock()
+			logs = append(logs, fmt.Sprintf(format, args...))
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+	<-clock.waiting
+
+	clock.step() // 10:01 starts the slow run
+	clock.step() // 10:02 finds it still running
+	close(release)
+	for s.busy(job.Name) {
+		time.Sleep(time.Millisecond)
+	}
+	clock.step() // 10:03 runs again
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if want := []string{"10:01", "10:03"}; !reflect.DeepEqual(fired, want) {
+		t.Errorf("fired at %v, want %v", fired, want)
+	}
+	if len(logs) != 1 || !strings.Contains(logs[0], "skipped 2026-01-05 10:02") {
+		t.Errorf("expected one skip for 10:02, got %q", logs)
+	}
+}
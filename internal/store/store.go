@@ -11,6 +11,7 @@
 //
 //	obs         — accumulated observations keyed by series+params
 //	series_meta — metadata for fetched series
+//	results     — cached analysis Result envelopes keyed by command+series+params
 //	config      — reserved for future use (api_key etc. stay in config.json)
 //	_meta       — internal: schema version, created_at
 //
@@ -19,15 +20,34 @@
 //     envelope level rather than per-row, cutting per-observation storage by ~35%.
 //   - New batch write methods: PutObsBatch, PutSeriesMetaBatch.
 //   - New maintenance method: Compact.
+//
+// Schema v3 changes (from v2):
+//   - New results bucket for opt-in caching of analysis output.
+//   - storedObs may carry response_meta (ETag / Last-Modified) for
+//     conditional refreshes; entries without it still read normally.
+//
+// Concurrency: bbolt takes an exclusive file lock for a read-write handle and
+// a shared lock for a read-only one. Within a process, one Store is safe to
+// share across goroutines (writes are serialized by bbolt). Across processes,
+// any number of OpenReadOnly handles can coexist, but none can be acquired
+// while another process holds a read-write handle, and vice versa; Open and
+// OpenReadOnly give up after a 2s lock timeout.
 package store
 
 import (
+	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,17 +57,26 @@ import (
 )
 
 // Current schema version. Bump when bucket layout or key format changes.
-const schemaVersion = 2
+const schemaVersion = 4
 
 // Bucket name constants.
 var (
 	bucketObs        = []byte("obs")
 	bucketSeriesMeta = []byte("series_meta")
+	bucketResults    = []byte("results")
+	bucketSchedules  = []byte("schedules")
 	bucketInternal   = []byte("_meta")
 )
 
 // AllBuckets lists every user-facing bucket for stats and clear operations.
-var AllBuckets = []string{"obs", "series_meta"}
+// Scheduled jobs are configuration rather than cached data, so the schedules
+// bucket is left out and survives 'reserve cache clear --all'.
+var AllBuckets = []string{"obs", "series_meta", "results"}
+
+// ExportBuckets lists the buckets ExportAll dumps and ImportAll restores:
+// every user-facing bucket plus the scheduled jobs, so a dump moved to
+// another machine keeps its schedules.
+var ExportBuckets = []string{"obs", "series_meta", "results", "schedules"}
 
 // Store wraps a bbolt database.
 type Store struct {
@@ -82,11 +111,43 @@ func Open(path string) (*Store, error) {
 	return s, nil
 }
 
+// OpenReadOnly opens an existing database without taking the exclusive lock,
+// so several inspecting processes can read it at once. No migrations run: the
+// file must exist and already be at the current schema version. Write methods
+// on the returned Store fail with bolt.ErrDatabaseReadOnly.
+func OpenReadOnly(path string) (*Store, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("opening db %s: %w", path, err)
+	}
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 2 * time.Second, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("opening db %s read-only: %w", path, err)
+	}
+
+	s := &Store{db: db}
+	meta, err := s.Metadata()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if meta.SchemaVersion != schemaVersion {
+		db.Close()
+		return nil, fmt.Errorf("db %s is at schema v%d, expected v%d; open it read-write once to migrate",
+			path, meta.SchemaVersion, schemaVersion)
+	}
+	return s, nil
+}
+
 // Close closes the database.
 func (s *Store) Close() error {
 	return s.db.Close()
 }
 
+// ReadOnly reports whether the store was opened with OpenReadOnly.
+func (s *Store) ReadOnly() bool {
+	return s.db.IsReadOnly()
+}
+
 // Path returns the filesystem path of the open database.
 func (s *Store) Path() string {
 	return s.db.Path()
@@ -103,7 +164,11 @@ func (s *Store) Metadata() (Metadata, error) {
 	}
 
 	err := s.db.View(func(tx *bolt.Tx) error {
-		raw := tx.Bucket(bucketInternal).Get([]byte("schema_version"))
+		b := tx.Bucket(bucketInternal)
+		if b == nil {
+			return nil
+		}
+		raw := b.Get([]byte("schema_version"))
 		if raw == nil {
 			return nil
 		}
@@ -157,10 +222,12 @@ func (s *Store) DeleteInternalKey(key string) error {
 // migrate ensures all buckets exist and the schema version is current.
 // v1 → v2: realtime fields moved to envelope level; old obs entries are
 // dropped (pre-release, no installed user data to preserve).
+// v2 → v3: results bucket added; existing data is untouched.
+// v3 → v4: schedules bucket added; existing data is untouched.
 func (s *Store) migrate() error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		// Create all buckets if they don't exist.
-		for _, name := range [][]byte{bucketObs, bucketSeriesMeta, bucketInternal} {
+		for _, name := range [][]byte{bucketObs, bucketSeriesMeta, bucketResults, bucketSchedules, bucketInternal} {
 			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
 				return fmt.Errorf("creating bucket %s: %w", name, err)
 			}
@@ -190,6 +257,11 @@ func (s *Store) migrate() error {
 			if _, err := tx.CreateBucket(bucketObs); err != nil {
 				return fmt.Errorf("recreating obs bucket for v2 migration: %w", err)
 			}
+		}
+
+		// v2 → v3 and v3 → v4 only add the results and schedules buckets,
+		// created above.
+		if existing < schemaVersion {
 			if err := meta.Put([]byte("schema_version"), []byte(fmt.Sprintf("%d", schemaVersion))); err != nil {
 				return err
 			}
@@ -255,6 +327,14 @@ func (s *Store) GetSeriesMeta(id string) (model.SeriesMeta, bool, error) {
 	return meta, meta.ID != "", nil
 }
 
+// DeleteSeriesMeta removes the metadata entry for a series. Deleting an ID
+// that is not stored is not an error.
+func (s *Store) DeleteSeriesMeta(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSeriesMeta).Delete([]byte(id))
+	})
+}
+
 // ListSeriesMeta returns all stored series metadata, sorted by ID.
 func (s *Store) ListSeriesMeta() ([]model.SeriesMeta, error) {
 	var metas []model.SeriesMeta
@@ -271,12 +351,41 @@ func (s *Store) ListSeriesMeta() ([]model.SeriesMeta, error) {
 	return metas, err
 }
 
+// MatchSeriesIDs returns the IDs of stored series whose metadata ID matches
+// the glob pattern (path.Match syntax, e.g. "DGS*"), sorted by ID.
+// Matching is case-insensitive because series IDs are stored upper-cased.
+func (s *Store) MatchSeriesIDs(pattern string) ([]string, error) {
+	pattern = strings.ToUpper(strings.TrimSpace(pattern))
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid series pattern %q: %w", pattern, err)
+	}
+	metas, err := s.ListSeriesMeta()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, m := range metas {
+		if ok, _ := path.Match(pattern, strings.ToUpper(m.ID)); ok {
+			ids = append(ids, m.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
 // ─── Observations ─────────────────────────────────────────────────────────────
 
 // ObsKey builds the canonical key for an observations entry.
 // Format: series:<ID>|start:<date>|end:<date>|freq:<f>|units:<u>|agg:<a>
 // Empty optional fields are omitted.
 func ObsKey(seriesID, start, end, freq, units, agg string) string {
+	return ObsKeyRealtime(seriesID, start, end, freq, units, agg, "", "")
+}
+
+// ObsKeyRealtime is ObsKey for a vintage (point-in-time) request. The realtime
+// window is appended as |rt_start:<date>|rt_end:<date>, so vintages are cached
+// apart from current data; with both empty the key equals ObsKey.
+func ObsKeyRealtime(seriesID, start, end, freq, units, agg, realtimeStart, realtimeEnd string) string {
 	key := "series:" + seriesID
 	if start != "" {
 		key += "|start:" + start
@@ -293,6 +402,12 @@ func ObsKey(seriesID, start, end, freq, units, agg string) string {
 	if agg != "" {
 		key += "|agg:" + agg
 	}
+	if realtimeStart != "" {
+		key += "|rt_start:" + realtimeStart
+	}
+	if realtimeEnd != "" {
+		key += "|rt_end:" + realtimeEnd
+	}
 	return key
 }
 
@@ -319,6 +434,10 @@ type storedObs struct {
 	RealtimeStart string         `json:"realtime_start,omitempty"`
 	RealtimeEnd   string         `json:"realtime_end,omitempty"`
 	Obs           []storedObsRow `json:"observations"`
+	// ResponseMeta holds FRED's cache validators for the fetch that produced
+	// Obs, letting a refresh revalidate instead of re-downloading. Absent in
+	// entries written before it existed or when FRED sent none.
+	ResponseMeta *model.ResponseMeta `json:"response_meta,omitempty"`
 }
 
 // obsToStored converts model.Observation → storedObsRow (NaN → null).
@@ -377,6 +496,7 @@ func (s *Store) PutObs(key string, data model.SeriesData) error {
 		RealtimeStart: rtStart,
 		RealtimeEnd:   rtEnd,
 		Obs:           rows,
+		ResponseMeta:  data.Response,
 	}
 	b, err := json.Marshal(envelope)
 	if err != nil {
@@ -410,6 +530,7 @@ func (s *Store) PutObsBatch(entries map[string]model.SeriesData) error {
 				RealtimeStart: rtStart,
 				RealtimeEnd:   rtEnd,
 				Obs:           rows,
+				ResponseMeta:  data.Response,
 			}
 			b, err := json.Marshal(envelope)
 			if err != nil {
@@ -423,6 +544,55 @@ func (s *Store) PutObsBatch(entries map[string]model.SeriesData) error {
 	})
 }
 
+// PutObsAppend merges data into the observation set already stored under key
+// instead of replacing it: new dates are added, dates already present take the
+// incoming row, and the result is written sorted by date. FetchedAt is reset to
+// now. With no existing entry it behaves like PutObs. The read and write share
+// one transaction, so concurrent appends cannot lose each other's rows.
+func (s *Store) PutObsAppend(key string, data model.SeriesData) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketObs)
+		var envelope storedObs
+		if v := bucket.Get([]byte(key)); v != nil {
+			if err := json.Unmarshal(v, &envelope); err != nil {
+				return fmt.Errorf("decoding existing obs %s: %w", key, err)
+			}
+		}
+
+		byDate := make(map[string]storedObsRow, len(envelope.Obs)+len(data.Obs))
+		for _, r := range envelope.Obs {
+			byDate[r.Date] = r
+		}
+		for _, o := range data.Obs {
+			r := obsToStored(o)
+			byDate[r.Date] = r
+		}
+		rows := make([]storedObsRow, 0, len(byDate))
+		for _, r := range byDate {
+			rows = append(rows, r)
+		}
+		// ISO dates sort lexically in chronological order.
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Date < rows[j].Date })
+
+		if data.SeriesID != "" {
+			envelope.SeriesID = data.SeriesID
+		}
+		if rtStart, rtEnd := realtimeFieldsFromData(data); rtStart != "" || rtEnd != "" {
+			envelope.RealtimeStart, envelope.RealtimeEnd = rtStart, rtEnd
+		}
+		envelope.FetchedAt = time.Now().UTC()
+		envelope.Obs = rows
+		// The merged set no longer matches any single FRED response.
+		envelope.ResponseMeta = nil
+
+		b, err := json.Marshal(envelope)
+		if err != nil {
+			return fmt.Errorf("encoding obs: %w", err)
+		}
+		return bucket.Put([]byte(key), b)
+	})
+}
+
 // GetObs retrieves observations by key.
 // Returns (data, true, nil) if found, (zero, false, nil) if not found.
 func (s *Store) GetObs(key string) (model.SeriesData, bool, error) {
@@ -444,7 +614,90 @@ func (s *Store) GetObs(key string) (model.SeriesData, bool, error) {
 	for i, r := range envelope.Obs {
 		obs[i] = storedToObs(r, envelope.RealtimeStart, envelope.RealtimeEnd)
 	}
-	return model.SeriesData{SeriesID: envelope.SeriesID, Obs: obs}, true, nil
+	return model.SeriesData{SeriesID: envelope.SeriesID, Obs: obs, Response: envelope.ResponseMeta}, true, nil
+}
+
+// GetObsRange retrieves observations by key, keeping only dates within
+// [after, before] inclusive. A zero bound leaves that side open, so two zero
+// bounds match GetObs. Rows outside the range are dropped before they are
+// decoded into observations. Returns (zero, false, nil) if the key is not
+// found and (data, true, nil) if it is, even when no dates fall in range.
+func (s *Store) GetObsRange(key string, after, before time.Time) (model.SeriesData, bool, error) {
+	var envelope storedObs
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketObs).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(v, &envelope)
+	})
+	if err != nil || !found {
+		return model.SeriesData{}, false, err
+	}
+	// ISO dates compare lexically in chronological order.
+	lo, hi := "", ""
+	if !after.IsZero() {
+		lo = after.Format("2006-01-02")
+	}
+	if !before.IsZero() {
+		hi = before.Format("2006-01-02")
+	}
+	obs := make([]model.Observation, 0, len(envelope.Obs))
+	for _, r := range envelope.Obs {
+		if (lo != "" && r.Date < lo) || (hi != "" && r.Date > hi) {
+			continue
+		}
+		obs = append(obs, storedToObs(r, envelope.RealtimeStart, envelope.RealtimeEnd))
+	}
+	return model.SeriesData{SeriesID: envelope.SeriesID, Obs: obs, Response: envelope.ResponseMeta}, true, nil
+}
+
+// Age reports how long ago the observation set under key was fetched, based on
+// the FetchedAt stamp in its envelope. The store never expires data on its own;
+// callers decide what age counts as stale.
+func (s *Store) Age(key string) (time.Duration, error) {
+	fetchedAt, found, err := s.fetchedAt(key)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("no cached observations under key %q", key)
+	}
+	return time.Since(fetchedAt), nil
+}
+
+// IsStale reports whether the observation set under key should be re-fetched:
+// it is missing, maxAge is zero or negative, or it was fetched more than maxAge
+// ago. This is the opt-in expiry check for scheduled refreshes; nothing in the
+// store expires on its own.
+func (s *Store) IsStale(key string, maxAge time.Duration) (bool, error) {
+	if maxAge <= 0 {
+		return true, nil
+	}
+	fetchedAt, found, err := s.fetchedAt(key)
+	if err != nil || !found {
+		return true, err
+	}
+	return time.Since(fetchedAt) > maxAge, nil
+}
+
+// fetchedAt reads only the FetchedAt stamp of an observation envelope.
+func (s *Store) fetchedAt(key string) (time.Time, bool, error) {
+	var envelope struct {
+		FetchedAt time.Time `json:"fetched_at"`
+	}
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketObs).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(v, &envelope)
+	})
+	return envelope.FetchedAt, found, err
 }
 
 // ListObsKeys returns all keys in the obs bucket for a given series prefix.
@@ -473,6 +726,405 @@ func (s *Store) ListObsKeys(seriesID string) ([]string, error) {
 	return keys, err
 }
 
+// DeleteObs removes a single observation set by its full key (see ObsKey).
+// Deleting a key that is not stored is not an error.
+func (s *Store) DeleteObs(key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketObs).Delete([]byte(key))
+	})
+}
+
+// DeleteSeries evicts a series entirely: every cached observation set whose
+// key belongs to seriesID plus its metadata, in one write transaction.
+// Returns the number of entries removed (observation sets and metadata).
+func (s *Store) DeleteSeries(seriesID string) (int, error) {
+	if seriesID == "" {
+		return 0, fmt.Errorf("series ID is required")
+	}
+	keys, err := s.ListObsKeys(seriesID)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		obs := tx.Bucket(bucketObs)
+		for _, key := range keys {
+			if err := obs.Delete([]byte(key)); err != nil {
+				return err
+			}
+			removed++
+		}
+		meta := tx.Bucket(bucketSeriesMeta)
+		if meta.Get([]byte(seriesID)) != nil {
+			if err := meta.Delete([]byte(seriesID)); err != nil {
+				return err
+			}
+			removed++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// ─── Analysis Results ─────────────────────────────────────────────────────────
+
+// PutResult stores an analysis Result envelope under key, replacing any
+// previous entry. Callers build keys from the command, series, and parameters
+// so that a changed input never matches a stale result.
+func (s *Store) PutResult(key string, r model.Result) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("encoding result %s: %w", key, err)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketResults).Put([]byte(key), b)
+	})
+}
+
+// GetResult retrieves a cached Result by key.
+// Returns (result, true, nil) if found, (zero, false, nil) if not found.
+// Data is returned as json.RawMessage; callers decode it into the concrete
+// type they stored.
+func (s *Store) GetResult(key string) (model.Result, bool, error) {
+	var envelope struct {
+		model.Result
+		Data json.RawMessage `json:"data"`
+	}
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketResults).Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(v, &envelope)
+	})
+	if err != nil || !found {
+		return model.Result{}, false, err
+	}
+	r := envelope.Result
+	r.Data = envelope.Data
+	return r, true, nil
+}
+
+// ─── Scheduled Jobs ───────────────────────────────────────────────────────────
+
+// PutScheduledJob stores job under its name, replacing any previous entry.
+func (s *Store) PutScheduledJob(job model.ScheduledJob) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("encoding scheduled job %s: %w", job.Name, err)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSchedules).Put([]byte(job.Name), b)
+	})
+}
+
+// GetScheduledJob retrieves a scheduled job by name.
+// Returns (job, true, nil) if found, (zero, false, nil) if not found.
+func (s *Store) GetScheduledJob(name string) (model.ScheduledJob, bool, error) {
+	var job model.ScheduledJob
+	found := false
+	err := s.db.View(func(tx *bolt.Tx) error {
+		v := tx.Bucket(bucketSchedules).Get([]byte(name))
+		if v == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(v, &job)
+	})
+	return job, found, err
+}
+
+// ListScheduledJobs returns every scheduled job, sorted by name.
+func (s *Store) ListScheduledJobs() ([]model.ScheduledJob, error) {
+	var jobs []model.ScheduledJob
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSchedules).ForEach(func(k, v []byte) error {
+			var job model.ScheduledJob
+			if err := json.Unmarshal(v, &job); err != nil {
+				return fmt.Errorf("decoding scheduled job %s: %w", k, err)
+			}
+			jobs = append(jobs, job)
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+// DeleteScheduledJob removes a scheduled job, reporting whether it existed.
+func (s *Store) DeleteScheduledJob(name string) (bool, error) {
+	found := false
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketSchedules)
+		if b.Get([]byte(name)) == nil {
+			return nil
+		}
+		found = true
+		return b.Delete([]byte(name))
+	})
+	return found, err
+}
+
+// RecordScheduledRun stamps the last run time and status on a scheduled job.
+// A job removed while it was running is not recreated.
+func (s *Store) RecordScheduledRun(name string, at time.Time, status string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketSchedules)
+		v := b.Get([]byte(name))
+		if v == nil {
+			return nil
+		}
+		var job model.ScheduledJob
+		if err := json.Unmarshal(v, &job); err != nil {
+			return fmt.Errorf("decoding scheduled job %s: %w", name, err)
+		}
+		job.LastRunAt = at.UTC()
+		job.LastStatus = status
+		out, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("encoding scheduled job %s: %w", name, err)
+		}
+		return b.Put([]byte(name), out)
+	})
+}
+
+// ─── Export & Import ──────────────────────────────────────────────────────────
+
+// exportRecord is one line of an ExportAll dump. Value holds the stored bytes
+// verbatim, so a dump restores exactly what was on disk regardless of the
+// host architecture.
+type exportRecord struct {
+	Bucket string          `json:"bucket"`
+	Key    string          `json:"key"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// ExportAll streams every entry in ExportBuckets to w as newline-delimited
+// JSON records tagged with their bucket and key.
+func (s *Store) ExportAll(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	return s.db.View(func(tx *bolt.Tx) error {
+		for _, name := range ExportBuckets {
+			b := tx.Bucket([]byte(name))
+			if b == nil {
+				continue
+			}
+			err := b.ForEach(func(k, v []byte) error {
+				return enc.Encode(exportRecord{Bucket: name, Key: string(k), Value: v})
+			})
+			if err != nil {
+				return fmt.Errorf("exporting bucket %s: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ImportAll reads records written by ExportAll and upserts them by key in a
+// single write transaction, so re-importing the same dump is a no-op.
+func (s *Store) ImportAll(r io.Reader) error {
+	_, err := s.ImportAllCounts(r)
+	return err
+}
+
+// ImportAllCounts is ImportAll, also returning the number of records restored
+// per bucket.
+func (s *Store) ImportAllCounts(r io.Reader) (map[string]int, error) {
+	known := make(map[string]bool, len(ExportBuckets))
+	for _, name := range ExportBuckets {
+		known[name] = true
+	}
+
+	counts := make(map[string]int, len(ExportBuckets))
+	dec := json.NewDecoder(r)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for n := 1; ; n++ {
+			var rec exportRecord
+			if err := dec.Decode(&rec); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("record %d: invalid JSON: %w", n, err)
+			}
+			if !known[rec.Bucket] {
+				return fmt.Errorf("record %d: unknown bucket %q", n, rec.Bucket)
+			}
+			if rec.Key == "" || len(rec.Value) == 0 {
+				return fmt.Errorf("record %d: missing key or value", n)
+			}
+			if err := tx.Bucket([]byte(rec.Bucket)).Put([]byte(rec.Key), rec.Value); err != nil {
+				return err
+			}
+			counts[rec.Bucket]++
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ExportCSV writes the cached observations for seriesID to w as CSV with a
+// date,value,value_raw header. Missing values are written as nanSentinel
+// (typically "" or "."). See ExportCSVSeries for how a set is chosen when a
+// series has been cached under several keys.
+func (s *Store) ExportCSV(seriesID string, w io.Writer, nanSentinel string) error {
+	return s.exportCSV([]string{seriesID}, w, nanSentinel, false)
+}
+
+// ExportCSVSeries writes several cached series to a single CSV stream with a
+// leading series_id column. For each series the unparameterised observation
+// set is used when present; otherwise the set with the most observations.
+func (s *Store) ExportCSVSeries(seriesIDs []string, w io.Writer, nanSentinel string) error {
+	return s.exportCSV(seriesIDs, w, nanSentinel, true)
+}
+
+func (s *Store) exportCSV(seriesIDs []string, w io.Writer, nanSentinel string, withID bool) error {
+	cw := csv.NewWriter(w)
+	header := []string{"date", "value", "value_raw"}
+	if withID {
+		header = append([]string{"series_id"}, header...)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, id := range seriesIDs {
+		data, err := s.exportObsSet(id)
+		if err != nil {
+			return err
+		}
+		for _, o := range data.Obs {
+			value := nanSentinel
+			if !o.IsMissing() {
+				value = strconv.FormatFloat(o.Value, 'f', -1, 64)
+			}
+			row := []string{o.Date.Format("2006-01-02"), value, o.ValueRaw}
+			if withID {
+				row = append([]string{id}, row...)
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportCSV parses a CSV file of observations for seriesID. dateCol and
+// valueCol name the header columns to read; when empty they are detected from
+// common headers ("date"/"observation_date" and "value" or the series ID, as
+// in FRED downloads), falling back to the other column of a two-column file.
+// Empty cells and "." are always treated as missing, as is nanSentinel when
+// set. The result is sorted by date and ready for PutObs.
+func ImportCSV(path string, seriesID string, dateCol, valueCol string, nanSentinel string) (model.SeriesData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return model.SeriesData{}, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return model.SeriesData{}, fmt.Errorf("reading CSV header: %w", err)
+	}
+	dateIdx, err := csvColumn(header, dateCol, "date", "observation_date")
+	if err != nil {
+		return model.SeriesData{}, err
+	}
+	valueIdx, err := csvColumn(header, valueCol, "value", seriesID)
+	if err != nil && valueCol == "" && len(header) == 2 {
+		valueIdx, err = 1-dateIdx, nil
+	}
+	if err != nil {
+		return model.SeriesData{}, err
+	}
+
+	var obs []model.Observation
+	for line := 2; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return model.SeriesData{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(rec[dateIdx]))
+		if err != nil {
+			return model.SeriesData{}, fmt.Errorf("line %d: invalid date %q, expected YYYY-MM-DD", line, rec[dateIdx])
+		}
+		raw := strings.TrimSpace(rec[valueIdx])
+		o := model.Observation{Date: date, Value: math.NaN(), ValueRaw: "."}
+		if raw != "" && raw != "." && (nanSentinel == "" || raw != nanSentinel) {
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return model.SeriesData{}, fmt.Errorf("line %d: invalid value %q", line, raw)
+			}
+			o.Value, o.ValueRaw = v, raw
+		}
+		obs = append(obs, o)
+	}
+	if len(obs) == 0 {
+		return model.SeriesData{}, fmt.Errorf("%s contains no observations", path)
+	}
+	sort.SliceStable(obs, func(i, j int) bool { return obs[i].Date.Before(obs[j].Date) })
+	return model.SeriesData{SeriesID: seriesID, Obs: obs}, nil
+}
+
+// csvColumn returns the index of the named header column, or of the first
+// header matching one of the candidates when name is empty (case-insensitive).
+func csvColumn(header []string, name string, candidates ...string) (int, error) {
+	want := candidates
+	if name != "" {
+		want = []string{name}
+	}
+	for _, c := range want {
+		for i, h := range header {
+			if c != "" && strings.EqualFold(strings.TrimSpace(h), c) {
+				return i, nil
+			}
+		}
+	}
+	if name != "" {
+		return 0, fmt.Errorf("column %q not found in CSV header %v", name, header)
+	}
+	return 0, fmt.Errorf("could not detect a %s column in CSV header %v; specify it explicitly", candidates[0], header)
+}
+
+// exportObsSet picks the observation set to export for seriesID.
+func (s *Store) exportObsSet(seriesID string) (model.SeriesData, error) {
+	keys, err := s.ListObsKeys(seriesID)
+	if err != nil {
+		return model.SeriesData{}, err
+	}
+	if len(keys) == 0 {
+		return model.SeriesData{}, fmt.Errorf("no cached observations for %s", seriesID)
+	}
+	var best model.SeriesData
+	for _, key := range keys {
+		data, ok, err := s.GetObs(key)
+		if err != nil {
+			return model.SeriesData{}, err
+		}
+		if !ok {
+			continue
+		}
+		if key == ObsKey(seriesID, "", "", "", "", "") {
+			return data, nil
+		}
+		if len(data.Obs) > len(best.Obs) {
+			best = data
+		}
+	}
+	return best, nil
+}
+
 // ─── Stats & Maintenance ──────────────────────────────────────────────────────
 
 // BucketStats holds row count and byte size for a single bucket.
@@ -487,6 +1139,7 @@ func (s *Store) Stats() ([]BucketStats, error) {
 	buckets := map[string][]byte{
 		"obs":         bucketObs,
 		"series_meta": bucketSeriesMeta,
+		"results":     bucketResults,
 	}
 
 	var stats []BucketStats
@@ -510,6 +1163,22 @@ func (s *Store) Stats() ([]BucketStats, error) {
 	return stats, err
 }
 
+// ObsBytes returns the size of the obs entries stored under keys, counted as
+// Stats counts them: key plus encoded value. Keys with no entry count as zero.
+func (s *Store) ObsBytes(keys []string) (int64, error) {
+	var total int64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketObs)
+		for _, key := range keys {
+			if v := b.Get([]byte(key)); v != nil {
+				total += int64(len(key) + len(v))
+			}
+		}
+		return nil
+	})
+	return total, err
+}
+
 // ClearBucket deletes all entries in the named bucket by drop-and-recreate,
 // which is more efficient than iterating keys and returns pages to bbolt's
 // internal freelist. Note: the database file does not shrink automatically;
@@ -633,3 +1302,235 @@ func (s *Store) Compact() (beforeBytes, afterBytes int64, err error) {
 
 	return beforeBytes, afterBytes, nil
 }
+
+// ─── Verification ─────────────────────────────────────────────────────────────
+
+// CorruptKey identifies a stored value that failed to decode.
+type CorruptKey struct {
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	Error  string `json:"error"`
+}
+
+// VerifyResult summarizes an integrity check of the database.
+type VerifyResult struct {
+	SchemaVersion int          `json:"schema_version"`
+	Buckets       []string     `json:"buckets"`
+	KeysChecked   int          `json:"keys_checked"`
+	CorruptKeys   []CorruptKey `json:"corrupt_keys,omitempty"`
+	PageErrors    []string     `json:"page_errors,omitempty"` // bbolt structural check failures
+}
+
+// OK reports whether verification found no corrupt keys or page errors.
+func (r VerifyResult) OK() bool {
+	return len(r.CorruptKeys) == 0 && len(r.PageErrors) == 0
+}
+
+// Verify checks the database for corruption in a single read transaction.
+// It runs bbolt's page-level consistency check, then decodes every value in
+// every known bucket into its on-disk type. Values in unrecognized buckets
+// only need to be valid JSON. Decode failures are collected in the result
+// rather than returned as an error; the error is reserved for failures to
+// read the database at all.
+func Verify(db *bolt.DB) (VerifyResult, error) {
+	var res VerifyResult
+	err := db.View(func(tx *bolt.Tx) error {
+		for cerr := range tx.Check() {
+			res.PageErrors = append(res.PageErrors, cerr.Error())
+		}
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			bucket := string(name)
+			res.Buckets = append(res.Buckets, bucket)
+			return b.ForEach(func(k, v []byte) error {
+				res.KeysChecked++
+				if err := verifyValue(bucket, string(k), v); err != nil {
+					res.CorruptKeys = append(res.CorruptKeys, CorruptKey{Bucket: bucket, Key: string(k), Error: err.Error()})
+				} else if bucket == string(bucketInternal) && string(k) == "schema_version" {
+					res.SchemaVersion, _ = strconv.Atoi(string(v))
+				}
+				return nil
+			})
+		})
+	})
+	return res, err
+}
+
+// Verify runs Verify against the store's database.
+func (s *Store) Verify() (VerifyResult, error) {
+	return Verify(s.db)
+}
+
+// verifyValue decodes v as the type stored in bucket.
+func verifyValue(bucket, key string, v []byte) error {
+	if v == nil {
+		return fmt.Errorf("unexpected nested bucket")
+	}
+	switch bucket {
+	case string(bucketObs):
+		var env storedObs
+		return json.Unmarshal(v, &env)
+	case string(bucketSeriesMeta):
+		var meta model.SeriesMeta
+		return json.Unmarshal(v, &meta)
+	case string(bucketResults):
+		var envelope struct {
+			model.Result
+			Data json.RawMessage `json:"data"`
+		}
+		return json.Unmarshal(v, &envelope)
+	case string(bucketSchedules):
+		var job model.ScheduledJob
+		return json.Unmarshal(v, &job)
+	case string(bucketInternal):
+		switch key {
+		case "schema_version":
+			_, err := strconv.Atoi(string(v))
+			return err
+		case "created_at":
+			_, err := time.Parse(time.RFC3339, string(v))
+			return err
+		}
+	}
+	if !json.Valid(v) {
+		return fmt.Errorf("invalid JSON")
+	}
+	return nil
+}
+
+// ─── Backup & Restore ─────────────────────────────────────────────────────────
+
+// Backup archive member names.
+const (
+	backupManifestName = "reserve_version"
+	backupDBName       = "reserve.db"
+)
+
+// BackupManifest is the reserve_version member of a backup archive. Restore
+// uses it to reject databases from a newer schema before touching disk.
+type BackupManifest struct {
+	ReserveVersion string    `json:"reserve_version"`
+	SchemaVersion  int       `json:"schema_version"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Backup writes a gzip-compressed tar archive holding a reserve_version
+// manifest and a consistent copy of the database file, taken inside a single
+// read transaction so concurrent writers cannot tear it. Callers that want a
+// minimal archive run Compact first.
+func (s *Store) Backup(w io.Writer, reserveVersion string) (BackupManifest, error) {
+	manifest := BackupManifest{
+		ReserveVersion: reserveVersion,
+		SchemaVersion:  schemaVersion,
+		CreatedAt:      time.Now().UTC(),
+	}
+	mb, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, fmt.Errorf("encoding backup manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	err = s.db.View(func(tx *bolt.Tx) error {
+		if err := tw.WriteHeader(&tar.Header{Name: backupManifestName, Mode: 0600, Size: int64(len(mb)), ModTime: manifest.CreatedAt}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(mb); err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: backupDBName, Mode: 0600, Size: tx.Size(), ModTime: manifest.CreatedAt}); err != nil {
+			return err
+		}
+		_, err := tx.WriteTo(tw)
+		return err
+	})
+	if err != nil {
+		return manifest, fmt.Errorf("writing backup: %w", err)
+	}
+	if err := tw.Close(); err != nil {
+		return manifest, fmt.Errorf("writing backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return manifest, fmt.Errorf("writing backup: %w", err)
+	}
+	return manifest, nil
+}
+
+// RestoreBackup extracts an archive written by Backup to path. Backups from an
+// older schema are migrated as they are restored; backups from a newer schema
+// are rejected. The database is staged beside path and only moved into place
+// once it opens cleanly. An existing database at path is kept as path+".bak".
+func RestoreBackup(r io.Reader, path string) (BackupManifest, error) {
+	var manifest BackupManifest
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return manifest, fmt.Errorf("reading backup: %w", err)
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return manifest, fmt.Errorf("creating db directory: %w", err)
+	}
+	tmpPath := path + ".restore.tmp"
+	defer os.Remove(tmpPath)
+
+	var haveManifest, haveDB bool
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifest, fmt.Errorf("reading backup: %w", err)
+		}
+		switch hdr.Name {
+		case backupManifestName:
+			if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+				return manifest, fmt.Errorf("reading backup manifest: %w", err)
+			}
+			haveManifest = true
+		case backupDBName:
+			f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+			if err != nil {
+				return manifest, fmt.Errorf("staging restored db: %w", err)
+			}
+			_, err = io.Copy(f, tr)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				return manifest, fmt.Errorf("staging restored db: %w", err)
+			}
+			haveDB = true
+		}
+	}
+	if !haveManifest {
+		return manifest, fmt.Errorf("not a reserve backup: missing %s", backupManifestName)
+	}
+	if !haveDB {
+		return manifest, fmt.Errorf("not a reserve backup: missing %s", backupDBName)
+	}
+	if manifest.SchemaVersion > schemaVersion {
+		return manifest, fmt.Errorf("backup is at schema v%d (reserve %s), newer than the v%d this build supports; upgrade reserve to restore it",
+			manifest.SchemaVersion, manifest.ReserveVersion, schemaVersion)
+	}
+
+	// Opening runs any migrations from the backup's schema to the current one.
+	staged, err := Open(tmpPath)
+	if err != nil {
+		return manifest, fmt.Errorf("restored db from schema v%d is unusable: %w", manifest.SchemaVersion, err)
+	}
+	if err := staged.Close(); err != nil {
+		return manifest, err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, path+".bak"); err != nil {
+			return manifest, fmt.Errorf("keeping existing db: %w", err)
+		}
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return manifest, fmt.Errorf("moving restored db into place: %w", err)
+	}
+	return manifest, nil
+}
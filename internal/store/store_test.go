@@ -4,12 +4,20 @@
 package store_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
 	"math"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	bolt "go.etcd.io/bbolt"
+
 	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/store"
 )
@@ -70,6 +78,60 @@ func TestOpenCreatesDB(t *testing.T) {
 	}
 }
 
+func TestOpenReadOnlyAllowsConcurrentReaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	_ = s.PutSeriesMeta(makeMeta("GDP", "Gross Domestic Product"))
+	_ = s.Close()
+
+	r1, err := store.OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly #1: %v", err)
+	}
+	defer r1.Close()
+	r2, err := store.OpenReadOnly(path)
+	if err != nil {
+		t.Fatalf("OpenReadOnly #2 while #1 is open: %v", err)
+	}
+	defer r2.Close()
+
+	if _, found, err := r2.GetSeriesMeta("GDP"); err != nil || !found {
+		t.Fatalf("GetSeriesMeta via read-only handle: found=%v err=%v", found, err)
+	}
+	if err := r1.PutSeriesMeta(makeMeta("UNRATE", "Unemployment Rate")); err == nil {
+		t.Fatal("expected write through a read-only handle to fail")
+	}
+}
+
+func TestOpenReadOnlyRequiresExistingCurrentDB(t *testing.T) {
+	if _, err := store.OpenReadOnly(filepath.Join(t.TempDir(), "missing.db")); err == nil {
+		t.Fatal("expected error for a missing database")
+	}
+
+	path := filepath.Join(t.TempDir(), "old.db")
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("_meta"))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte("schema_version"), []byte("2"))
+	})
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("seeding v2 db: %v", err)
+	}
+	if _, err := store.OpenReadOnly(path); err == nil || !strings.Contains(err.Error(), "v2") {
+		t.Fatalf("expected schema mismatch error, got %v", err)
+	}
+}
+
 func TestOpenCreatesParentDirs(t *testing.T) {
 	// Open with nested path that doesn't exist yet
 	path := filepath.Join(t.TempDir(), "a", "b", "c", "test.db")
@@ -128,6 +190,25 @@ func TestObsKeyOmitsEmptyFields(t *testing.T) {
 	}
 }
 
+func TestObsKeyRealtimeSeparatesVintages(t *testing.T) {
+	current := store.ObsKey("UNRATE", "2020-01-01", "", "", "", "")
+	if got := store.ObsKeyRealtime("UNRATE", "2020-01-01", "", "", "", "", "", ""); got != current {
+		t.Errorf("no realtime window should equal ObsKey: %q vs %q", got, current)
+	}
+	vintage := store.ObsKeyRealtime("UNRATE", "2020-01-01", "", "", "", "", "2020-06-01", "2020-06-30")
+	if want := current + "|rt_start:2020-06-01|rt_end:2020-06-30"; vintage != want {
+		t.Errorf("vintage key:\n  expected: %q\n  got:      %q", want, vintage)
+	}
+
+	s := testDB(t)
+	_ = s.PutObs(current, makeSeriesData("UNRATE", 2020, 1, 3.5))
+	_ = s.PutObs(vintage, makeSeriesData("UNRATE", 2020, 1, 3.6))
+	got, _, _ := s.GetObs(vintage)
+	if len(got.Obs) != 1 || got.Obs[0].Value != 3.6 {
+		t.Errorf("vintage entry should be stored apart from current data, got %v", obsValues(got))
+	}
+}
+
 func TestObsKeyDeterministic(t *testing.T) {
 	// Same args → same key every time
 	k1 := store.ObsKey("GDP", "2020-01-01", "2024-12-31", "q", "lin", "avg")
@@ -222,6 +303,25 @@ func TestListSeriesMeta(t *testing.T) {
 	}
 }
 
+func TestMatchSeriesIDsGlob(t *testing.T) {
+	s := testDB(t)
+	_ = s.PutSeriesMeta(makeMeta("DGS10", "10-Year Treasury"))
+	_ = s.PutSeriesMeta(makeMeta("DGS2", "2-Year Treasury"))
+	_ = s.PutSeriesMeta(makeMeta("GDP", "Gross Domestic Product"))
+
+	ids, err := s.MatchSeriesIDs("dgs*")
+	if err != nil {
+		t.Fatalf("MatchSeriesIDs: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "DGS10" || ids[1] != "DGS2" {
+		t.Fatalf("expected [DGS10 DGS2], got %v", ids)
+	}
+
+	if _, err := s.MatchSeriesIDs("["); err == nil {
+		t.Fatal("expected error for malformed pattern")
+	}
+}
+
 func TestListSeriesMetaEmpty(t *testing.T) {
 	s := testDB(t)
 	metas, err := s.ListSeriesMeta()
@@ -297,6 +397,32 @@ func TestPutObsNaNRoundTrip(t *testing.T) {
 	}
 }
 
+func TestPutObsResponseMetaRoundTrip(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	data := makeSeriesData("UNRATE", 2024, 1, 3.7)
+	data.Response = &model.ResponseMeta{ETag: `"abc"`, LastModified: "Fri, 03 May 2024 12:00:00 GMT"}
+	if err := s.PutObsBatch(map[string]model.SeriesData{key: data}); err != nil {
+		t.Fatalf("PutObsBatch: %v", err)
+	}
+	got, _, err := s.GetObs(key)
+	if err != nil {
+		t.Fatalf("GetObs: %v", err)
+	}
+	if got.Response == nil || *got.Response != *data.Response {
+		t.Fatalf("Response: got %+v, want %+v", got.Response, data.Response)
+	}
+
+	// Appending merges rows from another response, so the validators no
+	// longer describe the stored set.
+	if err := s.PutObsAppend(key, makeSeriesData("UNRATE", 2024, 2, 3.9)); err != nil {
+		t.Fatalf("PutObsAppend: %v", err)
+	}
+	if got, _, _ := s.GetObs(key); got.Response != nil {
+		t.Errorf("Response after append: got %+v, want nil", got.Response)
+	}
+}
+
 func TestPutObsDatesPreserved(t *testing.T) {
 	s := testDB(t)
 	key := store.ObsKey("TEST", "", "", "", "", "")
@@ -329,6 +455,107 @@ func TestPutObsOverwrites(t *testing.T) {
 	}
 }
 
+// ─── PutObsAppend ─────────────────────────────────────────────────────────────
+
+// obsValues flattens got into "YYYY-MM-DD=value" strings for compact asserts.
+func obsValues(got model.SeriesData) []string {
+	out := make([]string, len(got.Obs))
+	for i, o := range got.Obs {
+		out[i] = fmt.Sprintf("%s=%g", o.Date.Format("2006-01-02"), o.Value)
+	}
+	return out
+}
+
+func assertObsValues(t *testing.T, s *store.Store, key string, want ...string) {
+	t.Helper()
+	got, found, err := s.GetObs(key)
+	if err != nil {
+		t.Fatalf("GetObs: %v", err)
+	}
+	if !found {
+		t.Fatal("expected key to be present")
+	}
+	if have := obsValues(got); strings.Join(have, " ") != strings.Join(want, " ") {
+		t.Errorf("obs mismatch:\n got  %v\n want %v", have, want)
+	}
+}
+
+func TestPutObsAppendToEmptyActsLikePut(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	if err := s.PutObsAppend(key, makeSeriesData("UNRATE", 2024, 1, 3.7, 3.9)); err != nil {
+		t.Fatalf("PutObsAppend: %v", err)
+	}
+	assertObsValues(t, s, key, "2024-01-01=3.7", "2024-02-01=3.9")
+	got, _, _ := s.GetObs(key)
+	if got.SeriesID != "UNRATE" {
+		t.Errorf("SeriesID: expected UNRATE, got %q", got.SeriesID)
+	}
+}
+
+func TestPutObsAppendAddsNewDates(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	_ = s.PutObs(key, makeSeriesData("UNRATE", 2024, 1, 3.7, 3.9))
+	if err := s.PutObsAppend(key, makeSeriesData("UNRATE", 2024, 3, 3.8)); err != nil {
+		t.Fatalf("PutObsAppend: %v", err)
+	}
+	assertObsValues(t, s, key, "2024-01-01=3.7", "2024-02-01=3.9", "2024-03-01=3.8")
+}
+
+func TestPutObsAppendDuplicateDatesLatestWins(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	_ = s.PutObs(key, makeSeriesData("UNRATE", 2024, 1, 3.7, 3.9, 3.8))
+	if err := s.PutObsAppend(key, makeSeriesData("UNRATE", 2024, 2, 4.0, math.NaN())); err != nil {
+		t.Fatalf("PutObsAppend: %v", err)
+	}
+	got, _, _ := s.GetObs(key)
+	if len(got.Obs) != 3 {
+		t.Fatalf("expected 3 obs after revising 2 dates, got %d", len(got.Obs))
+	}
+	if got.Obs[1].Value != 4.0 {
+		t.Errorf("2024-02-01: expected revised 4.0, got %g", got.Obs[1].Value)
+	}
+	if !isNaN(got.Obs[2].Value) {
+		t.Errorf("2024-03-01: expected revised NaN, got %g", got.Obs[2].Value)
+	}
+}
+
+func TestPutObsAppendExtendsForward(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	_ = s.PutObs(key, makeSeriesData("UNRATE", 2020, 1, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12))
+	// An incremental refresh: the last two cached months plus three new ones.
+	if err := s.PutObsAppend(key, makeSeriesData("UNRATE", 2020, 11, 11.5, 12.5, 13, 14, 15)); err != nil {
+		t.Fatalf("PutObsAppend: %v", err)
+	}
+	got, _, _ := s.GetObs(key)
+	if len(got.Obs) != 15 {
+		t.Fatalf("expected 15 obs, got %d", len(got.Obs))
+	}
+	if first := got.Obs[0]; first.Date.Format("2006-01-02") != "2020-01-01" || first.Value != 1 {
+		t.Errorf("history lost: first obs %v=%g", first.Date, first.Value)
+	}
+	if last := got.Obs[14]; last.Date.Format("2006-01-02") != "2021-03-01" || last.Value != 15 {
+		t.Errorf("expected 2021-03-01=15 last, got %v=%g", last.Date, last.Value)
+	}
+}
+
+func TestPutObsAppendSortsMerge(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	_ = s.PutObs(key, makeSeriesData("UNRATE", 2024, 5, 5, 6))
+	// Earlier dates delivered out of order must still land first.
+	backfill := makeSeriesData("UNRATE", 2024, 1, 1, 2, 3)
+	backfill.Obs[0], backfill.Obs[2] = backfill.Obs[2], backfill.Obs[0]
+	if err := s.PutObsAppend(key, backfill); err != nil {
+		t.Fatalf("PutObsAppend: %v", err)
+	}
+	assertObsValues(t, s, key,
+		"2024-01-01=1", "2024-02-01=2", "2024-03-01=3", "2024-05-01=5", "2024-06-01=6")
+}
+
 func TestPutObsMultipleKeys(t *testing.T) {
 	s := testDB(t)
 	k1 := store.ObsKey("UNRATE", "2020-01-01", "", "", "", "")
@@ -351,6 +578,108 @@ func TestPutObsMultipleKeys(t *testing.T) {
 	}
 }
 
+// ─── GetObsRange ──────────────────────────────────────────────────────────────
+
+func day(y, m, d int) time.Time { return time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+
+func TestGetObsRange(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("DGS10", "", "", "", "", "")
+	if err := s.PutObs(key, makeSeriesData("DGS10", 2024, 1, 1, 2, 3, 4, 5)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+
+	cases := []struct {
+		name          string
+		after, before time.Time
+		want          []string
+	}{
+		{"full range", time.Time{}, time.Time{},
+			[]string{"2024-01-01=1", "2024-02-01=2", "2024-03-01=3", "2024-04-01=4", "2024-05-01=5"}},
+		{"start only", day(2024, 3, 1), time.Time{},
+			[]string{"2024-03-01=3", "2024-04-01=4", "2024-05-01=5"}},
+		{"end only", time.Time{}, day(2024, 2, 15),
+			[]string{"2024-01-01=1", "2024-02-01=2"}},
+		{"both", day(2024, 2, 1), day(2024, 4, 1),
+			[]string{"2024-02-01=2", "2024-03-01=3", "2024-04-01=4"}},
+		{"outside stored dates", day(2025, 1, 1), day(2025, 12, 31), []string{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found, err := s.GetObsRange(key, tc.after, tc.before)
+			if err != nil {
+				t.Fatalf("GetObsRange: %v", err)
+			}
+			if !found {
+				t.Fatal("expected key to be found")
+			}
+			if got.SeriesID != "DGS10" {
+				t.Errorf("SeriesID = %q, want DGS10", got.SeriesID)
+			}
+			if have := obsValues(got); strings.Join(have, " ") != strings.Join(tc.want, " ") {
+				t.Errorf("obs mismatch:\n got  %v\n want %v", have, tc.want)
+			}
+		})
+	}
+
+	full, _, _ := s.GetObs(key)
+	ranged, _, _ := s.GetObsRange(key, time.Time{}, time.Time{})
+	if strings.Join(obsValues(full), " ") != strings.Join(obsValues(ranged), " ") {
+		t.Errorf("open range should match GetObs: %v vs %v", obsValues(ranged), obsValues(full))
+	}
+}
+
+func TestGetObsRangeKeepsResponseMeta(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("GDP", "", "", "", "", "")
+	data := makeSeriesData("GDP", 2024, 1, 1, 2, 3)
+	data.Response = &model.ResponseMeta{ETag: `"v1"`, LastModified: "Mon, 05 Jan 2026 08:00:00 GMT"}
+	if err := s.PutObs(key, data); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	for _, after := range []time.Time{{}, day(2024, 2, 1)} {
+		got, found, err := s.GetObsRange(key, after, time.Time{})
+		if err != nil || !found {
+			t.Fatalf("GetObsRange: found=%v err=%v", found, err)
+		}
+		if got.Response == nil || *got.Response != *data.Response {
+			t.Errorf("after %s: Response = %+v, want %+v", after.Format("2006-01-02"), got.Response, data.Response)
+		}
+	}
+}
+
+func TestGetObsRangeMissingKey(t *testing.T) {
+	s := testDB(t)
+	got, found, err := s.GetObsRange(store.ObsKey("NOPE", "", "", "", "", ""), day(2024, 1, 1), time.Time{})
+	if err != nil {
+		t.Fatalf("GetObsRange: %v", err)
+	}
+	if found {
+		t.Error("expected not found")
+	}
+	if got.SeriesID != "" || len(got.Obs) != 0 {
+		t.Errorf("expected zero value, got %+v", got)
+	}
+}
+
+func TestGetObsRangeFoundWithoutSeriesID(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("DGS10", "", "", "", "", "")
+	if err := s.PutObs(key, makeSeriesData("", 2024, 1, 1, 2)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	got, found, err := s.GetObsRange(key, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("GetObsRange: %v", err)
+	}
+	if !found {
+		t.Fatal("a stored key should be found even when its envelope has no series ID")
+	}
+	if len(got.Obs) != 2 {
+		t.Errorf("expected 2 obs, got %d", len(got.Obs))
+	}
+}
+
 // ─── ListObsKeys ──────────────────────────────────────────────────────────────
 
 func TestListObsKeysAllSeries(t *testing.T) {
@@ -452,6 +781,33 @@ func TestStatsCountsRows(t *testing.T) {
 	if byName["obs"] != 1 {
 		t.Errorf("obs: expected 1, got %d", byName["obs"])
 	}
+	if count, ok := byName["results"]; !ok || count != 0 {
+		t.Errorf("results: expected an empty row, got %d (present=%v)", count, ok)
+	}
+}
+
+func TestObsBytesMatchesStats(t *testing.T) {
+	s := testDB(t)
+	keys := []string{store.ObsKey("UNRATE", "", "", "", "", ""), store.ObsKey("GDP", "", "", "", "", "")}
+	_ = s.PutObs(keys[0], makeSeriesData("UNRATE", 2020, 12, 1.0))
+	_ = s.PutObs(keys[1], makeSeriesData("GDP", 2020, 4, 100.0))
+
+	got, err := s.ObsBytes(append(keys, store.ObsKey("MISSING", "", "", "", "", "")))
+	if err != nil {
+		t.Fatalf("ObsBytes: %v", err)
+	}
+	stats, err := s.Stats()
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	for _, bs := range stats {
+		if bs.Name == "obs" && bs.Bytes != got {
+			t.Errorf("ObsBytes = %d, want the obs bucket size %d", got, bs.Bytes)
+		}
+	}
+	if got == 0 {
+		t.Error("expected a non-zero size for stored entries")
+	}
 }
 
 // ─── ClearBucket / ClearAll ───────────────────────────────────────────────────
@@ -506,6 +862,53 @@ func TestClearAll(t *testing.T) {
 	}
 }
 
+func TestAgeReflectsFetchedAt(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("GDP", "", "", "", "", "")
+	if err := s.PutObs(key, makeSeriesData("GDP", 2020, 1, 1.0)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	age, err := s.Age(key)
+	if err != nil {
+		t.Fatalf("Age: %v", err)
+	}
+	if age < 0 || age > time.Minute {
+		t.Fatalf("freshly written entry should be seconds old, got %v", age)
+	}
+	if _, err := s.Age(store.ObsKey("MISSING", "", "", "", "", "")); err == nil {
+		t.Fatal("expected error for missing key")
+	}
+}
+
+func TestIsStale(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("GDP", "", "", "", "", "")
+	if err := s.PutObs(key, makeSeriesData("GDP", 2020, 1, 1.0)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		key    string
+		maxAge time.Duration
+		want   bool
+	}{
+		{"younger than maxAge", key, time.Hour, false},
+		{"older than maxAge", key, time.Nanosecond, true},
+		{"zero maxAge", key, 0, true},
+		{"missing entry", store.ObsKey("MISSING", "", "", "", "", ""), time.Hour, true},
+	}
+	for _, tc := range cases {
+		got, err := s.IsStale(tc.key, tc.maxAge)
+		if err != nil {
+			t.Fatalf("%s: IsStale: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: IsStale = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
 func TestClearObsSeries(t *testing.T) {
 	s := testDB(t)
 	_ = s.PutSeriesMeta(makeMeta("GDP", "GDP"))
@@ -540,6 +943,428 @@ func TestClearObsSeries(t *testing.T) {
 	}
 }
 
+func TestDeleteSeriesRemovesObsAndMeta(t *testing.T) {
+	s := testDB(t)
+	_ = s.PutSeriesMeta(makeMeta("GDP", "GDP"))
+	_ = s.PutSeriesMeta(makeMeta("GDPC1", "Real GDP"))
+	_ = s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), makeSeriesData("GDP", 2020, 1, 1.0))
+	_ = s.PutObs(store.ObsKey("GDP", "2021-01-01", "", "", "", ""), makeSeriesData("GDP", 2021, 1, 2.0))
+	_ = s.PutObs(store.ObsKey("GDPC1", "", "", "", "", ""), makeSeriesData("GDPC1", 2020, 1, 3.0))
+
+	removed, err := s.DeleteSeries("GDP")
+	if err != nil {
+		t.Fatalf("DeleteSeries: %v", err)
+	}
+	if removed != 3 {
+		t.Fatalf("expected 3 removed entries (2 obs + meta), got %d", removed)
+	}
+	if keys, _ := s.ListObsKeys("GDP"); len(keys) != 0 {
+		t.Fatalf("expected no GDP obs keys, got %v", keys)
+	}
+	if _, found, _ := s.GetSeriesMeta("GDP"); found {
+		t.Fatal("GDP metadata should be deleted")
+	}
+
+	// A series sharing the prefix must survive.
+	if keys, _ := s.ListObsKeys("GDPC1"); len(keys) != 1 {
+		t.Fatalf("GDPC1 obs should remain, got %v", keys)
+	}
+	if _, found, _ := s.GetSeriesMeta("GDPC1"); !found {
+		t.Fatal("GDPC1 metadata should remain")
+	}
+
+	if removed, err := s.DeleteSeries("GDP"); err != nil || removed != 0 {
+		t.Fatalf("second DeleteSeries: removed=%d err=%v", removed, err)
+	}
+}
+
+func TestDeleteObsAndSeriesMeta(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	_ = s.PutObs(key, makeSeriesData("UNRATE", 2020, 1, 3.5))
+	_ = s.PutSeriesMeta(makeMeta("UNRATE", "Unemployment Rate"))
+
+	if err := s.DeleteObs(key); err != nil {
+		t.Fatalf("DeleteObs: %v", err)
+	}
+	if _, found, _ := s.GetObs(key); found {
+		t.Fatal("obs should be gone after DeleteObs")
+	}
+	if err := s.DeleteSeriesMeta("UNRATE"); err != nil {
+		t.Fatalf("DeleteSeriesMeta: %v", err)
+	}
+	if _, found, _ := s.GetSeriesMeta("UNRATE"); found {
+		t.Fatal("meta should be gone after DeleteSeriesMeta")
+	}
+	if err := s.DeleteObs(key); err != nil {
+		t.Fatalf("DeleteObs on missing key should not error: %v", err)
+	}
+}
+
+// ─── Analysis Results ─────────────────────────────────────────────────────────
+
+func TestPutGetResultRoundTrip(t *testing.T) {
+	s := testDB(t)
+	key := "analyze:trend|series:GDP|method:linear|input:abc"
+	in := model.Result{
+		Kind:        model.KindReport,
+		GeneratedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Command:     "analyze trend",
+		Data:        map[string]any{"slope": 1.5},
+	}
+	if err := s.PutResult(key, in); err != nil {
+		t.Fatalf("PutResult: %v", err)
+	}
+
+	got, found, err := s.GetResult(key)
+	if err != nil || !found {
+		t.Fatalf("GetResult: found=%v err=%v", found, err)
+	}
+	if got.Command != in.Command || got.Kind != in.Kind || !got.GeneratedAt.Equal(in.GeneratedAt) {
+		t.Fatalf("envelope not preserved: %+v", got)
+	}
+	raw, ok := got.Data.(json.RawMessage)
+	if !ok || string(raw) != `{"slope":1.5}` {
+		t.Fatalf("unexpected data: %#v", got.Data)
+	}
+
+	stats, _ := s.Stats()
+	for _, st := range stats {
+		if st.Name == "results" && st.Count != 1 {
+			t.Fatalf("expected 1 results row, got %d", st.Count)
+		}
+	}
+}
+
+func TestGetResultMissing(t *testing.T) {
+	s := testDB(t)
+	_, found, err := s.GetResult("nope")
+	if err != nil || found {
+		t.Fatalf("expected not found, got found=%v err=%v", found, err)
+	}
+}
+
+func TestOpenMigratesV2ToCurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "v2.db")
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	key := store.ObsKey("GDP", "", "", "", "", "")
+	if err := s.PutObs(key, makeSeriesData("GDP", 2024, 1, 1.0)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	_ = s.Close()
+
+	// Rewind the file to the v2 layout: no results bucket, version 2.
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket([]byte("results")); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte("_meta")).Put([]byte("schema_version"), []byte("2"))
+	})
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("rewinding to v2: %v", err)
+	}
+
+	s, err = store.Open(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	meta, err := s.Metadata()
+	if err != nil || meta.SchemaVersion != 4 {
+		t.Fatalf("expected schema v4, got %d (err=%v)", meta.SchemaVersion, err)
+	}
+	if _, found, _ := s.GetObs(key); !found {
+		t.Fatal("v2 → v4 migration should keep existing observations")
+	}
+	if err := s.PutResult("k", model.Result{Command: "analyze trend"}); err != nil {
+		t.Fatalf("PutResult after migration: %v", err)
+	}
+	if err := s.PutScheduledJob(model.ScheduledJob{Name: "daily", Cron: "0 8 * * *", Command: "fetch series GDP --store"}); err != nil {
+		t.Fatalf("PutScheduledJob after migration: %v", err)
+	}
+}
+
+func TestScheduledJobLifecycle(t *testing.T) {
+	s := testDB(t)
+	created := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"weekly", "daily"} {
+		job := model.ScheduledJob{Name: name, Cron: "0 8 * * 1-5", Command: "fetch series UNRATE --store", CreatedAt: created}
+		if err := s.PutScheduledJob(job); err != nil {
+			t.Fatalf("PutScheduledJob: %v", err)
+		}
+	}
+
+	jobs, err := s.ListScheduledJobs()
+	if err != nil || len(jobs) != 2 || jobs[0].Name != "daily" || jobs[1].Name != "weekly" {
+		t.Fatalf("expected daily and weekly in name order, got %+v (err=%v)", jobs, err)
+	}
+
+	ran := time.Date(2026, 1, 6, 8, 0, 0, 0, time.UTC)
+	if err := s.RecordScheduledRun("daily", ran, "ok"); err != nil {
+		t.Fatalf("RecordScheduledRun: %v", err)
+	}
+	got, found, err := s.GetScheduledJob("daily")
+	if err != nil || !found {
+		t.Fatalf("GetScheduledJob: found=%v err=%v", found, err)
+	}
+	if !got.LastRunAt.Equal(ran) || got.LastStatus != "ok" || !got.CreatedAt.Equal(created) {
+		t.Errorf("run not recorded: %+v", got)
+	}
+
+	if removed, err := s.DeleteScheduledJob("daily"); err != nil || !removed {
+		t.Fatalf("DeleteScheduledJob: removed=%v err=%v", removed, err)
+	}
+	if removed, _ := s.DeleteScheduledJob("daily"); removed {
+		t.Error("second delete should report nothing removed")
+	}
+	if err := s.RecordScheduledRun("daily", ran, "ok"); err != nil {
+		t.Fatalf("RecordScheduledRun on removed job: %v", err)
+	}
+	if _, found, _ := s.GetScheduledJob("daily"); found {
+		t.Error("recording a run must not recreate a removed job")
+	}
+
+	if err := s.ClearAll(); err != nil {
+		t.Fatalf("ClearAll: %v", err)
+	}
+	if jobs, _ := s.ListScheduledJobs(); len(jobs) != 1 {
+		t.Errorf("cache clear --all should keep scheduled jobs, got %d", len(jobs))
+	}
+}
+
+// ─── Export & Import ──────────────────────────────────────────────────────────
+
+func TestExportImportRoundTrip(t *testing.T) {
+	src := testDB(t)
+	_ = src.PutSeriesMeta(makeMeta("GDP", "Gross Domestic Product"))
+	_ = src.PutSeriesMeta(makeMeta("UNRATE", "Unemployment Rate"))
+	gdpKey := store.ObsKey("GDP", "", "", "", "", "")
+	if err := src.PutObs(gdpKey, makeSeriesData("GDP", 2024, 1, 1.5, math.NaN(), 2.5)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.ExportAll(&buf); err != nil {
+		t.Fatalf("ExportAll: %v", err)
+	}
+	dump := buf.String()
+	if lines := strings.Count(dump, "\n"); lines != 3 {
+		t.Fatalf("expected 3 exported records, got %d:\n%s", lines, dump)
+	}
+
+	dst := testDB(t)
+	counts, err := dst.ImportAllCounts(strings.NewReader(dump))
+	if err != nil {
+		t.Fatalf("ImportAllCounts: %v", err)
+	}
+	if counts["obs"] != 1 || counts["series_meta"] != 2 {
+		t.Fatalf("unexpected import counts: %v", counts)
+	}
+
+	got, found, err := dst.GetObs(gdpKey)
+	if err != nil || !found {
+		t.Fatalf("GetObs after import: found=%v err=%v", found, err)
+	}
+	if len(got.Obs) != 3 || got.Obs[0].Value != 1.5 || !isNaN(got.Obs[1].Value) {
+		t.Fatalf("observations not restored faithfully: %+v", got.Obs)
+	}
+	meta, found, _ := dst.GetSeriesMeta("UNRATE")
+	if !found || meta.Title != "Unemployment Rate" {
+		t.Fatalf("metadata not restored: %+v", meta)
+	}
+
+	// Re-importing the same dump upserts rather than duplicating.
+	if err := dst.ImportAll(strings.NewReader(dump)); err != nil {
+		t.Fatalf("second ImportAll: %v", err)
+	}
+	stats, _ := dst.Stats()
+	for _, st := range stats {
+		if st.Name == "series_meta" && st.Count != 2 {
+			t.Fatalf("expected 2 series_meta rows after re-import, got %d", st.Count)
+		}
+	}
+}
+
+func TestExportImportCarriesScheduledJobs(t *testing.T) {
+	src := testDB(t)
+	job := model.ScheduledJob{Name: "nightly", Cron: "@daily", Command: "fetch series GDP --store"}
+	if err := src.PutScheduledJob(job); err != nil {
+		t.Fatalf("PutScheduledJob: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.ExportAll(&buf); err != nil {
+		t.Fatalf("ExportAll: %v", err)
+	}
+	dst := testDB(t)
+	counts, err := dst.ImportAllCounts(&buf)
+	if err != nil {
+		t.Fatalf("ImportAllCounts: %v", err)
+	}
+	if counts["schedules"] != 1 {
+		t.Fatalf("expected 1 schedules record, got %v", counts)
+	}
+	got, found, err := dst.GetScheduledJob("nightly")
+	if err != nil || !found || got.Cron != job.Cron || got.Command != job.Command {
+		t.Fatalf("scheduled job not restored: %+v found=%v err=%v", got, found, err)
+	}
+
+	// Clearing the cache still leaves the restored jobs alone.
+	if err := dst.ClearAll(); err != nil {
+		t.Fatalf("ClearAll: %v", err)
+	}
+	if _, found, _ := dst.GetScheduledJob("nightly"); !found {
+		t.Fatal("ClearAll removed a scheduled job")
+	}
+}
+
+func TestImportAllRejectsUnknownBucket(t *testing.T) {
+	s := testDB(t)
+	err := s.ImportAll(strings.NewReader(`{"bucket":"_meta","key":"schema_version","value":"9"}` + "\n"))
+	if err == nil {
+		t.Fatal("expected error importing into internal bucket")
+	}
+}
+
+func TestExportCSVFormatsDatesAndNaN(t *testing.T) {
+	s := testDB(t)
+	key := store.ObsKey("UNRATE", "", "", "", "", "")
+	if err := s.PutObs(key, makeSeriesData("UNRATE", 2024, 1, 3.7, math.NaN(), 3.9)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := s.ExportCSV("UNRATE", &buf, ""); err != nil {
+		t.Fatalf("ExportCSV: %v", err)
+	}
+	want := "date,value,value_raw\n2024-01-01,3.7,x\n2024-02-01,,.\n2024-03-01,3.9,x\n"
+	if buf.String() != want {
+		t.Fatalf("ExportCSV output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := s.ExportCSV("UNRATE", &buf, "."); err != nil {
+		t.Fatalf("ExportCSV with sentinel: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\n2024-02-01,.,.\n") {
+		t.Fatalf("expected dot sentinel for missing value, got:\n%s", buf.String())
+	}
+}
+
+func TestExportCSVSeriesAddsSeriesIDColumn(t *testing.T) {
+	s := testDB(t)
+	_ = s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), makeSeriesData("GDP", 2024, 1, 1.5))
+	_ = s.PutObs(store.ObsKey("UNRATE", "", "", "", "", ""), makeSeriesData("UNRATE", 2024, 1, 3.7))
+
+	var buf bytes.Buffer
+	if err := s.ExportCSVSeries([]string{"GDP", "UNRATE"}, &buf, ""); err != nil {
+		t.Fatalf("ExportCSVSeries: %v", err)
+	}
+	want := "series_id,date,value,value_raw\nGDP,2024-01-01,1.5,x\nUNRATE,2024-01-01,3.7,x\n"
+	if buf.String() != want {
+		t.Fatalf("ExportCSVSeries output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestExportCSVUnknownSeries(t *testing.T) {
+	s := testDB(t)
+	err := s.ExportCSV("NOPE", &bytes.Buffer{}, "")
+	if err == nil || !strings.Contains(err.Error(), "NOPE") {
+		t.Fatalf("expected error naming the missing series, got %v", err)
+	}
+}
+
+func writeCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "obs.csv")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	return path
+}
+
+func TestImportCSVRoundTripsThroughGetObs(t *testing.T) {
+	path := writeCSV(t, "observation_date,CPIAUCSL\n2024-02-01,310.3\n2024-01-01,308.4\n2024-03-01,.\n")
+	data, err := store.ImportCSV(path, "CPIAUCSL", "", "", "")
+	if err != nil {
+		t.Fatalf("ImportCSV: %v", err)
+	}
+	if len(data.Obs) != 3 || data.Obs[0].Date.Format("2006-01-02") != "2024-01-01" {
+		t.Fatalf("expected 3 observations sorted by date, got %+v", data.Obs)
+	}
+
+	s := testDB(t)
+	key := store.ObsKey("CPIAUCSL", "", "", "", "", "")
+	if err := s.PutObs(key, data); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	got, found, err := s.GetObs(key)
+	if err != nil || !found {
+		t.Fatalf("GetObs: found=%v err=%v", found, err)
+	}
+	if got.Obs[0].Value != 308.4 || got.Obs[1].ValueRaw != "310.3" || !isNaN(got.Obs[2].Value) {
+		t.Fatalf("unexpected round-trip observations: %+v", got.Obs)
+	}
+}
+
+func TestImportCSVExplicitColumns(t *testing.T) {
+	path := writeCSV(t, "when,level,note\n2024-01-01,1.5,a\n")
+	data, err := store.ImportCSV(path, "X", "when", "level", "")
+	if err != nil {
+		t.Fatalf("ImportCSV: %v", err)
+	}
+	if len(data.Obs) != 1 || data.Obs[0].Value != 1.5 {
+		t.Fatalf("unexpected observations: %+v", data.Obs)
+	}
+}
+
+func TestImportCSVMissingColumn(t *testing.T) {
+	path := writeCSV(t, "date,value\n2024-01-01,1\n")
+	_, err := store.ImportCSV(path, "X", "", "level", "")
+	if err == nil || !strings.Contains(err.Error(), "level") {
+		t.Fatalf("expected missing column error, got %v", err)
+	}
+	path = writeCSV(t, "day,a,b\n2024-01-01,1,2\n")
+	if _, err := store.ImportCSV(path, "X", "", "", ""); err == nil {
+		t.Fatal("expected error when columns cannot be detected")
+	}
+}
+
+func TestImportCSVInvalidDate(t *testing.T) {
+	path := writeCSV(t, "date,value\n2024-01-01,1\n01/02/2024,2\n")
+	_, err := store.ImportCSV(path, "X", "", "", "")
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("expected invalid date error naming line 3, got %v", err)
+	}
+}
+
+func TestImportCSVNaNSentinels(t *testing.T) {
+	path := writeCSV(t, "date,value\n2024-01-01,\n2024-02-01,.\n2024-03-01,NA\n2024-04-01,4\n")
+	if _, err := store.ImportCSV(path, "X", "", "", ""); err == nil {
+		t.Fatal("expected NA to be rejected without a sentinel")
+	}
+	data, err := store.ImportCSV(path, "X", "", "", "NA")
+	if err != nil {
+		t.Fatalf("ImportCSV: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if !isNaN(data.Obs[i].Value) || data.Obs[i].ValueRaw != "." {
+			t.Fatalf("obs[%d]: expected missing value, got %+v", i, data.Obs[i])
+		}
+	}
+	if data.Obs[3].Value != 4 {
+		t.Fatalf("obs[3]: expected 4, got %g", data.Obs[3].Value)
+	}
+}
+
 // ─── Isolation ────────────────────────────────────────────────────────────────
 
 func TestEachTestGetsIsolatedDB(t *testing.T) {
@@ -556,3 +1381,234 @@ func TestEachTestGetsIsolatedDB(t *testing.T) {
 		t.Error("s2 should not see data written to s1 — databases are not isolated")
 	}
 }
+
+// ─── Verify ───────────────────────────────────────────────────────────────────
+
+func TestVerifyValidStorePasses(t *testing.T) {
+	s := testDB(t)
+	_ = s.PutSeriesMeta(makeMeta("GDP", "Gross Domestic Product"))
+	if err := s.PutObs(store.ObsKey("GDP", "", "", "", "", ""), makeSeriesData("GDP", 2024, 1, 1.0, math.NaN())); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	if err := s.PutResult("k", model.Result{Command: "analyze trend"}); err != nil {
+		t.Fatalf("PutResult: %v", err)
+	}
+
+	res, err := s.Verify()
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !res.OK() {
+		t.Fatalf("expected pass, got corrupt=%v pages=%v", res.CorruptKeys, res.PageErrors)
+	}
+	if res.SchemaVersion != 4 {
+		t.Errorf("schema version: expected 4, got %d", res.SchemaVersion)
+	}
+	// obs, series_meta, results, plus schema_version and created_at in _meta.
+	if res.KeysChecked != 5 {
+		t.Errorf("keys checked: expected 5, got %d", res.KeysChecked)
+	}
+	if len(res.Buckets) != 5 {
+		t.Errorf("expected 5 buckets, got %v", res.Buckets)
+	}
+}
+
+func TestVerifyReportsCorruptValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupt.db")
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	good := store.ObsKey("GDP", "", "", "", "", "")
+	if err := s.PutObs(good, makeSeriesData("GDP", 2024, 1, 1.0)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	_ = s.Close()
+
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	defer db.Close()
+	err = db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte("obs")).Put([]byte("series:BAD"), []byte(`{"series_id":"BAD","observations":[{`))
+	})
+	if err != nil {
+		t.Fatalf("writing corrupt value: %v", err)
+	}
+
+	res, err := store.Verify(db)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if res.OK() {
+		t.Fatal("expected verification failure")
+	}
+	if len(res.CorruptKeys) != 1 || res.CorruptKeys[0].Bucket != "obs" || res.CorruptKeys[0].Key != "series:BAD" {
+		t.Errorf("expected only obs/series:BAD to be reported, got %+v", res.CorruptKeys)
+	}
+}
+
+func TestVerifyEmptyDBPasses(t *testing.T) {
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "empty.db"), 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	defer db.Close()
+
+	res, err := store.Verify(db)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !res.OK() || res.KeysChecked != 0 || len(res.Buckets) != 0 {
+		t.Errorf("expected empty pass, got %+v", res)
+	}
+}
+
+// ─── Backup & Restore ─────────────────────────────────────────────────────────
+
+// backupArchive builds a gzip tar from name → contents, for hand-crafted backups.
+func backupArchive(t *testing.T, files map[string][]byte) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(data))}); err != nil {
+			t.Fatalf("WriteHeader: %v", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+	return &buf
+}
+
+func TestBackupRestoreRoundTrip(t *testing.T) {
+	src := testDB(t)
+	_ = src.PutSeriesMeta(makeMeta("GDP", "Gross Domestic Product"))
+	key := store.ObsKey("GDP", "", "", "", "", "")
+	if err := src.PutObs(key, makeSeriesData("GDP", 2024, 1, 1.5, math.NaN(), 2.5)); err != nil {
+		t.Fatalf("PutObs: %v", err)
+	}
+	if err := src.PutResult("analyze:trend|series:GDP", model.Result{Command: "analyze trend", Data: map[string]any{"slope": 1.0}}); err != nil {
+		t.Fatalf("PutResult: %v", err)
+	}
+
+	var buf bytes.Buffer
+	manifest, err := src.Backup(&buf, "v9.9.9")
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	if manifest.SchemaVersion != 4 || manifest.ReserveVersion != "v9.9.9" {
+		t.Errorf("unexpected manifest: %+v", manifest)
+	}
+
+	dst := filepath.Join(t.TempDir(), "restored", "reserve.db")
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.RestoreBackup(&buf, dst)
+	if err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	if got.ReserveVersion != "v9.9.9" {
+		t.Errorf("restored manifest: %+v", got)
+	}
+	if old, err := os.ReadFile(dst + ".bak"); err != nil || string(old) != "old" {
+		t.Errorf("expected previous db kept as .bak, got %q (err=%v)", old, err)
+	}
+
+	s, err := store.Open(dst)
+	if err != nil {
+		t.Fatalf("Open restored: %v", err)
+	}
+	defer s.Close()
+	data, found, err := s.GetObs(key)
+	if err != nil || !found || len(data.Obs) != 3 || !isNaN(data.Obs[1].Value) {
+		t.Errorf("obs not preserved: found=%v err=%v data=%+v", found, err, data)
+	}
+	if _, found, _ := s.GetSeriesMeta("GDP"); !found {
+		t.Error("series_meta not preserved")
+	}
+	if _, found, _ := s.GetResult("analyze:trend|series:GDP"); !found {
+		t.Error("results not preserved")
+	}
+}
+
+func TestRestoreBackupRejectsNewerSchema(t *testing.T) {
+	manifest, _ := json.Marshal(store.BackupManifest{ReserveVersion: "v99.0.0", SchemaVersion: 99})
+	archive := backupArchive(t, map[string][]byte{
+		"reserve_version": manifest,
+		"reserve.db":      []byte("unused"),
+	})
+	dst := filepath.Join(t.TempDir(), "reserve.db")
+	_, err := store.RestoreBackup(archive, dst)
+	if err == nil || !strings.Contains(err.Error(), "schema v99") || !strings.Contains(err.Error(), "upgrade reserve") {
+		t.Fatalf("expected newer-schema error, got %v", err)
+	}
+	if _, err := os.Stat(dst); !os.IsNotExist(err) {
+		t.Error("rejected restore should not create the db")
+	}
+}
+
+func TestRestoreBackupMigratesOlderSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "v2.db")
+	s, err := store.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	_ = s.PutSeriesMeta(makeMeta("GDP", "Gross Domestic Product"))
+	_ = s.Close()
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		t.Fatalf("bolt.Open: %v", err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket([]byte("results")); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte("_meta")).Put([]byte("schema_version"), []byte("2"))
+	})
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("rewinding to v2: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, _ := json.Marshal(store.BackupManifest{ReserveVersion: "v1.0.0", SchemaVersion: 2})
+	dst := filepath.Join(t.TempDir(), "reserve.db")
+	if _, err := store.RestoreBackup(backupArchive(t, map[string][]byte{
+		"reserve_version": manifest,
+		"reserve.db":      raw,
+	}), dst); err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	restored, err := store.OpenReadOnly(dst)
+	if err != nil {
+		t.Fatalf("restored db should already be at the current schema: %v", err)
+	}
+	defer restored.Close()
+	if _, found, _ := restored.GetSeriesMeta("GDP"); !found {
+		t.Error("series_meta lost during migrating restore")
+	}
+}
+
+func TestRestoreBackupRequiresManifest(t *testing.T) {
+	archive := backupArchive(t, map[string][]byte{"reserve.db": []byte("x")})
+	if _, err := store.RestoreBackup(archive, filepath.Join(t.TempDir(), "reserve.db")); err == nil || !strings.Contains(err.Error(), "reserve_version") {
+		t.Fatalf("expected missing-manifest error, got %v", err)
+	}
+}
@@ -9,7 +9,9 @@ package transform
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/model"
@@ -17,24 +19,73 @@ import (
 
 // ─── Percent Change ───────────────────────────────────────────────────────────
 
-// PctChange computes (v[t] - v[t-period]) / v[t-period] * 100.
-// Leading observations that have no prior period are dropped.
-// NaN inputs propagate as NaN outputs.
-func PctChange(obs []model.Observation, period int) ([]model.Observation, error) {
+// PctChangeMethod selects how PctChange measures the change between v[t] and
+// v[t-period].
+type PctChangeMethod string
+
+const (
+	// PctChangeStandard is the simple percent change, scaled by 100.
+	PctChangeStandard PctChangeMethod = "standard"
+	// PctChangeLog is the log difference ln(v[t]) - ln(v[t-period]) as a
+	// fraction, not percent: 0.01 is about 1%. Log differences add up over
+	// time and read as continuously compounded growth; for small changes they
+	// approximate standard/100. LogReturns is this method at period 1 scaled
+	// to percent.
+	PctChangeLog PctChangeMethod = "log"
+)
+
+// PctChange computes (v[t] - v[t-period]) / v[t-period] * 100, or the log
+// difference when method is PctChangeLog.
+// Leading observations that have no prior period are dropped unless
+// keepLength is set, in which case they are emitted as NaN so the output
+// stays aligned with the input and can be chained without losing rows.
+// NaN inputs propagate as NaN outputs. Under the log method, non-positive
+// values produce NaN with a warning, as in Log.
+func PctChange(obs []model.Observation, period int, keepLength bool, method PctChangeMethod) ([]model.Observation, []string, error) {
+	if method != PctChangeStandard && method != PctChangeLog {
+		return nil, nil, fmt.Errorf("pct-change: unknown method %q; valid methods are standard, log", method)
+	}
 	if period < 1 {
-		return nil, fmt.Errorf("pct-change: period must be >= 1, got %d", period)
+		return nil, nil, fmt.Errorf("pct-change: period must be >= 1, got %d", period)
 	}
 	if len(obs) <= period {
-		return nil, fmt.Errorf("pct-change: need more than %d observations, got %d", period, len(obs))
+		return nil, nil, fmt.Errorf("pct-change: need more than %d observations, got %d", period, len(obs))
+	}
+	var warnings []string
+	if method == PctChangeLog {
+		for _, o := range obs {
+			if !math.IsNaN(o.Value) && o.Value <= 0 {
+				warnings = append(warnings, fmt.Sprintf("%s: log(%g) is undefined, set to NaN",
+					o.Date.Format("2006-01-02"), o.Value))
+			}
+		}
+	}
+	out := make([]model.Observation, 0, len(obs))
+	if keepLength {
+		for i := 0; i < period; i++ {
+			out = append(out, model.Observation{
+				Date:     obs[i].Date,
+				Value:    math.NaN(),
+				ValueRaw: formatRaw(math.NaN()),
+			})
+		}
 	}
-	out := make([]model.Observation, 0, len(obs)-period)
 	for i := period; i < len(obs); i++ {
 		curr := obs[i].Value
 		prev := obs[i-period].Value
 		var val float64
-		if math.IsNaN(curr) || math.IsNaN(prev) || prev == 0 {
+		switch {
+		case math.IsNaN(curr) || math.IsNaN(prev):
 			val = math.NaN()
-		} else {
+		case method == PctChangeLog:
+			if curr <= 0 || prev <= 0 {
+				val = math.NaN()
+			} else {
+				val = math.Log(curr) - math.Log(prev)
+			}
+		case prev == 0:
+			val = math.NaN()
+		default:
 			val = (curr - prev) / math.Abs(prev) * 100
 		}
 		out = append(out, model.Observation{
@@ -43,6 +94,53 @@ func PctChange(obs []model.Observation, period int) ([]model.Observation, error)
 			ValueRaw: formatRaw(val),
 		})
 	}
+	return out, warnings, nil
+}
+
+// ─── Log Returns ──────────────────────────────────────────────────────────────
+
+// LogReturns computes 100 * ln(v[t] / v[t-1]), the continuously compounded
+// rate of change in percent (FRED's cch units), so results sit on the same
+// scale as PctChange(obs, 1). It is PctChange's log method, which returns a
+// fraction, scaled by 100. The first observation is dropped. NaN inputs and
+// non-positive values produce NaN.
+func LogReturns(obs []model.Observation) ([]model.Observation, error) {
+	if len(obs) < 2 {
+		return nil, fmt.Errorf("log-returns: need at least 2 observations, got %d", len(obs))
+	}
+	out, _, err := PctChange(obs, 1, false, PctChangeLog)
+	if err != nil {
+		return nil, err
+	}
+	for i := range out {
+		out[i].Value *= 100 // fraction → percent
+		out[i].ValueRaw = formatRaw(out[i].Value)
+	}
+	return out, nil
+}
+
+// ─── Annualize ────────────────────────────────────────────────────────────────
+
+// Annualize compounds periodic percent rates, such as the output of
+// PctChange(obs, 1), to annual percent rates: ((1 + r/100)^periodsPerYear - 1)
+// * 100. Dates are kept. NaN inputs stay NaN, as do rates at or below -100%,
+// which have no compounded equivalent.
+func Annualize(obs []model.Observation, periodsPerYear int) ([]model.Observation, error) {
+	if periodsPerYear < 1 {
+		return nil, fmt.Errorf("annualize: periods per year must be >= 1, got %d", periodsPerYear)
+	}
+	out := make([]model.Observation, len(obs))
+	for i, o := range obs {
+		val := math.NaN()
+		if o.Value > -100 {
+			val = (math.Pow(1+o.Value/100, float64(periodsPerYear)) - 1) * 100
+		}
+		out[i] = model.Observation{
+			Date:     o.Date,
+			Value:    val,
+			ValueRaw: formatRaw(val),
+		}
+	}
 	return out, nil
 }
 
@@ -85,6 +183,78 @@ func diffOnce(obs []model.Observation) ([]model.Observation, error) {
 	return out, nil
 }
 
+// SeasonalDiff computes v[t] - v[t-lag], the additive counterpart to
+// PctChange with a period: lag=12 removes a stable seasonal pattern from
+// monthly data. The leading lag observations are dropped and NaN inputs
+// propagate as NaN outputs.
+func SeasonalDiff(obs []model.Observation, lag int) ([]model.Observation, error) {
+	if lag < 1 {
+		return nil, fmt.Errorf("seasonal-diff: lag must be >= 1, got %d", lag)
+	}
+	if len(obs) <= lag {
+		return nil, fmt.Errorf("seasonal-diff: need more than %d observations, got %d", lag, len(obs))
+	}
+	out := make([]model.Observation, 0, len(obs)-lag)
+	for i := lag; i < len(obs); i++ {
+		val := obs[i].Value - obs[i-lag].Value // NaN in either propagates
+		out = append(out, model.Observation{
+			Date:     obs[i].Date,
+			Value:    val,
+			ValueRaw: formatRaw(val),
+		})
+	}
+	return out, nil
+}
+
+// DeltaFromPrev returns, aligned with obs, each value's change from the most
+// recent earlier non-NaN observation. It is Diff over the non-NaN rows mapped
+// back onto obs, so every row is kept and gaps are skipped over: the first
+// valid value and any NaN value yield NaN.
+func DeltaFromPrev(obs []model.Observation) []float64 {
+	out := make([]float64, len(obs))
+	valid := make([]model.Observation, 0, len(obs))
+	rows := make([]int, 0, len(obs))
+	for i, o := range obs {
+		out[i] = math.NaN()
+		if !math.IsNaN(o.Value) {
+			valid = append(valid, o)
+			rows = append(rows, i)
+		}
+	}
+	if len(valid) < 2 {
+		return out
+	}
+	diffs, err := Diff(valid, 1)
+	if err != nil {
+		return out
+	}
+	for k, d := range diffs {
+		out[rows[k+1]] = d.Value
+	}
+	return out
+}
+
+// Shift moves each value periods observations later, keeping the date grid:
+// the value at obs[i] is reported on obs[i+periods].Date. A negative periods
+// moves values earlier. Dates left without a source value are dropped, so the
+// result is |periods| shorter. Observations are assumed to be in date order
+// at a regular frequency.
+func Shift(obs []model.Observation, periods int) []model.Observation {
+	out := make([]model.Observation, 0, len(obs))
+	for j := range obs {
+		i := j - periods
+		if i < 0 || i >= len(obs) {
+			continue
+		}
+		out = append(out, model.Observation{
+			Date:     obs[j].Date,
+			Value:    obs[i].Value,
+			ValueRaw: obs[i].ValueRaw,
+		})
+	}
+	return out
+}
+
 // ─── Log ──────────────────────────────────────────────────────────────────────
 
 // Log computes the natural log of each observation value.
@@ -115,30 +285,23 @@ func Log(obs []model.Observation) ([]model.Observation, []string) {
 // ─── Index ────────────────────────────────────────────────────────────────────
 
 // Index re-scales the series so the value at anchorDate equals base.
-// All other values are scaled proportionally.
+// All other values are scaled proportionally. The anchor observation is the
+// one IndexAnchor picks for anchorDate.
 func Index(obs []model.Observation, base float64, anchorDate time.Time) ([]model.Observation, error) {
-	// Find anchor value
-	var anchor float64
-	found := false
-	for _, o := range obs {
-		if o.Date.Equal(anchorDate) {
-			if math.IsNaN(o.Value) {
-				return nil, fmt.Errorf("index: anchor date %s has missing value",
-					anchorDate.Format("2006-01-02"))
-			}
-			if o.Value == 0 {
-				return nil, fmt.Errorf("index: anchor date %s has zero value, cannot index",
-					anchorDate.Format("2006-01-02"))
-			}
-			anchor = o.Value
-			found = true
-			break
-		}
-	}
-	if !found {
+	i := anchorIndex(obs, anchorDate)
+	if i < 0 {
 		return nil, fmt.Errorf("index: anchor date %s not found in series",
 			anchorDate.Format("2006-01-02"))
 	}
+	anchor := obs[i].Value
+	if math.IsNaN(anchor) {
+		return nil, fmt.Errorf("index: anchor date %s has missing value",
+			obs[i].Date.Format("2006-01-02"))
+	}
+	if anchor == 0 {
+		return nil, fmt.Errorf("index: anchor date %s has zero value, cannot index",
+			obs[i].Date.Format("2006-01-02"))
+	}
 
 	scale := base / anchor
 	out := make([]model.Observation, len(obs))
@@ -158,6 +321,114 @@ func Index(obs []model.Observation, base float64, anchorDate time.Time) ([]model
 	return out, nil
 }
 
+// IndexAnchor returns the date of the observation Index anchors at for at.
+// An observation dated exactly at wins. Otherwise a monthly, quarterly, or
+// annual series anchors at its observation for the period containing at, so
+// 2020-01-15 finds a monthly series' 2020-01-01. Other series anchor at the
+// observation nearest to at, if one lies within their median spacing.
+func IndexAnchor(obs []model.Observation, at time.Time) (time.Time, error) {
+	i := anchorIndex(obs, at)
+	if i < 0 {
+		return time.Time{}, fmt.Errorf("index: anchor date %s not found in series", at.Format("2006-01-02"))
+	}
+	return obs[i].Date, nil
+}
+
+// anchorIndex is the position of the IndexAnchor observation, or -1.
+func anchorIndex(obs []model.Observation, at time.Time) int {
+	for i, o := range obs {
+		if o.Date.Equal(at) {
+			return i
+		}
+	}
+	switch freq, _ := model.DetectFrequency(obs); freq {
+	case model.FrequencyMonthly, model.FrequencyQuarterly, model.FrequencyAnnual:
+		want, _ := periodKey(at, ResampleFreq(freq))
+		for i, o := range obs {
+			if key, _ := periodKey(o.Date, ResampleFreq(freq)); key == want {
+				return i
+			}
+		}
+		return -1
+	}
+	tolerance := medianSpacing(obs)
+	best := -1
+	var bestGap time.Duration
+	for i, o := range obs {
+		gap := o.Date.Sub(at)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap <= tolerance && (best < 0 || gap < bestGap) {
+			best, bestGap = i, gap
+		}
+	}
+	return best
+}
+
+// ─── Rebase ───────────────────────────────────────────────────────────────────
+
+// RebaseMode selects the reference value Rebase scales to 100.
+type RebaseMode string
+
+const (
+	RebaseFirst RebaseMode = "first" // first non-NaN value
+	RebaseLast  RebaseMode = "last"  // last non-NaN value
+	RebaseMax   RebaseMode = "max"
+	RebaseMin   RebaseMode = "min"
+	RebaseMean  RebaseMode = "mean"
+)
+
+// Rebase re-scales the series so the reference value chosen by mode equals
+// 100, the anchor-free complement to Index. NaN values are skipped when
+// finding the reference and stay NaN in the output.
+func Rebase(obs []model.Observation, mode RebaseMode) ([]model.Observation, error) {
+	switch mode {
+	case RebaseFirst, RebaseLast, RebaseMax, RebaseMin, RebaseMean:
+	default:
+		return nil, fmt.Errorf("rebase: unknown mode %q (use first, last, max, min, mean)", mode)
+	}
+	var vals []float64
+	for _, o := range obs {
+		if !math.IsNaN(o.Value) {
+			vals = append(vals, o.Value)
+		}
+	}
+	ref := math.NaN()
+	if len(vals) > 0 {
+		switch mode {
+		case RebaseFirst:
+			ref = vals[0]
+		case RebaseLast:
+			ref = vals[len(vals)-1]
+		case RebaseMax:
+			_, ref = minmax(vals)
+		case RebaseMin:
+			ref, _ = minmax(vals)
+		case RebaseMean:
+			ref = mean(vals)
+		}
+	}
+	if math.IsNaN(ref) {
+		return nil, fmt.Errorf("rebase: no non-NaN values to take the %s of", mode)
+	}
+	if ref == 0 {
+		return nil, fmt.Errorf("rebase: %s value is zero, cannot rebase", mode)
+	}
+
+	scale := 100 / ref
+	out := make([]model.Observation, len(obs))
+	for i, o := range obs {
+		val := o.Value * scale // NaN stays NaN
+		out[i] = model.Observation{
+			Date:     o.Date,
+			Value:    val,
+			ValueRaw: formatRaw(val),
+		}
+	}
+	return out, nil
+}
+
 // ─── Normalize ────────────────────────────────────────────────────────────────
 
 // NormalizeMethod selects the normalization algorithm.
@@ -166,10 +437,14 @@ type NormalizeMethod string
 const (
 	NormalizeZScore NormalizeMethod = "zscore"
 	NormalizeMinMax NormalizeMethod = "minmax"
+	// NormalizeRobust centers on the median and scales by the interquartile
+	// range, so a few extreme values barely move the result.
+	NormalizeRobust NormalizeMethod = "robust"
 )
 
-// Normalize scales observations using z-score or min-max normalization.
-// NaN values are skipped when computing statistics but preserved in output.
+// Normalize scales observations using z-score, min-max, or robust (median
+// and IQR) normalization. NaN values are skipped when computing statistics
+// but preserved in output.
 func Normalize(obs []model.Observation, method NormalizeMethod) ([]model.Observation, error) {
 	// Collect non-NaN values
 	var vals []float64
@@ -186,7 +461,7 @@ func Normalize(obs []model.Observation, method NormalizeMethod) ([]model.Observa
 	switch method {
 	case NormalizeZScore:
 		mean := mean(vals)
-		std := stddev(vals, mean)
+		std := stddev(vals, mean, 1)
 		if std == 0 {
 			return nil, fmt.Errorf("normalize: standard deviation is zero, cannot z-score")
 		}
@@ -198,8 +473,16 @@ func Normalize(obs []model.Observation, method NormalizeMethod) ([]model.Observa
 			return nil, fmt.Errorf("normalize: min == max (%g), cannot min-max normalize", mn)
 		}
 		a, b = mn, rng
+	case NormalizeRobust:
+		sorted := append([]float64(nil), vals...)
+		sort.Float64s(sorted)
+		iqr := quantile(sorted, 75) - quantile(sorted, 25)
+		if iqr == 0 {
+			return nil, fmt.Errorf("normalize: interquartile range is zero (the middle half of the values all equal %g), cannot robust-scale", quantile(sorted, 25))
+		}
+		a, b = quantile(sorted, 50), iqr
 	default:
-		return nil, fmt.Errorf("normalize: unknown method %q (use zscore or minmax)", method)
+		return nil, fmt.Errorf("normalize: unknown method %q (use zscore, minmax, or robust)", method)
 	}
 
 	out := make([]model.Observation, len(obs))
@@ -225,6 +508,8 @@ func Normalize(obs []model.Observation, method NormalizeMethod) ([]model.Observa
 type ResampleFreq string
 
 const (
+	ResampleDaily     ResampleFreq = "daily"
+	ResampleWeekly    ResampleFreq = "weekly"
 	ResampleMonthly   ResampleFreq = "monthly"
 	ResampleQuarterly ResampleFreq = "quarterly"
 	ResampleAnnual    ResampleFreq = "annual"
@@ -234,14 +519,33 @@ const (
 type ResampleMethod string
 
 const (
-	ResampleMean ResampleMethod = "mean"
-	ResampleLast ResampleMethod = "last"
-	ResampleSum  ResampleMethod = "sum"
+	ResampleMean  ResampleMethod = "mean"
+	ResampleFirst ResampleMethod = "first"
+	ResampleLast  ResampleMethod = "last"
+	ResampleSum   ResampleMethod = "sum"
 )
 
+// ResampleOptions holds optional behaviour for ResampleWithOptions.
+type ResampleOptions struct {
+	// FillMissing emits a NaN row for every period between the first and
+	// last one that has no observations at all, so the output is a complete
+	// calendar grid. Without it such periods are omitted.
+	FillMissing bool
+}
+
 // Resample aggregates observations to a lower frequency.
 // Observations are grouped by period; NaN values are skipped in aggregation.
 func Resample(obs []model.Observation, freq ResampleFreq, method ResampleMethod) ([]model.Observation, error) {
+	return ResampleWithOptions(obs, freq, method, ResampleOptions{})
+}
+
+// ResampleWithOptions is Resample with the behaviour in opts.
+func ResampleWithOptions(obs []model.Observation, freq ResampleFreq, method ResampleMethod, opts ResampleOptions) ([]model.Observation, error) {
+	switch method {
+	case ResampleMean, ResampleFirst, ResampleLast, ResampleSum:
+	default:
+		return nil, fmt.Errorf("resample: unknown method %q (use mean, first, last, sum)", method)
+	}
 	if len(obs) == 0 {
 		return nil, fmt.Errorf("resample: empty input")
 	}
@@ -260,6 +564,10 @@ func Resample(obs []model.Observation, freq ResampleFreq, method ResampleMethod)
 		}
 	}
 
+	if opts.FillMissing {
+		fillPeriodGaps(keys, freq)
+	}
+
 	// Sort period keys
 	sorted := make([]string, 0, len(keys))
 	for k := range keys {
@@ -277,12 +585,12 @@ func Resample(obs []model.Observation, freq ResampleFreq, method ResampleMethod)
 			switch method {
 			case ResampleMean:
 				val = mean(vals)
+			case ResampleFirst:
+				val = vals[0]
 			case ResampleLast:
 				val = vals[len(vals)-1]
 			case ResampleSum:
 				val = sum(vals)
-			default:
-				return nil, fmt.Errorf("resample: unknown method %q (use mean, last, sum)", method)
 			}
 		}
 		out = append(out, model.Observation{
@@ -294,9 +602,54 @@ func Resample(obs []model.Observation, freq ResampleFreq, method ResampleMethod)
 	return out, nil
 }
 
-// periodKey returns a sortable string key and canonical start date for a period.
+// fillPeriodGaps adds every period between the earliest and latest start in
+// keys that is not already there.
+func fillPeriodGaps(keys map[string]time.Time, freq ResampleFreq) {
+	var first, last time.Time
+	for _, start := range keys {
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+	}
+	for t := first; !t.After(last); t = nextPeriod(t, freq) {
+		key, start := periodKey(t, freq)
+		if _, exists := keys[key]; !exists {
+			keys[key] = start
+		}
+	}
+}
+
+// nextPeriod returns the start of the period after the one starting at t.
+func nextPeriod(t time.Time, freq ResampleFreq) time.Time {
+	switch freq {
+	case ResampleDaily:
+		return t.AddDate(0, 0, 1)
+	case ResampleWeekly:
+		return t.AddDate(0, 0, 7)
+	case ResampleQuarterly:
+		return t.AddDate(0, 3, 0)
+	case ResampleAnnual:
+		return t.AddDate(1, 0, 0)
+	default: // monthly
+		return t.AddDate(0, 1, 0)
+	}
+}
+
+// periodKey returns a sortable string key and canonical start date for a
+// period. Weeks are ISO weeks, keyed YYYY-WNN and starting on Monday.
 func periodKey(t time.Time, freq ResampleFreq) (string, time.Time) {
 	switch freq {
+	case ResampleDaily:
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start.Format("2006-01-02"), start
+	case ResampleWeekly:
+		year, week := t.ISOWeek()
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		return fmt.Sprintf("%04d-W%02d", year, week), start
 	case ResampleQuarterly:
 		q := (t.Month()-1)/3 + 1
 		start := time.Date(t.Year(), time.Month((q-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
@@ -310,25 +663,117 @@ func periodKey(t time.Time, freq ResampleFreq) (string, time.Time) {
 	}
 }
 
+// Upsample methods fill the periods between two source observations.
+const (
+	UpsampleFFill  = "ffill"  // carry the last value forward
+	UpsampleLinear = "linear" // step evenly from one value to the next
+)
+
+// Upsample converts observations to a higher frequency. Each observation is
+// placed at the start of its target period, and every period from the first
+// observation's to the last one's is filled: ffill repeats the last known
+// value, linear steps evenly between the known values on either side. The
+// output stops at the last observation rather than filling the rest of its
+// period. Periods before the first non-NaN value are NaN. Two observations
+// in the same target period mean the input is not coarser than freq, which
+// is an error.
+func Upsample(obs []model.Observation, freq ResampleFreq, method string) ([]model.Observation, error) {
+	if len(obs) == 0 {
+		return nil, fmt.Errorf("upsample: empty input")
+	}
+	var months int
+	switch freq {
+	case ResampleMonthly:
+		months = 1
+	case ResampleQuarterly:
+		months = 3
+	case ResampleAnnual:
+		months = 12
+	default:
+		return nil, fmt.Errorf("upsample: unsupported frequency %q (use monthly, quarterly, annual)", freq)
+	}
+	if method != UpsampleFFill && method != UpsampleLinear {
+		return nil, fmt.Errorf("upsample: unknown method %q (use ffill, linear)", method)
+	}
+
+	// Known values keyed by target period start, in date order.
+	var anchors []model.Observation
+	for i, o := range obs {
+		_, start := periodKey(o.Date, freq)
+		if i > 0 {
+			_, prev := periodKey(obs[i-1].Date, freq)
+			if !start.After(prev) {
+				return nil, fmt.Errorf("upsample: %s and %s fall in the same %s period; the input is not coarser than %s",
+					obs[i-1].Date.Format("2006-01-02"), o.Date.Format("2006-01-02"), freq, freq)
+			}
+		}
+		if !math.IsNaN(o.Value) {
+			anchors = append(anchors, model.Observation{Date: start, Value: o.Value})
+		}
+	}
+
+	_, first := periodKey(obs[0].Date, freq)
+	_, last := periodKey(obs[len(obs)-1].Date, freq)
+	var out []model.Observation
+	k := -1 // index of the last anchor at or before d
+	for d := first; !d.After(last); d = d.AddDate(0, months, 0) {
+		for k+1 < len(anchors) && !anchors[k+1].Date.After(d) {
+			k++
+		}
+		val := math.NaN()
+		switch {
+		case k < 0:
+		case anchors[k].Date.Equal(d) || method == UpsampleFFill:
+			val = anchors[k].Value
+		case k+1 < len(anchors):
+			a, b := anchors[k], anchors[k+1]
+			frac := float64(monthsBetween(a.Date, d)) / float64(monthsBetween(a.Date, b.Date))
+			val = a.Value + (b.Value-a.Value)*frac
+		}
+		out = append(out, model.Observation{Date: d, Value: val, ValueRaw: formatRaw(val)})
+	}
+	return out, nil
+}
+
+func monthsBetween(a, b time.Time) int {
+	return (b.Year()-a.Year())*12 + int(b.Month()-a.Month())
+}
+
 // ─── Filter ───────────────────────────────────────────────────────────────────
 
-// FilterOptions describes a date/value filter predicate.
+// FilterOptions describes a date/value filter predicate. Date bounds are
+// exclusive unless the matching Inclusive flag is set.
 type FilterOptions struct {
-	After       time.Time // keep obs with date > After (zero = no lower bound)
-	Before      time.Time // keep obs with date < Before (zero = no upper bound)
-	MinValue    float64   // keep obs with value >= MinValue (NaN = no lower bound)
-	MaxValue    float64   // keep obs with value <= MaxValue (NaN = no upper bound)
-	DropMissing bool      // drop NaN observations
+	After           time.Time // keep obs with date > After (zero = no lower bound)
+	Before          time.Time // keep obs with date < Before (zero = no upper bound)
+	AfterInclusive  bool      // also keep obs dated exactly After
+	BeforeInclusive bool      // also keep obs dated exactly Before
+	MinValue        float64   // keep obs with value >= MinValue (NaN = no lower bound)
+	MaxValue        float64   // keep obs with value <= MaxValue (NaN = no upper bound)
+	DropMissing     bool      // drop NaN observations
+}
+
+// DateWindow returns the FilterOptions that keep observations dated from lo
+// through hi inclusive, whatever their value. A zero bound is open.
+func DateWindow(lo, hi time.Time) FilterOptions {
+	return FilterOptions{
+		After:           lo,
+		AfterInclusive:  true,
+		Before:          hi,
+		BeforeInclusive: true,
+		MinValue:        math.NaN(),
+		MaxValue:        math.NaN(),
+	}
 }
 
 // Filter returns observations matching all non-zero criteria in opts.
 func Filter(obs []model.Observation, opts FilterOptions) []model.Observation {
 	out := make([]model.Observation, 0, len(obs))
 	for _, o := range obs {
-		if !opts.After.IsZero() && !o.Date.After(opts.After) {
+		if !opts.After.IsZero() && !o.Date.After(opts.After) && !(opts.AfterInclusive && o.Date.Equal(opts.After)) {
 			continue
 		}
-		if !opts.Before.IsZero() && !o.Date.Before(opts.Before) {
+		if !opts.Before.IsZero() && !o.Date.Before(opts.Before) && !(opts.BeforeInclusive && o.Date.Equal(opts.Before)) {
 			continue
 		}
 		if math.IsNaN(o.Value) {
@@ -348,23 +793,107 @@ func Filter(obs []model.Observation, opts FilterOptions) []model.Observation {
 	return out
 }
 
+// ─── Clip ─────────────────────────────────────────────────────────────────────
+
+// Clip bounds every value to [lo, hi]. Out-of-band values are set to the
+// nearest boundary; in-band and NaN values pass through unchanged.
+func Clip(obs []model.Observation, lo, hi float64) ([]model.Observation, error) {
+	if math.IsNaN(lo) || math.IsNaN(hi) || lo > hi {
+		return nil, fmt.Errorf("invalid clip range [%g, %g]", lo, hi)
+	}
+	out := make([]model.Observation, len(obs))
+	for i, o := range obs {
+		out[i] = o
+		if math.IsNaN(o.Value) {
+			continue
+		}
+		if o.Value < lo || o.Value > hi {
+			out[i].Value = math.Max(lo, math.Min(hi, o.Value))
+			out[i].ValueRaw = formatRaw(out[i].Value)
+		}
+	}
+	return out, nil
+}
+
+// ObservedRange returns the minimum and maximum non-NaN values in obs.
+func ObservedRange(obs []model.Observation) (float64, float64, error) {
+	vals := make([]float64, 0, len(obs))
+	for _, o := range obs {
+		if !math.IsNaN(o.Value) {
+			vals = append(vals, o.Value)
+		}
+	}
+	if len(vals) == 0 {
+		return 0, 0, fmt.Errorf("no valid (non-NaN) values")
+	}
+	lo, hi := minmax(vals)
+	return lo, hi, nil
+}
+
 // ─── Rolling Window ───────────────────────────────────────────────────────────
 
 // RollStat selects the statistic for rolling window computation.
 type RollStat string
 
 const (
-	RollMean RollStat = "mean"
-	RollStd  RollStat = "std"
-	RollMin  RollStat = "min"
-	RollMax  RollStat = "max"
-	RollSum  RollStat = "sum"
+	RollMean   RollStat = "mean"
+	RollMedian RollStat = "median"
+	RollStd    RollStat = "std"
+	RollVar    RollStat = "var"
+	RollMin    RollStat = "min"
+	RollMax    RollStat = "max"
+	RollSum    RollStat = "sum"
+	// RollEWMMean weights the most recent non-NaN value in the window by 1
+	// and each earlier one by a further factor of (1-alpha), normalized by
+	// the total weight.
+	RollEWMMean RollStat = "ewm-mean"
 )
 
+// RollStats lists every RollStat in the order help text shows them.
+var RollStats = []RollStat{RollMean, RollMedian, RollStd, RollVar, RollMin, RollMax, RollSum, RollEWMMean}
+
+// RollOptions holds optional behaviour for RollWithOptions.
+type RollOptions struct {
+	// DDOF is the delta degrees of freedom for RollStd and RollVar: 1
+	// divides by n-1 (sample), 0 by n (population).
+	DDOF int
+	// Alpha is the smoothing factor for RollEWMMean, in (0, 1]. It is
+	// required for that stat and ignored by the others.
+	Alpha float64
+}
+
 // Roll computes a rolling window statistic. Window observations include the
 // current point and the (window-1) preceding points. NaN values are skipped.
 // If fewer than minPeriods non-NaN values exist in a window, the output is NaN.
+// RollStd and RollVar are the sample statistics; see RollDDOF. RollMedian
+// of an even count averages the two central values.
 func Roll(obs []model.Observation, window int, minPeriods int, stat RollStat) ([]model.Observation, error) {
+	return RollDDOF(obs, window, minPeriods, stat, 1)
+}
+
+// RollDDOF is Roll with the delta degrees of freedom for RollStd and RollVar:
+// 1 divides by n-1 (sample), 0 by n (population). A window holding a single
+// value has a std and var of 0 under either.
+func RollDDOF(obs []model.Observation, window int, minPeriods int, stat RollStat, ddof int) ([]model.Observation, error) {
+	return RollWithOptions(obs, window, minPeriods, stat, RollOptions{DDOF: ddof})
+}
+
+// RollWithOptions is Roll with the behaviour in opts.
+func RollWithOptions(obs []model.Observation, window int, minPeriods int, stat RollStat, opts RollOptions) ([]model.Observation, error) {
+	ddof := opts.DDOF
+	if !slices.Contains(RollStats, stat) {
+		names := make([]string, len(RollStats))
+		for i, s := range RollStats {
+			names[i] = string(s)
+		}
+		return nil, fmt.Errorf("roll: unknown stat %q; valid stats are %s", stat, strings.Join(names, ", "))
+	}
+	if ddof != 0 && ddof != 1 {
+		return nil, fmt.Errorf("roll: ddof must be 0 or 1, got %d", ddof)
+	}
+	if stat == RollEWMMean && !(opts.Alpha > 0 && opts.Alpha <= 1) {
+		return nil, fmt.Errorf("roll: ewm-mean needs alpha in (0, 1], got %g", opts.Alpha)
+	}
 	if window < 1 {
 		return nil, fmt.Errorf("roll: window must be >= 1, got %d", window)
 	}
@@ -398,16 +927,21 @@ func Roll(obs []model.Observation, window int, minPeriods int, stat RollStat) ([
 			switch stat {
 			case RollMean:
 				val = mean(vals)
+			case RollMedian:
+				val = median(vals)
 			case RollStd:
-				val = stddev(vals, mean(vals))
+				val = stddev(vals, mean(vals), ddof)
+			case RollVar:
+				sd := stddev(vals, mean(vals), ddof)
+				val = sd * sd
 			case RollMin:
 				val, _ = minmax(vals)
 			case RollMax:
 				_, val = minmax(vals)
 			case RollSum:
 				val = sum(vals)
-			default:
-				return nil, fmt.Errorf("roll: unknown stat %q (use mean, std, min, max, sum)", stat)
+			case RollEWMMean:
+				val = ewmMean(vals, opts.Alpha)
 			}
 		}
 		out[i] = model.Observation{
@@ -419,6 +953,136 @@ func Roll(obs []model.Observation, window int, minPeriods int, stat RollStat) ([
 	return out, nil
 }
 
+// ─── Join ─────────────────────────────────────────────────────────────────────
+
+// JoinMode selects how Join matches the dates of two series.
+type JoinMode string
+
+const (
+	// JoinInner keeps only the dates both series report.
+	JoinInner JoinMode = "inner"
+	// JoinRight aligns series of different frequencies at period end: each
+	// date of the coarser series takes the finer series' last value within
+	// that period. For series on the same dates it is the inner join.
+	JoinRight JoinMode = "right"
+	// JoinLeft keeps every date of the first series; B is NaN where the
+	// second series has no observation.
+	JoinLeft JoinMode = "left"
+	// JoinOuter keeps every date either series reports, with NaN for the
+	// side that has no observation.
+	JoinOuter JoinMode = "outer"
+)
+
+// JoinedRow is one date of two joined series, A and B in argument order.
+type JoinedRow struct {
+	Date time.Time
+	A, B float64
+}
+
+// Join pairs two date-ordered series. JoinInner and JoinRight drop dates
+// where either value is missing; JoinLeft and JoinOuter keep them as NaN.
+// Under JoinRight the coarser series is the one with the longer median
+// spacing between observations (a on a tie), and its dates label the rows.
+func Join(a, b []model.Observation, mode JoinMode) ([]JoinedRow, error) {
+	switch mode {
+	case JoinInner, JoinRight:
+	case JoinLeft, JoinOuter:
+		return joinKeepingGaps(a, b, mode == JoinOuter), nil
+	default:
+		return nil, fmt.Errorf("join: unknown mode %q (use inner, right, left, or outer)", mode)
+	}
+	if mode == JoinRight {
+		sa, sb := medianSpacing(a), medianSpacing(b)
+		if sb > sa {
+			return joinAtPeriodEnd(b, a, sb, true), nil
+		}
+		return joinAtPeriodEnd(a, b, sa, false), nil
+	}
+	bByDate := make(map[time.Time]float64, len(b))
+	for _, o := range b {
+		if !math.IsNaN(o.Value) {
+			bByDate[o.Date] = o.Value
+		}
+	}
+	var rows []JoinedRow
+	for _, o := range a {
+		if v, ok := bByDate[o.Date]; ok && !math.IsNaN(o.Value) {
+			rows = append(rows, JoinedRow{Date: o.Date, A: o.Value, B: v})
+		}
+	}
+	return rows, nil
+}
+
+// joinKeepingGaps merges the dates of a and b in order. Dates only b reports
+// are kept when outer is set.
+func joinKeepingGaps(a, b []model.Observation, outer bool) []JoinedRow {
+	var rows []JoinedRow
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i].Date.Before(b[j].Date)):
+			rows = append(rows, JoinedRow{Date: a[i].Date, A: a[i].Value, B: math.NaN()})
+			i++
+		case i == len(a) || b[j].Date.Before(a[i].Date):
+			if outer {
+				rows = append(rows, JoinedRow{Date: b[j].Date, A: math.NaN(), B: b[j].Value})
+			}
+			j++
+		default:
+			rows = append(rows, JoinedRow{Date: a[i].Date, A: a[i].Value, B: b[j].Value})
+			i++
+			j++
+		}
+	}
+	return rows
+}
+
+// joinAtPeriodEnd labels rows with coarse's dates. The period of each runs up
+// to the next coarse date, or one median spacing for the last. swapped means
+// coarse was the B argument.
+func joinAtPeriodEnd(coarse, fine []model.Observation, spacing time.Duration, swapped bool) []JoinedRow {
+	var rows []JoinedRow
+	j := 0
+	for i, c := range coarse {
+		end := c.Date.Add(spacing)
+		if i+1 < len(coarse) {
+			end = coarse[i+1].Date
+		}
+		for j < len(fine) && fine[j].Date.Before(c.Date) {
+			j++
+		}
+		last := math.NaN()
+		for ; j < len(fine) && fine[j].Date.Before(end); j++ {
+			if !math.IsNaN(fine[j].Value) {
+				last = fine[j].Value
+			}
+		}
+		if math.IsNaN(c.Value) || math.IsNaN(last) {
+			continue
+		}
+		row := JoinedRow{Date: c.Date, A: c.Value, B: last}
+		if swapped {
+			row.A, row.B = last, c.Value
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// medianSpacing is the median gap between consecutive observation dates, or
+// zero with fewer than two observations.
+func medianSpacing(obs []model.Observation) time.Duration {
+	if len(obs) < 2 {
+		return 0
+	}
+	gaps := make([]time.Duration, 0, len(obs)-1)
+	for i := 1; i < len(obs); i++ {
+		gaps = append(gaps, obs[i].Date.Sub(obs[i-1].Date))
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps[len(gaps)/2]
+}
+
 // ─── Math helpers ─────────────────────────────────────────────────────────────
 
 func mean(vals []float64) float64 {
@@ -436,7 +1100,42 @@ func sum(vals []float64) float64 {
 	return s
 }
 
-func stddev(vals []float64, m float64) float64 {
+// median returns the middle of vals, averaging the two central values for an
+// even count. vals is not modified.
+// ewmMean is the exponentially weighted mean of vals, oldest first: the last
+// value has weight 1 and each earlier one (1-alpha) times the next.
+func ewmMean(vals []float64, alpha float64) float64 {
+	var num, den float64
+	w := 1.0
+	for i := len(vals) - 1; i >= 0; i-- {
+		num += w * vals[i]
+		den += w
+		w *= 1 - alpha
+	}
+	return num / den
+}
+
+func median(vals []float64) float64 {
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+	return quantile(sorted, 50)
+}
+
+// quantile is the p-th percentile (0-100) of sorted, an ascending non-empty
+// slice, interpolating linearly between ranks as analyze.Quantile does.
+func quantile(sorted []float64, p float64) float64 {
+	idx := p / 100 * float64(len(sorted)-1)
+	lo := int(idx)
+	if lo+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	frac := idx - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[lo+1]*frac
+}
+
+// stddev divides the squared deviations by n-ddof. Fewer than two values
+// give 0.
+func stddev(vals []float64, m float64, ddof int) float64 {
 	if len(vals) < 2 {
 		return 0
 	}
@@ -445,7 +1144,7 @@ func stddev(vals []float64, m float64) float64 {
 		d := v - m
 		sq += d * d
 	}
-	return math.Sqrt(sq / float64(len(vals)-1))
+	return math.Sqrt(sq / float64(len(vals)-ddof))
 }
 
 func minmax(vals []float64) (float64, float64) {
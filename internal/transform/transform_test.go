@@ -5,6 +5,7 @@ package transform_test
 
 import (
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -70,7 +71,7 @@ func values(obs []model.Observation) []float64 {
 func TestPctChangePeriod1(t *testing.T) {
 	// 100 → 110 → 121: each is +10%
 	obs := makeObs(2020, 1, 100.0, 110.0, 121.0)
-	out, err := transform.PctChange(obs, 1)
+	out, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -93,7 +94,7 @@ func TestPctChangePeriod12(t *testing.T) {
 	}
 	vals[12] = 110.0
 	obs := makeObs(2020, 1, vals...)
-	out, err := transform.PctChange(obs, 12)
+	out, _, err := transform.PctChange(obs, 12, false, transform.PctChangeStandard)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -107,7 +108,7 @@ func TestPctChangePeriod12(t *testing.T) {
 
 func TestPctChangeNaNPropagates(t *testing.T) {
 	obs := makeObs(2020, 1, 100.0, math.NaN(), 110.0)
-	out, err := transform.PctChange(obs, 1)
+	out, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -122,7 +123,7 @@ func TestPctChangeNaNPropagates(t *testing.T) {
 
 func TestPctChangeZeroDenominator(t *testing.T) {
 	obs := makeObs(2020, 1, 0.0, 100.0)
-	out, err := transform.PctChange(obs, 1)
+	out, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -133,7 +134,7 @@ func TestPctChangeZeroDenominator(t *testing.T) {
 
 func TestPctChangeInvalidPeriod(t *testing.T) {
 	obs := makeObs(2020, 1, 1.0, 2.0, 3.0)
-	_, err := transform.PctChange(obs, 0)
+	_, _, err := transform.PctChange(obs, 0, false, transform.PctChangeStandard)
 	if err == nil {
 		t.Error("expected error for period=0")
 	}
@@ -141,7 +142,7 @@ func TestPctChangeInvalidPeriod(t *testing.T) {
 
 func TestPctChangeTooFewObs(t *testing.T) {
 	obs := makeObs(2020, 1, 1.0)
-	_, err := transform.PctChange(obs, 1)
+	_, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
 	if err == nil {
 		t.Error("expected error when len(obs) <= period")
 	}
@@ -149,7 +150,7 @@ func TestPctChangeTooFewObs(t *testing.T) {
 
 func TestPctChangeOutputLength(t *testing.T) {
 	obs := makeObs(2020, 1, 1, 2, 3, 4, 5)
-	out, err := transform.PctChange(obs, 1)
+	out, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -161,13 +162,232 @@ func TestPctChangeOutputLength(t *testing.T) {
 
 func TestPctChangeDatesPreserved(t *testing.T) {
 	obs := makeObs(2020, 1, 100.0, 110.0, 121.0)
-	out, _ := transform.PctChange(obs, 1)
+	out, _, _ := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
 	// Dates should align with the current (not prior) observation
 	if !out[0].Date.Equal(obs[1].Date) {
 		t.Errorf("date mismatch: expected %v, got %v", obs[1].Date, out[0].Date)
 	}
 }
 
+func TestPctChangeKeepLength(t *testing.T) {
+	obs := makeObs(2020, 1, 100.0, 110.0, 121.0, 133.1)
+	out, _, err := transform.PctChange(obs, 2, true, transform.PctChangeStandard)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(obs) {
+		t.Fatalf("expected %d outputs, got %d", len(obs), len(out))
+	}
+	for i := range obs {
+		if !out[i].Date.Equal(obs[i].Date) {
+			t.Errorf("[%d] date mismatch: expected %v, got %v", i, obs[i].Date, out[i].Date)
+		}
+	}
+	if !isNaN(out[0].Value) || !isNaN(out[1].Value) {
+		t.Errorf("expected leading NaN rows, got %v, %v", out[0].Value, out[1].Value)
+	}
+	if out[0].ValueRaw != "." {
+		t.Errorf("expected NaN raw value %q, got %q", ".", out[0].ValueRaw)
+	}
+	if !approxEqual(out[2].Value, 21.0, 1e-9) {
+		t.Errorf("expected 21.0, got %v", out[2].Value)
+	}
+}
+
+func TestPctChangeKeepLengthChains(t *testing.T) {
+	// Three observations: without padding the second pass would see only two
+	// rows and the third would fail.
+	obs := makeObs(2020, 1, 100.0, 110.0, 132.0)
+	first, _, err := transform.PctChange(obs, 1, true, transform.PctChangeStandard)
+	if err != nil {
+		t.Fatalf("first pct-change: %v", err)
+	}
+	second, _, err := transform.PctChange(first, 1, true, transform.PctChangeStandard)
+	if err != nil {
+		t.Fatalf("second pct-change: %v", err)
+	}
+	if len(second) != len(obs) {
+		t.Fatalf("expected %d outputs, got %d", len(obs), len(second))
+	}
+	// first = [NaN, 10, 20]; second = [NaN, NaN, 100]
+	if !isNaN(second[0].Value) || !isNaN(second[1].Value) {
+		t.Errorf("expected NaN for rows 0 and 1, got %v, %v", second[0].Value, second[1].Value)
+	}
+	if !approxEqual(second[2].Value, 100.0, 1e-9) {
+		t.Errorf("expected 100.0, got %v", second[2].Value)
+	}
+	if !second[2].Date.Equal(obs[2].Date) {
+		t.Errorf("date drift: expected %v, got %v", obs[2].Date, second[2].Date)
+	}
+}
+
+func TestPctChangeLogConstantForExponentialGrowth(t *testing.T) {
+	obs := makeObs(2020, 1, 100, 100*math.Exp(0.05), 100*math.Exp(0.10), 100*math.Exp(0.15))
+	out, warnings, err := transform.PctChange(obs, 1, false, transform.PctChangeLog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+	if len(out) != 3 {
+		t.Fatalf("expected 3 outputs, got %d", len(out))
+	}
+	for i, o := range out {
+		if !approxEqual(o.Value, 0.05, 1e-12) {
+			t.Errorf("[%d] expected 0.05, got %v", i, o.Value)
+		}
+	}
+}
+
+func TestPctChangeLogNonPositive(t *testing.T) {
+	obs := makeObs(2020, 1, 100, 0, 110, -5, 120)
+	out, warnings, err := transform.PctChange(obs, 1, false, transform.PctChangeLog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Every output touching the zero or the negative value is NaN.
+	for i := 0; i < 4; i++ {
+		if !isNaN(out[i].Value) {
+			t.Errorf("[%d] expected NaN, got %v", i, out[i].Value)
+		}
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %d: %v", len(warnings), warnings)
+	}
+	if !strings.Contains(warnings[0], "2020-02-01") || !strings.Contains(warnings[1], "2020-04-01") {
+		t.Errorf("warnings should name the offending dates, got %v", warnings)
+	}
+}
+
+func TestPctChangeLogApproximatesStandardForSmallChanges(t *testing.T) {
+	obs := makeObs(2020, 1, 100, 100.2, 100.1, 100.4)
+	std, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
+	if err != nil {
+		t.Fatalf("standard: %v", err)
+	}
+	lg, _, err := transform.PctChange(obs, 1, false, transform.PctChangeLog)
+	if err != nil {
+		t.Fatalf("log: %v", err)
+	}
+	for i := range std {
+		if !approxEqual(lg[i].Value, std[i].Value/100, 1e-5) {
+			t.Errorf("[%d] log %v vs standard/100 %v", i, lg[i].Value, std[i].Value/100)
+		}
+	}
+}
+
+func TestPctChangeUnknownMethod(t *testing.T) {
+	obs := makeObs(2020, 1, 1, 2, 3)
+	_, _, err := transform.PctChange(obs, 1, false, "geometric")
+	if err == nil || !strings.Contains(err.Error(), "unknown method") {
+		t.Errorf("expected unknown method error, got %v", err)
+	}
+}
+
+// ─── LogReturns ───────────────────────────────────────────────────────────────
+
+func TestLogReturns(t *testing.T) {
+	obs := makeObs(2020, 1, 100.0, 110.0, math.NaN(), 121.0, -5.0)
+	out, err := transform.LogReturns(obs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 4 {
+		t.Fatalf("expected 4 outputs, got %d", len(out))
+	}
+	if !out[0].Date.Equal(obs[1].Date) {
+		t.Errorf("first output should be dated at the second input, got %s", out[0].Date)
+	}
+	if want := 100 * math.Log(1.1); !approxEqual(out[0].Value, want, 1e-9) {
+		t.Errorf("out[0]: expected %g, got %g", want, out[0].Value)
+	}
+	for i := 1; i < 4; i++ {
+		if !isNaN(out[i].Value) {
+			t.Errorf("out[%d]: expected NaN after a missing or non-positive value, got %g", i, out[i].Value)
+		}
+	}
+}
+
+func TestLogReturnsIsPercentLogPctChange(t *testing.T) {
+	obs := makeObs(2020, 1, 100.0, 104.0, 99.0, 103.5)
+	ret, err := transform.LogReturns(obs)
+	if err != nil {
+		t.Fatalf("LogReturns: %v", err)
+	}
+	frac, _, err := transform.PctChange(obs, 1, false, transform.PctChangeLog)
+	if err != nil {
+		t.Fatalf("PctChange: %v", err)
+	}
+	for i := range ret {
+		if !approxEqual(ret[i].Value, 100*frac[i].Value, 1e-12) {
+			t.Errorf("row %d: LogReturns %g, want 100 × %g", i, ret[i].Value, frac[i].Value)
+		}
+	}
+}
+
+func TestLogReturnsTooShort(t *testing.T) {
+	if _, err := transform.LogReturns(makeObs(2020, 1, 100.0)); err == nil {
+		t.Error("expected error for a single observation")
+	}
+}
+
+// ─── Annualize ────────────────────────────────────────────────────────────────
+
+func TestAnnualizeMonthlyRate(t *testing.T) {
+	obs := makeObs(2020, 1, 1.0, 0, -2.0, math.NaN(), -100)
+	out, err := transform.Annualize(obs, 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(obs) {
+		t.Fatalf("expected %d outputs, got %d", len(obs), len(out))
+	}
+	want := []float64{(math.Pow(1.01, 12) - 1) * 100, 0, (math.Pow(0.98, 12) - 1) * 100}
+	for i, w := range want {
+		if !approxEqual(out[i].Value, w, 1e-9) {
+			t.Errorf("out[%d]: expected %g, got %g", i, w, out[i].Value)
+		}
+		if !out[i].Date.Equal(obs[i].Date) {
+			t.Errorf("out[%d]: date changed to %s", i, out[i].Date)
+		}
+	}
+	if !approxEqual(out[0].Value, 12.6825, 1e-4) {
+		t.Errorf("1%% a month should annualize to about 12.68%%, got %g", out[0].Value)
+	}
+	if !isNaN(out[3].Value) || !isNaN(out[4].Value) {
+		t.Errorf("NaN and -100%% should annualize to NaN, got %g and %g", out[3].Value, out[4].Value)
+	}
+}
+
+func TestAnnualizeChainsFromPctChange(t *testing.T) {
+	obs := makeObs(2020, 1, 100, 100.5, 101.0025, 101.5075)
+	pct, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
+	if err != nil {
+		t.Fatalf("PctChange: %v", err)
+	}
+	freq, periods := model.DetectFrequency(pct)
+	if freq != model.FrequencyMonthly {
+		t.Fatalf("pct-change output should still read as monthly, got %s", freq)
+	}
+	out, err := transform.Annualize(pct, int(periods))
+	if err != nil {
+		t.Fatalf("Annualize: %v", err)
+	}
+	want := (math.Pow(1.005, 12) - 1) * 100
+	for i, o := range out {
+		if !approxEqual(o.Value, want, 1e-3) {
+			t.Errorf("out[%d]: expected %g, got %g", i, want, o.Value)
+		}
+	}
+}
+
+func TestAnnualizeInvalidPeriods(t *testing.T) {
+	if _, err := transform.Annualize(makeObs(2020, 1, 1.0), 0); err == nil {
+		t.Error("expected error for periods = 0")
+	}
+}
+
 // ─── Diff ─────────────────────────────────────────────────────────────────────
 
 func TestDiffOrder1(t *testing.T) {
@@ -218,6 +438,205 @@ func TestDiffNaNPropagates(t *testing.T) {
 	}
 }
 
+// ─── SeasonalDiff ─────────────────────────────────────────────────────────────
+
+func TestSeasonalDiffLag(t *testing.T) {
+	obs := makeObs(2020, 1, 10, 20, 30, 13, 24, math.NaN(), 19)
+	out, err := transform.SeasonalDiff(obs, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 4 {
+		t.Fatalf("expected 4 outputs, got %d", len(out))
+	}
+	if !out[0].Date.Equal(obs[3].Date) {
+		t.Errorf("first output should be dated %s, got %s", obs[3].Date, out[0].Date)
+	}
+	for i, exp := range []float64{3, 4} {
+		if !approxEqual(out[i].Value, exp, 1e-9) {
+			t.Errorf("out[%d]: expected %g, got %g", i, exp, out[i].Value)
+		}
+	}
+	if !isNaN(out[2].Value) {
+		t.Errorf("out[2]: expected NaN from a NaN input, got %g", out[2].Value)
+	}
+	if !approxEqual(out[3].Value, 6, 1e-9) {
+		t.Errorf("out[3]: expected 6, got %g", out[3].Value)
+	}
+}
+
+func TestSeasonalDiffRejectsBadInput(t *testing.T) {
+	if _, err := transform.SeasonalDiff(makeObs(2020, 1, 1, 2, 3), 0); err == nil {
+		t.Error("expected error for lag 0")
+	}
+	if _, err := transform.SeasonalDiff(makeObs(2020, 1, 1, 2, 3), 3); err == nil {
+		t.Error("expected error for a series no longer than the lag")
+	}
+}
+
+// ─── Shift ────────────────────────────────────────────────────────────────────
+
+func TestShiftForwardAndBack(t *testing.T) {
+	obs := makeObs(2020, 1, 1, 2, 3, 4)
+	fwd := transform.Shift(obs, 2)
+	if len(fwd) != 2 {
+		t.Fatalf("expected 2 outputs, got %d", len(fwd))
+	}
+	if !fwd[0].Date.Equal(obs[2].Date) || fwd[0].Value != 1 || fwd[1].Value != 2 {
+		t.Errorf("shift +2: got %s=%g, %g", fwd[0].Date, fwd[0].Value, fwd[1].Value)
+	}
+	back := transform.Shift(obs, -1)
+	if len(back) != 3 {
+		t.Fatalf("expected 3 outputs, got %d", len(back))
+	}
+	if !back[0].Date.Equal(obs[0].Date) || back[0].Value != 2 || back[2].Value != 4 {
+		t.Errorf("shift -1: got %s=%g ... %g", back[0].Date, back[0].Value, back[2].Value)
+	}
+	if got := transform.Shift(obs, 0); len(got) != 4 || got[3].Value != 4 {
+		t.Errorf("shift 0 should return the series unchanged, got %v", got)
+	}
+	if got := transform.Shift(obs, 5); len(got) != 0 {
+		t.Errorf("shift past the end should be empty, got %d", len(got))
+	}
+}
+
+// ─── Join ─────────────────────────────────────────────────────────────────────
+
+// makeDaily builds daily observations from a start date.
+func makeDaily(start time.Time, values ...float64) []model.Observation {
+	out := make([]model.Observation, len(values))
+	for i, v := range values {
+		out[i] = model.Observation{Date: start.AddDate(0, 0, i), Value: v}
+	}
+	return out
+}
+
+func TestJoinSameDates(t *testing.T) {
+	a := makeObs(2020, 1, 1, 2, math.NaN(), 4)
+	b := makeObs(2020, 1, 10, 20, 30, 40)
+	for _, mode := range []transform.JoinMode{transform.JoinInner, transform.JoinRight} {
+		rows, err := transform.Join(a, b, mode)
+		if err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		if len(rows) != 3 || rows[2].A != 4 || rows[2].B != 40 {
+			t.Errorf("%s: expected 3 rows ending 4/40, got %+v", mode, rows)
+		}
+	}
+}
+
+func TestJoinRightAlignsAtPeriodEnd(t *testing.T) {
+	monthly := makeObs(2020, 1, 3.5, 3.6)
+	// Jan 1 .. Feb 29, 2020, valued by day number.
+	vals := make([]float64, 60)
+	for i := range vals {
+		vals[i] = float64(i + 1)
+	}
+	daily := makeDaily(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), vals...)
+
+	rows, err := transform.Join(daily, monthly, transform.JoinRight)
+	if err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected one row per month, got %d", len(rows))
+	}
+	// January takes Jan 31 (day 31); February takes Feb 29 (day 60).
+	if rows[0].A != 31 || rows[0].B != 3.5 || rows[1].A != 60 || rows[1].B != 3.6 {
+		t.Errorf("unexpected rows %+v", rows)
+	}
+	if inner, _ := transform.Join(daily, monthly, transform.JoinInner); len(inner) != 2 || inner[0].A != 1 {
+		t.Errorf("inner join should match the first of each month, got %+v", inner)
+	}
+	if _, err := transform.Join(daily, monthly, "cross"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestJoinLeftAndOuterKeepGaps(t *testing.T) {
+	a := makeObs(2020, 1, 1, math.NaN(), 3) // Jan–Mar
+	b := makeObs(2020, 2, 20, 30, 40)       // Feb–Apr
+	left, err := transform.Join(a, b, transform.JoinLeft)
+	if err != nil {
+		t.Fatalf("left: %v", err)
+	}
+	if len(left) != 3 || !math.IsNaN(left[0].B) || !math.IsNaN(left[1].A) || left[1].B != 20 || left[2].B != 30 {
+		t.Errorf("left join should keep a's three dates, got %+v", left)
+	}
+
+	outer, err := transform.Join(a, b, transform.JoinOuter)
+	if err != nil {
+		t.Fatalf("outer: %v", err)
+	}
+	if len(outer) != 4 {
+		t.Fatalf("outer join should keep all four months, got %+v", outer)
+	}
+	if outer[0].A != 1 || !math.IsNaN(outer[0].B) || !math.IsNaN(outer[3].A) || outer[3].B != 40 {
+		t.Errorf("unexpected outer rows %+v", outer)
+	}
+	if !outer[3].Date.Equal(time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("last outer date = %s, want 2020-04-01", outer[3].Date.Format("2006-01-02"))
+	}
+}
+
+func TestDeltaFromPrevSkipsGaps(t *testing.T) {
+	obs := makeObs(2020, 1, 10.0, math.NaN(), 15.0, 14.5)
+	got := transform.DeltaFromPrev(obs)
+	if len(got) != len(obs) {
+		t.Fatalf("expected %d deltas, got %d", len(obs), len(got))
+	}
+	if !isNaN(got[0]) || !isNaN(got[1]) {
+		t.Errorf("first and missing rows should be NaN, got %v", got[:2])
+	}
+	if !approxEqual(got[2], 5.0, 1e-9) {
+		t.Errorf("got[2]: expected 5 (vs last valid 10), got %g", got[2])
+	}
+	if !approxEqual(got[3], -0.5, 1e-9) {
+		t.Errorf("got[3]: expected -0.5, got %g", got[3])
+	}
+
+	// Fewer than two valid values leave nothing to difference.
+	if got := transform.DeltaFromPrev(makeObs(2020, 1, math.NaN(), 7.0)); len(got) != 2 || !isNaN(got[0]) || !isNaN(got[1]) {
+		t.Errorf("expected two NaN deltas, got %v", got)
+	}
+}
+
+func TestClipBoundsToRange(t *testing.T) {
+	obs := makeObs(2020, 1, -3.0, 2.0, math.NaN(), 9.0, 5.0)
+	got, err := transform.Clip(obs, 0, 5)
+	if err != nil {
+		t.Fatalf("Clip: %v", err)
+	}
+	want := []float64{0, 2, math.NaN(), 5, 5}
+	for i, w := range want {
+		if isNaN(w) {
+			if !isNaN(got[i].Value) {
+				t.Errorf("got[%d]: expected NaN, got %g", i, got[i].Value)
+			}
+			continue
+		}
+		if got[i].Value != w {
+			t.Errorf("got[%d]: expected %g, got %g", i, w, got[i].Value)
+		}
+	}
+	if got[0].ValueRaw != "0" || got[1].ValueRaw != obs[1].ValueRaw {
+		t.Errorf("ValueRaw: clamped %q, in-band %q", got[0].ValueRaw, got[1].ValueRaw)
+	}
+	if _, err := transform.Clip(obs, 5, 0); err == nil {
+		t.Error("expected error for inverted range")
+	}
+}
+
+func TestObservedRange(t *testing.T) {
+	lo, hi, err := transform.ObservedRange(makeObs(2020, 1, 4.0, math.NaN(), -1.5, 3.0))
+	if err != nil || lo != -1.5 || hi != 4.0 {
+		t.Fatalf("ObservedRange = %g, %g, %v", lo, hi, err)
+	}
+	if _, _, err := transform.ObservedRange(makeObs(2020, 1, math.NaN())); err == nil {
+		t.Error("expected error when no valid values")
+	}
+}
+
 func TestDiffInvalidOrder(t *testing.T) {
 	obs := makeObs(2020, 1, 1.0, 2.0, 3.0)
 	_, err := transform.Diff(obs, 3)
@@ -333,6 +752,56 @@ func TestIndexMissingAnchorDate(t *testing.T) {
 	}
 }
 
+func TestIndexMidMonthAnchor(t *testing.T) {
+	// Monthly observations on the 1st; --at falls mid-January.
+	obs := makeObs(2020, 1, 200.0, 400.0, 100.0)
+	got, err := transform.IndexAnchor(obs, date("2020-01-15"))
+	if err != nil || !got.Equal(date("2020-01-01")) {
+		t.Fatalf("IndexAnchor = %s, %v; want 2020-01-01", got.Format("2006-01-02"), err)
+	}
+	out, err := transform.Index(obs, 100.0, date("2020-01-15"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out[0].Value != 100 || out[1].Value != 200 {
+		t.Errorf("expected January as the anchor, got %+v", out)
+	}
+
+	// A series stamped at month end anchors the same way.
+	var monthEnd []model.Observation
+	for _, o := range obs {
+		monthEnd = append(monthEnd, model.Observation{Date: o.Date.AddDate(0, 1, -1), Value: o.Value})
+	}
+	if got, err := transform.IndexAnchor(monthEnd, date("2020-02-10")); err != nil || !got.Equal(date("2020-02-29")) {
+		t.Errorf("IndexAnchor = %s, %v; want 2020-02-29", got.Format("2006-01-02"), err)
+	}
+}
+
+func TestIndexAnchorByPeriod(t *testing.T) {
+	quarterly := []model.Observation{
+		{Date: date("2020-01-01"), Value: 1}, {Date: date("2020-04-01"), Value: 2}, {Date: date("2020-07-01"), Value: 3},
+	}
+	if got, _ := transform.IndexAnchor(quarterly, date("2020-06-30")); !got.Equal(date("2020-04-01")) {
+		t.Errorf("quarterly anchor = %s, want 2020-04-01", got.Format("2006-01-02"))
+	}
+	annual := []model.Observation{
+		{Date: date("2019-01-01"), Value: 1}, {Date: date("2020-01-01"), Value: 2}, {Date: date("2021-01-01"), Value: 3},
+	}
+	if got, _ := transform.IndexAnchor(annual, date("2020-11-15")); !got.Equal(date("2020-01-01")) {
+		t.Errorf("annual anchor = %s, want 2020-01-01", got.Format("2006-01-02"))
+	}
+	// Weekly data snaps to the nearest observation within a week.
+	weekly := []model.Observation{
+		{Date: date("2020-01-03"), Value: 1}, {Date: date("2020-01-10"), Value: 2}, {Date: date("2020-01-17"), Value: 3},
+	}
+	if got, _ := transform.IndexAnchor(weekly, date("2020-01-12")); !got.Equal(date("2020-01-10")) {
+		t.Errorf("weekly anchor = %s, want 2020-01-10", got.Format("2006-01-02"))
+	}
+	if _, err := transform.IndexAnchor(weekly, date("2020-02-01")); err == nil {
+		t.Error("a date more than a week past the last observation should not anchor")
+	}
+}
+
 func TestIndexZeroAnchorValue(t *testing.T) {
 	obs := makeObs(2020, 1, 0.0, 100.0)
 	_, err := transform.Index(obs, 100.0, date("2020-01-01"))
@@ -360,6 +829,97 @@ func TestIndexNaNPreserved(t *testing.T) {
 	}
 }
 
+func TestNormalizeRobustResistsOutlier(t *testing.T) {
+	// A bulk around 10 with one extreme spike.
+	obs := makeObs(2020, 1, 9, 10, 11, 10, 9, 11, 10, 500)
+	zs, err := transform.Normalize(obs, transform.NormalizeZScore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rb, err := transform.Normalize(obs, transform.NormalizeRobust)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Median 10 and IQR 1.25 (P25 9.75, P75 11): a bulk value of 10 maps to
+	// 0, while z-scoring pulls every bulk value well below zero.
+	if !approxEqual(rb[1].Value, 0, 1e-9) || !approxEqual(rb[2].Value, 0.8, 1e-9) {
+		t.Errorf("robust: expected 0 and 0.8 for 10 and 11, got %g and %g", rb[1].Value, rb[2].Value)
+	}
+	if math.Abs(rb[1].Value) >= math.Abs(zs[1].Value) {
+		t.Errorf("robust center should sit closer to the bulk: robust %g vs zscore %g", rb[1].Value, zs[1].Value)
+	}
+}
+
+func TestNormalizeRobustSymmetricMedianZero(t *testing.T) {
+	obs := makeObs(2020, 1, 1, 2, 3, 4, 5, math.NaN())
+	out, err := transform.Normalize(obs, transform.NormalizeRobust)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !approxEqual(out[2].Value, 0, 1e-9) {
+		t.Errorf("median should map to 0, got %g", out[2].Value)
+	}
+	if !approxEqual(out[0].Value, -out[4].Value, 1e-9) {
+		t.Errorf("symmetric input should stay symmetric: %g vs %g", out[0].Value, out[4].Value)
+	}
+	if !isNaN(out[5].Value) {
+		t.Errorf("NaN should be preserved, got %g", out[5].Value)
+	}
+}
+
+func TestNormalizeRobustZeroIQR(t *testing.T) {
+	obs := makeObs(2020, 1, 5, 5, 5, 5, 5, 100)
+	_, err := transform.Normalize(obs, transform.NormalizeRobust)
+	if err == nil || !strings.Contains(err.Error(), "interquartile range is zero") {
+		t.Fatalf("expected a zero-IQR error, got %v", err)
+	}
+	if _, err := transform.Normalize(makeObs(2020, 1, 5, 5, 5), transform.NormalizeZScore); err == nil || strings.Contains(err.Error(), "interquartile") {
+		t.Errorf("the zero-std error should stay distinct, got %v", err)
+	}
+}
+
+// ─── Rebase ───────────────────────────────────────────────────────────────────
+
+func TestRebaseModes(t *testing.T) {
+	obs := makeObs(2020, 1, math.NaN(), 50.0, 200.0, 25.0, 125.0, math.NaN())
+	cases := []struct {
+		mode transform.RebaseMode
+		want []float64 // for the non-NaN values 50, 200, 25, 125
+	}{
+		{transform.RebaseFirst, []float64{100, 400, 50, 250}},
+		{transform.RebaseLast, []float64{40, 160, 20, 100}},
+		{transform.RebaseMax, []float64{25, 100, 12.5, 62.5}},
+		{transform.RebaseMin, []float64{200, 800, 100, 500}},
+		{transform.RebaseMean, []float64{50, 200, 25, 125}},
+	}
+	for _, tc := range cases {
+		out, err := transform.Rebase(obs, tc.mode)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.mode, err)
+		}
+		if !isNaN(out[0].Value) || !isNaN(out[5].Value) {
+			t.Errorf("%s: NaN should be preserved", tc.mode)
+		}
+		for i, w := range tc.want {
+			if !approxEqual(out[i+1].Value, w, 1e-9) {
+				t.Errorf("%s: out[%d] = %g, want %g", tc.mode, i+1, out[i+1].Value, w)
+			}
+		}
+	}
+}
+
+func TestRebaseErrors(t *testing.T) {
+	if _, err := transform.Rebase(makeObs(2020, 1, math.NaN(), math.NaN()), transform.RebaseMax); err == nil || !strings.Contains(err.Error(), "no non-NaN") {
+		t.Errorf("expected an error for an all-NaN series, got %v", err)
+	}
+	if _, err := transform.Rebase(makeObs(2020, 1, 0, 5), transform.RebaseFirst); err == nil || !strings.Contains(err.Error(), "zero") {
+		t.Errorf("expected an error for a zero reference, got %v", err)
+	}
+	if _, err := transform.Rebase(makeObs(2020, 1, 1, 2), "median"); err == nil || !strings.Contains(err.Error(), "unknown mode") {
+		t.Errorf("expected an error for an unknown mode, got %v", err)
+	}
+}
+
 // ─── Normalize ────────────────────────────────────────────────────────────────
 
 func TestNormalizeZScore(t *testing.T) {
@@ -478,6 +1038,126 @@ func TestResampleMonthlyToAnnualLast(t *testing.T) {
 	}
 }
 
+func TestResampleMonthlyToQuarterlyFirst(t *testing.T) {
+	obs := makeObs(2020, 1, math.NaN(), 2, 3, 4, 5, 6)
+	out, err := transform.Resample(obs, transform.ResampleQuarterly, transform.ResampleFirst)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected 2 outputs, got %d", len(out))
+	}
+	// Q1 opens with NaN, which is skipped like every other aggregation.
+	if out[0].Value != 2 || out[1].Value != 4 {
+		t.Errorf("quarterly first: expected [2 4], got [%g %g]", out[0].Value, out[1].Value)
+	}
+}
+
+func TestResampleFillMissing(t *testing.T) {
+	obs := []model.Observation{
+		{Date: date("2019-03-01"), Value: 1},
+		{Date: date("2021-06-01"), Value: 3},
+		{Date: date("2022-09-01"), Value: 4},
+	}
+
+	gappy, err := transform.Resample(obs, transform.ResampleAnnual, transform.ResampleMean)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(gappy) != 3 {
+		t.Fatalf("without fill-missing the empty year should be omitted, got %d rows", len(gappy))
+	}
+
+	full, err := transform.ResampleWithOptions(obs, transform.ResampleAnnual, transform.ResampleMean,
+		transform.ResampleOptions{FillMissing: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(full) != 4 {
+		t.Fatalf("expected 4 rows with fill-missing, got %d", len(full))
+	}
+	if !full[1].Date.Equal(date("2020-01-01")) || !isNaN(full[1].Value) {
+		t.Errorf("expected NaN row for 2020, got %v = %v", full[1].Date, full[1].Value)
+	}
+	if full[1].ValueRaw != "." {
+		t.Errorf("expected raw %q for the filled row, got %q", ".", full[1].ValueRaw)
+	}
+	if full[2].Value != 3 || full[3].Value != 4 {
+		t.Errorf("observed years changed: %v, %v", full[2].Value, full[3].Value)
+	}
+}
+
+func TestResampleDailyToWeeklyMean(t *testing.T) {
+	// Two business weeks: Mon 2024-01-08..Fri 01-12, then Mon 01-15..Wed 01-17.
+	var obs []model.Observation
+	vals := []float64{1, 2, 3, 4, 5, 10, 20, 30}
+	days := []string{"2024-01-08", "2024-01-09", "2024-01-10", "2024-01-11", "2024-01-12",
+		"2024-01-15", "2024-01-16", "2024-01-17"}
+	for i, d := range days {
+		obs = append(obs, model.Observation{Date: date(d), Value: vals[i]})
+	}
+	out, err := transform.Resample(obs, transform.ResampleWeekly, transform.ResampleMean)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected 2 weeks, got %d", len(out))
+	}
+	if !out[0].Date.Equal(date("2024-01-08")) || !out[1].Date.Equal(date("2024-01-15")) {
+		t.Errorf("weekly dates should be Mondays, got %v and %v", out[0].Date, out[1].Date)
+	}
+	if !approxEqual(out[0].Value, 3, 1e-9) || !approxEqual(out[1].Value, 20, 1e-9) {
+		t.Errorf("weekly means: expected [3 20], got [%g %g]", out[0].Value, out[1].Value)
+	}
+}
+
+func TestResampleWeeklyISOYearBoundary(t *testing.T) {
+	// 2020-12-31 (Thu) and 2021-01-01 (Fri) share ISO week 2020-W53.
+	obs := []model.Observation{
+		{Date: date("2020-12-31"), Value: 1},
+		{Date: date("2021-01-01"), Value: 3},
+	}
+	out, err := transform.Resample(obs, transform.ResampleWeekly, transform.ResampleMean)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 || !out[0].Date.Equal(date("2020-12-28")) || out[0].Value != 2 {
+		t.Errorf("expected one week starting 2020-12-28 with mean 2, got %+v", out)
+	}
+}
+
+func TestResampleDailyPassthrough(t *testing.T) {
+	obs := []model.Observation{
+		{Date: date("2024-01-08"), Value: 1.5},
+		{Date: date("2024-01-09"), Value: math.NaN()},
+		{Date: date("2024-01-11"), Value: 2.5},
+	}
+	out, err := transform.Resample(obs, transform.ResampleDaily, transform.ResampleLast)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != len(obs) {
+		t.Fatalf("expected %d rows, got %d", len(obs), len(out))
+	}
+	for i := range obs {
+		if !out[i].Date.Equal(obs[i].Date) {
+			t.Errorf("[%d] date changed: %v → %v", i, obs[i].Date, out[i].Date)
+		}
+	}
+	if out[0].Value != 1.5 || !isNaN(out[1].Value) || out[2].Value != 2.5 {
+		t.Errorf("values changed: %+v", out)
+	}
+
+	filled, err := transform.ResampleWithOptions(obs, transform.ResampleDaily, transform.ResampleLast,
+		transform.ResampleOptions{FillMissing: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filled) != 4 || !filled[2].Date.Equal(date("2024-01-10")) || !isNaN(filled[2].Value) {
+		t.Errorf("expected a NaN row for 2024-01-10, got %+v", filled)
+	}
+}
+
 func TestResampleMonthlyToAnnualSum(t *testing.T) {
 	obs := makeObs(2020, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1) // 12 months of 1.0
 	out, err := transform.Resample(obs, transform.ResampleAnnual, transform.ResampleSum)
@@ -555,6 +1235,70 @@ func TestResampleUnknownMethod(t *testing.T) {
 	}
 }
 
+func TestUpsampleQuarterlyToMonthly(t *testing.T) {
+	// 2020Q1, Q2 (missing), and Q3, dated at quarter start as on FRED.
+	obs := makeObs(2020, 1, 100)
+	obs = append(obs, makeObs(2020, 4, math.NaN())...)
+	obs = append(obs, makeObs(2020, 7, 106)...)
+	ffill, err := transform.Upsample(obs, transform.ResampleMonthly, transform.UpsampleFFill)
+	if err != nil {
+		t.Fatalf("ffill: %v", err)
+	}
+	// Jan 2020 through Jul 2020: seven months, stopping at the last quarter's start.
+	if len(ffill) != 7 || !ffill[0].Date.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) || ffill[6].Date.Month() != time.July {
+		t.Fatalf("unexpected ffill dates %+v", ffill)
+	}
+	for i, want := range []float64{100, 100, 100, 100, 100, 100, 106} {
+		if ffill[i].Value != want {
+			t.Errorf("ffill[%d] = %v, want %v", i, ffill[i].Value, want)
+		}
+	}
+
+	linear, err := transform.Upsample(obs, transform.ResampleMonthly, transform.UpsampleLinear)
+	if err != nil {
+		t.Fatalf("linear: %v", err)
+	}
+	for i, want := range []float64{100, 101, 102, 103, 104, 105, 106} {
+		if !approxEqual(linear[i].Value, want, 1e-9) {
+			t.Errorf("linear[%d] = %v, want %v", i, linear[i].Value, want)
+		}
+	}
+	if linear[3].ValueRaw != "103" {
+		t.Errorf("value_raw = %q, want 103", linear[3].ValueRaw)
+	}
+}
+
+func TestUpsampleLeadingGapIsNaN(t *testing.T) {
+	annual := []model.Observation{
+		{Date: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), Value: math.NaN()},
+		{Date: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), Value: 8},
+	}
+	out, err := transform.Upsample(annual, transform.ResampleQuarterly, transform.UpsampleLinear)
+	if err != nil {
+		t.Fatalf("Upsample: %v", err)
+	}
+	if len(out) != 5 || !math.IsNaN(out[0].Value) || !math.IsNaN(out[3].Value) || out[4].Value != 8 {
+		t.Errorf("unexpected output %+v", out)
+	}
+	monthly := makeObs(2020, 1, 1, 2)
+	if _, err := transform.Upsample(monthly, transform.ResampleQuarterly, transform.UpsampleFFill); err == nil {
+		t.Error("two months in one quarter should be rejected")
+	}
+}
+
+func TestUpsampleRejectsBadArguments(t *testing.T) {
+	obs := makeObs(2020, 1, 1, 2)
+	if _, err := transform.Upsample(nil, transform.ResampleMonthly, transform.UpsampleFFill); err == nil {
+		t.Error("expected error for empty input")
+	}
+	if _, err := transform.Upsample(obs, "weekly", transform.UpsampleFFill); err == nil {
+		t.Error("expected error for unknown frequency")
+	}
+	if _, err := transform.Upsample(obs, transform.ResampleMonthly, "mean"); err == nil {
+		t.Error("expected error for a downsampling method")
+	}
+}
+
 func TestResampleOutputDatesAreperiodStart(t *testing.T) {
 	// Annual resample of any month → output date should be Jan 1
 	obs := makeObs(2020, 6, 1.0, 2.0, 3.0) // June, July, August
@@ -587,6 +1331,17 @@ func TestFilterAfter(t *testing.T) {
 	}
 }
 
+func TestFilterDateWindow(t *testing.T) {
+	obs := makeObs(2020, 1, -1, math.NaN(), 3, 4, 5) // Jan–May 2020
+	out := transform.Filter(obs, transform.DateWindow(date("2020-01-01"), date("2020-04-01")))
+	if len(out) != 4 || out[3].Date.Month() != time.April {
+		t.Fatalf("expected Jan–Apr inclusive with every value kept, got %+v", out)
+	}
+	if out := transform.Filter(obs, transform.DateWindow(date("2020-04-01"), time.Time{})); len(out) != 2 {
+		t.Errorf("expected an open upper bound to keep Apr–May, got %d observations", len(out))
+	}
+}
+
 func TestFilterBefore(t *testing.T) {
 	obs := makeObs(2020, 1, 1, 2, 3, 4, 5) // Jan–May 2020
 	out := transform.Filter(obs, transform.FilterOptions{
@@ -762,6 +1517,32 @@ func TestRollStd(t *testing.T) {
 	}
 }
 
+func TestRollStdDDOF(t *testing.T) {
+	obs := makeObs(2020, 1, 1.0, 2.0, 3.0)
+	sample, err := transform.RollDDOF(obs, 3, 1, transform.RollStd, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	population, err := transform.RollDDOF(obs, 3, 1, transform.RollStd, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// [1,2,3]: squared deviations sum to 2, so sqrt(2/2) vs sqrt(2/3).
+	if !approxEqual(sample[2].Value, 1.0, 1e-9) {
+		t.Errorf("ddof=1: expected 1.0, got %g", sample[2].Value)
+	}
+	if !approxEqual(population[2].Value, math.Sqrt(2.0/3.0), 1e-9) {
+		t.Errorf("ddof=0: expected %g, got %g", math.Sqrt(2.0/3.0), population[2].Value)
+	}
+	// The single-value first window is 0 under both.
+	if sample[0].Value != 0 || population[0].Value != 0 {
+		t.Errorf("single-value window: expected 0 and 0, got %g and %g", sample[0].Value, population[0].Value)
+	}
+	if _, err := transform.RollDDOF(obs, 3, 1, transform.RollStd, 2); err == nil {
+		t.Error("expected an error for ddof=2")
+	}
+}
+
 func TestRollMin(t *testing.T) {
 	obs := makeObs(2020, 1, 5.0, 3.0, 8.0, 1.0, 4.0)
 	out, err := transform.Roll(obs, 3, 1, transform.RollMin)
@@ -852,7 +1633,90 @@ func TestRollUnknownStat(t *testing.T) {
 	obs := makeObs(2020, 1, 1.0, 2.0, 3.0)
 	_, err := transform.Roll(obs, 2, 1, "bogus")
 	if err == nil {
-		t.Error("expected error for unknown roll stat")
+		t.Fatal("expected error for unknown roll stat")
+	}
+	if !strings.Contains(err.Error(), "mean, median, std, var, min, max, sum") {
+		t.Errorf("error should list the valid stats, got %v", err)
+	}
+	// Rejected up front, even when no window reaches min-periods.
+	if _, err := transform.Roll(obs, 3, 3, "bogus"); err == nil {
+		t.Error("expected error for unknown roll stat with short windows")
+	}
+}
+
+func TestRollEWMMeanAlphaOne(t *testing.T) {
+	obs := makeObs(2020, 1, 1.0, 5.0, 2.0, 8.0)
+	out, err := transform.RollWithOptions(obs, 3, 1, transform.RollEWMMean, transform.RollOptions{Alpha: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// alpha=1 forgets everything but the current value.
+	for i, o := range obs {
+		if !approxEqual(out[i].Value, o.Value, 1e-12) {
+			t.Errorf("out[%d]: expected %g, got %g", i, o.Value, out[i].Value)
+		}
+	}
+}
+
+func TestRollEWMMeanSmallAlphaIsMean(t *testing.T) {
+	obs := makeObs(2020, 1, 1.0, 5.0, 2.0, 8.0)
+	ewm, err := transform.RollWithOptions(obs, 3, 1, transform.RollEWMMean, transform.RollOptions{Alpha: 1e-9})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	plain, _ := transform.Roll(obs, 3, 1, transform.RollMean)
+	for i := range obs {
+		if !approxEqual(ewm[i].Value, plain[i].Value, 1e-6) {
+			t.Errorf("out[%d]: expected ≈ mean %g, got %g", i, plain[i].Value, ewm[i].Value)
+		}
+	}
+}
+
+func TestRollEWMMeanWeightsAndNaN(t *testing.T) {
+	obs := makeObs(2020, 1, 4.0, math.NaN(), 2.0)
+	out, err := transform.RollWithOptions(obs, 3, 1, transform.RollEWMMean, transform.RollOptions{Alpha: 0.5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// NaN skipped: weights 1 on 2.0 and 0.5 on 4.0 → (2 + 2) / 1.5.
+	if want := 4.0 / 1.5; !approxEqual(out[2].Value, want, 1e-12) {
+		t.Errorf("out[2]: expected %g, got %g", want, out[2].Value)
+	}
+	if _, err := transform.Roll(obs, 3, 1, transform.RollEWMMean); err == nil {
+		t.Error("expected error for ewm-mean without alpha")
+	}
+}
+
+func TestRollMedian(t *testing.T) {
+	obs := makeObs(2020, 1, 5.0, 1.0, 100.0, 2.0, math.NaN(), 4.0)
+	out, err := transform.Roll(obs, 4, 1, transform.RollMedian)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Windows: [5] [5,1] [5,1,100] [5,1,100,2] [1,100,2] [100,2,4]
+	want := []float64{5, 3, 5, 3.5, 2, 4}
+	for i, w := range want {
+		if !approxEqual(out[i].Value, w, 1e-9) {
+			t.Errorf("out[%d]: expected median %g, got %g", i, w, out[i].Value)
+		}
+	}
+}
+
+func TestRollVar(t *testing.T) {
+	obs := makeObs(2020, 1, 1.0, 2.0, 3.0)
+	sample, err := transform.Roll(obs, 3, 3, transform.RollVar)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !approxEqual(sample[2].Value, 1.0, 1e-9) {
+		t.Errorf("sample var of [1,2,3]: expected 1, got %g", sample[2].Value)
+	}
+	population, err := transform.RollDDOF(obs, 3, 3, transform.RollVar, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !approxEqual(population[2].Value, 2.0/3.0, 1e-9) {
+		t.Errorf("population var of [1,2,3]: expected %g, got %g", 2.0/3.0, population[2].Value)
 	}
 }
 
@@ -882,7 +1746,7 @@ func TestRollPreservesDates(t *testing.T) {
 func TestPctChangeThenRoll(t *testing.T) {
 	// Realistic pipeline: monthly data → pct-change → 3-month rolling mean
 	obs := makeObs(2020, 1, 100, 102, 101, 104, 103, 106, 105)
-	pct, err := transform.PctChange(obs, 1)
+	pct, _, err := transform.PctChange(obs, 1, false, transform.PctChangeStandard)
 	if err != nil {
 		t.Fatalf("PctChange: %v", err)
 	}
@@ -927,3 +1791,38 @@ func TestResampleThenDiff(t *testing.T) {
 		}
 	}
 }
+
+func TestFilterInclusiveBounds(t *testing.T) {
+	obs := makeObs(2020, 1, 1, 2, 3, 4, 5) // Jan–May 2020
+	cases := []struct {
+		name       string
+		opts       transform.FilterOptions
+		first, n   int
+		boundaryIn bool
+	}{
+		{"after exclusive", transform.FilterOptions{After: date("2020-03-01")}, 4, 2, false},
+		{"after inclusive", transform.FilterOptions{After: date("2020-03-01"), AfterInclusive: true}, 3, 3, true},
+		{"before exclusive", transform.FilterOptions{Before: date("2020-03-01")}, 1, 2, false},
+		{"before inclusive", transform.FilterOptions{Before: date("2020-03-01"), BeforeInclusive: true}, 1, 3, true},
+		{"both inclusive", transform.FilterOptions{After: date("2020-02-01"), Before: date("2020-04-01"), AfterInclusive: true, BeforeInclusive: true}, 2, 3, false},
+		{"inclusive between observations", transform.FilterOptions{After: date("2020-02-15"), AfterInclusive: true}, 3, 3, false},
+	}
+	for _, tc := range cases {
+		tc.opts.MinValue, tc.opts.MaxValue = math.NaN(), math.NaN()
+		out := transform.Filter(obs, tc.opts)
+		if len(out) != tc.n {
+			t.Errorf("%s: expected %d observations, got %d", tc.name, tc.n, len(out))
+			continue
+		}
+		if int(out[0].Date.Month()) != tc.first {
+			t.Errorf("%s: first observation should be month %d, got %v", tc.name, tc.first, out[0].Date.Month())
+		}
+		kept := false
+		for _, o := range out {
+			kept = kept || o.Date.Equal(date("2020-03-01"))
+		}
+		if tc.boundaryIn && !kept {
+			t.Errorf("%s: the 2020-03-01 boundary observation should be kept", tc.name)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package watch
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// Mailer sends plain-text notification email through an SMTP server.
+type Mailer struct {
+	Addr     string // host:port
+	From     string
+	To       []string
+	Username string // empty for servers that accept unauthenticated mail
+	Password string
+}
+
+// Send delivers one message. With a username set it authenticates with SMTP
+// PLAIN, which net/smtp only allows over TLS or to localhost.
+func (m Mailer) Send(subject, body string) error {
+	host, _, err := net.SplitHostPort(m.Addr)
+	if err != nil {
+		return fmt.Errorf("email: smtp_addr %q: %w", m.Addr, err)
+	}
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		m.From, strings.Join(m.To, ", "), subject, strings.ReplaceAll(body, "\n", "\r\n"))
+	if err := smtp.SendMail(m.Addr, auth, m.From, m.To, []byte(msg)); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
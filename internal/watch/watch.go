@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+// Package watch polls FRED for newly published observations of a series.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+// WatchOptions controls a Watch loop.
+type WatchOptions struct {
+	// Interval is the time between polls. It must be positive.
+	Interval time.Duration
+	// Since limits notifications to observations dated after it. Zero means
+	// no limit.
+	Since time.Time
+	// Last is the latest observation date already known, such as the newest
+	// cached observation. When both Last and Since are zero, the first poll
+	// only records the current latest date.
+	Last time.Time
+	// OnError, when set, receives the error of each failed poll that Watch
+	// rides out.
+	OnError func(error)
+}
+
+// Watch polls the latest observation of seriesID every opts.Interval and
+// calls notify once for each poll that finds a date later than any seen
+// before. A failed poll is passed to opts.OnError and retried on the next
+// tick. Watch returns nil when ctx is cancelled, and the error when FRED
+// rejects the request outright (see retryable).
+func Watch(ctx context.Context, client *fred.Client, seriesID string, opts WatchOptions, notify func(model.Observation)) error {
+	p := poller{interval: opts.Interval, changed: newDate, onError: opts.OnError}
+	if !opts.Last.IsZero() {
+		p.prev = &model.Observation{Date: opts.Last}
+	}
+	baseline := opts.Last.IsZero() && opts.Since.IsZero()
+	p.notify = func(o model.Observation, _ *model.Observation) {
+		switch {
+		case baseline:
+			baseline = false
+		case o.Date.After(opts.Since):
+			notify(o)
+		}
+	}
+	return p.run(ctx, client, seriesID)
+}
+
+// Changes polls the latest observation of seriesID every interval and calls
+// notify with the first observation it sees and again whenever the latest
+// date or value differs from the previous poll, which also catches a revision
+// of the current observation. prev is nil on the first call. Failed polls
+// are handled as in Watch, with onError (when set) in place of
+// WatchOptions.OnError.
+func Changes(ctx context.Context, client *fred.Client, seriesID string, interval time.Duration, notify func(o model.Observation, prev *model.Observation), onError func(error)) error {
+	p := poller{interval: interval, changed: dateOrValueChanged, notify: notify, onError: onError}
+	return p.run(ctx, client, seriesID)
+}
+
+// newDate reports a change when o is dated after prev.
+func newDate(o model.Observation, prev *model.Observation) bool {
+	return prev == nil || o.Date.After(prev.Date)
+}
+
+// dateOrValueChanged reports a change when o has a different date or value
+// from prev.
+func dateOrValueChanged(o model.Observation, prev *model.Observation) bool {
+	return prev == nil || !o.Date.Equal(prev.Date) || o.ValueRaw != prev.ValueRaw
+}
+
+// poller is the polling loop shared by Watch and Changes. Each poll's latest
+// observation is passed to notify when changed reports that it differs from
+// prev, the last observation notify received, which it then replaces.
+type poller struct {
+	interval time.Duration
+	prev     *model.Observation
+	changed  func(o model.Observation, prev *model.Observation) bool
+	notify   func(o model.Observation, prev *model.Observation)
+	onError  func(error)
+}
+
+func (p poller) run(ctx context.Context, client *fred.Client, seriesID string) error {
+	if p.interval <= 0 {
+		return fmt.Errorf("watch: interval must be > 0, got %s", p.interval)
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		obs, err := client.GetLatestObservation(ctx, seriesID)
+		switch {
+		case err == nil:
+			if p.changed(*obs, p.prev) {
+				p.notify(*obs, p.prev)
+				p.prev = obs
+			}
+		case ctx.Err() != nil:
+			return nil
+		case !retryable(err):
+			return err
+		case p.onError != nil:
+			p.onError(err)
+		}
+		if !wait(ctx, ticker) {
+			return nil
+		}
+	}
+}
+
+// retryable reports whether a failed poll may succeed on a later tick: any
+// error except a request FRED rejected, such as an unknown series or a bad
+// API key.
+func retryable(err error) bool {
+	var apiErr *fred.APIError
+	return !errors.As(err, &apiErr)
+}
+
+// wait blocks until the next tick and reports false if ctx ends first.
+func wait(ctx context.Context, ticker *time.Ticker) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-ticker.C:
+		return true
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Derick Schaefer
+// Licensed under the MIT License. See LICENSE file for details.
+
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/model"
+)
+
+// latestServer serves 2026-08-01 as the latest observation on the first call
+// and 2026-09-01 from then on. It cancels the watch after stopAfter calls.
+func latestServer(t *testing.T, stopAfter int32, cancel context.CancelFunc) (*fred.Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		date, value := "2026-08-01", "4.33"
+		if n > 1 {
+			date, value = "2026-09-01", "4.08"
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"observations": []map[string]string{{"date": date, "value": value}},
+		})
+		if n >= stopAfter {
+			cancel()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return fred.NewClientWithOptions(fred.ClientOptions{
+		APIKey:     "test_key",
+		BaseURL:    srv.URL + "/",
+		Timeout:    5 * time.Second,
+		RatePerSec: 1000,
+	}), &calls
+}
+
+func TestWatchNotifiesOnceForNewObservation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, calls := latestServer(t, 4, cancel)
+
+	var got []model.Observation
+	err := Watch(ctx, client, "FEDFUNDS", WatchOptions{Interval: time.Millisecond}, func(o model.Observation) {
+		got = append(got, o)
+	})
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if calls.Load() < 3 {
+		t.Fatalf("expected several polls, got %d", calls.Load())
+	}
+	if len(got) != 1 {
+		t.Fatalf("notify fired %d times, want 1: %v", len(got), got)
+	}
+	if d := got[0].Date.Format("2006-01-02"); d != "2026-09-01" || got[0].Value != 4.08 {
+		t.Errorf("notified %s = %g, want 2026-09-01 = 4.08", d, got[0].Value)
+	}
+}
+
+func TestWatchSinceReportsCurrentLatest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, _ := latestServer(t, 3, cancel)
+
+	var dates []string
+	opts := WatchOptions{Interval: time.Millisecond, Since: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)}
+	if err := Watch(ctx, client, "FEDFUNDS", opts, func(o model.Observation) {
+		dates = append(dates, o.Date.Format("2006-01-02"))
+	}); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if len(dates) != 2 || dates[0] != "2026-08-01" || dates[1] != "2026-09-01" {
+		t.Errorf("notified %v, want [2026-08-01 2026-09-01]", dates)
+	}
+}
+
+func TestWatchLastSkipsKnownObservation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, _ := latestServer(t, 3, cancel)
+
+	var dates []string
+	opts := WatchOptions{Interval: time.Millisecond, Last: time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)}
+	if err := Watch(ctx, client, "FEDFUNDS", opts, func(o model.Observation) {
+		dates = append(dates, o.Date.Format("2006-01-02"))
+	}); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if len(dates) != 1 || dates[0] != "2026-09-01" {
+		t.Errorf("notified %v, want [2026-09-01]", dates)
+	}
+}
+
+func TestWatchRejectsNonPositiveInterval(t *testing.T) {
+	if err := Watch(context.Background(), nil, "FEDFUNDS", WatchOptions{}, func(model.Observation) {}); err == nil {
+		t.Fatal("expected an interval error")
+	}
+}
+
+func TestChangesReportsFirstAndChangedValues(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, calls := latestServer(t, 4, cancel)
+
+	type change struct{ date, prev string }
+	var got []change
+	err := Changes(ctx, client, "FEDFUNDS", time.Millisecond, func(o model.Observation, prev *model.Observation) {
+		c := change{date: o.Date.Format("2006-01-02")}
+		if prev != nil {
+			c.prev = prev.Date.Format("2006-01-02")
+		}
+		got = append(got, c)
+	}, nil)
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if calls.Load() < 3 {
+		t.Fatalf("expected several polls, got %d", calls.Load())
+	}
+	want := []change{{"2026-08-01", ""}, {"2026-09-01", "2026-08-01"}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("notified %v, want %v", got, want)
+	}
+}
+
+// flakyServer answers the first poll with an unparseable body and later polls
+// like latestServer does from its second call on. It cancels after stopAfter
+// calls.
+func flakyServer(t *testing.T, stopAfter int32, cancel context.CancelFunc) *fred.Client {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		if n >= stopAfter {
+			defer cancel()
+		}
+		if n == 1 {
+			w.Write([]byte("not json"))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"observations": []map[string]string{{"date": "2026-09-01", "value": "4.08"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return fred.NewClientWithOptions(fred.ClientOptions{
+		APIKey:     "test_key",
+		BaseURL:    srv.URL + "/",
+		Timeout:    5 * time.Second,
+		RatePerSec: 1000,
+	})
+}
+
+func TestWatchKeepsPollingAfterFailedPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := flakyServer(t, 3, cancel)
+
+	var errs int
+	var got []model.Observation
+	opts := WatchOptions{
+		Interval: time.Millisecond,
+		Since:    time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC),
+		OnError:  func(error) { errs++ },
+	}
+	if err := Watch(ctx, client, "FEDFUNDS", opts, func(o model.Observation) { got = append(got, o) }); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	if errs != 1 || len(got) != 1 {
+		t.Errorf("got %d errors and %d notifications, want 1 and 1", errs, len(got))
+	}
+}
+
+func TestWatchStopsOnRejectedRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_message":"Bad Request. The series does not exist."}`))
+	}))
+	defer srv.Close()
+	client := fred.NewClientWithOptions(fred.ClientOptions{
+		APIKey: "test_key", BaseURL: srv.URL + "/", Timeout: 5 * time.Second, RatePerSec: 1000,
+	})
+
+	err := Watch(context.Background(), client, "NOPE", WatchOptions{Interval: time.Millisecond}, func(model.Observation) {})
+	var apiErr *fred.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected a 400 APIError, got %v", err)
+	}
+}
+
+func TestChangesKeepsPollingAfterFailedPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := flakyServer(t, 3, cancel)
+
+	var errs []error
+	var got []string
+	err := Changes(ctx, client, "FEDFUNDS", time.Millisecond, func(o model.Observation, prev *model.Observation) {
+		got = append(got, o.Date.Format("2006-01-02"))
+	}, func(err error) { errs = append(errs, err) })
+	if err != nil {
+		t.Fatalf("Changes: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected the first poll's error to be reported once, got %v", errs)
+	}
+	if len(got) != 1 || got[0] != "2026-09-01" {
+		t.Errorf("notified %v, want [2026-09-01] from the poll after the failure", got)
+	}
+}
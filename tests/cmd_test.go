@@ -47,7 +47,7 @@ func TestCommandSurface(t *testing.T) {
 	rootHelp := runReserveHelp(t, "--help")
 	for _, cmdName := range []string{
 		"alias", "analyze", "cache", "category", "chart", "completion", "config",
-		"fetch", "meta", "obs", "onboard", "release", "search",
+		"fetch", "meta", "obs", "onboard", "pipeline", "release", "search",
 		"series", "source", "tag", "transform", "version", "window",
 	} {
 		r.check(t, strings.Contains(rootHelp, "\n  "+cmdName),
@@ -9,7 +9,9 @@
 //   1. FRED API Connectivity  — live HTTP reachability and JSON payload shape
 //   2. Payload Integrity      — observation parsing, NaN handling, value
 //                               formatting, config precedence (all offline)
-//   3. API Client Behaviour   — mock HTTP server: retries, params, search
+//   3. API Client Behaviour   — mock HTTP server: retries, params, search,
+//                               connection pooling, circuit breaker,
+//                               conditional GET
 //
 // TEST RUNNER:
 //   go test -v -run TestFredAPIConnectivity  ./tests/
@@ -32,18 +34,23 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/derickschaefer/reserve/internal/config"
 	"github.com/derickschaefer/reserve/internal/fred"
+	"github.com/derickschaefer/reserve/internal/model"
 	"github.com/derickschaefer/reserve/internal/util"
 	"golang.org/x/time/rate"
 )
@@ -637,6 +644,199 @@ func TestAPIClientBehaviour(t *testing.T) {
 		fmt.Sprintf("SearchSeries: err=%v, results=%d, search_text=%q", searchErr, len(results), gotSearchText),
 	)
 
+	// ── Check 12: Realtime (vintage) params forwarded and parsed ──────────────
+	var gotRTStart, gotRTEnd string
+	vintageClient := newClient(map[string]http.HandlerFunc{
+		"/series/observations": func(w http.ResponseWriter, r *http.Request) {
+			gotRTStart = r.URL.Query().Get("realtime_start")
+			gotRTEnd = r.URL.Query().Get("realtime_end")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"observations": []map[string]string{
+					{"date": "2019-12-01", "value": "3.5", "realtime_start": "2020-01-10", "realtime_end": "2020-02-06"},
+				},
+			})
+		},
+	})
+
+	vintage, vintageErr := vintageClient.GetObservations(context.Background(), "UNRATE", fred.ObsOptions{
+		RealtimeStart: "2020-01-01", RealtimeEnd: "2020-12-31",
+	})
+	r.check(t, gotRTStart == "2020-01-01" && gotRTEnd == "2020-12-31",
+		fmt.Sprintf("GetObservations: realtime params forwarded (start=%q end=%q)", gotRTStart, gotRTEnd),
+		fmt.Sprintf("GetObservations: realtime params wrong: start=%q end=%q", gotRTStart, gotRTEnd),
+	)
+	r.check(t,
+		vintageErr == nil && len(vintage.Obs) == 1 &&
+			vintage.Obs[0].RealtimeStart == "2020-01-10" && vintage.Obs[0].RealtimeEnd == "2020-02-06",
+		"GetObservations: vintage response parsed with its realtime window",
+		fmt.Sprintf("GetObservations: vintage parse wrong: err=%v data=%+v", vintageErr, vintage),
+	)
+
+	// ── Check 13: Revisions query all vintages and collapse repeats ───────────
+	var revQuery url.Values
+	revClient := newClient(map[string]http.HandlerFunc{
+		"/series/observations": func(w http.ResponseWriter, r *http.Request) {
+			revQuery = r.URL.Query()
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"observations": []map[string]string{
+					{"date": "2020-04-01", "value": "14.8", "realtime_start": "2020-06-05", "realtime_end": "2021-01-07"},
+					{"date": "2020-04-01", "value": "14.7", "realtime_start": "2020-05-08", "realtime_end": "2020-06-04"},
+					{"date": "2020-04-01", "value": "14.8", "realtime_start": "2021-01-08", "realtime_end": "9999-12-31"},
+				},
+			})
+		},
+	})
+
+	revs, revErr := revClient.GetObservationRevisions(context.Background(), "UNRATE", "2020-04-01")
+	r.check(t,
+		revQuery.Get("realtime_start") == "1776-07-04" && revQuery.Get("realtime_end") == "9999-12-31" &&
+			revQuery.Get("observation_start") == "2020-04-01" && revQuery.Get("observation_end") == "2020-04-01",
+		"GetObservationRevisions: requests every vintage of the one date",
+		fmt.Sprintf("GetObservationRevisions: query wrong: %v", revQuery),
+	)
+	r.check(t,
+		revErr == nil && len(revs) == 2 &&
+			revs[0].VintageDate == "2020-05-08" && revs[0].ValueRaw == "14.7" &&
+			revs[1].VintageDate == "2020-06-05" && revs[1].Value == 14.8,
+		"GetObservationRevisions: vintages sorted oldest first, repeats collapsed",
+		fmt.Sprintf("GetObservationRevisions: err=%v rows=%+v", revErr, revs),
+	)
+
+	// ── Check 14: Concurrent requests reuse pooled connections ───────────────
+	var dials atomic.Int32
+	poolSrv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond) // keep workers' requests overlapping
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"seriess": []map[string]interface{}{{"id": r.URL.Query().Get("series_id")}},
+		})
+	}))
+	poolSrv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			dials.Add(1)
+		}
+	}
+	poolSrv.Start()
+	defer poolSrv.Close()
+
+	const poolWorkers, poolRequests = 4, 20
+	poolClient := fred.NewClientWithOptions(fred.ClientOptions{
+		APIKey:       "test_key",
+		BaseURL:      poolSrv.URL + "/",
+		Timeout:      5 * time.Second,
+		RatePerSec:   1000,
+		MaxIdleConns: poolWorkers,
+	})
+	jobs := make(chan int)
+	var poolWG sync.WaitGroup
+	var poolErrs atomic.Int32
+	for i := 0; i < poolWorkers; i++ {
+		poolWG.Add(1)
+		go func() {
+			defer poolWG.Done()
+			for n := range jobs {
+				if _, err := poolClient.GetSeries(context.Background(), fmt.Sprintf("S%d", n)); err != nil {
+					poolErrs.Add(1)
+				}
+			}
+		}()
+	}
+	for n := 0; n < poolRequests; n++ {
+		jobs <- n
+	}
+	close(jobs)
+	poolWG.Wait()
+	r.check(t,
+		poolErrs.Load() == 0 && dials.Load() <= poolWorkers,
+		fmt.Sprintf("Connection pool: %d requests over %d workers opened %d connections", poolRequests, poolWorkers, dials.Load()),
+		fmt.Sprintf("Connection pool: %d requests opened %d connections (want <= %d), %d errors",
+			poolRequests, dials.Load(), poolWorkers, poolErrs.Load()),
+	)
+
+	// ── Check 15: Circuit breaker fails fast once FRED keeps failing ─────────
+	var breakerHits atomic.Int32
+	breakerClient := fred.NewClientWithOptions(fred.ClientOptions{
+		APIKey:                "test_key",
+		BaseURL:               "https://mock.fred.local/",
+		Timeout:               5 * time.Second,
+		RatePerSec:            1000,
+		CircuitBreakThreshold: 2,
+		CircuitBreakTimeout:   time.Minute,
+	})
+	breakerClient.SetHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			breakerHits.Add(1)
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusServiceUnavailable)
+			return rec.Result(), nil
+		}),
+	})
+
+	_, firstErr := breakerClient.GetSeries(context.Background(), "GDP")
+	hitsAfterOpen := breakerHits.Load()
+	_, fastErr := breakerClient.GetSeries(context.Background(), "UNRATE")
+	r.check(t,
+		errors.Is(firstErr, fred.ErrCircuitOpen) && hitsAfterOpen == 2,
+		"Circuit breaker: opens after 2 consecutive 503s and stops retrying",
+		fmt.Sprintf("Circuit breaker: err=%v after %d requests (want open after 2)", firstErr, hitsAfterOpen),
+	)
+	r.check(t,
+		errors.Is(fastErr, fred.ErrCircuitOpen) && breakerHits.Load() == hitsAfterOpen,
+		"Circuit breaker: later requests fail fast without touching the network",
+		fmt.Sprintf("Circuit breaker: err=%v, requests %d → %d", fastErr, hitsAfterOpen, breakerHits.Load()),
+	)
+
+	// ── Check 16: Conditional GET revalidates cached observations ────────────
+	const lastModified = "Fri, 03 May 2024 12:00:00 GMT"
+	var condHeaders []http.Header
+	sendValidators := true
+	condClient := newClient(map[string]http.HandlerFunc{
+		"/series/observations": func(w http.ResponseWriter, r *http.Request) {
+			condHeaders = append(condHeaders, r.Header.Clone())
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				fmt.Fprint(w, "not json") // must never be decoded
+				return
+			}
+			if sendValidators {
+				w.Header().Set("ETag", `"v1"`)
+				w.Header().Set("Last-Modified", lastModified)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"observations": []map[string]string{{"date": "2024-04-01", "value": "3.9"}},
+			})
+		},
+	})
+
+	fresh, freshErr := condClient.GetObservations(context.Background(), "UNRATE", fred.ObsOptions{})
+	r.check(t,
+		freshErr == nil && fresh != nil && fresh.Response != nil &&
+			fresh.Response.ETag == `"v1"` && fresh.Response.LastModified == lastModified,
+		"Conditional GET: 200 response records ETag and Last-Modified with the data",
+		fmt.Sprintf("Conditional GET: err=%v data=%+v", freshErr, fresh),
+	)
+	var prev model.ResponseMeta
+	if fresh != nil && fresh.Response != nil {
+		prev = *fresh.Response
+	}
+	same, notModified, sameErr := condClient.GetObservationsIfModified(context.Background(), "UNRATE", fred.ObsOptions{}, prev)
+	lastHeaders := condHeaders[len(condHeaders)-1]
+	r.check(t,
+		sameErr == nil && notModified && same == nil &&
+			lastHeaders.Get("If-None-Match") == `"v1"` && lastHeaders.Get("If-Modified-Since") == lastModified,
+		"Conditional GET: validators sent; 304 reports not-modified without decoding a body",
+		fmt.Sprintf("Conditional GET: err=%v notModified=%v data=%+v headers=%v", sameErr, notModified, same, lastHeaders),
+	)
+
+	sendValidators = false
+	bare, bareNotModified, bareErr := condClient.GetObservationsIfModified(context.Background(), "UNRATE", fred.ObsOptions{}, model.ResponseMeta{})
+	lastHeaders = condHeaders[len(condHeaders)-1]
+	r.check(t,
+		bareErr == nil && !bareNotModified && bare != nil && len(bare.Obs) == 1 && bare.Response == nil &&
+			lastHeaders.Get("If-None-Match") == "" && lastHeaders.Get("If-Modified-Since") == "",
+		"Conditional GET: without validators the request is plain and the data is returned",
+		fmt.Sprintf("Conditional GET: err=%v data=%+v headers=%v", bareErr, bare, lastHeaders),
+	)
+
 	r.summary(t, "API CLIENT BEHAVIOUR")
 }
 